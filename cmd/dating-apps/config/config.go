@@ -11,15 +11,32 @@ import (
 
 // Config struct to hold the configuration data for server
 type Config struct {
-	Port           string   `yaml:"port"`
-	Postgres       Postgres `yaml:"postgres"`
-	Redis          Redis    `yaml:"redis"`
-	Hash           Hash     `yaml:"hash"`
-	Token          Token    `yaml:"token"`
-	UserHandler    Handler  `yaml:"user_handler"`
-	AuthHandler    Handler  `yaml:"auth_handler"`
-	PartnerHandler Handler  `yaml:"partner_handler"`
-	MaxCounter     int      `yaml:"max_find_counter"`
+	Port                string            `yaml:"port"`
+	Postgres            Postgres          `yaml:"postgres"`
+	Redis               Redis             `yaml:"redis"`
+	Hash                Hash              `yaml:"hash"`
+	Token               Token             `yaml:"token"`
+	UserHandler         Handler           `yaml:"user_handler"`
+	AuthHandler         Handler           `yaml:"auth_handler"`
+	PartnerHandler      Handler           `yaml:"partner_handler"`
+	ModerationHandler   Handler           `yaml:"moderation_handler"`
+	ChatHandler         Handler           `yaml:"chat_handler"`
+	RealtimeHandler     RealtimeHandler   `yaml:"realtime_handler"`
+	SubscriptionHandler Handler           `yaml:"subscription_handler"`
+	PaymentHandler      Handler           `yaml:"payment_handler"`
+	VerificationHandler Handler           `yaml:"verification_handler"`
+	MaxCounter          int               `yaml:"max_find_counter"`
+	PassCooldownInHour  int               `yaml:"pass_cooldown_in_hour"`
+	SuperLike           SuperLike         `yaml:"super_like"`
+	Mailer              Mailer            `yaml:"mailer"`
+	EmailVerification   EmailVerification `yaml:"email_verification"`
+	PasswordReset       PasswordReset     `yaml:"password_reset"`
+	LoginProtection     LoginProtection   `yaml:"login_protection"`
+	TwoFactor           TwoFactor         `yaml:"two_factor"`
+	Session             Session           `yaml:"session"`
+	OAuth               OAuth             `yaml:"oauth"`
+	Subscription        Subscription      `yaml:"subscription"`
+	Payment             Payment           `yaml:"payment"`
 }
 
 // Postgres struct to hold the configuration data for postgres
@@ -41,8 +58,132 @@ type Hash struct {
 
 // Token struct to hold the configuration data for Token Package
 type Token struct {
-	Secret    string `yaml:"secret"`
-	ExpInHour int64  `yaml:"exp_in_hour"`
+	Secret           string `yaml:"secret"`
+	ExpInHour        int64  `yaml:"exp_in_hour"`
+	RefreshExpInHour int64  `yaml:"refresh_exp_in_hour"`
+	// ActiveKeyID is kid of the signing key stored in vault to sign new token
+	ActiveKeyID string `yaml:"active_key_id"`
+	// Issuer and Audience is validated on every token, empty value is skipped
+	Issuer   string `yaml:"issuer"`
+	Audience string `yaml:"audience"`
+}
+
+// SuperLike struct to hold the configuration data for daily super like allowance
+type SuperLike struct {
+	DailyLimit        int `yaml:"daily_limit"`
+	PremiumDailyLimit int `yaml:"premium_daily_limit"`
+}
+
+// Mailer struct to hold the configuration data for Mailer Package
+type Mailer struct {
+	// Type is smtp or file, the file mailer is used for local development
+	Type     string `yaml:"type"`
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+	// FilePath is the file to write the email for file mailer, empty means the email is written to the log
+	FilePath string `yaml:"file_path"`
+}
+
+// EmailVerification struct to hold the configuration data for email verification
+type EmailVerification struct {
+	TokenExpInHour int64 `yaml:"token_exp_in_hour"`
+	// URL is the verify email link sent to the user, the token is appended as query parameter
+	URL string `yaml:"url"`
+}
+
+// PasswordReset struct to hold the configuration data for password reset code
+type PasswordReset struct {
+	CodeExpInMinute int64 `yaml:"code_exp_in_minute"`
+	// MaxAttempts is number of wrong code allowed before the code is invalidated
+	MaxAttempts int `yaml:"max_attempts"`
+	// MaxUsernameRequests and MaxIPRequests is number of reset request allowed in the login attempt window
+	MaxUsernameRequests int `yaml:"max_username_requests"`
+	MaxIPRequests       int `yaml:"max_ip_requests"`
+}
+
+// LoginProtection struct to hold the configuration data for login brute force protection
+type LoginProtection struct {
+	// MaxUsernameAttempts and MaxIPAttempts is number of failed login before the username or client ip is locked
+	MaxUsernameAttempts int `yaml:"max_username_attempts"`
+	MaxIPAttempts       int `yaml:"max_ip_attempts"`
+	// AttemptWindowInMinute is how long the failed login is remembered since the last failure
+	AttemptWindowInMinute int64 `yaml:"attempt_window_in_minute"`
+	// BaseLockoutInSec is doubled for every failed login after the threshold until MaxLockoutInMinute
+	BaseLockoutInSec   int64 `yaml:"base_lockout_in_sec"`
+	MaxLockoutInMinute int64 `yaml:"max_lockout_in_minute"`
+}
+
+// TwoFactor struct to hold the configuration data for two factor authentication
+type TwoFactor struct {
+	// Issuer is the account name shown in the authenticator app
+	Issuer string `yaml:"issuer"`
+}
+
+// Session struct to hold the configuration data for device session
+type Session struct {
+	// CacheExpInMinute is how long the session is cached in redis, the cache is removed when the session is changed
+	CacheExpInMinute int64 `yaml:"cache_exp_in_minute"`
+}
+
+// OAuth struct to hold the configuration data for social login
+type OAuth struct {
+	// Providers is mapped by the provider name in the url, the provider without client id or secret is disabled
+	Providers map[string]OAuthProvider `yaml:"providers"`
+}
+
+// OAuthProvider struct to hold the configuration data for OpenID Connect provider
+type OAuthProvider struct {
+	Issuer   string `yaml:"issuer"`
+	ClientID string `yaml:"client_id"`
+	// ClientSecret is stored in vault, apple use pre generated client secret JWT signed by the team key
+	ClientSecret string   `yaml:"client_secret"`
+	RedirectURL  string   `yaml:"redirect_url"`
+	AuthURL      string   `yaml:"auth_url"`
+	TokenURL     string   `yaml:"token_url"`
+	JWKSURL      string   `yaml:"jwks_url"`
+	Scopes       []string `yaml:"scopes"`
+	// ResponseMode is form_post when the provider post the callback instead of redirect
+	ResponseMode string `yaml:"response_mode"`
+}
+
+// IsConfigured is func to check the client id and secret of the provider is set,
+// the value still in <secret_name> form is not replaced because the secret is missing in vault
+func (p OAuthProvider) IsConfigured() bool {
+	return isSecretSet(p.ClientID) && isSecretSet(p.ClientSecret)
+}
+
+// isSecretSet is func to check the value is not empty and not the placeholder of the secret
+func isSecretSet(value string) bool {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		return false
+	}
+	return !(strings.HasPrefix(value, "<") && strings.HasSuffix(value, ">"))
+}
+
+// Subscription struct to hold the configuration data for subscription plan
+type Subscription struct {
+	// PeriodInDay is how long the plan is active for each subscription
+	PeriodInDay int `yaml:"period_in_day"`
+	// DowngradeIntervalInMinute is how often the job check the expired subscription to downgrade the user
+	DowngradeIntervalInMinute int64 `yaml:"downgrade_interval_in_minute"`
+}
+
+// Payment struct to hold the configuration data for payment provider
+type Payment struct {
+	// Provider is stripe or fake, the fake provider is used for local development
+	Provider      string `yaml:"provider"`
+	SecretKey     string `yaml:"secret_key"`
+	WebhookSecret string `yaml:"webhook_secret"`
+	BaseURL       string `yaml:"base_url"`
+	Currency      string `yaml:"currency"`
+	SuccessURL    string `yaml:"success_url"`
+	CancelURL     string `yaml:"cancel_url"`
+	// Prices is the price of each paid plan in the smallest unit of the currency
+	Prices map[string]int64 `yaml:"prices"`
 }
 
 // Handler struct to hold the configuration data for handler
@@ -50,6 +191,13 @@ type Handler struct {
 	TimeoutInSec int `yaml:"timeout_in_sec"`
 }
 
+// RealtimeHandler struct to hold the configuration data for websocket handler
+type RealtimeHandler struct {
+	TimeoutInSec    int      `yaml:"timeout_in_sec"`
+	TokenCheckInSec int      `yaml:"token_check_in_sec"`
+	AllowedOrigins  []string `yaml:"allowed_origins"`
+}
+
 // GetConfig is func to load config and replace it by secret value
 func GetConfig(values map[string]string) (Config, error) {
 	var cfg Config
@@ -0,0 +1,73 @@
+package migration
+
+import (
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/postgres"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// legacyPremiumPeriod is the period of the subscription given to the user upgraded before the subscription plan
+const legacyPremiumPeriod = 365 * 24 * time.Hour
+
+// migrations is list of data migration in the applied order, the applied migration must not be changed
+var migrations = []postgres.Migration{
+	{
+		Name: "0001_legacy_verified_to_premium",
+		Up:   migrateLegacyVerified,
+	},
+	{
+		Name: "0002_backfill_matches",
+		Up:   backfillMatches,
+	},
+	{
+		Name: "0003_grandfather_email_verified",
+		Up:   grandfatherEmailVerified,
+	},
+}
+
+// Run is func to apply the data migration that is not applied yet
+func Run(db *gorm.DB) error {
+	return postgres.RunMigrations(db, migrations)
+}
+
+// migrateLegacyVerified is func to move the user upgraded by the legacy is_verified flag into the premium plan,
+// the legacy upgrade unlocked every premium feature so the user get the active premium subscription
+func migrateLegacyVerified(tx *gorm.DB) error {
+	// the column only exists on the database created before the subscription plan
+	if !tx.Dialect().HasColumn("users", "is_verified") {
+		return nil
+	}
+
+	now := time.Now()
+	err := tx.Exec(`INSERT INTO subscriptions (created_at, updated_at, user_id, plan, status, started_at, expired_at)
+		SELECT ?, ?, users.id, ?, ?, ?, ? FROM users
+		WHERE users.is_verified = TRUE AND users.deleted_at IS NULL
+		AND NOT EXISTS (SELECT 1 FROM subscriptions WHERE subscriptions.user_id = users.id AND subscriptions.status <> ?)`,
+		now, now, models.PlanPremium, models.SubscriptionStatusActive, now, now.Add(legacyPremiumPeriod), models.SubscriptionStatusExpired).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Exec(`UPDATE users SET plan = ? WHERE is_verified = TRUE AND deleted_at IS NULL AND (plan IS NULL OR plan IN (?, ?))`,
+		models.PlanPremium, "", models.PlanFree).Error
+}
+
+// backfillMatches is func to create the match of the pair approved before the match is stored, the pair is ordered
+// by the user id the same as models.NewMatch and the latest approval of the pair is the matched time
+func backfillMatches(tx *gorm.DB) error {
+	now := time.Now()
+	return tx.Exec(`INSERT INTO matches (created_at, updated_at, user_id, partner_id, status, matched_at, unmatched_by)
+		SELECT ?, ?, LEAST(user_id, partner_id), GREATEST(user_id, partner_id), ?, MAX(updated_at), 0 FROM user_match_histories
+		WHERE status = ? AND decision IN (?) AND deleted_at IS NULL
+		GROUP BY LEAST(user_id, partner_id), GREATEST(user_id, partner_id)
+		ON CONFLICT (user_id, partner_id) DO NOTHING`,
+		now, now, models.MatchStatusApproved, models.MatchStatusApproved, models.LikeDecisions).Error
+}
+
+// grandfatherEmailVerified is func to mark the email of the user registered before the email verification as verified,
+// the migration is applied before the server accept any request so the user created after the cutoff is never included
+func grandfatherEmailVerified(tx *gorm.DB) error {
+	return tx.Exec(`UPDATE users SET is_email_verified = TRUE WHERE COALESCE(is_email_verified, FALSE) = FALSE AND created_at < ?`, time.Now()).Error
+}
@@ -0,0 +1,153 @@
+package migration
+
+import (
+	"errors"
+	"gilsaputro/dating-apps/models"
+	"regexp"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func Test_migrateLegacyVerified(t *testing.T) {
+	hasColumnQuery := regexp.QuoteMeta(`SELECT count(*) FROM INFORMATION_SCHEMA.columns WHERE table_name = $1 AND column_name = $2 AND table_schema = CURRENT_SCHEMA()`)
+	tests := []struct {
+		name     string
+		mockFunc func(mock sqlmock.Sqlmock)
+		wantErr  bool
+	}{
+		{
+			name: "success skip database without legacy column",
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(hasColumnQuery).WithArgs("users", "is_verified").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+		},
+		{
+			name: "success migrate legacy verified user",
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(hasColumnQuery).WithArgs("users", "is_verified").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO subscriptions (created_at, updated_at, user_id, plan, status, started_at, expired_at)`)).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "PREMIUM", "ACTIVE", sqlmock.AnyArg(), sqlmock.AnyArg(), "EXPIRED").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE users SET plan = $1 WHERE is_verified = TRUE AND deleted_at IS NULL AND (plan IS NULL OR plan IN ($2, $3))`)).
+					WithArgs("PREMIUM", "", "FREE").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		{
+			name: "failed create subscription",
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(hasColumnQuery).WithArgs("users", "is_verified").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO subscriptions`)).
+					WillReturnError(errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			defer db.Close()
+			gormDB, _ := gorm.Open("postgres", db)
+			tt.mockFunc(mock)
+
+			err := migrateLegacyVerified(gormDB)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("migrateLegacyVerified() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_backfillMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		mockFunc func(mock sqlmock.Sqlmock)
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO matches (created_at, updated_at, user_id, partner_id, status, matched_at, unmatched_by)`)).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), int(models.MatchStatusApproved), int(models.MatchStatusApproved), int(models.DecisionLike), int(models.DecisionSuperLike)).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+			},
+		},
+		{
+			name: "error",
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO matches`)).
+					WillReturnError(errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			defer db.Close()
+			gormDB, _ := gorm.Open("postgres", db)
+			tt.mockFunc(mock)
+
+			err := backfillMatches(gormDB)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("backfillMatches() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_grandfatherEmailVerified(t *testing.T) {
+	tests := []struct {
+		name     string
+		mockFunc func(mock sqlmock.Sqlmock)
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE users SET is_email_verified = TRUE WHERE COALESCE(is_email_verified, FALSE) = FALSE AND created_at < $1`)).
+					WithArgs(sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 5))
+			},
+		},
+		{
+			name: "error",
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE users SET is_email_verified = TRUE`)).
+					WillReturnError(errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			defer db.Close()
+			gormDB, _ := gorm.Open("postgres", db)
+			tt.mockFunc(mock)
+
+			err := grandfatherEmailVerified(gormDB)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("grandfatherEmailVerified() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"gilsaputro/dating-apps/internal/store/user"
 	"gilsaputro/dating-apps/models"
 	"gilsaputro/dating-apps/pkg/hash"
+	"time"
 )
 
 const numUser = 20
@@ -19,13 +20,23 @@ func GenerateSeed(store user.UserStoreMethod, hash hash.HashMethod) error {
 
 	if count <= 0 {
 		for i := 0; i <= numUser; i++ {
+			gender := models.GenderMale
+			if i%2 == 0 {
+				gender = models.GenderFemale
+			}
+			birthdate := time.Now().AddDate(-(20 + i), 0, 0)
+
 			// Create a new faker instance
 			store.CreateUser(models.User{
-				Username:   fmt.Sprintf("username_%v", i),
-				Fullname:   fmt.Sprintf("User Person %v", i),
-				Password:   string(newHash),
-				Email:      fmt.Sprintf("email%v@fake.com", i),
-				IsVerified: false,
+				Username:  fmt.Sprintf("username_%v", i),
+				Fullname:  fmt.Sprintf("User Person %v", i),
+				Password:  string(newHash),
+				Email:     fmt.Sprintf("email%v@fake.com", i),
+				Plan:      models.PlanFree,
+				Gender:    gender,
+				Birthdate: &birthdate,
+				// the seed email is fake, so it is verified to allow the seed user to like
+				IsEmailVerified: true,
 			})
 		}
 	}
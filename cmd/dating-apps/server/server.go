@@ -13,43 +13,102 @@ import (
 	"github.com/joho/godotenv"
 
 	"gilsaputro/dating-apps/cmd/dating-apps/config"
+	"gilsaputro/dating-apps/cmd/dating-apps/migration"
 	"gilsaputro/dating-apps/cmd/dating-apps/seed"
 	auth_handler "gilsaputro/dating-apps/internal/handler/authentication"
+	chat_handler "gilsaputro/dating-apps/internal/handler/chat"
 	"gilsaputro/dating-apps/internal/handler/middleware"
+	moderation_handler "gilsaputro/dating-apps/internal/handler/moderation"
 	partner_handler "gilsaputro/dating-apps/internal/handler/partner"
+	payment_handler "gilsaputro/dating-apps/internal/handler/payment"
+	realtime_handler "gilsaputro/dating-apps/internal/handler/realtime"
+	subscription_handler "gilsaputro/dating-apps/internal/handler/subscription"
 	user_handler "gilsaputro/dating-apps/internal/handler/user"
+	verification_handler "gilsaputro/dating-apps/internal/handler/verification"
 	auth_service "gilsaputro/dating-apps/internal/service/authentication"
+	chat_service "gilsaputro/dating-apps/internal/service/chat"
+	moderation_service "gilsaputro/dating-apps/internal/service/moderation"
 	partner_service "gilsaputro/dating-apps/internal/service/partner"
+	payment_service "gilsaputro/dating-apps/internal/service/payment"
+	realtime_service "gilsaputro/dating-apps/internal/service/realtime"
+	subscription_service "gilsaputro/dating-apps/internal/service/subscription"
 	user_service "gilsaputro/dating-apps/internal/service/user"
+	verification_service "gilsaputro/dating-apps/internal/service/verification"
+	block_store "gilsaputro/dating-apps/internal/store/block"
+	identity_store "gilsaputro/dating-apps/internal/store/identity"
+	loginattempt_store "gilsaputro/dating-apps/internal/store/loginattempt"
+	match_store "gilsaputro/dating-apps/internal/store/match"
+	message_store "gilsaputro/dating-apps/internal/store/message"
 	partner_store "gilsaputro/dating-apps/internal/store/partnercache"
+	payment_store "gilsaputro/dating-apps/internal/store/payment"
+	report_store "gilsaputro/dating-apps/internal/store/report"
+	session_store "gilsaputro/dating-apps/internal/store/session"
+	subscription_store "gilsaputro/dating-apps/internal/store/subscription"
+	tokencache_store "gilsaputro/dating-apps/internal/store/tokencache"
+	twofactor_store "gilsaputro/dating-apps/internal/store/twofactor"
 	user_store "gilsaputro/dating-apps/internal/store/user"
 	userhist_store "gilsaputro/dating-apps/internal/store/userhistory"
+	verification_store "gilsaputro/dating-apps/internal/store/verification"
+	verificationcache_store "gilsaputro/dating-apps/internal/store/verificationcache"
 	"gilsaputro/dating-apps/pkg/hash"
+	"gilsaputro/dating-apps/pkg/mailer"
+	"gilsaputro/dating-apps/pkg/oidc"
+	"gilsaputro/dating-apps/pkg/payment"
 	"gilsaputro/dating-apps/pkg/postgres"
 	"gilsaputro/dating-apps/pkg/redis"
 	"gilsaputro/dating-apps/pkg/token"
+	"gilsaputro/dating-apps/pkg/totp"
 	"gilsaputro/dating-apps/pkg/vault"
 )
 
 // Servcer is list configuration to run Server
 type Server struct {
-	cfg            config.Config
-	vault          vault.VaultMethod
-	hashMethod     hash.HashMethod
-	tokenMethod    token.TokenMethod
-	postgres       postgres.PostgresMethod
-	redisMethod    redis.RedisMethod
-	middleware     middleware.Middleware
-	userStore      user_store.UserStoreMethod
-	userService    user_service.UserServiceMethod
-	userHandler    user_handler.UserHandler
-	authService    auth_service.AuthenticationServiceMethod
-	authHandler    auth_handler.AuthenticationHandler
-	partnerStore   partner_store.PartnerCacheStoreMethod
-	partnerService partner_service.PartnerServiceMethod
-	partnerHandler partner_handler.PartnerHandler
-	userHistStore  userhist_store.UserHistoryStoreMethod
-	httpServer     *http.Server
+	cfg                 config.Config
+	vault               vault.VaultMethod
+	hashMethod          hash.HashMethod
+	tokenMethod         token.TokenMethod
+	postgres            postgres.PostgresMethod
+	redisMethod         redis.RedisMethod
+	middleware          middleware.Middleware
+	userStore           user_store.UserStoreMethod
+	userService         user_service.UserServiceMethod
+	userHandler         user_handler.UserHandler
+	authService         auth_service.AuthenticationServiceMethod
+	authHandler         auth_handler.AuthenticationHandler
+	partnerStore        partner_store.PartnerCacheStoreMethod
+	tokenCacheStore     tokencache_store.TokenCacheStoreMethod
+	verifyCacheStore    verificationcache_store.VerificationCacheStoreMethod
+	loginAttemptStore   loginattempt_store.LoginAttemptStoreMethod
+	mailer              mailer.Mailer
+	totpMethod          totp.TOTPMethod
+	twoFactorStore      twofactor_store.TwoFactorStoreMethod
+	sessionStore        session_store.SessionStoreMethod
+	identityStore       identity_store.IdentityStoreMethod
+	oauthProviders      map[string]oidc.Provider
+	partnerService      partner_service.PartnerServiceMethod
+	partnerHandler      partner_handler.PartnerHandler
+	userHistStore       userhist_store.UserHistoryStoreMethod
+	matchStore          match_store.MatchStoreMethod
+	blockStore          block_store.BlockStoreMethod
+	reportStore         report_store.ReportStoreMethod
+	moderationService   moderation_service.ModerationServiceMethod
+	moderationHandler   moderation_handler.ModerationHandler
+	messageStore        message_store.MessageStoreMethod
+	chatService         chat_service.ChatServiceMethod
+	chatHandler         chat_handler.ChatHandler
+	realtimeService     realtime_service.RealtimeServiceMethod
+	realtimeHandler     realtime_handler.RealtimeHandler
+	subscriptionStore   subscription_store.SubscriptionStoreMethod
+	subscriptionService subscription_service.SubscriptionServiceMethod
+	subscriptionHandler subscription_handler.SubscriptionHandler
+	paymentProvider     payment.PaymentProvider
+	paymentStore        payment_store.PaymentStoreMethod
+	paymentService      payment_service.PaymentServiceMethod
+	paymentHandler      payment_handler.PaymentHandler
+	verificationStore   verification_store.VerificationStoreMethod
+	verificationService verification_service.VerificationServiceMethod
+	verificationHandler verification_handler.VerificationHandler
+	httpServer          *http.Server
 }
 
 // NewServer is func to create server with all configuration
@@ -116,6 +175,16 @@ func NewServer() (*Server, error) {
 		log.Println("Init-Postgres")
 	}
 
+	// Run Data Migration
+	{
+		err := migration.Run(s.postgres.GetDB())
+		if err != nil {
+			fmt.Print("[Got Error]-Migration :", err)
+			return s, err
+		}
+		log.Println("Init-Migration")
+	}
+
 	// Init Redis
 	{
 		redisMethod := redis.NewRedisClient(redis.RedisConfig{
@@ -136,11 +205,95 @@ func NewServer() (*Server, error) {
 
 	// Init Token Package
 	{
-		tokenMethod := token.NewTokenMethod(s.cfg.Token.Secret, s.cfg.Token.ExpInHour)
+		var opts []token.Option
+		opts = append(opts, token.WithIssuerOptions(s.cfg.Token.Issuer, s.cfg.Token.Audience))
+		// sign with the asymmetric key when it is stored in vault, otherwise the shared secret is used
+		pemKeys, err := s.vault.GetTokenKeys()
+		if err != nil {
+			fmt.Print("[Got Error]-Load Token Keys :", err)
+			return s, err
+		}
+		if len(pemKeys) > 0 {
+			signingKeys, err := token.ParseSigningKeys(s.cfg.Token.ActiveKeyID, pemKeys)
+			if err != nil {
+				fmt.Print("[Got Error]-Token Keys :", err)
+				return s, err
+			}
+			opts = append(opts, token.WithSigningKeys(s.cfg.Token.ActiveKeyID, signingKeys))
+		}
+
+		tokenMethod := token.NewTokenMethod(s.cfg.Token.Secret, s.cfg.Token.ExpInHour, s.cfg.Token.RefreshExpInHour, opts...)
 		s.tokenMethod = tokenMethod
 		log.Println("Init-Token Package")
 	}
 
+	// Init Mailer Package
+	{
+		switch s.cfg.Mailer.Type {
+		case mailer.TypeSMTP:
+			s.mailer = mailer.NewSMTPMailer(mailer.SMTPConfig{
+				Host:     s.cfg.Mailer.Host,
+				Port:     s.cfg.Mailer.Port,
+				Username: s.cfg.Mailer.Username,
+				Password: s.cfg.Mailer.Password,
+				From:     s.cfg.Mailer.From,
+			})
+		default:
+			s.mailer = mailer.NewFileMailer(s.cfg.Mailer.FilePath)
+		}
+		log.Println("Init-Mailer Package")
+	}
+
+	// Init TOTP Package
+	{
+		s.totpMethod = totp.NewTOTPMethod(s.cfg.TwoFactor.Issuer)
+		log.Println("Init-TOTP Package")
+	}
+
+	// Init OAuth Providers
+	{
+		s.oauthProviders = map[string]oidc.Provider{}
+		for name, provider := range s.cfg.OAuth.Providers {
+			if !provider.IsConfigured() {
+				log.Println("[Warning]-OAuth Provider : client id or secret is not set, the provider is disabled :", name)
+				continue
+			}
+			s.oauthProviders[name] = oidc.NewProvider(oidc.ProviderConfig{
+				Issuer:       provider.Issuer,
+				ClientID:     provider.ClientID,
+				ClientSecret: provider.ClientSecret,
+				RedirectURL:  provider.RedirectURL,
+				AuthURL:      provider.AuthURL,
+				TokenURL:     provider.TokenURL,
+				JWKSURL:      provider.JWKSURL,
+				Scopes:       provider.Scopes,
+				ResponseMode: provider.ResponseMode,
+			})
+		}
+		log.Println("Init-OAuth Providers")
+	}
+
+	// Init Payment Provider
+	{
+		switch s.cfg.Payment.Provider {
+		case payment.ProviderStripe:
+			s.paymentProvider = payment.NewStripeProvider(payment.StripeConfig{
+				SecretKey:     s.cfg.Payment.SecretKey,
+				WebhookSecret: s.cfg.Payment.WebhookSecret,
+				BaseURL:       s.cfg.Payment.BaseURL,
+			})
+		case payment.ProviderFake:
+			// the fake provider does not take real payment, it must only be selected by the test or local config
+			log.Println("[Warning]-Payment Provider : fake provider is used, the checkout does not take real payment")
+			s.paymentProvider = payment.NewFakeProvider(s.cfg.Payment.WebhookSecret)
+		default:
+			err := fmt.Errorf("%w: %q", payment.ErrUnknownProvider, s.cfg.Payment.Provider)
+			fmt.Print("[Got Error]-Payment Provider :", err)
+			return s, err
+		}
+		log.Println("Init-Payment Provider")
+	}
+
 	// ======== Init Dependencies Store ========
 	// Init User Store
 	{
@@ -155,36 +308,169 @@ func NewServer() (*Server, error) {
 		log.Println("Init-User History Store")
 	}
 
+	{
+		matchStore := match_store.NewMatchStore(s.postgres)
+		s.matchStore = matchStore
+		log.Println("Init-Match Store")
+	}
+
+	{
+		blockStore := block_store.NewBlockStore(s.postgres)
+		s.blockStore = blockStore
+		log.Println("Init-Block Store")
+	}
+
+	{
+		reportStore := report_store.NewReportStore(s.postgres)
+		s.reportStore = reportStore
+		log.Println("Init-Report Store")
+	}
+
+	{
+		messageStore := message_store.NewMessageStore(s.postgres)
+		s.messageStore = messageStore
+		log.Println("Init-Message Store")
+	}
+
+	{
+		twoFactorStore := twofactor_store.NewTwoFactorStore(s.postgres)
+		s.twoFactorStore = twoFactorStore
+		log.Println("Init-Two Factor Store")
+	}
+
+	{
+		sessionStore := session_store.NewSessionStore(s.postgres, s.redisMethod, time.Duration(s.cfg.Session.CacheExpInMinute)*time.Minute)
+		s.sessionStore = sessionStore
+		log.Println("Init-Session Store")
+	}
+
+	{
+		identityStore := identity_store.NewIdentityStore(s.postgres)
+		s.identityStore = identityStore
+		log.Println("Init-Identity Store")
+	}
+
+	{
+		subscriptionStore := subscription_store.NewSubscriptionStore(s.postgres)
+		s.subscriptionStore = subscriptionStore
+		log.Println("Init-Subscription Store")
+	}
+
+	{
+		paymentStore := payment_store.NewPaymentStore(s.postgres)
+		s.paymentStore = paymentStore
+		log.Println("Init-Payment Store")
+	}
+
+	{
+		verificationStore := verification_store.NewVerificationStore(s.postgres)
+		s.verificationStore = verificationStore
+		log.Println("Init-Verification Store")
+	}
+
 	{
 		partnerStore := partner_store.NewPartnerCacheStore(s.redisMethod)
 		s.partnerStore = partnerStore
 		log.Println("Init-Partner Cache Store")
 	}
 
+	{
+		tokenCacheStore := tokencache_store.NewTokenCacheStore(s.redisMethod, time.Duration(s.cfg.Token.ExpInHour)*time.Hour, time.Duration(s.cfg.Token.RefreshExpInHour)*time.Hour)
+		s.tokenCacheStore = tokenCacheStore
+		log.Println("Init-Token Cache Store")
+	}
+
+	{
+		verifyCacheStore := verificationcache_store.NewVerificationCacheStore(s.redisMethod, time.Duration(s.cfg.EmailVerification.TokenExpInHour)*time.Hour)
+		s.verifyCacheStore = verifyCacheStore
+		log.Println("Init-Verification Cache Store")
+	}
+
+	{
+		loginAttemptStore := loginattempt_store.NewLoginAttemptStore(s.redisMethod, time.Duration(s.cfg.LoginProtection.AttemptWindowInMinute)*time.Minute)
+		s.loginAttemptStore = loginAttemptStore
+		log.Println("Init-Login Attempt Store")
+	}
+
 	// ======== Init Dependencies Service ========
-	// Init User Service
+	// Init Realtime Service
 	{
-		userService := user_service.NewUserService(s.userStore, s.hashMethod)
-		s.userService = userService
-		log.Println("Init-User Service")
+		realtimeService := realtime_service.NewRealtimeService(s.redisMethod)
+		s.realtimeService = realtimeService
+		log.Println("Init-Realtime Service")
 	}
 
 	{
-		authService := auth_service.NewAuthenticationService(s.userStore, s.tokenMethod, s.hashMethod)
+		authService := auth_service.NewAuthenticationService(s.userStore, s.tokenMethod, s.hashMethod, s.tokenCacheStore, s.verifyCacheStore, s.mailer, s.cfg.EmailVerification.URL, auth_service.PasswordResetConfig{
+			CodeTTL:             time.Duration(s.cfg.PasswordReset.CodeExpInMinute) * time.Minute,
+			MaxAttempts:         s.cfg.PasswordReset.MaxAttempts,
+			MaxUsernameRequests: s.cfg.PasswordReset.MaxUsernameRequests,
+			MaxIPRequests:       s.cfg.PasswordReset.MaxIPRequests,
+		}, s.loginAttemptStore, auth_service.LoginProtectionConfig{
+			MaxUsernameAttempts: s.cfg.LoginProtection.MaxUsernameAttempts,
+			MaxIPAttempts:       s.cfg.LoginProtection.MaxIPAttempts,
+			BaseLockout:         time.Duration(s.cfg.LoginProtection.BaseLockoutInSec) * time.Second,
+			MaxLockout:          time.Duration(s.cfg.LoginProtection.MaxLockoutInMinute) * time.Minute,
+		}, s.twoFactorStore, s.totpMethod, s.sessionStore, s.identityStore, s.oauthProviders)
 		s.authService = authService
 		log.Println("Init-Auth Service")
 	}
 
+	// Init User Service
+	{
+		userService := user_service.NewUserService(s.userStore, s.hashMethod, s.tokenCacheStore, s.twoFactorStore, s.totpMethod, s.sessionStore, s.authService)
+		s.userService = userService
+		log.Println("Init-User Service")
+	}
+
 	{
-		partnerService := partner_service.NewPartnerService(s.userStore, s.userHistStore, s.partnerStore, s.cfg.MaxCounter)
+		partnerService := partner_service.NewPartnerService(s.userStore, s.userHistStore, s.matchStore, s.blockStore, s.partnerStore, s.realtimeService, s.cfg.MaxCounter, time.Duration(s.cfg.PassCooldownInHour)*time.Hour, partner_service.SuperLikeAllowance{
+			Default: s.cfg.SuperLike.DailyLimit,
+			Premium: s.cfg.SuperLike.PremiumDailyLimit,
+		})
 		s.partnerService = partnerService
 		log.Println("Init-Partner Service")
 	}
 
+	{
+		moderationService := moderation_service.NewModerationService(s.userStore, s.blockStore, s.reportStore, s.matchStore)
+		s.moderationService = moderationService
+		log.Println("Init-Moderation Service")
+	}
+
+	{
+		chatService := chat_service.NewChatService(s.matchStore, s.messageStore, s.realtimeService)
+		s.chatService = chatService
+		log.Println("Init-Chat Service")
+	}
+
+	{
+		subscriptionService := subscription_service.NewSubscriptionService(s.subscriptionStore, s.userStore, s.tokenCacheStore, time.Duration(s.cfg.Subscription.PeriodInDay)*24*time.Hour)
+		s.subscriptionService = subscriptionService
+		log.Println("Init-Subscription Service")
+	}
+
+	{
+		paymentService := payment_service.NewPaymentService(s.paymentStore, s.userStore, s.subscriptionService, s.paymentProvider, payment_service.CheckoutConfig{
+			Prices:     s.cfg.Payment.Prices,
+			Currency:   s.cfg.Payment.Currency,
+			SuccessURL: s.cfg.Payment.SuccessURL,
+			CancelURL:  s.cfg.Payment.CancelURL,
+		})
+		s.paymentService = paymentService
+		log.Println("Init-Payment Service")
+	}
+
+	{
+		verificationService := verification_service.NewVerificationService(s.verificationStore, s.userStore)
+		s.verificationService = verificationService
+		log.Println("Init-Verification Service")
+	}
+
 	// ======== Init Dependencies Handler ========
 	// Init Middleware
 	{
-		midlewareService := middleware.NewMiddleware(s.tokenMethod, s.userStore)
+		midlewareService := middleware.NewMiddleware(s.tokenMethod, s.tokenCacheStore, s.sessionStore)
 		s.middleware = midlewareService
 		log.Println("Init-Middleware")
 	}
@@ -216,6 +502,62 @@ func NewServer() (*Server, error) {
 		log.Println("Init-Partner Handler")
 	}
 
+	// Init Moderation Handler
+	{
+		var opts []moderation_handler.Option
+		opts = append(opts, moderation_handler.WithTimeoutOptions(s.cfg.ModerationHandler.TimeoutInSec))
+		moderationHandler := moderation_handler.NewModerationHandler(s.moderationService, opts...)
+		s.moderationHandler = *moderationHandler
+		log.Println("Init-Moderation Handler")
+	}
+
+	// Init Subscription Handler
+	{
+		var opts []subscription_handler.Option
+		opts = append(opts, subscription_handler.WithTimeoutOptions(s.cfg.SubscriptionHandler.TimeoutInSec))
+		subscriptionHandler := subscription_handler.NewSubscriptionHandler(s.subscriptionService, opts...)
+		s.subscriptionHandler = *subscriptionHandler
+		log.Println("Init-Subscription Handler")
+	}
+
+	// Init Payment Handler
+	{
+		var opts []payment_handler.Option
+		opts = append(opts, payment_handler.WithTimeoutOptions(s.cfg.PaymentHandler.TimeoutInSec))
+		paymentHandler := payment_handler.NewPaymentHandler(s.paymentService, opts...)
+		s.paymentHandler = *paymentHandler
+		log.Println("Init-Payment Handler")
+	}
+
+	// Init Verification Handler
+	{
+		var opts []verification_handler.Option
+		opts = append(opts, verification_handler.WithTimeoutOptions(s.cfg.VerificationHandler.TimeoutInSec))
+		verificationHandler := verification_handler.NewVerificationHandler(s.verificationService, opts...)
+		s.verificationHandler = *verificationHandler
+		log.Println("Init-Verification Handler")
+	}
+
+	// Init Chat Handler
+	{
+		var opts []chat_handler.Option
+		opts = append(opts, chat_handler.WithTimeoutOptions(s.cfg.ChatHandler.TimeoutInSec))
+		chatHandler := chat_handler.NewChatHandler(s.chatService, opts...)
+		s.chatHandler = *chatHandler
+		log.Println("Init-Chat Handler")
+	}
+
+	// Init Realtime Handler
+	{
+		var opts []realtime_handler.Option
+		opts = append(opts, realtime_handler.WithTimeoutOptions(s.cfg.RealtimeHandler.TimeoutInSec))
+		opts = append(opts, realtime_handler.WithCheckOriginOptions(realtime_handler.AllowOrigins(s.cfg.RealtimeHandler.AllowedOrigins)))
+		opts = append(opts, realtime_handler.WithTokenCheckOptions(s.middleware.IsTokenActive, s.cfg.RealtimeHandler.TokenCheckInSec))
+		realtimeHandler := realtime_handler.NewRealtimeHandler(s.realtimeService, opts...)
+		s.realtimeHandler = *realtimeHandler
+		log.Println("Init-Realtime Handler")
+	}
+
 	// Generate Seed
 	{
 		err := seed.GenerateSeed(s.userStore, s.hashMethod)
@@ -231,19 +573,69 @@ func NewServer() (*Server, error) {
 		r := mux.NewRouter()
 		// Init Guest Path
 		r.HandleFunc("/v1/login", s.authHandler.LoginUserHandler).Methods("POST")
+		r.HandleFunc("/v1/login/2fa", s.authHandler.LoginTwoFactorHandler).Methods("POST")
+		r.HandleFunc("/v1/oauth/{provider}/start", s.authHandler.OAuthStartHandler).Methods("GET")
+		r.HandleFunc("/v1/oauth/{provider}/callback", s.authHandler.OAuthCallbackHandler).Methods("GET", "POST")
 		r.HandleFunc("/v1/register", s.authHandler.RegisterUserHandler).Methods("POST")
+		r.HandleFunc("/.well-known/jwks.json", s.authHandler.JWKSHandler).Methods("GET")
+		r.HandleFunc("/v1/token/refresh", s.authHandler.RefreshTokenHandler).Methods("POST")
+		r.HandleFunc("/v1/logout", s.middleware.MiddlewareVerifyToken(s.authHandler.LogoutUserHandler)).Methods("POST")
+		r.HandleFunc("/v1/verify-email", s.authHandler.VerifyEmailHandler).Methods("GET")
+		r.HandleFunc("/v1/verify-email/resend", s.middleware.MiddlewareVerifyToken(s.authHandler.ResendEmailVerificationHandler)).Methods("POST")
+		r.HandleFunc("/v1/password/forgot", s.authHandler.ForgotPasswordHandler).Methods("POST")
+		r.HandleFunc("/v1/password/reset", s.authHandler.ResetPasswordHandler).Methods("POST")
 
 		// Init User Path
 		r.HandleFunc("/v1/user", s.middleware.MiddlewareVerifyToken(s.userHandler.ProfileUserHandler)).Methods("GET")
 		r.HandleFunc("/v1/user", s.middleware.MiddlewareVerifyToken(s.userHandler.DeleteUserHandler)).Methods("DELETE")
 		r.HandleFunc("/v1/user", s.middleware.MiddlewareVerifyToken(s.userHandler.EditUserHandler)).Methods("PUT")
-		r.HandleFunc("/v1/user/upgrade", s.middleware.MiddlewareVerifyToken(s.userHandler.UpgradeUserHandler)).Methods("POST")
+		r.HandleFunc("/v1/user/location", s.middleware.MiddlewareVerifyToken(s.userHandler.UpdateLocationHandler)).Methods("PUT")
+		r.HandleFunc("/v1/user/2fa", s.middleware.MiddlewareVerifyToken(s.userHandler.EnrollTwoFactorHandler)).Methods("POST")
+		r.HandleFunc("/v1/user/2fa", s.middleware.MiddlewareVerifyToken(s.userHandler.DisableTwoFactorHandler)).Methods("DELETE")
+		r.HandleFunc("/v1/user/2fa/confirm", s.middleware.MiddlewareVerifyToken(s.userHandler.ConfirmTwoFactorHandler)).Methods("POST")
+		r.HandleFunc("/v1/user/sessions", s.middleware.MiddlewareVerifyToken(s.userHandler.SessionListHandler)).Methods("GET")
+		r.HandleFunc("/v1/user/sessions/{sessionID}", s.middleware.MiddlewareVerifyToken(s.userHandler.RevokeSessionHandler)).Methods("DELETE")
 
 		// Init Partner Partner Path
-		r.HandleFunc("/v1/partner", s.middleware.MiddlewareVerifyToken(s.middleware.MiddlewareCheckVerifiedStatus(s.partnerHandler.CurrentPartnerHandler))).Methods("GET")
-		r.HandleFunc("/v1/partner/history", s.middleware.MiddlewareVerifyToken(s.middleware.MiddlewareCheckVerifiedStatus(s.partnerHandler.LikedHistoryHandler))).Methods("GET")
-		r.HandleFunc("/v1/partner/pass", s.middleware.MiddlewareVerifyToken(s.middleware.MiddlewareCheckVerifiedStatus(s.partnerHandler.PassPartnerHandler))).Methods("POST")
-		r.HandleFunc("/v1/partner/like", s.middleware.MiddlewareVerifyToken(s.middleware.MiddlewareCheckVerifiedStatus(s.partnerHandler.LikePartnerHandler))).Methods("POST")
+		r.HandleFunc("/v1/partner", s.middleware.MiddlewareVerifyToken(s.partnerHandler.CurrentPartnerHandler)).Methods("GET")
+		r.HandleFunc("/v1/partner/history", s.middleware.MiddlewareVerifyToken(s.partnerHandler.LikedHistoryHandler)).Methods("GET")
+		r.HandleFunc("/v1/partner/likes-received", s.middleware.MiddlewareVerifyToken(s.partnerHandler.LikesReceivedHandler)).Methods("GET")
+		r.HandleFunc("/v1/partner/pass", s.middleware.MiddlewareVerifyToken(s.partnerHandler.PassPartnerHandler)).Methods("POST")
+		r.HandleFunc("/v1/partner/like", s.middleware.MiddlewareVerifyToken(s.partnerHandler.LikePartnerHandler)).Methods("POST")
+		r.HandleFunc("/v1/partner/superlike", s.middleware.MiddlewareVerifyToken(s.partnerHandler.SuperLikePartnerHandler)).Methods("POST")
+		r.HandleFunc("/v1/partner/rewind", s.middleware.MiddlewareVerifyToken(s.partnerHandler.RewindPartnerHandler)).Methods("POST")
+
+		// Init Match Path
+		r.HandleFunc("/v1/matches", s.middleware.MiddlewareVerifyToken(s.partnerHandler.MatchListHandler)).Methods("GET")
+		r.HandleFunc("/v1/matches/{partnerID:[0-9]+}/unmatch", s.middleware.MiddlewareVerifyToken(s.partnerHandler.UnmatchPartnerHandler)).Methods("POST")
+		r.HandleFunc("/v1/matches/{partnerID:[0-9]+}/messages", s.middleware.MiddlewareVerifyToken(s.chatHandler.SendMessageHandler)).Methods("POST")
+		r.HandleFunc("/v1/matches/{partnerID:[0-9]+}/messages", s.middleware.MiddlewareVerifyToken(s.chatHandler.MessageListHandler)).Methods("GET")
+
+		// Init Subscription Path
+		r.HandleFunc("/v1/subscription/plans", s.subscriptionHandler.PlanListHandler).Methods("GET")
+		r.HandleFunc("/v1/user/subscription", s.middleware.MiddlewareVerifyToken(s.subscriptionHandler.SubscriptionInfoHandler)).Methods("GET")
+		r.HandleFunc("/v1/user/subscription", s.middleware.MiddlewareVerifyToken(s.subscriptionHandler.CancelSubscriptionHandler)).Methods("DELETE")
+
+		// Init Payment Path
+		r.HandleFunc("/v1/user/subscription/checkout", s.middleware.MiddlewareVerifyToken(s.paymentHandler.CheckoutHandler)).Methods("POST")
+		r.HandleFunc("/v1/payment/webhook", s.paymentHandler.WebhookHandler).Methods("POST")
+
+		// Init Verification Path
+		r.HandleFunc("/v1/user/verification", s.middleware.MiddlewareVerifyToken(s.verificationHandler.VerificationInfoHandler)).Methods("GET")
+		r.HandleFunc("/v1/user/verification", s.middleware.MiddlewareVerifyToken(s.verificationHandler.SubmitVerificationHandler)).Methods("POST")
+
+		// Init Realtime Path
+		r.HandleFunc("/v1/ws", s.middleware.MiddlewareVerifyToken(s.realtimeHandler.EventHandler)).Methods("GET")
+
+		// Init Moderation Path
+		r.HandleFunc("/v1/users/{id:[0-9]+}/block", s.middleware.MiddlewareVerifyToken(s.moderationHandler.BlockUserHandler)).Methods("POST")
+		r.HandleFunc("/v1/users/{id:[0-9]+}/report", s.middleware.MiddlewareVerifyToken(s.moderationHandler.ReportUserHandler)).Methods("POST")
+
+		// Init Admin Path
+		r.HandleFunc("/v1/admin/reports", s.middleware.MiddlewareVerifyToken(s.middleware.MiddlewareCheckAdmin(s.moderationHandler.ReportListHandler))).Methods("GET")
+		r.HandleFunc("/v1/admin/reports/{reportID:[0-9]+}/resolve", s.middleware.MiddlewareVerifyToken(s.middleware.MiddlewareCheckAdmin(s.moderationHandler.ResolveReportHandler))).Methods("POST")
+		r.HandleFunc("/v1/admin/verifications", s.middleware.MiddlewareVerifyToken(s.middleware.MiddlewareCheckAdmin(s.verificationHandler.VerificationListHandler))).Methods("GET")
+		r.HandleFunc("/v1/admin/verifications/{verificationID:[0-9]+}/review", s.middleware.MiddlewareVerifyToken(s.middleware.MiddlewareCheckAdmin(s.verificationHandler.ReviewVerificationHandler))).Methods("POST")
 
 		port := ":" + s.cfg.Port
 		log.Println("running on port ", port)
@@ -265,6 +657,18 @@ func (s *Server) Start() int {
 		}
 	}()
 
+	// Receive the realtime event published by all server instances
+	listenCtx, stopListen := context.WithCancel(context.Background())
+	defer stopListen()
+	go s.realtimeService.RunListener(listenCtx)
+
+	// Downgrade the user of the expired subscription, the job is stopped together with the realtime listener
+	downgradeInterval := time.Duration(s.cfg.Subscription.DowngradeIntervalInMinute) * time.Minute
+	if downgradeInterval <= 0 {
+		downgradeInterval = 5 * time.Minute
+	}
+	go s.subscriptionService.RunDowngradeJob(listenCtx, downgradeInterval)
+
 	// Wait for a signal to shut down the application
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt)
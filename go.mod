@@ -8,6 +8,7 @@ require (
 	github.com/go-redis/redis/v8 v8.11.5
 	github.com/golang/mock v1.6.0
 	github.com/gorilla/mux v1.8.0
+	github.com/gorilla/websocket v1.5.0
 	github.com/hashicorp/vault/api v1.9.1
 	github.com/jinzhu/gorm v1.9.16
 	github.com/joho/godotenv v1.5.1
@@ -0,0 +1,92 @@
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/authentication"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+)
+
+// ForgotPasswordRequest is list request parameter for Forgot Password Api
+type ForgotPasswordRequest struct {
+	Username string `json:"username"`
+}
+
+// ForgotPasswordHandler is func handler for send password reset code to the user email
+func (h *AuthenticationHandler) ForgotPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		response.Code = code
+		if err == nil {
+			response.Message = "success"
+		} else {
+			response.Message = err.Error()
+		}
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[ForgotPasswordHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	var body ForgotPasswordRequest
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Bad Request")
+		return
+	}
+
+	err = json.Unmarshal(data, &body)
+	if err != nil {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Bad Request")
+		return
+	}
+
+	// checking valid body
+	if len(body.Username) < 1 {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Invalid Parameter Request")
+		return
+	}
+
+	errChan := make(chan error, 1)
+	go func(ctx context.Context) {
+		err = h.service.ForgotPassword(authentication.ForgotPasswordServiceRequest{
+			Username: body.Username,
+			ClientIP: utilhttp.GetClientIP(r),
+		})
+		errChan <- err
+	}(ctx)
+
+	select {
+	case <-ctx.Done():
+		code = http.StatusGatewayTimeout
+		err = fmt.Errorf("Timeout")
+		return
+	case err = <-errChan:
+		if err != nil {
+			if err == authentication.ErrTooManyResetRequests {
+				code = http.StatusTooManyRequests
+			} else {
+				code = http.StatusInternalServerError
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package authentication
+
+import (
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/authentication"
+	"gilsaputro/dating-apps/internal/service/authentication/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestAuthenticationHandler_ForgotPasswordHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mService := mock.NewMockAuthenticationServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		body    string
+		timeout int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				body:    `{"username": "abc"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().ForgotPassword(authentication.ForgotPasswordServiceRequest{
+					Username: "abc",
+					ClientIP: "192.0.2.1",
+				}).Return(nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				body:    `{"username": "abc"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().ForgotPassword(authentication.ForgotPasswordServiceRequest{
+					Username: "abc",
+					ClientIP: "192.0.2.1",
+				}).Return(fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error too many request flow",
+			args: args{
+				body:    `{"username": "abc"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().ForgotPassword(authentication.ForgotPasswordServiceRequest{
+					Username: "abc",
+					ClientIP: "192.0.2.1",
+				}).Return(authentication.ErrTooManyResetRequests)
+			},
+			want: want{
+				code: 429,
+				body: `{"code":429,"message":"too many reset password requests, please try again later"}`,
+			},
+		},
+		{
+			name: "error invalid parameter flow",
+			args: args{
+				body:    `{"username": ""}`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error bad request flow",
+			args: args{
+				body:    `{"username": "abc"`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Bad Request"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			handler := NewAuthenticationHandler(mService, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodPost, "/v1/password/forgot", strings.NewReader(tt.args.body))
+			w := httptest.NewRecorder()
+			handler.ForgotPasswordHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("ForgotPasswordHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("ForgotPasswordHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package authentication
+
+import (
+	"encoding/json"
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"log"
+	"net/http"
+)
+
+// jwksMaxAgeInSec is how long the client can cache the key set, it must be shorter than the overlap of the key rotation
+const jwksMaxAgeInSec = "300"
+
+// JWKSHandler is func handler to publish the public key of the token signing key in JSON Web Key Set format
+func (h *AuthenticationHandler) JWKSHandler(w http.ResponseWriter, r *http.Request) {
+	// the response follow the JWKS format instead of the standard response, so the other service can use common JWT library
+	data, err := json.Marshal(h.service.GetJWKS())
+	if err != nil {
+		log.Println("[JWKSHandler]-Error Marshal Response :", err)
+		utilhttp.WriteResponse(w, []byte(`{"code":500,"message":"Internal Server Error"}`), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age="+jwksMaxAgeInSec)
+	utilhttp.WriteResponse(w, data, http.StatusOK)
+}
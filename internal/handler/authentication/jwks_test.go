@@ -0,0 +1,76 @@
+package authentication
+
+import (
+	"gilsaputro/dating-apps/internal/service/authentication/mock"
+	"gilsaputro/dating-apps/pkg/token"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestAuthenticationHandler_JWKSHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mService := mock.NewMockAuthenticationServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantBody string
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				mService.EXPECT().GetJWKS().Return(token.JWKS{
+					Keys: []token.JWK{
+						{
+							KeyType:   "OKP",
+							KeyID:     "key-1",
+							Use:       "sig",
+							Algorithm: token.AlgorithmEdDSA,
+							Curve:     "Ed25519",
+							X:         "abc",
+						},
+					},
+				})
+			},
+			wantBody: `{"keys":[{"kty":"OKP","kid":"key-1","use":"sig","alg":"EdDSA","crv":"Ed25519","x":"abc"}]}`,
+		},
+		{
+			name: "success without key flow",
+			mockFunc: func() {
+				mService.EXPECT().GetJWKS().Return(token.JWKS{Keys: []token.JWK{}})
+			},
+			wantBody: `{"keys":[]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			handler := NewAuthenticationHandler(mService)
+			r := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+			w := httptest.NewRecorder()
+			handler.JWKSHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.wantBody {
+				t.Fatalf("JWKSHandler body got =%s, want %s \n", string(resBody), tt.wantBody)
+			}
+
+			if result.StatusCode != http.StatusOK {
+				t.Fatalf("JWKSHandler status code got =%d, want %d \n", result.StatusCode, http.StatusOK)
+			}
+
+			if result.Header.Get("Cache-Control") != "public, max-age=300" {
+				t.Fatalf("JWKSHandler cache control got =%s \n", result.Header.Get("Cache-Control"))
+			}
+		})
+	}
+}
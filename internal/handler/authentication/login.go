@@ -9,7 +9,9 @@ import (
 	"gilsaputro/dating-apps/internal/service/user"
 	"io/ioutil"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -18,11 +20,17 @@ import (
 type LoginUserRequest struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+	// Device is optional name of the device shown in the session list
+	Device string `json:"device"`
 }
 
 // LoginUserResponse is list response parameter for Login Api
 type LoginUserResponse struct {
-	Token string `json:"token"`
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	// ChallengeToken is returned instead of the token when the two factor code must be submitted to /v1/login/2fa
+	ChallengeToken    string `json:"challenge_token,omitempty"`
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty"`
 }
 
 // LoginUserHandler is func handler for login
@@ -74,12 +82,15 @@ func (h *AuthenticationHandler) LoginUserHandler(w http.ResponseWriter, r *http.
 	}
 
 	errChan := make(chan error, 1)
-	var token string
+	var result authentication.LoginServiceInfo
 	go func(ctx context.Context) {
-		token, err = h.service.Login(
+		result, err = h.service.Login(
 			authentication.LoginServiceRequest{
-				Username: body.Username,
-				Password: body.Password,
+				Username:  body.Username,
+				Password:  body.Password,
+				ClientIP:  utilhttp.GetClientIP(r),
+				Device:    body.Device,
+				UserAgent: r.UserAgent(),
 			})
 		errChan <- err
 	}(ctx)
@@ -91,7 +102,11 @@ func (h *AuthenticationHandler) LoginUserHandler(w http.ResponseWriter, r *http.
 		return
 	case err = <-errChan:
 		if err != nil {
-			if err == user.ErrUserNameNotExists || err == user.ErrPasswordIsIncorrect || strings.Contains(err.Error(), "not found") {
+			if err == authentication.ErrLoginLocked {
+				code = http.StatusTooManyRequests
+				retryAfter := int(math.Ceil(result.RetryAfter.Seconds()))
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			} else if err == user.ErrUserNameNotExists || err == user.ErrPasswordIsIncorrect || strings.Contains(err.Error(), "not found") {
 				code = http.StatusNotFound
 				err = fmt.Errorf("Invalid Username or Password")
 			} else {
@@ -101,13 +116,16 @@ func (h *AuthenticationHandler) LoginUserHandler(w http.ResponseWriter, r *http.
 		}
 	}
 
-	response = mapResponseLogin(token)
+	response = mapResponseLogin(result)
 }
 
-func mapResponseLogin(result string) utilhttp.StandardResponse {
+func mapResponseLogin(result authentication.LoginServiceInfo) utilhttp.StandardResponse {
 	var res utilhttp.StandardResponse
 	data := LoginUserResponse{
-		Token: result,
+		Token:             result.Token,
+		RefreshToken:      result.RefreshToken,
+		ChallengeToken:    result.ChallengeToken,
+		TwoFactorRequired: len(result.ChallengeToken) > 0,
 	}
 	res.Data = data
 	return res
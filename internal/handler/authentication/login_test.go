@@ -11,6 +11,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 )
@@ -24,8 +25,9 @@ func TestUserHandler_LoginUserHandler(t *testing.T) {
 		timeout int
 	}
 	type want struct {
-		body string
-		code int
+		body       string
+		code       int
+		retryAfter string
 	}
 	tests := []struct {
 		name        string
@@ -36,6 +38,35 @@ func TestUserHandler_LoginUserHandler(t *testing.T) {
 	}{
 		{
 			name: "success flow",
+			args: args{
+				body: `{
+					"username": "abc",
+					"password": "pas1",
+					"device": "iphone"
+				}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().Login(authentication.LoginServiceRequest{
+					Username: "abc",
+					Password: "pas1",
+					ClientIP: "192.0.2.1",
+					Device:   "iphone",
+				}).Return(authentication.LoginServiceInfo{
+					Token:        "new_token",
+					RefreshToken: "new_refresh_token",
+				}, nil)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"token":"new_token","refresh_token":"new_refresh_token"},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "success two factor required flow",
 			args: args{
 				body: `{
 					"username": "abc",
@@ -47,14 +78,44 @@ func TestUserHandler_LoginUserHandler(t *testing.T) {
 				mService.EXPECT().Login(authentication.LoginServiceRequest{
 					Username: "abc",
 					Password: "pas1",
-				}).Return("new_token", nil)
+					ClientIP: "192.0.2.1",
+				}).Return(authentication.LoginServiceInfo{
+					ChallengeToken: "challenge",
+				}, nil)
 			},
 			mockContext: func() (context.Context, func()) {
 				return context.Background(), func() {}
 			},
 			want: want{
 				code: 200,
-				body: `{"data":{"token":"new_token"},"code":200,"message":"success"}`,
+				body: `{"data":{"challenge_token":"challenge","two_factor_required":true},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error login is locked flow",
+			args: args{
+				body: `{
+					"username": "abc",
+					"password": "pas1"
+				}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().Login(authentication.LoginServiceRequest{
+					Username: "abc",
+					Password: "pas1",
+					ClientIP: "192.0.2.1",
+				}).Return(authentication.LoginServiceInfo{
+					RetryAfter: 29500 * time.Millisecond,
+				}, authentication.ErrLoginLocked)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code:       429,
+				body:       `{"code":429,"message":"too many failed login attempts, please try again later"}`,
+				retryAfter: "30",
 			},
 		},
 		{
@@ -70,7 +131,8 @@ func TestUserHandler_LoginUserHandler(t *testing.T) {
 				mService.EXPECT().Login(authentication.LoginServiceRequest{
 					Username: "abc",
 					Password: "pas1",
-				}).Return("new_token", fmt.Errorf("some error"))
+					ClientIP: "192.0.2.1",
+				}).Return(authentication.LoginServiceInfo{}, fmt.Errorf("some error"))
 			},
 			mockContext: func() (context.Context, func()) {
 				return context.Background(), func() {}
@@ -93,7 +155,8 @@ func TestUserHandler_LoginUserHandler(t *testing.T) {
 				mService.EXPECT().Login(authentication.LoginServiceRequest{
 					Username: "abc",
 					Password: "pas1",
-				}).Return("", user.ErrUserNameNotExists)
+					ClientIP: "192.0.2.1",
+				}).Return(authentication.LoginServiceInfo{}, user.ErrUserNameNotExists)
 			},
 			mockContext: func() (context.Context, func()) {
 				return context.Background(), func() {}
@@ -166,6 +229,10 @@ func TestUserHandler_LoginUserHandler(t *testing.T) {
 			if result.StatusCode != tt.want.code {
 				t.Fatalf("GetStatHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
 			}
+
+			if got := result.Header.Get("Retry-After"); got != tt.want.retryAfter {
+				t.Fatalf("LoginUserHandler retry after got =%s, want %s \n", got, tt.want.retryAfter)
+			}
 		})
 	}
 }
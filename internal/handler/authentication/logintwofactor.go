@@ -0,0 +1,102 @@
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/authentication"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+)
+
+// LoginTwoFactorRequest is list request parameter for Login Two Factor Api
+type LoginTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	// Code is from the authenticator app or one of the recovery code
+	Code string `json:"code"`
+	// Device is optional name of the device shown in the session list
+	Device string `json:"device"`
+}
+
+// LoginTwoFactorHandler is func handler for complete the login with two factor code
+func (h *AuthenticationHandler) LoginTwoFactorHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		response.Code = code
+		if err == nil {
+			response.Message = "success"
+		} else {
+			response.Message = err.Error()
+		}
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[LoginTwoFactorHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	var body LoginTwoFactorRequest
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Bad Request")
+		return
+	}
+
+	err = json.Unmarshal(data, &body)
+	if err != nil {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Bad Request")
+		return
+	}
+
+	// checking valid body
+	if len(body.ChallengeToken) < 1 || len(body.Code) < 1 {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Invalid Parameter Request")
+		return
+	}
+
+	errChan := make(chan error, 1)
+	var result authentication.LoginServiceInfo
+	go func(ctx context.Context) {
+		result, err = h.service.LoginTwoFactor(authentication.LoginTwoFactorServiceRequest{
+			ChallengeToken: body.ChallengeToken,
+			Code:           body.Code,
+			Device:         body.Device,
+			ClientIP:       utilhttp.GetClientIP(r),
+			UserAgent:      r.UserAgent(),
+		})
+		errChan <- err
+	}(ctx)
+
+	select {
+	case <-ctx.Done():
+		code = http.StatusGatewayTimeout
+		err = fmt.Errorf("Timeout")
+		return
+	case err = <-errChan:
+		if err != nil {
+			if err == authentication.ErrInvalidChallengeToken || err == authentication.ErrInvalidTwoFactorCode {
+				code = http.StatusUnauthorized
+			} else {
+				code = http.StatusInternalServerError
+			}
+			return
+		}
+	}
+
+	response = mapResponseLogin(result)
+}
@@ -0,0 +1,163 @@
+package authentication
+
+import (
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/authentication"
+	"gilsaputro/dating-apps/internal/service/authentication/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestAuthenticationHandler_LoginTwoFactorHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mService := mock.NewMockAuthenticationServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		body    string
+		timeout int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				body:    `{"challenge_token": "challenge", "code": "123456", "device": "iphone"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().LoginTwoFactor(authentication.LoginTwoFactorServiceRequest{
+					ChallengeToken: "challenge",
+					Code:           "123456",
+					Device:         "iphone",
+					ClientIP:       "192.0.2.1",
+					UserAgent:      "agent",
+				}).Return(authentication.LoginServiceInfo{
+					Token:        "new_token",
+					RefreshToken: "new_refresh_token",
+				}, nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"token":"new_token","refresh_token":"new_refresh_token"},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error invalid code flow",
+			args: args{
+				body:    `{"challenge_token": "challenge", "code": "123456"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().LoginTwoFactor(authentication.LoginTwoFactorServiceRequest{
+					ChallengeToken: "challenge",
+					Code:           "123456",
+					ClientIP:       "192.0.2.1",
+					UserAgent:      "agent",
+				}).Return(authentication.LoginServiceInfo{}, authentication.ErrInvalidTwoFactorCode)
+			},
+			want: want{
+				code: 401,
+				body: `{"code":401,"message":"two factor code is invalid"}`,
+			},
+		},
+		{
+			name: "error invalid challenge flow",
+			args: args{
+				body:    `{"challenge_token": "challenge", "code": "123456"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().LoginTwoFactor(authentication.LoginTwoFactorServiceRequest{
+					ChallengeToken: "challenge",
+					Code:           "123456",
+					ClientIP:       "192.0.2.1",
+					UserAgent:      "agent",
+				}).Return(authentication.LoginServiceInfo{}, authentication.ErrInvalidChallengeToken)
+			},
+			want: want{
+				code: 401,
+				body: `{"code":401,"message":"two factor challenge is invalid or expired"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				body:    `{"challenge_token": "challenge", "code": "123456"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().LoginTwoFactor(authentication.LoginTwoFactorServiceRequest{
+					ChallengeToken: "challenge",
+					Code:           "123456",
+					ClientIP:       "192.0.2.1",
+					UserAgent:      "agent",
+				}).Return(authentication.LoginServiceInfo{}, fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error invalid parameter flow",
+			args: args{
+				body:    `{"challenge_token": "challenge"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error bad request flow",
+			args: args{
+				body:    `{"challenge_token": "challenge"`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Bad Request"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			handler := NewAuthenticationHandler(mService, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodPost, "/v1/login/2fa", strings.NewReader(tt.args.body))
+			r.Header.Set("User-Agent", "agent")
+			w := httptest.NewRecorder()
+			handler.LoginTwoFactorHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("LoginTwoFactorHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("LoginTwoFactorHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/authentication"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// LogoutUserRequest is list request parameter for Logout Api, the refresh token is optional
+type LogoutUserRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// LogoutUserHandler is func handler for logout and revoke the token of the user
+func (h *AuthenticationHandler) LogoutUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		response.Code = code
+		if err == nil {
+			response.Message = "success"
+		} else {
+			response.Message = err.Error()
+		}
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[LogoutUserHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	var body LogoutUserRequest
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Bad Request")
+		return
+	}
+
+	if len(data) > 0 {
+		err = json.Unmarshal(data, &body)
+		if err != nil {
+			code = http.StatusBadRequest
+			err = fmt.Errorf("Bad Request")
+			return
+		}
+	}
+
+	// the access token is already validated by the middleware
+	accessToken := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	errChan := make(chan error, 1)
+	go func(ctx context.Context) {
+		err = h.service.Logout(
+			authentication.LogoutServiceRequest{
+				AccessToken:  accessToken,
+				RefreshToken: body.RefreshToken,
+			})
+		errChan <- err
+	}(ctx)
+
+	select {
+	case <-ctx.Done():
+		code = http.StatusGatewayTimeout
+		err = fmt.Errorf("Timeout")
+		return
+	case err = <-errChan:
+		if err != nil {
+			if err == authentication.ErrUnauthorized || err == authentication.ErrInvalidRefreshToken {
+				code = http.StatusUnauthorized
+			} else {
+				code = http.StatusInternalServerError
+			}
+			return
+		}
+	}
+}
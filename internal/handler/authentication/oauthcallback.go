@@ -0,0 +1,89 @@
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/authentication"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// OAuthCallbackHandler is func handler for complete the social login when the provider redirect the user back,
+// the code and state is read from the query or the form body for the provider that use form_post (apple)
+func (h *AuthenticationHandler) OAuthCallbackHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		response.Code = code
+		if err == nil {
+			response.Message = "success"
+		} else {
+			response.Message = err.Error()
+		}
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[OAuthCallbackHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	provider := mux.Vars(r)["provider"]
+	authCode := r.FormValue("code")
+	state := r.FormValue("state")
+
+	// checking valid parameter, the code is empty when the user cancel the login on the provider
+	if len(provider) < 1 || len(authCode) < 1 || len(state) < 1 {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Invalid Parameter Request")
+		return
+	}
+
+	errChan := make(chan error, 1)
+	var result authentication.LoginServiceInfo
+	go func(ctx context.Context) {
+		result, err = h.service.LoginOAuth(authentication.LoginOAuthServiceRequest{
+			Provider:  provider,
+			State:     state,
+			Code:      authCode,
+			ClientIP:  utilhttp.GetClientIP(r),
+			UserAgent: r.UserAgent(),
+		})
+		errChan <- err
+	}(ctx)
+
+	select {
+	case <-ctx.Done():
+		code = http.StatusGatewayTimeout
+		err = fmt.Errorf("Timeout")
+		return
+	case err = <-errChan:
+		if err != nil {
+			switch err {
+			case authentication.ErrUnknownOAuthProvider:
+				code = http.StatusNotFound
+			case authentication.ErrInvalidOAuthState, authentication.ErrInvalidOAuthCode:
+				code = http.StatusUnauthorized
+			case authentication.ErrOAuthAccountExists:
+				code = http.StatusConflict
+			default:
+				code = http.StatusInternalServerError
+			}
+			return
+		}
+	}
+
+	response = mapResponseLogin(result)
+}
@@ -0,0 +1,214 @@
+package authentication
+
+import (
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/authentication"
+	"gilsaputro/dating-apps/internal/service/authentication/mock"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+func TestAuthenticationHandler_OAuthCallbackHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mService := mock.NewMockAuthenticationServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		method   string
+		provider string
+		query    string
+		form     string
+		timeout  int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	request := authentication.LoginOAuthServiceRequest{
+		Provider:  "google",
+		State:     "state",
+		Code:      "code",
+		ClientIP:  "192.0.2.1",
+		UserAgent: "agent",
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success redirect flow",
+			args: args{
+				method:   http.MethodGet,
+				provider: "google",
+				query:    "?code=code&state=state",
+				timeout:  5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().LoginOAuth(request).Return(authentication.LoginServiceInfo{
+					Token:        "new_token",
+					RefreshToken: "new_refresh_token",
+				}, nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"token":"new_token","refresh_token":"new_refresh_token"},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "success form post flow",
+			args: args{
+				method:   http.MethodPost,
+				provider: "apple",
+				form:     "code=code&state=state",
+				timeout:  5,
+			},
+			mockFunc: func() {
+				appleRequest := request
+				appleRequest.Provider = "apple"
+				mService.EXPECT().LoginOAuth(appleRequest).Return(authentication.LoginServiceInfo{
+					ChallengeToken: "challenge",
+				}, nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"challenge_token":"challenge","two_factor_required":true},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error invalid state flow",
+			args: args{
+				method:   http.MethodGet,
+				provider: "google",
+				query:    "?code=code&state=state",
+				timeout:  5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().LoginOAuth(request).Return(authentication.LoginServiceInfo{}, authentication.ErrInvalidOAuthState)
+			},
+			want: want{
+				code: 401,
+				body: `{"code":401,"message":"social login state is invalid or expired"}`,
+			},
+		},
+		{
+			name: "error invalid code flow",
+			args: args{
+				method:   http.MethodGet,
+				provider: "google",
+				query:    "?code=code&state=state",
+				timeout:  5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().LoginOAuth(request).Return(authentication.LoginServiceInfo{}, authentication.ErrInvalidOAuthCode)
+			},
+			want: want{
+				code: 401,
+				body: `{"code":401,"message":"social login code is invalid"}`,
+			},
+		},
+		{
+			name: "error account exists flow",
+			args: args{
+				method:   http.MethodGet,
+				provider: "google",
+				query:    "?code=code&state=state",
+				timeout:  5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().LoginOAuth(request).Return(authentication.LoginServiceInfo{}, authentication.ErrOAuthAccountExists)
+			},
+			want: want{
+				code: 409,
+				body: `{"code":409,"message":"email is already registered, please login with password"}`,
+			},
+		},
+		{
+			name: "error unknown provider flow",
+			args: args{
+				method:   http.MethodGet,
+				provider: "facebook",
+				query:    "?code=code&state=state",
+				timeout:  5,
+			},
+			mockFunc: func() {
+				unknownRequest := request
+				unknownRequest.Provider = "facebook"
+				mService.EXPECT().LoginOAuth(unknownRequest).Return(authentication.LoginServiceInfo{}, authentication.ErrUnknownOAuthProvider)
+			},
+			want: want{
+				code: 404,
+				body: `{"code":404,"message":"social login provider is not supported"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				method:   http.MethodGet,
+				provider: "google",
+				query:    "?code=code&state=state",
+				timeout:  5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().LoginOAuth(request).Return(authentication.LoginServiceInfo{}, fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error cancelled by user flow",
+			args: args{
+				method:   http.MethodGet,
+				provider: "google",
+				query:    "?error=access_denied&state=state",
+				timeout:  5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			handler := NewAuthenticationHandler(mService, WithTimeoutOptions(tt.args.timeout))
+			var body io.Reader
+			if len(tt.args.form) > 0 {
+				body = strings.NewReader(tt.args.form)
+			}
+			r := httptest.NewRequest(tt.args.method, "/v1/oauth/"+tt.args.provider+"/callback"+tt.args.query, body)
+			if len(tt.args.form) > 0 {
+				r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+			r.Header.Set("User-Agent", "agent")
+			r = mux.SetURLVars(r, map[string]string{"provider": tt.args.provider})
+			w := httptest.NewRecorder()
+			handler.OAuthCallbackHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("OAuthCallbackHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("OAuthCallbackHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
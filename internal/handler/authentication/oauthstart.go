@@ -0,0 +1,82 @@
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/authentication"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// OAuthStartResponse is list response parameter for OAuth Start Api
+type OAuthStartResponse struct {
+	AuthURL string `json:"auth_url"`
+}
+
+// OAuthStartHandler is func handler for get the social login url of the provider
+func (h *AuthenticationHandler) OAuthStartHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		response.Code = code
+		if err == nil {
+			response.Message = "success"
+		} else {
+			response.Message = err.Error()
+		}
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[OAuthStartHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	provider := mux.Vars(r)["provider"]
+	if len(provider) < 1 {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Invalid Parameter Request")
+		return
+	}
+
+	errChan := make(chan error, 1)
+	var result authentication.StartOAuthServiceInfo
+	go func(ctx context.Context) {
+		result, err = h.service.StartOAuth(authentication.StartOAuthServiceRequest{
+			Provider: provider,
+		})
+		errChan <- err
+	}(ctx)
+
+	select {
+	case <-ctx.Done():
+		code = http.StatusGatewayTimeout
+		err = fmt.Errorf("Timeout")
+		return
+	case err = <-errChan:
+		if err != nil {
+			if err == authentication.ErrUnknownOAuthProvider {
+				code = http.StatusNotFound
+			} else {
+				code = http.StatusInternalServerError
+			}
+			return
+		}
+	}
+
+	response.Data = OAuthStartResponse{
+		AuthURL: result.AuthURL,
+	}
+}
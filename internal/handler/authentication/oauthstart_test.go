@@ -0,0 +1,120 @@
+package authentication
+
+import (
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/authentication"
+	"gilsaputro/dating-apps/internal/service/authentication/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+func TestAuthenticationHandler_OAuthStartHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mService := mock.NewMockAuthenticationServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		provider string
+		timeout  int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				provider: "google",
+				timeout:  5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().StartOAuth(authentication.StartOAuthServiceRequest{
+					Provider: "google",
+				}).Return(authentication.StartOAuthServiceInfo{
+					AuthURL: "https://accounts.google.com/o/oauth2/v2/auth?state=abc",
+				}, nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"auth_url":"https://accounts.google.com/o/oauth2/v2/auth?state=abc"},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error unknown provider flow",
+			args: args{
+				provider: "facebook",
+				timeout:  5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().StartOAuth(authentication.StartOAuthServiceRequest{
+					Provider: "facebook",
+				}).Return(authentication.StartOAuthServiceInfo{}, authentication.ErrUnknownOAuthProvider)
+			},
+			want: want{
+				code: 404,
+				body: `{"code":404,"message":"social login provider is not supported"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				provider: "google",
+				timeout:  5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().StartOAuth(authentication.StartOAuthServiceRequest{
+					Provider: "google",
+				}).Return(authentication.StartOAuthServiceInfo{}, fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error invalid parameter flow",
+			args: args{
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			handler := NewAuthenticationHandler(mService, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodGet, "/v1/oauth/"+tt.args.provider+"/start", nil)
+			r = mux.SetURLVars(r, map[string]string{"provider": tt.args.provider})
+			w := httptest.NewRecorder()
+			handler.OAuthStartHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("OAuthStartHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("OAuthStartHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
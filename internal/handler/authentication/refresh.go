@@ -0,0 +1,95 @@
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/authentication"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+)
+
+// RefreshTokenRequest is list request parameter for Refresh Token Api
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RefreshTokenHandler is func handler for rotate the refresh token and issue new access token
+func (h *AuthenticationHandler) RefreshTokenHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		response.Code = code
+		if err == nil {
+			response.Message = "success"
+		} else {
+			response.Message = err.Error()
+		}
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[RefreshTokenHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	var body RefreshTokenRequest
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Bad Request")
+		return
+	}
+
+	err = json.Unmarshal(data, &body)
+	if err != nil {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Bad Request")
+		return
+	}
+
+	// checking valid body
+	if len(body.RefreshToken) < 1 {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Invalid Parameter Request")
+		return
+	}
+
+	errChan := make(chan error, 1)
+	var result authentication.LoginServiceInfo
+	go func(ctx context.Context) {
+		result, err = h.service.RefreshToken(
+			authentication.RefreshTokenServiceRequest{
+				RefreshToken: body.RefreshToken,
+			})
+		errChan <- err
+	}(ctx)
+
+	select {
+	case <-ctx.Done():
+		code = http.StatusGatewayTimeout
+		err = fmt.Errorf("Timeout")
+		return
+	case err = <-errChan:
+		if err != nil {
+			if err == authentication.ErrInvalidRefreshToken {
+				code = http.StatusUnauthorized
+			} else {
+				code = http.StatusInternalServerError
+			}
+			return
+		}
+	}
+
+	response = mapResponseLogin(result)
+}
@@ -0,0 +1,135 @@
+package authentication
+
+import (
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/authentication"
+	"gilsaputro/dating-apps/internal/service/authentication/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestAuthenticationHandler_RefreshTokenHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mService := mock.NewMockAuthenticationServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		body    string
+		timeout int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				body:    `{"refresh_token": "refresh_token"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().RefreshToken(authentication.RefreshTokenServiceRequest{
+					RefreshToken: "refresh_token",
+				}).Return(authentication.LoginServiceInfo{
+					Token:        "new_token",
+					RefreshToken: "new_refresh_token",
+				}, nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"token":"new_token","refresh_token":"new_refresh_token"},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error invalid refresh token flow",
+			args: args{
+				body:    `{"refresh_token": "refresh_token"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().RefreshToken(authentication.RefreshTokenServiceRequest{
+					RefreshToken: "refresh_token",
+				}).Return(authentication.LoginServiceInfo{}, authentication.ErrInvalidRefreshToken)
+			},
+			want: want{
+				code: 401,
+				body: `{"code":401,"message":"refresh token is invalid"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				body:    `{"refresh_token": "refresh_token"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().RefreshToken(authentication.RefreshTokenServiceRequest{
+					RefreshToken: "refresh_token",
+				}).Return(authentication.LoginServiceInfo{}, fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error on empty refresh token",
+			args: args{
+				body:    `{"refresh_token": ""}`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error on invalid body value",
+			args: args{
+				body:    `{"refresh_token": "refresh_token",}`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Bad Request"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			handler := NewAuthenticationHandler(mService, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodPost, "/v1/token/refresh", strings.NewReader(tt.args.body))
+			r = r.WithContext(context.Background())
+			w := httptest.NewRecorder()
+			handler.RefreshTokenHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("RefreshTokenHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("RefreshTokenHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
@@ -90,6 +90,8 @@ func (h *AuthenticationHandler) RegisterUserHandler(w http.ResponseWriter, r *ht
 		if err != nil {
 			if strings.Contains(err.Error(), user.ErrUserNameAlreadyExists.Error()) {
 				code = http.StatusConflict
+			} else if err == authentication.ErrInvalidEmail {
+				code = http.StatusBadRequest
 			} else {
 				code = http.StatusInternalServerError
 			}
@@ -118,6 +118,34 @@ func TestUserHandler_RegisterUserHandler(t *testing.T) {
 				body: `{"code":409,"message":"username already exists"}`,
 			},
 		},
+		{
+			name: "error on service flow invalid email",
+			args: args{
+				body: `{
+					"username": "abc",
+					"email": "email",
+					"password": "pas1",
+					"fullname": "fullname"
+				}`,
+				timeout: 5,
+				token:   "token_baru",
+			},
+			mockFunc: func() {
+				mService.EXPECT().Register(authentication.RegisterServiceRequest{
+					Username: "abc",
+					Password: "pas1",
+					Fullname: "fullname",
+					Email:    "email",
+				}).Return(authentication.ErrInvalidEmail)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"email is invalid"}`,
+			},
+		},
 		{
 			name: "error on invalid username value",
 			args: args{
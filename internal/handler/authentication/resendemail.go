@@ -0,0 +1,72 @@
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/authentication"
+	"log"
+	"net/http"
+	"time"
+)
+
+// ResendEmailVerificationHandler is func handler for send new email verification link to the login user
+func (h *AuthenticationHandler) ResendEmailVerificationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		response.Code = code
+		if err == nil {
+			response.Message = "success"
+		} else {
+			response.Message = err.Error()
+		}
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[ResendEmailVerificationHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	userID, ok := r.Context().Value("id").(int)
+	if !ok {
+		code = http.StatusInternalServerError
+		err = fmt.Errorf("Internal Server Error")
+		return
+	}
+
+	errChan := make(chan error, 1)
+	go func(ctx context.Context) {
+		err = h.service.ResendEmailVerification(authentication.ResendEmailVerificationServiceRequest{
+			UserID: userID,
+		})
+		errChan <- err
+	}(ctx)
+
+	select {
+	case <-ctx.Done():
+		code = http.StatusGatewayTimeout
+		err = fmt.Errorf("Timeout")
+		return
+	case err = <-errChan:
+		if err != nil {
+			if err == authentication.ErrEmailAlreadyVerified {
+				code = http.StatusConflict
+			} else if err == authentication.ErrInvalidEmail {
+				code = http.StatusBadRequest
+			} else {
+				code = http.StatusInternalServerError
+			}
+			return
+		}
+	}
+}
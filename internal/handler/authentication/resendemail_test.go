@@ -0,0 +1,138 @@
+package authentication
+
+import (
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/authentication"
+	"gilsaputro/dating-apps/internal/service/authentication/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestAuthenticationHandler_ResendEmailVerificationHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mService := mock.NewMockAuthenticationServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		userID  int
+		timeout int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				userID:  1,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().ResendEmailVerification(authentication.ResendEmailVerificationServiceRequest{
+					UserID: 1,
+				}).Return(nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error already verified flow",
+			args: args{
+				userID:  1,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().ResendEmailVerification(authentication.ResendEmailVerificationServiceRequest{
+					UserID: 1,
+				}).Return(authentication.ErrEmailAlreadyVerified)
+			},
+			want: want{
+				code: 409,
+				body: `{"code":409,"message":"email is already verified"}`,
+			},
+		},
+		{
+			name: "error invalid email flow",
+			args: args{
+				userID:  1,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().ResendEmailVerification(authentication.ResendEmailVerificationServiceRequest{
+					UserID: 1,
+				}).Return(authentication.ErrInvalidEmail)
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"email is invalid"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				userID:  1,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().ResendEmailVerification(authentication.ResendEmailVerificationServiceRequest{
+					UserID: 1,
+				}).Return(fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error missing user id flow",
+			args: args{
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			handler := NewAuthenticationHandler(mService, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodPost, "/v1/verify-email/resend", nil)
+			ctx := context.Background()
+			if tt.args.userID > 0 {
+				ctx = context.WithValue(ctx, "id", tt.args.userID)
+			}
+			r = r.WithContext(ctx)
+			w := httptest.NewRecorder()
+			handler.ResendEmailVerificationHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("ResendEmailVerificationHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("ResendEmailVerificationHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
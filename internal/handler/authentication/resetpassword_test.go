@@ -0,0 +1,136 @@
+package authentication
+
+import (
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/authentication"
+	"gilsaputro/dating-apps/internal/service/authentication/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestAuthenticationHandler_ResetPasswordHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mService := mock.NewMockAuthenticationServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		body    string
+		timeout int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				body:    `{"username": "abc", "code": "123456", "new_password": "pass"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().ResetPassword(authentication.ResetPasswordServiceRequest{
+					Username:    "abc",
+					Code:        "123456",
+					NewPassword: "pass",
+				}).Return(nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error invalid code flow",
+			args: args{
+				body:    `{"username": "abc", "code": "123456", "new_password": "pass"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().ResetPassword(authentication.ResetPasswordServiceRequest{
+					Username:    "abc",
+					Code:        "123456",
+					NewPassword: "pass",
+				}).Return(authentication.ErrInvalidResetCode)
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"reset code is invalid or expired"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				body:    `{"username": "abc", "code": "123456", "new_password": "pass"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().ResetPassword(authentication.ResetPasswordServiceRequest{
+					Username:    "abc",
+					Code:        "123456",
+					NewPassword: "pass",
+				}).Return(fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error invalid parameter flow",
+			args: args{
+				body:    `{"username": "abc", "code": "", "new_password": "pass"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error bad request flow",
+			args: args{
+				body:    `{"username": "abc"`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Bad Request"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			handler := NewAuthenticationHandler(mService, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodPost, "/v1/password/reset", strings.NewReader(tt.args.body))
+			w := httptest.NewRecorder()
+			handler.ResetPasswordHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("ResetPasswordHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("ResetPasswordHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
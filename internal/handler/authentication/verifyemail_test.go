@@ -0,0 +1,119 @@
+package authentication
+
+import (
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/authentication"
+	"gilsaputro/dating-apps/internal/service/authentication/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestAuthenticationHandler_VerifyEmailHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mService := mock.NewMockAuthenticationServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		query   string
+		timeout int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				query:   "?token=abc",
+				timeout: 5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().VerifyEmail(authentication.VerifyEmailServiceRequest{
+					Token: "abc",
+				}).Return(nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error invalid token flow",
+			args: args{
+				query:   "?token=abc",
+				timeout: 5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().VerifyEmail(authentication.VerifyEmailServiceRequest{
+					Token: "abc",
+				}).Return(authentication.ErrInvalidEmailToken)
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"email verification token is invalid or expired"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				query:   "?token=abc",
+				timeout: 5,
+			},
+			mockFunc: func() {
+				mService.EXPECT().VerifyEmail(authentication.VerifyEmailServiceRequest{
+					Token: "abc",
+				}).Return(fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error on empty token",
+			args: args{
+				query:   "",
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			handler := NewAuthenticationHandler(mService, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodGet, "/v1/verify-email"+tt.args.query, nil)
+			r = r.WithContext(context.Background())
+			w := httptest.NewRecorder()
+			handler.VerifyEmailHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("VerifyEmailHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("VerifyEmailHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package chat
+
+import (
+	"gilsaputro/dating-apps/internal/service/chat"
+)
+
+// ChatHandler list dependencies for chat handler
+type ChatHandler struct {
+	service      chat.ChatServiceMethod
+	timeoutInSec int
+}
+
+// Option set options for http handler config
+type Option func(*ChatHandler)
+
+const (
+	defaultTimeout = 5
+)
+
+// NewChatHandler is func to create http chat handler
+func NewChatHandler(service chat.ChatServiceMethod, options ...Option) *ChatHandler {
+	handler := &ChatHandler{
+		service:      service,
+		timeoutInSec: defaultTimeout,
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(handler)
+	}
+
+	return handler
+}
+
+// WithTimeoutOptions is func to set timeout config into handler
+func WithTimeoutOptions(timeoutinsec int) Option {
+	return Option(
+		func(h *ChatHandler) {
+			if timeoutinsec <= 0 {
+				timeoutinsec = defaultTimeout
+			}
+			h.timeoutInSec = timeoutinsec
+		})
+}
@@ -0,0 +1,97 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/chat"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// MessageListHandler is func handler for get the conversation with the matched partner
+func (h *ChatHandler) MessageListHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		response.Code = code
+		if err == nil {
+			response.Message = "success"
+		} else {
+			response.Message = err.Error()
+		}
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[MessageListHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	partnerID, err := strconv.Atoi(mux.Vars(r)["partnerID"])
+	if err != nil || partnerID <= 0 {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Invalid Parameter Request")
+		return
+	}
+
+	limit, err := utilhttp.ParseQueryInt(r, "limit")
+	if err != nil {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Invalid Parameter Request")
+		return
+	}
+
+	var userID int
+	var ok bool
+	userID, ok = r.Context().Value("id").(int)
+	if !ok {
+		code = http.StatusInternalServerError
+		err = fmt.Errorf("Internal Server Error")
+		return
+	}
+
+	errChan := make(chan error, 1)
+	var result chat.MessageListServiceInfo
+	go func(ctx context.Context) {
+		result, err = h.service.GetListMessage(chat.MessageListServiceRequest{
+			UserID:    userID,
+			PartnerID: partnerID,
+			Cursor:    r.URL.Query().Get("cursor"),
+			Limit:     limit,
+		})
+		errChan <- err
+	}(ctx)
+
+	select {
+	case <-ctx.Done():
+		code = http.StatusGatewayTimeout
+		err = fmt.Errorf("Timeout")
+		return
+	case err = <-errChan:
+		if err != nil {
+			if err == chat.ErrInvalidMessageCursor {
+				code = http.StatusBadRequest
+			} else if err == chat.ErrMatchNotFound {
+				code = http.StatusForbidden
+			} else {
+				code = http.StatusInternalServerError
+			}
+			return
+		}
+	}
+
+	response = mapMessageListResponse(result)
+}
@@ -0,0 +1,214 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/chat"
+	"gilsaputro/dating-apps/internal/service/chat/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+func TestChatHandler_MessageListHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockChatServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		userID    int
+		partnerID string
+		query     string
+		timeout   int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				userID:    1,
+				partnerID: "2",
+				query:     "?cursor=abc&limit=1",
+				timeout:   5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetListMessage(chat.MessageListServiceRequest{
+					UserID:    1,
+					PartnerID: 2,
+					Cursor:    "abc",
+					Limit:     1,
+				}).Return(chat.MessageListServiceInfo{
+					Messages: []chat.MessageServiceInfo{
+						{
+							MessageID:  3,
+							SenderID:   2,
+							ReceiverID: 1,
+							Content:    "hi",
+							IsRead:     true,
+							ReadDate:   "now",
+						},
+					},
+					NextCursor: "def",
+				}, nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"data":[{"id":3,"sender_id":2,"receiver_id":1,"content":"hi","is_read":true,"read_date":"now","created_date":""}],"code":200,"message":"success","next_cursor":"def"}`,
+			},
+		},
+		{
+			name: "success empty conversation flow",
+			args: args{
+				userID:    1,
+				partnerID: "2",
+				timeout:   5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetListMessage(chat.MessageListServiceRequest{
+					UserID:    1,
+					PartnerID: 2,
+				}).Return(chat.MessageListServiceInfo{
+					Messages: []chat.MessageServiceInfo{},
+				}, nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"data":[],"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error invalid cursor flow",
+			args: args{
+				userID:    1,
+				partnerID: "2",
+				query:     "?cursor=abc",
+				timeout:   5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetListMessage(chat.MessageListServiceRequest{
+					UserID:    1,
+					PartnerID: 2,
+					Cursor:    "abc",
+				}).Return(chat.MessageListServiceInfo{}, chat.ErrInvalidMessageCursor)
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"the message cursor is invalid"}`,
+			},
+		},
+		{
+			name: "error not matched flow",
+			args: args{
+				userID:    1,
+				partnerID: "2",
+				timeout:   5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetListMessage(chat.MessageListServiceRequest{
+					UserID:    1,
+					PartnerID: 2,
+				}).Return(chat.MessageListServiceInfo{}, chat.ErrMatchNotFound)
+			},
+			want: want{
+				code: 403,
+				body: `{"code":403,"message":"the user does not have match with the partner"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				userID:    1,
+				partnerID: "2",
+				timeout:   5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetListMessage(chat.MessageListServiceRequest{
+					UserID:    1,
+					PartnerID: 2,
+				}).Return(chat.MessageListServiceInfo{}, fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error invalid limit flow",
+			args: args{
+				userID:    1,
+				partnerID: "2",
+				query:     "?limit=abc",
+				timeout:   5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error invalid partner id",
+			args: args{
+				userID:    1,
+				partnerID: "abc",
+				timeout:   5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error missing user id",
+			args: args{
+				partnerID: "2",
+				timeout:   5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			defer mockCtrl.Finish()
+			handler := NewChatHandler(m, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodGet, "/v1/matches/"+tt.args.partnerID+"/messages"+tt.args.query, nil)
+			if tt.args.userID > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.userID))
+			}
+			r = mux.SetURLVars(r, map[string]string{"partnerID": tt.args.partnerID})
+			w := httptest.NewRecorder()
+			handler.MessageListHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("MessageListHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("MessageListHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/chat"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// SendMessageHandler is func handler for send message to the matched partner
+func (h *ChatHandler) SendMessageHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		response.Code = code
+		if err == nil {
+			response.Message = "success"
+		} else {
+			response.Message = err.Error()
+		}
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[SendMessageHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	partnerID, err := strconv.Atoi(mux.Vars(r)["partnerID"])
+	if err != nil || partnerID <= 0 {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Invalid Parameter Request")
+		return
+	}
+
+	var body SendMessageRequest
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Bad Request")
+		return
+	}
+
+	err = json.Unmarshal(data, &body)
+	if err != nil {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Bad Request")
+		return
+	}
+
+	var userID int
+	var ok bool
+	userID, ok = r.Context().Value("id").(int)
+	if !ok {
+		code = http.StatusInternalServerError
+		err = fmt.Errorf("Internal Server Error")
+		return
+	}
+
+	errChan := make(chan error, 1)
+	var result chat.MessageServiceInfo
+	go func(ctx context.Context) {
+		result, err = h.service.SendMessage(chat.SendMessageServiceRequest{
+			UserID:    userID,
+			PartnerID: partnerID,
+			Content:   body.Content,
+		})
+		errChan <- err
+	}(ctx)
+
+	select {
+	case <-ctx.Done():
+		code = http.StatusGatewayTimeout
+		err = fmt.Errorf("Timeout")
+		return
+	case err = <-errChan:
+		if err != nil {
+			if err == chat.ErrInvalidMessageContent {
+				code = http.StatusBadRequest
+			} else if err == chat.ErrMatchNotFound {
+				code = http.StatusForbidden
+			} else {
+				code = http.StatusInternalServerError
+			}
+			return
+		}
+	}
+
+	response.Data = mapMessageResponse(result)
+}
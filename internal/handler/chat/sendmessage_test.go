@@ -0,0 +1,192 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/chat"
+	"gilsaputro/dating-apps/internal/service/chat/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+func TestChatHandler_SendMessageHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockChatServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		userID    int
+		partnerID string
+		body      string
+		timeout   int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				userID:    1,
+				partnerID: "2",
+				body:      `{"content":"hello"}`,
+				timeout:   5,
+			},
+			mockFunc: func() {
+				m.EXPECT().SendMessage(chat.SendMessageServiceRequest{
+					UserID:    1,
+					PartnerID: 2,
+					Content:   "hello",
+				}).Return(chat.MessageServiceInfo{
+					MessageID:  3,
+					SenderID:   1,
+					ReceiverID: 2,
+					Content:    "hello",
+				}, nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"id":3,"sender_id":1,"receiver_id":2,"content":"hello","is_read":false,"created_date":""},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error invalid content flow",
+			args: args{
+				userID:    1,
+				partnerID: "2",
+				body:      `{"content":""}`,
+				timeout:   5,
+			},
+			mockFunc: func() {
+				m.EXPECT().SendMessage(chat.SendMessageServiceRequest{
+					UserID:    1,
+					PartnerID: 2,
+				}).Return(chat.MessageServiceInfo{}, chat.ErrInvalidMessageContent)
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"message content should not be empty and at most 1000 characters"}`,
+			},
+		},
+		{
+			name: "error not matched flow",
+			args: args{
+				userID:    1,
+				partnerID: "2",
+				body:      `{"content":"hello"}`,
+				timeout:   5,
+			},
+			mockFunc: func() {
+				m.EXPECT().SendMessage(chat.SendMessageServiceRequest{
+					UserID:    1,
+					PartnerID: 2,
+					Content:   "hello",
+				}).Return(chat.MessageServiceInfo{}, chat.ErrMatchNotFound)
+			},
+			want: want{
+				code: 403,
+				body: `{"code":403,"message":"the user does not have match with the partner"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				userID:    1,
+				partnerID: "2",
+				body:      `{"content":"hello"}`,
+				timeout:   5,
+			},
+			mockFunc: func() {
+				m.EXPECT().SendMessage(chat.SendMessageServiceRequest{
+					UserID:    1,
+					PartnerID: 2,
+					Content:   "hello",
+				}).Return(chat.MessageServiceInfo{}, fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error invalid body flow",
+			args: args{
+				userID:    1,
+				partnerID: "2",
+				body:      `{`,
+				timeout:   5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Bad Request"}`,
+			},
+		},
+		{
+			name: "error invalid partner id",
+			args: args{
+				userID:    1,
+				partnerID: "abc",
+				body:      `{"content":"hello"}`,
+				timeout:   5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error missing user id",
+			args: args{
+				partnerID: "2",
+				body:      `{"content":"hello"}`,
+				timeout:   5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			defer mockCtrl.Finish()
+			handler := NewChatHandler(m, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodPost, "/v1/matches/"+tt.args.partnerID+"/messages", strings.NewReader(tt.args.body))
+			if tt.args.userID > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.userID))
+			}
+			r = mux.SetURLVars(r, map[string]string{"partnerID": tt.args.partnerID})
+			w := httptest.NewRecorder()
+			handler.SendMessageHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("SendMessageHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("SendMessageHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package chat
+
+import (
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/chat"
+)
+
+// SendMessageRequest is list request parameter for Send Message Api
+type SendMessageRequest struct {
+	Content string `json:"content"`
+}
+
+// MessageResponse is list response parameter for a message
+type MessageResponse struct {
+	MessageID   int    `json:"id"`
+	SenderID    int    `json:"sender_id"`
+	ReceiverID  int    `json:"receiver_id"`
+	Content     string `json:"content"`
+	IsRead      bool   `json:"is_read"`
+	ReadDate    string `json:"read_date,omitempty"`
+	CreatedDate string `json:"created_date"`
+}
+
+func mapMessageResponse(result chat.MessageServiceInfo) MessageResponse {
+	return MessageResponse{
+		MessageID:   result.MessageID,
+		SenderID:    result.SenderID,
+		ReceiverID:  result.ReceiverID,
+		Content:     result.Content,
+		IsRead:      result.IsRead,
+		ReadDate:    result.ReadDate,
+		CreatedDate: result.CreatedDate,
+	}
+}
+
+func mapMessageListResponse(result chat.MessageListServiceInfo) utilhttp.StandardResponse {
+	var res utilhttp.StandardResponse
+	list := []MessageResponse{}
+	for _, data := range result.Messages {
+		list = append(list, mapMessageResponse(data))
+	}
+
+	res.Data = list
+	res.NextCursor = result.NextCursor
+	return res
+}
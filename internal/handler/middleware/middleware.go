@@ -2,24 +2,40 @@ package middleware
 
 import (
 	"context"
+	"fmt"
 	"gilsaputro/dating-apps/internal/handler/utilhttp"
-	"gilsaputro/dating-apps/internal/store/user"
+	"gilsaputro/dating-apps/internal/store/session"
+	"gilsaputro/dating-apps/internal/store/tokencache"
+	"gilsaputro/dating-apps/models"
 	"gilsaputro/dating-apps/pkg/token"
+	"log"
 	"net/http"
 	"strings"
+	"time"
+)
+
+// lastSeenInterval is minimum interval to update the last seen of the session, so the database is not updated on every request
+const lastSeenInterval = time.Minute
+
+// list reason the token is rejected
+const (
+	reasonUnauthorized  = "unauthorized"
+	reasonTokenOutdated = "token is outdated, please refresh the token"
 )
 
 // Middleware struct is list dependecies to run Middleware func
 type Middleware struct {
 	tokenMethod token.TokenMethod
-	userStore   user.UserStoreMethod
+	tokenCache  tokencache.TokenCacheStoreMethod
+	session     session.SessionStoreMethod
 }
 
 // NewMiddleware is func to create Middleware Struct
-func NewMiddleware(tokenMethod token.TokenMethod, userStore user.UserStoreMethod) Middleware {
+func NewMiddleware(tokenMethod token.TokenMethod, tokenCache tokencache.TokenCacheStoreMethod, session session.SessionStoreMethod) Middleware {
 	return Middleware{
 		tokenMethod: tokenMethod,
-		userStore:   userStore,
+		tokenCache:  tokenCache,
+		session:     session,
 	}
 }
 
@@ -52,31 +68,111 @@ func (m *Middleware) MiddlewareVerifyToken(next http.HandlerFunc) http.HandlerFu
 			return
 		}
 
+		sessionInfo, reason, err := m.checkToken(tokenBody)
+		if err != nil {
+			data := []byte(`{"code":500,"message":"Internal Server Error"}`)
+			utilhttp.WriteResponse(w, data, http.StatusInternalServerError)
+			return
+		}
+
+		if len(reason) > 0 {
+			data := []byte(fmt.Sprintf(`{"code":401,"message":"%s"}`, reason))
+			utilhttp.WriteResponse(w, data, http.StatusUnauthorized)
+			return
+		}
+
+		if sessionInfo.ID > 0 && time.Since(sessionInfo.LastSeenAt) > lastSeenInterval {
+			err = m.session.UpdateLastSeen(tokenBody.SessionID, time.Now())
+			if err != nil {
+				log.Println("[MiddlewareVerifyToken]-Error Update Session Last Seen :", err)
+			}
+		}
+
 		// Parse variable into context
-		r = r.WithContext(context.WithValue(r.Context(), "id", tokenBody.UserID))
+		ctx := context.WithValue(r.Context(), "id", tokenBody.UserID)
+		ctx = context.WithValue(ctx, "plan", tokenBody.Plan)
+		ctx = context.WithValue(ctx, "isemailverified", tokenBody.IsEmailVerified)
+		ctx = context.WithValue(ctx, "roles", tokenBody.Roles)
+		ctx = context.WithValue(ctx, "sessionid", tokenBody.SessionID)
+		ctx = context.WithValue(ctx, "token", tokenBody)
+		r = r.WithContext(ctx)
 		next.ServeHTTP(w, r)
 	}
 }
 
-// MiddlewareCheckVerifiedStatus is func to validate before execute the handler
-func (m *Middleware) MiddlewareCheckVerifiedStatus(next http.HandlerFunc) http.HandlerFunc {
+// IsTokenActive is func to check the token is not expired, revoked by logout, outdated by the claims change
+// or belong to the revoked session, it is used by the long lived connection that only verify the token once
+func (m *Middleware) IsTokenActive(tokenBody token.TokenBody) (bool, error) {
+	if !time.Now().Before(tokenBody.ExpiredAt) {
+		return false, nil
+	}
+
+	_, reason, err := m.checkToken(tokenBody)
+	if err != nil {
+		return false, err
+	}
+
+	return len(reason) == 0, nil
+}
+
+// checkToken is func to get the reason the valid token is rejected, the reason is empty when the token is accepted.
+// The session of the token is returned so the caller can update the last seen
+func (m *Middleware) checkToken(tokenBody token.TokenBody) (models.UserSession, string, error) {
+	// Reject the token that already revoked by logout
+	isRevoked, err := m.tokenCache.IsTokenRevoked(tokenBody.TokenID)
+	if err != nil {
+		return models.UserSession{}, "", err
+	}
+
+	if isRevoked {
+		return models.UserSession{}, reasonUnauthorized, nil
+	}
+
+	// Force the client to refresh the token when the claims is changed after the token is issued
+	changedAt, err := m.tokenCache.GetClaimsChangedAt(tokenBody.UserID)
+	if err != nil {
+		return models.UserSession{}, "", err
+	}
+
+	if tokenBody.IssuedAt.Unix() < changedAt.Unix() {
+		return models.UserSession{}, reasonTokenOutdated, nil
+	}
+
+	// Reject the token of the session that already revoked from other device
+	if len(tokenBody.SessionID) == 0 {
+		return models.UserSession{}, "", nil
+	}
+
+	sessionInfo, err := m.session.GetSession(tokenBody.SessionID)
+	if err != nil {
+		return models.UserSession{}, "", err
+	}
+
+	if sessionInfo.IsRevoked() {
+		return models.UserSession{}, reasonUnauthorized, nil
+	}
+
+	return sessionInfo, "", nil
+}
+
+// MiddlewareCheckAdmin is func to allow only admin user to execute the handler
+func (m *Middleware) MiddlewareCheckAdmin(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		userID, ok := r.Context().Value("id").(int)
+		roles, ok := r.Context().Value("roles").([]string)
 		if !ok {
 			data := []byte(`{"code":500,"message":"Internal Server Error"}`)
 			utilhttp.WriteResponse(w, data, http.StatusInternalServerError)
 			return
 		}
 
-		userInfo, err := m.userStore.GetUserInfoByID(userID)
-		if err != nil {
-			data := []byte(`{"code":500,"message":"Internal Server Error"}`)
-			utilhttp.WriteResponse(w, data, http.StatusInternalServerError)
-			return
+		for _, role := range roles {
+			if role == models.RoleAdmin {
+				next.ServeHTTP(w, r)
+				return
+			}
 		}
 
-		// Parse variable into context
-		r = r.WithContext(context.WithValue(r.Context(), "isverified", userInfo.IsVerified))
-		next.ServeHTTP(w, r)
+		data := []byte(`{"code":403,"message":"forbidden"}`)
+		utilhttp.WriteResponse(w, data, http.StatusForbidden)
 	}
 }
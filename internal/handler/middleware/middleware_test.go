@@ -1,22 +1,30 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
-	"gilsaputro/dating-apps/internal/store/user"
+	"gilsaputro/dating-apps/internal/store/session"
+	mock_session "gilsaputro/dating-apps/internal/store/session/mock"
+	"gilsaputro/dating-apps/internal/store/tokencache"
+	mock_tokencache "gilsaputro/dating-apps/internal/store/tokencache/mock"
+	"gilsaputro/dating-apps/models"
 	"gilsaputro/dating-apps/pkg/token"
 	mock_token "gilsaputro/dating-apps/pkg/token/mock"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
 )
 
 func TestNewMiddleware(t *testing.T) {
 	type args struct {
 		tokenMethod token.TokenMethod
-		userStore   user.UserStore
+		tokenCache  tokencache.TokenCacheStoreMethod
+		session     session.SessionStoreMethod
 	}
 	tests := []struct {
 		name string
@@ -27,17 +35,19 @@ func TestNewMiddleware(t *testing.T) {
 			name: "success",
 			args: args{
 				tokenMethod: token.TokenConfig{},
-				userStore:   user.UserStore{},
+				tokenCache:  &tokencache.TokenCacheStore{},
+				session:     &session.SessionStore{},
 			},
 			want: Middleware{
 				tokenMethod: token.TokenConfig{},
-				userStore:   &user.UserStore{},
+				tokenCache:  &tokencache.TokenCacheStore{},
+				session:     &session.SessionStore{},
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewMiddleware(tt.args.tokenMethod, &tt.args.userStore); !reflect.DeepEqual(got, tt.want) {
+			if got := NewMiddleware(tt.args.tokenMethod, tt.args.tokenCache, tt.args.session); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewMiddleware() = %v, want %v", got, tt.want)
 			}
 		})
@@ -47,26 +57,41 @@ func TestNewMiddleware(t *testing.T) {
 func TestMiddleware_MiddlewareVerifyToken(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	mToken := mock_token.NewMockTokenMethod(mockCtrl)
+	mTokenCache := mock_tokencache.NewMockTokenCacheStoreMethod(mockCtrl)
+	mSession := mock_session.NewMockSessionStoreMethod(mockCtrl)
 	defer mockCtrl.Finish()
 	type args struct {
 		token string
 		path  string
 	}
 
+	issuedAt := time.Unix(1700000000, 0)
 	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		token, ok := r.Context().Value("id").(int)
 		if !ok {
 			w.WriteHeader(http.StatusUnauthorized)
 		}
+		plan, _ := r.Context().Value("plan").(string)
+		isEmailVerified, _ := r.Context().Value("isemailverified").(bool)
+		roles, _ := r.Context().Value("roles").([]string)
+		sessionID, _ := r.Context().Value("sessionid").(string)
 		w.Header().Set("id", fmt.Sprintf("%v", token))
+		w.Header().Set("plan", plan)
+		w.Header().Set("isemailverified", fmt.Sprintf("%v", isEmailVerified))
+		w.Header().Set("roles", fmt.Sprintf("%v", roles))
+		w.Header().Set("sessionid", sessionID)
 		_, _ = w.Write([]byte{})
 	})
 
 	tests := []struct {
-		name      string
-		args      args
-		mockFunc  func()
-		wantToken string
+		name                string
+		args                args
+		mockFunc            func()
+		wantToken           string
+		wantPlan            string
+		wantIsEmailVerified string
+		wantRoles           string
+		wantSessionID       string
 	}{
 		{
 			name: "success flow",
@@ -76,10 +101,170 @@ func TestMiddleware_MiddlewareVerifyToken(t *testing.T) {
 			},
 			mockFunc: func() {
 				mToken.EXPECT().ValidateToken("token_baru").Return(token.TokenBody{
-					UserID: 1,
+					UserID:          1,
+					Roles:           []string{"user"},
+					Plan:            models.PlanPremium,
+					IsEmailVerified: true,
+					SessionID:       "sid",
+					TokenID:         "jti",
+					IssuedAt:        issuedAt,
+				}, nil)
+				mTokenCache.EXPECT().IsTokenRevoked("jti").Return(false, nil)
+				mTokenCache.EXPECT().GetClaimsChangedAt(1).Return(time.Time{}, nil)
+				mSession.EXPECT().GetSession("sid").Return(models.UserSession{Model: gorm.Model{ID: 1}, SessionID: "sid", LastSeenAt: time.Now()}, nil)
+			},
+			wantToken:           "1",
+			wantPlan:            models.PlanPremium,
+			wantIsEmailVerified: "true",
+			wantRoles:           "[user]",
+			wantSessionID:       "sid",
+		},
+		{
+			name: "update session last seen flow",
+			args: args{
+				token: "token_baru",
+				path:  "/user",
+			},
+			mockFunc: func() {
+				mToken.EXPECT().ValidateToken("token_baru").Return(token.TokenBody{
+					UserID:    1,
+					SessionID: "sid",
+					TokenID:   "jti",
+					IssuedAt:  issuedAt,
+				}, nil)
+				mTokenCache.EXPECT().IsTokenRevoked("jti").Return(false, nil)
+				mTokenCache.EXPECT().GetClaimsChangedAt(1).Return(time.Time{}, nil)
+				mSession.EXPECT().GetSession("sid").Return(models.UserSession{Model: gorm.Model{ID: 1}, SessionID: "sid", LastSeenAt: issuedAt}, nil)
+				mSession.EXPECT().UpdateLastSeen("sid", gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			wantToken:           "1",
+			wantPlan:            "",
+			wantIsEmailVerified: "false",
+			wantRoles:           "[]",
+			wantSessionID:       "sid",
+		},
+		{
+			name: "revoked session flow",
+			args: args{
+				token: "token_baru",
+				path:  "/user",
+			},
+			mockFunc: func() {
+				mToken.EXPECT().ValidateToken("token_baru").Return(token.TokenBody{
+					UserID:    1,
+					SessionID: "sid",
+					TokenID:   "jti",
+					IssuedAt:  issuedAt,
+				}, nil)
+				mTokenCache.EXPECT().IsTokenRevoked("jti").Return(false, nil)
+				mTokenCache.EXPECT().GetClaimsChangedAt(1).Return(time.Time{}, nil)
+				mSession.EXPECT().GetSession("sid").Return(models.UserSession{Model: gorm.Model{ID: 1}, SessionID: "sid", RevokedAt: &issuedAt}, nil)
+			},
+			wantToken: "",
+		},
+		{
+			name: "error get session flow",
+			args: args{
+				token: "token_baru",
+				path:  "/user",
+			},
+			mockFunc: func() {
+				mToken.EXPECT().ValidateToken("token_baru").Return(token.TokenBody{
+					UserID:    1,
+					SessionID: "sid",
+					TokenID:   "jti",
+					IssuedAt:  issuedAt,
+				}, nil)
+				mTokenCache.EXPECT().IsTokenRevoked("jti").Return(false, nil)
+				mTokenCache.EXPECT().GetClaimsChangedAt(1).Return(time.Time{}, nil)
+				mSession.EXPECT().GetSession("sid").Return(models.UserSession{}, fmt.Errorf("some error"))
+			},
+			wantToken: "",
+		},
+		{
+			name: "claims changed before token issued flow",
+			args: args{
+				token: "token_baru",
+				path:  "/user",
+			},
+			mockFunc: func() {
+				mToken.EXPECT().ValidateToken("token_baru").Return(token.TokenBody{
+					UserID:   1,
+					Roles:    []string{"user"},
+					TokenID:  "jti",
+					IssuedAt: issuedAt,
 				}, nil)
+				mTokenCache.EXPECT().IsTokenRevoked("jti").Return(false, nil)
+				mTokenCache.EXPECT().GetClaimsChangedAt(1).Return(issuedAt, nil)
 			},
-			wantToken: "1",
+			wantToken:           "1",
+			wantPlan:            "",
+			wantIsEmailVerified: "false",
+			wantRoles:           "[user]",
+		},
+		{
+			name: "outdated token flow",
+			args: args{
+				token: "token_baru",
+				path:  "/user",
+			},
+			mockFunc: func() {
+				mToken.EXPECT().ValidateToken("token_baru").Return(token.TokenBody{
+					UserID:   1,
+					TokenID:  "jti",
+					IssuedAt: issuedAt,
+				}, nil)
+				mTokenCache.EXPECT().IsTokenRevoked("jti").Return(false, nil)
+				mTokenCache.EXPECT().GetClaimsChangedAt(1).Return(issuedAt.Add(time.Second), nil)
+			},
+			wantToken: "",
+		},
+		{
+			name: "error get claims changed flow",
+			args: args{
+				token: "token_baru",
+				path:  "/user",
+			},
+			mockFunc: func() {
+				mToken.EXPECT().ValidateToken("token_baru").Return(token.TokenBody{
+					UserID:   1,
+					TokenID:  "jti",
+					IssuedAt: issuedAt,
+				}, nil)
+				mTokenCache.EXPECT().IsTokenRevoked("jti").Return(false, nil)
+				mTokenCache.EXPECT().GetClaimsChangedAt(1).Return(time.Time{}, fmt.Errorf("some error"))
+			},
+			wantToken: "",
+		},
+		{
+			name: "revoked token flow",
+			args: args{
+				token: "token_baru",
+				path:  "/user",
+			},
+			mockFunc: func() {
+				mToken.EXPECT().ValidateToken("token_baru").Return(token.TokenBody{
+					UserID:  1,
+					TokenID: "jti",
+				}, nil)
+				mTokenCache.EXPECT().IsTokenRevoked("jti").Return(true, nil)
+			},
+			wantToken: "",
+		},
+		{
+			name: "error check revoked token flow",
+			args: args{
+				token: "token_baru",
+				path:  "/user",
+			},
+			mockFunc: func() {
+				mToken.EXPECT().ValidateToken("token_baru").Return(token.TokenBody{
+					UserID:  1,
+					TokenID: "jti",
+				}, nil)
+				mTokenCache.EXPECT().IsTokenRevoked("jti").Return(false, fmt.Errorf("some error"))
+			},
+			wantToken: "",
 		},
 		{
 			name: "invalid token flow",
@@ -95,6 +280,8 @@ func TestMiddleware_MiddlewareVerifyToken(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			m := Middleware{
 				tokenMethod: mToken,
+				tokenCache:  mTokenCache,
+				session:     mSession,
 			}
 
 			tt.mockFunc()
@@ -110,6 +297,156 @@ func TestMiddleware_MiddlewareVerifyToken(t *testing.T) {
 			if !reflect.DeepEqual(new_token, tt.wantToken) {
 				t.Errorf("NewMiddleware() token = %v, want %v", new_token, tt.wantToken)
 			}
+			if len(tt.wantToken) == 0 {
+				return
+			}
+			if got := recorder.Header().Get("plan"); got != tt.wantPlan {
+				t.Errorf("NewMiddleware() plan = %v, want %v", got, tt.wantPlan)
+			}
+			if got := recorder.Header().Get("isemailverified"); got != tt.wantIsEmailVerified {
+				t.Errorf("NewMiddleware() isemailverified = %v, want %v", got, tt.wantIsEmailVerified)
+			}
+			if got := recorder.Header().Get("roles"); got != tt.wantRoles {
+				t.Errorf("NewMiddleware() roles = %v, want %v", got, tt.wantRoles)
+			}
+			if got := recorder.Header().Get("sessionid"); got != tt.wantSessionID {
+				t.Errorf("NewMiddleware() sessionid = %v, want %v", got, tt.wantSessionID)
+			}
+		})
+	}
+}
+
+func TestMiddleware_MiddlewareCheckAdmin(t *testing.T) {
+	type args struct {
+		roles []string
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name     string
+		args     args
+		wantCode int
+	}{
+		{
+			name: "success flow",
+			args: args{
+				roles: []string{models.RoleUser, models.RoleAdmin},
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "not admin flow",
+			args: args{
+				roles: []string{models.RoleUser},
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "missing roles flow",
+			args:     args{},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Middleware{}
+
+			middleware := m.MiddlewareCheckAdmin(next)
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/v1/admin/reports", nil)
+			if tt.args.roles != nil {
+				request = request.WithContext(context.WithValue(request.Context(), "roles", tt.args.roles))
+			}
+
+			middleware(recorder, request)
+			if recorder.Code != tt.wantCode {
+				t.Errorf("MiddlewareCheckAdmin() code = %v, want %v", recorder.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestMiddleware_IsTokenActive(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mToken := mock_token.NewMockTokenMethod(mockCtrl)
+	mTokenCache := mock_tokencache.NewMockTokenCacheStoreMethod(mockCtrl)
+	mSession := mock_session.NewMockSessionStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+
+	issuedAt := time.Unix(1700000000, 0)
+	tokenBody := token.TokenBody{
+		UserID:    1,
+		TokenID:   "jti",
+		SessionID: "sid",
+		IssuedAt:  issuedAt,
+		ExpiredAt: time.Now().Add(time.Hour),
+	}
+	tests := []struct {
+		name      string
+		tokenBody token.TokenBody
+		mockFunc  func()
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name:      "success active token flow",
+			tokenBody: tokenBody,
+			mockFunc: func() {
+				mTokenCache.EXPECT().IsTokenRevoked("jti").Return(false, nil)
+				mTokenCache.EXPECT().GetClaimsChangedAt(1).Return(time.Time{}, nil)
+				mSession.EXPECT().GetSession("sid").Return(models.UserSession{Model: gorm.Model{ID: 1}, SessionID: "sid"}, nil)
+			},
+			want: true,
+		},
+		{
+			name:      "expired token flow",
+			tokenBody: token.TokenBody{UserID: 1, TokenID: "jti", ExpiredAt: time.Now().Add(-time.Second)},
+			mockFunc:  func() {},
+			want:      false,
+		},
+		{
+			name:      "revoked token flow",
+			tokenBody: tokenBody,
+			mockFunc: func() {
+				mTokenCache.EXPECT().IsTokenRevoked("jti").Return(true, nil)
+			},
+			want: false,
+		},
+		{
+			name:      "revoked session flow",
+			tokenBody: tokenBody,
+			mockFunc: func() {
+				mTokenCache.EXPECT().IsTokenRevoked("jti").Return(false, nil)
+				mTokenCache.EXPECT().GetClaimsChangedAt(1).Return(time.Time{}, nil)
+				mSession.EXPECT().GetSession("sid").Return(models.UserSession{Model: gorm.Model{ID: 1}, SessionID: "sid", RevokedAt: &issuedAt}, nil)
+			},
+			want: false,
+		},
+		{
+			name:      "error check revoked token flow",
+			tokenBody: tokenBody,
+			mockFunc: func() {
+				mTokenCache.EXPECT().IsTokenRevoked("jti").Return(false, fmt.Errorf("some error"))
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMiddleware(mToken, mTokenCache, mSession)
+			tt.mockFunc()
+			got, err := m.IsTokenActive(tt.tokenBody)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Middleware.IsTokenActive() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Middleware.IsTokenActive() = %v, want %v", got, tt.want)
+			}
 		})
 	}
 }
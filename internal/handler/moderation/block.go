@@ -0,0 +1,91 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/moderation"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// BlockUserHandler is func handler for block another user
+func (h *ModerationHandler) BlockUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		response.Code = code
+		if err == nil {
+			response.Message = "success"
+		} else {
+			response.Message = err.Error()
+		}
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[BlockUserHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	targetID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || targetID <= 0 {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Invalid Parameter Request")
+		return
+	}
+
+	var userID int
+	var ok bool
+	userID, ok = r.Context().Value("id").(int)
+	if !ok {
+		code = http.StatusInternalServerError
+		err = fmt.Errorf("Internal Server Error")
+		return
+	}
+
+	errChan := make(chan error, 1)
+	go func(ctx context.Context) {
+		err = h.service.BlockUser(moderation.BlockServiceRequest{
+			UserID:        userID,
+			BlockedUserID: targetID,
+		})
+		errChan <- err
+	}(ctx)
+
+	select {
+	case <-ctx.Done():
+		code = http.StatusGatewayTimeout
+		err = fmt.Errorf("Timeout")
+		return
+	case err = <-errChan:
+		if err != nil {
+			code = mapTargetErrorCode(err)
+			return
+		}
+	}
+}
+
+// mapTargetErrorCode is func to get http status code of the target user validation error
+func mapTargetErrorCode(err error) int {
+	switch err {
+	case moderation.ErrInvalidTargetUser, moderation.ErrInvalidReportReason:
+		return http.StatusBadRequest
+	case moderation.ErrUserNotFound:
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
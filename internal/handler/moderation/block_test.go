@@ -0,0 +1,162 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/moderation"
+	"gilsaputro/dating-apps/internal/service/moderation/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+func TestModerationHandler_BlockUserHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockModerationServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		userID   int
+		targetID string
+		timeout  int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				userID:   1,
+				targetID: "2",
+				timeout:  5,
+			},
+			mockFunc: func() {
+				m.EXPECT().BlockUser(moderation.BlockServiceRequest{
+					UserID:        1,
+					BlockedUserID: 2,
+				}).Return(nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error block self flow",
+			args: args{
+				userID:   1,
+				targetID: "1",
+				timeout:  5,
+			},
+			mockFunc: func() {
+				m.EXPECT().BlockUser(moderation.BlockServiceRequest{
+					UserID:        1,
+					BlockedUserID: 1,
+				}).Return(moderation.ErrInvalidTargetUser)
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"the target user is invalid"}`,
+			},
+		},
+		{
+			name: "error user not found flow",
+			args: args{
+				userID:   1,
+				targetID: "2",
+				timeout:  5,
+			},
+			mockFunc: func() {
+				m.EXPECT().BlockUser(moderation.BlockServiceRequest{
+					UserID:        1,
+					BlockedUserID: 2,
+				}).Return(moderation.ErrUserNotFound)
+			},
+			want: want{
+				code: 404,
+				body: `{"code":404,"message":"the target user is not found"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				userID:   1,
+				targetID: "2",
+				timeout:  5,
+			},
+			mockFunc: func() {
+				m.EXPECT().BlockUser(moderation.BlockServiceRequest{
+					UserID:        1,
+					BlockedUserID: 2,
+				}).Return(fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error invalid user id",
+			args: args{
+				userID:   1,
+				targetID: "abc",
+				timeout:  5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error missing user id",
+			args: args{
+				targetID: "2",
+				timeout:  5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			defer mockCtrl.Finish()
+			handler := NewModerationHandler(m, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodPost, "/v1/users/"+tt.args.targetID+"/block", nil)
+			if tt.args.userID > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.userID))
+			}
+			r = mux.SetURLVars(r, map[string]string{"id": tt.args.targetID})
+			w := httptest.NewRecorder()
+			handler.BlockUserHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("BlockUserHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("BlockUserHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package moderation
+
+import (
+	"gilsaputro/dating-apps/internal/service/moderation"
+)
+
+// ModerationHandler list dependencies for moderation handler
+type ModerationHandler struct {
+	service      moderation.ModerationServiceMethod
+	timeoutInSec int
+}
+
+// Option set options for http handler config
+type Option func(*ModerationHandler)
+
+const (
+	defaultTimeout = 5
+)
+
+// NewModerationHandler is func to create http moderation handler
+func NewModerationHandler(service moderation.ModerationServiceMethod, options ...Option) *ModerationHandler {
+	handler := &ModerationHandler{
+		service:      service,
+		timeoutInSec: defaultTimeout,
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(handler)
+	}
+
+	return handler
+}
+
+// WithTimeoutOptions is func to set timeout config into handler
+func WithTimeoutOptions(timeoutinsec int) Option {
+	return Option(
+		func(h *ModerationHandler) {
+			if timeoutinsec <= 0 {
+				timeoutinsec = defaultTimeout
+			}
+			h.timeoutInSec = timeoutinsec
+		})
+}
@@ -0,0 +1,190 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/moderation"
+	"gilsaputro/dating-apps/internal/service/moderation/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+func TestModerationHandler_ReportUserHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockModerationServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		userID   int
+		targetID string
+		body     string
+		timeout  int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				userID:   1,
+				targetID: "2",
+				body:     `{"reason":"SPAM","note":"sending link"}`,
+				timeout:  5,
+			},
+			mockFunc: func() {
+				m.EXPECT().ReportUser(moderation.ReportServiceRequest{
+					UserID:         1,
+					ReportedUserID: 2,
+					Reason:         "SPAM",
+					Note:           "sending link",
+				}).Return(moderation.ReportServiceInfo{
+					ReportID:       3,
+					ReporterID:     1,
+					ReportedUserID: 2,
+					Reason:         "SPAM",
+					Note:           "sending link",
+					Status:         "OPEN",
+				}, nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"id":3,"reporter_id":1,"reported_user_id":2,"reason":"SPAM","note":"sending link","status":"OPEN","created_date":""},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error invalid reason flow",
+			args: args{
+				userID:   1,
+				targetID: "2",
+				body:     `{"reason":"BORING"}`,
+				timeout:  5,
+			},
+			mockFunc: func() {
+				m.EXPECT().ReportUser(moderation.ReportServiceRequest{
+					UserID:         1,
+					ReportedUserID: 2,
+					Reason:         "BORING",
+				}).Return(moderation.ReportServiceInfo{}, moderation.ErrInvalidReportReason)
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"report reason is invalid, the value should be SPAM, HARASSMENT, INAPPROPRIATE_CONTENT, FAKE_PROFILE, UNDERAGE or OTHER"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				userID:   1,
+				targetID: "2",
+				body:     `{"reason":"SPAM"}`,
+				timeout:  5,
+			},
+			mockFunc: func() {
+				m.EXPECT().ReportUser(moderation.ReportServiceRequest{
+					UserID:         1,
+					ReportedUserID: 2,
+					Reason:         "SPAM",
+				}).Return(moderation.ReportServiceInfo{}, fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error empty reason flow",
+			args: args{
+				userID:   1,
+				targetID: "2",
+				body:     `{"note":"sending link"}`,
+				timeout:  5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error invalid body flow",
+			args: args{
+				userID:   1,
+				targetID: "2",
+				body:     `{`,
+				timeout:  5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Bad Request"}`,
+			},
+		},
+		{
+			name: "error invalid user id",
+			args: args{
+				userID:   1,
+				targetID: "abc",
+				body:     `{"reason":"SPAM"}`,
+				timeout:  5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error missing user id",
+			args: args{
+				targetID: "2",
+				body:     `{"reason":"SPAM"}`,
+				timeout:  5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			defer mockCtrl.Finish()
+			handler := NewModerationHandler(m, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodPost, "/v1/users/"+tt.args.targetID+"/report", strings.NewReader(tt.args.body))
+			if tt.args.userID > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.userID))
+			}
+			r = mux.SetURLVars(r, map[string]string{"id": tt.args.targetID})
+			w := httptest.NewRecorder()
+			handler.ReportUserHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("ReportUserHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("ReportUserHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
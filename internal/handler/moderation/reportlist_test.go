@@ -0,0 +1,142 @@
+package moderation
+
+import (
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/moderation"
+	"gilsaputro/dating-apps/internal/service/moderation/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestModerationHandler_ReportListHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockModerationServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		query   string
+		timeout int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				query:   "?status=OPEN&page=1&limit=1",
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetListReport(moderation.ReportListServiceRequest{
+					Status: "OPEN",
+					Page:   1,
+					Limit:  1,
+				}).Return(moderation.ReportListServiceInfo{
+					Reports: []moderation.ReportServiceInfo{
+						{
+							ReportID:       3,
+							ReporterID:     1,
+							ReportedUserID: 2,
+							Reason:         "SPAM",
+							Status:         "OPEN",
+						},
+					},
+					Page:  1,
+					Limit: 1,
+					Total: 4,
+				}, nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"reports":[{"id":3,"reporter_id":1,"reported_user_id":2,"reason":"SPAM","status":"OPEN","created_date":""}],"pagination":{"page":1,"limit":1,"total":4}},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error invalid status flow",
+			args: args{
+				query:   "?status=UNKNOWN",
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetListReport(moderation.ReportListServiceRequest{
+					Status: "UNKNOWN",
+				}).Return(moderation.ReportListServiceInfo{}, moderation.ErrInvalidReportStatus)
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"report status is invalid"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetListReport(moderation.ReportListServiceRequest{}).Return(moderation.ReportListServiceInfo{}, fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error invalid page flow",
+			args: args{
+				query:   "?page=abc",
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error invalid limit flow",
+			args: args{
+				query:   "?limit=-1",
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			defer mockCtrl.Finish()
+			handler := NewModerationHandler(m, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodGet, "/v1/admin/reports"+tt.args.query, nil)
+			w := httptest.NewRecorder()
+			handler.ReportListHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("ReportListHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("ReportListHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
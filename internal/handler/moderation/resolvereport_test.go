@@ -0,0 +1,189 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/moderation"
+	"gilsaputro/dating-apps/internal/service/moderation/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+func TestModerationHandler_ResolveReportHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockModerationServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		adminID  int
+		reportID string
+		body     string
+		timeout  int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				adminID:  9,
+				reportID: "3",
+				body:     `{"status":"RESOLVED","resolution":"user banned"}`,
+				timeout:  5,
+			},
+			mockFunc: func() {
+				m.EXPECT().ResolveReport(moderation.ResolveReportServiceRequest{
+					ReportID:   3,
+					AdminID:    9,
+					Status:     "RESOLVED",
+					Resolution: "user banned",
+				}).Return(nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error report not found flow",
+			args: args{
+				adminID:  9,
+				reportID: "3",
+				body:     `{"status":"DISMISSED"}`,
+				timeout:  5,
+			},
+			mockFunc: func() {
+				m.EXPECT().ResolveReport(moderation.ResolveReportServiceRequest{
+					ReportID: 3,
+					AdminID:  9,
+					Status:   "DISMISSED",
+				}).Return(moderation.ErrReportNotFound)
+			},
+			want: want{
+				code: 404,
+				body: `{"code":404,"message":"the report is not found or already resolved"}`,
+			},
+		},
+		{
+			name: "error invalid status flow",
+			args: args{
+				adminID:  9,
+				reportID: "3",
+				body:     `{"status":"OPEN"}`,
+				timeout:  5,
+			},
+			mockFunc: func() {
+				m.EXPECT().ResolveReport(moderation.ResolveReportServiceRequest{
+					ReportID: 3,
+					AdminID:  9,
+					Status:   "OPEN",
+				}).Return(moderation.ErrInvalidReportStatus)
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"report status is invalid"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				adminID:  9,
+				reportID: "3",
+				body:     `{"status":"RESOLVED"}`,
+				timeout:  5,
+			},
+			mockFunc: func() {
+				m.EXPECT().ResolveReport(moderation.ResolveReportServiceRequest{
+					ReportID: 3,
+					AdminID:  9,
+					Status:   "RESOLVED",
+				}).Return(fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error empty status flow",
+			args: args{
+				adminID:  9,
+				reportID: "3",
+				body:     `{}`,
+				timeout:  5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error invalid report id",
+			args: args{
+				adminID:  9,
+				reportID: "abc",
+				body:     `{"status":"RESOLVED"}`,
+				timeout:  5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error missing admin id",
+			args: args{
+				reportID: "3",
+				body:     `{"status":"RESOLVED"}`,
+				timeout:  5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			defer mockCtrl.Finish()
+			handler := NewModerationHandler(m, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodPost, "/v1/admin/reports/"+tt.args.reportID+"/resolve", strings.NewReader(tt.args.body))
+			if tt.args.adminID > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.adminID))
+			}
+			r = mux.SetURLVars(r, map[string]string{"reportID": tt.args.reportID})
+			w := httptest.NewRecorder()
+			handler.ResolveReportHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("ResolveReportHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("ResolveReportHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
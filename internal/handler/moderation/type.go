@@ -0,0 +1,74 @@
+package moderation
+
+import (
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/moderation"
+)
+
+// ReportUserRequest is list request parameter for Report User Api
+type ReportUserRequest struct {
+	Reason string `json:"reason"`
+	Note   string `json:"note"`
+}
+
+// ResolveReportRequest is list request parameter for Resolve Report Api
+type ResolveReportRequest struct {
+	Status     string `json:"status"`
+	Resolution string `json:"resolution"`
+}
+
+// ReportResponse is list response parameter for a report
+type ReportResponse struct {
+	ReportID       int    `json:"id"`
+	ReporterID     int    `json:"reporter_id"`
+	ReportedUserID int    `json:"reported_user_id"`
+	Reason         string `json:"reason"`
+	Note           string `json:"note,omitempty"`
+	Status         string `json:"status"`
+	Resolution     string `json:"resolution,omitempty"`
+	CreatedDate    string `json:"created_date"`
+}
+
+// ReportListResponse is list response parameter for Report List Api
+type ReportListResponse struct {
+	Reports    []ReportResponse `json:"reports"`
+	Pagination Pagination       `json:"pagination"`
+}
+
+// Pagination is pagination info of the list response
+type Pagination struct {
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+	Total int `json:"total"`
+}
+
+func mapReportResponse(result moderation.ReportServiceInfo) ReportResponse {
+	return ReportResponse{
+		ReportID:       result.ReportID,
+		ReporterID:     result.ReporterID,
+		ReportedUserID: result.ReportedUserID,
+		Reason:         result.Reason,
+		Note:           result.Note,
+		Status:         result.Status,
+		Resolution:     result.Resolution,
+		CreatedDate:    result.CreatedDate,
+	}
+}
+
+func mapReportListResponse(result moderation.ReportListServiceInfo) utilhttp.StandardResponse {
+	var res utilhttp.StandardResponse
+	list := []ReportResponse{}
+	for _, data := range result.Reports {
+		list = append(list, mapReportResponse(data))
+	}
+
+	res.Data = ReportListResponse{
+		Reports: list,
+		Pagination: Pagination{
+			Page:  result.Page,
+			Limit: result.Limit,
+			Total: result.Total,
+		},
+	}
+	return res
+}
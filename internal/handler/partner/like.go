@@ -46,19 +46,23 @@ func (h *PartnerHandler) LikePartnerHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	var isVerified bool
-	isVerified, ok = r.Context().Value("isverified").(bool)
+	var plan string
+	plan, ok = r.Context().Value("plan").(string)
 	if !ok {
 		code = http.StatusInternalServerError
 		err = fmt.Errorf("Internal Server Error")
 		return
 	}
 
+	// the user with unverified email is not allowed to like, missing value is treated as unverified
+	isEmailVerified, _ := r.Context().Value("isemailverified").(bool)
+
 	errChan := make(chan error, 1)
 	go func(ctx context.Context) {
 		err = h.service.LikePartner(partner.PartnerServiceRequest{
-			UserID:     userID,
-			IsVerified: isVerified,
+			UserID:          userID,
+			Plan:            plan,
+			IsEmailVerified: isEmailVerified,
 		})
 		errChan <- err
 	}(ctx)
@@ -70,7 +74,13 @@ func (h *PartnerHandler) LikePartnerHandler(w http.ResponseWriter, r *http.Reque
 		return
 	case err = <-errChan:
 		if err != nil {
-			code = http.StatusInternalServerError
+			if err == partner.ErrPartnerIsBlocked {
+				code = http.StatusConflict
+			} else if err == partner.ErrEmailNotVerified {
+				code = http.StatusForbidden
+			} else {
+				code = http.StatusInternalServerError
+			}
 			return
 		}
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"gilsaputro/dating-apps/internal/service/partner"
 	"gilsaputro/dating-apps/internal/service/partner/mock"
+	"gilsaputro/dating-apps/models"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -19,9 +20,10 @@ func TestPartnerHandler_LikePartnerHandler(t *testing.T) {
 	m := mock.NewMockPartnerServiceMethod(mockCtrl)
 	defer mockCtrl.Finish()
 	type args struct {
-		userID     int
-		isVerified bool
-		timeout    int
+		userID          int
+		plan            string
+		isEmailVerified bool
+		timeout         int
 	}
 	type want struct {
 		body string
@@ -34,17 +36,61 @@ func TestPartnerHandler_LikePartnerHandler(t *testing.T) {
 		mockContext func() (context.Context, func())
 		want        want
 	}{
+		{
+			name: "success email verified flow",
+			args: args{
+				userID:          1,
+				plan:            models.PlanPremium,
+				isEmailVerified: true,
+				timeout:         5,
+			},
+			mockFunc: func() {
+				m.EXPECT().LikePartner(partner.PartnerServiceRequest{
+					UserID:          1,
+					Plan:            models.PlanPremium,
+					IsEmailVerified: true,
+				}).Return(nil)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 200,
+				body: `{"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error email not verified flow",
+			args: args{
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().LikePartner(partner.PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				}).Return(partner.ErrEmailNotVerified)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 403,
+				body: `{"code":403,"message":"please verify your email before like a partner"}`,
+			},
+		},
 		{
 			name: "success flow",
 			args: args{
-				userID:     1,
-				isVerified: true,
-				timeout:    5,
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
 			},
 			mockFunc: func() {
 				m.EXPECT().LikePartner(partner.PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: true,
+					UserID: 1,
+					Plan:   models.PlanPremium,
 				}).Return(nil)
 			},
 			mockContext: func() (context.Context, func()) {
@@ -55,17 +101,38 @@ func TestPartnerHandler_LikePartnerHandler(t *testing.T) {
 				body: `{"code":200,"message":"success"}`,
 			},
 		},
+		{
+			name: "error partner is blocked flow",
+			args: args{
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().LikePartner(partner.PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				}).Return(partner.ErrPartnerIsBlocked)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 409,
+				body: `{"code":409,"message":"the partner is no longer available"}`,
+			},
+		},
 		{
 			name: "error on service flow",
 			args: args{
-				userID:     1,
-				isVerified: true,
-				timeout:    5,
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
 			},
 			mockFunc: func() {
 				m.EXPECT().LikePartner(partner.PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: true,
+					UserID: 1,
+					Plan:   models.PlanPremium,
 				}).Return(fmt.Errorf("some error"))
 			},
 			mockContext: func() (context.Context, func()) {
@@ -79,14 +146,14 @@ func TestPartnerHandler_LikePartnerHandler(t *testing.T) {
 		{
 			name: "error on service flow",
 			args: args{
-				userID:     1,
-				isVerified: true,
-				timeout:    5,
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
 			},
 			mockFunc: func() {
 				m.EXPECT().LikePartner(partner.PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: true,
+					UserID: 1,
+					Plan:   models.PlanPremium,
 				}).Return(fmt.Errorf("some error"))
 			},
 			mockContext: func() (context.Context, func()) {
@@ -98,7 +165,7 @@ func TestPartnerHandler_LikePartnerHandler(t *testing.T) {
 			},
 		},
 		{
-			name: "error on verified value flow",
+			name: "error on plan value flow",
 			args: args{
 				userID:  1,
 				timeout: 5,
@@ -142,8 +209,12 @@ func TestPartnerHandler_LikePartnerHandler(t *testing.T) {
 				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.userID))
 			}
 
-			if tt.args.isVerified {
-				r = r.WithContext(context.WithValue(r.Context(), "isverified", tt.args.isVerified))
+			if len(tt.args.plan) > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "plan", tt.args.plan))
+			}
+
+			if tt.args.isEmailVerified {
+				r = r.WithContext(context.WithValue(r.Context(), "isemailverified", tt.args.isEmailVerified))
 			}
 			w := httptest.NewRecorder()
 			handler.LikePartnerHandler(w, r)
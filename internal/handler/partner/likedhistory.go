@@ -13,7 +13,7 @@ import (
 	"time"
 )
 
-// LikedHistoryHandler is func handler for get current partner
+// LikedHistoryHandler is func handler for get liked or passed partner history
 func (h *PartnerHandler) LikedHistoryHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
 	defer cancel()
@@ -39,6 +39,27 @@ func (h *PartnerHandler) LikedHistoryHandler(w http.ResponseWriter, r *http.Requ
 		utilhttp.WriteResponse(w, data, code)
 	}()
 
+	historyType := r.URL.Query().Get("type")
+	if len(historyType) > 0 && historyType != historyTypeLiked && historyType != historyTypePassed {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Invalid Parameter Request")
+		return
+	}
+
+	limit, err := utilhttp.ParseQueryInt(r, "limit")
+	if err != nil {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Invalid Parameter Request")
+		return
+	}
+
+	order := r.URL.Query().Get("order")
+	if len(order) > 0 && order != sortOrderAsc && order != sortOrderDesc {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Invalid Parameter Request")
+		return
+	}
+
 	var userID int
 	var ok bool
 	userID, ok = r.Context().Value("id").(int)
@@ -48,8 +69,8 @@ func (h *PartnerHandler) LikedHistoryHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	var isVerified bool
-	isVerified, ok = r.Context().Value("isverified").(bool)
+	var plan string
+	plan, ok = r.Context().Value("plan").(string)
 	if !ok {
 		code = http.StatusInternalServerError
 		err = fmt.Errorf("Internal Server Error")
@@ -57,12 +78,21 @@ func (h *PartnerHandler) LikedHistoryHandler(w http.ResponseWriter, r *http.Requ
 	}
 
 	errChan := make(chan error, 1)
-	var partnerInfo []partner.PartnerServiceInfo
+	var result partner.HistoryServiceInfo
 	go func(ctx context.Context) {
-		partnerInfo, err = h.service.GetListLikedPartner(partner.PartnerServiceRequest{
-			UserID:     userID,
-			IsVerified: isVerified,
-		})
+		request := partner.HistoryServiceRequest{
+			UserID:  userID,
+			Plan:    plan,
+			Status:  r.URL.Query().Get("status"),
+			Cursor:  r.URL.Query().Get("cursor"),
+			Limit:   limit,
+			SortAsc: order == sortOrderAsc,
+		}
+		if historyType == historyTypePassed {
+			result, err = h.service.GetListPassedPartner(request)
+		} else {
+			result, err = h.service.GetListLikedPartner(request)
+		}
 		errChan <- err
 	}(ctx)
 
@@ -73,7 +103,9 @@ func (h *PartnerHandler) LikedHistoryHandler(w http.ResponseWriter, r *http.Requ
 		return
 	case err = <-errChan:
 		if err != nil {
-			if err == user.ErrUserNameNotExists || err == user.ErrPasswordIsIncorrect || strings.Contains(err.Error(), "not found") {
+			if err == partner.ErrInvalidHistoryCursor || err == partner.ErrInvalidHistoryStatus {
+				code = http.StatusBadRequest
+			} else if err == user.ErrUserNameNotExists || err == user.ErrPasswordIsIncorrect || strings.Contains(err.Error(), "not found") {
 				code = http.StatusNotFound
 				err = fmt.Errorf("Invalid Username or Password")
 			} else {
@@ -83,17 +115,17 @@ func (h *PartnerHandler) LikedHistoryHandler(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
-	response = mapListResponse(partnerInfo)
+	response = mapListResponse(result)
 }
 
-func mapListResponse(result []partner.PartnerServiceInfo) utilhttp.StandardResponse {
+func mapListResponse(result partner.HistoryServiceInfo) utilhttp.StandardResponse {
 	var res utilhttp.StandardResponse
 	var list []PartnerResponse
-	for _, data := range result {
+	for _, data := range result.Histories {
 		list = append(list, PartnerResponse{
 			PartnerID:   data.PartnerID,
 			Fullname:    data.Fullname,
-			Status:      data.Fullname,
+			Status:      data.Status,
 			CreatedDate: data.CreatedDate,
 		})
 	}
@@ -101,6 +133,7 @@ func mapListResponse(result []partner.PartnerServiceInfo) utilhttp.StandardRespo
 	if len(list) > 0 {
 		res.Data = list
 	}
+	res.NextCursor = result.NextCursor
 
 	return res
 }
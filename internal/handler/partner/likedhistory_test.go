@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"gilsaputro/dating-apps/internal/service/partner"
 	"gilsaputro/dating-apps/internal/service/partner/mock"
+	"gilsaputro/dating-apps/models"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -19,9 +20,11 @@ func TestPartnerHandler_LikedHistoryHandler(t *testing.T) {
 	m := mock.NewMockPartnerServiceMethod(mockCtrl)
 	defer mockCtrl.Finish()
 	type args struct {
-		userID     int
-		isVerified bool
-		timeout    int
+		userID      int
+		plan        string
+		historyType string
+		query       string
+		timeout     int
 	}
 	type want struct {
 		body string
@@ -37,20 +40,22 @@ func TestPartnerHandler_LikedHistoryHandler(t *testing.T) {
 		{
 			name: "success flow",
 			args: args{
-				userID:     1,
-				isVerified: true,
-				timeout:    5,
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
 			},
 			mockFunc: func() {
-				m.EXPECT().GetListLikedPartner(partner.PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: true,
-				}).Return([]partner.PartnerServiceInfo{
-					{
-						PartnerID:  1,
-						Fullname:   "full",
-						IsVerified: true,
-						Status:     "PENDING",
+				m.EXPECT().GetListLikedPartner(partner.HistoryServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				}).Return(partner.HistoryServiceInfo{
+					Histories: []partner.PartnerServiceInfo{
+						{
+							PartnerID: 1,
+							Fullname:  "full",
+							IsPremium: true,
+							Status:    "PENDING",
+						},
 					},
 				}, nil)
 			},
@@ -59,21 +64,21 @@ func TestPartnerHandler_LikedHistoryHandler(t *testing.T) {
 			},
 			want: want{
 				code: 200,
-				body: `{"data":[{"id":1,"fullname":"full","status":"full","is_verified":false,"created_date":""}],"code":200,"message":"success"}`,
+				body: `{"data":[{"id":1,"fullname":"full","status":"PENDING","is_premium":false,"is_verified":false,"created_date":""}],"code":200,"message":"success"}`,
 			},
 		},
 		{
 			name: "error on service flow",
 			args: args{
-				userID:     1,
-				isVerified: true,
-				timeout:    5,
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
 			},
 			mockFunc: func() {
-				m.EXPECT().GetListLikedPartner(partner.PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: true,
-				}).Return([]partner.PartnerServiceInfo{}, fmt.Errorf("some error"))
+				m.EXPECT().GetListLikedPartner(partner.HistoryServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				}).Return(partner.HistoryServiceInfo{}, fmt.Errorf("some error"))
 			},
 			mockContext: func() (context.Context, func()) {
 				return context.Background(), func() {}
@@ -86,15 +91,15 @@ func TestPartnerHandler_LikedHistoryHandler(t *testing.T) {
 		{
 			name: "error on service flow",
 			args: args{
-				userID:     1,
-				isVerified: true,
-				timeout:    5,
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
 			},
 			mockFunc: func() {
-				m.EXPECT().GetListLikedPartner(partner.PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: true,
-				}).Return([]partner.PartnerServiceInfo{}, fmt.Errorf("some error"))
+				m.EXPECT().GetListLikedPartner(partner.HistoryServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				}).Return(partner.HistoryServiceInfo{}, fmt.Errorf("some error"))
 			},
 			mockContext: func() (context.Context, func()) {
 				return context.Background(), func() {}
@@ -105,7 +110,149 @@ func TestPartnerHandler_LikedHistoryHandler(t *testing.T) {
 			},
 		},
 		{
-			name: "error on verified value flow",
+			name: "success passed history flow",
+			args: args{
+				userID:      1,
+				plan:        models.PlanPremium,
+				historyType: "passed",
+				timeout:     5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetListPassedPartner(partner.HistoryServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				}).Return(partner.HistoryServiceInfo{
+					Histories: []partner.PartnerServiceInfo{
+						{
+							PartnerID: 2,
+							Fullname:  "full",
+							Status:    partner.StatusPassed,
+						},
+					},
+				}, nil)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 200,
+				body: `{"data":[{"id":2,"fullname":"full","status":"PASSED","is_premium":false,"is_verified":false,"created_date":""}],"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "success paginated flow",
+			args: args{
+				userID:  1,
+				plan:    models.PlanPremium,
+				query:   "&status=APPROVED&limit=1&cursor=abc&order=asc",
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetListLikedPartner(partner.HistoryServiceRequest{
+					UserID:  1,
+					Plan:    models.PlanPremium,
+					Status:  "APPROVED",
+					Cursor:  "abc",
+					Limit:   1,
+					SortAsc: true,
+				}).Return(partner.HistoryServiceInfo{
+					Histories: []partner.PartnerServiceInfo{
+						{
+							PartnerID: 3,
+							Fullname:  "full",
+							Status:    "APPROVED",
+						},
+					},
+					NextCursor: "def",
+				}, nil)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 200,
+				body: `{"data":[{"id":3,"fullname":"full","status":"APPROVED","is_premium":false,"is_verified":false,"created_date":""}],"code":200,"message":"success","next_cursor":"def"}`,
+			},
+		},
+		{
+			name: "error invalid cursor flow",
+			args: args{
+				userID:  1,
+				plan:    models.PlanPremium,
+				query:   "&cursor=abc",
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetListLikedPartner(partner.HistoryServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+					Cursor: "abc",
+				}).Return(partner.HistoryServiceInfo{}, partner.ErrInvalidHistoryCursor)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"the history cursor is invalid"}`,
+			},
+		},
+		{
+			name: "error invalid limit flow",
+			args: args{
+				userID:  1,
+				plan:    models.PlanPremium,
+				query:   "&limit=abc",
+				timeout: 5,
+			},
+			mockFunc: func() {
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error invalid order flow",
+			args: args{
+				userID:  1,
+				plan:    models.PlanPremium,
+				query:   "&order=random",
+				timeout: 5,
+			},
+			mockFunc: func() {
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error invalid history type flow",
+			args: args{
+				userID:      1,
+				plan:        models.PlanPremium,
+				historyType: "unknown",
+				timeout:     5,
+			},
+			mockFunc: func() {
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error on plan value flow",
 			args: args{
 				userID:  1,
 				timeout: 5,
@@ -141,7 +288,7 @@ func TestPartnerHandler_LikedHistoryHandler(t *testing.T) {
 			tt.mockFunc()
 			defer mockCtrl.Finish()
 			handler := NewPartnerHandler(m, WithTimeoutOptions(tt.args.timeout))
-			r := httptest.NewRequest(http.MethodGet, "/user", strings.NewReader(``))
+			r := httptest.NewRequest(http.MethodGet, "/v1/partner/history?type="+tt.args.historyType+tt.args.query, strings.NewReader(``))
 			ctx, cancel := tt.mockContext()
 			defer cancel()
 			r = r.WithContext(ctx)
@@ -149,8 +296,8 @@ func TestPartnerHandler_LikedHistoryHandler(t *testing.T) {
 				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.userID))
 			}
 
-			if tt.args.isVerified {
-				r = r.WithContext(context.WithValue(r.Context(), "isverified", tt.args.isVerified))
+			if len(tt.args.plan) > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "plan", tt.args.plan))
 			}
 			w := httptest.NewRecorder()
 			handler.LikedHistoryHandler(w, r)
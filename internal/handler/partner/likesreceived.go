@@ -0,0 +1,104 @@
+package partner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/partner"
+	"log"
+	"net/http"
+	"time"
+)
+
+// LikesReceivedHandler is func handler for get list pending like received by the user
+func (h *PartnerHandler) LikesReceivedHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		response.Code = code
+		if err == nil {
+			response.Message = "success"
+		} else {
+			response.Message = err.Error()
+		}
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[PartnerPartnerHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	var userID int
+	var ok bool
+	userID, ok = r.Context().Value("id").(int)
+	if !ok {
+		code = http.StatusInternalServerError
+		err = fmt.Errorf("Internal Server Error")
+		return
+	}
+
+	var plan string
+	plan, ok = r.Context().Value("plan").(string)
+	if !ok {
+		code = http.StatusInternalServerError
+		err = fmt.Errorf("Internal Server Error")
+		return
+	}
+
+	errChan := make(chan error, 1)
+	var likesInfo partner.LikesReceivedServiceInfo
+	go func(ctx context.Context) {
+		likesInfo, err = h.service.GetListLikesReceived(partner.PartnerServiceRequest{
+			UserID: userID,
+			Plan:   plan,
+		})
+		errChan <- err
+	}(ctx)
+
+	select {
+	case <-ctx.Done():
+		code = http.StatusGatewayTimeout
+		err = fmt.Errorf("Timeout")
+		return
+	case err = <-errChan:
+		if err != nil {
+			code = http.StatusInternalServerError
+			return
+		}
+	}
+
+	response = mapLikesReceivedResponse(likesInfo)
+}
+
+func mapLikesReceivedResponse(result partner.LikesReceivedServiceInfo) utilhttp.StandardResponse {
+	var res utilhttp.StandardResponse
+	list := []PartnerResponse{}
+	for _, data := range result.Likes {
+		list = append(list, PartnerResponse{
+			PartnerID:    data.PartnerID,
+			Fullname:     data.Fullname,
+			Status:       data.Status,
+			IsPremium:    data.IsPremium,
+			IsVerified:   data.IsVerified,
+			CreatedDate:  data.CreatedDate,
+			Distance:     data.Distance,
+			IsSuperLiked: data.IsSuperLiked,
+		})
+	}
+
+	res.Data = LikesReceivedResponse{
+		Likes:      list,
+		Total:      result.Total,
+		IsRedacted: result.IsRedacted,
+	}
+	return res
+}
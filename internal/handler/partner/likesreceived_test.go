@@ -0,0 +1,188 @@
+package partner
+
+import (
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/partner"
+	"gilsaputro/dating-apps/internal/service/partner/mock"
+	"gilsaputro/dating-apps/models"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestPartnerHandler_LikesReceivedHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockPartnerServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		userID  int
+		plan    string
+		timeout int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		mockFunc    func()
+		mockContext func() (context.Context, func())
+		want        want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetListLikesReceived(partner.PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				}).Return(partner.LikesReceivedServiceInfo{
+					Likes: []partner.PartnerServiceInfo{
+						{
+							PartnerID:    2,
+							Fullname:     "full",
+							Status:       "PENDING",
+							IsPremium:    true,
+							CreatedDate:  "date",
+							IsSuperLiked: true,
+						},
+					},
+					Total: 1,
+				}, nil)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"likes":[{"id":2,"fullname":"full","status":"PENDING","is_premium":true,"is_verified":false,"created_date":"date","is_super_liked":true}],"total":1,"is_redacted":false},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "success redacted flow",
+			args: args{
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetListLikesReceived(partner.PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				}).Return(partner.LikesReceivedServiceInfo{
+					Likes: []partner.PartnerServiceInfo{
+						{
+							Status:      "PENDING",
+							CreatedDate: "date",
+						},
+					},
+					Total:      1,
+					IsRedacted: true,
+				}, nil)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"likes":[{"id":0,"fullname":"","status":"PENDING","is_premium":false,"is_verified":false,"created_date":"date"}],"total":1,"is_redacted":true},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetListLikesReceived(partner.PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				}).Return(partner.LikesReceivedServiceInfo{}, fmt.Errorf("some error"))
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error on plan value flow",
+			args: args{
+				userID:  1,
+				timeout: 5,
+			},
+			mockFunc: func() {
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+		{
+			name: "error on userid value flow",
+			args: args{
+				timeout: 5,
+			},
+			mockFunc: func() {
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			defer mockCtrl.Finish()
+			handler := NewPartnerHandler(m, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodGet, "/user", strings.NewReader(``))
+			ctx, cancel := tt.mockContext()
+			defer cancel()
+			r = r.WithContext(ctx)
+			if tt.args.userID > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.userID))
+			}
+
+			if len(tt.args.plan) > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "plan", tt.args.plan))
+			}
+			w := httptest.NewRecorder()
+			handler.LikesReceivedHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("LikesReceivedHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("LikesReceivedHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package partner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/partner"
+	"log"
+	"net/http"
+	"time"
+)
+
+// MatchListHandler is func handler for get list match of the user
+func (h *PartnerHandler) MatchListHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		response.Code = code
+		if err == nil {
+			response.Message = "success"
+		} else {
+			response.Message = err.Error()
+		}
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[MatchListHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	page, err := utilhttp.ParseQueryInt(r, "page")
+	if err != nil {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Invalid Parameter Request")
+		return
+	}
+
+	limit, err := utilhttp.ParseQueryInt(r, "limit")
+	if err != nil {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Invalid Parameter Request")
+		return
+	}
+
+	var userID int
+	var ok bool
+	userID, ok = r.Context().Value("id").(int)
+	if !ok {
+		code = http.StatusInternalServerError
+		err = fmt.Errorf("Internal Server Error")
+		return
+	}
+
+	errChan := make(chan error, 1)
+	var result partner.MatchListServiceInfo
+	go func(ctx context.Context) {
+		result, err = h.service.GetListMatch(partner.MatchListServiceRequest{
+			UserID: userID,
+			Page:   page,
+			Limit:  limit,
+		})
+		errChan <- err
+	}(ctx)
+
+	select {
+	case <-ctx.Done():
+		code = http.StatusGatewayTimeout
+		err = fmt.Errorf("Timeout")
+		return
+	case err = <-errChan:
+		if err != nil {
+			code = http.StatusInternalServerError
+			return
+		}
+	}
+
+	response = mapMatchListResponse(result)
+}
+
+func mapMatchListResponse(result partner.MatchListServiceInfo) utilhttp.StandardResponse {
+	var res utilhttp.StandardResponse
+	list := []MatchResponse{}
+	for _, data := range result.Matches {
+		list = append(list, MatchResponse{
+			MatchID: data.MatchID,
+			Partner: PartnerResponse{
+				PartnerID:   data.Partner.PartnerID,
+				Fullname:    data.Partner.Fullname,
+				Status:      data.Partner.Status,
+				IsPremium:   data.Partner.IsPremium,
+				IsVerified:  data.Partner.IsVerified,
+				CreatedDate: data.Partner.CreatedDate,
+				Distance:    data.Partner.Distance,
+			},
+			MatchedDate: data.MatchedDate,
+		})
+	}
+
+	res.Data = MatchListResponse{
+		Matches: list,
+		Pagination: Pagination{
+			Page:  result.Page,
+			Limit: result.Limit,
+			Total: result.Total,
+		},
+	}
+	return res
+}
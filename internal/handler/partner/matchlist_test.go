@@ -0,0 +1,192 @@
+package partner
+
+import (
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/partner"
+	"gilsaputro/dating-apps/internal/service/partner/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestPartnerHandler_MatchListHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockPartnerServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		userID  int
+		query   string
+		timeout int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		mockFunc    func()
+		mockContext func() (context.Context, func())
+		want        want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				userID:  1,
+				query:   "?page=2&limit=1",
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetListMatch(partner.MatchListServiceRequest{
+					UserID: 1,
+					Page:   2,
+					Limit:  1,
+				}).Return(partner.MatchListServiceInfo{
+					Matches: []partner.MatchServiceInfo{
+						{
+							MatchID: 10,
+							Partner: partner.PartnerServiceInfo{
+								PartnerID: 2,
+								Fullname:  "full",
+								IsPremium: true,
+								Status:    "APPROVED",
+							},
+							MatchedDate: "2023-06-15",
+						},
+					},
+					Page:  2,
+					Limit: 1,
+					Total: 3,
+				}, nil)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"matches":[{"id":10,"partner":{"id":2,"fullname":"full","status":"APPROVED","is_premium":true,"is_verified":false,"created_date":""},"matched_date":"2023-06-15"}],"pagination":{"page":2,"limit":1,"total":3}},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "success empty flow",
+			args: args{
+				userID:  1,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetListMatch(partner.MatchListServiceRequest{
+					UserID: 1,
+				}).Return(partner.MatchListServiceInfo{
+					Page:  1,
+					Limit: 10,
+				}, nil)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"matches":[],"pagination":{"page":1,"limit":10,"total":0}},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				userID:  1,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetListMatch(partner.MatchListServiceRequest{
+					UserID: 1,
+				}).Return(partner.MatchListServiceInfo{}, fmt.Errorf("some error"))
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error invalid page",
+			args: args{
+				userID:  1,
+				query:   "?page=abc",
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error invalid limit",
+			args: args{
+				userID:  1,
+				query:   "?limit=-1",
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error missing user id",
+			args: args{
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			defer mockCtrl.Finish()
+			handler := NewPartnerHandler(m, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodGet, "/v1/matches"+tt.args.query, nil)
+			ctx, cancel := tt.mockContext()
+			defer cancel()
+			r = r.WithContext(ctx)
+			if tt.args.userID > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.userID))
+			}
+			w := httptest.NewRecorder()
+			handler.MatchListHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("MatchListHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("MatchListHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
@@ -46,8 +46,8 @@ func (h *PartnerHandler) PassPartnerHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	var isVerified bool
-	isVerified, ok = r.Context().Value("isverified").(bool)
+	var plan string
+	plan, ok = r.Context().Value("plan").(string)
 	if !ok {
 		code = http.StatusInternalServerError
 		err = fmt.Errorf("Internal Server Error")
@@ -58,8 +58,8 @@ func (h *PartnerHandler) PassPartnerHandler(w http.ResponseWriter, r *http.Reque
 	var partnerInfo partner.PartnerServiceInfo
 	go func(ctx context.Context) {
 		partnerInfo, err = h.service.PassPartner(partner.PartnerServiceRequest{
-			UserID:     userID,
-			IsVerified: isVerified,
+			UserID: userID,
+			Plan:   plan,
 		})
 		errChan <- err
 	}(ctx)
@@ -71,7 +71,11 @@ func (h *PartnerHandler) PassPartnerHandler(w http.ResponseWriter, r *http.Reque
 		return
 	case err = <-errChan:
 		if err != nil {
-			code = http.StatusInternalServerError
+			if err == partner.ErrNoPartnerAvailable {
+				code = http.StatusNotFound
+			} else {
+				code = http.StatusInternalServerError
+			}
 			return
 		}
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"gilsaputro/dating-apps/internal/service/partner"
 	"gilsaputro/dating-apps/internal/service/partner/mock"
+	"gilsaputro/dating-apps/models"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -19,9 +20,9 @@ func TestPartnerHandler_PassPartnerHandler(t *testing.T) {
 	m := mock.NewMockPartnerServiceMethod(mockCtrl)
 	defer mockCtrl.Finish()
 	type args struct {
-		userID     int
-		isVerified bool
-		timeout    int
+		userID  int
+		plan    string
+		timeout int
 	}
 	type want struct {
 		body string
@@ -37,19 +38,19 @@ func TestPartnerHandler_PassPartnerHandler(t *testing.T) {
 		{
 			name: "success flow",
 			args: args{
-				userID:     1,
-				isVerified: true,
-				timeout:    5,
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
 			},
 			mockFunc: func() {
 				m.EXPECT().PassPartner(partner.PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: true,
+					UserID: 1,
+					Plan:   models.PlanPremium,
 				}).Return(partner.PartnerServiceInfo{
-					PartnerID:  1,
-					Fullname:   "full",
-					IsVerified: true,
-					Status:     "PENDING",
+					PartnerID: 1,
+					Fullname:  "full",
+					IsPremium: true,
+					Status:    "PENDING",
 				}, nil)
 			},
 			mockContext: func() (context.Context, func()) {
@@ -57,20 +58,20 @@ func TestPartnerHandler_PassPartnerHandler(t *testing.T) {
 			},
 			want: want{
 				code: 200,
-				body: `{"data":{"id":1,"fullname":"full","status":"PENDING","is_verified":true,"created_date":""},"code":200,"message":"success"}`,
+				body: `{"data":{"id":1,"fullname":"full","status":"PENDING","is_premium":true,"is_verified":false,"created_date":""},"code":200,"message":"success"}`,
 			},
 		},
 		{
 			name: "error on service flow",
 			args: args{
-				userID:     1,
-				isVerified: true,
-				timeout:    5,
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
 			},
 			mockFunc: func() {
 				m.EXPECT().PassPartner(partner.PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: true,
+					UserID: 1,
+					Plan:   models.PlanPremium,
 				}).Return(partner.PartnerServiceInfo{}, fmt.Errorf("some error"))
 			},
 			mockContext: func() (context.Context, func()) {
@@ -84,14 +85,14 @@ func TestPartnerHandler_PassPartnerHandler(t *testing.T) {
 		{
 			name: "error on service flow",
 			args: args{
-				userID:     1,
-				isVerified: true,
-				timeout:    5,
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
 			},
 			mockFunc: func() {
 				m.EXPECT().PassPartner(partner.PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: true,
+					UserID: 1,
+					Plan:   models.PlanPremium,
 				}).Return(partner.PartnerServiceInfo{}, fmt.Errorf("some error"))
 			},
 			mockContext: func() (context.Context, func()) {
@@ -103,7 +104,28 @@ func TestPartnerHandler_PassPartnerHandler(t *testing.T) {
 			},
 		},
 		{
-			name: "error on verified value flow",
+			name: "error no partner available flow",
+			args: args{
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().PassPartner(partner.PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				}).Return(partner.PartnerServiceInfo{}, partner.ErrNoPartnerAvailable)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 404,
+				body: `{"code":404,"message":"there is no partner available for the user right now"}`,
+			},
+		},
+		{
+			name: "error on plan value flow",
 			args: args{
 				userID:  1,
 				timeout: 5,
@@ -147,8 +169,8 @@ func TestPartnerHandler_PassPartnerHandler(t *testing.T) {
 				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.userID))
 			}
 
-			if tt.args.isVerified {
-				r = r.WithContext(context.WithValue(r.Context(), "isverified", tt.args.isVerified))
+			if len(tt.args.plan) > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "plan", tt.args.plan))
 			}
 			w := httptest.NewRecorder()
 			handler.PassPartnerHandler(w, r)
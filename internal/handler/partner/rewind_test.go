@@ -0,0 +1,214 @@
+package partner
+
+import (
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/partner"
+	"gilsaputro/dating-apps/internal/service/partner/mock"
+	"gilsaputro/dating-apps/models"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestPartnerHandler_RewindPartnerHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockPartnerServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		userID  int
+		plan    string
+		timeout int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		mockFunc    func()
+		mockContext func() (context.Context, func())
+		want        want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().RewindPartner(partner.PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				}).Return(partner.PartnerServiceInfo{
+					PartnerID: 1,
+					Fullname:  "full",
+					IsPremium: true,
+					Status:    "PENDING",
+				}, nil)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"id":1,"fullname":"full","status":"PENDING","is_premium":true,"is_verified":false,"created_date":""},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().RewindPartner(partner.PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				}).Return(partner.PartnerServiceInfo{}, fmt.Errorf("some error"))
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error rewind not allowed flow",
+			args: args{
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().RewindPartner(partner.PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				}).Return(partner.PartnerServiceInfo{}, partner.ErrRewindNotAllowed)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 403,
+				body: `{"code":403,"message":"rewind is not available in the user plan"}`,
+			},
+		},
+		{
+			name: "error nothing to rewind flow",
+			args: args{
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().RewindPartner(partner.PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				}).Return(partner.PartnerServiceInfo{}, partner.ErrNothingToRewind)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 404,
+				body: `{"code":404,"message":"there is no swipe to rewind"}`,
+			},
+		},
+		{
+			name: "error rewind matched partner flow",
+			args: args{
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().RewindPartner(partner.PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				}).Return(partner.PartnerServiceInfo{}, partner.ErrRewindMatchedPartner)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 409,
+				body: `{"code":409,"message":"the like already become a match and cannot be rewound"}`,
+			},
+		},
+		{
+			name: "error on plan value flow",
+			args: args{
+				userID:  1,
+				timeout: 5,
+			},
+			mockFunc: func() {
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+		{
+			name: "error on userid value flow",
+			args: args{
+				timeout: 5,
+			},
+			mockFunc: func() {
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			defer mockCtrl.Finish()
+			handler := NewPartnerHandler(m, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodPost, "/user", strings.NewReader(``))
+			ctx, cancel := tt.mockContext()
+			defer cancel()
+			r = r.WithContext(ctx)
+			if tt.args.userID > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.userID))
+			}
+
+			if len(tt.args.plan) > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "plan", tt.args.plan))
+			}
+			w := httptest.NewRecorder()
+			handler.RewindPartnerHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("RewindPartnerHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("RewindPartnerHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
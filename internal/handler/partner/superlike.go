@@ -0,0 +1,89 @@
+package partner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/partner"
+	"log"
+	"net/http"
+	"time"
+)
+
+// SuperLikePartnerHandler is func handler for super like current partner
+func (h *PartnerHandler) SuperLikePartnerHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		response.Code = code
+		if err == nil {
+			response.Message = "success"
+		} else {
+			response.Message = err.Error()
+		}
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[PartnerPartnerHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	var userID int
+	var ok bool
+	userID, ok = r.Context().Value("id").(int)
+	if !ok {
+		code = http.StatusInternalServerError
+		err = fmt.Errorf("Internal Server Error")
+		return
+	}
+
+	var plan string
+	plan, ok = r.Context().Value("plan").(string)
+	if !ok {
+		code = http.StatusInternalServerError
+		err = fmt.Errorf("Internal Server Error")
+		return
+	}
+
+	// the user with unverified email is not allowed to like, missing value is treated as unverified
+	isEmailVerified, _ := r.Context().Value("isemailverified").(bool)
+
+	errChan := make(chan error, 1)
+	go func(ctx context.Context) {
+		err = h.service.SuperLikePartner(partner.PartnerServiceRequest{
+			UserID:          userID,
+			Plan:            plan,
+			IsEmailVerified: isEmailVerified,
+		})
+		errChan <- err
+	}(ctx)
+
+	select {
+	case <-ctx.Done():
+		code = http.StatusGatewayTimeout
+		err = fmt.Errorf("Timeout")
+		return
+	case err = <-errChan:
+		if err != nil {
+			if err == partner.ErrReachedMaxSuperLikeQuota {
+				code = http.StatusTooManyRequests
+			} else if err == partner.ErrPartnerIsBlocked {
+				code = http.StatusConflict
+			} else if err == partner.ErrEmailNotVerified {
+				code = http.StatusForbidden
+			} else {
+				code = http.StatusInternalServerError
+			}
+			return
+		}
+	}
+}
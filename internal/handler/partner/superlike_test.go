@@ -0,0 +1,258 @@
+package partner
+
+import (
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/partner"
+	"gilsaputro/dating-apps/internal/service/partner/mock"
+	"gilsaputro/dating-apps/models"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestPartnerHandler_SuperLikePartnerHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockPartnerServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		userID          int
+		plan            string
+		isEmailVerified bool
+		timeout         int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		mockFunc    func()
+		mockContext func() (context.Context, func())
+		want        want
+	}{
+		{
+			name: "success email verified flow",
+			args: args{
+				userID:          1,
+				plan:            models.PlanPremium,
+				isEmailVerified: true,
+				timeout:         5,
+			},
+			mockFunc: func() {
+				m.EXPECT().SuperLikePartner(partner.PartnerServiceRequest{
+					UserID:          1,
+					Plan:            models.PlanPremium,
+					IsEmailVerified: true,
+				}).Return(nil)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 200,
+				body: `{"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error email not verified flow",
+			args: args{
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().SuperLikePartner(partner.PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				}).Return(partner.ErrEmailNotVerified)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 403,
+				body: `{"code":403,"message":"please verify your email before like a partner"}`,
+			},
+		},
+		{
+			name: "success flow",
+			args: args{
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().SuperLikePartner(partner.PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				}).Return(nil)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 200,
+				body: `{"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error reach max quota flow",
+			args: args{
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().SuperLikePartner(partner.PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				}).Return(partner.ErrReachedMaxSuperLikeQuota)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 429,
+				body: `{"code":429,"message":"the user already reach max quota for super like"}`,
+			},
+		},
+		{
+			name: "error partner is blocked flow",
+			args: args{
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().SuperLikePartner(partner.PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				}).Return(partner.ErrPartnerIsBlocked)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 409,
+				body: `{"code":409,"message":"the partner is no longer available"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().SuperLikePartner(partner.PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				}).Return(fmt.Errorf("some error"))
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				userID:  1,
+				plan:    models.PlanPremium,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().SuperLikePartner(partner.PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				}).Return(fmt.Errorf("some error"))
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error on plan value flow",
+			args: args{
+				userID:  1,
+				timeout: 5,
+			},
+			mockFunc: func() {
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+		{
+			name: "error on userid value flow",
+			args: args{
+				timeout: 5,
+			},
+			mockFunc: func() {
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			defer mockCtrl.Finish()
+			handler := NewPartnerHandler(m, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodGet, "/user", strings.NewReader(``))
+			ctx, cancel := tt.mockContext()
+			defer cancel()
+			r = r.WithContext(ctx)
+			if tt.args.userID > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.userID))
+			}
+
+			if len(tt.args.plan) > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "plan", tt.args.plan))
+			}
+
+			if tt.args.isEmailVerified {
+				r = r.WithContext(context.WithValue(r.Context(), "isemailverified", tt.args.isEmailVerified))
+			}
+			w := httptest.NewRecorder()
+			handler.SuperLikePartnerHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("SuperLikePartnerHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("SuperLikePartnerHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
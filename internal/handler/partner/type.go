@@ -5,24 +5,69 @@ import (
 	"gilsaputro/dating-apps/internal/service/partner"
 )
 
+// list supported partner history type
+const (
+	historyTypeLiked  = "liked"
+	historyTypePassed = "passed"
+)
+
+// list supported sort order of the created date
+const (
+	sortOrderAsc  = "asc"
+	sortOrderDesc = "desc"
+)
+
 // PartnerPartnerResponse is list response parameter for Login Api
 type PartnerResponse struct {
-	PartnerID   int    `json:"id"`
-	Fullname    string `json:"fullname"`
-	Status      string `json:"status"`
-	IsVerified  bool   `json:"is_verified"`
-	CreatedDate string `json:"created_date"`
+	PartnerID    int    `json:"id"`
+	Fullname     string `json:"fullname"`
+	Status       string `json:"status"`
+	IsPremium    bool   `json:"is_premium"`
+	IsVerified   bool   `json:"is_verified"`
+	CreatedDate  string `json:"created_date"`
+	Distance     *int   `json:"distance_km,omitempty"`
+	IsSuperLiked bool   `json:"is_super_liked,omitempty"`
 }
 
 func mapResponse(result partner.PartnerServiceInfo) utilhttp.StandardResponse {
 	var res utilhttp.StandardResponse
 	data := PartnerResponse{
-		PartnerID:   result.PartnerID,
-		Fullname:    result.Fullname,
-		Status:      result.Status,
-		IsVerified:  result.IsVerified,
-		CreatedDate: result.CreatedDate,
+		PartnerID:    result.PartnerID,
+		Fullname:     result.Fullname,
+		Status:       result.Status,
+		IsPremium:    result.IsPremium,
+		IsVerified:   result.IsVerified,
+		CreatedDate:  result.CreatedDate,
+		Distance:     result.Distance,
+		IsSuperLiked: result.IsSuperLiked,
 	}
 	res.Data = data
 	return res
 }
+
+// LikesReceivedResponse is list response parameter for Likes Received Api
+type LikesReceivedResponse struct {
+	Likes      []PartnerResponse `json:"likes"`
+	Total      int               `json:"total"`
+	IsRedacted bool              `json:"is_redacted"`
+}
+
+// MatchResponse is list response parameter for a match
+type MatchResponse struct {
+	MatchID     int             `json:"id"`
+	Partner     PartnerResponse `json:"partner"`
+	MatchedDate string          `json:"matched_date"`
+}
+
+// MatchListResponse is list response parameter for Match List Api
+type MatchListResponse struct {
+	Matches    []MatchResponse `json:"matches"`
+	Pagination Pagination      `json:"pagination"`
+}
+
+// Pagination is list pagination info of the list response
+type Pagination struct {
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+	Total int `json:"total"`
+}
@@ -0,0 +1,163 @@
+package partner
+
+import (
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/partner"
+	"gilsaputro/dating-apps/internal/service/partner/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+func TestPartnerHandler_UnmatchPartnerHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockPartnerServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		userID    int
+		partnerID string
+		timeout   int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		mockFunc    func()
+		mockContext func() (context.Context, func())
+		want        want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				userID:    1,
+				partnerID: "2",
+				timeout:   5,
+			},
+			mockFunc: func() {
+				m.EXPECT().UnmatchPartner(partner.UnmatchServiceRequest{
+					UserID:    1,
+					PartnerID: 2,
+				}).Return(nil)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 200,
+				body: `{"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error match not found",
+			args: args{
+				userID:    1,
+				partnerID: "2",
+				timeout:   5,
+			},
+			mockFunc: func() {
+				m.EXPECT().UnmatchPartner(partner.UnmatchServiceRequest{
+					UserID:    1,
+					PartnerID: 2,
+				}).Return(partner.ErrMatchNotFound)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 404,
+				body: `{"code":404,"message":"the user does not have match with the partner"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				userID:    1,
+				partnerID: "2",
+				timeout:   5,
+			},
+			mockFunc: func() {
+				m.EXPECT().UnmatchPartner(partner.UnmatchServiceRequest{
+					UserID:    1,
+					PartnerID: 2,
+				}).Return(fmt.Errorf("some error"))
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error invalid partner id",
+			args: args{
+				userID:    1,
+				partnerID: "abc",
+				timeout:   5,
+			},
+			mockFunc: func() {},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error missing user id",
+			args: args{
+				partnerID: "2",
+				timeout:   5,
+			},
+			mockFunc: func() {},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			defer mockCtrl.Finish()
+			handler := NewPartnerHandler(m, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodPost, "/v1/matches/"+tt.args.partnerID+"/unmatch", nil)
+			ctx, cancel := tt.mockContext()
+			defer cancel()
+			r = r.WithContext(ctx)
+			if tt.args.userID > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.userID))
+			}
+			r = mux.SetURLVars(r, map[string]string{"partnerID": tt.args.partnerID})
+			w := httptest.NewRecorder()
+			handler.UnmatchPartnerHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("UnmatchPartnerHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("UnmatchPartnerHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
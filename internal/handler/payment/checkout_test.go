@@ -0,0 +1,178 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/payment"
+	"gilsaputro/dating-apps/internal/service/payment/mock"
+	"gilsaputro/dating-apps/models"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestPaymentHandler_CheckoutHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockPaymentServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		userID  int
+		body    string
+		timeout int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				userID:  1,
+				body:    `{"plan":"PREMIUM"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().CreateCheckout(payment.CheckoutServiceRequest{
+					UserId: 1,
+					Plan:   models.PlanPremium,
+				}).Return(payment.CheckoutServiceInfo{
+					SessionID: "cs_1",
+					URL:       "https://checkout.stripe.com/c/pay/cs_1",
+				}, nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"session_id":"cs_1","checkout_url":"https://checkout.stripe.com/c/pay/cs_1"},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error invalid plan flow",
+			args: args{
+				userID:  1,
+				body:    `{"plan":"FREE"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().CreateCheckout(payment.CheckoutServiceRequest{
+					UserId: 1,
+					Plan:   models.PlanFree,
+				}).Return(payment.CheckoutServiceInfo{}, payment.ErrInvalidPlan)
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"plan is invalid, the value should be PLUS or PREMIUM"}`,
+			},
+		},
+		{
+			name: "error already subscribed flow",
+			args: args{
+				userID:  1,
+				body:    `{"plan":"PREMIUM"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().CreateCheckout(payment.CheckoutServiceRequest{
+					UserId: 1,
+					Plan:   models.PlanPremium,
+				}).Return(payment.CheckoutServiceInfo{}, payment.ErrAlreadySubscribed)
+			},
+			want: want{
+				code: 409,
+				body: `{"code":409,"message":"user already subscribed to the plan"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				userID:  1,
+				body:    `{"plan":"PREMIUM"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().CreateCheckout(payment.CheckoutServiceRequest{
+					UserId: 1,
+					Plan:   models.PlanPremium,
+				}).Return(payment.CheckoutServiceInfo{}, fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error empty plan",
+			args: args{
+				userID:  1,
+				body:    `{}`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error invalid body",
+			args: args{
+				userID:  1,
+				body:    `{`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Bad Request"}`,
+			},
+		},
+		{
+			name: "error missing user id",
+			args: args{
+				body:    `{"plan":"PREMIUM"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			defer mockCtrl.Finish()
+			handler := NewPaymentHandler(m, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodPost, "/v1/user/subscription/checkout", bytes.NewReader([]byte(tt.args.body)))
+			if tt.args.userID > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.userID))
+			}
+			w := httptest.NewRecorder()
+			handler.CheckoutHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("CheckoutHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("CheckoutHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
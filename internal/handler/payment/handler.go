@@ -0,0 +1,44 @@
+package payment
+
+import (
+	"gilsaputro/dating-apps/internal/service/payment"
+)
+
+// PaymentHandler list dependencies for payment handler
+type PaymentHandler struct {
+	service      payment.PaymentServiceMethod
+	timeoutInSec int
+}
+
+// Option set options for http handler config
+type Option func(*PaymentHandler)
+
+const (
+	defaultTimeout = 5
+)
+
+// NewPaymentHandler is func to create http payment handler
+func NewPaymentHandler(service payment.PaymentServiceMethod, options ...Option) *PaymentHandler {
+	handler := &PaymentHandler{
+		service:      service,
+		timeoutInSec: defaultTimeout,
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(handler)
+	}
+
+	return handler
+}
+
+// WithTimeoutOptions is func to set timeout config into handler
+func WithTimeoutOptions(timeoutinsec int) Option {
+	return Option(
+		func(h *PaymentHandler) {
+			if timeoutinsec <= 0 {
+				timeoutinsec = defaultTimeout
+			}
+			h.timeoutInSec = timeoutinsec
+		})
+}
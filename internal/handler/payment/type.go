@@ -0,0 +1,42 @@
+package payment
+
+import (
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/payment"
+	"net/http"
+)
+
+// maxWebhookBodySize is max size of the webhook payload read by the handler
+const maxWebhookBodySize = 64 * 1024
+
+// CheckoutRequest is list request parameter for Checkout Api
+type CheckoutRequest struct {
+	Plan string `json:"plan"`
+}
+
+// CheckoutResponse is list response parameter for Checkout Api
+type CheckoutResponse struct {
+	SessionID   string `json:"session_id"`
+	CheckoutURL string `json:"checkout_url"`
+}
+
+func mapCheckoutResponse(result payment.CheckoutServiceInfo) utilhttp.StandardResponse {
+	var res utilhttp.StandardResponse
+	res.Data = CheckoutResponse{
+		SessionID:   result.SessionID,
+		CheckoutURL: result.URL,
+	}
+	return res
+}
+
+// mapPaymentErrorCode is func to get http status code of the payment error
+func mapPaymentErrorCode(err error) int {
+	switch err {
+	case payment.ErrDataNotFound, payment.ErrInvalidPlan, payment.ErrInvalidWebhook:
+		return http.StatusBadRequest
+	case payment.ErrAlreadySubscribed:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,79 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/payment"
+	payment_provider "gilsaputro/dating-apps/pkg/payment"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+)
+
+// WebhookHandler is func handler for receive the event of the payment provider, the non 2xx response
+// is retried by the provider so only the invalid request is rejected permanently
+func (h *PaymentHandler) WebhookHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		response.Code = code
+		if err == nil {
+			response.Message = "success"
+		} else {
+			response.Message = err.Error()
+		}
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[WebhookHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	// the raw payload is needed to verify the signature, so the body is not decoded here
+	data, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Bad Request")
+		return
+	}
+
+	signature := r.Header.Get(payment_provider.SignatureHeader)
+	if len(data) < 1 || len(signature) < 1 {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Invalid Parameter Request")
+		return
+	}
+
+	errChan := make(chan error, 1)
+	go func(ctx context.Context) {
+		err = h.service.HandleWebhook(payment.WebhookServiceRequest{
+			Payload:   data,
+			Signature: signature,
+		})
+		errChan <- err
+	}(ctx)
+
+	select {
+	case <-ctx.Done():
+		code = http.StatusGatewayTimeout
+		err = fmt.Errorf("Timeout")
+		return
+	case err = <-errChan:
+		if err != nil {
+			log.Println("[WebhookHandler]-Error Handle Webhook :", err)
+			code = mapPaymentErrorCode(err)
+			return
+		}
+	}
+}
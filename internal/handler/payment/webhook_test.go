@@ -0,0 +1,136 @@
+package payment
+
+import (
+	"bytes"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/payment"
+	"gilsaputro/dating-apps/internal/service/payment/mock"
+	payment_provider "gilsaputro/dating-apps/pkg/payment"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestPaymentHandler_WebhookHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockPaymentServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		body      string
+		signature string
+		timeout   int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				body:      `{"id":"evt_1"}`,
+				signature: "t=1,v1=abc",
+				timeout:   5,
+			},
+			mockFunc: func() {
+				m.EXPECT().HandleWebhook(payment.WebhookServiceRequest{
+					Payload:   []byte(`{"id":"evt_1"}`),
+					Signature: "t=1,v1=abc",
+				}).Return(nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error invalid webhook flow",
+			args: args{
+				body:      `{"id":"evt_1"}`,
+				signature: "t=1,v1=abc",
+				timeout:   5,
+			},
+			mockFunc: func() {
+				m.EXPECT().HandleWebhook(gomock.Any()).Return(payment.ErrInvalidWebhook)
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"invalid webhook request"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				body:      `{"id":"evt_1"}`,
+				signature: "t=1,v1=abc",
+				timeout:   5,
+			},
+			mockFunc: func() {
+				m.EXPECT().HandleWebhook(gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error missing signature",
+			args: args{
+				body:    `{"id":"evt_1"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error empty body",
+			args: args{
+				signature: "t=1,v1=abc",
+				timeout:   5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			defer mockCtrl.Finish()
+			handler := NewPaymentHandler(m, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodPost, "/v1/payment/webhook", bytes.NewReader([]byte(tt.args.body)))
+			if len(tt.args.signature) > 0 {
+				r.Header.Set(payment_provider.SignatureHeader, tt.args.signature)
+			}
+			w := httptest.NewRecorder()
+			handler.WebhookHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("WebhookHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("WebhookHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
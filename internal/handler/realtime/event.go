@@ -0,0 +1,129 @@
+package realtime
+
+import (
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/pkg/token"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// pongWait is the maximum time to wait the pong from the client before the connection is closed
+	pongWait = 60 * time.Second
+	// pingPeriod must be less than pongWait so the client has time to reply
+	pingPeriod = (pongWait * 9) / 10
+	// maxReadSize is the maximum size of message from the client, the client only need to send control message
+	maxReadSize = 512
+)
+
+// list reason the connection is closed by the server
+const (
+	closeReasonTokenExpired = "token is expired"
+	closeReasonTokenRevoked = "token is revoked"
+)
+
+// EventHandler is func handler to upgrade the request into websocket and push the event of the user
+func (h *RealtimeHandler) EventHandler(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("id").(int)
+	if !ok {
+		utilhttp.WriteResponse(w, []byte(`{"code":500,"message":"Internal Server Error"}`), http.StatusInternalServerError)
+		return
+	}
+
+	tokenBody, ok := r.Context().Value("token").(token.TokenBody)
+	if !ok {
+		utilhttp.WriteResponse(w, []byte(`{"code":500,"message":"Internal Server Error"}`), http.StatusInternalServerError)
+		return
+	}
+
+	// the upgrader already write the error response when the upgrade is failed
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Println("[EventHandler]-Error Upgrade Connection :", err)
+		return
+	}
+	defer conn.Close()
+
+	events, unsubscribe := h.service.Subscribe(userID)
+	defer unsubscribe()
+
+	done := make(chan struct{})
+	go readConnection(conn, done)
+
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	// the token is only verified on upgrade, so the connection is closed once the token is expired or revoked
+	expired := time.NewTimer(time.Until(tokenBody.ExpiredAt))
+	defer expired.Stop()
+
+	tokenTicker := time.NewTicker(time.Duration(h.tokenCheckInSec) * time.Second)
+	defer tokenTicker.Stop()
+
+	writeWait := time.Duration(h.timeoutInSec) * time.Second
+	for {
+		select {
+		case <-done:
+			return
+		case event, ok := <-events:
+			if !ok {
+				closeConnection(conn, websocket.CloseGoingAway, "", writeWait)
+				return
+			}
+
+			conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.WriteJSON(event); err != nil {
+				log.Println("[EventHandler]-Error Write Event :", err)
+				return
+			}
+		case <-expired.C:
+			closeConnection(conn, websocket.ClosePolicyViolation, closeReasonTokenExpired, writeWait)
+			return
+		case <-tokenTicker.C:
+			if h.isTokenActive == nil {
+				continue
+			}
+
+			active, err := h.isTokenActive(tokenBody)
+			if err != nil {
+				// the connection is kept when the token can not be checked, it is checked again on the next tick
+				log.Println("[EventHandler]-Error Check Token :", err)
+				continue
+			}
+
+			if !active {
+				closeConnection(conn, websocket.ClosePolicyViolation, closeReasonTokenRevoked, writeWait)
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// closeConnection is func to send the close message to the client before the connection is closed
+func closeConnection(conn *websocket.Conn, code int, reason string, writeWait time.Duration) {
+	conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(writeWait))
+}
+
+// readConnection is func to keep reading the connection so the pong and close message are processed, done is closed when the client is gone
+func readConnection(conn *websocket.Conn, done chan struct{}) {
+	defer close(done)
+
+	conn.SetReadLimit(maxReadSize)
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
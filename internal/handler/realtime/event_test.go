@@ -0,0 +1,177 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"gilsaputro/dating-apps/internal/service/realtime"
+	"gilsaputro/dating-apps/internal/service/realtime/mock"
+	"gilsaputro/dating-apps/pkg/token"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+)
+
+func TestRealtimeHandler_EventHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockRealtimeServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+
+	t.Run("success push event", func(t *testing.T) {
+		events := make(chan realtime.Event, 1)
+		unsubscribed := make(chan struct{})
+		m.EXPECT().Subscribe(1).Return(events, func() { close(unsubscribed) })
+
+		handler := NewRealtimeHandler(m, WithTimeoutOptions(5))
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler.EventHandler(w, r.WithContext(tokenContext(r.Context(), 1, time.Now().Add(time.Hour))))
+		}))
+		defer server.Close()
+
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+		if err != nil {
+			t.Fatalf("Error dial websocket err = %v\n", err)
+		}
+
+		events <- realtime.Event{Type: realtime.EventNewMatch, Data: json.RawMessage(`{"partner_id":2}`)}
+
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		_, body, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("Error read event err = %v\n", err)
+		}
+
+		want := `{"type":"new_match","data":{"partner_id":2}}`
+		if strings.TrimSpace(string(body)) != want {
+			t.Fatalf("EventHandler body got =%s, want %s \n", string(body), want)
+		}
+
+		conn.Close()
+		select {
+		case <-unsubscribed:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("EventHandler expect unsubscribe after the connection is closed")
+		}
+	})
+
+	t.Run("close connection on expired token", func(t *testing.T) {
+		events := make(chan realtime.Event, 1)
+		unsubscribed := make(chan struct{})
+		m.EXPECT().Subscribe(1).Return(events, func() { close(unsubscribed) })
+
+		handler := NewRealtimeHandler(m)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler.EventHandler(w, r.WithContext(tokenContext(r.Context(), 1, time.Now().Add(100*time.Millisecond))))
+		}))
+		defer server.Close()
+
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+		if err != nil {
+			t.Fatalf("Error dial websocket err = %v\n", err)
+		}
+		defer conn.Close()
+
+		assertClosed(t, conn, closeReasonTokenExpired)
+		select {
+		case <-unsubscribed:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("EventHandler expect unsubscribe after the connection is closed")
+		}
+	})
+
+	t.Run("close connection on revoked token", func(t *testing.T) {
+		events := make(chan realtime.Event, 1)
+		unsubscribed := make(chan struct{})
+		m.EXPECT().Subscribe(1).Return(events, func() { close(unsubscribed) })
+
+		isTokenActive := func(tokenBody token.TokenBody) (bool, error) {
+			return tokenBody.UserID != 1, nil
+		}
+		handler := NewRealtimeHandler(m, WithTokenCheckOptions(isTokenActive, 1))
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler.EventHandler(w, r.WithContext(tokenContext(r.Context(), 1, time.Now().Add(time.Hour))))
+		}))
+		defer server.Close()
+
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+		if err != nil {
+			t.Fatalf("Error dial websocket err = %v\n", err)
+		}
+		defer conn.Close()
+
+		assertClosed(t, conn, closeReasonTokenRevoked)
+		select {
+		case <-unsubscribed:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("EventHandler expect unsubscribe after the connection is closed")
+		}
+	})
+
+	t.Run("error on token value", func(t *testing.T) {
+		handler := NewRealtimeHandler(m)
+		r := httptest.NewRequest(http.MethodGet, "/v1/ws", nil)
+		r = r.WithContext(context.WithValue(r.Context(), "id", 1))
+		w := httptest.NewRecorder()
+		handler.EventHandler(w, r)
+		if w.Result().StatusCode != http.StatusInternalServerError {
+			t.Fatalf("EventHandler status code got =%d, want %d \n", w.Result().StatusCode, http.StatusInternalServerError)
+		}
+	})
+
+	t.Run("error not websocket request", func(t *testing.T) {
+		handler := NewRealtimeHandler(m)
+		r := httptest.NewRequest(http.MethodGet, "/v1/ws", nil)
+		r = r.WithContext(tokenContext(r.Context(), 1, time.Now().Add(time.Hour)))
+		w := httptest.NewRecorder()
+		handler.EventHandler(w, r)
+		if w.Result().StatusCode != http.StatusBadRequest {
+			t.Fatalf("EventHandler status code got =%d, want %d \n", w.Result().StatusCode, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("error on userid value", func(t *testing.T) {
+		handler := NewRealtimeHandler(m)
+		r := httptest.NewRequest(http.MethodGet, "/v1/ws", nil)
+		w := httptest.NewRecorder()
+		handler.EventHandler(w, r)
+		result := w.Result()
+		resBody, err := ioutil.ReadAll(result.Body)
+		if err != nil {
+			t.Fatalf("Error read body err = %v\n", err)
+		}
+
+		want := `{"code":500,"message":"Internal Server Error"}`
+		if string(resBody) != want {
+			t.Fatalf("EventHandler body got =%s, want %s \n", string(resBody), want)
+		}
+
+		if result.StatusCode != http.StatusInternalServerError {
+			t.Fatalf("EventHandler status code got =%d, want %d \n", result.StatusCode, http.StatusInternalServerError)
+		}
+	})
+}
+
+// tokenContext is func to set the context value of the verified token like the middleware
+func tokenContext(ctx context.Context, userID int, expiredAt time.Time) context.Context {
+	ctx = context.WithValue(ctx, "id", userID)
+	return context.WithValue(ctx, "token", token.TokenBody{UserID: userID, ExpiredAt: expiredAt})
+}
+
+// assertClosed is func to check the server close the connection with the policy violation and the reason
+func assertClosed(t *testing.T, conn *websocket.Conn, reason string) {
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, _, err := conn.ReadMessage()
+	closeErr, ok := err.(*websocket.CloseError)
+	if !ok {
+		t.Fatalf("EventHandler expect close error, got err = %v\n", err)
+	}
+
+	if closeErr.Code != websocket.ClosePolicyViolation || closeErr.Text != reason {
+		t.Fatalf("EventHandler close got = %d %s, want %d %s \n", closeErr.Code, closeErr.Text, websocket.ClosePolicyViolation, reason)
+	}
+}
@@ -0,0 +1,106 @@
+package realtime
+
+import (
+	"gilsaputro/dating-apps/internal/service/realtime"
+	"gilsaputro/dating-apps/pkg/token"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+// RealtimeHandler list dependencies for realtime handler
+type RealtimeHandler struct {
+	service  realtime.RealtimeServiceMethod
+	upgrader websocket.Upgrader
+	// timeoutInSec is the maximum time to write one message into the connection
+	timeoutInSec int
+	// isTokenActive is func to check the token of the connection periodically, the connection is closed when the token is not active
+	isTokenActive func(tokenBody token.TokenBody) (bool, error)
+	// tokenCheckInSec is interval to check the token of the connection
+	tokenCheckInSec int
+}
+
+// Option set options for http handler config
+type Option func(*RealtimeHandler)
+
+const (
+	defaultTimeout    = 5
+	defaultTokenCheck = 30
+)
+
+// NewRealtimeHandler is func to create http realtime handler
+func NewRealtimeHandler(service realtime.RealtimeServiceMethod, options ...Option) *RealtimeHandler {
+	handler := &RealtimeHandler{
+		service: service,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+		},
+		timeoutInSec:    defaultTimeout,
+		tokenCheckInSec: defaultTokenCheck,
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(handler)
+	}
+
+	return handler
+}
+
+// WithTimeoutOptions is func to set timeout config into handler
+func WithTimeoutOptions(timeoutinsec int) Option {
+	return Option(
+		func(h *RealtimeHandler) {
+			if timeoutinsec <= 0 {
+				timeoutinsec = defaultTimeout
+			}
+			h.timeoutInSec = timeoutinsec
+		})
+}
+
+// WithCheckOriginOptions is func to set the allowed origin of the websocket connection
+func WithCheckOriginOptions(checkOrigin func(r *http.Request) bool) Option {
+	return Option(
+		func(h *RealtimeHandler) {
+			h.upgrader.CheckOrigin = checkOrigin
+		})
+}
+
+// WithTokenCheckOptions is func to set the token check of the connection, so the connection is closed after logout or session revoke
+func WithTokenCheckOptions(isTokenActive func(tokenBody token.TokenBody) (bool, error), intervalinsec int) Option {
+	return Option(
+		func(h *RealtimeHandler) {
+			if intervalinsec <= 0 {
+				intervalinsec = defaultTokenCheck
+			}
+			h.isTokenActive = isTokenActive
+			h.tokenCheckInSec = intervalinsec
+		})
+}
+
+// AllowOrigins is func to generate the origin check that accept the request without origin header like the mobile apps,
+// the same host origin and the listed origins
+func AllowOrigins(origins []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if len(origin) == 0 {
+			return true
+		}
+
+		for _, allowed := range origins {
+			if strings.EqualFold(origin, allowed) {
+				return true
+			}
+		}
+
+		u, err := url.Parse(origin)
+		if err != nil {
+			return false
+		}
+
+		return strings.EqualFold(u.Host, r.Host)
+	}
+}
@@ -0,0 +1,47 @@
+package realtime
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAllowOrigins(t *testing.T) {
+	tests := []struct {
+		name   string
+		origin string
+		want   bool
+	}{
+		{
+			name: "success without origin",
+			want: true,
+		},
+		{
+			name:   "success listed origin",
+			origin: "https://app.example.com",
+			want:   true,
+		},
+		{
+			name:   "success same host origin",
+			origin: "https://api.example.com",
+			want:   true,
+		},
+		{
+			name:   "reject other origin",
+			origin: "https://evil.example.com",
+			want:   false,
+		},
+	}
+	checkOrigin := AllowOrigins([]string{"https://app.example.com"})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "https://api.example.com/v1/ws", nil)
+			if len(tt.origin) > 0 {
+				r.Header.Set("Origin", tt.origin)
+			}
+			if got := checkOrigin(r); got != tt.want {
+				t.Errorf("AllowOrigins() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/subscription"
+	"gilsaputro/dating-apps/internal/service/subscription/mock"
+	"gilsaputro/dating-apps/models"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestSubscriptionHandler_CancelSubscriptionHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockSubscriptionServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		userID  int
+		timeout int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				userID:  1,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().CancelSubscription(subscription.CancelSubscriptionServiceRequest{
+					UserId: 1,
+				}).Return(subscription.SubscriptionServiceInfo{
+					Plan:          models.PlanPlus,
+					Status:        models.SubscriptionStatusCancelled,
+					StartedDate:   "2023-11-14",
+					ExpiredDate:   "2023-12-14",
+					CancelledDate: "2023-11-20",
+					Entitlements:  models.GetEntitlements(models.PlanPlus),
+				}, nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"plan":"PLUS","status":"CANCELLED","started_date":"2023-11-14","expired_date":"2023-12-14","cancelled_date":"2023-11-20","entitlements":{"unlimited_swipe":true,"rewind":true,"see_likes_received":false,"extra_super_like":false}},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error subscription not active flow",
+			args: args{
+				userID:  1,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().CancelSubscription(subscription.CancelSubscriptionServiceRequest{
+					UserId: 1,
+				}).Return(subscription.SubscriptionServiceInfo{}, subscription.ErrSubscriptionNotActive)
+			},
+			want: want{
+				code: 409,
+				body: `{"code":409,"message":"user does not have active subscription"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				userID:  1,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().CancelSubscription(subscription.CancelSubscriptionServiceRequest{
+					UserId: 1,
+				}).Return(subscription.SubscriptionServiceInfo{}, fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error missing user id",
+			args: args{
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			defer mockCtrl.Finish()
+			handler := NewSubscriptionHandler(m, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodDelete, "/v1/user/subscription", nil)
+			if tt.args.userID > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.userID))
+			}
+			w := httptest.NewRecorder()
+			handler.CancelSubscriptionHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("CancelSubscriptionHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("CancelSubscriptionHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
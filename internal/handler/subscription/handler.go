@@ -0,0 +1,44 @@
+package subscription
+
+import (
+	"gilsaputro/dating-apps/internal/service/subscription"
+)
+
+// SubscriptionHandler list dependencies for subscription handler
+type SubscriptionHandler struct {
+	service      subscription.SubscriptionServiceMethod
+	timeoutInSec int
+}
+
+// Option set options for http handler config
+type Option func(*SubscriptionHandler)
+
+const (
+	defaultTimeout = 5
+)
+
+// NewSubscriptionHandler is func to create http subscription handler
+func NewSubscriptionHandler(service subscription.SubscriptionServiceMethod, options ...Option) *SubscriptionHandler {
+	handler := &SubscriptionHandler{
+		service:      service,
+		timeoutInSec: defaultTimeout,
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(handler)
+	}
+
+	return handler
+}
+
+// WithTimeoutOptions is func to set timeout config into handler
+func WithTimeoutOptions(timeoutinsec int) Option {
+	return Option(
+		func(h *SubscriptionHandler) {
+			if timeoutinsec <= 0 {
+				timeoutinsec = defaultTimeout
+			}
+			h.timeoutInSec = timeoutinsec
+		})
+}
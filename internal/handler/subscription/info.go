@@ -0,0 +1,83 @@
+package subscription
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/subscription"
+	"log"
+	"net/http"
+	"time"
+)
+
+// SubscriptionInfoHandler is func handler for get the current plan and subscription of the user
+func (h *SubscriptionHandler) SubscriptionInfoHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		response.Code = code
+		if err == nil {
+			response.Message = "success"
+		} else {
+			response.Message = err.Error()
+		}
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[SubscriptionInfoHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	var userID int
+	var ok bool
+	userID, ok = r.Context().Value("id").(int)
+	if !ok {
+		code = http.StatusInternalServerError
+		err = fmt.Errorf("Internal Server Error")
+		return
+	}
+
+	errChan := make(chan error, 1)
+	var result subscription.SubscriptionServiceInfo
+	go func(ctx context.Context) {
+		result, err = h.service.GetSubscription(subscription.GetSubscriptionServiceRequest{
+			UserId: userID,
+		})
+		errChan <- err
+	}(ctx)
+
+	select {
+	case <-ctx.Done():
+		code = http.StatusGatewayTimeout
+		err = fmt.Errorf("Timeout")
+		return
+	case err = <-errChan:
+		if err != nil {
+			code = mapSubscriptionErrorCode(err)
+			return
+		}
+	}
+
+	response = mapSubscriptionResponse(result)
+}
+
+// mapSubscriptionErrorCode is func to get http status code of the subscription error
+func mapSubscriptionErrorCode(err error) int {
+	switch err {
+	case subscription.ErrDataNotFound, subscription.ErrInvalidPlan:
+		return http.StatusBadRequest
+	case subscription.ErrSubscriptionNotActive:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,25 @@
+package subscription
+
+import (
+	"encoding/json"
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"log"
+	"net/http"
+)
+
+// PlanListHandler is func handler for get list of plan tier and the entitlements
+func (h *SubscriptionHandler) PlanListHandler(w http.ResponseWriter, r *http.Request) {
+	// the plan list is static config of the service, so it does not need the timeout
+	response := mapPlanListResponse(h.service.GetPlans())
+	response.Code = http.StatusOK
+	response.Message = "success"
+
+	code := http.StatusOK
+	data, err := json.Marshal(response)
+	if err != nil {
+		log.Println("[PlanListHandler]-Error Marshal Response :", err)
+		code = http.StatusInternalServerError
+		data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+	}
+	utilhttp.WriteResponse(w, data, code)
+}
@@ -0,0 +1,74 @@
+package subscription
+
+import (
+	"gilsaputro/dating-apps/internal/service/subscription"
+	"gilsaputro/dating-apps/internal/service/subscription/mock"
+	"gilsaputro/dating-apps/models"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestSubscriptionHandler_PlanListHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockSubscriptionServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantBody string
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				m.EXPECT().GetPlans().Return([]subscription.PlanServiceInfo{
+					{
+						Plan: models.PlanFree,
+					},
+					{
+						Plan:        models.PlanPlus,
+						PeriodInDay: 30,
+						Entitlements: models.Entitlements{
+							UnlimitedSwipe: true,
+							Rewind:         true,
+						},
+					},
+				})
+			},
+			wantBody: `{"data":{"plans":[{"plan":"FREE","period_in_day":0,"entitlements":{"unlimited_swipe":false,"rewind":false,"see_likes_received":false,"extra_super_like":false}},{"plan":"PLUS","period_in_day":30,"entitlements":{"unlimited_swipe":true,"rewind":true,"see_likes_received":false,"extra_super_like":false}}]},"code":200,"message":"success"}`,
+		},
+		{
+			name: "success without plan flow",
+			mockFunc: func() {
+				m.EXPECT().GetPlans().Return(nil)
+			},
+			wantBody: `{"data":{"plans":[]},"code":200,"message":"success"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			handler := NewSubscriptionHandler(m)
+			r := httptest.NewRequest(http.MethodGet, "/v1/subscription/plans", nil)
+			w := httptest.NewRecorder()
+			handler.PlanListHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.wantBody {
+				t.Fatalf("PlanListHandler body got =%s, want %s \n", string(resBody), tt.wantBody)
+			}
+
+			if result.StatusCode != http.StatusOK {
+				t.Fatalf("PlanListHandler status code got =%d, want %d \n", result.StatusCode, http.StatusOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package subscription
+
+import (
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/subscription"
+	"gilsaputro/dating-apps/models"
+)
+
+// EntitlementsResponse is list feature unlocked by the plan
+type EntitlementsResponse struct {
+	UnlimitedSwipe   bool `json:"unlimited_swipe"`
+	Rewind           bool `json:"rewind"`
+	SeeLikesReceived bool `json:"see_likes_received"`
+	ExtraSuperLike   bool `json:"extra_super_like"`
+}
+
+// PlanResponse is list response parameter for a plan tier
+type PlanResponse struct {
+	Plan         string               `json:"plan"`
+	PeriodInDay  int                  `json:"period_in_day"`
+	Entitlements EntitlementsResponse `json:"entitlements"`
+}
+
+// PlanListResponse is list response parameter for Plan List Api
+type PlanListResponse struct {
+	Plans []PlanResponse `json:"plans"`
+}
+
+// SubscriptionResponse is list response parameter for subscription of the user
+type SubscriptionResponse struct {
+	Plan          string               `json:"plan"`
+	Status        string               `json:"status,omitempty"`
+	StartedDate   string               `json:"started_date,omitempty"`
+	ExpiredDate   string               `json:"expired_date,omitempty"`
+	CancelledDate string               `json:"cancelled_date,omitempty"`
+	Entitlements  EntitlementsResponse `json:"entitlements"`
+}
+
+func mapEntitlementsResponse(entitlements models.Entitlements) EntitlementsResponse {
+	return EntitlementsResponse{
+		UnlimitedSwipe:   entitlements.UnlimitedSwipe,
+		Rewind:           entitlements.Rewind,
+		SeeLikesReceived: entitlements.SeeLikesReceived,
+		ExtraSuperLike:   entitlements.ExtraSuperLike,
+	}
+}
+
+func mapPlanListResponse(result []subscription.PlanServiceInfo) utilhttp.StandardResponse {
+	var res utilhttp.StandardResponse
+	list := []PlanResponse{}
+	for _, data := range result {
+		list = append(list, PlanResponse{
+			Plan:         data.Plan,
+			PeriodInDay:  data.PeriodInDay,
+			Entitlements: mapEntitlementsResponse(data.Entitlements),
+		})
+	}
+
+	res.Data = PlanListResponse{
+		Plans: list,
+	}
+	return res
+}
+
+func mapSubscriptionResponse(result subscription.SubscriptionServiceInfo) utilhttp.StandardResponse {
+	var res utilhttp.StandardResponse
+	res.Data = SubscriptionResponse{
+		Plan:          result.Plan,
+		Status:        result.Status,
+		StartedDate:   result.StartedDate,
+		ExpiredDate:   result.ExpiredDate,
+		CancelledDate: result.CancelledDate,
+		Entitlements:  mapEntitlementsResponse(result.Entitlements),
+	}
+	return res
+}
@@ -54,7 +54,7 @@ func (h *UserHandler) EditUserHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// checking valid body
-	if (len(body.Email) < 1 && len(body.Fullname) < 1) && len(body.Password) < 1 {
+	if (len(body.Email) < 1 && len(body.Fullname) < 1) && len(body.Password) < 1 && !body.hasDiscoveryProfile() {
 		code = http.StatusBadRequest
 		err = fmt.Errorf("Invalid Parameter Request")
 		return
@@ -73,11 +73,17 @@ func (h *UserHandler) EditUserHandler(w http.ResponseWriter, r *http.Request) {
 	var result user.UserServiceInfo
 	go func(ctx context.Context) {
 		result, err = h.service.UpdateUser(user.UpdateUserServiceRequest{
-			UserId:   userID,
-			Username: body.Username,
-			Password: body.Password,
-			Fullname: body.Fullname,
-			Email:    body.Email,
+			UserId:       userID,
+			Username:     body.Username,
+			Password:     body.Password,
+			Fullname:     body.Fullname,
+			Email:        body.Email,
+			Birthdate:    body.Birthdate,
+			Gender:       body.Gender,
+			InterestedIn: body.InterestedIn,
+			AgeMin:       body.Discovery.AgeMin.Value,
+			AgeMax:       body.Discovery.AgeMax.Value,
+			MaxDistance:  body.Discovery.MaxDistance.Value,
 		})
 		errChan <- err
 	}(ctx)
@@ -89,7 +95,8 @@ func (h *UserHandler) EditUserHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	case err = <-errChan:
 		if err != nil {
-			if err == user.ErrUserNameNotExists || err == user.ErrPasswordIsIncorrect {
+			if err == user.ErrUserNameNotExists || err == user.ErrPasswordIsIncorrect || err == user.ErrInvalidBirthdate ||
+				err == user.ErrInvalidGender || err == user.ErrInvalidAgeRange || err == user.ErrInvalidMaxDistance || err == user.ErrInvalidEmail {
 				code = http.StatusBadRequest
 			} else {
 				code = http.StatusInternalServerError
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"gilsaputro/dating-apps/internal/service/user"
 	"gilsaputro/dating-apps/internal/service/user/mock"
+	"gilsaputro/dating-apps/models"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -18,6 +19,7 @@ func TestUserHandler_UpdateUserHandler(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	m := mock.NewMockUserServiceMethod(mockCtrl)
 	defer mockCtrl.Finish()
+	intPointer := func(v int) *int { return &v }
 	type args struct {
 		userID  int
 		body    string
@@ -48,11 +50,74 @@ func TestUserHandler_UpdateUserHandler(t *testing.T) {
 					UserId:   1,
 					Password: "pas1",
 				}).Return(user.UserServiceInfo{
-					UserId:     1,
-					Username:   "username",
-					Fullname:   "full name",
-					Email:      "email.com",
-					IsVerified: true,
+					UserId:   1,
+					Username: "username",
+					Fullname: "full name",
+					Email:    "email.com",
+					Plan:     models.PlanPremium,
+				}, nil)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"username":"username","email":"email.com","fullname":"full name","discovery":{"age_min":0,"age_max":0,"max_distance_km":0}},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "success update discovery profile flow",
+			args: args{
+				userID: 1,
+				body: `{
+					"gender": "MALE",
+					"interested_in": ["FEMALE"],
+					"discovery": {"age_min": 20, "age_max": 30}
+				}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().UpdateUser(user.UpdateUserServiceRequest{
+					UserId:       1,
+					Gender:       "MALE",
+					InterestedIn: []string{"FEMALE"},
+					AgeMin:       intPointer(20),
+					AgeMax:       intPointer(30),
+				}).Return(user.UserServiceInfo{
+					UserId:       1,
+					Username:     "username",
+					Gender:       "MALE",
+					InterestedIn: []string{"FEMALE"},
+					AgeMin:       20,
+					AgeMax:       30,
+				}, nil)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"username":"username","email":"","fullname":"","gender":"MALE","interested_in":["FEMALE"],"discovery":{"age_min":20,"age_max":30,"max_distance_km":0}},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "success clear discovery preference flow",
+			args: args{
+				userID: 1,
+				body: `{
+					"discovery": {"age_min": 0, "max_distance_km": null}
+				}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().UpdateUser(user.UpdateUserServiceRequest{
+					UserId:      1,
+					AgeMin:      intPointer(0),
+					MaxDistance: intPointer(0),
+				}).Return(user.UserServiceInfo{
+					UserId:   1,
+					Username: "username",
+					AgeMax:   30,
 				}, nil)
 			},
 			mockContext: func() (context.Context, func()) {
@@ -60,7 +125,53 @@ func TestUserHandler_UpdateUserHandler(t *testing.T) {
 			},
 			want: want{
 				code: 200,
-				body: `{"data":{"username":"username","email":"email.com","fullname":"full name"},"code":200,"message":"success"}`,
+				body: `{"data":{"username":"username","email":"","fullname":"","discovery":{"age_min":0,"age_max":30,"max_distance_km":0}},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error invalid discovery profile flow",
+			args: args{
+				userID: 1,
+				body: `{
+					"gender": "unknown"
+				}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().UpdateUser(user.UpdateUserServiceRequest{
+					UserId: 1,
+					Gender: "unknown",
+				}).Return(user.UserServiceInfo{}, user.ErrInvalidGender)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"gender is invalid, the value should be MALE, FEMALE or OTHER"}`,
+			},
+		},
+		{
+			name: "error invalid email flow",
+			args: args{
+				userID: 1,
+				body: `{
+					"email": "invalid"
+				}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().UpdateUser(user.UpdateUserServiceRequest{
+					UserId: 1,
+					Email:  "invalid",
+				}).Return(user.UserServiceInfo{}, user.ErrInvalidEmail)
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"email is invalid"}`,
 			},
 		},
 		{
@@ -12,8 +12,8 @@ import (
 	"time"
 )
 
-// UpgradeUserHandler is func handler for Upgrade user
-func (h *UserHandler) UpgradeUserHandler(w http.ResponseWriter, r *http.Request) {
+// UpdateLocationHandler is func handler for update user location
+func (h *UserHandler) UpdateLocationHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
 	defer cancel()
 
@@ -31,14 +31,14 @@ func (h *UserHandler) UpgradeUserHandler(w http.ResponseWriter, r *http.Request)
 
 		data, errMarshal := json.Marshal(response)
 		if errMarshal != nil {
-			log.Println("[UpgradeUserHandler]-Error Marshal Response :", err)
+			log.Println("[UpdateLocationHandler]-Error Marshal Response :", err)
 			code = http.StatusInternalServerError
 			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
 		}
 		utilhttp.WriteResponse(w, data, code)
 	}()
 
-	var body UpgradeUserRequest
+	var body UpdateLocationRequest
 	data, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		code = http.StatusBadRequest
@@ -54,7 +54,7 @@ func (h *UserHandler) UpgradeUserHandler(w http.ResponseWriter, r *http.Request)
 	}
 
 	// checking valid body
-	if len(body.Password) < 1 {
+	if body.Latitude == nil || body.Longitude == nil {
 		code = http.StatusBadRequest
 		err = fmt.Errorf("Invalid Parameter Request")
 		return
@@ -70,10 +70,12 @@ func (h *UserHandler) UpgradeUserHandler(w http.ResponseWriter, r *http.Request)
 	}
 
 	errChan := make(chan error, 1)
+	var result user.UserServiceInfo
 	go func(ctx context.Context) {
-		err = h.service.UpgradeUser(user.UpgradeServiceRequest{
-			UserId:   userID,
-			Password: body.Password,
+		result, err = h.service.UpdateLocation(user.UpdateLocationServiceRequest{
+			UserId:    userID,
+			Latitude:  *body.Latitude,
+			Longitude: *body.Longitude,
 		})
 		errChan <- err
 	}(ctx)
@@ -85,7 +87,7 @@ func (h *UserHandler) UpgradeUserHandler(w http.ResponseWriter, r *http.Request)
 		return
 	case err = <-errChan:
 		if err != nil {
-			if err == user.ErrUserNameNotExists || err == user.ErrPasswordIsIncorrect {
+			if err == user.ErrInvalidLocation {
 				code = http.StatusBadRequest
 			} else {
 				code = http.StatusInternalServerError
@@ -94,10 +96,5 @@ func (h *UserHandler) UpgradeUserHandler(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	response = mapResponseUpgrade()
-}
-
-func mapResponseUpgrade() utilhttp.StandardResponse {
-	var res utilhttp.StandardResponse
-	return res
+	response = mapResponseUpdateLocation(result)
 }
@@ -14,7 +14,7 @@ import (
 	"github.com/golang/mock/gomock"
 )
 
-func TestUserHandler_UpgradeUserHandler(t *testing.T) {
+func TestUserHandler_UpdateLocationHandler(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	m := mock.NewMockUserServiceMethod(mockCtrl)
 	defer mockCtrl.Finish()
@@ -39,22 +39,31 @@ func TestUserHandler_UpgradeUserHandler(t *testing.T) {
 			args: args{
 				userID: 1,
 				body: `{
-					"password": "pas1"
+					"latitude": -6.2,
+					"longitude": 106.8
 				}`,
 				timeout: 5,
 			},
 			mockFunc: func() {
-				m.EXPECT().UpgradeUser(user.UpgradeServiceRequest{
-					UserId:   1,
-					Password: "pas1",
-				}).Return(nil)
+				m.EXPECT().UpdateLocation(user.UpdateLocationServiceRequest{
+					UserId:    1,
+					Latitude:  -6.2,
+					Longitude: 106.8,
+				}).Return(user.UserServiceInfo{
+					UserId: 1,
+					Location: &user.LocationInfo{
+						Latitude:    -6.2,
+						Longitude:   106.8,
+						UpdatedDate: "2023-06-15",
+					},
+				}, nil)
 			},
 			mockContext: func() (context.Context, func()) {
 				return context.Background(), func() {}
 			},
 			want: want{
 				code: 200,
-				body: `{"code":200,"message":"success"}`,
+				body: `{"data":{"latitude":-6.2,"longitude":106.8,"updated_date":"2023-06-15"},"code":200,"message":"success"}`,
 			},
 		},
 		{
@@ -62,15 +71,17 @@ func TestUserHandler_UpgradeUserHandler(t *testing.T) {
 			args: args{
 				userID: 1,
 				body: `{
-					"password": "pas1"
+					"latitude": -6.2,
+					"longitude": 106.8
 				}`,
 				timeout: 5,
 			},
 			mockFunc: func() {
-				m.EXPECT().UpgradeUser(user.UpgradeServiceRequest{
-					UserId:   1,
-					Password: "pas1",
-				}).Return(fmt.Errorf("some error"))
+				m.EXPECT().UpdateLocation(user.UpdateLocationServiceRequest{
+					UserId:    1,
+					Latitude:  -6.2,
+					Longitude: 106.8,
+				}).Return(user.UserServiceInfo{}, fmt.Errorf("some error"))
 			},
 			mockContext: func() (context.Context, func()) {
 				return context.Background(), func() {}
@@ -81,27 +92,64 @@ func TestUserHandler_UpgradeUserHandler(t *testing.T) {
 			},
 		},
 		{
-			name: "error on service flow user not exists",
+			name: "error on service flow invalid location",
 			args: args{
 				userID: 1,
 				body: `{
+					"latitude": 91,
+					"longitude": 106.8
 				}`,
 				timeout: 5,
 			},
 			mockFunc: func() {
-				m.EXPECT().UpgradeUser(user.UpgradeServiceRequest{
-					UserId:   1,
-					Password: "pas1",
-				}).Return(user.ErrUserNameNotExists)
+				m.EXPECT().UpdateLocation(user.UpdateLocationServiceRequest{
+					UserId:    1,
+					Latitude:  91,
+					Longitude: 106.8,
+				}).Return(user.UserServiceInfo{}, user.ErrInvalidLocation)
 			},
 			mockContext: func() (context.Context, func()) {
 				return context.Background(), func() {}
 			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"location is invalid, latitude should be between -90 and 90 and longitude should be between -180 and 180"}`,
+			},
+		},
+		{
+			name: "error missing longitude",
+			args: args{
+				userID: 1,
+				body: `{
+					"latitude": -6.2
+				}`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
 			want: want{
 				code: 400,
 				body: `{"code":400,"message":"Invalid Parameter Request"}`,
 			},
 		},
+		{
+			name: "error invalid body",
+			args: args{
+				userID:  1,
+				body:    `{`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Bad Request"}`,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -111,7 +159,7 @@ func TestUserHandler_UpgradeUserHandler(t *testing.T) {
 				service:      m,
 				timeoutInSec: tt.args.timeout,
 			}
-			r := httptest.NewRequest(http.MethodGet, "/user", strings.NewReader(tt.args.body))
+			r := httptest.NewRequest(http.MethodPut, "/v1/user/location", strings.NewReader(tt.args.body))
 			ctx, cancel := tt.mockContext()
 			defer cancel()
 			r = r.WithContext(ctx)
@@ -119,7 +167,7 @@ func TestUserHandler_UpgradeUserHandler(t *testing.T) {
 				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.userID))
 			}
 			w := httptest.NewRecorder()
-			handler.UpgradeUserHandler(w, r)
+			handler.UpdateLocationHandler(w, r)
 			result := w.Result()
 			resBody, err := ioutil.ReadAll(result.Body)
 
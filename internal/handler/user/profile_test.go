@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"gilsaputro/dating-apps/internal/service/user"
 	"gilsaputro/dating-apps/internal/service/user/mock"
+	"gilsaputro/dating-apps/models"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -45,11 +46,11 @@ func TestUserHandler_ProfileUserHandler(t *testing.T) {
 				m.EXPECT().GetUserByID(user.GetByIDServiceRequest{
 					UserId: 1,
 				}).Return(user.UserServiceInfo{
-					UserId:     1,
-					Username:   "username",
-					Fullname:   "full name",
-					Email:      "email.com",
-					IsVerified: true,
+					UserId:   1,
+					Username: "username",
+					Fullname: "full name",
+					Email:    "email.com",
+					Plan:     models.PlanPremium,
 				}, nil)
 			},
 			mockContext: func() (context.Context, func()) {
@@ -57,7 +58,7 @@ func TestUserHandler_ProfileUserHandler(t *testing.T) {
 			},
 			want: want{
 				code: 200,
-				body: `{"data":{"id":1,"username":"username","fullname":"full name","email":"email.com","is_verified":true,"created_date":""},"code":200,"message":"success"}`,
+				body: `{"data":{"id":1,"username":"username","fullname":"full name","email":"email.com","plan":"PREMIUM","is_email_verified":false,"discovery":{"age_min":0,"age_max":0,"max_distance_km":0},"created_date":""},"code":200,"message":"success"}`,
 			},
 		},
 		{
@@ -0,0 +1,142 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/user"
+	"gilsaputro/dating-apps/internal/service/user/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+func TestUserHandler_RevokeSessionHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockUserServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		userID    int
+		sessionID string
+		timeout   int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				userID:    1,
+				sessionID: "sid",
+				timeout:   5,
+			},
+			mockFunc: func() {
+				m.EXPECT().RevokeSession(user.RevokeSessionServiceRequest{
+					UserId:    1,
+					SessionID: "sid",
+				}).Return(nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error session not found flow",
+			args: args{
+				userID:    1,
+				sessionID: "sid",
+				timeout:   5,
+			},
+			mockFunc: func() {
+				m.EXPECT().RevokeSession(user.RevokeSessionServiceRequest{
+					UserId:    1,
+					SessionID: "sid",
+				}).Return(user.ErrSessionNotFound)
+			},
+			want: want{
+				code: 404,
+				body: `{"code":404,"message":"session is not found or already revoked"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				userID:    1,
+				sessionID: "sid",
+				timeout:   5,
+			},
+			mockFunc: func() {
+				m.EXPECT().RevokeSession(user.RevokeSessionServiceRequest{
+					UserId:    1,
+					SessionID: "sid",
+				}).Return(fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error empty session id",
+			args: args{
+				userID:  1,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error missing user id",
+			args: args{
+				sessionID: "sid",
+				timeout:   5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			handler := NewUserHandler(m, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodDelete, "/v1/user/sessions/"+tt.args.sessionID, nil)
+			if tt.args.userID > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.userID))
+			}
+			r = mux.SetURLVars(r, map[string]string{"sessionID": tt.args.sessionID})
+			w := httptest.NewRecorder()
+			handler.RevokeSessionHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("RevokeSessionHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("RevokeSessionHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
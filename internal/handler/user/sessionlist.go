@@ -0,0 +1,73 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/user"
+	"log"
+	"net/http"
+	"time"
+)
+
+// SessionListHandler is func handler for get list active device session of the login user
+func (h *UserHandler) SessionListHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		response.Code = code
+		if err == nil {
+			response.Message = "success"
+		} else {
+			response.Message = err.Error()
+		}
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[SessionListHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	userID, ok := r.Context().Value("id").(int)
+	if !ok {
+		code = http.StatusInternalServerError
+		err = fmt.Errorf("Internal Server Error")
+		return
+	}
+
+	// the token issued before the session is stored has no session id, so none of the session is marked as current
+	sessionID, _ := r.Context().Value("sessionid").(string)
+
+	errChan := make(chan error, 1)
+	var result []user.SessionInfo
+	go func(ctx context.Context) {
+		result, err = h.service.GetSessions(user.GetSessionsServiceRequest{
+			UserId:           userID,
+			CurrentSessionID: sessionID,
+		})
+		errChan <- err
+	}(ctx)
+
+	select {
+	case <-ctx.Done():
+		code = http.StatusGatewayTimeout
+		err = fmt.Errorf("Timeout")
+		return
+	case err = <-errChan:
+		if err != nil {
+			code = http.StatusInternalServerError
+			return
+		}
+	}
+
+	response = mapResponseSessionList(result)
+}
@@ -0,0 +1,136 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/user"
+	"gilsaputro/dating-apps/internal/service/user/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestUserHandler_SessionListHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockUserServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		userID    int
+		sessionID string
+		timeout   int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				userID:    1,
+				sessionID: "sid-1",
+				timeout:   5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetSessions(user.GetSessionsServiceRequest{
+					UserId:           1,
+					CurrentSessionID: "sid-1",
+				}).Return([]user.SessionInfo{
+					{
+						SessionID:   "sid-1",
+						Device:      "iphone",
+						IPAddress:   "192.0.2.1",
+						UserAgent:   "agent",
+						LastSeen:    "2023-11-14 22:13:20 +0000 UTC",
+						CreatedDate: "2023-11-14 22:13:20 +0000 UTC",
+						IsCurrent:   true,
+					},
+				}, nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"data":[{"session_id":"sid-1","device":"iphone","ip_address":"192.0.2.1","user_agent":"agent","last_seen":"2023-11-14 22:13:20 +0000 UTC","created_date":"2023-11-14 22:13:20 +0000 UTC","is_current":true}],"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "success no active session flow",
+			args: args{
+				userID:  1,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetSessions(user.GetSessionsServiceRequest{
+					UserId: 1,
+				}).Return([]user.SessionInfo{}, nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"data":[],"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				userID:  1,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetSessions(user.GetSessionsServiceRequest{
+					UserId: 1,
+				}).Return(nil, fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error missing user id",
+			args: args{
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			handler := NewUserHandler(m, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodGet, "/v1/user/sessions", nil)
+			if tt.args.userID > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.userID))
+			}
+			if len(tt.args.sessionID) > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "sessionid", tt.args.sessionID))
+			}
+			w := httptest.NewRecorder()
+			handler.SessionListHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("SessionListHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("SessionListHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
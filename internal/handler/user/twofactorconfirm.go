@@ -0,0 +1,120 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/user"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+)
+
+// ConfirmTwoFactorRequest is list request parameter for Confirm Two Factor Api
+type ConfirmTwoFactorRequest struct {
+	Code string `json:"code"`
+}
+
+// ConfirmTwoFactorHandler is func handler for enable the two factor authentication with code from the authenticator app
+func (h *UserHandler) ConfirmTwoFactorHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		response.Code = code
+		if err == nil {
+			response.Message = "success"
+		} else {
+			response.Message = err.Error()
+		}
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[ConfirmTwoFactorHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	var body ConfirmTwoFactorRequest
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Bad Request")
+		return
+	}
+
+	err = json.Unmarshal(data, &body)
+	if err != nil {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Bad Request")
+		return
+	}
+
+	// checking valid body
+	if len(body.Code) < 1 {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Invalid Parameter Request")
+		return
+	}
+
+	userID, ok := r.Context().Value("id").(int)
+	if !ok {
+		code = http.StatusInternalServerError
+		err = fmt.Errorf("Internal Server Error")
+		return
+	}
+
+	errChan := make(chan error, 1)
+	var result user.TwoFactorRecoveryInfo
+	go func(ctx context.Context) {
+		result, err = h.service.ConfirmTwoFactor(user.ConfirmTwoFactorServiceRequest{
+			UserId: userID,
+			Code:   body.Code,
+		})
+		errChan <- err
+	}(ctx)
+
+	select {
+	case <-ctx.Done():
+		code = http.StatusGatewayTimeout
+		err = fmt.Errorf("Timeout")
+		return
+	case err = <-errChan:
+		if err != nil {
+			if err == user.ErrInvalidTwoFactorCode {
+				code = http.StatusBadRequest
+			} else if err == user.ErrTwoFactorNotEnrolled {
+				code = http.StatusNotFound
+			} else if err == user.ErrTwoFactorAlreadyEnabled {
+				code = http.StatusConflict
+			} else {
+				code = http.StatusInternalServerError
+			}
+			return
+		}
+	}
+
+	response = mapResponseConfirmTwoFactor(result)
+}
+
+// ConfirmTwoFactorResponse is list response parameter for Confirm Two Factor Api
+type ConfirmTwoFactorResponse struct {
+	// RecoveryCodes is only shown once, each code can be used once instead of the authenticator app code
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func mapResponseConfirmTwoFactor(result user.TwoFactorRecoveryInfo) utilhttp.StandardResponse {
+	var res utilhttp.StandardResponse
+	res.Data = ConfirmTwoFactorResponse{
+		RecoveryCodes: result.RecoveryCodes,
+	}
+	return res
+}
@@ -0,0 +1,179 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/user"
+	"gilsaputro/dating-apps/internal/service/user/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestUserHandler_ConfirmTwoFactorHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockUserServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		userID  int
+		body    string
+		timeout int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				userID:  1,
+				body:    `{"code": "123456"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().ConfirmTwoFactor(user.ConfirmTwoFactorServiceRequest{UserId: 1, Code: "123456"}).Return(user.TwoFactorRecoveryInfo{RecoveryCodes: []string{"abcde-12345", "fghij-67890"}}, nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"recovery_codes":["abcde-12345","fghij-67890"]},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error invalid code flow",
+			args: args{
+				userID:  1,
+				body:    `{"code": "123456"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().ConfirmTwoFactor(user.ConfirmTwoFactorServiceRequest{UserId: 1, Code: "123456"}).Return(user.TwoFactorRecoveryInfo{}, user.ErrInvalidTwoFactorCode)
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"two factor code is invalid"}`,
+			},
+		},
+		{
+			name: "error not enrolled flow",
+			args: args{
+				userID:  1,
+				body:    `{"code": "123456"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().ConfirmTwoFactor(user.ConfirmTwoFactorServiceRequest{UserId: 1, Code: "123456"}).Return(user.TwoFactorRecoveryInfo{}, user.ErrTwoFactorNotEnrolled)
+			},
+			want: want{
+				code: 404,
+				body: `{"code":404,"message":"two factor authentication is not enrolled"}`,
+			},
+		},
+		{
+			name: "error already enabled flow",
+			args: args{
+				userID:  1,
+				body:    `{"code": "123456"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().ConfirmTwoFactor(user.ConfirmTwoFactorServiceRequest{UserId: 1, Code: "123456"}).Return(user.TwoFactorRecoveryInfo{}, user.ErrTwoFactorAlreadyEnabled)
+			},
+			want: want{
+				code: 409,
+				body: `{"code":409,"message":"two factor authentication is already enabled"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				userID:  1,
+				body:    `{"code": "123456"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().ConfirmTwoFactor(user.ConfirmTwoFactorServiceRequest{UserId: 1, Code: "123456"}).Return(user.TwoFactorRecoveryInfo{}, fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error missing user id flow",
+			args: args{
+				body:    `{"code": "123456"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+		{
+			name: "error invalid parameter flow",
+			args: args{
+				userID:  1,
+				body:    `{"code": ""}`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error bad request flow",
+			args: args{
+				userID:  1,
+				body:    `{"code": "123456"`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Bad Request"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			handler := UserHandler{
+				service:      m,
+				timeoutInSec: tt.args.timeout,
+			}
+			r := httptest.NewRequest(http.MethodPost, "/v1/user/2fa/confirm", strings.NewReader(tt.args.body))
+			if tt.args.userID > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.userID))
+			}
+			w := httptest.NewRecorder()
+			handler.ConfirmTwoFactorHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("ConfirmTwoFactorHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("ConfirmTwoFactorHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/user"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+)
+
+// DisableTwoFactorRequest is list request parameter for Disable Two Factor Api
+type DisableTwoFactorRequest struct {
+	Password string `json:"password"`
+}
+
+// DisableTwoFactorHandler is func handler for disable the two factor authentication of the login user
+func (h *UserHandler) DisableTwoFactorHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		response.Code = code
+		if err == nil {
+			response.Message = "success"
+		} else {
+			response.Message = err.Error()
+		}
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[DisableTwoFactorHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	var body DisableTwoFactorRequest
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Bad Request")
+		return
+	}
+
+	err = json.Unmarshal(data, &body)
+	if err != nil {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Bad Request")
+		return
+	}
+
+	// checking valid body
+	if len(body.Password) < 1 {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Invalid Parameter Request")
+		return
+	}
+
+	userID, ok := r.Context().Value("id").(int)
+	if !ok {
+		code = http.StatusInternalServerError
+		err = fmt.Errorf("Internal Server Error")
+		return
+	}
+
+	errChan := make(chan error, 1)
+	go func(ctx context.Context) {
+		err = h.service.DisableTwoFactor(user.DisableTwoFactorServiceRequest{
+			UserId:   userID,
+			Password: body.Password,
+		})
+		errChan <- err
+	}(ctx)
+
+	select {
+	case <-ctx.Done():
+		code = http.StatusGatewayTimeout
+		err = fmt.Errorf("Timeout")
+		return
+	case err = <-errChan:
+		if err != nil {
+			if err == user.ErrPasswordIsIncorrect {
+				code = http.StatusBadRequest
+			} else if err == user.ErrTwoFactorNotEnrolled {
+				code = http.StatusNotFound
+			} else {
+				code = http.StatusInternalServerError
+			}
+			return
+		}
+	}
+}
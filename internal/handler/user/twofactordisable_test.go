@@ -0,0 +1,164 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/user"
+	"gilsaputro/dating-apps/internal/service/user/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestUserHandler_DisableTwoFactorHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockUserServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		userID  int
+		body    string
+		timeout int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				userID:  1,
+				body:    `{"password": "pas1"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().DisableTwoFactor(user.DisableTwoFactorServiceRequest{UserId: 1, Password: "pas1"}).Return(nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error password incorrect flow",
+			args: args{
+				userID:  1,
+				body:    `{"password": "pas1"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().DisableTwoFactor(user.DisableTwoFactorServiceRequest{UserId: 1, Password: "pas1"}).Return(user.ErrPasswordIsIncorrect)
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"password is incorrect"}`,
+			},
+		},
+		{
+			name: "error not enrolled flow",
+			args: args{
+				userID:  1,
+				body:    `{"password": "pas1"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().DisableTwoFactor(user.DisableTwoFactorServiceRequest{UserId: 1, Password: "pas1"}).Return(user.ErrTwoFactorNotEnrolled)
+			},
+			want: want{
+				code: 404,
+				body: `{"code":404,"message":"two factor authentication is not enrolled"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				userID:  1,
+				body:    `{"password": "pas1"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().DisableTwoFactor(user.DisableTwoFactorServiceRequest{UserId: 1, Password: "pas1"}).Return(fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error missing user id flow",
+			args: args{
+				body:    `{"password": "pas1"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+		{
+			name: "error invalid parameter flow",
+			args: args{
+				userID:  1,
+				body:    `{"password": ""}`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error bad request flow",
+			args: args{
+				userID:  1,
+				body:    `{"password": "pas1"`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Bad Request"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			handler := UserHandler{
+				service:      m,
+				timeoutInSec: tt.args.timeout,
+			}
+			r := httptest.NewRequest(http.MethodDelete, "/v1/user/2fa", strings.NewReader(tt.args.body))
+			if tt.args.userID > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.userID))
+			}
+			w := httptest.NewRecorder()
+			handler.DisableTwoFactorHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("DisableTwoFactorHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("DisableTwoFactorHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
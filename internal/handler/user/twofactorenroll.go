@@ -0,0 +1,122 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/user"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+)
+
+// EnrollTwoFactorRequest is list request parameter for Enroll Two Factor Api
+type EnrollTwoFactorRequest struct {
+	Password string `json:"password"`
+}
+
+// EnrollTwoFactorHandler is func handler for start the two factor authentication enrollment of the login user
+func (h *UserHandler) EnrollTwoFactorHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		response.Code = code
+		if err == nil {
+			response.Message = "success"
+		} else {
+			response.Message = err.Error()
+		}
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[EnrollTwoFactorHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	var body EnrollTwoFactorRequest
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Bad Request")
+		return
+	}
+
+	err = json.Unmarshal(data, &body)
+	if err != nil {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Bad Request")
+		return
+	}
+
+	// checking valid body
+	if len(body.Password) < 1 {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Invalid Parameter Request")
+		return
+	}
+
+	userID, ok := r.Context().Value("id").(int)
+	if !ok {
+		code = http.StatusInternalServerError
+		err = fmt.Errorf("Internal Server Error")
+		return
+	}
+
+	errChan := make(chan error, 1)
+	var result user.TwoFactorEnrollmentInfo
+	go func(ctx context.Context) {
+		result, err = h.service.EnrollTwoFactor(user.EnrollTwoFactorServiceRequest{
+			UserId:   userID,
+			Password: body.Password,
+		})
+		errChan <- err
+	}(ctx)
+
+	select {
+	case <-ctx.Done():
+		code = http.StatusGatewayTimeout
+		err = fmt.Errorf("Timeout")
+		return
+	case err = <-errChan:
+		if err != nil {
+			if err == user.ErrPasswordIsIncorrect {
+				code = http.StatusBadRequest
+			} else if err == user.ErrTwoFactorNotAllowed {
+				code = http.StatusForbidden
+			} else if err == user.ErrTwoFactorAlreadyEnabled {
+				code = http.StatusConflict
+			} else {
+				code = http.StatusInternalServerError
+			}
+			return
+		}
+	}
+
+	response = mapResponseEnrollTwoFactor(result)
+}
+
+// EnrollTwoFactorResponse is list response parameter for Enroll Two Factor Api
+type EnrollTwoFactorResponse struct {
+	Secret string `json:"secret"`
+	// OtpauthURI can be shown as qr code to be scanned by the authenticator app
+	OtpauthURI string `json:"otpauth_uri"`
+}
+
+func mapResponseEnrollTwoFactor(result user.TwoFactorEnrollmentInfo) utilhttp.StandardResponse {
+	var res utilhttp.StandardResponse
+	res.Data = EnrollTwoFactorResponse{
+		Secret:     result.Secret,
+		OtpauthURI: result.URI,
+	}
+	return res
+}
@@ -1,59 +1,203 @@
 package user
 
 import (
+	"encoding/json"
 	"gilsaputro/dating-apps/internal/handler/utilhttp"
 	"gilsaputro/dating-apps/internal/service/user"
 )
 
-// UpgradeUserRequest is list request parameter for Upgrade Api
-type UpgradeUserRequest struct {
-	Password string `json:"password"`
+type UserProfile struct {
+	UserID          int                 `json:"id"`
+	Username        string              `json:"username"`
+	Fullname        string              `json:"fullname"`
+	Email           string              `json:"email"`
+	Plan            string              `json:"plan"`
+	IsEmailVerified bool                `json:"is_email_verified"`
+	Birthdate       string              `json:"birthdate,omitempty"`
+	Age             int                 `json:"age,omitempty"`
+	Gender          string              `json:"gender,omitempty"`
+	InterestedIn    []string            `json:"interested_in,omitempty"`
+	Discovery       DiscoveryPreference `json:"discovery"`
+	Location        *UserLocation       `json:"location,omitempty"`
+	CreatedDate     string              `json:"created_date"`
 }
 
-type UserProfile struct {
-	UserID      int    `json:"id"`
-	Username    string `json:"username"`
-	Fullname    string `json:"fullname"`
-	Email       string `json:"email"`
-	IsVerified  bool   `json:"is_verified"`
-	CreatedDate string `json:"created_date"`
+// UserLocation is last location shared by the user
+type UserLocation struct {
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	UpdatedDate string  `json:"updated_date"`
+}
+
+// DiscoveryPreference is list discovery preference of the user
+type DiscoveryPreference struct {
+	AgeMin      int `json:"age_min"`
+	AgeMax      int `json:"age_max"`
+	MaxDistance int `json:"max_distance_km"`
 }
 
 func mapResponseUserProfile(profile user.UserServiceInfo) utilhttp.StandardResponse {
 	var res utilhttp.StandardResponse
 	res.Data = UserProfile{
-		UserID:      profile.UserId,
-		Username:    profile.Username,
-		Fullname:    profile.Fullname,
-		Email:       profile.Email,
-		IsVerified:  profile.IsVerified,
+		UserID:          profile.UserId,
+		Username:        profile.Username,
+		Fullname:        profile.Fullname,
+		Email:           profile.Email,
+		Plan:            profile.Plan,
+		IsEmailVerified: profile.IsEmailVerified,
+		Birthdate:       profile.Birthdate,
+		Age:             profile.Age,
+		Gender:          profile.Gender,
+		InterestedIn:    profile.InterestedIn,
+		Discovery: DiscoveryPreference{
+			AgeMin:      profile.AgeMin,
+			AgeMax:      profile.AgeMax,
+			MaxDistance: profile.MaxDistance,
+		},
+		Location:    mapUserLocation(profile.Location),
 		CreatedDate: profile.CreatedDate,
 	}
 	return res
 }
 
+func mapUserLocation(location *user.LocationInfo) *UserLocation {
+	if location == nil {
+		return nil
+	}
+
+	return &UserLocation{
+		Latitude:    location.Latitude,
+		Longitude:   location.Longitude,
+		UpdatedDate: location.UpdatedDate,
+	}
+}
+
 // EditUserRequest is list request parameter for Edit Api
 type EditUserRequest struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
-	Email    string `json:"email"`
-	Fullname string `json:"fullname"`
+	Username     string                     `json:"username"`
+	Password     string                     `json:"password"`
+	Email        string                     `json:"email"`
+	Fullname     string                     `json:"fullname"`
+	Birthdate    string                     `json:"birthdate"`
+	Gender       string                     `json:"gender"`
+	InterestedIn []string                   `json:"interested_in"`
+	Discovery    DiscoveryPreferenceRequest `json:"discovery"`
+}
+
+// DiscoveryPreferenceRequest is list discovery preference to update, the preference that is not sent is not changed
+// and the preference sent as 0 or null is reset to no preference
+type DiscoveryPreferenceRequest struct {
+	AgeMin      OptionalInt `json:"age_min"`
+	AgeMax      OptionalInt `json:"age_max"`
+	MaxDistance OptionalInt `json:"max_distance_km"`
+}
+
+// OptionalInt is optional integer of the request, the value is nil when the field is not sent and 0 when the field is null
+type OptionalInt struct {
+	Value *int
+}
+
+// UnmarshalJSON is func to read the optional integer, it is only called when the field is sent
+func (o *OptionalInt) UnmarshalJSON(data []byte) error {
+	var value *int
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	if value == nil {
+		value = new(int)
+	}
+	o.Value = value
+	return nil
+}
+
+// hasDiscoveryProfile is func to check the request contain discovery profile or preference
+func (r EditUserRequest) hasDiscoveryProfile() bool {
+	return len(r.Birthdate) > 0 || len(r.Gender) > 0 || r.InterestedIn != nil || r.Discovery.AgeMin.Value != nil ||
+		r.Discovery.AgeMax.Value != nil || r.Discovery.MaxDistance.Value != nil
 }
 
 // EditUserResponse is list response parameter for Edit Api
 type EditUserResponse struct {
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Fullname string `json:"fullname"`
+	Username     string              `json:"username"`
+	Email        string              `json:"email"`
+	Fullname     string              `json:"fullname"`
+	Birthdate    string              `json:"birthdate,omitempty"`
+	Gender       string              `json:"gender,omitempty"`
+	InterestedIn []string            `json:"interested_in,omitempty"`
+	Discovery    DiscoveryPreference `json:"discovery"`
 }
 
 func mapResponseEdit(result user.UserServiceInfo) utilhttp.StandardResponse {
 	var res utilhttp.StandardResponse
 
 	data := EditUserResponse{
-		Username: result.Username,
-		Email:    result.Email,
-		Fullname: result.Fullname,
+		Username:     result.Username,
+		Email:        result.Email,
+		Fullname:     result.Fullname,
+		Birthdate:    result.Birthdate,
+		Gender:       result.Gender,
+		InterestedIn: result.InterestedIn,
+		Discovery: DiscoveryPreference{
+			AgeMin:      result.AgeMin,
+			AgeMax:      result.AgeMax,
+			MaxDistance: result.MaxDistance,
+		},
+	}
+
+	res.Data = data
+	return res
+}
+
+// UpdateLocationRequest is list request parameter for Update Location Api
+type UpdateLocationRequest struct {
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+}
+
+// UpdateLocationResponse is list response parameter for Update Location Api
+type UpdateLocationResponse struct {
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	UpdatedDate string  `json:"updated_date"`
+}
+
+func mapResponseUpdateLocation(result user.UserServiceInfo) utilhttp.StandardResponse {
+	var res utilhttp.StandardResponse
+	if result.Location != nil {
+		res.Data = UpdateLocationResponse{
+			Latitude:    result.Location.Latitude,
+			Longitude:   result.Location.Longitude,
+			UpdatedDate: result.Location.UpdatedDate,
+		}
+	}
+	return res
+}
+
+// SessionResponse is device session of the user
+type SessionResponse struct {
+	SessionID   string `json:"session_id"`
+	Device      string `json:"device,omitempty"`
+	IPAddress   string `json:"ip_address,omitempty"`
+	UserAgent   string `json:"user_agent,omitempty"`
+	LastSeen    string `json:"last_seen"`
+	CreatedDate string `json:"created_date"`
+	IsCurrent   bool   `json:"is_current"`
+}
+
+func mapResponseSessionList(result []user.SessionInfo) utilhttp.StandardResponse {
+	var res utilhttp.StandardResponse
+	data := make([]SessionResponse, 0, len(result))
+	for _, session := range result {
+		data = append(data, SessionResponse{
+			SessionID:   session.SessionID,
+			Device:      session.Device,
+			IPAddress:   session.IPAddress,
+			UserAgent:   session.UserAgent,
+			LastSeen:    session.LastSeen,
+			CreatedDate: session.CreatedDate,
+			IsCurrent:   session.IsCurrent,
+		})
 	}
 
 	res.Data = data
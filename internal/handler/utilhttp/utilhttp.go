@@ -1,6 +1,11 @@
 package utilhttp
 
-import "net/http"
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+)
 
 // WriteResponse is func to generate response for http handler
 func WriteResponse(w http.ResponseWriter, data []byte, status int) (int, error) {
@@ -11,7 +16,33 @@ func WriteResponse(w http.ResponseWriter, data []byte, status int) (int, error)
 
 // StandardResponse is AquaFarmManager standard JSON HTTP response.
 type StandardResponse struct {
-	Data    interface{} `json:"data,omitempty"`
-	Code    int         `json:"code"`
-	Message string      `json:"message"`
+	Data       interface{} `json:"data,omitempty"`
+	Code       int         `json:"code"`
+	Message    string      `json:"message"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+// GetClientIP is func to get the ip address of the client from the remote address of the request,
+// the forwarded header is not trusted because it can be set by the client
+func GetClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// ParseQueryInt is func to get optional integer query parameter, it will return 0 if the parameter is empty
+func ParseQueryInt(r *http.Request, key string) (int, error) {
+	value := r.URL.Query().Get(key)
+	if len(value) == 0 {
+		return 0, nil
+	}
+
+	num, err := strconv.Atoi(value)
+	if err != nil || num < 0 {
+		return 0, fmt.Errorf("invalid %s", key)
+	}
+
+	return num, nil
 }
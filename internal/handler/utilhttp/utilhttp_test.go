@@ -42,3 +42,79 @@ func TestWriteResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestGetClientIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		want       string
+	}{
+		{
+			name:       "ipv4 with port",
+			remoteAddr: "192.0.2.1:1234",
+			want:       "192.0.2.1",
+		},
+		{
+			name:       "ipv6 with port",
+			remoteAddr: "[2001:db8::1]:1234",
+			want:       "2001:db8::1",
+		},
+		{
+			name:       "without port",
+			remoteAddr: "192.0.2.1",
+			want:       "192.0.2.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if got := GetClientIP(r); got != tt.want {
+				t.Errorf("GetClientIP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQueryInt(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "empty parameter",
+			url:  "/",
+			want: 0,
+		},
+		{
+			name: "valid parameter",
+			url:  "/?page=2",
+			want: 2,
+		},
+		{
+			name:    "not a number",
+			url:     "/?page=abc",
+			wantErr: true,
+		},
+		{
+			name:    "negative number",
+			url:     "/?page=-1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			got, err := ParseQueryInt(r, "page")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseQueryInt() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseQueryInt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package verification
+
+import (
+	"gilsaputro/dating-apps/internal/service/verification"
+)
+
+// VerificationHandler list dependencies for verification handler
+type VerificationHandler struct {
+	service      verification.VerificationServiceMethod
+	timeoutInSec int
+}
+
+// Option set options for http handler config
+type Option func(*VerificationHandler)
+
+const (
+	defaultTimeout = 5
+)
+
+// NewVerificationHandler is func to create http verification handler
+func NewVerificationHandler(service verification.VerificationServiceMethod, options ...Option) *VerificationHandler {
+	handler := &VerificationHandler{
+		service:      service,
+		timeoutInSec: defaultTimeout,
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(handler)
+	}
+
+	return handler
+}
+
+// WithTimeoutOptions is func to set timeout config into handler
+func WithTimeoutOptions(timeoutinsec int) Option {
+	return Option(
+		func(h *VerificationHandler) {
+			if timeoutinsec <= 0 {
+				timeoutinsec = defaultTimeout
+			}
+			h.timeoutInSec = timeoutinsec
+		})
+}
@@ -0,0 +1,133 @@
+package verification
+
+import (
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/verification"
+	"gilsaputro/dating-apps/internal/service/verification/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestVerificationHandler_VerificationInfoHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockVerificationServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		userID  int
+		timeout int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				userID:  1,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetVerification(verification.GetVerificationServiceRequest{
+					UserId: 1,
+				}).Return(verification.VerificationStatusServiceInfo{
+					IsVerified: false,
+					Request: &verification.VerificationServiceInfo{
+						VerificationID: 3,
+						UserID:         1,
+						Type:           "ID_DOCUMENT",
+						MediaURL:       "https://cdn.local/id.jpg",
+						Status:         "REJECTED",
+						RejectReason:   "blurry photo",
+						CreatedDate:    "2023-11-14",
+						ReviewedDate:   "2023-11-15",
+					},
+				}, nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"is_verified":false,"request":{"id":3,"user_id":1,"type":"ID_DOCUMENT","media_url":"https://cdn.local/id.jpg","status":"REJECTED","reject_reason":"blurry photo","created_date":"2023-11-14","reviewed_date":"2023-11-15"}},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "success never submit flow",
+			args: args{
+				userID:  1,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetVerification(verification.GetVerificationServiceRequest{
+					UserId: 1,
+				}).Return(verification.VerificationStatusServiceInfo{}, nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"is_verified":false},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				userID:  1,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetVerification(verification.GetVerificationServiceRequest{
+					UserId: 1,
+				}).Return(verification.VerificationStatusServiceInfo{}, fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error missing user id",
+			args: args{
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			defer mockCtrl.Finish()
+			handler := NewVerificationHandler(m, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodGet, "/v1/user/verification", nil)
+			if tt.args.userID > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.userID))
+			}
+			w := httptest.NewRecorder()
+			handler.VerificationInfoHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("VerificationInfoHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("VerificationInfoHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package verification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/verification"
+	"log"
+	"net/http"
+	"time"
+)
+
+// VerificationListHandler is func handler for get verification review queue by admin
+func (h *VerificationHandler) VerificationListHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		response.Code = code
+		if err == nil {
+			response.Message = "success"
+		} else {
+			response.Message = err.Error()
+		}
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[VerificationListHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	page, err := utilhttp.ParseQueryInt(r, "page")
+	if err != nil {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Invalid Parameter Request")
+		return
+	}
+
+	limit, err := utilhttp.ParseQueryInt(r, "limit")
+	if err != nil {
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Invalid Parameter Request")
+		return
+	}
+
+	errChan := make(chan error, 1)
+	var result verification.VerificationListServiceInfo
+	go func(ctx context.Context) {
+		result, err = h.service.GetListVerification(verification.VerificationListServiceRequest{
+			Status: r.URL.Query().Get("status"),
+			Page:   page,
+			Limit:  limit,
+		})
+		errChan <- err
+	}(ctx)
+
+	select {
+	case <-ctx.Done():
+		code = http.StatusGatewayTimeout
+		err = fmt.Errorf("Timeout")
+		return
+	case err = <-errChan:
+		if err != nil {
+			code = mapVerificationErrorCode(err)
+			return
+		}
+	}
+
+	response = mapVerificationListResponse(result)
+}
@@ -0,0 +1,143 @@
+package verification
+
+import (
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/verification"
+	"gilsaputro/dating-apps/internal/service/verification/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestVerificationHandler_VerificationListHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockVerificationServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		query   string
+		timeout int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				query:   "?status=PENDING&page=1&limit=1",
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetListVerification(verification.VerificationListServiceRequest{
+					Status: "PENDING",
+					Page:   1,
+					Limit:  1,
+				}).Return(verification.VerificationListServiceInfo{
+					Verifications: []verification.VerificationServiceInfo{
+						{
+							VerificationID: 3,
+							UserID:         1,
+							Type:           "SELFIE",
+							MediaURL:       "https://cdn.local/selfie.jpg",
+							Status:         "PENDING",
+							CreatedDate:    "2023-11-14",
+						},
+					},
+					Page:  1,
+					Limit: 1,
+					Total: 4,
+				}, nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"verifications":[{"id":3,"user_id":1,"type":"SELFIE","media_url":"https://cdn.local/selfie.jpg","status":"PENDING","created_date":"2023-11-14"}],"pagination":{"page":1,"limit":1,"total":4}},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error invalid status flow",
+			args: args{
+				query:   "?status=UNKNOWN",
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetListVerification(verification.VerificationListServiceRequest{
+					Status: "UNKNOWN",
+				}).Return(verification.VerificationListServiceInfo{}, verification.ErrInvalidVerificationStatus)
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"verification status is invalid"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().GetListVerification(verification.VerificationListServiceRequest{}).Return(verification.VerificationListServiceInfo{}, fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error invalid page flow",
+			args: args{
+				query:   "?page=abc",
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error invalid limit flow",
+			args: args{
+				query:   "?limit=-1",
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			defer mockCtrl.Finish()
+			handler := NewVerificationHandler(m, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodGet, "/v1/admin/verifications"+tt.args.query, nil)
+			w := httptest.NewRecorder()
+			handler.VerificationListHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("VerificationListHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("VerificationListHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package verification
+
+import (
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/verification"
+	"gilsaputro/dating-apps/internal/service/verification/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+func TestVerificationHandler_ReviewVerificationHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockVerificationServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		adminID        int
+		verificationID string
+		body           string
+		timeout        int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				adminID:        9,
+				verificationID: "3",
+				body:           `{"status":"REJECTED","reason":"blurry photo"}`,
+				timeout:        5,
+			},
+			mockFunc: func() {
+				m.EXPECT().ReviewVerification(verification.ReviewVerificationServiceRequest{
+					VerificationID: 3,
+					AdminID:        9,
+					Status:         "REJECTED",
+					RejectReason:   "blurry photo",
+				}).Return(nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error verification not found flow",
+			args: args{
+				adminID:        9,
+				verificationID: "3",
+				body:           `{"status":"APPROVED"}`,
+				timeout:        5,
+			},
+			mockFunc: func() {
+				m.EXPECT().ReviewVerification(verification.ReviewVerificationServiceRequest{
+					VerificationID: 3,
+					AdminID:        9,
+					Status:         "APPROVED",
+				}).Return(verification.ErrVerificationNotFound)
+			},
+			want: want{
+				code: 404,
+				body: `{"code":404,"message":"the verification request is not found or already reviewed"}`,
+			},
+		},
+		{
+			name: "error reject reason required flow",
+			args: args{
+				adminID:        9,
+				verificationID: "3",
+				body:           `{"status":"REJECTED"}`,
+				timeout:        5,
+			},
+			mockFunc: func() {
+				m.EXPECT().ReviewVerification(verification.ReviewVerificationServiceRequest{
+					VerificationID: 3,
+					AdminID:        9,
+					Status:         "REJECTED",
+				}).Return(verification.ErrRejectReasonRequired)
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"reject reason is required to reject the verification request"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				adminID:        9,
+				verificationID: "3",
+				body:           `{"status":"APPROVED"}`,
+				timeout:        5,
+			},
+			mockFunc: func() {
+				m.EXPECT().ReviewVerification(verification.ReviewVerificationServiceRequest{
+					VerificationID: 3,
+					AdminID:        9,
+					Status:         "APPROVED",
+				}).Return(fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error empty status flow",
+			args: args{
+				adminID:        9,
+				verificationID: "3",
+				body:           `{}`,
+				timeout:        5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error invalid verification id",
+			args: args{
+				adminID:        9,
+				verificationID: "abc",
+				body:           `{"status":"APPROVED"}`,
+				timeout:        5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error missing admin id",
+			args: args{
+				verificationID: "3",
+				body:           `{"status":"APPROVED"}`,
+				timeout:        5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			defer mockCtrl.Finish()
+			handler := NewVerificationHandler(m, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodPost, "/v1/admin/verifications/"+tt.args.verificationID+"/review", strings.NewReader(tt.args.body))
+			if tt.args.adminID > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.adminID))
+			}
+			r = mux.SetURLVars(r, map[string]string{"verificationID": tt.args.verificationID})
+			w := httptest.NewRecorder()
+			handler.ReviewVerificationHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("ReviewVerificationHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("ReviewVerificationHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package verification
+
+import (
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/verification"
+	"gilsaputro/dating-apps/internal/service/verification/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestVerificationHandler_SubmitVerificationHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	m := mock.NewMockVerificationServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		userID  int
+		body    string
+		timeout int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				userID:  1,
+				body:    `{"type":"SELFIE","media_url":"https://cdn.local/selfie.jpg"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().SubmitVerification(verification.SubmitVerificationServiceRequest{
+					UserId:   1,
+					Type:     "SELFIE",
+					MediaURL: "https://cdn.local/selfie.jpg",
+				}).Return(verification.VerificationServiceInfo{
+					VerificationID: 3,
+					UserID:         1,
+					Type:           "SELFIE",
+					MediaURL:       "https://cdn.local/selfie.jpg",
+					Status:         "PENDING",
+					CreatedDate:    "2023-11-14",
+				}, nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"id":3,"user_id":1,"type":"SELFIE","media_url":"https://cdn.local/selfie.jpg","status":"PENDING","created_date":"2023-11-14"},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "error pending request flow",
+			args: args{
+				userID:  1,
+				body:    `{"type":"SELFIE","media_url":"https://cdn.local/selfie.jpg"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().SubmitVerification(verification.SubmitVerificationServiceRequest{
+					UserId:   1,
+					Type:     "SELFIE",
+					MediaURL: "https://cdn.local/selfie.jpg",
+				}).Return(verification.VerificationServiceInfo{}, verification.ErrVerificationPending)
+			},
+			want: want{
+				code: 409,
+				body: `{"code":409,"message":"user already has pending verification request"}`,
+			},
+		},
+		{
+			name: "error invalid type flow",
+			args: args{
+				userID:  1,
+				body:    `{"type":"PASSPORT","media_url":"https://cdn.local/selfie.jpg"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().SubmitVerification(verification.SubmitVerificationServiceRequest{
+					UserId:   1,
+					Type:     "PASSPORT",
+					MediaURL: "https://cdn.local/selfie.jpg",
+				}).Return(verification.VerificationServiceInfo{}, verification.ErrInvalidVerificationType)
+			},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"verification type is invalid, the value should be SELFIE or ID_DOCUMENT"}`,
+			},
+		},
+		{
+			name: "error on service flow",
+			args: args{
+				userID:  1,
+				body:    `{"type":"SELFIE","media_url":"https://cdn.local/selfie.jpg"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {
+				m.EXPECT().SubmitVerification(verification.SubmitVerificationServiceRequest{
+					UserId:   1,
+					Type:     "SELFIE",
+					MediaURL: "https://cdn.local/selfie.jpg",
+				}).Return(verification.VerificationServiceInfo{}, fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"some error"}`,
+			},
+		},
+		{
+			name: "error empty media url flow",
+			args: args{
+				userID:  1,
+				body:    `{"type":"SELFIE"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Invalid Parameter Request"}`,
+			},
+		},
+		{
+			name: "error invalid body flow",
+			args: args{
+				userID:  1,
+				body:    `{`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 400,
+				body: `{"code":400,"message":"Bad Request"}`,
+			},
+		},
+		{
+			name: "error missing user id",
+			args: args{
+				body:    `{"type":"SELFIE","media_url":"https://cdn.local/selfie.jpg"}`,
+				timeout: 5,
+			},
+			mockFunc: func() {},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			defer mockCtrl.Finish()
+			handler := NewVerificationHandler(m, WithTimeoutOptions(tt.args.timeout))
+			r := httptest.NewRequest(http.MethodPost, "/v1/user/verification", strings.NewReader(tt.args.body))
+			if tt.args.userID > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "id", tt.args.userID))
+			}
+			w := httptest.NewRecorder()
+			handler.SubmitVerificationHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("SubmitVerificationHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("SubmitVerificationHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+		})
+	}
+}
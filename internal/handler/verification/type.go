@@ -0,0 +1,109 @@
+package verification
+
+import (
+	"gilsaputro/dating-apps/internal/handler/utilhttp"
+	"gilsaputro/dating-apps/internal/service/verification"
+	"net/http"
+)
+
+// SubmitVerificationRequest is list request parameter for Submit Verification Api
+type SubmitVerificationRequest struct {
+	Type     string `json:"type"`
+	MediaURL string `json:"media_url"`
+}
+
+// ReviewVerificationRequest is list request parameter for Review Verification Api
+type ReviewVerificationRequest struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+// VerificationResponse is list response parameter for a verification request
+type VerificationResponse struct {
+	VerificationID int    `json:"id"`
+	UserID         int    `json:"user_id"`
+	Type           string `json:"type"`
+	MediaURL       string `json:"media_url"`
+	Status         string `json:"status"`
+	RejectReason   string `json:"reject_reason,omitempty"`
+	CreatedDate    string `json:"created_date"`
+	ReviewedDate   string `json:"reviewed_date,omitempty"`
+}
+
+// VerificationStatusResponse is list response parameter for Verification Info Api
+type VerificationStatusResponse struct {
+	IsVerified bool                  `json:"is_verified"`
+	Request    *VerificationResponse `json:"request,omitempty"`
+}
+
+// VerificationListResponse is list response parameter for Verification List Api
+type VerificationListResponse struct {
+	Verifications []VerificationResponse `json:"verifications"`
+	Pagination    Pagination             `json:"pagination"`
+}
+
+// Pagination is pagination info of the list response
+type Pagination struct {
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+	Total int `json:"total"`
+}
+
+func mapVerificationResponse(result verification.VerificationServiceInfo) VerificationResponse {
+	return VerificationResponse{
+		VerificationID: result.VerificationID,
+		UserID:         result.UserID,
+		Type:           result.Type,
+		MediaURL:       result.MediaURL,
+		Status:         result.Status,
+		RejectReason:   result.RejectReason,
+		CreatedDate:    result.CreatedDate,
+		ReviewedDate:   result.ReviewedDate,
+	}
+}
+
+func mapVerificationStatusResponse(result verification.VerificationStatusServiceInfo) utilhttp.StandardResponse {
+	var res utilhttp.StandardResponse
+	data := VerificationStatusResponse{
+		IsVerified: result.IsVerified,
+	}
+	if result.Request != nil {
+		request := mapVerificationResponse(*result.Request)
+		data.Request = &request
+	}
+
+	res.Data = data
+	return res
+}
+
+func mapVerificationListResponse(result verification.VerificationListServiceInfo) utilhttp.StandardResponse {
+	var res utilhttp.StandardResponse
+	list := []VerificationResponse{}
+	for _, data := range result.Verifications {
+		list = append(list, mapVerificationResponse(data))
+	}
+
+	res.Data = VerificationListResponse{
+		Verifications: list,
+		Pagination: Pagination{
+			Page:  result.Page,
+			Limit: result.Limit,
+			Total: result.Total,
+		},
+	}
+	return res
+}
+
+// mapVerificationErrorCode is func to get http status code of the verification error
+func mapVerificationErrorCode(err error) int {
+	switch err {
+	case verification.ErrInvalidVerificationType, verification.ErrInvalidMediaURL, verification.ErrInvalidVerificationStatus, verification.ErrRejectReasonRequired, verification.ErrDataNotFound:
+		return http.StatusBadRequest
+	case verification.ErrAlreadyVerified, verification.ErrVerificationPending:
+		return http.StatusConflict
+	case verification.ErrVerificationNotFound:
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
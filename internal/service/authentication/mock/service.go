@@ -6,6 +6,7 @@ package mock
 
 import (
 	authentication "gilsaputro/dating-apps/internal/service/authentication"
+	token "gilsaputro/dating-apps/pkg/token"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,11 +35,39 @@ func (m *MockAuthenticationServiceMethod) EXPECT() *MockAuthenticationServiceMet
 	return m.recorder
 }
 
+// ForgotPassword mocks base method.
+func (m *MockAuthenticationServiceMethod) ForgotPassword(arg0 authentication.ForgotPasswordServiceRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockAuthenticationServiceMethodMockRecorder) ForgotPassword(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockAuthenticationServiceMethod)(nil).ForgotPassword), arg0)
+}
+
+// GetJWKS mocks base method.
+func (m *MockAuthenticationServiceMethod) GetJWKS() token.JWKS {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJWKS")
+	ret0, _ := ret[0].(token.JWKS)
+	return ret0
+}
+
+// GetJWKS indicates an expected call of GetJWKS.
+func (mr *MockAuthenticationServiceMethodMockRecorder) GetJWKS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWKS", reflect.TypeOf((*MockAuthenticationServiceMethod)(nil).GetJWKS))
+}
+
 // Login mocks base method.
-func (m *MockAuthenticationServiceMethod) Login(arg0 authentication.LoginServiceRequest) (string, error) {
+func (m *MockAuthenticationServiceMethod) Login(arg0 authentication.LoginServiceRequest) (authentication.LoginServiceInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Login", arg0)
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].(authentication.LoginServiceInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -49,6 +78,65 @@ func (mr *MockAuthenticationServiceMethodMockRecorder) Login(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuthenticationServiceMethod)(nil).Login), arg0)
 }
 
+// LoginOAuth mocks base method.
+func (m *MockAuthenticationServiceMethod) LoginOAuth(arg0 authentication.LoginOAuthServiceRequest) (authentication.LoginServiceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginOAuth", arg0)
+	ret0, _ := ret[0].(authentication.LoginServiceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginOAuth indicates an expected call of LoginOAuth.
+func (mr *MockAuthenticationServiceMethodMockRecorder) LoginOAuth(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginOAuth", reflect.TypeOf((*MockAuthenticationServiceMethod)(nil).LoginOAuth), arg0)
+}
+
+// LoginTwoFactor mocks base method.
+func (m *MockAuthenticationServiceMethod) LoginTwoFactor(arg0 authentication.LoginTwoFactorServiceRequest) (authentication.LoginServiceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginTwoFactor", arg0)
+	ret0, _ := ret[0].(authentication.LoginServiceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginTwoFactor indicates an expected call of LoginTwoFactor.
+func (mr *MockAuthenticationServiceMethodMockRecorder) LoginTwoFactor(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginTwoFactor", reflect.TypeOf((*MockAuthenticationServiceMethod)(nil).LoginTwoFactor), arg0)
+}
+
+// Logout mocks base method.
+func (m *MockAuthenticationServiceMethod) Logout(arg0 authentication.LogoutServiceRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockAuthenticationServiceMethodMockRecorder) Logout(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthenticationServiceMethod)(nil).Logout), arg0)
+}
+
+// RefreshToken mocks base method.
+func (m *MockAuthenticationServiceMethod) RefreshToken(arg0 authentication.RefreshTokenServiceRequest) (authentication.LoginServiceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken", arg0)
+	ret0, _ := ret[0].(authentication.LoginServiceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockAuthenticationServiceMethodMockRecorder) RefreshToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockAuthenticationServiceMethod)(nil).RefreshToken), arg0)
+}
+
 // Register mocks base method.
 func (m *MockAuthenticationServiceMethod) Register(arg0 authentication.RegisterServiceRequest) error {
 	m.ctrl.T.Helper()
@@ -62,3 +150,60 @@ func (mr *MockAuthenticationServiceMethodMockRecorder) Register(arg0 interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockAuthenticationServiceMethod)(nil).Register), arg0)
 }
+
+// ResendEmailVerification mocks base method.
+func (m *MockAuthenticationServiceMethod) ResendEmailVerification(arg0 authentication.ResendEmailVerificationServiceRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendEmailVerification", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendEmailVerification indicates an expected call of ResendEmailVerification.
+func (mr *MockAuthenticationServiceMethodMockRecorder) ResendEmailVerification(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendEmailVerification", reflect.TypeOf((*MockAuthenticationServiceMethod)(nil).ResendEmailVerification), arg0)
+}
+
+// ResetPassword mocks base method.
+func (m *MockAuthenticationServiceMethod) ResetPassword(arg0 authentication.ResetPasswordServiceRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAuthenticationServiceMethodMockRecorder) ResetPassword(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAuthenticationServiceMethod)(nil).ResetPassword), arg0)
+}
+
+// StartOAuth mocks base method.
+func (m *MockAuthenticationServiceMethod) StartOAuth(arg0 authentication.StartOAuthServiceRequest) (authentication.StartOAuthServiceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartOAuth", arg0)
+	ret0, _ := ret[0].(authentication.StartOAuthServiceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartOAuth indicates an expected call of StartOAuth.
+func (mr *MockAuthenticationServiceMethodMockRecorder) StartOAuth(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartOAuth", reflect.TypeOf((*MockAuthenticationServiceMethod)(nil).StartOAuth), arg0)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockAuthenticationServiceMethod) VerifyEmail(arg0 authentication.VerifyEmailServiceRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockAuthenticationServiceMethodMockRecorder) VerifyEmail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockAuthenticationServiceMethod)(nil).VerifyEmail), arg0)
+}
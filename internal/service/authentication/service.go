@@ -1,57 +1,698 @@
 package authentication
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"gilsaputro/dating-apps/internal/store/identity"
+	"gilsaputro/dating-apps/internal/store/loginattempt"
+	"gilsaputro/dating-apps/internal/store/session"
+	"gilsaputro/dating-apps/internal/store/tokencache"
+	"gilsaputro/dating-apps/internal/store/twofactor"
 	"gilsaputro/dating-apps/internal/store/user"
+	"gilsaputro/dating-apps/internal/store/verificationcache"
 	"gilsaputro/dating-apps/models"
 	"gilsaputro/dating-apps/pkg/hash"
+	"gilsaputro/dating-apps/pkg/mailer"
+	"gilsaputro/dating-apps/pkg/oidc"
 	"gilsaputro/dating-apps/pkg/token"
+	"gilsaputro/dating-apps/pkg/totp"
+	"log"
+	"math/big"
+	"net/url"
 	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
 )
 
 // AuthenticationServiceMethod is list method for Authentication Service
 type AuthenticationServiceMethod interface {
-	Login(LoginServiceRequest) (string, error)
+	Login(LoginServiceRequest) (LoginServiceInfo, error)
 	Register(RegisterServiceRequest) error
+	RefreshToken(RefreshTokenServiceRequest) (LoginServiceInfo, error)
+	Logout(LogoutServiceRequest) error
+	GetJWKS() token.JWKS
+	VerifyEmail(VerifyEmailServiceRequest) error
+	ResendEmailVerification(ResendEmailVerificationServiceRequest) error
+	ForgotPassword(ForgotPasswordServiceRequest) error
+	ResetPassword(ResetPasswordServiceRequest) error
+	LoginTwoFactor(LoginTwoFactorServiceRequest) (LoginServiceInfo, error)
+	StartOAuth(StartOAuthServiceRequest) (StartOAuthServiceInfo, error)
+	LoginOAuth(LoginOAuthServiceRequest) (LoginServiceInfo, error)
 }
 
 // AuthenticationService is list dependencies for Authentication service
 type AuthenticationService struct {
-	store user.UserStoreMethod
-	token token.TokenMethod
-	hash  hash.HashMethod
+	store          user.UserStoreMethod
+	token          token.TokenMethod
+	hash           hash.HashMethod
+	tokenCache     tokencache.TokenCacheStoreMethod
+	verifyCache    verificationcache.VerificationCacheStoreMethod
+	mailer         mailer.Mailer
+	verifyEmailURL string
+	passwordReset  PasswordResetConfig
+	// loginAttempt and loginProtection is used to lock the login after too many failed attempt
+	loginAttempt    loginattempt.LoginAttemptStoreMethod
+	loginProtection LoginProtectionConfig
+	twoFactor       twofactor.TwoFactorStoreMethod
+	totp            totp.TOTPMethod
+	session         session.SessionStoreMethod
+	// identity and oauthProviders is used by the social login, the key of the provider is the name in the url
+	identity       identity.IdentityStoreMethod
+	oauthProviders map[string]oidc.Provider
 }
 
 // NewAuthenticationService is func to generate AuthenticationServiceMethod interface
-func NewAuthenticationService(store user.UserStoreMethod, token token.TokenMethod, hash hash.HashMethod) AuthenticationServiceMethod {
+func NewAuthenticationService(store user.UserStoreMethod, token token.TokenMethod, hash hash.HashMethod, tokenCache tokencache.TokenCacheStoreMethod, verifyCache verificationcache.VerificationCacheStoreMethod, mailer mailer.Mailer, verifyEmailURL string, passwordReset PasswordResetConfig, loginAttempt loginattempt.LoginAttemptStoreMethod, loginProtection LoginProtectionConfig, twoFactor twofactor.TwoFactorStoreMethod, totp totp.TOTPMethod, session session.SessionStoreMethod, identity identity.IdentityStoreMethod, oauthProviders map[string]oidc.Provider) AuthenticationServiceMethod {
+	if passwordReset.CodeTTL <= 0 {
+		passwordReset.CodeTTL = defaultResetCodeTTL
+	}
+	if passwordReset.MaxAttempts <= 0 {
+		passwordReset.MaxAttempts = defaultResetCodeMaxAttempts
+	}
+	if passwordReset.MaxUsernameRequests <= 0 {
+		passwordReset.MaxUsernameRequests = defaultMaxResetUsernameRequests
+	}
+	if passwordReset.MaxIPRequests <= 0 {
+		passwordReset.MaxIPRequests = defaultMaxResetIPRequests
+	}
+	if loginProtection.MaxUsernameAttempts <= 0 {
+		loginProtection.MaxUsernameAttempts = defaultMaxUsernameAttempts
+	}
+	if loginProtection.MaxIPAttempts <= 0 {
+		loginProtection.MaxIPAttempts = defaultMaxIPAttempts
+	}
+	if loginProtection.BaseLockout <= 0 {
+		loginProtection.BaseLockout = defaultBaseLockout
+	}
+	if loginProtection.MaxLockout < loginProtection.BaseLockout {
+		loginProtection.MaxLockout = defaultMaxLockout
+	}
 	return &AuthenticationService{
-		hash:  hash,
-		token: token,
-		store: store,
+		hash:           hash,
+		token:          token,
+		store:          store,
+		tokenCache:     tokenCache,
+		verifyCache:    verifyCache,
+		mailer:         mailer,
+		verifyEmailURL: verifyEmailURL,
+		passwordReset:  passwordReset,
+
+		loginAttempt:    loginAttempt,
+		loginProtection: loginProtection,
+		twoFactor:       twoFactor,
+		totp:            totp,
+		session:         session,
+		identity:        identity,
+		oauthProviders:  oauthProviders,
 	}
 }
 
 // Login is service layer func to validate and generate token if the Authentication is exists
-func (u *AuthenticationService) Login(request LoginServiceRequest) (string, error) {
+func (u *AuthenticationService) Login(request LoginServiceRequest) (LoginServiceInfo, error) {
+	subjects := u.loginSubjects(request)
+	retryAfter, err := u.getLoginLockout(subjects)
+	if err != nil {
+		return LoginServiceInfo{}, err
+	}
+
+	if retryAfter > 0 {
+		return LoginServiceInfo{RetryAfter: retryAfter}, ErrLoginLocked
+	}
+
 	AuthenticationInfo, err := u.store.GetUserInfoByUsername(request.Username)
 	if err != nil {
-		return "", err
+		// unknown username is counted too, so the lockout does not tell whether the username exists
+		if strings.Contains(err.Error(), "not found") {
+			u.recordFailedLogin(subjects)
+		}
+		return LoginServiceInfo{}, err
 	}
 
 	if AuthenticationInfo.ID <= 0 {
-		return "", ErrUserNameNotExists
+		u.recordFailedLogin(subjects)
+		return LoginServiceInfo{}, ErrUserNameNotExists
 	}
 
 	if !u.hash.CompareValue(AuthenticationInfo.Password, request.Password) {
-		return "", ErrPasswordIsIncorrect
+		u.recordFailedLogin(subjects)
+		return LoginServiceInfo{}, ErrPasswordIsIncorrect
+	}
+
+	// the token is only issued after the two factor code is verified
+	twoFactor, err := u.twoFactor.GetTwoFactor(int(AuthenticationInfo.ID))
+	if err != nil {
+		return LoginServiceInfo{}, err
+	}
+
+	if twoFactor.IsEnabled() {
+		return u.createTwoFactorChallenge(int(AuthenticationInfo.ID))
+	}
+
+	// only the username counter is reset, a valid account should not unlock the client ip
+	err = u.loginAttempt.ResetFailedAttempt(subjects[0].key)
+	if err != nil {
+		log.Println("[AuthenticationService]-Error Reset Failed Login :", err)
+	}
+
+	return u.startSession(AuthenticationInfo, DeviceInfo{
+		Device:    request.Device,
+		ClientIP:  request.ClientIP,
+		UserAgent: request.UserAgent,
+	})
+}
+
+// LoginTwoFactor is service layer func to complete the login of the user with two factor authentication
+func (u *AuthenticationService) LoginTwoFactor(request LoginTwoFactorServiceRequest) (LoginServiceInfo, error) {
+	if len(request.ChallengeToken) < 1 {
+		return LoginServiceInfo{}, ErrInvalidChallengeToken
+	}
+
+	challenge, err := u.verifyCache.ConsumeTwoFactorChallenge(request.ChallengeToken)
+	if err != nil {
+		return LoginServiceInfo{}, err
+	}
+
+	if challenge.UserID <= 0 {
+		return LoginServiceInfo{}, ErrInvalidChallengeToken
+	}
+
+	userInfo, err := u.store.GetUserInfoByID(challenge.UserID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return LoginServiceInfo{}, ErrInvalidChallengeToken
+		}
+		return LoginServiceInfo{}, err
+	}
+
+	twoFactor, err := u.twoFactor.GetTwoFactor(challenge.UserID)
+	if err != nil {
+		return LoginServiceInfo{}, err
+	}
+
+	// the two factor is disabled after the challenge is created
+	if !twoFactor.IsEnabled() {
+		return LoginServiceInfo{}, ErrInvalidChallengeToken
+	}
+
+	isValid, err := u.verifyTwoFactorCode(twoFactor, request.Code)
+	if err != nil {
+		return LoginServiceInfo{}, err
+	}
+
+	// the wrong code is counted as failed login, so guessing the code is also limited by the login lockout
+	subjects := u.loginSubjects(LoginServiceRequest{Username: userInfo.Username})
+	if !isValid {
+		u.recordFailedLogin(subjects)
+
+		challenge.Attempts++
+		if challenge.Attempts < twoFactorMaxAttempts {
+			err = u.verifyCache.SetTwoFactorChallenge(request.ChallengeToken, challenge)
+			if err != nil {
+				log.Println("[AuthenticationService]-Error Set Two Factor Challenge :", err)
+			}
+		}
+		return LoginServiceInfo{}, ErrInvalidTwoFactorCode
+	}
+
+	err = u.loginAttempt.ResetFailedAttempt(subjects[0].key)
+	if err != nil {
+		log.Println("[AuthenticationService]-Error Reset Failed Login :", err)
+	}
+
+	return u.startSession(userInfo, DeviceInfo{
+		Device:    request.Device,
+		ClientIP:  request.ClientIP,
+		UserAgent: request.UserAgent,
+	})
+}
+
+// StartOAuth is service layer func to generate the social login url of the provider,
+// the state and nonce is stored until the provider redirect the user back to the callback
+func (u *AuthenticationService) StartOAuth(request StartOAuthServiceRequest) (StartOAuthServiceInfo, error) {
+	provider, ok := u.oauthProviders[request.Provider]
+	if !ok {
+		return StartOAuthServiceInfo{}, ErrUnknownOAuthProvider
+	}
+
+	state, err := generateVerificationToken()
+	if err != nil {
+		return StartOAuthServiceInfo{}, err
+	}
+
+	nonce, err := generateVerificationToken()
+	if err != nil {
+		return StartOAuthServiceInfo{}, err
+	}
+
+	err = u.verifyCache.SetOAuthState(state, verificationcache.OAuthState{
+		Provider:  request.Provider,
+		Nonce:     nonce,
+		ExpiredAt: time.Now().Add(oauthStateTTL),
+	})
+	if err != nil {
+		return StartOAuthServiceInfo{}, err
+	}
+
+	return StartOAuthServiceInfo{
+		AuthURL: provider.AuthCodeURL(state, nonce),
+	}, nil
+}
+
+// LoginOAuth is service layer func to login the user by the authorization code from the provider callback,
+// the user is created on the first login and the two factor authentication is still required when it is enabled
+func (u *AuthenticationService) LoginOAuth(request LoginOAuthServiceRequest) (LoginServiceInfo, error) {
+	provider, ok := u.oauthProviders[request.Provider]
+	if !ok {
+		return LoginServiceInfo{}, ErrUnknownOAuthProvider
+	}
+
+	if len(request.State) < 1 || len(request.Code) < 1 {
+		return LoginServiceInfo{}, ErrInvalidOAuthState
+	}
+
+	state, err := u.verifyCache.ConsumeOAuthState(request.State)
+	if err != nil {
+		return LoginServiceInfo{}, err
+	}
+
+	if state.Provider != request.Provider {
+		return LoginServiceInfo{}, ErrInvalidOAuthState
+	}
+
+	account, err := provider.Exchange(request.Code, state.Nonce)
+	if err != nil {
+		log.Println("[AuthenticationService]-Error Exchange OAuth Code :", err)
+		return LoginServiceInfo{}, ErrInvalidOAuthCode
+	}
+
+	userInfo, err := u.getOAuthUser(request.Provider, account)
+	if err != nil {
+		return LoginServiceInfo{}, err
+	}
+
+	twoFactor, err := u.twoFactor.GetTwoFactor(int(userInfo.ID))
+	if err != nil {
+		return LoginServiceInfo{}, err
+	}
+
+	if twoFactor.IsEnabled() {
+		return u.createTwoFactorChallenge(int(userInfo.ID))
+	}
+
+	return u.startSession(userInfo, DeviceInfo{
+		Device:    request.Device,
+		ClientIP:  request.ClientIP,
+		UserAgent: request.UserAgent,
+	})
+}
+
+// getOAuthUser is func to get the user linked to the provider account, the existing user is linked by the email
+// only when the email is verified on both side, the user with unverified email is ignored and a new user is created
+func (u *AuthenticationService) getOAuthUser(provider string, account oidc.Identity) (models.User, error) {
+	linked, err := u.identity.GetIdentity(provider, account.Subject)
+	if err != nil {
+		return models.User{}, err
 	}
 
-	return u.token.GenerateToken(token.TokenBody{
-		UserID: int(AuthenticationInfo.ID),
+	if linked.ID > 0 {
+		return u.store.GetUserInfoByID(int(linked.UserID))
+	}
+
+	if len(account.Email) > 0 {
+		userInfo, err := u.store.GetUserInfoByEmail(account.Email)
+		if err != nil && !strings.Contains(err.Error(), "not found") {
+			return models.User{}, err
+		}
+
+		// the unverified email can be registered by anyone, so it can not be used to take over
+		// or to block the signup of the email owner
+		if userInfo.ID > 0 && userInfo.IsEmailVerified {
+			if !account.EmailVerified {
+				return models.User{}, ErrOAuthAccountExists
+			}
+
+			err = u.linkIdentity(userInfo, provider, account)
+			if err != nil {
+				return models.User{}, err
+			}
+			return userInfo, nil
+		}
+	}
+
+	userInfo, err := u.createOAuthUser(provider, account)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	err = u.linkIdentity(userInfo, provider, account)
+	if err != nil {
+		return models.User{}, err
+	}
+	return userInfo, nil
+}
+
+// linkIdentity is func to store the provider account of the user, so the next login does not depend on the email
+func (u *AuthenticationService) linkIdentity(userInfo models.User, provider string, account oidc.Identity) error {
+	return u.identity.CreateIdentity(models.UserIdentity{
+		UserID:   userInfo.ID,
+		Provider: provider,
+		Subject:  account.Subject,
+		Email:    account.Email,
+	})
+}
+
+// createOAuthUser is func to register new user from the provider account, the password is random
+// so the user can only login by the provider until the password is reset through the verified email
+func (u *AuthenticationService) createOAuthUser(provider string, account oidc.Identity) (models.User, error) {
+	var username string
+	for i := 0; i < oauthUsernameAttempts && len(username) == 0; i++ {
+		candidate, err := generateOAuthUsername(provider, account.Email)
+		if err != nil {
+			return models.User{}, err
+		}
+
+		userInfo, err := u.store.GetUserInfoByUsername(candidate)
+		if err != nil && !strings.Contains(err.Error(), "not found") {
+			return models.User{}, err
+		}
+
+		if userInfo.ID <= 0 {
+			username = candidate
+		}
+	}
+
+	if len(username) == 0 {
+		return models.User{}, ErrUserNameAlreadyExists
+	}
+
+	password, err := generateVerificationToken()
+	if err != nil {
+		return models.User{}, err
+	}
+
+	hashPassword, err := u.hash.HashValue(password)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	fullname := account.Name
+	if len(fullname) == 0 {
+		fullname = username
+	}
+
+	err = u.store.CreateUser(models.User{
+		Username:        username,
+		Password:        string(hashPassword),
+		Fullname:        fullname,
+		Email:           account.Email,
+		IsEmailVerified: account.EmailVerified && len(account.Email) > 0,
+	})
+	if err != nil {
+		return models.User{}, err
+	}
+
+	return u.store.GetUserInfoByUsername(username)
+}
+
+// startSession is func to store new device session and issue the token for it,
+// every login is a new session and the session id is kept when the token is refreshed
+func (u *AuthenticationService) startSession(userInfo models.User, device DeviceInfo) (LoginServiceInfo, error) {
+	sessionID, err := token.GenerateSessionID()
+	if err != nil {
+		return LoginServiceInfo{}, err
+	}
+
+	err = u.session.CreateSession(models.UserSession{
+		SessionID:  sessionID,
+		UserID:     userInfo.ID,
+		Device:     device.Device,
+		IPAddress:  device.ClientIP,
+		UserAgent:  device.UserAgent,
+		LastSeenAt: time.Now(),
+	})
+	if err != nil {
+		return LoginServiceInfo{}, err
+	}
+
+	return u.generateTokenPair(userInfo, sessionID)
+}
+
+// createTwoFactorChallenge is func to store new challenge token that must be completed with the two factor code
+func (u *AuthenticationService) createTwoFactorChallenge(userID int) (LoginServiceInfo, error) {
+	challengeToken, err := generateVerificationToken()
+	if err != nil {
+		return LoginServiceInfo{}, err
+	}
+
+	err = u.verifyCache.SetTwoFactorChallenge(challengeToken, verificationcache.TwoFactorChallenge{
+		UserID:    userID,
+		ExpiredAt: time.Now().Add(twoFactorChallengeTTL),
 	})
+	if err != nil {
+		return LoginServiceInfo{}, err
+	}
+
+	return LoginServiceInfo{
+		ChallengeToken: challengeToken,
+	}, nil
+}
+
+// verifyTwoFactorCode is func to check the code from the authenticator app or the recovery code, the used recovery code is removed
+func (u *AuthenticationService) verifyTwoFactorCode(twoFactor models.UserTwoFactor, code string) (bool, error) {
+	if u.totp.ValidateCode(twoFactor.Secret, code) {
+		return true, nil
+	}
+
+	// the recovery code is only checked when the format is match, the hash comparison is expensive
+	recoveryCode := models.NormalizeRecoveryCode(code)
+	if len(recoveryCode) != models.RecoveryCodeLength {
+		return false, nil
+	}
+
+	hashedCodes := twoFactor.GetRecoveryCodes()
+	for i, hashedCode := range hashedCodes {
+		if !u.hash.CompareValue(hashedCode, recoveryCode) {
+			continue
+		}
+
+		twoFactor.SetRecoveryCodes(append(hashedCodes[:i:i], hashedCodes[i+1:]...))
+		err := u.twoFactor.SaveTwoFactor(twoFactor)
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// loginSubject is the key of failed login counter and its threshold before the login is locked
+type loginSubject struct {
+	key         string
+	maxAttempts int
+}
+
+// loginSubjects is func to get the failed login counter of the request, the username counter is always the first
+func (u *AuthenticationService) loginSubjects(request LoginServiceRequest) []loginSubject {
+	subjects := []loginSubject{
+		{key: "user:" + strings.ToLower(request.Username), maxAttempts: u.loginProtection.MaxUsernameAttempts},
+	}
+	if len(request.ClientIP) > 0 {
+		subjects = append(subjects, loginSubject{key: "ip:" + request.ClientIP, maxAttempts: u.loginProtection.MaxIPAttempts})
+	}
+	return subjects
+}
+
+// getLoginLockout is func to get the longest remaining lockout of the subjects, it is zero when no subject is locked
+func (u *AuthenticationService) getLoginLockout(subjects []loginSubject) (time.Duration, error) {
+	var retryAfter time.Duration
+	for _, subject := range subjects {
+		until, err := u.loginAttempt.GetLockout(subject.key)
+		if err != nil {
+			return 0, err
+		}
+
+		if remaining := time.Until(until); remaining > retryAfter {
+			retryAfter = remaining
+		}
+	}
+	return retryAfter, nil
+}
+
+// recordFailedLogin is func to count the failed login and lock the subject that reach the threshold,
+// the error is only logged so the caller still get the invalid credential error
+func (u *AuthenticationService) recordFailedLogin(subjects []loginSubject) {
+	for _, subject := range subjects {
+		counter, err := u.loginAttempt.AddFailedAttempt(subject.key)
+		if err != nil {
+			log.Println("[AuthenticationService]-Error Add Failed Login :", err)
+			continue
+		}
+
+		duration := u.lockoutDuration(counter, subject.maxAttempts)
+		if duration <= 0 {
+			continue
+		}
+
+		err = u.loginAttempt.SetLockout(subject.key, time.Now().Add(duration))
+		if err != nil {
+			log.Println("[AuthenticationService]-Error Set Login Lockout :", err)
+		}
+	}
+}
+
+// lockoutDuration is func to calculate the exponential lockout, it is doubled for every failed attempt after the threshold
+func (u *AuthenticationService) lockoutDuration(counter, maxAttempts int) time.Duration {
+	if counter < maxAttempts {
+		return 0
+	}
+
+	duration := u.loginProtection.BaseLockout
+	for i := maxAttempts; i < counter && duration < u.loginProtection.MaxLockout; i++ {
+		duration *= 2
+	}
+
+	if duration > u.loginProtection.MaxLockout {
+		duration = u.loginProtection.MaxLockout
+	}
+	return duration
+}
+
+// RefreshToken is service layer func to rotate the refresh token, the old refresh token can not be used anymore
+func (u *AuthenticationService) RefreshToken(request RefreshTokenServiceRequest) (LoginServiceInfo, error) {
+	body, err := u.token.ValidateRefreshToken(request.RefreshToken)
+	if err != nil {
+		return LoginServiceInfo{}, ErrInvalidRefreshToken
+	}
+
+	// the session is revoked after the refresh token is issued, e.g. the password is reset
+	revokedAt, err := u.tokenCache.GetSessionsRevokedAt(body.UserID)
+	if err != nil {
+		return LoginServiceInfo{}, err
+	}
+
+	if body.IssuedAt.Unix() < revokedAt.Unix() {
+		return LoginServiceInfo{}, ErrInvalidRefreshToken
+	}
+
+	// the session is revoked from other device, the token issued before the session is stored has no session id
+	var sessionInfo models.UserSession
+	if len(body.SessionID) > 0 {
+		sessionInfo, err = u.session.GetSession(body.SessionID)
+		if err != nil {
+			return LoginServiceInfo{}, err
+		}
+
+		if sessionInfo.IsRevoked() {
+			return LoginServiceInfo{}, ErrInvalidRefreshToken
+		}
+	}
+
+	// revoke the old refresh token first, so the same refresh token only can be rotated once
+	isRevoked, err := u.tokenCache.RevokeToken(body.TokenID, body.ExpiredAt)
+	if err != nil {
+		return LoginServiceInfo{}, err
+	}
+
+	if !isRevoked {
+		return LoginServiceInfo{}, ErrInvalidRefreshToken
+	}
+
+	userInfo, err := u.store.GetUserInfoByID(body.UserID)
+	if err != nil {
+		// the user is already deleted
+		if strings.Contains(err.Error(), "not found") {
+			return LoginServiceInfo{}, ErrInvalidRefreshToken
+		}
+		return LoginServiceInfo{}, err
+	}
+
+	if sessionInfo.ID > 0 {
+		err = u.session.UpdateLastSeen(body.SessionID, time.Now())
+		if err != nil {
+			log.Println("[AuthenticationService]-Error Update Session Last Seen :", err)
+		}
+	}
+
+	// use the latest user info, so the refreshed token carry the latest roles and verified status
+	return u.generateTokenPair(userInfo, body.SessionID)
+}
+
+// Logout is service layer func to revoke the access token and the refresh token of the user
+func (u *AuthenticationService) Logout(request LogoutServiceRequest) error {
+	accessBody, err := u.token.ValidateToken(request.AccessToken)
+	if err != nil {
+		return ErrUnauthorized
+	}
+
+	if len(request.RefreshToken) > 0 {
+		refreshBody, err := u.token.ValidateRefreshToken(request.RefreshToken)
+		if err != nil || refreshBody.UserID != accessBody.UserID {
+			return ErrInvalidRefreshToken
+		}
+
+		_, err = u.tokenCache.RevokeToken(refreshBody.TokenID, refreshBody.ExpiredAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = u.tokenCache.RevokeToken(accessBody.TokenID, accessBody.ExpiredAt)
+	if err != nil {
+		return err
+	}
+
+	// the session is ended, so it is not listed as active session anymore
+	if len(accessBody.SessionID) > 0 {
+		err = u.session.RevokeSession(accessBody.UserID, accessBody.SessionID, time.Now())
+		if err != nil && !gorm.IsRecordNotFoundError(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetJWKS is service layer func to get the public key set to validate the token
+func (u *AuthenticationService) GetJWKS() token.JWKS {
+	return u.token.GetJWKS()
+}
+
+// generateTokenPair is func to issue new access token and refresh token for the user
+func (u *AuthenticationService) generateTokenPair(userInfo models.User, sessionID string) (LoginServiceInfo, error) {
+	body := token.TokenBody{
+		UserID:          int(userInfo.ID),
+		Roles:           userInfo.Roles(),
+		Plan:            userInfo.GetPlan(),
+		IsEmailVerified: userInfo.IsEmailVerified,
+		SessionID:       sessionID,
+	}
+
+	accessToken, err := u.token.GenerateToken(body)
+	if err != nil {
+		return LoginServiceInfo{}, err
+	}
+
+	refreshToken, err := u.token.GenerateRefreshToken(body)
+	if err != nil {
+		return LoginServiceInfo{}, err
+	}
+
+	return LoginServiceInfo{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+	}, nil
 }
 
 // Register is service layer func to validate and creating Authentication to database if the Authentication is not exists
 func (u *AuthenticationService) Register(request RegisterServiceRequest) error {
+	if !models.IsValidEmail(request.Email) {
+		return ErrInvalidEmail
+	}
+
 	AuthenticationInfo, err := u.store.GetUserInfoByUsername(request.Username)
 	if err != nil && !strings.Contains(err.Error(), "not found") {
 		return err
@@ -66,10 +707,323 @@ func (u *AuthenticationService) Register(request RegisterServiceRequest) error {
 		return err
 	}
 
-	return u.store.CreateUser(models.User{
+	err = u.store.CreateUser(models.User{
 		Username: request.Username,
 		Password: string(hashPassword),
 		Fullname: request.Fullname,
 		Email:    request.Email,
 	})
+	if err != nil {
+		return err
+	}
+
+	// the user is already registered, the failure is only logged because the user can resend the verification email
+	userInfo, err := u.store.GetUserInfoByUsername(request.Username)
+	if err == nil {
+		err = u.sendEmailVerification(userInfo)
+	}
+	if err != nil {
+		log.Println("[AuthenticationService]-Error Send Email Verification :", err)
+	}
+
+	return nil
+}
+
+// VerifyEmail is service layer func to mark the email of the user as verified by one time token
+func (u *AuthenticationService) VerifyEmail(request VerifyEmailServiceRequest) error {
+	if len(request.Token) == 0 {
+		return ErrInvalidEmailToken
+	}
+
+	info, err := u.verifyCache.ConsumeEmailVerification(request.Token)
+	if err != nil {
+		return err
+	}
+
+	if info.UserID <= 0 {
+		return ErrInvalidEmailToken
+	}
+
+	userInfo, err := u.store.GetUserInfoByID(info.UserID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return ErrInvalidEmailToken
+		}
+		return err
+	}
+
+	// the token is issued for the old email when the user change the email after the token is sent
+	if userInfo.Email != info.Email {
+		return ErrInvalidEmailToken
+	}
+
+	if userInfo.IsEmailVerified {
+		return nil
+	}
+
+	// the email is checked again on update, so the email changed at the same time is not verified
+	err = u.store.UpdateEmailVerified(info.UserID, info.Email)
+	if err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return ErrInvalidEmailToken
+		}
+		return err
+	}
+
+	// the email verified claim in the issued token is outdated, force the user to refresh the token
+	err = u.tokenCache.SetClaimsChangedAt(info.UserID, time.Now())
+	if err != nil {
+		log.Println("[AuthenticationService]-Error Set Claims Changed :", err)
+	}
+
+	return nil
+}
+
+// ResendEmailVerification is service layer func to send new email verification token to the user
+func (u *AuthenticationService) ResendEmailVerification(request ResendEmailVerificationServiceRequest) error {
+	userInfo, err := u.store.GetUserInfoByID(request.UserID)
+	if err != nil {
+		return err
+	}
+
+	if userInfo.IsEmailVerified {
+		return ErrEmailAlreadyVerified
+	}
+
+	if !models.IsValidEmail(userInfo.Email) {
+		return ErrInvalidEmail
+	}
+
+	return u.sendEmailVerification(userInfo)
+}
+
+// sendEmailVerification is func to store new one time token and send the verification link to the user email
+func (u *AuthenticationService) sendEmailVerification(userInfo models.User) error {
+	verifyToken, err := generateVerificationToken()
+	if err != nil {
+		return err
+	}
+
+	err = u.verifyCache.SetEmailVerification(verifyToken, verificationcache.EmailVerification{
+		UserID: int(userInfo.ID),
+		Email:  userInfo.Email,
+	})
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s?token=%s", u.verifyEmailURL, url.QueryEscape(verifyToken))
+	return u.mailer.SendMail(userInfo.Email, verifyEmailSubject, fmt.Sprintf(verifyEmailBody, userInfo.Fullname, link))
+}
+
+// ForgotPassword is service layer func to send the reset password code to the user email
+// it does not return error when the user is not exists and the mail is sent in background,
+// so the caller can not find out whether the account exists from the response or the response time
+func (u *AuthenticationService) ForgotPassword(request ForgotPasswordServiceRequest) error {
+	// the request is counted before the user is checked, so the limit does not tell whether the username exists
+	allowed, err := u.countResetRequest(u.resetRequestSubjects(request))
+	if err != nil {
+		return err
+	}
+
+	if !allowed {
+		return ErrTooManyResetRequests
+	}
+
+	userInfo, err := u.store.GetUserInfoByUsername(request.Username)
+	if err != nil && !strings.Contains(err.Error(), "not found") {
+		return err
+	}
+
+	// the code is only sent to the verified email, so the unverified email owner can not take over the account
+	if userInfo.ID <= 0 || !userInfo.IsEmailVerified {
+		return nil
+	}
+
+	go func() {
+		err := u.sendResetPasswordCode(userInfo)
+		if err != nil {
+			log.Println("[AuthenticationService]-Error Send Reset Password Code :", err)
+		}
+	}()
+
+	return nil
+}
+
+// resetRequestSubjects is func to get the reset password request counter of the request, the username counter is always the first
+func (u *AuthenticationService) resetRequestSubjects(request ForgotPasswordServiceRequest) []loginSubject {
+	subjects := []loginSubject{
+		{key: "reset:user:" + strings.ToLower(request.Username), maxAttempts: u.passwordReset.MaxUsernameRequests},
+	}
+	if len(request.ClientIP) > 0 {
+		subjects = append(subjects, loginSubject{key: "reset:ip:" + request.ClientIP, maxAttempts: u.passwordReset.MaxIPRequests})
+	}
+	return subjects
+}
+
+// countResetRequest is func to count the reset password request of the subjects, it returns false when any subject is over the limit
+func (u *AuthenticationService) countResetRequest(subjects []loginSubject) (bool, error) {
+	allowed := true
+	for _, subject := range subjects {
+		counter, err := u.loginAttempt.AddFailedAttempt(subject.key)
+		if err != nil {
+			return false, err
+		}
+
+		if counter > subject.maxAttempts {
+			allowed = false
+		}
+	}
+	return allowed, nil
+}
+
+// sendResetPasswordCode is func to store new reset password code and send it to the user email, the old code is replaced
+// but the wrong attempts of it is kept, so requesting new code does not give more guess
+func (u *AuthenticationService) sendResetPasswordCode(userInfo models.User) error {
+	userID := int(userInfo.ID)
+	previous, err := u.verifyCache.ConsumePasswordReset(userID)
+	if err != nil {
+		return err
+	}
+
+	// the attempts is used up, no new code is sent until the previous code is expired
+	if previous.Attempts >= u.passwordReset.MaxAttempts {
+		return u.verifyCache.SetPasswordReset(userID, previous)
+	}
+
+	code, err := generateResetCode()
+	if err != nil {
+		return err
+	}
+
+	err = u.verifyCache.SetPasswordReset(userID, verificationcache.PasswordReset{
+		Code:      code,
+		Attempts:  previous.Attempts,
+		ExpiredAt: time.Now().Add(u.passwordReset.CodeTTL),
+	})
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(resetPasswordBody, userInfo.Fullname, code, int(u.passwordReset.CodeTTL.Minutes()))
+	return u.mailer.SendMail(userInfo.Email, resetPasswordSubject, body)
+}
+
+// ResetPassword is service layer func to change the password by the code sent to the email and revoke all session of the user
+func (u *AuthenticationService) ResetPassword(request ResetPasswordServiceRequest) error {
+	if len(request.Code) == 0 {
+		return ErrInvalidResetCode
+	}
+
+	userInfo, err := u.store.GetUserInfoByUsername(request.Username)
+	if err != nil && !strings.Contains(err.Error(), "not found") {
+		return err
+	}
+
+	// the same error with the wrong code, so the caller can not find out whether the account exists
+	if userInfo.ID <= 0 {
+		return ErrInvalidResetCode
+	}
+
+	userID := int(userInfo.ID)
+	reset, err := u.verifyCache.ConsumePasswordReset(userID)
+	if err != nil {
+		return err
+	}
+
+	if len(reset.Code) == 0 {
+		// the used up code is put back, so the attempts is kept until it is expired
+		if reset.Attempts > 0 {
+			u.keepPasswordReset(userID, reset)
+		}
+		return ErrInvalidResetCode
+	}
+
+	if subtle.ConstantTimeCompare([]byte(reset.Code), []byte(request.Code)) != 1 {
+		// the code is removed when the user reach max attempts, but the attempts is kept so the new code does not reset it
+		reset.Attempts++
+		if reset.Attempts >= u.passwordReset.MaxAttempts {
+			reset.Code = ""
+		}
+		u.keepPasswordReset(userID, reset)
+		return ErrInvalidResetCode
+	}
+
+	hashPassword, err := u.hash.HashValue(request.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	userInfo.Password = string(hashPassword)
+	err = u.store.UpdateUser(userInfo)
+	if err != nil {
+		return err
+	}
+
+	// the refresh token can not be used anymore and the access token must be refreshed, so all session is logged out
+	now := time.Now()
+	err = u.tokenCache.SetSessionsRevokedAt(userID, now)
+	if err != nil {
+		return err
+	}
+
+	err = u.session.RevokeAllSessions(userID, now)
+	if err != nil {
+		return err
+	}
+
+	return u.tokenCache.SetClaimsChangedAt(userID, now)
+}
+
+// keepPasswordReset is func to put back the consumed reset password code, the error is only logged
+// so the caller still get the invalid code error
+func (u *AuthenticationService) keepPasswordReset(userID int, reset verificationcache.PasswordReset) {
+	err := u.verifyCache.SetPasswordReset(userID, reset)
+	if err != nil {
+		log.Println("[AuthenticationService]-Error Set Reset Password Code :", err)
+	}
+}
+
+// generateResetCode is func to generate random 6 digit code
+func generateResetCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// generateVerificationToken is func to generate random one time token
+func generateVerificationToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// generateOAuthUsername is func to generate username from the local part of the email with random suffix,
+// the provider name is used when the provider does not share the email
+func generateOAuthUsername(provider, email string) (string, error) {
+	var sb strings.Builder
+	local := strings.ToLower(strings.SplitN(email, "@", 2)[0])
+	for _, r := range local {
+		if sb.Len() >= oauthUsernameMaxPrefix {
+			break
+		}
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '_' {
+			sb.WriteRune(r)
+		}
+	}
+
+	prefix := sb.String()
+	if len(prefix) == 0 {
+		prefix = provider
+	}
+
+	b := make([]byte, 3)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return prefix + "_" + hex.EncodeToString(b), nil
 }
@@ -2,15 +2,34 @@ package authentication
 
 import (
 	"fmt"
+	"gilsaputro/dating-apps/internal/store/identity"
+	mock_identity "gilsaputro/dating-apps/internal/store/identity/mock"
+	"gilsaputro/dating-apps/internal/store/loginattempt"
+	mock_loginattempt "gilsaputro/dating-apps/internal/store/loginattempt/mock"
+	"gilsaputro/dating-apps/internal/store/session"
+	mock_session "gilsaputro/dating-apps/internal/store/session/mock"
+	"gilsaputro/dating-apps/internal/store/tokencache"
+	mock_tokencache "gilsaputro/dating-apps/internal/store/tokencache/mock"
+	"gilsaputro/dating-apps/internal/store/twofactor"
+	mock_twofactor "gilsaputro/dating-apps/internal/store/twofactor/mock"
 	"gilsaputro/dating-apps/internal/store/user"
 	mock_user "gilsaputro/dating-apps/internal/store/user/mock"
+	"gilsaputro/dating-apps/internal/store/verificationcache"
+	mock_verificationcache "gilsaputro/dating-apps/internal/store/verificationcache/mock"
 	"gilsaputro/dating-apps/models"
 	"gilsaputro/dating-apps/pkg/hash"
 	mock_hash "gilsaputro/dating-apps/pkg/hash/mock"
+	"gilsaputro/dating-apps/pkg/mailer"
+	mock_mailer "gilsaputro/dating-apps/pkg/mailer/mock"
+	"gilsaputro/dating-apps/pkg/oidc"
+	mock_oidc "gilsaputro/dating-apps/pkg/oidc/mock"
 	"gilsaputro/dating-apps/pkg/token"
 	mock_token "gilsaputro/dating-apps/pkg/token/mock"
+	"gilsaputro/dating-apps/pkg/totp"
+	mock_totp "gilsaputro/dating-apps/pkg/totp/mock"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/jinzhu/gorm"
@@ -18,9 +37,22 @@ import (
 
 func TestNewAuthenticationService(t *testing.T) {
 	type args struct {
-		store user.UserStoreMethod
-		token token.TokenMethod
-		hash  hash.HashMethod
+		store          user.UserStoreMethod
+		token          token.TokenMethod
+		hash           hash.HashMethod
+		tokenCache     tokencache.TokenCacheStoreMethod
+		verifyCache    verificationcache.VerificationCacheStoreMethod
+		mailer         mailer.Mailer
+		verifyEmailURL string
+		passwordReset  PasswordResetConfig
+
+		loginAttempt    loginattempt.LoginAttemptStoreMethod
+		loginProtection LoginProtectionConfig
+		twoFactor       twofactor.TwoFactorStoreMethod
+		totp            totp.TOTPMethod
+		session         session.SessionStoreMethod
+		identity        identity.IdentityStoreMethod
+		oauthProviders  map[string]oidc.Provider
 	}
 	tests := []struct {
 		name string
@@ -29,21 +61,68 @@ func TestNewAuthenticationService(t *testing.T) {
 	}{
 		{
 			name: "success flow",
+			args: args{
+				store:          &user.UserStore{},
+				token:          &token.TokenConfig{},
+				hash:           &hash.HashConfig{},
+				tokenCache:     &tokencache.TokenCacheStore{},
+				verifyCache:    &verificationcache.VerificationCacheStore{},
+				mailer:         &mailer.FileMailer{},
+				verifyEmailURL: "http://localhost/v1/verify-email",
+				passwordReset:  PasswordResetConfig{CodeTTL: time.Minute, MaxAttempts: 3, MaxUsernameRequests: 2, MaxIPRequests: 10},
+
+				loginAttempt:    &loginattempt.LoginAttemptStore{},
+				loginProtection: LoginProtectionConfig{MaxUsernameAttempts: 3, MaxIPAttempts: 10, BaseLockout: time.Second, MaxLockout: time.Minute},
+				twoFactor:       &twofactor.TwoFactorStore{},
+				totp:            &totp.TOTPConfig{},
+				session:         &session.SessionStore{},
+				identity:        &identity.IdentityStore{},
+				oauthProviders:  map[string]oidc.Provider{oidc.ProviderGoogle: &oidc.OIDCProvider{}},
+			},
+			want: &AuthenticationService{
+				store:          &user.UserStore{},
+				token:          &token.TokenConfig{},
+				hash:           &hash.HashConfig{},
+				tokenCache:     &tokencache.TokenCacheStore{},
+				verifyCache:    &verificationcache.VerificationCacheStore{},
+				mailer:         &mailer.FileMailer{},
+				verifyEmailURL: "http://localhost/v1/verify-email",
+				passwordReset:  PasswordResetConfig{CodeTTL: time.Minute, MaxAttempts: 3, MaxUsernameRequests: 2, MaxIPRequests: 10},
+
+				loginAttempt:    &loginattempt.LoginAttemptStore{},
+				loginProtection: LoginProtectionConfig{MaxUsernameAttempts: 3, MaxIPAttempts: 10, BaseLockout: time.Second, MaxLockout: time.Minute},
+				twoFactor:       &twofactor.TwoFactorStore{},
+				totp:            &totp.TOTPConfig{},
+				session:         &session.SessionStore{},
+				identity:        &identity.IdentityStore{},
+				oauthProviders:  map[string]oidc.Provider{oidc.ProviderGoogle: &oidc.OIDCProvider{}},
+			},
+		},
+		{
+			name: "success default password reset flow",
 			args: args{
 				store: &user.UserStore{},
-				token: &token.TokenConfig{},
-				hash:  &hash.HashConfig{},
 			},
 			want: &AuthenticationService{
 				store: &user.UserStore{},
-				token: &token.TokenConfig{},
-				hash:  &hash.HashConfig{},
+				passwordReset: PasswordResetConfig{
+					CodeTTL:             defaultResetCodeTTL,
+					MaxAttempts:         defaultResetCodeMaxAttempts,
+					MaxUsernameRequests: defaultMaxResetUsernameRequests,
+					MaxIPRequests:       defaultMaxResetIPRequests,
+				},
+				loginProtection: LoginProtectionConfig{
+					MaxUsernameAttempts: defaultMaxUsernameAttempts,
+					MaxIPAttempts:       defaultMaxIPAttempts,
+					BaseLockout:         defaultBaseLockout,
+					MaxLockout:          defaultMaxLockout,
+				},
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewAuthenticationService(tt.args.store, tt.args.token, tt.args.hash); !reflect.DeepEqual(got, tt.want) {
+			if got := NewAuthenticationService(tt.args.store, tt.args.token, tt.args.hash, tt.args.tokenCache, tt.args.verifyCache, tt.args.mailer, tt.args.verifyEmailURL, tt.args.passwordReset, tt.args.loginAttempt, tt.args.loginProtection, tt.args.twoFactor, tt.args.totp, tt.args.session, tt.args.identity, tt.args.oauthProviders); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewAuthenticationService() = %v, want %v", got, tt.want)
 			}
 		})
@@ -55,74 +134,312 @@ func TestAuthenticationService_Login(t *testing.T) {
 	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
 	mToken := mock_token.NewMockTokenMethod(mockCtrl)
 	mHash := mock_hash.NewMockHashMethod(mockCtrl)
+	mLoginAttempt := mock_loginattempt.NewMockLoginAttemptStoreMethod(mockCtrl)
+	mTwoFactor := mock_twofactor.NewMockTwoFactorStoreMethod(mockCtrl)
+	mVerifyCache := mock_verificationcache.NewMockVerificationCacheStoreMethod(mockCtrl)
+	mSession := mock_session.NewMockSessionStoreMethod(mockCtrl)
 	defer mockCtrl.Finish()
+	enabledAt := time.Now()
 	type args struct {
 		request LoginServiceRequest
 	}
 	tests := []struct {
-		name     string
-		mockFunc func()
-		args     args
-		want     string
-		wantErr  bool
+		name          string
+		mockFunc      func()
+		args          args
+		want          LoginServiceInfo
+		wantLocked    bool
+		wantChallenge bool
+		wantErr       bool
 	}{
 		{
 			name: "success flow",
 			mockFunc: func() {
+				mLoginAttempt.EXPECT().GetLockout("user:username").Return(time.Time{}, nil)
+				mLoginAttempt.EXPECT().GetLockout("ip:127.0.0.1").Return(time.Time{}, nil)
 				uStore.EXPECT().GetUserInfoByUsername("username").Return(models.User{
 					Model: gorm.Model{
 						ID: 1,
 					},
-					Username:   "username",
-					Password:   "password",
-					Fullname:   "fullname",
-					Email:      "email",
-					IsVerified: true,
+					Username: "username",
+					Password: "password",
+					Fullname: "fullname",
+					Email:    "email",
+					Plan:     models.PlanPremium,
 				}, nil)
 
 				mHash.EXPECT().CompareValue("password", "password").Return(true)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{}, nil)
+				mLoginAttempt.EXPECT().ResetFailedAttempt("user:username").Return(nil)
+				mSession.EXPECT().CreateSession(gomock.Any()).DoAndReturn(func(session models.UserSession) error {
+					if len(session.SessionID) == 0 || session.UserID != 1 || session.Device != "iphone" || session.IPAddress != "127.0.0.1" ||
+						session.UserAgent != "agent" || time.Since(session.LastSeenAt) > time.Minute {
+						t.Errorf("AuthenticationService.Login() session = %+v", session)
+					}
+					return nil
+				})
 
-				mToken.EXPECT().GenerateToken(token.TokenBody{
+				mToken.EXPECT().GenerateToken(newSessionBody(token.TokenBody{
 					UserID: int(1),
-				}).Return("token", nil)
+					Roles:  []string{models.RoleUser},
+					Plan:   models.PlanPremium,
+				})).Return("token", nil)
+				mToken.EXPECT().GenerateRefreshToken(newSessionBody(token.TokenBody{
+					UserID: int(1),
+					Roles:  []string{models.RoleUser},
+					Plan:   models.PlanPremium,
+				})).Return("refresh_token", nil)
 			},
 			args: args{
 				request: LoginServiceRequest{
-					Username: "username",
+					Username:  "username",
+					Password:  "password",
+					ClientIP:  "127.0.0.1",
+					Device:    "iphone",
+					UserAgent: "agent",
+				},
+			},
+			want: LoginServiceInfo{
+				Token:        "token",
+				RefreshToken: "refresh_token",
+			},
+			wantErr: false,
+		},
+		{
+			name: "success with error reset failed attempt flow",
+			mockFunc: func() {
+				mLoginAttempt.EXPECT().GetLockout("user:username").Return(time.Time{}, nil)
+				uStore.EXPECT().GetUserInfoByUsername("Username").Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Password: "password",
+				}, nil)
+
+				mHash.EXPECT().CompareValue("password", "password").Return(true)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{}, nil)
+				mLoginAttempt.EXPECT().ResetFailedAttempt("user:username").Return(fmt.Errorf("some error"))
+				mSession.EXPECT().CreateSession(gomock.Any()).Return(nil)
+
+				mToken.EXPECT().GenerateToken(newSessionBody(token.TokenBody{
+					UserID: int(1),
+					Roles:  []string{models.RoleUser},
+					Plan:   models.PlanFree,
+				})).Return("token", nil)
+				mToken.EXPECT().GenerateRefreshToken(newSessionBody(token.TokenBody{
+					UserID: int(1),
+					Roles:  []string{models.RoleUser},
+					Plan:   models.PlanFree,
+				})).Return("refresh_token", nil)
+			},
+			args: args{
+				request: LoginServiceRequest{
+					Username: "Username",
 					Password: "password",
 				},
 			},
-			want:    "token",
+			want: LoginServiceInfo{
+				Token:        "token",
+				RefreshToken: "refresh_token",
+			},
 			wantErr: false,
 		},
+		{
+			name: "success two factor challenge flow",
+			mockFunc: func() {
+				mLoginAttempt.EXPECT().GetLockout("user:username").Return(time.Time{}, nil)
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Password: "password",
+				}, nil)
+
+				mHash.EXPECT().CompareValue("password", "password").Return(true)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{EnabledAt: &enabledAt}, nil)
+				mVerifyCache.EXPECT().SetTwoFactorChallenge(gomock.Any(), gomock.Any()).DoAndReturn(func(challengeToken string, info verificationcache.TwoFactorChallenge) error {
+					if len(challengeToken) != 64 || info.UserID != 1 || info.Attempts != 0 || time.Until(info.ExpiredAt) <= 4*time.Minute {
+						t.Errorf("AuthenticationService.Login() challenge = %v %+v", challengeToken, info)
+					}
+					return nil
+				})
+			},
+			args: args{
+				request: LoginServiceRequest{
+					Username: "username",
+					Password: "password",
+				},
+			},
+			wantChallenge: true,
+			wantErr:       false,
+		},
+		{
+			name: "error set two factor challenge flow",
+			mockFunc: func() {
+				mLoginAttempt.EXPECT().GetLockout("user:username").Return(time.Time{}, nil)
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Password: "password",
+				}, nil)
+
+				mHash.EXPECT().CompareValue("password", "password").Return(true)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{EnabledAt: &enabledAt}, nil)
+				mVerifyCache.EXPECT().SetTwoFactorChallenge(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			args: args{
+				request: LoginServiceRequest{
+					Username: "username",
+					Password: "password",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error get two factor flow",
+			mockFunc: func() {
+				mLoginAttempt.EXPECT().GetLockout("user:username").Return(time.Time{}, nil)
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Password: "password",
+				}, nil)
+
+				mHash.EXPECT().CompareValue("password", "password").Return(true)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{}, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: LoginServiceRequest{
+					Username: "username",
+					Password: "password",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error generate refresh token flow",
+			mockFunc: func() {
+				mLoginAttempt.EXPECT().GetLockout("user:username").Return(time.Time{}, nil)
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Password: "password",
+				}, nil)
+
+				mHash.EXPECT().CompareValue("password", "password").Return(true)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{}, nil)
+				mLoginAttempt.EXPECT().ResetFailedAttempt("user:username").Return(nil)
+				mSession.EXPECT().CreateSession(gomock.Any()).Return(nil)
+				mToken.EXPECT().GenerateToken(newSessionBody(token.TokenBody{
+					UserID: int(1),
+					Roles:  []string{models.RoleUser},
+					Plan:   models.PlanFree,
+				})).Return("token", nil)
+				mToken.EXPECT().GenerateRefreshToken(newSessionBody(token.TokenBody{
+					UserID: int(1),
+					Roles:  []string{models.RoleUser},
+					Plan:   models.PlanFree,
+				})).Return("", fmt.Errorf("some error"))
+			},
+			args: args{
+				request: LoginServiceRequest{
+					Username: "username",
+					Password: "password",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error create session flow",
+			mockFunc: func() {
+				mLoginAttempt.EXPECT().GetLockout("user:username").Return(time.Time{}, nil)
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Password: "password",
+				}, nil)
+
+				mHash.EXPECT().CompareValue("password", "password").Return(true)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{}, nil)
+				mLoginAttempt.EXPECT().ResetFailedAttempt("user:username").Return(nil)
+				mSession.EXPECT().CreateSession(gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			args: args{
+				request: LoginServiceRequest{
+					Username: "username",
+					Password: "password",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "error password flow",
 			mockFunc: func() {
+				mLoginAttempt.EXPECT().GetLockout("user:username").Return(time.Time{}, nil)
+				mLoginAttempt.EXPECT().GetLockout("ip:127.0.0.1").Return(time.Time{}, nil)
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Username: "username",
+					Password: "password",
+					Fullname: "fullname",
+					Email:    "email",
+					Plan:     models.PlanPremium,
+				}, nil)
+
+				mHash.EXPECT().CompareValue("password", "password").Return(false)
+				mLoginAttempt.EXPECT().AddFailedAttempt("user:username").Return(1, nil)
+				mLoginAttempt.EXPECT().AddFailedAttempt("ip:127.0.0.1").Return(1, nil)
+			},
+			args: args{
+				request: LoginServiceRequest{
+					Username: "username",
+					Password: "password",
+					ClientIP: "127.0.0.1",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error password reach threshold flow",
+			mockFunc: func() {
+				mLoginAttempt.EXPECT().GetLockout("user:username").Return(time.Time{}, nil)
+				mLoginAttempt.EXPECT().GetLockout("ip:127.0.0.1").Return(time.Time{}, nil)
 				uStore.EXPECT().GetUserInfoByUsername("username").Return(models.User{
 					Model: gorm.Model{
 						ID: 1,
 					},
-					Username:   "username",
-					Password:   "password",
-					Fullname:   "fullname",
-					Email:      "email",
-					IsVerified: true,
+					Password: "password",
 				}, nil)
 
 				mHash.EXPECT().CompareValue("password", "password").Return(false)
+				mLoginAttempt.EXPECT().AddFailedAttempt("user:username").Return(5, nil)
+				mLoginAttempt.EXPECT().SetLockout("user:username", gomock.Any()).DoAndReturn(func(subject string, until time.Time) error {
+					if remaining := time.Until(until); remaining <= 29*time.Second || remaining > 30*time.Second {
+						t.Errorf("AuthenticationService.Login() lockout = %v, want 30s", remaining)
+					}
+					return nil
+				})
+				mLoginAttempt.EXPECT().AddFailedAttempt("ip:127.0.0.1").Return(0, fmt.Errorf("some error"))
 			},
 			args: args{
 				request: LoginServiceRequest{
 					Username: "username",
 					Password: "password",
+					ClientIP: "127.0.0.1",
 				},
 			},
-			want:    "",
 			wantErr: true,
 		},
 		{
 			name: "error get info flow",
 			mockFunc: func() {
+				mLoginAttempt.EXPECT().GetLockout("user:username").Return(time.Time{}, nil)
 				uStore.EXPECT().GetUserInfoByUsername("username").Return(models.User{}, fmt.Errorf("some error"))
 			},
 			args: args{
@@ -131,33 +448,90 @@ func TestAuthenticationService_Login(t *testing.T) {
 					Password: "password",
 				},
 			},
-			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "error user not found flow",
+			mockFunc: func() {
+				mLoginAttempt.EXPECT().GetLockout("user:username").Return(time.Time{}, nil)
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(models.User{}, fmt.Errorf("record not found"))
+				mLoginAttempt.EXPECT().AddFailedAttempt("user:username").Return(1, nil)
+			},
+			args: args{
+				request: LoginServiceRequest{
+					Username: "username",
+					Password: "password",
+				},
+			},
 			wantErr: true,
 		},
 		{
 			name: "error invalid user flow",
 			mockFunc: func() {
+				mLoginAttempt.EXPECT().GetLockout("user:username").Return(time.Time{}, nil)
 				uStore.EXPECT().GetUserInfoByUsername("username").Return(models.User{}, nil)
+				mLoginAttempt.EXPECT().AddFailedAttempt("user:username").Return(1, nil)
+			},
+			args: args{
+				request: LoginServiceRequest{
+					Username: "username",
+					Password: "password",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error ip is locked flow",
+			mockFunc: func() {
+				mLoginAttempt.EXPECT().GetLockout("user:username").Return(time.Time{}, nil)
+				mLoginAttempt.EXPECT().GetLockout("ip:127.0.0.1").Return(time.Now().Add(time.Minute), nil)
+			},
+			args: args{
+				request: LoginServiceRequest{
+					Username: "username",
+					Password: "password",
+					ClientIP: "127.0.0.1",
+				},
+			},
+			wantLocked: true,
+			wantErr:    true,
+		},
+		{
+			name: "error get lockout flow",
+			mockFunc: func() {
+				mLoginAttempt.EXPECT().GetLockout("user:username").Return(time.Time{}, fmt.Errorf("some error"))
 			},
 			args: args{
 				request: LoginServiceRequest{
 					Username: "username",
 					Password: "password",
+					ClientIP: "127.0.0.1",
 				},
 			},
-			want:    "",
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := NewAuthenticationService(uStore, mToken, mHash)
+			s := NewAuthenticationService(uStore, mToken, mHash, nil, mVerifyCache, nil, "", PasswordResetConfig{}, mLoginAttempt, LoginProtectionConfig{}, mTwoFactor, nil, mSession, nil, nil)
 			tt.mockFunc()
 			got, err := s.Login(tt.args.request)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AuthenticationService.Login() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantLocked {
+				if err != ErrLoginLocked || got.RetryAfter <= 0 || got.RetryAfter > time.Minute {
+					t.Errorf("AuthenticationService.Login() = %v, error = %v, want locked", got, err)
+				}
+				return
+			}
+			if tt.wantChallenge {
+				if len(got.ChallengeToken) != 64 || len(got.Token) > 0 || len(got.RefreshToken) > 0 {
+					t.Errorf("AuthenticationService.Login() = %v, want challenge token only", got)
+				}
+				return
+			}
 			if got != tt.want {
 				t.Errorf("AuthenticationService.Login() = %v, want %v", got, tt.want)
 			}
@@ -165,11 +539,55 @@ func TestAuthenticationService_Login(t *testing.T) {
 	}
 }
 
+func TestAuthenticationService_lockoutDuration(t *testing.T) {
+	s := AuthenticationService{
+		loginProtection: LoginProtectionConfig{
+			BaseLockout: 30 * time.Second,
+			MaxLockout:  5 * time.Minute,
+		},
+	}
+	tests := []struct {
+		name    string
+		counter int
+		want    time.Duration
+	}{
+		{
+			name:    "below threshold",
+			counter: 4,
+			want:    0,
+		},
+		{
+			name:    "reach threshold",
+			counter: 5,
+			want:    30 * time.Second,
+		},
+		{
+			name:    "doubled after threshold",
+			counter: 7,
+			want:    2 * time.Minute,
+		},
+		{
+			name:    "capped by max lockout",
+			counter: 100,
+			want:    5 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.lockoutDuration(tt.counter, 5); got != tt.want {
+				t.Errorf("AuthenticationService.lockoutDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestAuthenticationService_Register(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
 	mToken := mock_token.NewMockTokenMethod(mockCtrl)
 	mHash := mock_hash.NewMockHashMethod(mockCtrl)
+	mVerifyCache := mock_verificationcache.NewMockVerificationCacheStoreMethod(mockCtrl)
+	mMailer := mock_mailer.NewMockMailer(mockCtrl)
 	defer mockCtrl.Finish()
 	type args struct {
 		request RegisterServiceRequest
@@ -178,7 +596,7 @@ func TestAuthenticationService_Register(t *testing.T) {
 		name     string
 		mockFunc func()
 		args     args
-		wantErr  bool
+		wantErr  error
 	}{
 		{
 			name: "success flow",
@@ -187,56 +605,1785 @@ func TestAuthenticationService_Register(t *testing.T) {
 
 				mHash.EXPECT().HashValue("password").Return([]byte("hash"), nil)
 				uStore.EXPECT().CreateUser(gomock.Any()).Return(nil)
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Fullname: "fullname",
+					Email:    "user@mail.com",
+				}, nil)
+				mVerifyCache.EXPECT().SetEmailVerification(gomock.Any(), verificationcache.EmailVerification{
+					UserID: 1,
+					Email:  "user@mail.com",
+				}).Return(nil)
+				mMailer.EXPECT().SendMail("user@mail.com", verifyEmailSubject, gomock.Any()).Return(nil)
 			},
 			args: args{
 				request: RegisterServiceRequest{
 					Username: "username",
 					Password: "password",
 					Fullname: "fullname",
-					Email:    "email",
+					Email:    "user@mail.com",
 				},
 			},
-			wantErr: false,
 		},
 		{
-			name: "error hash password flow",
+			name: "success with error send email flow",
 			mockFunc: func() {
 				uStore.EXPECT().GetUserInfoByUsername("username").Return(models.User{}, nil)
 
-				mHash.EXPECT().HashValue("password").Return([]byte("hash"), fmt.Errorf("some error"))
+				mHash.EXPECT().HashValue("password").Return([]byte("hash"), nil)
+				uStore.EXPECT().CreateUser(gomock.Any()).Return(nil)
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Email: "user@mail.com",
+				}, nil)
+				mVerifyCache.EXPECT().SetEmailVerification(gomock.Any(), gomock.Any()).Return(nil)
+				mMailer.EXPECT().SendMail("user@mail.com", verifyEmailSubject, gomock.Any()).Return(fmt.Errorf("some error"))
 			},
 			args: args{
 				request: RegisterServiceRequest{
 					Username: "username",
 					Password: "password",
 					Fullname: "fullname",
-					Email:    "email",
+					Email:    "user@mail.com",
 				},
 			},
-			wantErr: true,
 		},
 		{
-			name: "error check user flow",
+			name: "error create user flow",
 			mockFunc: func() {
-				uStore.EXPECT().GetUserInfoByUsername("username").Return(models.User{}, fmt.Errorf("some error"))
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(models.User{}, nil)
+
+				mHash.EXPECT().HashValue("password").Return([]byte("hash"), nil)
+				uStore.EXPECT().CreateUser(gomock.Any()).Return(fmt.Errorf("some error"))
 			},
 			args: args{
 				request: RegisterServiceRequest{
 					Username: "username",
 					Password: "password",
 					Fullname: "fullname",
-					Email:    "email",
+					Email:    "user@mail.com",
 				},
 			},
-			wantErr: true,
+			wantErr: fmt.Errorf("some error"),
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s := NewAuthenticationService(uStore, mToken, mHash)
-			tt.mockFunc()
-			if err := s.Register(tt.args.request); (err != nil) != tt.wantErr {
-				t.Errorf("AuthenticationService.Register() error = %v, wantErr %v", err, tt.wantErr)
+		{
+			name: "error hash password flow",
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(models.User{}, nil)
+
+				mHash.EXPECT().HashValue("password").Return([]byte("hash"), fmt.Errorf("some error"))
+			},
+			args: args{
+				request: RegisterServiceRequest{
+					Username: "username",
+					Password: "password",
+					Fullname: "fullname",
+					Email:    "user@mail.com",
+				},
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error check user flow",
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(models.User{}, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: RegisterServiceRequest{
+					Username: "username",
+					Password: "password",
+					Fullname: "fullname",
+					Email:    "user@mail.com",
+				},
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:     "error invalid email flow",
+			mockFunc: func() {},
+			args: args{
+				request: RegisterServiceRequest{
+					Username: "username",
+					Password: "password",
+					Fullname: "fullname",
+					Email:    "email",
+				},
+			},
+			wantErr: ErrInvalidEmail,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewAuthenticationService(uStore, mToken, mHash, nil, mVerifyCache, mMailer, "http://localhost/v1/verify-email", PasswordResetConfig{}, nil, LoginProtectionConfig{}, nil, nil, nil, nil, nil)
+			tt.mockFunc()
+			if err := s.Register(tt.args.request); !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("AuthenticationService.Register() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthenticationService_VerifyEmail(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
+	mVerifyCache := mock_verificationcache.NewMockVerificationCacheStoreMethod(mockCtrl)
+	mTokenCache := mock_tokencache.NewMockTokenCacheStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		request VerifyEmailServiceRequest
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		wantErr  error
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeEmailVerification("token").Return(verificationcache.EmailVerification{
+					UserID: 1,
+					Email:  "user@mail.com",
+				}, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Email: "user@mail.com",
+				}, nil)
+				uStore.EXPECT().UpdateEmailVerified(1, "user@mail.com").Return(nil)
+				mTokenCache.EXPECT().SetClaimsChangedAt(1, gomock.Any()).Return(nil)
+			},
+			args: args{
+				request: VerifyEmailServiceRequest{Token: "token"},
+			},
+		},
+		{
+			name: "success with error set claims changed flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeEmailVerification("token").Return(verificationcache.EmailVerification{
+					UserID: 1,
+					Email:  "user@mail.com",
+				}, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Email: "user@mail.com",
+				}, nil)
+				uStore.EXPECT().UpdateEmailVerified(1, "user@mail.com").Return(nil)
+				mTokenCache.EXPECT().SetClaimsChangedAt(1, gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			args: args{
+				request: VerifyEmailServiceRequest{Token: "token"},
+			},
+		},
+		{
+			name: "error update user flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeEmailVerification("token").Return(verificationcache.EmailVerification{
+					UserID: 1,
+					Email:  "user@mail.com",
+				}, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Email: "user@mail.com",
+				}, nil)
+				uStore.EXPECT().UpdateEmailVerified(1, "user@mail.com").Return(fmt.Errorf("some error"))
+			},
+			args: args{
+				request: VerifyEmailServiceRequest{Token: "token"},
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error email is changed on update flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeEmailVerification("token").Return(verificationcache.EmailVerification{
+					UserID: 1,
+					Email:  "user@mail.com",
+				}, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Email: "user@mail.com",
+				}, nil)
+				uStore.EXPECT().UpdateEmailVerified(1, "user@mail.com").Return(gorm.ErrRecordNotFound)
+			},
+			args: args{
+				request: VerifyEmailServiceRequest{Token: "token"},
+			},
+			wantErr: ErrInvalidEmailToken,
+		},
+		{
+			name: "success already verified flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeEmailVerification("token").Return(verificationcache.EmailVerification{
+					UserID: 1,
+					Email:  "user@mail.com",
+				}, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Email:           "user@mail.com",
+					IsEmailVerified: true,
+				}, nil)
+			},
+			args: args{
+				request: VerifyEmailServiceRequest{Token: "token"},
+			},
+		},
+		{
+			name: "error email is changed flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeEmailVerification("token").Return(verificationcache.EmailVerification{
+					UserID: 1,
+					Email:  "old@mail.com",
+				}, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Email: "user@mail.com",
+				}, nil)
+			},
+			args: args{
+				request: VerifyEmailServiceRequest{Token: "token"},
+			},
+			wantErr: ErrInvalidEmailToken,
+		},
+		{
+			name: "error user not found flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeEmailVerification("token").Return(verificationcache.EmailVerification{
+					UserID: 1,
+					Email:  "user@mail.com",
+				}, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{}, fmt.Errorf("record not found"))
+			},
+			args: args{
+				request: VerifyEmailServiceRequest{Token: "token"},
+			},
+			wantErr: ErrInvalidEmailToken,
+		},
+		{
+			name: "error get user flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeEmailVerification("token").Return(verificationcache.EmailVerification{
+					UserID: 1,
+					Email:  "user@mail.com",
+				}, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{}, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: VerifyEmailServiceRequest{Token: "token"},
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error token not exists flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeEmailVerification("token").Return(verificationcache.EmailVerification{}, nil)
+			},
+			args: args{
+				request: VerifyEmailServiceRequest{Token: "token"},
+			},
+			wantErr: ErrInvalidEmailToken,
+		},
+		{
+			name: "error consume token flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeEmailVerification("token").Return(verificationcache.EmailVerification{}, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: VerifyEmailServiceRequest{Token: "token"},
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:     "error empty token flow",
+			mockFunc: func() {},
+			args: args{
+				request: VerifyEmailServiceRequest{},
+			},
+			wantErr: ErrInvalidEmailToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewAuthenticationService(uStore, nil, nil, mTokenCache, mVerifyCache, nil, "", PasswordResetConfig{}, nil, LoginProtectionConfig{}, nil, nil, nil, nil, nil)
+			tt.mockFunc()
+			if err := s.VerifyEmail(tt.args.request); !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("AuthenticationService.VerifyEmail() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthenticationService_ResendEmailVerification(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
+	mVerifyCache := mock_verificationcache.NewMockVerificationCacheStoreMethod(mockCtrl)
+	mMailer := mock_mailer.NewMockMailer(mockCtrl)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Fullname: "fullname",
+					Email:    "user@mail.com",
+				}, nil)
+				var verifyToken string
+				mVerifyCache.EXPECT().SetEmailVerification(gomock.Any(), verificationcache.EmailVerification{
+					UserID: 1,
+					Email:  "user@mail.com",
+				}).DoAndReturn(func(token string, info verificationcache.EmailVerification) error {
+					verifyToken = token
+					return nil
+				})
+				mMailer.EXPECT().SendMail("user@mail.com", verifyEmailSubject, gomock.Any()).DoAndReturn(func(to, subject, body string) error {
+					want := fmt.Sprintf(verifyEmailBody, "fullname", "http://localhost/v1/verify-email?token="+verifyToken)
+					if len(verifyToken) != 64 || body != want {
+						t.Errorf("AuthenticationService.ResendEmailVerification() body = %v, want %v", body, want)
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name: "error set token flow",
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Email: "user@mail.com",
+				}, nil)
+				mVerifyCache.EXPECT().SetEmailVerification(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error already verified flow",
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Email:           "user@mail.com",
+					IsEmailVerified: true,
+				}, nil)
+			},
+			wantErr: ErrEmailAlreadyVerified,
+		},
+		{
+			name: "error invalid email flow",
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+				}, nil)
+			},
+			wantErr: ErrInvalidEmail,
+		},
+		{
+			name: "error get user flow",
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{}, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewAuthenticationService(uStore, nil, nil, nil, mVerifyCache, mMailer, "http://localhost/v1/verify-email", PasswordResetConfig{}, nil, LoginProtectionConfig{}, nil, nil, nil, nil, nil)
+			tt.mockFunc()
+			if err := s.ResendEmailVerification(ResendEmailVerificationServiceRequest{UserID: 1}); !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("AuthenticationService.ResendEmailVerification() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthenticationService_RefreshToken(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
+	mToken := mock_token.NewMockTokenMethod(mockCtrl)
+	mHash := mock_hash.NewMockHashMethod(mockCtrl)
+	mTokenCache := mock_tokencache.NewMockTokenCacheStoreMethod(mockCtrl)
+	mSession := mock_session.NewMockSessionStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	expiredAt := time.Now().Add(time.Hour)
+	type args struct {
+		request RefreshTokenServiceRequest
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		want     LoginServiceInfo
+		wantErr  error
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				mToken.EXPECT().ValidateRefreshToken("refresh_token").Return(token.TokenBody{
+					UserID:    1,
+					SessionID: "sid",
+					TokenID:   "jti",
+					ExpiredAt: expiredAt,
+				}, nil)
+				mTokenCache.EXPECT().GetSessionsRevokedAt(1).Return(time.Time{}, nil)
+				mSession.EXPECT().GetSession("sid").Return(models.UserSession{Model: gorm.Model{ID: 1}, SessionID: "sid"}, nil)
+				mTokenCache.EXPECT().RevokeToken("jti", expiredAt).Return(true, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Plan:            models.PlanPremium,
+					IsAdmin:         true,
+					IsEmailVerified: true,
+				}, nil)
+				body := token.TokenBody{
+					UserID:          1,
+					Roles:           []string{models.RoleUser, models.RoleAdmin},
+					Plan:            models.PlanPremium,
+					IsEmailVerified: true,
+					SessionID:       "sid",
+				}
+				mSession.EXPECT().UpdateLastSeen("sid", gomock.Any()).Return(nil)
+				mToken.EXPECT().GenerateToken(body).Return("new_token", nil)
+				mToken.EXPECT().GenerateRefreshToken(body).Return("new_refresh_token", nil)
+			},
+			args: args{
+				request: RefreshTokenServiceRequest{RefreshToken: "refresh_token"},
+			},
+			want: LoginServiceInfo{
+				Token:        "new_token",
+				RefreshToken: "new_refresh_token",
+			},
+		},
+		{
+			name: "error invalid refresh token flow",
+			mockFunc: func() {
+				mToken.EXPECT().ValidateRefreshToken("refresh_token").Return(token.TokenBody{}, fmt.Errorf("Invalid Token"))
+			},
+			args: args{
+				request: RefreshTokenServiceRequest{RefreshToken: "refresh_token"},
+			},
+			wantErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "error session is revoked flow",
+			mockFunc: func() {
+				mToken.EXPECT().ValidateRefreshToken("refresh_token").Return(token.TokenBody{
+					UserID:    1,
+					TokenID:   "jti",
+					IssuedAt:  expiredAt.Add(-2 * time.Hour),
+					ExpiredAt: expiredAt,
+				}, nil)
+				mTokenCache.EXPECT().GetSessionsRevokedAt(1).Return(expiredAt.Add(-time.Hour), nil)
+			},
+			args: args{
+				request: RefreshTokenServiceRequest{RefreshToken: "refresh_token"},
+			},
+			wantErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "error device session is revoked flow",
+			mockFunc: func() {
+				mToken.EXPECT().ValidateRefreshToken("refresh_token").Return(token.TokenBody{
+					UserID:    1,
+					SessionID: "sid",
+					TokenID:   "jti",
+					ExpiredAt: expiredAt,
+				}, nil)
+				mTokenCache.EXPECT().GetSessionsRevokedAt(1).Return(time.Time{}, nil)
+				mSession.EXPECT().GetSession("sid").Return(models.UserSession{SessionID: "sid", RevokedAt: &expiredAt}, nil)
+			},
+			args: args{
+				request: RefreshTokenServiceRequest{RefreshToken: "refresh_token"},
+			},
+			wantErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "error get device session flow",
+			mockFunc: func() {
+				mToken.EXPECT().ValidateRefreshToken("refresh_token").Return(token.TokenBody{
+					UserID:    1,
+					SessionID: "sid",
+					TokenID:   "jti",
+					ExpiredAt: expiredAt,
+				}, nil)
+				mTokenCache.EXPECT().GetSessionsRevokedAt(1).Return(time.Time{}, nil)
+				mSession.EXPECT().GetSession("sid").Return(models.UserSession{}, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: RefreshTokenServiceRequest{RefreshToken: "refresh_token"},
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error get session revoked flow",
+			mockFunc: func() {
+				mToken.EXPECT().ValidateRefreshToken("refresh_token").Return(token.TokenBody{
+					UserID:    1,
+					TokenID:   "jti",
+					ExpiredAt: expiredAt,
+				}, nil)
+				mTokenCache.EXPECT().GetSessionsRevokedAt(1).Return(time.Time{}, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: RefreshTokenServiceRequest{RefreshToken: "refresh_token"},
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error refresh token already used flow",
+			mockFunc: func() {
+				mToken.EXPECT().ValidateRefreshToken("refresh_token").Return(token.TokenBody{
+					UserID:    1,
+					TokenID:   "jti",
+					ExpiredAt: expiredAt,
+				}, nil)
+				mTokenCache.EXPECT().GetSessionsRevokedAt(1).Return(time.Time{}, nil)
+				mTokenCache.EXPECT().RevokeToken("jti", expiredAt).Return(false, nil)
+			},
+			args: args{
+				request: RefreshTokenServiceRequest{RefreshToken: "refresh_token"},
+			},
+			wantErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "error on revoke flow",
+			mockFunc: func() {
+				mToken.EXPECT().ValidateRefreshToken("refresh_token").Return(token.TokenBody{
+					UserID:    1,
+					TokenID:   "jti",
+					ExpiredAt: expiredAt,
+				}, nil)
+				mTokenCache.EXPECT().GetSessionsRevokedAt(1).Return(time.Time{}, nil)
+				mTokenCache.EXPECT().RevokeToken("jti", expiredAt).Return(false, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: RefreshTokenServiceRequest{RefreshToken: "refresh_token"},
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error user is deleted flow",
+			mockFunc: func() {
+				mToken.EXPECT().ValidateRefreshToken("refresh_token").Return(token.TokenBody{
+					UserID:    1,
+					TokenID:   "jti",
+					ExpiredAt: expiredAt,
+				}, nil)
+				mTokenCache.EXPECT().GetSessionsRevokedAt(1).Return(time.Time{}, nil)
+				mTokenCache.EXPECT().RevokeToken("jti", expiredAt).Return(true, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{}, fmt.Errorf("record not found"))
+			},
+			args: args{
+				request: RefreshTokenServiceRequest{RefreshToken: "refresh_token"},
+			},
+			wantErr: ErrInvalidRefreshToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewAuthenticationService(uStore, mToken, mHash, mTokenCache, nil, nil, "", PasswordResetConfig{}, nil, LoginProtectionConfig{}, nil, nil, mSession, nil, nil)
+			tt.mockFunc()
+			got, err := s.RefreshToken(tt.args.request)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("AuthenticationService.RefreshToken() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("AuthenticationService.RefreshToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticationService_Logout(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
+	mToken := mock_token.NewMockTokenMethod(mockCtrl)
+	mHash := mock_hash.NewMockHashMethod(mockCtrl)
+	mTokenCache := mock_tokencache.NewMockTokenCacheStoreMethod(mockCtrl)
+	mSession := mock_session.NewMockSessionStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	expiredAt := time.Now().Add(time.Hour)
+	type args struct {
+		request LogoutServiceRequest
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		wantErr  error
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				mToken.EXPECT().ValidateToken("token").Return(token.TokenBody{UserID: 1, SessionID: "sid", TokenID: "access_jti", ExpiredAt: expiredAt}, nil)
+				mToken.EXPECT().ValidateRefreshToken("refresh_token").Return(token.TokenBody{UserID: 1, SessionID: "sid", TokenID: "refresh_jti", ExpiredAt: expiredAt}, nil)
+				mTokenCache.EXPECT().RevokeToken("refresh_jti", expiredAt).Return(true, nil)
+				mTokenCache.EXPECT().RevokeToken("access_jti", expiredAt).Return(true, nil)
+				mSession.EXPECT().RevokeSession(1, "sid", gomock.Any()).Return(nil)
+			},
+			args: args{
+				request: LogoutServiceRequest{AccessToken: "token", RefreshToken: "refresh_token"},
+			},
+		},
+		{
+			name: "success without refresh token flow",
+			mockFunc: func() {
+				mToken.EXPECT().ValidateToken("token").Return(token.TokenBody{UserID: 1, TokenID: "access_jti", ExpiredAt: expiredAt}, nil)
+				mTokenCache.EXPECT().RevokeToken("access_jti", expiredAt).Return(true, nil)
+			},
+			args: args{
+				request: LogoutServiceRequest{AccessToken: "token"},
+			},
+		},
+		{
+			name: "success session already revoked flow",
+			mockFunc: func() {
+				mToken.EXPECT().ValidateToken("token").Return(token.TokenBody{UserID: 1, SessionID: "sid", TokenID: "access_jti", ExpiredAt: expiredAt}, nil)
+				mTokenCache.EXPECT().RevokeToken("access_jti", expiredAt).Return(true, nil)
+				mSession.EXPECT().RevokeSession(1, "sid", gomock.Any()).Return(gorm.ErrRecordNotFound)
+			},
+			args: args{
+				request: LogoutServiceRequest{AccessToken: "token"},
+			},
+		},
+		{
+			name: "error revoke device session flow",
+			mockFunc: func() {
+				mToken.EXPECT().ValidateToken("token").Return(token.TokenBody{UserID: 1, SessionID: "sid", TokenID: "access_jti", ExpiredAt: expiredAt}, nil)
+				mTokenCache.EXPECT().RevokeToken("access_jti", expiredAt).Return(true, nil)
+				mSession.EXPECT().RevokeSession(1, "sid", gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			args: args{
+				request: LogoutServiceRequest{AccessToken: "token"},
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error invalid access token flow",
+			mockFunc: func() {
+				mToken.EXPECT().ValidateToken("token").Return(token.TokenBody{}, fmt.Errorf("Invalid Token"))
+			},
+			args: args{
+				request: LogoutServiceRequest{AccessToken: "token"},
+			},
+			wantErr: ErrUnauthorized,
+		},
+		{
+			name: "error refresh token of other user flow",
+			mockFunc: func() {
+				mToken.EXPECT().ValidateToken("token").Return(token.TokenBody{UserID: 1, TokenID: "access_jti", ExpiredAt: expiredAt}, nil)
+				mToken.EXPECT().ValidateRefreshToken("refresh_token").Return(token.TokenBody{UserID: 2, TokenID: "refresh_jti", ExpiredAt: expiredAt}, nil)
+			},
+			args: args{
+				request: LogoutServiceRequest{AccessToken: "token", RefreshToken: "refresh_token"},
+			},
+			wantErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "error on revoke flow",
+			mockFunc: func() {
+				mToken.EXPECT().ValidateToken("token").Return(token.TokenBody{UserID: 1, TokenID: "access_jti", ExpiredAt: expiredAt}, nil)
+				mTokenCache.EXPECT().RevokeToken("access_jti", expiredAt).Return(false, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: LogoutServiceRequest{AccessToken: "token"},
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewAuthenticationService(uStore, mToken, mHash, mTokenCache, nil, nil, "", PasswordResetConfig{}, nil, LoginProtectionConfig{}, nil, nil, mSession, nil, nil)
+			tt.mockFunc()
+			if err := s.Logout(tt.args.request); !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("AuthenticationService.Logout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthenticationService_GetJWKS(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mToken := mock_token.NewMockTokenMethod(mockCtrl)
+	defer mockCtrl.Finish()
+
+	want := token.JWKS{Keys: []token.JWK{{KeyType: "OKP", KeyID: "key-1"}}}
+	mToken.EXPECT().GetJWKS().Return(want)
+
+	s := NewAuthenticationService(nil, mToken, nil, nil, nil, nil, "", PasswordResetConfig{}, nil, LoginProtectionConfig{}, nil, nil, nil, nil, nil)
+	if got := s.GetJWKS(); !reflect.DeepEqual(got, want) {
+		t.Errorf("AuthenticationService.GetJWKS() = %v, want %v", got, want)
+	}
+}
+
+// sessionBodyMatcher is gomock matcher for token body with random session id
+type sessionBodyMatcher struct {
+	want token.TokenBody
+}
+
+func newSessionBody(want token.TokenBody) gomock.Matcher {
+	return sessionBodyMatcher{want: want}
+}
+
+func (m sessionBodyMatcher) Matches(x interface{}) bool {
+	body, ok := x.(token.TokenBody)
+	if !ok || len(body.SessionID) == 0 {
+		return false
+	}
+	body.SessionID = ""
+	return reflect.DeepEqual(body, m.want)
+}
+
+func (m sessionBodyMatcher) String() string {
+	return fmt.Sprintf("is %v with any session id", m.want)
+}
+
+func TestAuthenticationService_ForgotPassword(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
+	mVerifyCache := mock_verificationcache.NewMockVerificationCacheStoreMethod(mockCtrl)
+	mMailer := mock_mailer.NewMockMailer(mockCtrl)
+	mLoginAttempt := mock_loginattempt.NewMockLoginAttemptStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	verifiedUser := models.User{
+		Model: gorm.Model{
+			ID: 1,
+		},
+		Fullname:        "fullname",
+		Email:           "user@mail.com",
+		IsEmailVerified: true,
+	}
+	expiredAt := time.Now().Add(10 * time.Minute)
+	request := ForgotPasswordServiceRequest{Username: "UserName", ClientIP: "192.0.2.1"}
+	tests := []struct {
+		name string
+		// mockFunc call done after the last mock of the mail sent in background
+		mockFunc func(done func())
+		// wantSend is true when the test wait the mail sent in background
+		wantSend bool
+		wantErr  error
+	}{
+		{
+			name: "success flow",
+			mockFunc: func(done func()) {
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:user:username").Return(1, nil)
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:ip:192.0.2.1").Return(1, nil)
+				uStore.EXPECT().GetUserInfoByUsername("UserName").Return(verifiedUser, nil)
+				mVerifyCache.EXPECT().ConsumePasswordReset(1).Return(verificationcache.PasswordReset{}, nil)
+				var code string
+				mVerifyCache.EXPECT().SetPasswordReset(1, gomock.Any()).DoAndReturn(func(userID int, info verificationcache.PasswordReset) error {
+					code = info.Code
+					if len(info.Code) != 6 || info.Attempts != 0 || time.Until(info.ExpiredAt) <= 14*time.Minute {
+						t.Errorf("AuthenticationService.ForgotPassword() reset = %+v", info)
+					}
+					return nil
+				})
+				mMailer.EXPECT().SendMail("user@mail.com", resetPasswordSubject, gomock.Any()).DoAndReturn(func(to, subject, body string) error {
+					defer done()
+					if want := fmt.Sprintf(resetPasswordBody, "fullname", code, 15); body != want {
+						t.Errorf("AuthenticationService.ForgotPassword() body = %v, want %v", body, want)
+					}
+					return nil
+				})
+			},
+			wantSend: true,
+		},
+		{
+			name: "success keep attempts of previous code flow",
+			mockFunc: func(done func()) {
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:user:username").Return(2, nil)
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:ip:192.0.2.1").Return(2, nil)
+				uStore.EXPECT().GetUserInfoByUsername("UserName").Return(verifiedUser, nil)
+				mVerifyCache.EXPECT().ConsumePasswordReset(1).Return(verificationcache.PasswordReset{Code: "123456", Attempts: 3, ExpiredAt: expiredAt}, nil)
+				mVerifyCache.EXPECT().SetPasswordReset(1, gomock.Any()).DoAndReturn(func(userID int, info verificationcache.PasswordReset) error {
+					if info.Code == "123456" || info.Attempts != 3 {
+						t.Errorf("AuthenticationService.ForgotPassword() reset = %+v", info)
+					}
+					return nil
+				})
+				mMailer.EXPECT().SendMail("user@mail.com", resetPasswordSubject, gomock.Any()).DoAndReturn(func(to, subject, body string) error {
+					done()
+					return nil
+				})
+			},
+			wantSend: true,
+		},
+		{
+			name: "success attempts of previous code is used up flow",
+			mockFunc: func(done func()) {
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:user:username").Return(2, nil)
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:ip:192.0.2.1").Return(2, nil)
+				uStore.EXPECT().GetUserInfoByUsername("UserName").Return(verifiedUser, nil)
+				mVerifyCache.EXPECT().ConsumePasswordReset(1).Return(verificationcache.PasswordReset{Attempts: 5, ExpiredAt: expiredAt}, nil)
+				mVerifyCache.EXPECT().SetPasswordReset(1, verificationcache.PasswordReset{Attempts: 5, ExpiredAt: expiredAt}).DoAndReturn(func(userID int, info verificationcache.PasswordReset) error {
+					done()
+					return nil
+				})
+			},
+			wantSend: true,
+		},
+		{
+			name: "success with error send mail flow",
+			mockFunc: func(done func()) {
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:user:username").Return(1, nil)
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:ip:192.0.2.1").Return(1, nil)
+				uStore.EXPECT().GetUserInfoByUsername("UserName").Return(verifiedUser, nil)
+				mVerifyCache.EXPECT().ConsumePasswordReset(1).Return(verificationcache.PasswordReset{}, nil)
+				mVerifyCache.EXPECT().SetPasswordReset(1, gomock.Any()).Return(nil)
+				mMailer.EXPECT().SendMail("user@mail.com", resetPasswordSubject, gomock.Any()).DoAndReturn(func(to, subject, body string) error {
+					done()
+					return fmt.Errorf("some error")
+				})
+			},
+			wantSend: true,
+		},
+		{
+			name: "success with error set code flow",
+			mockFunc: func(done func()) {
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:user:username").Return(1, nil)
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:ip:192.0.2.1").Return(1, nil)
+				uStore.EXPECT().GetUserInfoByUsername("UserName").Return(verifiedUser, nil)
+				mVerifyCache.EXPECT().ConsumePasswordReset(1).Return(verificationcache.PasswordReset{}, nil)
+				mVerifyCache.EXPECT().SetPasswordReset(1, gomock.Any()).DoAndReturn(func(userID int, info verificationcache.PasswordReset) error {
+					done()
+					return fmt.Errorf("some error")
+				})
+			},
+			wantSend: true,
+		},
+		{
+			name: "success with error consume previous code flow",
+			mockFunc: func(done func()) {
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:user:username").Return(1, nil)
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:ip:192.0.2.1").Return(1, nil)
+				uStore.EXPECT().GetUserInfoByUsername("UserName").Return(verifiedUser, nil)
+				mVerifyCache.EXPECT().ConsumePasswordReset(1).DoAndReturn(func(userID int) (verificationcache.PasswordReset, error) {
+					done()
+					return verificationcache.PasswordReset{}, fmt.Errorf("some error")
+				})
+			},
+			wantSend: true,
+		},
+		{
+			name: "success email not verified flow",
+			mockFunc: func(done func()) {
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:user:username").Return(1, nil)
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:ip:192.0.2.1").Return(1, nil)
+				uStore.EXPECT().GetUserInfoByUsername("UserName").Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Email: "user@mail.com",
+				}, nil)
+			},
+		},
+		{
+			name: "success user not exists flow",
+			mockFunc: func(done func()) {
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:user:username").Return(1, nil)
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:ip:192.0.2.1").Return(1, nil)
+				uStore.EXPECT().GetUserInfoByUsername("UserName").Return(models.User{}, fmt.Errorf("record not found"))
+			},
+		},
+		{
+			name: "error too many request of username flow",
+			mockFunc: func(done func()) {
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:user:username").Return(4, nil)
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:ip:192.0.2.1").Return(1, nil)
+			},
+			wantErr: ErrTooManyResetRequests,
+		},
+		{
+			name: "error too many request of client ip flow",
+			mockFunc: func(done func()) {
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:user:username").Return(1, nil)
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:ip:192.0.2.1").Return(21, nil)
+			},
+			wantErr: ErrTooManyResetRequests,
+		},
+		{
+			name: "error count request flow",
+			mockFunc: func(done func()) {
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:user:username").Return(0, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error get user flow",
+			mockFunc: func(done func()) {
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:user:username").Return(1, nil)
+				mLoginAttempt.EXPECT().AddFailedAttempt("reset:ip:192.0.2.1").Return(1, nil)
+				uStore.EXPECT().GetUserInfoByUsername("UserName").Return(models.User{}, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewAuthenticationService(uStore, nil, nil, nil, mVerifyCache, mMailer, "", PasswordResetConfig{}, mLoginAttempt, LoginProtectionConfig{}, nil, nil, nil, nil, nil)
+			sent := make(chan struct{})
+			tt.mockFunc(func() { close(sent) })
+			if err := s.ForgotPassword(request); !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("AuthenticationService.ForgotPassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantSend {
+				select {
+				case <-sent:
+				case <-time.After(time.Second):
+					t.Errorf("AuthenticationService.ForgotPassword() reset password code is not sent")
+				}
+			}
+		})
+	}
+}
+
+func TestAuthenticationService_ResetPassword(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
+	mHash := mock_hash.NewMockHashMethod(mockCtrl)
+	mTokenCache := mock_tokencache.NewMockTokenCacheStoreMethod(mockCtrl)
+	mVerifyCache := mock_verificationcache.NewMockVerificationCacheStoreMethod(mockCtrl)
+	mSession := mock_session.NewMockSessionStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	expiredAt := time.Now().Add(10 * time.Minute)
+	userInfo := models.User{
+		Model: gorm.Model{
+			ID: 1,
+		},
+		Username: "username",
+		Password: "old_hash",
+	}
+	type args struct {
+		request ResetPasswordServiceRequest
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		wantErr  error
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(userInfo, nil)
+				mVerifyCache.EXPECT().ConsumePasswordReset(1).Return(verificationcache.PasswordReset{Code: "123456", ExpiredAt: expiredAt}, nil)
+				mHash.EXPECT().HashValue("new_password").Return([]byte("new_hash"), nil)
+				uStore.EXPECT().UpdateUser(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Username: "username",
+					Password: "new_hash",
+				}).Return(nil)
+				mTokenCache.EXPECT().SetSessionsRevokedAt(1, gomock.Any()).Return(nil)
+				mSession.EXPECT().RevokeAllSessions(1, gomock.Any()).Return(nil)
+				mTokenCache.EXPECT().SetClaimsChangedAt(1, gomock.Any()).Return(nil)
+			},
+			args: args{
+				request: ResetPasswordServiceRequest{Username: "username", Code: "123456", NewPassword: "new_password"},
+			},
+		},
+		{
+			name: "error revoke device session flow",
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(userInfo, nil)
+				mVerifyCache.EXPECT().ConsumePasswordReset(1).Return(verificationcache.PasswordReset{Code: "123456", ExpiredAt: expiredAt}, nil)
+				mHash.EXPECT().HashValue("new_password").Return([]byte("new_hash"), nil)
+				uStore.EXPECT().UpdateUser(gomock.Any()).Return(nil)
+				mTokenCache.EXPECT().SetSessionsRevokedAt(1, gomock.Any()).Return(nil)
+				mSession.EXPECT().RevokeAllSessions(1, gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			args: args{
+				request: ResetPasswordServiceRequest{Username: "username", Code: "123456", NewPassword: "new_password"},
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error revoke session flow",
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(userInfo, nil)
+				mVerifyCache.EXPECT().ConsumePasswordReset(1).Return(verificationcache.PasswordReset{Code: "123456", ExpiredAt: expiredAt}, nil)
+				mHash.EXPECT().HashValue("new_password").Return([]byte("new_hash"), nil)
+				uStore.EXPECT().UpdateUser(gomock.Any()).Return(nil)
+				mTokenCache.EXPECT().SetSessionsRevokedAt(1, gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			args: args{
+				request: ResetPasswordServiceRequest{Username: "username", Code: "123456", NewPassword: "new_password"},
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error update user flow",
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(userInfo, nil)
+				mVerifyCache.EXPECT().ConsumePasswordReset(1).Return(verificationcache.PasswordReset{Code: "123456", ExpiredAt: expiredAt}, nil)
+				mHash.EXPECT().HashValue("new_password").Return([]byte("new_hash"), nil)
+				uStore.EXPECT().UpdateUser(gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			args: args{
+				request: ResetPasswordServiceRequest{Username: "username", Code: "123456", NewPassword: "new_password"},
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error hash password flow",
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(userInfo, nil)
+				mVerifyCache.EXPECT().ConsumePasswordReset(1).Return(verificationcache.PasswordReset{Code: "123456", ExpiredAt: expiredAt}, nil)
+				mHash.EXPECT().HashValue("new_password").Return(nil, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: ResetPasswordServiceRequest{Username: "username", Code: "123456", NewPassword: "new_password"},
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error wrong code flow",
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(userInfo, nil)
+				mVerifyCache.EXPECT().ConsumePasswordReset(1).Return(verificationcache.PasswordReset{Code: "123456", Attempts: 1, ExpiredAt: expiredAt}, nil)
+				mVerifyCache.EXPECT().SetPasswordReset(1, verificationcache.PasswordReset{Code: "123456", Attempts: 2, ExpiredAt: expiredAt}).Return(nil)
+			},
+			args: args{
+				request: ResetPasswordServiceRequest{Username: "username", Code: "654321", NewPassword: "new_password"},
+			},
+			wantErr: ErrInvalidResetCode,
+		},
+		{
+			name: "error wrong code reach max attempts flow",
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(userInfo, nil)
+				mVerifyCache.EXPECT().ConsumePasswordReset(1).Return(verificationcache.PasswordReset{Code: "123456", Attempts: 4, ExpiredAt: expiredAt}, nil)
+				mVerifyCache.EXPECT().SetPasswordReset(1, verificationcache.PasswordReset{Attempts: 5, ExpiredAt: expiredAt}).Return(nil)
+			},
+			args: args{
+				request: ResetPasswordServiceRequest{Username: "username", Code: "654321", NewPassword: "new_password"},
+			},
+			wantErr: ErrInvalidResetCode,
+		},
+		{
+			name: "error attempts is used up flow",
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(userInfo, nil)
+				mVerifyCache.EXPECT().ConsumePasswordReset(1).Return(verificationcache.PasswordReset{Attempts: 5, ExpiredAt: expiredAt}, nil)
+				mVerifyCache.EXPECT().SetPasswordReset(1, verificationcache.PasswordReset{Attempts: 5, ExpiredAt: expiredAt}).Return(nil)
+			},
+			args: args{
+				request: ResetPasswordServiceRequest{Username: "username", Code: "123456", NewPassword: "new_password"},
+			},
+			wantErr: ErrInvalidResetCode,
+		},
+		{
+			name: "error code not exists flow",
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(userInfo, nil)
+				mVerifyCache.EXPECT().ConsumePasswordReset(1).Return(verificationcache.PasswordReset{}, nil)
+			},
+			args: args{
+				request: ResetPasswordServiceRequest{Username: "username", Code: "123456", NewPassword: "new_password"},
+			},
+			wantErr: ErrInvalidResetCode,
+		},
+		{
+			name: "error consume code flow",
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(userInfo, nil)
+				mVerifyCache.EXPECT().ConsumePasswordReset(1).Return(verificationcache.PasswordReset{}, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: ResetPasswordServiceRequest{Username: "username", Code: "123456", NewPassword: "new_password"},
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error user not exists flow",
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(models.User{}, fmt.Errorf("record not found"))
+			},
+			args: args{
+				request: ResetPasswordServiceRequest{Username: "username", Code: "123456", NewPassword: "new_password"},
+			},
+			wantErr: ErrInvalidResetCode,
+		},
+		{
+			name: "error get user flow",
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByUsername("username").Return(models.User{}, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: ResetPasswordServiceRequest{Username: "username", Code: "123456", NewPassword: "new_password"},
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:     "error empty code flow",
+			mockFunc: func() {},
+			args: args{
+				request: ResetPasswordServiceRequest{Username: "username", NewPassword: "new_password"},
+			},
+			wantErr: ErrInvalidResetCode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewAuthenticationService(uStore, nil, mHash, mTokenCache, mVerifyCache, nil, "", PasswordResetConfig{}, nil, LoginProtectionConfig{}, nil, nil, mSession, nil, nil)
+			tt.mockFunc()
+			if err := s.ResetPassword(tt.args.request); !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("AuthenticationService.ResetPassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthenticationService_LoginTwoFactor(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
+	mToken := mock_token.NewMockTokenMethod(mockCtrl)
+	mHash := mock_hash.NewMockHashMethod(mockCtrl)
+	mVerifyCache := mock_verificationcache.NewMockVerificationCacheStoreMethod(mockCtrl)
+	mLoginAttempt := mock_loginattempt.NewMockLoginAttemptStoreMethod(mockCtrl)
+	mTwoFactor := mock_twofactor.NewMockTwoFactorStoreMethod(mockCtrl)
+	mTOTP := mock_totp.NewMockTOTPMethod(mockCtrl)
+	mSession := mock_session.NewMockSessionStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	enabledAt := time.Now()
+	expiredAt := time.Now().Add(time.Minute)
+	challenge := verificationcache.TwoFactorChallenge{UserID: 1, Attempts: 1, ExpiredAt: expiredAt}
+	userInfo := models.User{
+		Model: gorm.Model{
+			ID: 1,
+		},
+		Username: "username",
+	}
+	twoFactor := models.UserTwoFactor{
+		Model: gorm.Model{
+			ID: 2,
+		},
+		UserID:        1,
+		Secret:        "SECRET",
+		EnabledAt:     &enabledAt,
+		RecoveryCodes: "hash1,hash2",
+	}
+	type args struct {
+		request LoginTwoFactorServiceRequest
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		want     LoginServiceInfo
+		wantErr  error
+	}{
+		{
+			name: "success totp code flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeTwoFactorChallenge("challenge").Return(challenge, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(twoFactor, nil)
+				mTOTP.EXPECT().ValidateCode("SECRET", "123456").Return(true)
+				mLoginAttempt.EXPECT().ResetFailedAttempt("user:username").Return(nil)
+				mSession.EXPECT().CreateSession(gomock.Any()).DoAndReturn(func(session models.UserSession) error {
+					if session.UserID != 1 || session.Device != "iphone" || session.IPAddress != "192.0.2.1" || session.UserAgent != "agent" {
+						t.Errorf("AuthenticationService.LoginTwoFactor() session = %+v", session)
+					}
+					return nil
+				})
+				mToken.EXPECT().GenerateToken(newSessionBody(token.TokenBody{
+					UserID: 1,
+					Roles:  []string{models.RoleUser},
+					Plan:   models.PlanFree,
+				})).Return("token", nil)
+				mToken.EXPECT().GenerateRefreshToken(newSessionBody(token.TokenBody{
+					UserID: 1,
+					Roles:  []string{models.RoleUser},
+					Plan:   models.PlanFree,
+				})).Return("refresh_token", nil)
+			},
+			args: args{
+				request: LoginTwoFactorServiceRequest{ChallengeToken: "challenge", Code: "123456", Device: "iphone", ClientIP: "192.0.2.1", UserAgent: "agent"},
+			},
+			want: LoginServiceInfo{
+				Token:        "token",
+				RefreshToken: "refresh_token",
+			},
+		},
+		{
+			name: "success recovery code flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeTwoFactorChallenge("challenge").Return(challenge, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(twoFactor, nil)
+				mTOTP.EXPECT().ValidateCode("SECRET", "ABCDE-12345").Return(false)
+				mHash.EXPECT().CompareValue("hash1", "abcde12345").Return(false)
+				mHash.EXPECT().CompareValue("hash2", "abcde12345").Return(true)
+				usedTwoFactor := twoFactor
+				usedTwoFactor.RecoveryCodes = "hash1"
+				mTwoFactor.EXPECT().SaveTwoFactor(usedTwoFactor).Return(nil)
+				mLoginAttempt.EXPECT().ResetFailedAttempt("user:username").Return(fmt.Errorf("some error"))
+				mSession.EXPECT().CreateSession(gomock.Any()).Return(nil)
+				mToken.EXPECT().GenerateToken(gomock.Any()).Return("token", nil)
+				mToken.EXPECT().GenerateRefreshToken(gomock.Any()).Return("refresh_token", nil)
+			},
+			args: args{
+				request: LoginTwoFactorServiceRequest{ChallengeToken: "challenge", Code: "ABCDE-12345"},
+			},
+			want: LoginServiceInfo{
+				Token:        "token",
+				RefreshToken: "refresh_token",
+			},
+		},
+		{
+			name: "error save used recovery code flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeTwoFactorChallenge("challenge").Return(challenge, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(twoFactor, nil)
+				mTOTP.EXPECT().ValidateCode("SECRET", "abcde12345").Return(false)
+				mHash.EXPECT().CompareValue("hash1", "abcde12345").Return(true)
+				mTwoFactor.EXPECT().SaveTwoFactor(gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			args: args{
+				request: LoginTwoFactorServiceRequest{ChallengeToken: "challenge", Code: "abcde12345"},
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error wrong code flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeTwoFactorChallenge("challenge").Return(challenge, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(twoFactor, nil)
+				mTOTP.EXPECT().ValidateCode("SECRET", "654321").Return(false)
+				mLoginAttempt.EXPECT().AddFailedAttempt("user:username").Return(1, nil)
+				mVerifyCache.EXPECT().SetTwoFactorChallenge("challenge", verificationcache.TwoFactorChallenge{UserID: 1, Attempts: 2, ExpiredAt: expiredAt}).Return(nil)
+			},
+			args: args{
+				request: LoginTwoFactorServiceRequest{ChallengeToken: "challenge", Code: "654321"},
+			},
+			wantErr: ErrInvalidTwoFactorCode,
+		},
+		{
+			name: "error wrong code reach max attempts flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeTwoFactorChallenge("challenge").Return(verificationcache.TwoFactorChallenge{UserID: 1, Attempts: 4, ExpiredAt: expiredAt}, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(twoFactor, nil)
+				mTOTP.EXPECT().ValidateCode("SECRET", "wrong-recovery").Return(false)
+				mLoginAttempt.EXPECT().AddFailedAttempt("user:username").Return(1, nil)
+			},
+			args: args{
+				request: LoginTwoFactorServiceRequest{ChallengeToken: "challenge", Code: "wrong-recovery"},
+			},
+			wantErr: ErrInvalidTwoFactorCode,
+		},
+		{
+			name: "error two factor is disabled flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeTwoFactorChallenge("challenge").Return(challenge, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{}, nil)
+			},
+			args: args{
+				request: LoginTwoFactorServiceRequest{ChallengeToken: "challenge", Code: "123456"},
+			},
+			wantErr: ErrInvalidChallengeToken,
+		},
+		{
+			name: "error get two factor flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeTwoFactorChallenge("challenge").Return(challenge, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{}, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: LoginTwoFactorServiceRequest{ChallengeToken: "challenge", Code: "123456"},
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error user deleted flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeTwoFactorChallenge("challenge").Return(challenge, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{}, fmt.Errorf("record not found"))
+			},
+			args: args{
+				request: LoginTwoFactorServiceRequest{ChallengeToken: "challenge", Code: "123456"},
+			},
+			wantErr: ErrInvalidChallengeToken,
+		},
+		{
+			name: "error get user flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeTwoFactorChallenge("challenge").Return(challenge, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{}, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: LoginTwoFactorServiceRequest{ChallengeToken: "challenge", Code: "123456"},
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error challenge not exists flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeTwoFactorChallenge("challenge").Return(verificationcache.TwoFactorChallenge{}, nil)
+			},
+			args: args{
+				request: LoginTwoFactorServiceRequest{ChallengeToken: "challenge", Code: "123456"},
+			},
+			wantErr: ErrInvalidChallengeToken,
+		},
+		{
+			name: "error consume challenge flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeTwoFactorChallenge("challenge").Return(verificationcache.TwoFactorChallenge{}, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: LoginTwoFactorServiceRequest{ChallengeToken: "challenge", Code: "123456"},
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:     "error empty challenge flow",
+			mockFunc: func() {},
+			args: args{
+				request: LoginTwoFactorServiceRequest{Code: "123456"},
+			},
+			wantErr: ErrInvalidChallengeToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewAuthenticationService(uStore, mToken, mHash, nil, mVerifyCache, nil, "", PasswordResetConfig{}, mLoginAttempt, LoginProtectionConfig{}, mTwoFactor, mTOTP, mSession, nil, nil)
+			tt.mockFunc()
+			got, err := s.LoginTwoFactor(tt.args.request)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("AuthenticationService.LoginTwoFactor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("AuthenticationService.LoginTwoFactor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticationService_StartOAuth(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mVerifyCache := mock_verificationcache.NewMockVerificationCacheStoreMethod(mockCtrl)
+	mProvider := mock_oidc.NewMockProvider(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		request StartOAuthServiceRequest
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		want     StartOAuthServiceInfo
+		wantErr  error
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				var stored verificationcache.OAuthState
+				var storedState string
+				mVerifyCache.EXPECT().SetOAuthState(gomock.Any(), gomock.Any()).DoAndReturn(func(state string, info verificationcache.OAuthState) error {
+					if len(state) == 0 || len(info.Nonce) == 0 || info.Provider != oidc.ProviderGoogle || time.Until(info.ExpiredAt) <= 0 {
+						t.Errorf("AuthenticationService.StartOAuth() state = %v, info = %+v", state, info)
+					}
+					storedState, stored = state, info
+					return nil
+				})
+				mProvider.EXPECT().AuthCodeURL(gomock.Any(), gomock.Any()).DoAndReturn(func(state, nonce string) string {
+					if state != storedState || nonce != stored.Nonce {
+						t.Errorf("AuthenticationService.StartOAuth() AuthCodeURL(%v, %v), want (%v, %v)", state, nonce, storedState, stored.Nonce)
+					}
+					return "https://accounts.google.com/o/oauth2/v2/auth?state=abc"
+				})
+			},
+			args: args{
+				request: StartOAuthServiceRequest{Provider: oidc.ProviderGoogle},
+			},
+			want: StartOAuthServiceInfo{AuthURL: "https://accounts.google.com/o/oauth2/v2/auth?state=abc"},
+		},
+		{
+			name:     "unknown provider flow",
+			mockFunc: func() {},
+			args: args{
+				request: StartOAuthServiceRequest{Provider: "facebook"},
+			},
+			wantErr: ErrUnknownOAuthProvider,
+		},
+		{
+			name: "error set state flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().SetOAuthState(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			args: args{
+				request: StartOAuthServiceRequest{Provider: oidc.ProviderGoogle},
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewAuthenticationService(nil, nil, nil, nil, mVerifyCache, nil, "", PasswordResetConfig{}, nil, LoginProtectionConfig{}, nil, nil, nil, nil, map[string]oidc.Provider{oidc.ProviderGoogle: mProvider})
+			tt.mockFunc()
+			got, err := s.StartOAuth(tt.args.request)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("AuthenticationService.StartOAuth() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("AuthenticationService.StartOAuth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticationService_LoginOAuth(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
+	mToken := mock_token.NewMockTokenMethod(mockCtrl)
+	mHash := mock_hash.NewMockHashMethod(mockCtrl)
+	mVerifyCache := mock_verificationcache.NewMockVerificationCacheStoreMethod(mockCtrl)
+	mTwoFactor := mock_twofactor.NewMockTwoFactorStoreMethod(mockCtrl)
+	mSession := mock_session.NewMockSessionStoreMethod(mockCtrl)
+	mIdentity := mock_identity.NewMockIdentityStoreMethod(mockCtrl)
+	mProvider := mock_oidc.NewMockProvider(mockCtrl)
+	defer mockCtrl.Finish()
+	enabledAt := time.Now()
+	state := verificationcache.OAuthState{Provider: oidc.ProviderGoogle, Nonce: "nonce", ExpiredAt: time.Now().Add(time.Minute)}
+	account := oidc.Identity{Subject: "sub", Email: "john.doe@mail.com", EmailVerified: true, Name: "John Doe"}
+	userInfo := models.User{
+		Model: gorm.Model{
+			ID: 1,
+		},
+		Username:        "username",
+		Email:           "john.doe@mail.com",
+		IsEmailVerified: true,
+	}
+	identityInfo := models.UserIdentity{
+		UserID:   1,
+		Provider: oidc.ProviderGoogle,
+		Subject:  "sub",
+		Email:    "john.doe@mail.com",
+	}
+	request := LoginOAuthServiceRequest{Provider: oidc.ProviderGoogle, State: "state", Code: "code", Device: "pixel", ClientIP: "192.0.2.1", UserAgent: "agent"}
+	type args struct {
+		request LoginOAuthServiceRequest
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		want     LoginServiceInfo
+		wantErr  error
+		// wantChallenge is set when the random challenge token is expected instead of the token
+		wantChallenge bool
+	}{
+		{
+			name: "success linked identity flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeOAuthState("state").Return(state, nil)
+				mProvider.EXPECT().Exchange("code", "nonce").Return(account, nil)
+				mIdentity.EXPECT().GetIdentity(oidc.ProviderGoogle, "sub").Return(models.UserIdentity{Model: gorm.Model{ID: 3}, UserID: 1}, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{}, nil)
+				mSession.EXPECT().CreateSession(gomock.Any()).DoAndReturn(func(session models.UserSession) error {
+					if session.UserID != 1 || session.Device != "pixel" || session.IPAddress != "192.0.2.1" || session.UserAgent != "agent" {
+						t.Errorf("AuthenticationService.LoginOAuth() session = %+v", session)
+					}
+					return nil
+				})
+				mToken.EXPECT().GenerateToken(newSessionBody(token.TokenBody{
+					UserID:          1,
+					IsEmailVerified: true,
+					Roles:           []string{models.RoleUser},
+					Plan:            models.PlanFree,
+				})).Return("token", nil)
+				mToken.EXPECT().GenerateRefreshToken(gomock.Any()).Return("refresh_token", nil)
+			},
+			args: args{
+				request: request,
+			},
+			want: LoginServiceInfo{
+				Token:        "token",
+				RefreshToken: "refresh_token",
+			},
+		},
+		{
+			name: "success link existing user by verified email flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeOAuthState("state").Return(state, nil)
+				mProvider.EXPECT().Exchange("code", "nonce").Return(account, nil)
+				mIdentity.EXPECT().GetIdentity(oidc.ProviderGoogle, "sub").Return(models.UserIdentity{}, nil)
+				uStore.EXPECT().GetUserInfoByEmail("john.doe@mail.com").Return(userInfo, nil)
+				mIdentity.EXPECT().CreateIdentity(identityInfo).Return(nil)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{}, nil)
+				mSession.EXPECT().CreateSession(gomock.Any()).Return(nil)
+				mToken.EXPECT().GenerateToken(gomock.Any()).Return("token", nil)
+				mToken.EXPECT().GenerateRefreshToken(gomock.Any()).Return("refresh_token", nil)
+			},
+			args: args{
+				request: request,
+			},
+			want: LoginServiceInfo{
+				Token:        "token",
+				RefreshToken: "refresh_token",
+			},
+		},
+		{
+			name: "success create new user flow",
+			mockFunc: func() {
+				var username string
+				mVerifyCache.EXPECT().ConsumeOAuthState("state").Return(state, nil)
+				mProvider.EXPECT().Exchange("code", "nonce").Return(account, nil)
+				mIdentity.EXPECT().GetIdentity(oidc.ProviderGoogle, "sub").Return(models.UserIdentity{}, nil)
+				uStore.EXPECT().GetUserInfoByEmail("john.doe@mail.com").Return(models.User{}, gorm.ErrRecordNotFound)
+				uStore.EXPECT().GetUserInfoByUsername(gomock.Any()).Return(models.User{}, gorm.ErrRecordNotFound)
+				mHash.EXPECT().HashValue(gomock.Any()).Return([]byte("hashed"), nil)
+				uStore.EXPECT().CreateUser(gomock.Any()).DoAndReturn(func(user models.User) error {
+					username = user.Username
+					if len(user.Username) != len("john.doe_")+6 || user.Username[:9] != "john.doe_" || user.Password != "hashed" ||
+						user.Fullname != "John Doe" || user.Email != "john.doe@mail.com" || !user.IsEmailVerified {
+						t.Errorf("AuthenticationService.LoginOAuth() user = %+v", user)
+					}
+					return nil
+				})
+				uStore.EXPECT().GetUserInfoByUsername(gomock.Any()).DoAndReturn(func(name string) (models.User, error) {
+					if name != username {
+						t.Errorf("AuthenticationService.LoginOAuth() GetUserInfoByUsername(%v), want %v", name, username)
+					}
+					return models.User{Model: gorm.Model{ID: 5}, Username: name}, nil
+				})
+				mIdentity.EXPECT().CreateIdentity(models.UserIdentity{UserID: 5, Provider: oidc.ProviderGoogle, Subject: "sub", Email: "john.doe@mail.com"}).Return(nil)
+				mTwoFactor.EXPECT().GetTwoFactor(5).Return(models.UserTwoFactor{}, nil)
+				mSession.EXPECT().CreateSession(gomock.Any()).Return(nil)
+				mToken.EXPECT().GenerateToken(gomock.Any()).Return("token", nil)
+				mToken.EXPECT().GenerateRefreshToken(gomock.Any()).Return("refresh_token", nil)
+			},
+			args: args{
+				request: request,
+			},
+			want: LoginServiceInfo{
+				Token:        "token",
+				RefreshToken: "refresh_token",
+			},
+		},
+		{
+			name: "success two factor required flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeOAuthState("state").Return(state, nil)
+				mProvider.EXPECT().Exchange("code", "nonce").Return(account, nil)
+				mIdentity.EXPECT().GetIdentity(oidc.ProviderGoogle, "sub").Return(models.UserIdentity{Model: gorm.Model{ID: 3}, UserID: 1}, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{EnabledAt: &enabledAt}, nil)
+				mVerifyCache.EXPECT().SetTwoFactorChallenge(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			args: args{
+				request: request,
+			},
+			wantChallenge: true,
+		},
+		{
+			name: "unverified email already registered flow",
+			mockFunc: func() {
+				unverified := account
+				unverified.EmailVerified = false
+				mVerifyCache.EXPECT().ConsumeOAuthState("state").Return(state, nil)
+				mProvider.EXPECT().Exchange("code", "nonce").Return(unverified, nil)
+				mIdentity.EXPECT().GetIdentity(oidc.ProviderGoogle, "sub").Return(models.UserIdentity{}, nil)
+				uStore.EXPECT().GetUserInfoByEmail("john.doe@mail.com").Return(userInfo, nil)
+			},
+			args: args{
+				request: request,
+			},
+			wantErr: ErrOAuthAccountExists,
+		},
+		{
+			name: "success create new user when email of existing user is not verified flow",
+			mockFunc: func() {
+				unverifiedUser := userInfo
+				unverifiedUser.IsEmailVerified = false
+				mVerifyCache.EXPECT().ConsumeOAuthState("state").Return(state, nil)
+				mProvider.EXPECT().Exchange("code", "nonce").Return(account, nil)
+				mIdentity.EXPECT().GetIdentity(oidc.ProviderGoogle, "sub").Return(models.UserIdentity{}, nil)
+				uStore.EXPECT().GetUserInfoByEmail("john.doe@mail.com").Return(unverifiedUser, nil)
+				uStore.EXPECT().GetUserInfoByUsername(gomock.Any()).Return(models.User{}, gorm.ErrRecordNotFound)
+				mHash.EXPECT().HashValue(gomock.Any()).Return([]byte("hashed"), nil)
+				uStore.EXPECT().CreateUser(gomock.Any()).DoAndReturn(func(user models.User) error {
+					if user.Email != "john.doe@mail.com" || !user.IsEmailVerified {
+						t.Errorf("AuthenticationService.LoginOAuth() user = %+v", user)
+					}
+					return nil
+				})
+				uStore.EXPECT().GetUserInfoByUsername(gomock.Any()).Return(models.User{Model: gorm.Model{ID: 5}}, nil)
+				mIdentity.EXPECT().CreateIdentity(models.UserIdentity{UserID: 5, Provider: oidc.ProviderGoogle, Subject: "sub", Email: "john.doe@mail.com"}).Return(nil)
+				mTwoFactor.EXPECT().GetTwoFactor(5).Return(models.UserTwoFactor{}, nil)
+				mSession.EXPECT().CreateSession(gomock.Any()).Return(nil)
+				mToken.EXPECT().GenerateToken(gomock.Any()).Return("token", nil)
+				mToken.EXPECT().GenerateRefreshToken(gomock.Any()).Return("refresh_token", nil)
+			},
+			args: args{
+				request: request,
+			},
+			want: LoginServiceInfo{
+				Token:        "token",
+				RefreshToken: "refresh_token",
+			},
+		},
+		{
+			name:     "unknown provider flow",
+			mockFunc: func() {},
+			args: args{
+				request: LoginOAuthServiceRequest{Provider: "facebook", State: "state", Code: "code"},
+			},
+			wantErr: ErrUnknownOAuthProvider,
+		},
+		{
+			name:     "empty code flow",
+			mockFunc: func() {},
+			args: args{
+				request: LoginOAuthServiceRequest{Provider: oidc.ProviderGoogle, State: "state"},
+			},
+			wantErr: ErrInvalidOAuthState,
+		},
+		{
+			name: "expired state flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeOAuthState("state").Return(verificationcache.OAuthState{}, nil)
+			},
+			args: args{
+				request: request,
+			},
+			wantErr: ErrInvalidOAuthState,
+		},
+		{
+			name: "state of other provider flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeOAuthState("state").Return(verificationcache.OAuthState{Provider: oidc.ProviderApple, Nonce: "nonce"}, nil)
+			},
+			args: args{
+				request: request,
+			},
+			wantErr: ErrInvalidOAuthState,
+		},
+		{
+			name: "error consume state flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeOAuthState("state").Return(verificationcache.OAuthState{}, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: request,
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error exchange code flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeOAuthState("state").Return(state, nil)
+				mProvider.EXPECT().Exchange("code", "nonce").Return(oidc.Identity{}, oidc.ErrInvalidIDToken)
+			},
+			args: args{
+				request: request,
+			},
+			wantErr: ErrInvalidOAuthCode,
+		},
+		{
+			name: "error get identity flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeOAuthState("state").Return(state, nil)
+				mProvider.EXPECT().Exchange("code", "nonce").Return(account, nil)
+				mIdentity.EXPECT().GetIdentity(oidc.ProviderGoogle, "sub").Return(models.UserIdentity{}, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: request,
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error link identity flow",
+			mockFunc: func() {
+				mVerifyCache.EXPECT().ConsumeOAuthState("state").Return(state, nil)
+				mProvider.EXPECT().Exchange("code", "nonce").Return(account, nil)
+				mIdentity.EXPECT().GetIdentity(oidc.ProviderGoogle, "sub").Return(models.UserIdentity{}, nil)
+				uStore.EXPECT().GetUserInfoByEmail("john.doe@mail.com").Return(userInfo, nil)
+				mIdentity.EXPECT().CreateIdentity(identityInfo).Return(fmt.Errorf("some error"))
+			},
+			args: args{
+				request: request,
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewAuthenticationService(uStore, mToken, mHash, nil, mVerifyCache, nil, "", PasswordResetConfig{}, nil, LoginProtectionConfig{}, mTwoFactor, nil, mSession, mIdentity, map[string]oidc.Provider{oidc.ProviderGoogle: mProvider})
+			tt.mockFunc()
+			got, err := s.LoginOAuth(tt.args.request)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("AuthenticationService.LoginOAuth() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantChallenge {
+				if len(got.ChallengeToken) == 0 || len(got.Token) > 0 {
+					t.Errorf("AuthenticationService.LoginOAuth() = %v, want challenge token", got)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("AuthenticationService.LoginOAuth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_generateOAuthUsername(t *testing.T) {
+	tests := []struct {
+		name       string
+		email      string
+		wantPrefix string
+	}{
+		{name: "email flow", email: "John.Doe+test@mail.com", wantPrefix: "john.doetest_"},
+		{name: "long email flow", email: "abcdefghijklmnopqrstuvwxyz0123456789@mail.com", wantPrefix: "abcdefghijklmnopqrstuvwxyz0123_"},
+		{name: "empty email flow", email: "", wantPrefix: "apple_"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := generateOAuthUsername(oidc.ProviderApple, tt.email)
+			if err != nil {
+				t.Fatalf("generateOAuthUsername() error = %v", err)
+			}
+			if len(got) != len(tt.wantPrefix)+6 || got[:len(tt.wantPrefix)] != tt.wantPrefix {
+				t.Errorf("generateOAuthUsername() = %v, want prefix %v", got, tt.wantPrefix)
 			}
 		})
 	}
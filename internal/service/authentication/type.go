@@ -1,6 +1,9 @@
 package authentication
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 // list Service error
 var (
@@ -13,12 +16,102 @@ var (
 	ErrDataNotFound          = errors.New("data not found")
 	ErrCannotUpdateOtherUser = errors.New("cannot edit other user, please login first")
 	ErrCannotGetOtherUser    = errors.New("cannot get other user data")
+	ErrInvalidRefreshToken   = errors.New("refresh token is invalid")
+	ErrInvalidEmail          = errors.New("email is invalid")
+	ErrInvalidEmailToken     = errors.New("email verification token is invalid or expired")
+	ErrEmailAlreadyVerified  = errors.New("email is already verified")
+	ErrInvalidResetCode      = errors.New("reset code is invalid or expired")
+	ErrLoginLocked           = errors.New("too many failed login attempts, please try again later")
+	ErrTooManyResetRequests  = errors.New("too many reset password requests, please try again later")
+	ErrInvalidChallengeToken = errors.New("two factor challenge is invalid or expired")
+	ErrInvalidTwoFactorCode  = errors.New("two factor code is invalid")
+	ErrUnknownOAuthProvider  = errors.New("social login provider is not supported")
+	ErrInvalidOAuthState     = errors.New("social login state is invalid or expired")
+	ErrInvalidOAuthCode      = errors.New("social login code is invalid")
+	ErrOAuthAccountExists    = errors.New("email is already registered, please login with password")
 )
 
+// verifyEmailSubject is subject of the email verification mail
+const verifyEmailSubject = "Verify your email"
+
+// verifyEmailBody is template of the email verification mail, the argument is fullname and verification link
+const verifyEmailBody = "Hi %s,\n\nPlease verify your email by opening the link below:\n%s\n\nIf you did not register to dating apps, please ignore this email."
+
 // LoginUserServiceRequest is list parameter for login user
 type LoginServiceRequest struct {
 	Username string
 	Password string
+	// ClientIP is used to count the failed login per client, empty value is skipped
+	ClientIP  string
+	Device    string
+	UserAgent string
+}
+
+// DeviceInfo is the client that create the session, it is shown in the session list
+type DeviceInfo struct {
+	Device    string
+	ClientIP  string
+	UserAgent string
+}
+
+// LoginServiceInfo is list token issued for the user
+type LoginServiceInfo struct {
+	Token        string
+	RefreshToken string
+	// RetryAfter is the remaining lockout when the login is rejected by ErrLoginLocked
+	RetryAfter time.Duration
+	// ChallengeToken is set instead of the token when the user must submit the two factor code
+	ChallengeToken string
+}
+
+// LoginTwoFactorServiceRequest is list parameter for complete the login with two factor code,
+// the code is from the authenticator app or one of the recovery code
+type LoginTwoFactorServiceRequest struct {
+	ChallengeToken string
+	Code           string
+	Device         string
+	ClientIP       string
+	UserAgent      string
+}
+
+const (
+	// oauthStateTTL is lifetime of the social login state until the provider redirect back to the callback
+	oauthStateTTL = 10 * time.Minute
+	// oauthUsernameAttempts is number of generated username checked before the social login registration is failed
+	oauthUsernameAttempts = 3
+	// oauthUsernameMaxPrefix is max length of the email part in the generated username
+	oauthUsernameMaxPrefix = 30
+)
+
+// StartOAuthServiceRequest is list parameter for start the social login
+type StartOAuthServiceRequest struct {
+	Provider string
+}
+
+// StartOAuthServiceInfo is the provider url that must be opened by the user
+type StartOAuthServiceInfo struct {
+	AuthURL string
+}
+
+// LoginOAuthServiceRequest is list parameter for complete the social login from the provider callback
+type LoginOAuthServiceRequest struct {
+	Provider  string
+	State     string
+	Code      string
+	Device    string
+	ClientIP  string
+	UserAgent string
+}
+
+// RefreshTokenServiceRequest is list parameter for rotate the refresh token
+type RefreshTokenServiceRequest struct {
+	RefreshToken string
+}
+
+// LogoutServiceRequest is list parameter for logout user, the refresh token is optional
+type LogoutServiceRequest struct {
+	AccessToken  string
+	RefreshToken string
 }
 
 // RegisterUserServiceRequest is list parameter for register user
@@ -28,3 +121,78 @@ type RegisterServiceRequest struct {
 	Fullname string
 	Email    string
 }
+
+// resetPasswordSubject is subject of the reset password mail
+const resetPasswordSubject = "Reset your password"
+
+// resetPasswordBody is template of the reset password mail, the argument is fullname, code and expiration in minute
+const resetPasswordBody = "Hi %s,\n\nUse the code below to reset your password:\n%s\n\nThe code will expire in %d minutes. If you did not request to reset the password, please ignore this email."
+
+const (
+	// defaultResetCodeTTL is default lifetime of the reset password code
+	defaultResetCodeTTL = 15 * time.Minute
+	// defaultResetCodeMaxAttempts is default number of wrong code allowed before the code is removed
+	defaultResetCodeMaxAttempts = 5
+	// defaultMaxResetUsernameRequests is default number of reset password request per username in the attempt window
+	defaultMaxResetUsernameRequests = 3
+	// defaultMaxResetIPRequests is default number of reset password request per client ip in the attempt window
+	defaultMaxResetIPRequests = 20
+)
+
+// PasswordResetConfig is list config for reset password code
+type PasswordResetConfig struct {
+	CodeTTL     time.Duration
+	MaxAttempts int
+	// MaxUsernameRequests and MaxIPRequests is the limit of the reset password request, it is counted in the login attempt window
+	MaxUsernameRequests int
+	MaxIPRequests       int
+}
+
+const (
+	// defaultMaxUsernameAttempts is default number of failed login per username before the login is locked
+	defaultMaxUsernameAttempts = 5
+	// defaultMaxIPAttempts is default number of failed login per client ip before the login is locked
+	defaultMaxIPAttempts = 20
+	// defaultBaseLockout is default lockout for the first time the threshold is reached
+	defaultBaseLockout = 30 * time.Second
+	// defaultMaxLockout is default upper limit of the exponential lockout
+	defaultMaxLockout = 15 * time.Minute
+)
+
+const (
+	// twoFactorChallengeTTL is lifetime of the challenge token returned by the login
+	twoFactorChallengeTTL = 5 * time.Minute
+	// twoFactorMaxAttempts is number of wrong code allowed before the challenge token is removed
+	twoFactorMaxAttempts = 5
+)
+
+// LoginProtectionConfig is list config for brute force protection of the login
+type LoginProtectionConfig struct {
+	MaxUsernameAttempts int
+	MaxIPAttempts       int
+	BaseLockout         time.Duration
+	MaxLockout          time.Duration
+}
+
+// VerifyEmailServiceRequest is list parameter for verify the email
+type VerifyEmailServiceRequest struct {
+	Token string
+}
+
+// ResendEmailVerificationServiceRequest is list parameter for resend the email verification
+type ResendEmailVerificationServiceRequest struct {
+	UserID int
+}
+
+// ForgotPasswordServiceRequest is list parameter for request the reset password code
+type ForgotPasswordServiceRequest struct {
+	Username string
+	ClientIP string
+}
+
+// ResetPasswordServiceRequest is list parameter for reset the password by the code sent to the email
+type ResetPasswordServiceRequest struct {
+	Username    string
+	Code        string
+	NewPassword string
+}
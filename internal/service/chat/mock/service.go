@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/chat/service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	chat "gilsaputro/dating-apps/internal/service/chat"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockChatServiceMethod is a mock of ChatServiceMethod interface.
+type MockChatServiceMethod struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatServiceMethodMockRecorder
+}
+
+// MockChatServiceMethodMockRecorder is the mock recorder for MockChatServiceMethod.
+type MockChatServiceMethodMockRecorder struct {
+	mock *MockChatServiceMethod
+}
+
+// NewMockChatServiceMethod creates a new mock instance.
+func NewMockChatServiceMethod(ctrl *gomock.Controller) *MockChatServiceMethod {
+	mock := &MockChatServiceMethod{ctrl: ctrl}
+	mock.recorder = &MockChatServiceMethodMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatServiceMethod) EXPECT() *MockChatServiceMethodMockRecorder {
+	return m.recorder
+}
+
+// GetListMessage mocks base method.
+func (m *MockChatServiceMethod) GetListMessage(request chat.MessageListServiceRequest) (chat.MessageListServiceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListMessage", request)
+	ret0, _ := ret[0].(chat.MessageListServiceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListMessage indicates an expected call of GetListMessage.
+func (mr *MockChatServiceMethodMockRecorder) GetListMessage(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListMessage", reflect.TypeOf((*MockChatServiceMethod)(nil).GetListMessage), request)
+}
+
+// SendMessage mocks base method.
+func (m *MockChatServiceMethod) SendMessage(request chat.SendMessageServiceRequest) (chat.MessageServiceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMessage", request)
+	ret0, _ := ret[0].(chat.MessageServiceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendMessage indicates an expected call of SendMessage.
+func (mr *MockChatServiceMethodMockRecorder) SendMessage(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockChatServiceMethod)(nil).SendMessage), request)
+}
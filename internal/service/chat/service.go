@@ -0,0 +1,206 @@
+package chat
+
+import (
+	"encoding/base64"
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/realtime"
+	"gilsaputro/dating-apps/internal/store/match"
+	"gilsaputro/dating-apps/internal/store/message"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/pagination"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ChatServiceMethod is list method for Chat Service
+type ChatServiceMethod interface {
+	SendMessage(request SendMessageServiceRequest) (MessageServiceInfo, error)
+	GetListMessage(request MessageListServiceRequest) (MessageListServiceInfo, error)
+}
+
+// ChatService is list dependencies for chat service
+type ChatService struct {
+	storeMatch   match.MatchStoreMethod
+	storeMessage message.MessageStoreMethod
+	realtime     realtime.RealtimeServiceMethod
+}
+
+// NewChatService is func to generate ChatServiceMethod interface
+func NewChatService(storeMatch match.MatchStoreMethod, storeMessage message.MessageStoreMethod, realtime realtime.RealtimeServiceMethod) ChatServiceMethod {
+	return &ChatService{
+		storeMatch:   storeMatch,
+		storeMessage: storeMessage,
+		realtime:     realtime,
+	}
+}
+
+// SendMessage is func to send message to the matched partner
+func (c *ChatService) SendMessage(request SendMessageServiceRequest) (MessageServiceInfo, error) {
+	content := strings.TrimSpace(request.Content)
+	if len(content) == 0 || utf8.RuneCountInString(content) > MaxMessageLength {
+		return MessageServiceInfo{}, ErrInvalidMessageContent
+	}
+
+	err := c.checkApprovedMatch(request.UserID, request.PartnerID)
+	if err != nil {
+		return MessageServiceInfo{}, err
+	}
+
+	result, err := c.storeMessage.CreateMessage(models.Message{
+		SenderID:   uint(request.UserID),
+		ReceiverID: uint(request.PartnerID),
+		Content:    content,
+	})
+	if err != nil {
+		return MessageServiceInfo{}, err
+	}
+
+	info := mapMessageServiceInfo(result)
+	// the message is already stored, so the receiver still get it on the next fetch if the push is failed
+	err = c.realtime.PublishEvent(request.PartnerID, realtime.EventNewMessage, realtime.NewMessageEventData{
+		MessageID:   info.MessageID,
+		SenderID:    info.SenderID,
+		Content:     info.Content,
+		CreatedDate: info.CreatedDate,
+	})
+	if err != nil {
+		log.Println("[ChatService]-Error Publish Event :", err)
+	}
+
+	return info, nil
+}
+
+// GetListMessage is func to get one page of the conversation with the matched partner and mark the received message as read
+func (c *ChatService) GetListMessage(request MessageListServiceRequest) (MessageListServiceInfo, error) {
+	limit := pagination.NormalizeLimit(request.Limit, DefaultMessagePageLimit, MaxMessagePageLimit)
+
+	var cursor *message.MessageCursor
+	var err error
+	if len(request.Cursor) > 0 {
+		cursor, err = decodeMessageCursor(request.Cursor)
+		if err != nil {
+			return MessageListServiceInfo{}, err
+		}
+	}
+
+	err = c.checkApprovedMatch(request.UserID, request.PartnerID)
+	if err != nil {
+		return MessageListServiceInfo{}, err
+	}
+
+	// fetch one more message to know whether there is a next page
+	messages, err := c.storeMessage.GetMessageList(message.MessageFilter{
+		UserID:    request.UserID,
+		PartnerID: request.PartnerID,
+		Cursor:    cursor,
+		Limit:     limit + 1,
+	})
+	if err != nil {
+		return MessageListServiceInfo{}, err
+	}
+
+	result := MessageListServiceInfo{
+		Messages: []MessageServiceInfo{},
+	}
+
+	if len(messages) > limit {
+		messages = messages[:limit]
+		result.NextCursor = encodeMessageCursor(messages[limit-1])
+	}
+
+	// the message received by the user is read once it is fetched
+	var unreadIDs []int
+	for _, data := range messages {
+		if int(data.ReceiverID) == request.UserID && data.ReadAt == nil {
+			unreadIDs = append(unreadIDs, int(data.ID))
+		}
+	}
+
+	err = c.storeMessage.MarkAsRead(request.UserID, unreadIDs)
+	if err != nil {
+		return MessageListServiceInfo{}, err
+	}
+
+	readAt := time.Now()
+	for _, data := range messages {
+		if int(data.ReceiverID) == request.UserID && data.ReadAt == nil {
+			data.ReadAt = &readAt
+		}
+		result.Messages = append(result.Messages, mapMessageServiceInfo(data))
+	}
+
+	return result, nil
+}
+
+// checkApprovedMatch is func to make sure only user with approved match can exchange message
+func (c *ChatService) checkApprovedMatch(userID, partnerID int) error {
+	if partnerID <= 0 || partnerID == userID {
+		return ErrMatchNotFound
+	}
+
+	count, err := c.storeMatch.CountApprovedByUserIDAndPartnerID(userID, partnerID)
+	if err != nil {
+		return err
+	}
+
+	if count <= 0 {
+		return ErrMatchNotFound
+	}
+
+	return nil
+}
+
+// mapMessageServiceInfo is func to convert message into message service info
+func mapMessageServiceInfo(data models.Message) MessageServiceInfo {
+	info := MessageServiceInfo{
+		MessageID:   int(data.ID),
+		SenderID:    int(data.SenderID),
+		ReceiverID:  int(data.ReceiverID),
+		Content:     data.Content,
+		CreatedDate: data.CreatedAt.String(),
+	}
+
+	if data.ReadAt != nil {
+		info.IsRead = true
+		info.ReadDate = data.ReadAt.String()
+	}
+
+	return info
+}
+
+// encodeMessageCursor is func to generate opaque cursor from the message position
+func encodeMessageCursor(data models.Message) string {
+	value := fmt.Sprintf("%d:%d", data.CreatedAt.UnixNano(), data.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+// decodeMessageCursor is func to get the message position from the opaque cursor
+func decodeMessageCursor(cursor string) (*message.MessageCursor, error) {
+	value, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidMessageCursor
+	}
+
+	values := strings.Split(string(value), ":")
+	if len(values) != 2 {
+		return nil, ErrInvalidMessageCursor
+	}
+
+	createdAt, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidMessageCursor
+	}
+
+	id, err := strconv.ParseUint(values[1], 10, 64)
+	if err != nil || id == 0 {
+		return nil, ErrInvalidMessageCursor
+	}
+
+	return &message.MessageCursor{
+		CreatedAt: time.Unix(0, createdAt).UTC(),
+		ID:        uint(id),
+	}, nil
+}
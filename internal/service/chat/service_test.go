@@ -0,0 +1,360 @@
+package chat
+
+import (
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/realtime"
+	mock_realtime "gilsaputro/dating-apps/internal/service/realtime/mock"
+	"gilsaputro/dating-apps/internal/store/match"
+	mock_match "gilsaputro/dating-apps/internal/store/match/mock"
+	"gilsaputro/dating-apps/internal/store/message"
+	mock_message "gilsaputro/dating-apps/internal/store/message/mock"
+	"gilsaputro/dating-apps/models"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+)
+
+func TestNewChatService(t *testing.T) {
+	type args struct {
+		storeMatch   match.MatchStoreMethod
+		storeMessage message.MessageStoreMethod
+		realtime     realtime.RealtimeServiceMethod
+	}
+	tests := []struct {
+		name string
+		args args
+		want ChatServiceMethod
+	}{
+		{
+			name: "success",
+			args: args{
+				storeMatch:   &match.MatchStore{},
+				storeMessage: &message.MessageStore{},
+				realtime:     &realtime.RealtimeService{},
+			},
+			want: &ChatService{
+				storeMatch:   &match.MatchStore{},
+				storeMessage: &message.MessageStore{},
+				realtime:     &realtime.RealtimeService{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewChatService(tt.args.storeMatch, tt.args.storeMessage, tt.args.realtime); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewChatService() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChatService_SendMessage(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mStore := mock_match.NewMockMatchStoreMethod(mockCtrl)
+	msgStore := mock_message.NewMockMessageStoreMethod(mockCtrl)
+	rService := mock_realtime.NewMockRealtimeServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		request SendMessageServiceRequest
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     MessageServiceInfo
+		wantErr  error
+	}{
+		{
+			name: "success",
+			args: args{
+				request: SendMessageServiceRequest{UserID: 1, PartnerID: 2, Content: " hello "},
+			},
+			mockFunc: func() {
+				mStore.EXPECT().CountApprovedByUserIDAndPartnerID(1, 2).Return(1, nil)
+				msgStore.EXPECT().CreateMessage(models.Message{
+					SenderID:   1,
+					ReceiverID: 2,
+					Content:    "hello",
+				}).Return(models.Message{
+					Model:      gorm.Model{ID: 3},
+					SenderID:   1,
+					ReceiverID: 2,
+					Content:    "hello",
+				}, nil)
+				rService.EXPECT().PublishEvent(2, realtime.EventNewMessage, realtime.NewMessageEventData{
+					MessageID:   3,
+					SenderID:    1,
+					Content:     "hello",
+					CreatedDate: "0001-01-01 00:00:00 +0000 UTC",
+				}).Return(nil)
+			},
+			want: MessageServiceInfo{
+				MessageID:   3,
+				SenderID:    1,
+				ReceiverID:  2,
+				Content:     "hello",
+				CreatedDate: "0001-01-01 00:00:00 +0000 UTC",
+			},
+		},
+		{
+			name: "success when publish event failed",
+			args: args{
+				request: SendMessageServiceRequest{UserID: 1, PartnerID: 2, Content: "hello"},
+			},
+			mockFunc: func() {
+				mStore.EXPECT().CountApprovedByUserIDAndPartnerID(1, 2).Return(1, nil)
+				msgStore.EXPECT().CreateMessage(gomock.Any()).Return(models.Message{
+					Model:      gorm.Model{ID: 3},
+					SenderID:   1,
+					ReceiverID: 2,
+					Content:    "hello",
+				}, nil)
+				rService.EXPECT().PublishEvent(2, realtime.EventNewMessage, gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			want: MessageServiceInfo{
+				MessageID:   3,
+				SenderID:    1,
+				ReceiverID:  2,
+				Content:     "hello",
+				CreatedDate: "0001-01-01 00:00:00 +0000 UTC",
+			},
+		},
+		{
+			name: "error empty content",
+			args: args{
+				request: SendMessageServiceRequest{UserID: 1, PartnerID: 2, Content: "  "},
+			},
+			mockFunc: func() {},
+			wantErr:  ErrInvalidMessageContent,
+		},
+		{
+			name: "error content too long",
+			args: args{
+				request: SendMessageServiceRequest{UserID: 1, PartnerID: 2, Content: strings.Repeat("a", MaxMessageLength+1)},
+			},
+			mockFunc: func() {},
+			wantErr:  ErrInvalidMessageContent,
+		},
+		{
+			name: "error send message to self",
+			args: args{
+				request: SendMessageServiceRequest{UserID: 1, PartnerID: 1, Content: "hello"},
+			},
+			mockFunc: func() {},
+			wantErr:  ErrMatchNotFound,
+		},
+		{
+			name: "error not matched",
+			args: args{
+				request: SendMessageServiceRequest{UserID: 1, PartnerID: 2, Content: "hello"},
+			},
+			mockFunc: func() {
+				mStore.EXPECT().CountApprovedByUserIDAndPartnerID(1, 2).Return(0, nil)
+			},
+			wantErr: ErrMatchNotFound,
+		},
+		{
+			name: "error on check match",
+			args: args{
+				request: SendMessageServiceRequest{UserID: 1, PartnerID: 2, Content: "hello"},
+			},
+			mockFunc: func() {
+				mStore.EXPECT().CountApprovedByUserIDAndPartnerID(1, 2).Return(0, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error on create message",
+			args: args{
+				request: SendMessageServiceRequest{UserID: 1, PartnerID: 2, Content: "hello"},
+			},
+			mockFunc: func() {
+				mStore.EXPECT().CountApprovedByUserIDAndPartnerID(1, 2).Return(1, nil)
+				msgStore.EXPECT().CreateMessage(gomock.Any()).Return(models.Message{}, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := ChatService{
+				storeMatch:   mStore,
+				storeMessage: msgStore,
+				realtime:     rService,
+			}
+			tt.mockFunc()
+			got, err := s.SendMessage(tt.args.request)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("ChatService.SendMessage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ChatService.SendMessage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChatService_GetListMessage(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mStore := mock_match.NewMockMatchStoreMethod(mockCtrl)
+	msgStore := mock_message.NewMockMessageStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	sentAt := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+	readAt := sentAt.Add(time.Minute)
+	messages := []models.Message{
+		{Model: gorm.Model{ID: 4, CreatedAt: sentAt}, SenderID: 2, ReceiverID: 1, Content: "hi"},
+		{Model: gorm.Model{ID: 3, CreatedAt: sentAt}, SenderID: 1, ReceiverID: 2, Content: "hello", ReadAt: &readAt},
+		{Model: gorm.Model{ID: 2, CreatedAt: sentAt}, SenderID: 1, ReceiverID: 2, Content: "hey"},
+	}
+	type args struct {
+		request MessageListServiceRequest
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     MessageListServiceInfo
+		wantErr  error
+	}{
+		{
+			name: "success mark received message as read and return next cursor",
+			args: args{
+				request: MessageListServiceRequest{UserID: 1, PartnerID: 2, Limit: 2},
+			},
+			mockFunc: func() {
+				mStore.EXPECT().CountApprovedByUserIDAndPartnerID(1, 2).Return(1, nil)
+				msgStore.EXPECT().GetMessageList(message.MessageFilter{
+					UserID:    1,
+					PartnerID: 2,
+					Limit:     3,
+				}).Return(messages, nil)
+				msgStore.EXPECT().MarkAsRead(1, []int{4}).Return(nil)
+			},
+			want: MessageListServiceInfo{
+				Messages: []MessageServiceInfo{
+					{
+						MessageID:   4,
+						SenderID:    2,
+						ReceiverID:  1,
+						Content:     "hi",
+						IsRead:      true,
+						CreatedDate: sentAt.String(),
+					},
+					{
+						MessageID:   3,
+						SenderID:    1,
+						ReceiverID:  2,
+						Content:     "hello",
+						IsRead:      true,
+						ReadDate:    readAt.String(),
+						CreatedDate: sentAt.String(),
+					},
+				},
+				NextCursor: encodeMessageCursor(messages[1]),
+			},
+		},
+		{
+			name: "success with cursor on the last page",
+			args: args{
+				request: MessageListServiceRequest{UserID: 2, PartnerID: 1, Cursor: encodeMessageCursor(messages[1])},
+			},
+			mockFunc: func() {
+				mStore.EXPECT().CountApprovedByUserIDAndPartnerID(2, 1).Return(1, nil)
+				msgStore.EXPECT().GetMessageList(message.MessageFilter{
+					UserID:    2,
+					PartnerID: 1,
+					Cursor: &message.MessageCursor{
+						CreatedAt: sentAt,
+						ID:        3,
+					},
+					Limit: DefaultMessagePageLimit + 1,
+				}).Return(messages[2:], nil)
+				msgStore.EXPECT().MarkAsRead(2, []int{2}).Return(nil)
+			},
+			want: MessageListServiceInfo{
+				Messages: []MessageServiceInfo{
+					{
+						MessageID:   2,
+						SenderID:    1,
+						ReceiverID:  2,
+						Content:     "hey",
+						IsRead:      true,
+						CreatedDate: sentAt.String(),
+					},
+				},
+			},
+		},
+		{
+			name: "error invalid cursor",
+			args: args{
+				request: MessageListServiceRequest{UserID: 1, PartnerID: 2, Cursor: "abc"},
+			},
+			mockFunc: func() {},
+			wantErr:  ErrInvalidMessageCursor,
+		},
+		{
+			name: "error not matched",
+			args: args{
+				request: MessageListServiceRequest{UserID: 1, PartnerID: 2},
+			},
+			mockFunc: func() {
+				mStore.EXPECT().CountApprovedByUserIDAndPartnerID(1, 2).Return(0, nil)
+			},
+			wantErr: ErrMatchNotFound,
+		},
+		{
+			name: "error on get message list",
+			args: args{
+				request: MessageListServiceRequest{UserID: 1, PartnerID: 2},
+			},
+			mockFunc: func() {
+				mStore.EXPECT().CountApprovedByUserIDAndPartnerID(1, 2).Return(1, nil)
+				msgStore.EXPECT().GetMessageList(gomock.Any()).Return(nil, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error on mark as read",
+			args: args{
+				request: MessageListServiceRequest{UserID: 1, PartnerID: 2},
+			},
+			mockFunc: func() {
+				mStore.EXPECT().CountApprovedByUserIDAndPartnerID(1, 2).Return(1, nil)
+				msgStore.EXPECT().GetMessageList(gomock.Any()).Return(messages[:1], nil)
+				msgStore.EXPECT().MarkAsRead(1, []int{4}).Return(fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := ChatService{
+				storeMatch:   mStore,
+				storeMessage: msgStore,
+			}
+			tt.mockFunc()
+			got, err := s.GetListMessage(tt.args.request)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("ChatService.GetListMessage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			// the read date of message read on this call is the current time
+			for i, data := range got.Messages {
+				if i < len(tt.want.Messages) && len(tt.want.Messages[i].ReadDate) == 0 {
+					if data.IsRead && len(data.ReadDate) == 0 {
+						t.Errorf("ChatService.GetListMessage() read message %d has no read date", data.MessageID)
+					}
+					got.Messages[i].ReadDate = ""
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ChatService.GetListMessage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package chat
+
+import "errors"
+
+// list Service error
+var (
+	ErrMatchNotFound         = errors.New("the user does not have match with the partner")
+	ErrInvalidMessageContent = errors.New("message content should not be empty and at most 1000 characters")
+	ErrInvalidMessageCursor  = errors.New("the message cursor is invalid")
+)
+
+const (
+	// MaxMessageLength is max number of character in one message
+	MaxMessageLength = 1000
+	// DefaultMessagePageLimit is default number of message returned for each page
+	DefaultMessagePageLimit = 20
+	// MaxMessagePageLimit is max number of message returned for each page
+	MaxMessagePageLimit = 100
+)
+
+// SendMessageServiceRequest is list parameter for send message
+type SendMessageServiceRequest struct {
+	UserID    int
+	PartnerID int
+	Content   string
+}
+
+// MessageListServiceRequest is list parameter for get the conversation with the partner
+type MessageListServiceRequest struct {
+	UserID    int
+	PartnerID int
+	// Cursor is opaque position of the last message on the previous page
+	Cursor string
+	Limit  int
+}
+
+// MessageServiceInfo struct is list parameter info for a message
+type MessageServiceInfo struct {
+	MessageID   int
+	SenderID    int
+	ReceiverID  int
+	Content     string
+	IsRead      bool
+	ReadDate    string
+	CreatedDate string
+}
+
+// MessageListServiceInfo struct is one page of the conversation ordered by the latest message
+type MessageListServiceInfo struct {
+	Messages []MessageServiceInfo
+	// NextCursor is empty when there is no more message
+	NextCursor string
+}
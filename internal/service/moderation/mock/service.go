@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/moderation/service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	moderation "gilsaputro/dating-apps/internal/service/moderation"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockModerationServiceMethod is a mock of ModerationServiceMethod interface.
+type MockModerationServiceMethod struct {
+	ctrl     *gomock.Controller
+	recorder *MockModerationServiceMethodMockRecorder
+}
+
+// MockModerationServiceMethodMockRecorder is the mock recorder for MockModerationServiceMethod.
+type MockModerationServiceMethodMockRecorder struct {
+	mock *MockModerationServiceMethod
+}
+
+// NewMockModerationServiceMethod creates a new mock instance.
+func NewMockModerationServiceMethod(ctrl *gomock.Controller) *MockModerationServiceMethod {
+	mock := &MockModerationServiceMethod{ctrl: ctrl}
+	mock.recorder = &MockModerationServiceMethodMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModerationServiceMethod) EXPECT() *MockModerationServiceMethodMockRecorder {
+	return m.recorder
+}
+
+// BlockUser mocks base method.
+func (m *MockModerationServiceMethod) BlockUser(request moderation.BlockServiceRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockUser", request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockUser indicates an expected call of BlockUser.
+func (mr *MockModerationServiceMethodMockRecorder) BlockUser(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUser", reflect.TypeOf((*MockModerationServiceMethod)(nil).BlockUser), request)
+}
+
+// GetListReport mocks base method.
+func (m *MockModerationServiceMethod) GetListReport(request moderation.ReportListServiceRequest) (moderation.ReportListServiceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListReport", request)
+	ret0, _ := ret[0].(moderation.ReportListServiceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListReport indicates an expected call of GetListReport.
+func (mr *MockModerationServiceMethodMockRecorder) GetListReport(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListReport", reflect.TypeOf((*MockModerationServiceMethod)(nil).GetListReport), request)
+}
+
+// ReportUser mocks base method.
+func (m *MockModerationServiceMethod) ReportUser(request moderation.ReportServiceRequest) (moderation.ReportServiceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportUser", request)
+	ret0, _ := ret[0].(moderation.ReportServiceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportUser indicates an expected call of ReportUser.
+func (mr *MockModerationServiceMethodMockRecorder) ReportUser(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportUser", reflect.TypeOf((*MockModerationServiceMethod)(nil).ReportUser), request)
+}
+
+// ResolveReport mocks base method.
+func (m *MockModerationServiceMethod) ResolveReport(request moderation.ResolveReportServiceRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveReport", request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveReport indicates an expected call of ResolveReport.
+func (mr *MockModerationServiceMethodMockRecorder) ResolveReport(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveReport", reflect.TypeOf((*MockModerationServiceMethod)(nil).ResolveReport), request)
+}
@@ -0,0 +1,183 @@
+package moderation
+
+import (
+	"gilsaputro/dating-apps/internal/store/block"
+	"gilsaputro/dating-apps/internal/store/match"
+	"gilsaputro/dating-apps/internal/store/report"
+	"gilsaputro/dating-apps/internal/store/user"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/pagination"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
+
+// ModerationServiceMethod is list method for Moderation Service
+type ModerationServiceMethod interface {
+	BlockUser(request BlockServiceRequest) error
+	ReportUser(request ReportServiceRequest) (ReportServiceInfo, error)
+	GetListReport(request ReportListServiceRequest) (ReportListServiceInfo, error)
+	ResolveReport(request ResolveReportServiceRequest) error
+}
+
+// ModerationService is list dependencies for moderation service
+type ModerationService struct {
+	storeUser   user.UserStoreMethod
+	storeBlock  block.BlockStoreMethod
+	storeReport report.ReportStoreMethod
+	storeMatch  match.MatchStoreMethod
+}
+
+// NewModerationService is func to generate ModerationServiceMethod interface
+func NewModerationService(storeUser user.UserStoreMethod, storeBlock block.BlockStoreMethod, storeReport report.ReportStoreMethod, storeMatch match.MatchStoreMethod) ModerationServiceMethod {
+	return &ModerationService{
+		storeUser:   storeUser,
+		storeBlock:  storeBlock,
+		storeReport: storeReport,
+		storeMatch:  storeMatch,
+	}
+}
+
+// BlockUser is func to block the target user and end the match between them if any
+func (m *ModerationService) BlockUser(request BlockServiceRequest) error {
+	err := m.validateTargetUser(request.UserID, request.BlockedUserID)
+	if err != nil {
+		return err
+	}
+
+	err = m.storeBlock.CreateBlock(models.UserBlock{
+		UserID:        uint(request.UserID),
+		BlockedUserID: uint(request.BlockedUserID),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = m.storeMatch.Unmatch(request.UserID, request.BlockedUserID)
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		return err
+	}
+
+	return nil
+}
+
+// ReportUser is func to put the report of the target user into moderation queue
+func (m *ModerationService) ReportUser(request ReportServiceRequest) (ReportServiceInfo, error) {
+	reason := models.ParseReportReason(strings.ToUpper(request.Reason))
+	if reason == models.ReportReasonUnknown {
+		return ReportServiceInfo{}, ErrInvalidReportReason
+	}
+
+	err := m.validateTargetUser(request.UserID, request.ReportedUserID)
+	if err != nil {
+		return ReportServiceInfo{}, err
+	}
+
+	result, err := m.storeReport.CreateReport(models.UserReport{
+		ReporterID:     uint(request.UserID),
+		ReportedUserID: uint(request.ReportedUserID),
+		Reason:         reason,
+		Note:           request.Note,
+	})
+	if err != nil {
+		return ReportServiceInfo{}, err
+	}
+
+	return mapReportServiceInfo(result), nil
+}
+
+// GetListReport is func to get paginated report of moderation queue
+func (m *ModerationService) GetListReport(request ReportListServiceRequest) (ReportListServiceInfo, error) {
+	status := models.ReportStatusOpen
+	if len(request.Status) > 0 {
+		status = models.ParseReportStatus(strings.ToUpper(request.Status))
+		if status == models.ReportStatusUnknown {
+			return ReportListServiceInfo{}, ErrInvalidReportStatus
+		}
+	}
+
+	page, limit := pagination.Normalize(request.Page, request.Limit, DefaultReportPageLimit, MaxReportPageLimit)
+	result := ReportListServiceInfo{
+		Reports: []ReportServiceInfo{},
+		Page:    page,
+		Limit:   limit,
+	}
+
+	total, err := m.storeReport.CountReport(status)
+	if err != nil {
+		return ReportListServiceInfo{}, err
+	}
+	result.Total = total
+
+	offset := (page - 1) * limit
+	if offset >= total {
+		return result, nil
+	}
+
+	reports, err := m.storeReport.GetReportList(report.ReportFilter{
+		Status: status,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return ReportListServiceInfo{}, err
+	}
+
+	for _, r := range reports {
+		result.Reports = append(result.Reports, mapReportServiceInfo(r))
+	}
+
+	return result, nil
+}
+
+// ResolveReport is func to close the open report by admin
+func (m *ModerationService) ResolveReport(request ResolveReportServiceRequest) error {
+	status := models.ParseReportStatus(strings.ToUpper(request.Status))
+	if status != models.ReportStatusResolved && status != models.ReportStatusDismissed {
+		return ErrInvalidReportStatus
+	}
+
+	if request.ReportID <= 0 {
+		return ErrReportNotFound
+	}
+
+	err := m.storeReport.ResolveReport(models.UserReport{
+		Model:      gorm.Model{ID: uint(request.ReportID)},
+		Status:     status,
+		ResolvedBy: uint(request.AdminID),
+		Resolution: request.Resolution,
+	})
+	if gorm.IsRecordNotFoundError(err) {
+		return ErrReportNotFound
+	}
+
+	return err
+}
+
+// validateTargetUser is func to check the target user is another existing user
+func (m *ModerationService) validateTargetUser(userID, targetUserID int) error {
+	if targetUserID <= 0 || targetUserID == userID {
+		return ErrInvalidTargetUser
+	}
+
+	_, err := m.storeUser.GetUserInfoByID(targetUserID)
+	if gorm.IsRecordNotFoundError(err) {
+		return ErrUserNotFound
+	}
+
+	return err
+}
+
+// mapReportServiceInfo is func to convert report into report service info
+func mapReportServiceInfo(r models.UserReport) ReportServiceInfo {
+	return ReportServiceInfo{
+		ReportID:       int(r.ID),
+		ReporterID:     int(r.ReporterID),
+		ReportedUserID: int(r.ReportedUserID),
+		Reason:         r.Reason.String(),
+		Note:           r.Note,
+		Status:         r.Status.String(),
+		Resolution:     r.Resolution,
+		CreatedDate:    r.CreatedAt.String(),
+	}
+}
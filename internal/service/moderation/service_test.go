@@ -0,0 +1,429 @@
+package moderation
+
+import (
+	"fmt"
+	"gilsaputro/dating-apps/internal/store/block"
+	mock_block "gilsaputro/dating-apps/internal/store/block/mock"
+	"gilsaputro/dating-apps/internal/store/match"
+	mock_match "gilsaputro/dating-apps/internal/store/match/mock"
+	"gilsaputro/dating-apps/internal/store/report"
+	mock_report "gilsaputro/dating-apps/internal/store/report/mock"
+	"gilsaputro/dating-apps/internal/store/user"
+	mock_user "gilsaputro/dating-apps/internal/store/user/mock"
+	"gilsaputro/dating-apps/models"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+)
+
+func TestNewModerationService(t *testing.T) {
+	type args struct {
+		storeUser   user.UserStoreMethod
+		storeBlock  block.BlockStoreMethod
+		storeReport report.ReportStoreMethod
+		storeMatch  match.MatchStoreMethod
+	}
+	tests := []struct {
+		name string
+		args args
+		want ModerationServiceMethod
+	}{
+		{
+			name: "success",
+			args: args{
+				storeUser:   &user.UserStore{},
+				storeBlock:  &block.BlockStore{},
+				storeReport: &report.ReportStore{},
+				storeMatch:  &match.MatchStore{},
+			},
+			want: &ModerationService{
+				storeUser:   &user.UserStore{},
+				storeBlock:  &block.BlockStore{},
+				storeReport: &report.ReportStore{},
+				storeMatch:  &match.MatchStore{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewModerationService(tt.args.storeUser, tt.args.storeBlock, tt.args.storeReport, tt.args.storeMatch); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewModerationService() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModerationService_BlockUser(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
+	bStore := mock_block.NewMockBlockStoreMethod(mockCtrl)
+	mStore := mock_match.NewMockMatchStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		request BlockServiceRequest
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name: "success and end the match",
+			args: args{
+				request: BlockServiceRequest{UserID: 1, BlockedUserID: 2},
+			},
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(2).Return(models.User{Model: gorm.Model{ID: 2}}, nil)
+				bStore.EXPECT().CreateBlock(models.UserBlock{UserID: 1, BlockedUserID: 2}).Return(nil)
+				mStore.EXPECT().Unmatch(1, 2).Return(nil)
+			},
+		},
+		{
+			name: "success without match",
+			args: args{
+				request: BlockServiceRequest{UserID: 1, BlockedUserID: 2},
+			},
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(2).Return(models.User{Model: gorm.Model{ID: 2}}, nil)
+				bStore.EXPECT().CreateBlock(models.UserBlock{UserID: 1, BlockedUserID: 2}).Return(nil)
+				mStore.EXPECT().Unmatch(1, 2).Return(gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			name: "error block self",
+			args: args{
+				request: BlockServiceRequest{UserID: 1, BlockedUserID: 1},
+			},
+			mockFunc: func() {},
+			wantErr:  ErrInvalidTargetUser,
+		},
+		{
+			name: "error user not found",
+			args: args{
+				request: BlockServiceRequest{UserID: 1, BlockedUserID: 2},
+			},
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(2).Return(models.User{}, gorm.ErrRecordNotFound)
+			},
+			wantErr: ErrUserNotFound,
+		},
+		{
+			name: "error on create block",
+			args: args{
+				request: BlockServiceRequest{UserID: 1, BlockedUserID: 2},
+			},
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(2).Return(models.User{Model: gorm.Model{ID: 2}}, nil)
+				bStore.EXPECT().CreateBlock(models.UserBlock{UserID: 1, BlockedUserID: 2}).Return(fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error on unmatch",
+			args: args{
+				request: BlockServiceRequest{UserID: 1, BlockedUserID: 2},
+			},
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(2).Return(models.User{Model: gorm.Model{ID: 2}}, nil)
+				bStore.EXPECT().CreateBlock(models.UserBlock{UserID: 1, BlockedUserID: 2}).Return(nil)
+				mStore.EXPECT().Unmatch(1, 2).Return(fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := ModerationService{
+				storeUser:  uStore,
+				storeBlock: bStore,
+				storeMatch: mStore,
+			}
+			tt.mockFunc()
+			err := s.BlockUser(tt.args.request)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("ModerationService.BlockUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestModerationService_ReportUser(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
+	rStore := mock_report.NewMockReportStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		request ReportServiceRequest
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     ReportServiceInfo
+		wantErr  error
+	}{
+		{
+			name: "success",
+			args: args{
+				request: ReportServiceRequest{UserID: 1, ReportedUserID: 2, Reason: "spam", Note: "sending link"},
+			},
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(2).Return(models.User{Model: gorm.Model{ID: 2}}, nil)
+				rStore.EXPECT().CreateReport(models.UserReport{
+					ReporterID:     1,
+					ReportedUserID: 2,
+					Reason:         models.ReportReasonSpam,
+					Note:           "sending link",
+				}).Return(models.UserReport{
+					Model:          gorm.Model{ID: 3},
+					ReporterID:     1,
+					ReportedUserID: 2,
+					Reason:         models.ReportReasonSpam,
+					Note:           "sending link",
+					Status:         models.ReportStatusOpen,
+				}, nil)
+			},
+			want: ReportServiceInfo{
+				ReportID:       3,
+				ReporterID:     1,
+				ReportedUserID: 2,
+				Reason:         "SPAM",
+				Note:           "sending link",
+				Status:         "OPEN",
+				CreatedDate:    "0001-01-01 00:00:00 +0000 UTC",
+			},
+		},
+		{
+			name: "error invalid reason",
+			args: args{
+				request: ReportServiceRequest{UserID: 1, ReportedUserID: 2, Reason: "boring"},
+			},
+			mockFunc: func() {},
+			wantErr:  ErrInvalidReportReason,
+		},
+		{
+			name: "error report self",
+			args: args{
+				request: ReportServiceRequest{UserID: 1, ReportedUserID: 1, Reason: "SPAM"},
+			},
+			mockFunc: func() {},
+			wantErr:  ErrInvalidTargetUser,
+		},
+		{
+			name: "error on create report",
+			args: args{
+				request: ReportServiceRequest{UserID: 1, ReportedUserID: 2, Reason: "SPAM"},
+			},
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(2).Return(models.User{Model: gorm.Model{ID: 2}}, nil)
+				rStore.EXPECT().CreateReport(gomock.Any()).Return(models.UserReport{}, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := ModerationService{
+				storeUser:   uStore,
+				storeReport: rStore,
+			}
+			tt.mockFunc()
+			got, err := s.ReportUser(tt.args.request)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("ModerationService.ReportUser() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ModerationService.ReportUser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModerationService_GetListReport(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	rStore := mock_report.NewMockReportStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	createdAt := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+	type args struct {
+		request ReportListServiceRequest
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     ReportListServiceInfo
+		wantErr  error
+	}{
+		{
+			name: "success default open report",
+			args: args{
+				request: ReportListServiceRequest{},
+			},
+			mockFunc: func() {
+				rStore.EXPECT().CountReport(models.ReportStatusOpen).Return(1, nil)
+				rStore.EXPECT().GetReportList(report.ReportFilter{
+					Status: models.ReportStatusOpen,
+					Limit:  DefaultReportPageLimit,
+				}).Return([]models.UserReport{
+					{
+						Model:          gorm.Model{ID: 3, CreatedAt: createdAt},
+						ReporterID:     1,
+						ReportedUserID: 2,
+						Reason:         models.ReportReasonHarassment,
+						Status:         models.ReportStatusOpen,
+					},
+				}, nil)
+			},
+			want: ReportListServiceInfo{
+				Reports: []ReportServiceInfo{
+					{
+						ReportID:       3,
+						ReporterID:     1,
+						ReportedUserID: 2,
+						Reason:         "HARASSMENT",
+						Status:         "OPEN",
+						CreatedDate:    createdAt.String(),
+					},
+				},
+				Page:  1,
+				Limit: DefaultReportPageLimit,
+				Total: 1,
+			},
+		},
+		{
+			name: "success page out of range",
+			args: args{
+				request: ReportListServiceRequest{Status: "resolved", Page: 3, Limit: 100},
+			},
+			mockFunc: func() {
+				rStore.EXPECT().CountReport(models.ReportStatusResolved).Return(5, nil)
+			},
+			want: ReportListServiceInfo{
+				Reports: []ReportServiceInfo{},
+				Page:    3,
+				Limit:   MaxReportPageLimit,
+				Total:   5,
+			},
+		},
+		{
+			name: "error invalid status",
+			args: args{
+				request: ReportListServiceRequest{Status: "unknown"},
+			},
+			mockFunc: func() {},
+			wantErr:  ErrInvalidReportStatus,
+		},
+		{
+			name: "error on count report",
+			args: args{
+				request: ReportListServiceRequest{},
+			},
+			mockFunc: func() {
+				rStore.EXPECT().CountReport(models.ReportStatusOpen).Return(0, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error on get report list",
+			args: args{
+				request: ReportListServiceRequest{},
+			},
+			mockFunc: func() {
+				rStore.EXPECT().CountReport(models.ReportStatusOpen).Return(1, nil)
+				rStore.EXPECT().GetReportList(gomock.Any()).Return(nil, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := ModerationService{
+				storeReport: rStore,
+			}
+			tt.mockFunc()
+			got, err := s.GetListReport(tt.args.request)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("ModerationService.GetListReport() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ModerationService.GetListReport() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModerationService_ResolveReport(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	rStore := mock_report.NewMockReportStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		request ResolveReportServiceRequest
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name: "success",
+			args: args{
+				request: ResolveReportServiceRequest{ReportID: 3, AdminID: 9, Status: "dismissed", Resolution: "not violating"},
+			},
+			mockFunc: func() {
+				rStore.EXPECT().ResolveReport(models.UserReport{
+					Model:      gorm.Model{ID: 3},
+					Status:     models.ReportStatusDismissed,
+					ResolvedBy: 9,
+					Resolution: "not violating",
+				}).Return(nil)
+			},
+		},
+		{
+			name: "error report not found",
+			args: args{
+				request: ResolveReportServiceRequest{ReportID: 3, AdminID: 9, Status: "RESOLVED"},
+			},
+			mockFunc: func() {
+				rStore.EXPECT().ResolveReport(gomock.Any()).Return(gorm.ErrRecordNotFound)
+			},
+			wantErr: ErrReportNotFound,
+		},
+		{
+			name: "error reopen report",
+			args: args{
+				request: ResolveReportServiceRequest{ReportID: 3, AdminID: 9, Status: "OPEN"},
+			},
+			mockFunc: func() {},
+			wantErr:  ErrInvalidReportStatus,
+		},
+		{
+			name: "error on resolve report",
+			args: args{
+				request: ResolveReportServiceRequest{ReportID: 3, AdminID: 9, Status: "RESOLVED"},
+			},
+			mockFunc: func() {
+				rStore.EXPECT().ResolveReport(gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := ModerationService{
+				storeReport: rStore,
+			}
+			tt.mockFunc()
+			err := s.ResolveReport(tt.args.request)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("ModerationService.ResolveReport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package moderation
+
+import "errors"
+
+// list Service error
+var (
+	ErrInvalidTargetUser   = errors.New("the target user is invalid")
+	ErrUserNotFound        = errors.New("the target user is not found")
+	ErrInvalidReportReason = errors.New("report reason is invalid, the value should be SPAM, HARASSMENT, INAPPROPRIATE_CONTENT, FAKE_PROFILE, UNDERAGE or OTHER")
+	ErrInvalidReportStatus = errors.New("report status is invalid")
+	ErrReportNotFound      = errors.New("the report is not found or already resolved")
+)
+
+const (
+	// DefaultReportPageLimit is default number of report returned for each page
+	DefaultReportPageLimit = 10
+	// MaxReportPageLimit is max number of report returned for each page
+	MaxReportPageLimit = 50
+)
+
+// BlockServiceRequest is list parameter for block user
+type BlockServiceRequest struct {
+	UserID        int
+	BlockedUserID int
+}
+
+// ReportServiceRequest is list parameter for report user
+type ReportServiceRequest struct {
+	UserID         int
+	ReportedUserID int
+	// Reason is the report reason code
+	Reason string
+	Note   string
+}
+
+// ReportServiceInfo struct is list parameter info for a report
+type ReportServiceInfo struct {
+	ReportID       int
+	ReporterID     int
+	ReportedUserID int
+	Reason         string
+	Note           string
+	Status         string
+	Resolution     string
+	CreatedDate    string
+}
+
+// ReportListServiceRequest is list parameter for get moderation queue
+type ReportListServiceRequest struct {
+	// Status is the report status name, empty means OPEN
+	Status string
+	Page   int
+	Limit  int
+}
+
+// ReportListServiceInfo struct is list report with the pagination info
+type ReportListServiceInfo struct {
+	Reports []ReportServiceInfo
+	Page    int
+	Limit   int
+	Total   int
+}
+
+// ResolveReportServiceRequest is list parameter for resolve report
+type ResolveReportServiceRequest struct {
+	ReportID int
+	AdminID  int
+	// Status is RESOLVED or DISMISSED
+	Status     string
+	Resolution string
+}
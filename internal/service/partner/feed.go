@@ -0,0 +1,222 @@
+package partner
+
+import (
+	"gilsaputro/dating-apps/internal/store/user"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/geo"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// candidateFeedSize is max number of candidate fetched from store for each feed
+	candidateFeedSize = 50
+	// maxViewedPartnerHistory is max number of recently viewed partner kept in cache
+	maxViewedPartnerHistory = 10
+
+	// scoreSuperLikedUser is higher than the other scores combined so the super liker always comes first
+	scoreSuperLikedUser = 10.0
+	scoreLikedUser      = 5.0
+	scorePremiumUser    = 2.0
+	scoreNewUser        = 1.0
+	newUserPeriod       = 7 * 24 * time.Hour
+)
+
+// candidate is partner candidate with the ranking score
+type candidate struct {
+	info  models.User
+	score float64
+}
+
+// getCandidateFeed is func to get eligible partner candidates ordered by the ranking score
+func (f PartnerService) getCandidateFeed(userInfo models.User, viewedPartnerIDs []int) ([]models.User, error) {
+	userID := int(userInfo.ID)
+
+	// exclude self, already liked and recently viewed partner, unmatched partner is also excluded because the like history is kept
+	likedPartnerIDs, err := f.storeHist.GetPartnerIDsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// passed partner is excluded until the cooldown is over
+	passedPartnerIDs, err := f.storeHist.GetPassedPartnerIDsByUserID(userID, time.Now().Add(-f.passCooldown))
+	if err != nil {
+		return nil, err
+	}
+
+	// blocked user is excluded in both direction
+	blockedUserIDs, err := f.storeBlock.GetBlockedUserIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	excludeIDs := append([]int{userID}, likedPartnerIDs...)
+	excludeIDs = append(excludeIDs, passedPartnerIDs...)
+	excludeIDs = append(excludeIDs, blockedUserIDs...)
+	excludeIDs = append(excludeIDs, viewedPartnerIDs...)
+
+	// user who already like the user is more likely to be a match
+	likedByIDs, err := f.storeHist.GetUserIDsByPartnerID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	likedBy := make(map[uint]bool)
+	for _, id := range likedByIDs {
+		likedBy[uint(id)] = true
+	}
+
+	superLikedByIDs, err := f.storeHist.GetSuperLikerIDsByPartnerID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	superLikedBy := make(map[uint]bool)
+	for _, id := range superLikedByIDs {
+		superLikedBy[uint(id)] = true
+	}
+
+	filter := buildCandidateFilter(userInfo, excludeIDs, time.Now())
+	filter.SuperLikerIDs = superLikedByIDs
+	filter.LikerIDs = likedByIDs
+	users, err := f.storeUser.GetCandidateList(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	candidates := make([]candidate, 0, len(users))
+	for _, u := range users {
+		candidates = append(candidates, candidate{
+			info:  u,
+			score: scoreCandidate(u, likedBy[u.ID], superLikedBy[u.ID]),
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	result := make([]models.User, 0, len(candidates))
+	for _, c := range candidates {
+		result = append(result, c.info)
+	}
+
+	return result, nil
+}
+
+// buildCandidateFilter is func to generate candidate filter that satisfy both user and candidate preference
+func buildCandidateFilter(info models.User, excludeIDs []int, now time.Time) user.CandidateFilter {
+	filter := user.CandidateFilter{
+		ExcludeIDs: excludeIDs,
+		Limit:      candidateFeedSize,
+		Genders:    splitGenders(info.InterestedIn),
+		Gender:     info.Gender,
+		Age:        info.Age(now),
+	}
+
+	// candidate age must be at least PrefAgeMin, so the birthdate must be before now - PrefAgeMin
+	if info.PrefAgeMin > 0 {
+		maxBirthdate := now.AddDate(-info.PrefAgeMin, 0, 0)
+		filter.MaxBirthdate = &maxBirthdate
+	}
+
+	// candidate age must be at most PrefAgeMax, so the birthdate must be after now - (PrefAgeMax + 1)
+	if info.PrefAgeMax > 0 {
+		minBirthdate := now.AddDate(-(info.PrefAgeMax + 1), 0, 0)
+		filter.MinBirthdate = &minBirthdate
+	}
+
+	// the user without location only accept the candidate without max distance preference
+	if !info.HasLocation() {
+		return filter
+	}
+
+	location := geo.Point{
+		Latitude:  info.Latitude,
+		Longitude: info.Longitude,
+	}
+	filter.Location = &location
+
+	// pre-filter the candidate location using bounding box before the exact distance is checked
+	if info.PrefMaxDistance > 0 {
+		area := geo.BoundingBox(location, float64(info.PrefMaxDistance))
+		filter.Area = &area
+		filter.MaxDistance = info.PrefMaxDistance
+	}
+
+	return filter
+}
+
+// partnerDistance is func to calculate distance between user and partner in kilometer
+func partnerDistance(userInfo models.User, partnerInfo models.User) (float64, bool) {
+	if !userInfo.HasLocation() || !partnerInfo.HasLocation() {
+		return 0, false
+	}
+
+	return geo.Distance(
+		geo.Point{Latitude: userInfo.Latitude, Longitude: userInfo.Longitude},
+		geo.Point{Latitude: partnerInfo.Latitude, Longitude: partnerInfo.Longitude},
+	), true
+}
+
+// splitGenders is func to convert comma separated gender into list of gender
+func splitGenders(genders string) []string {
+	var result []string
+	for _, gender := range strings.Split(genders, ",") {
+		gender = strings.TrimSpace(gender)
+		if len(gender) > 0 {
+			result = append(result, gender)
+		}
+	}
+	return result
+}
+
+// scoreCandidate is func to calculate ranking score of a candidate
+func scoreCandidate(info models.User, isLikedUser bool, isSuperLikedUser bool) float64 {
+	var score float64
+	if isSuperLikedUser {
+		score += scoreSuperLikedUser
+	}
+
+	if isLikedUser {
+		score += scoreLikedUser
+	}
+
+	if info.IsPremium() {
+		score += scorePremiumUser
+	}
+
+	if !info.CreatedAt.IsZero() && time.Since(info.CreatedAt) < newUserPeriod {
+		score += scoreNewUser
+	}
+
+	return score
+}
+
+// parsePartnerHistory is func to convert comma separated partner history into list of partner id
+func parsePartnerHistory(history string) []int {
+	var result []int
+	for _, str := range strings.Split(history, ",") {
+		num, err := strconv.Atoi(strings.TrimSpace(str))
+		if err != nil || num <= 0 {
+			continue
+		}
+		result = append(result, num)
+	}
+	return result
+}
+
+// formatPartnerHistory is func to convert list of partner id into comma separated partner history
+func formatPartnerHistory(history []int) string {
+	result := make([]string, len(history))
+	for i, v := range history {
+		result[i] = strconv.Itoa(v)
+	}
+	return strings.Join(result, ",")
+}
@@ -0,0 +1,202 @@
+package partner
+
+import (
+	"gilsaputro/dating-apps/internal/store/user"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/geo"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+func Test_scoreCandidate(t *testing.T) {
+	type args struct {
+		info             models.User
+		isLikedUser      bool
+		isSuperLikedUser bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want float64
+	}{
+		{
+			name: "liked premium new user",
+			args: args{
+				info: models.User{
+					Model: gorm.Model{
+						CreatedAt: time.Now().Add(-time.Hour),
+					},
+					Plan: models.PlanPremium,
+				},
+				isLikedUser: true,
+			},
+			want: scoreLikedUser + scorePremiumUser + scoreNewUser,
+		},
+		{
+			name: "super liked old user",
+			args: args{
+				info: models.User{
+					Model: gorm.Model{
+						CreatedAt: time.Now().Add(-2 * newUserPeriod),
+					},
+				},
+				isLikedUser:      true,
+				isSuperLikedUser: true,
+			},
+			want: scoreSuperLikedUser + scoreLikedUser,
+		},
+		{
+			name: "old user",
+			args: args{
+				info: models.User{
+					Model: gorm.Model{
+						CreatedAt: time.Now().Add(-2 * newUserPeriod),
+					},
+				},
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scoreCandidate(tt.args.info, tt.args.isLikedUser, tt.args.isSuperLikedUser); got != tt.want {
+				t.Errorf("scoreCandidate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parsePartnerHistory(t *testing.T) {
+	tests := []struct {
+		name    string
+		history string
+		want    []int
+	}{
+		{
+			name:    "success flow",
+			history: "1,2, 3",
+			want:    []int{1, 2, 3},
+		},
+		{
+			name:    "skip invalid value",
+			history: "1,,a,0,4",
+			want:    []int{1, 4},
+		},
+		{
+			name:    "empty history",
+			history: "",
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parsePartnerHistory(tt.history); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePartnerHistory() = %v, want %v", got, tt.want)
+			}
+			if tt.want != nil {
+				if got := parsePartnerHistory(formatPartnerHistory(tt.want)); !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("formatPartnerHistory() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func Test_buildCandidateFilter(t *testing.T) {
+	now := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+	birthdate := time.Date(1998, 1, 1, 0, 0, 0, 0, time.UTC)
+	minBirthdate := time.Date(1992, 6, 15, 0, 0, 0, 0, time.UTC)
+	maxBirthdate := time.Date(2002, 6, 15, 0, 0, 0, 0, time.UTC)
+	jakarta := geo.Point{Latitude: -6.2088, Longitude: 106.8456}
+	area := geo.BoundingBox(jakarta, 50)
+	type args struct {
+		info       models.User
+		excludeIDs []int
+	}
+	tests := []struct {
+		name string
+		args args
+		want user.CandidateFilter
+	}{
+		{
+			name: "user with preference",
+			args: args{
+				info: models.User{
+					Birthdate:    &birthdate,
+					Gender:       models.GenderMale,
+					InterestedIn: "FEMALE, OTHER",
+					PrefAgeMin:   21,
+					PrefAgeMax:   30,
+				},
+				excludeIDs: []int{1},
+			},
+			want: user.CandidateFilter{
+				ExcludeIDs:   []int{1},
+				Limit:        candidateFeedSize,
+				Genders:      []string{models.GenderFemale, models.GenderOther},
+				MinBirthdate: &minBirthdate,
+				MaxBirthdate: &maxBirthdate,
+				Gender:       models.GenderMale,
+				Age:          25,
+			},
+		},
+		{
+			name: "user without preference",
+			args: args{
+				info:       models.User{},
+				excludeIDs: []int{1},
+			},
+			want: user.CandidateFilter{
+				ExcludeIDs: []int{1},
+				Limit:      candidateFeedSize,
+			},
+		},
+		{
+			name: "user with location and max distance",
+			args: args{
+				info:       models.User{Latitude: -6.2088, Longitude: 106.8456, LocationUpdatedAt: &now, PrefMaxDistance: 50},
+				excludeIDs: []int{1},
+			},
+			want: user.CandidateFilter{
+				ExcludeIDs:  []int{1},
+				Limit:       candidateFeedSize,
+				Area:        &area,
+				Location:    &jakarta,
+				MaxDistance: 50,
+			},
+		},
+		{
+			name: "user with location without max distance",
+			args: args{
+				info:       models.User{Latitude: -6.2088, Longitude: 106.8456, LocationUpdatedAt: &now},
+				excludeIDs: []int{1},
+			},
+			want: user.CandidateFilter{
+				ExcludeIDs: []int{1},
+				Limit:      candidateFeedSize,
+				Location:   &jakarta,
+			},
+		},
+		{
+			name: "user without location ignore own max distance",
+			args: args{
+				info:       models.User{PrefMaxDistance: 50},
+				excludeIDs: []int{1},
+			},
+			want: user.CandidateFilter{
+				ExcludeIDs: []int{1},
+				Limit:      candidateFeedSize,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildCandidateFilter(tt.args.info, tt.args.excludeIDs, now); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("buildCandidateFilter() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
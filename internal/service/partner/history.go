@@ -0,0 +1,112 @@
+package partner
+
+import (
+	"encoding/base64"
+	"fmt"
+	"gilsaputro/dating-apps/internal/store/userhistory"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/pagination"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// getHistoryPage is func to get one page of the user history with the given decisions and the cursor of the next page
+func (f PartnerService) getHistoryPage(request HistoryServiceRequest, decisions []models.DecisionType, sortByUpdatedAt bool) ([]models.UserMatchHistory, string, error) {
+	limit := pagination.NormalizeLimit(request.Limit, DefaultHistoryPageLimit, MaxHistoryPageLimit)
+
+	status, err := parseHistoryStatus(request.Status)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var cursor *userhistory.HistoryCursor
+	if len(request.Cursor) > 0 {
+		cursor, err = decodeHistoryCursor(request.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	// the history of blocked partner is hidden
+	blockedUserIDs, err := f.storeBlock.GetBlockedUserIDs(request.UserID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// fetch one more history to know whether there is a next page
+	hist, err := f.storeHist.GetUserHistoryListByUserID(userhistory.HistoryFilter{
+		UserID:            request.UserID,
+		Decisions:         decisions,
+		ExcludePartnerIDs: blockedUserIDs,
+		Status:            status,
+		Cursor:            cursor,
+		Limit:             limit + 1,
+		SortAsc:           request.SortAsc,
+		SortByUpdatedAt:   sortByUpdatedAt,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(hist) <= limit {
+		return hist, "", nil
+	}
+
+	hist = hist[:limit]
+	return hist, encodeHistoryCursor(hist[limit-1], sortByUpdatedAt), nil
+}
+
+// parseHistoryStatus is func to convert the history status name into match status, empty status means all status
+func parseHistoryStatus(status string) (models.MatchStatus, error) {
+	if len(status) == 0 {
+		return 0, nil
+	}
+
+	for _, s := range []models.MatchStatus{models.MatchStatusPending, models.MatchStatusApproved, models.MatchStatusRejected} {
+		if s.String() == strings.ToUpper(status) {
+			return s, nil
+		}
+	}
+
+	return 0, ErrInvalidHistoryStatus
+}
+
+// encodeHistoryCursor is func to generate opaque cursor from the history position
+func encodeHistoryCursor(history models.UserMatchHistory, sortByUpdatedAt bool) string {
+	date := history.CreatedAt
+	if sortByUpdatedAt {
+		date = history.UpdatedAt
+	}
+
+	value := fmt.Sprintf("%d:%d", date.UnixNano(), history.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+// decodeHistoryCursor is func to get the history position from the opaque cursor
+func decodeHistoryCursor(cursor string) (*userhistory.HistoryCursor, error) {
+	value, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidHistoryCursor
+	}
+
+	values := strings.Split(string(value), ":")
+	if len(values) != 2 {
+		return nil, ErrInvalidHistoryCursor
+	}
+
+	date, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidHistoryCursor
+	}
+
+	id, err := strconv.ParseUint(values[1], 10, 64)
+	if err != nil || id == 0 {
+		return nil, ErrInvalidHistoryCursor
+	}
+
+	return &userhistory.HistoryCursor{
+		Time: time.Unix(0, date).UTC(),
+		ID:   uint(id),
+	}, nil
+}
@@ -0,0 +1,94 @@
+package partner
+
+import (
+	"gilsaputro/dating-apps/models"
+)
+
+// GetListLikesReceived is func to get the pending like received by the user, the liker profile is only shown when the plan is entitled
+func (f PartnerService) GetListLikesReceived(request PartnerServiceRequest) (LikesReceivedServiceInfo, error) {
+	likes, err := f.storeHist.GetPendingLikeListByPartnerID(request.UserID)
+	if err != nil {
+		return LikesReceivedServiceInfo{}, err
+	}
+
+	blockedUserIDs, err := f.storeBlock.GetBlockedUserIDs(request.UserID)
+	if err != nil {
+		return LikesReceivedServiceInfo{}, err
+	}
+
+	blocked := make(map[uint]bool, len(blockedUserIDs))
+	for _, id := range blockedUserIDs {
+		blocked[uint(id)] = true
+	}
+
+	// the like from blocked user is hidden
+	hist := make([]models.UserMatchHistory, 0, len(likes))
+	for _, data := range likes {
+		if !blocked[data.UserID] {
+			hist = append(hist, data)
+		}
+	}
+
+	result := LikesReceivedServiceInfo{
+		Likes:      []PartnerServiceInfo{},
+		IsRedacted: !models.GetEntitlements(request.Plan).SeeLikesReceived,
+	}
+
+	if len(hist) == 0 {
+		return result, nil
+	}
+
+	likerIDs := make([]int, 0, len(hist))
+	for _, data := range hist {
+		likerIDs = append(likerIDs, int(data.UserID))
+	}
+
+	likers, err := f.storeUser.GetUserListByIDs(likerIDs)
+	if err != nil {
+		return LikesReceivedServiceInfo{}, err
+	}
+
+	likerByID := make(map[uint]models.User, len(likers))
+	for _, l := range likers {
+		likerByID[l.ID] = l
+	}
+
+	// the like of the deleted liker account is not counted in both mode
+	active := make([]models.UserMatchHistory, 0, len(hist))
+	for _, data := range hist {
+		if _, ok := likerByID[data.UserID]; ok {
+			active = append(active, data)
+		}
+	}
+	result.Total = len(active)
+
+	// the user without entitlement only get the count and the redacted like without the liker identity
+	if result.IsRedacted {
+		for _, data := range active {
+			result.Likes = append(result.Likes, PartnerServiceInfo{
+				Status:       models.MatchStatusPending.String(),
+				CreatedDate:  data.CreatedAt.String(),
+				IsSuperLiked: data.Decision == models.DecisionSuperLike,
+			})
+		}
+		return result, nil
+	}
+
+	if len(active) == 0 {
+		return result, nil
+	}
+
+	userInfo, err := f.storeUser.GetUserInfoByID(request.UserID)
+	if err != nil {
+		return LikesReceivedServiceInfo{}, err
+	}
+
+	for _, data := range active {
+		info := mapPartnerServiceInfo(userInfo, likerByID[data.UserID], models.MatchStatusPending.String())
+		info.CreatedDate = data.CreatedAt.String()
+		info.IsSuperLiked = data.Decision == models.DecisionSuperLike
+		result.Likes = append(result.Likes, info)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,249 @@
+package partner
+
+import (
+	"fmt"
+	mock_block "gilsaputro/dating-apps/internal/store/block/mock"
+	mock_user "gilsaputro/dating-apps/internal/store/user/mock"
+	mock_userhist "gilsaputro/dating-apps/internal/store/userhistory/mock"
+	"gilsaputro/dating-apps/models"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+)
+
+func TestPartnerService_GetListLikesReceived(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
+	hStore := mock_userhist.NewMockUserHistoryStoreMethod(mockCtrl)
+	bStore := mock_block.NewMockBlockStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	likedAt := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+	hist := []models.UserMatchHistory{
+		{Model: gorm.Model{ID: 10, CreatedAt: likedAt}, UserID: 3, PartnerID: 1, Status: models.MatchStatusPending, Decision: models.DecisionSuperLike},
+		{Model: gorm.Model{ID: 11, CreatedAt: likedAt}, UserID: 4, PartnerID: 1, Status: models.MatchStatusPending, Decision: models.DecisionLike},
+		{Model: gorm.Model{ID: 12, CreatedAt: likedAt}, UserID: 5, PartnerID: 1, Status: models.MatchStatusPending, Decision: models.DecisionLike},
+	}
+	type args struct {
+		request PartnerServiceRequest
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		want     LikesReceivedServiceInfo
+		wantErr  bool
+	}{
+		{
+			name: "success premium user see the liker profile",
+			mockFunc: func() {
+				hStore.EXPECT().GetPendingLikeListByPartnerID(1).Return(hist, nil)
+				bStore.EXPECT().GetBlockedUserIDs(1).Return([]int{}, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				uStore.EXPECT().GetUserListByIDs([]int{3, 4, 5}).Return([]models.User{
+					{Model: gorm.Model{ID: 3}, Fullname: "P3", Plan: models.PlanPremium},
+					{Model: gorm.Model{ID: 4}, Fullname: "P4"},
+				}, nil)
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				},
+			},
+			want: LikesReceivedServiceInfo{
+				Likes: []PartnerServiceInfo{
+					{
+						PartnerID:    3,
+						Fullname:     "P3",
+						IsPremium:    true,
+						Status:       "PENDING",
+						CreatedDate:  likedAt.String(),
+						IsSuperLiked: true,
+					},
+					{
+						PartnerID:   4,
+						Fullname:    "P4",
+						Status:      "PENDING",
+						CreatedDate: likedAt.String(),
+					},
+				},
+				Total: 2,
+			},
+		},
+		{
+			name: "success free user only see redacted like",
+			mockFunc: func() {
+				hStore.EXPECT().GetPendingLikeListByPartnerID(1).Return(hist[:2], nil)
+				bStore.EXPECT().GetBlockedUserIDs(1).Return([]int{}, nil)
+				uStore.EXPECT().GetUserListByIDs([]int{3, 4}).Return([]models.User{{Model: gorm.Model{ID: 3}}, {Model: gorm.Model{ID: 4}}}, nil)
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanFree,
+				},
+			},
+			want: LikesReceivedServiceInfo{
+				Likes: []PartnerServiceInfo{
+					{
+						Status:       "PENDING",
+						CreatedDate:  likedAt.String(),
+						IsSuperLiked: true,
+					},
+					{
+						Status:      "PENDING",
+						CreatedDate: likedAt.String(),
+					},
+				},
+				Total:      2,
+				IsRedacted: true,
+			},
+		},
+		{
+			name: "success hide like from blocked user",
+			mockFunc: func() {
+				hStore.EXPECT().GetPendingLikeListByPartnerID(1).Return(hist[:2], nil)
+				bStore.EXPECT().GetBlockedUserIDs(1).Return([]int{3}, nil)
+				uStore.EXPECT().GetUserListByIDs([]int{4}).Return([]models.User{{Model: gorm.Model{ID: 4}}}, nil)
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanFree,
+				},
+			},
+			want: LikesReceivedServiceInfo{
+				Likes: []PartnerServiceInfo{
+					{
+						Status:      "PENDING",
+						CreatedDate: likedAt.String(),
+					},
+				},
+				Total:      1,
+				IsRedacted: true,
+			},
+		},
+		{
+			name: "success free user not count like from deleted user",
+			mockFunc: func() {
+				hStore.EXPECT().GetPendingLikeListByPartnerID(1).Return(hist, nil)
+				bStore.EXPECT().GetBlockedUserIDs(1).Return([]int{}, nil)
+				uStore.EXPECT().GetUserListByIDs([]int{3, 4, 5}).Return([]models.User{{Model: gorm.Model{ID: 4}}}, nil)
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanFree,
+				},
+			},
+			want: LikesReceivedServiceInfo{
+				Likes: []PartnerServiceInfo{
+					{
+						Status:      "PENDING",
+						CreatedDate: likedAt.String(),
+					},
+				},
+				Total:      1,
+				IsRedacted: true,
+			},
+		},
+		{
+			name: "success premium user all liker deleted",
+			mockFunc: func() {
+				hStore.EXPECT().GetPendingLikeListByPartnerID(1).Return(hist[:1], nil)
+				bStore.EXPECT().GetBlockedUserIDs(1).Return([]int{}, nil)
+				uStore.EXPECT().GetUserListByIDs([]int{3}).Return([]models.User{}, nil)
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				},
+			},
+			want: LikesReceivedServiceInfo{
+				Likes: []PartnerServiceInfo{},
+			},
+		},
+		{
+			name: "error on GetUserInfoByID",
+			mockFunc: func() {
+				hStore.EXPECT().GetPendingLikeListByPartnerID(1).Return(hist, nil)
+				bStore.EXPECT().GetBlockedUserIDs(1).Return([]int{}, nil)
+				uStore.EXPECT().GetUserListByIDs([]int{3, 4, 5}).Return([]models.User{{Model: gorm.Model{ID: 3}}}, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{}, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "success empty like",
+			mockFunc: func() {
+				hStore.EXPECT().GetPendingLikeListByPartnerID(1).Return([]models.UserMatchHistory{}, nil)
+				bStore.EXPECT().GetBlockedUserIDs(1).Return([]int{}, nil)
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				},
+			},
+			want: LikesReceivedServiceInfo{
+				Likes: []PartnerServiceInfo{},
+			},
+		},
+		{
+			name: "error on GetPendingLikeListByPartnerID",
+			mockFunc: func() {
+				hStore.EXPECT().GetPendingLikeListByPartnerID(1).Return(nil, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on GetUserListByIDs",
+			mockFunc: func() {
+				hStore.EXPECT().GetPendingLikeListByPartnerID(1).Return(hist, nil)
+				bStore.EXPECT().GetBlockedUserIDs(1).Return([]int{}, nil)
+				uStore.EXPECT().GetUserListByIDs([]int{3, 4, 5}).Return(nil, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := PartnerService{
+				storeUser:  uStore,
+				storeHist:  hStore,
+				storeBlock: bStore,
+			}
+			tt.mockFunc()
+			got, err := s.GetListLikesReceived(tt.args.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PartnerService.GetListLikesReceived() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PartnerService.GetListLikesReceived() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
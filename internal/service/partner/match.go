@@ -0,0 +1,89 @@
+package partner
+
+import (
+	"gilsaputro/dating-apps/internal/store/match"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/pagination"
+
+	"github.com/jinzhu/gorm"
+)
+
+// GetListMatch is func to get paginated approved matches of the user with the partner profile summary
+func (f PartnerService) GetListMatch(request MatchListServiceRequest) (MatchListServiceInfo, error) {
+	page, limit := pagination.Normalize(request.Page, request.Limit, DefaultMatchPageLimit, MaxMatchPageLimit)
+	result := MatchListServiceInfo{
+		Matches: []MatchServiceInfo{},
+		Page:    page,
+		Limit:   limit,
+	}
+
+	total, err := f.storeMatch.CountByUserID(request.UserID)
+	if err != nil {
+		return MatchListServiceInfo{}, err
+	}
+	result.Total = total
+
+	offset := (page - 1) * limit
+	if offset >= total {
+		return result, nil
+	}
+
+	matches, err := f.storeMatch.GetMatchListByUserID(match.MatchFilter{
+		UserID: request.UserID,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return MatchListServiceInfo{}, err
+	}
+
+	userInfo, err := f.storeUser.GetUserInfoByID(request.UserID)
+	if err != nil {
+		return MatchListServiceInfo{}, err
+	}
+
+	partnerIDs := make([]int, 0, len(matches))
+	for _, m := range matches {
+		partnerIDs = append(partnerIDs, int(m.GetPartnerID(userInfo.ID)))
+	}
+
+	partners, err := f.storeUser.GetUserListByIDs(partnerIDs)
+	if err != nil {
+		return MatchListServiceInfo{}, err
+	}
+
+	partnerByID := make(map[uint]models.User, len(partners))
+	for _, p := range partners {
+		partnerByID[p.ID] = p
+	}
+
+	for _, m := range matches {
+		// skip the match if the partner account is already deleted
+		partnerInfo, ok := partnerByID[m.GetPartnerID(userInfo.ID)]
+		if !ok {
+			continue
+		}
+
+		result.Matches = append(result.Matches, MatchServiceInfo{
+			MatchID:     int(m.ID),
+			Partner:     mapPartnerServiceInfo(userInfo, partnerInfo, m.Status.String()),
+			MatchedDate: m.MatchedAt.String(),
+		})
+	}
+
+	return result, nil
+}
+
+// UnmatchPartner is func to move the match of the user and partner into terminal state
+func (f PartnerService) UnmatchPartner(request UnmatchServiceRequest) error {
+	if request.PartnerID <= 0 || request.PartnerID == request.UserID {
+		return ErrMatchNotFound
+	}
+
+	err := f.storeMatch.Unmatch(request.UserID, request.PartnerID)
+	if gorm.IsRecordNotFoundError(err) {
+		return ErrMatchNotFound
+	}
+
+	return err
+}
@@ -0,0 +1,268 @@
+package partner
+
+import (
+	"fmt"
+	"gilsaputro/dating-apps/internal/store/match"
+	mock_match "gilsaputro/dating-apps/internal/store/match/mock"
+	mock_user "gilsaputro/dating-apps/internal/store/user/mock"
+	"gilsaputro/dating-apps/models"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+)
+
+func TestPartnerService_GetListMatch(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
+	mStore := mock_match.NewMockMatchStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	matchedAt := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+	type args struct {
+		request MatchListServiceRequest
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		want     MatchListServiceInfo
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				mStore.EXPECT().CountByUserID(2).Return(3, nil)
+				mStore.EXPECT().GetMatchListByUserID(match.MatchFilter{
+					UserID: 2,
+					Limit:  DefaultMatchPageLimit,
+					Offset: 0,
+				}).Return([]models.Match{
+					{Model: gorm.Model{ID: 10}, UserID: 1, PartnerID: 2, Status: models.MatchStatusApproved, MatchedAt: matchedAt},
+					{Model: gorm.Model{ID: 11}, UserID: 2, PartnerID: 3, Status: models.MatchStatusApproved, MatchedAt: matchedAt},
+					{Model: gorm.Model{ID: 12}, UserID: 2, PartnerID: 4, Status: models.MatchStatusApproved, MatchedAt: matchedAt},
+				}, nil)
+				uStore.EXPECT().GetUserInfoByID(2).Return(models.User{Model: gorm.Model{ID: 2}}, nil)
+				uStore.EXPECT().GetUserListByIDs([]int{1, 3, 4}).Return([]models.User{
+					{Model: gorm.Model{ID: 1}, Fullname: "P1"},
+					{Model: gorm.Model{ID: 3}, Fullname: "P3", Plan: models.PlanPremium},
+				}, nil)
+			},
+			args: args{
+				request: MatchListServiceRequest{
+					UserID: 2,
+				},
+			},
+			want: MatchListServiceInfo{
+				Matches: []MatchServiceInfo{
+					{
+						MatchID: 10,
+						Partner: PartnerServiceInfo{
+							PartnerID:   1,
+							Fullname:    "P1",
+							Status:      "APPROVED",
+							CreatedDate: time.Time{}.String(),
+						},
+						MatchedDate: matchedAt.String(),
+					},
+					{
+						MatchID: 11,
+						Partner: PartnerServiceInfo{
+							PartnerID:   3,
+							Fullname:    "P3",
+							IsPremium:   true,
+							Status:      "APPROVED",
+							CreatedDate: time.Time{}.String(),
+						},
+						MatchedDate: matchedAt.String(),
+					},
+				},
+				Page:  1,
+				Limit: DefaultMatchPageLimit,
+				Total: 3,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success page out of range",
+			mockFunc: func() {
+				mStore.EXPECT().CountByUserID(2).Return(3, nil)
+			},
+			args: args{
+				request: MatchListServiceRequest{
+					UserID: 2,
+					Page:   2,
+					Limit:  100,
+				},
+			},
+			want: MatchListServiceInfo{
+				Matches: []MatchServiceInfo{},
+				Page:    2,
+				Limit:   MaxMatchPageLimit,
+				Total:   3,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on get partner list",
+			mockFunc: func() {
+				mStore.EXPECT().CountByUserID(2).Return(1, nil)
+				mStore.EXPECT().GetMatchListByUserID(gomock.Any()).Return([]models.Match{
+					{Model: gorm.Model{ID: 10}, UserID: 1, PartnerID: 2, Status: models.MatchStatusApproved},
+				}, nil)
+				uStore.EXPECT().GetUserInfoByID(2).Return(models.User{Model: gorm.Model{ID: 2}}, nil)
+				uStore.EXPECT().GetUserListByIDs([]int{1}).Return(nil, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: MatchListServiceRequest{
+					UserID: 2,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on get user info",
+			mockFunc: func() {
+				mStore.EXPECT().CountByUserID(2).Return(1, nil)
+				mStore.EXPECT().GetMatchListByUserID(gomock.Any()).Return([]models.Match{
+					{Model: gorm.Model{ID: 10}, UserID: 1, PartnerID: 2, Status: models.MatchStatusApproved},
+				}, nil)
+				uStore.EXPECT().GetUserInfoByID(2).Return(models.User{}, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: MatchListServiceRequest{
+					UserID: 2,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on get match list",
+			mockFunc: func() {
+				mStore.EXPECT().CountByUserID(2).Return(1, nil)
+				mStore.EXPECT().GetMatchListByUserID(gomock.Any()).Return(nil, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: MatchListServiceRequest{
+					UserID: 2,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on count match",
+			mockFunc: func() {
+				mStore.EXPECT().CountByUserID(2).Return(0, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: MatchListServiceRequest{
+					UserID: 2,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := PartnerService{
+				storeUser:  uStore,
+				storeMatch: mStore,
+			}
+			tt.mockFunc()
+			got, err := s.GetListMatch(tt.args.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PartnerService.GetListMatch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PartnerService.GetListMatch() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPartnerService_UnmatchPartner(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mStore := mock_match.NewMockMatchStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		request UnmatchServiceRequest
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		wantErr  bool
+		err      error
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				mStore.EXPECT().Unmatch(1, 2).Return(nil)
+			},
+			args: args{
+				request: UnmatchServiceRequest{
+					UserID:    1,
+					PartnerID: 2,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error match not found",
+			mockFunc: func() {
+				mStore.EXPECT().Unmatch(1, 2).Return(gorm.ErrRecordNotFound)
+			},
+			args: args{
+				request: UnmatchServiceRequest{
+					UserID:    1,
+					PartnerID: 2,
+				},
+			},
+			wantErr: true,
+			err:     ErrMatchNotFound,
+		},
+		{
+			name: "error on unmatch",
+			mockFunc: func() {
+				mStore.EXPECT().Unmatch(1, 2).Return(fmt.Errorf("some error"))
+			},
+			args: args{
+				request: UnmatchServiceRequest{
+					UserID:    1,
+					PartnerID: 2,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:     "error unmatch self",
+			mockFunc: func() {},
+			args: args{
+				request: UnmatchServiceRequest{
+					UserID:    1,
+					PartnerID: 1,
+				},
+			},
+			wantErr: true,
+			err:     ErrMatchNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := PartnerService{
+				storeMatch: mStore,
+			}
+			tt.mockFunc()
+			err := s.UnmatchPartner(tt.args.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PartnerService.UnmatchPartner() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.err != nil && err != tt.err {
+				t.Errorf("PartnerService.UnmatchPartner() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
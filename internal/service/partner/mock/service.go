@@ -50,10 +50,10 @@ func (mr *MockPartnerServiceMethodMockRecorder) GetCurrentPartner(request interf
 }
 
 // GetListLikedPartner mocks base method.
-func (m *MockPartnerServiceMethod) GetListLikedPartner(request partner.PartnerServiceRequest) ([]partner.PartnerServiceInfo, error) {
+func (m *MockPartnerServiceMethod) GetListLikedPartner(request partner.HistoryServiceRequest) (partner.HistoryServiceInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetListLikedPartner", request)
-	ret0, _ := ret[0].([]partner.PartnerServiceInfo)
+	ret0, _ := ret[0].(partner.HistoryServiceInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -64,6 +64,51 @@ func (mr *MockPartnerServiceMethodMockRecorder) GetListLikedPartner(request inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListLikedPartner", reflect.TypeOf((*MockPartnerServiceMethod)(nil).GetListLikedPartner), request)
 }
 
+// GetListLikesReceived mocks base method.
+func (m *MockPartnerServiceMethod) GetListLikesReceived(request partner.PartnerServiceRequest) (partner.LikesReceivedServiceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListLikesReceived", request)
+	ret0, _ := ret[0].(partner.LikesReceivedServiceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListLikesReceived indicates an expected call of GetListLikesReceived.
+func (mr *MockPartnerServiceMethodMockRecorder) GetListLikesReceived(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListLikesReceived", reflect.TypeOf((*MockPartnerServiceMethod)(nil).GetListLikesReceived), request)
+}
+
+// GetListMatch mocks base method.
+func (m *MockPartnerServiceMethod) GetListMatch(request partner.MatchListServiceRequest) (partner.MatchListServiceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListMatch", request)
+	ret0, _ := ret[0].(partner.MatchListServiceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListMatch indicates an expected call of GetListMatch.
+func (mr *MockPartnerServiceMethodMockRecorder) GetListMatch(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListMatch", reflect.TypeOf((*MockPartnerServiceMethod)(nil).GetListMatch), request)
+}
+
+// GetListPassedPartner mocks base method.
+func (m *MockPartnerServiceMethod) GetListPassedPartner(request partner.HistoryServiceRequest) (partner.HistoryServiceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListPassedPartner", request)
+	ret0, _ := ret[0].(partner.HistoryServiceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListPassedPartner indicates an expected call of GetListPassedPartner.
+func (mr *MockPartnerServiceMethodMockRecorder) GetListPassedPartner(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListPassedPartner", reflect.TypeOf((*MockPartnerServiceMethod)(nil).GetListPassedPartner), request)
+}
+
 // LikePartner mocks base method.
 func (m *MockPartnerServiceMethod) LikePartner(request partner.PartnerServiceRequest) error {
 	m.ctrl.T.Helper()
@@ -92,3 +137,46 @@ func (mr *MockPartnerServiceMethodMockRecorder) PassPartner(request interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PassPartner", reflect.TypeOf((*MockPartnerServiceMethod)(nil).PassPartner), request)
 }
+
+// RewindPartner mocks base method.
+func (m *MockPartnerServiceMethod) RewindPartner(request partner.PartnerServiceRequest) (partner.PartnerServiceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RewindPartner", request)
+	ret0, _ := ret[0].(partner.PartnerServiceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RewindPartner indicates an expected call of RewindPartner.
+func (mr *MockPartnerServiceMethodMockRecorder) RewindPartner(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RewindPartner", reflect.TypeOf((*MockPartnerServiceMethod)(nil).RewindPartner), request)
+}
+
+// SuperLikePartner mocks base method.
+func (m *MockPartnerServiceMethod) SuperLikePartner(request partner.PartnerServiceRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuperLikePartner", request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SuperLikePartner indicates an expected call of SuperLikePartner.
+func (mr *MockPartnerServiceMethodMockRecorder) SuperLikePartner(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuperLikePartner", reflect.TypeOf((*MockPartnerServiceMethod)(nil).SuperLikePartner), request)
+}
+
+// UnmatchPartner mocks base method.
+func (m *MockPartnerServiceMethod) UnmatchPartner(request partner.UnmatchServiceRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmatchPartner", request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmatchPartner indicates an expected call of UnmatchPartner.
+func (mr *MockPartnerServiceMethodMockRecorder) UnmatchPartner(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmatchPartner", reflect.TypeOf((*MockPartnerServiceMethod)(nil).UnmatchPartner), request)
+}
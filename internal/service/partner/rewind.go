@@ -0,0 +1,76 @@
+package partner
+
+import (
+	"fmt"
+	"gilsaputro/dating-apps/models"
+)
+
+// RewindPartner is func to undo the last swipe decision when the plan is entitled and restore the partner as current partner
+func (f PartnerService) RewindPartner(request PartnerServiceRequest) (PartnerServiceInfo, error) {
+	if !models.GetEntitlements(request.Plan).Rewind {
+		return PartnerServiceInfo{}, ErrRewindNotAllowed
+	}
+
+	userID := fmt.Sprintf("%v", request.UserID)
+	last, err := f.cache.GetLastDecision(userID)
+	if err != nil {
+		return PartnerServiceInfo{}, err
+	}
+
+	decision, partnerID := last.Decision, last.PartnerID
+
+	if partnerID <= 0 {
+		return PartnerServiceInfo{}, ErrNothingToRewind
+	}
+
+	switch decision {
+	case models.DecisionPass:
+	case models.DecisionLike, models.DecisionSuperLike:
+		// the like cannot be undone if the partner already like the user back
+		count, err := f.storeHist.CountByUserIDAndPartnerID(partnerID, request.UserID)
+		if err != nil {
+			return PartnerServiceInfo{}, err
+		}
+
+		if count > 0 {
+			return PartnerServiceInfo{}, ErrRewindMatchedPartner
+		}
+	default:
+		return PartnerServiceInfo{}, ErrNothingToRewind
+	}
+
+	err = f.storeHist.DeleteUserHistory(request.UserID, partnerID, decision)
+	if err != nil {
+		return PartnerServiceInfo{}, err
+	}
+
+	// the rewound super like is given back to the daily allowance, the super like of yesterday is counted in the old counter
+	if decision == models.DecisionSuperLike && last.IsToday() {
+		f.refundSuperLike(userID)
+	}
+
+	err = f.cache.SetCurentPartnerState(request.UserID, partnerID)
+	if err != nil {
+		return PartnerServiceInfo{}, err
+	}
+
+	// only the last decision can be rewound
+	err = f.cache.DeleteLastDecision(userID)
+	if err != nil {
+		return PartnerServiceInfo{}, err
+	}
+
+	userInfo, err := f.storeUser.GetUserInfoByID(request.UserID)
+	if err != nil {
+		return PartnerServiceInfo{}, err
+	}
+
+	partnerInfo, err := f.storeUser.GetUserInfoByID(partnerID)
+	if err != nil {
+		return PartnerServiceInfo{}, err
+	}
+
+	result := mapPartnerServiceInfo(userInfo, partnerInfo, f.getPartnerStatus(request.UserID, partnerID))
+	result.IsSuperLiked = f.isSuperLikedBy(request.UserID, partnerID)
+	return result, nil
+}
@@ -0,0 +1,211 @@
+package partner
+
+import (
+	"fmt"
+	"gilsaputro/dating-apps/internal/store/partnercache"
+	mock_partner "gilsaputro/dating-apps/internal/store/partnercache/mock"
+	mock_user "gilsaputro/dating-apps/internal/store/user/mock"
+	mock_userhist "gilsaputro/dating-apps/internal/store/userhistory/mock"
+	"gilsaputro/dating-apps/models"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+)
+
+func TestPartnerService_RewindPartner(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
+	hStore := mock_userhist.NewMockUserHistoryStoreMethod(mockCtrl)
+	pStore := mock_partner.NewMockPartnerCacheStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	today := time.Now().Format("20060102")
+	type args struct {
+		request PartnerServiceRequest
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		want     PartnerServiceInfo
+		wantErr  error
+	}{
+		{
+			name: "success rewind pass",
+			mockFunc: func() {
+				pStore.EXPECT().GetLastDecision("1").Return(partnercache.LastDecision{Decision: models.DecisionPass, PartnerID: 4, Date: today}, nil)
+				hStore.EXPECT().DeleteUserHistory(1, 4, models.DecisionPass).Return(nil)
+				pStore.EXPECT().SetCurentPartnerState(1, 4).Return(nil)
+				pStore.EXPECT().DeleteLastDecision("1").Return(nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				uStore.EXPECT().GetUserInfoByID(4).Return(models.User{Model: gorm.Model{ID: 4}, Fullname: "P4"}, nil)
+				hStore.EXPECT().CountByUserIDAndPartnerID(1, 4).Return(0, nil)
+				hStore.EXPECT().GetSuperLikerIDsByPartnerID(1).Return([]int{}, nil)
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				},
+			},
+			want: PartnerServiceInfo{
+				PartnerID:   4,
+				Fullname:    "P4",
+				Status:      "PENDING",
+				CreatedDate: "0001-01-01 00:00:00 +0000 UTC",
+			},
+		},
+		{
+			name: "success rewind pending like",
+			mockFunc: func() {
+				pStore.EXPECT().GetLastDecision("1").Return(partnercache.LastDecision{Decision: models.DecisionLike, PartnerID: 4, Date: today}, nil)
+				hStore.EXPECT().CountByUserIDAndPartnerID(4, 1).Return(0, nil)
+				hStore.EXPECT().DeleteUserHistory(1, 4, models.DecisionLike).Return(nil)
+				pStore.EXPECT().SetCurentPartnerState(1, 4).Return(nil)
+				pStore.EXPECT().DeleteLastDecision("1").Return(nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				uStore.EXPECT().GetUserInfoByID(4).Return(models.User{Model: gorm.Model{ID: 4}, Fullname: "P4"}, nil)
+				hStore.EXPECT().CountByUserIDAndPartnerID(1, 4).Return(0, nil)
+				hStore.EXPECT().GetSuperLikerIDsByPartnerID(1).Return([]int{}, nil)
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				},
+			},
+			want: PartnerServiceInfo{
+				PartnerID:   4,
+				Fullname:    "P4",
+				Status:      "PENDING",
+				CreatedDate: "0001-01-01 00:00:00 +0000 UTC",
+			},
+		},
+		{
+			name: "success rewind super like and refund the allowance",
+			mockFunc: func() {
+				pStore.EXPECT().GetLastDecision("1").Return(partnercache.LastDecision{Decision: models.DecisionSuperLike, PartnerID: 4, Date: today}, nil)
+				hStore.EXPECT().CountByUserIDAndPartnerID(4, 1).Return(0, nil)
+				hStore.EXPECT().DeleteUserHistory(1, 4, models.DecisionSuperLike).Return(nil)
+				pStore.EXPECT().GetSuperLikeCounter("1").Return("2", nil)
+				pStore.EXPECT().SetSuperLikeCounter("1", "1").Return(nil)
+				pStore.EXPECT().SetCurentPartnerState(1, 4).Return(nil)
+				pStore.EXPECT().DeleteLastDecision("1").Return(nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				uStore.EXPECT().GetUserInfoByID(4).Return(models.User{Model: gorm.Model{ID: 4}, Fullname: "P4"}, nil)
+				hStore.EXPECT().CountByUserIDAndPartnerID(1, 4).Return(0, nil)
+				hStore.EXPECT().GetSuperLikerIDsByPartnerID(1).Return([]int{}, nil)
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				},
+			},
+			want: PartnerServiceInfo{
+				PartnerID:   4,
+				Fullname:    "P4",
+				Status:      "PENDING",
+				CreatedDate: "0001-01-01 00:00:00 +0000 UTC",
+			},
+		},
+		{
+			name: "success rewind super like of yesterday without refund",
+			mockFunc: func() {
+				yesterday := time.Now().AddDate(0, 0, -1).Format("20060102")
+				pStore.EXPECT().GetLastDecision("1").Return(partnercache.LastDecision{Decision: models.DecisionSuperLike, PartnerID: 4, Date: yesterday}, nil)
+				hStore.EXPECT().CountByUserIDAndPartnerID(4, 1).Return(0, nil)
+				hStore.EXPECT().DeleteUserHistory(1, 4, models.DecisionSuperLike).Return(nil)
+				pStore.EXPECT().SetCurentPartnerState(1, 4).Return(nil)
+				pStore.EXPECT().DeleteLastDecision("1").Return(nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				uStore.EXPECT().GetUserInfoByID(4).Return(models.User{Model: gorm.Model{ID: 4}, Fullname: "P4"}, nil)
+				hStore.EXPECT().CountByUserIDAndPartnerID(1, 4).Return(0, nil)
+				hStore.EXPECT().GetSuperLikerIDsByPartnerID(1).Return([]int{}, nil)
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				},
+			},
+			want: PartnerServiceInfo{
+				PartnerID:   4,
+				Fullname:    "P4",
+				Status:      "PENDING",
+				CreatedDate: "0001-01-01 00:00:00 +0000 UTC",
+			},
+		},
+		{
+			name: "error like already matched",
+			mockFunc: func() {
+				pStore.EXPECT().GetLastDecision("1").Return(partnercache.LastDecision{Decision: models.DecisionLike, PartnerID: 4, Date: today}, nil)
+				hStore.EXPECT().CountByUserIDAndPartnerID(4, 1).Return(1, nil)
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				},
+			},
+			wantErr: ErrRewindMatchedPartner,
+		},
+		{
+			name: "error nothing to rewind",
+			mockFunc: func() {
+				pStore.EXPECT().GetLastDecision("1").Return(partnercache.LastDecision{}, nil)
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				},
+			},
+			wantErr: ErrNothingToRewind,
+		},
+		{
+			name:     "error plan does not allow rewind",
+			mockFunc: func() {},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanFree,
+				},
+			},
+			wantErr: ErrRewindNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := PartnerService{
+				storeUser: uStore,
+				storeHist: hStore,
+				cache:     pStore,
+			}
+			tt.mockFunc()
+			got, err := s.RewindPartner(tt.args.request)
+			if err != tt.wantErr {
+				t.Errorf("PartnerService.RewindPartner() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PartnerService.RewindPartner() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("error on delete history", func(t *testing.T) {
+		s := PartnerService{
+			storeUser: uStore,
+			storeHist: hStore,
+			cache:     pStore,
+		}
+		pStore.EXPECT().GetLastDecision("1").Return(partnercache.LastDecision{Decision: models.DecisionPass, PartnerID: 4, Date: today}, nil)
+		hStore.EXPECT().DeleteUserHistory(1, 4, models.DecisionPass).Return(fmt.Errorf("some error"))
+		if _, err := s.RewindPartner(PartnerServiceRequest{UserID: 1, Plan: models.PlanPremium}); err == nil {
+			t.Errorf("PartnerService.RewindPartner() expect error")
+		}
+	})
+}
@@ -2,50 +2,82 @@ package partner
 
 import (
 	"fmt"
+	"gilsaputro/dating-apps/internal/service/realtime"
+	"gilsaputro/dating-apps/internal/store/block"
+	"gilsaputro/dating-apps/internal/store/match"
 	"gilsaputro/dating-apps/internal/store/partnercache"
 	"gilsaputro/dating-apps/internal/store/user"
 	"gilsaputro/dating-apps/internal/store/userhistory"
 	"gilsaputro/dating-apps/models"
-	"math/rand"
+	"log"
+	"math"
 	"strconv"
-	"strings"
 	"time"
+
+	"github.com/jinzhu/gorm"
 )
 
 // PartnerServiceMethod is list method for Partner Service
 type PartnerServiceMethod interface {
 	LikePartner(request PartnerServiceRequest) error
+	SuperLikePartner(request PartnerServiceRequest) error
 	PassPartner(request PartnerServiceRequest) (PartnerServiceInfo, error)
 	GetCurrentPartner(request PartnerServiceRequest) (PartnerServiceInfo, error)
-	GetListLikedPartner(request PartnerServiceRequest) ([]PartnerServiceInfo, error)
+	GetListLikedPartner(request HistoryServiceRequest) (HistoryServiceInfo, error)
+	GetListPassedPartner(request HistoryServiceRequest) (HistoryServiceInfo, error)
+	GetListLikesReceived(request PartnerServiceRequest) (LikesReceivedServiceInfo, error)
+	RewindPartner(request PartnerServiceRequest) (PartnerServiceInfo, error)
+	GetListMatch(request MatchListServiceRequest) (MatchListServiceInfo, error)
+	UnmatchPartner(request UnmatchServiceRequest) error
 }
 
 // PartnerService is list dependencies for Partner service
 type PartnerService struct {
 	storeUser  user.UserStoreMethod
 	storeHist  userhistory.UserHistoryStoreMethod
+	storeMatch match.MatchStoreMethod
+	storeBlock block.BlockStoreMethod
 	cache      partnercache.PartnerCacheStoreMethod
+	realtime   realtime.RealtimeServiceMethod
 	maxCounter int
+	// passCooldown is the duration before a passed partner can be offered again
+	passCooldown       time.Duration
+	superLikeAllowance SuperLikeAllowance
 }
 
 // NewPartnerService is func to generate PartnerServiceMethod interface
-func NewPartnerService(storeUser user.UserStoreMethod, storeHist userhistory.UserHistoryStoreMethod, cache partnercache.PartnerCacheStoreMethod, maxCounter int) PartnerServiceMethod {
+func NewPartnerService(storeUser user.UserStoreMethod, storeHist userhistory.UserHistoryStoreMethod, storeMatch match.MatchStoreMethod, storeBlock block.BlockStoreMethod, cache partnercache.PartnerCacheStoreMethod, realtime realtime.RealtimeServiceMethod, maxCounter int, passCooldown time.Duration, superLikeAllowance SuperLikeAllowance) PartnerServiceMethod {
 	if maxCounter <= 0 {
 		maxCounter = 10
 	}
+	if passCooldown <= 0 {
+		passCooldown = defaultPassCooldown
+	}
+	if superLikeAllowance.Default <= 0 {
+		superLikeAllowance.Default = defaultSuperLikeAllowance
+	}
+	if superLikeAllowance.Premium <= 0 {
+		superLikeAllowance.Premium = defaultPremiumSuperLikeAllowance
+	}
 	return &PartnerService{
-		storeHist:  storeHist,
-		storeUser:  storeUser,
-		cache:      cache,
-		maxCounter: maxCounter,
+		storeHist:          storeHist,
+		storeUser:          storeUser,
+		storeMatch:         storeMatch,
+		storeBlock:         storeBlock,
+		cache:              cache,
+		realtime:           realtime,
+		maxCounter:         maxCounter,
+		passCooldown:       passCooldown,
+		superLikeAllowance: superLikeAllowance,
 	}
 }
 
 func (f PartnerService) PassPartner(request PartnerServiceRequest) (PartnerServiceInfo, error) {
 	userID := fmt.Sprintf("%v", request.UserID)
 	var numCounter = 0
-	// check max counter swipe if the user is not verified
-	if !request.IsVerified {
+	// check max counter swipe if the plan does not have unlimited swipe
+	isUnlimitedSwipe := models.GetEntitlements(request.Plan).UnlimitedSwipe
+	if !isUnlimitedSwipe {
 		counter, err := f.cache.GetViewedUserCounter(userID)
 		if err != nil {
 			return PartnerServiceInfo{}, err
@@ -57,12 +89,17 @@ func (f PartnerService) PassPartner(request PartnerServiceRequest) (PartnerServi
 		}
 	}
 
-	newPartnerID, err := f.generateNewPartner(request)
+	userInfo, err := f.storeUser.GetUserInfoByID(request.UserID)
+	if err != nil {
+		return PartnerServiceInfo{}, err
+	}
+
+	err = f.passCurrentPartner(request)
 	if err != nil {
 		return PartnerServiceInfo{}, err
 	}
 
-	PartnerInfo, err := f.storeUser.GetUserInfoByID(newPartnerID)
+	PartnerInfo, err := f.generateNewPartner(request, userInfo)
 	if err != nil {
 		return PartnerServiceInfo{}, err
 	}
@@ -70,86 +107,96 @@ func (f PartnerService) PassPartner(request PartnerServiceRequest) (PartnerServi
 	status := f.getPartnerStatus(request.UserID, int(PartnerInfo.ID))
 
 	// Set NewPartnerID to History and Add Counter
-	if !request.IsVerified && status == "PENDING" {
+	if !isUnlimitedSwipe && status == "PENDING" {
 		numCounter++
 		f.cache.SetViewedUserCounter(userID, fmt.Sprintf("%d", numCounter))
 	}
 
-	return PartnerServiceInfo{
-		PartnerID:   int(PartnerInfo.ID),
-		Fullname:    PartnerInfo.Fullname,
-		IsVerified:  PartnerInfo.IsVerified,
-		Status:      status,
-		CreatedDate: PartnerInfo.CreatedAt.String(),
-	}, nil
+	result := mapPartnerServiceInfo(userInfo, PartnerInfo, status)
+	result.IsSuperLiked = f.isSuperLikedBy(request.UserID, int(PartnerInfo.ID))
+	return result, nil
 }
 
-func (f PartnerService) generateNewPartner(request PartnerServiceRequest) (int, error) {
-	userID := fmt.Sprintf("%v", request.UserID)
-	// Get Total User
-	totalUser, err := f.storeUser.Count()
+// passCurrentPartner is func to store pass decision of the current partner if the user has not liked the partner
+func (f PartnerService) passCurrentPartner(request PartnerServiceRequest) error {
+	currentPartnerID, err := f.cache.GetCurentPartnerState(fmt.Sprintf("%v", request.UserID))
 	if err != nil {
-		return 0, err
+		return err
 	}
 
-	// Get User Partner History to de-duplicate generate same partner
-	partnerHistory, err := f.cache.GetViewedPartnerHistory(userID)
-	if err != nil {
-		return 0, err
+	partnerID, err := strconv.Atoi(currentPartnerID)
+	if err != nil || partnerID <= 0 {
+		return nil
 	}
 
-	var listPartnerHistoryInt []int
-	listPartnerHistory := strings.Split(partnerHistory, ",")
-	if len(listPartnerHistory) < totalUser {
-		for _, str := range listPartnerHistory {
-			// Konversi string ke integer
-			num, _ := strconv.Atoi(str)
-			// Menambahkan integer ke slice integer
-			listPartnerHistoryInt = append(listPartnerHistoryInt, num)
-		}
+	count, err := f.storeHist.CountByUserIDAndPartnerID(request.UserID, partnerID)
+	if err != nil {
+		return err
 	}
-	excludePartnerID := append(listPartnerHistoryInt, request.UserID)
-	newPartnerID := generateRandomNumber(totalUser, excludePartnerID)
 
-	if len(listPartnerHistoryInt) > 10 {
-		listPartnerHistoryInt = listPartnerHistoryInt[1:]
+	if count > 0 {
+		return nil
 	}
-	listPartnerHistoryInt = append(listPartnerHistoryInt, newPartnerID)
 
-	newPartnerHistory := make([]string, len(listPartnerHistoryInt))
-	for i, v := range listPartnerHistoryInt {
-		newPartnerHistory[i] = fmt.Sprint(v)
+	partnerInfo, err := f.storeUser.GetUserInfoByID(partnerID)
+	if err != nil {
+		// the partner is no longer exists, so there is nothing to pass
+		if gorm.IsRecordNotFoundError(err) {
+			return nil
+		}
+		return err
 	}
 
-	err = f.cache.SetViewedPartnerHistory(userID, strings.Join(newPartnerHistory, ","))
+	err = f.storeHist.SavePassHistory(models.UserMatchHistory{
+		UserID:      uint(request.UserID),
+		PartnerID:   partnerInfo.ID,
+		PartnerName: partnerInfo.Fullname,
+		Decision:    models.DecisionPass,
+	})
 	if err != nil {
-		return 0, err
+		return err
 	}
-	err = f.cache.SetCurentPartnerState(request.UserID, newPartnerID)
+
+	f.cache.SetLastDecision(fmt.Sprintf("%v", request.UserID), models.DecisionPass, partnerID)
+	return nil
+}
+
+// generateNewPartner is func to pick the top candidate from the feed and store it as current partner
+func (f PartnerService) generateNewPartner(request PartnerServiceRequest, userInfo models.User) (models.User, error) {
+	userID := fmt.Sprintf("%v", request.UserID)
+
+	// Get User Partner History to de-duplicate generate same partner
+	partnerHistory, err := f.cache.GetViewedPartnerHistory(userID)
 	if err != nil {
-		return 0, err
+		return models.User{}, err
 	}
+	viewedPartnerIDs := parsePartnerHistory(partnerHistory)
 
-	return newPartnerID, err
-}
+	feed, err := f.getCandidateFeed(userInfo, viewedPartnerIDs)
+	if err != nil {
+		return models.User{}, err
+	}
 
-func generateRandomNumber(max int, exclude []int) int {
-	rand.Seed(time.Now().UnixNano())
+	if len(feed) == 0 {
+		return models.User{}, ErrNoPartnerAvailable
+	}
+	newPartner := feed[0]
 
-	excludeMap := make(map[int]bool)
-	for _, num := range exclude {
-		excludeMap[num] = true
+	viewedPartnerIDs = append(viewedPartnerIDs, int(newPartner.ID))
+	if len(viewedPartnerIDs) > maxViewedPartnerHistory {
+		viewedPartnerIDs = viewedPartnerIDs[len(viewedPartnerIDs)-maxViewedPartnerHistory:]
 	}
 
-	var randomNumber int
-	for {
-		randomNumber = rand.Intn(max) + 1
-		if !excludeMap[randomNumber] {
-			break
-		}
+	err = f.cache.SetViewedPartnerHistory(userID, formatPartnerHistory(viewedPartnerIDs))
+	if err != nil {
+		return models.User{}, err
+	}
+	err = f.cache.SetCurentPartnerState(request.UserID, int(newPartner.ID))
+	if err != nil {
+		return models.User{}, err
 	}
 
-	return randomNumber
+	return newPartner, nil
 }
 
 func (f PartnerService) getPartnerStatus(userID int, partnerID int) string {
@@ -165,10 +212,25 @@ func (f PartnerService) getPartnerStatus(userID int, partnerID int) string {
 	return status
 }
 
+// isSuperLikedBy is func to check whether the partner super liked the user
+func (f PartnerService) isSuperLikedBy(userID int, partnerID int) bool {
+	superLikerIDs, err := f.storeHist.GetSuperLikerIDsByPartnerID(userID)
+	if err != nil {
+		return false
+	}
+
+	for _, id := range superLikerIDs {
+		if id == partnerID {
+			return true
+		}
+	}
+	return false
+}
+
 func (f PartnerService) GetCurrentPartner(request PartnerServiceRequest) (PartnerServiceInfo, error) {
 	userID := fmt.Sprintf("%v", request.UserID)
 
-	if !request.IsVerified {
+	if !models.GetEntitlements(request.Plan).UnlimitedSwipe {
 		c, err := f.cache.GetViewedUserCounter(userID)
 		if err != nil {
 			return PartnerServiceInfo{}, err
@@ -184,6 +246,11 @@ func (f PartnerService) GetCurrentPartner(request PartnerServiceRequest) (Partne
 		}
 	}
 
+	userInfo, err := f.storeUser.GetUserInfoByID(request.UserID)
+	if err != nil {
+		return PartnerServiceInfo{}, err
+	}
+
 	newPartnerID, err := f.cache.GetCurentPartnerState(userID)
 	if err != nil {
 		return PartnerServiceInfo{}, err
@@ -194,31 +261,70 @@ func (f PartnerService) GetCurrentPartner(request PartnerServiceRequest) (Partne
 		partnerID = 0
 	}
 
-	// generate if the current is not state (should be for first time user)
-	if partnerID <= 0 {
-		partnerID, err = f.generateNewPartner(request)
+	var PartnerInfo models.User
+	if partnerID > 0 {
+		PartnerInfo, err = f.storeUser.GetUserInfoByID(partnerID)
+		if err != nil && !gorm.IsRecordNotFoundError(err) {
+			return PartnerServiceInfo{}, err
+		}
+	}
+
+	// the current partner is replaced when one of the user block the other after the partner is generated
+	if PartnerInfo.ID > 0 {
+		isBlocked, err := f.storeBlock.IsBlocked(request.UserID, int(PartnerInfo.ID))
 		if err != nil {
 			return PartnerServiceInfo{}, err
 		}
+
+		if isBlocked {
+			PartnerInfo = models.User{}
+		}
 	}
 
-	PartnerInfo, err := f.storeUser.GetUserInfoByID(partnerID)
-	if err != nil {
-		return PartnerServiceInfo{}, err
+	// generate if the current is not state (should be for first time user), the partner is no longer exists or blocked
+	if PartnerInfo.ID <= 0 {
+		PartnerInfo, err = f.generateNewPartner(request, userInfo)
+		if err != nil {
+			return PartnerServiceInfo{}, err
+		}
 	}
 
 	status := f.getPartnerStatus(request.UserID, int(PartnerInfo.ID))
 
-	return PartnerServiceInfo{
-		PartnerID:   int(PartnerInfo.ID),
-		Fullname:    PartnerInfo.Fullname,
-		IsVerified:  PartnerInfo.IsVerified,
+	result := mapPartnerServiceInfo(userInfo, PartnerInfo, status)
+	result.IsSuperLiked = f.isSuperLikedBy(request.UserID, int(PartnerInfo.ID))
+	return result, nil
+}
+
+// mapPartnerServiceInfo is func to convert partner info into partner service info
+func mapPartnerServiceInfo(userInfo models.User, partnerInfo models.User, status string) PartnerServiceInfo {
+	info := PartnerServiceInfo{
+		PartnerID:   int(partnerInfo.ID),
+		Fullname:    partnerInfo.Fullname,
+		IsPremium:   partnerInfo.IsPremium(),
+		IsVerified:  partnerInfo.IsIdentityVerified,
 		Status:      status,
-		CreatedDate: PartnerInfo.CreatedAt.String(),
-	}, nil
+		CreatedDate: partnerInfo.CreatedAt.String(),
+	}
+
+	if distance, ok := partnerDistance(userInfo, partnerInfo); ok {
+		rounded := int(math.Round(distance))
+		info.Distance = &rounded
+	}
+
+	return info
 }
 
 func (f PartnerService) LikePartner(request PartnerServiceRequest) error {
+	return f.likeCurrentPartner(request, models.DecisionLike)
+}
+
+// likeCurrentPartner is func to store like decision of the current partner and create the match if the partner already like the user
+func (f PartnerService) likeCurrentPartner(request PartnerServiceRequest, decision models.DecisionType) error {
+	if !request.IsEmailVerified {
+		return ErrEmailNotVerified
+	}
+
 	userID := fmt.Sprintf("%v", request.UserID)
 	partnerID, err := f.cache.GetCurentPartnerState(userID)
 	if err != nil {
@@ -233,25 +339,23 @@ func (f PartnerService) LikePartner(request PartnerServiceRequest) error {
 		return ErrCurrentPartnerIsMissing
 	}
 
-	count, err := f.storeHist.CountByUserIDAndPartnerID(request.UserID, intPartnerID)
+	isBlocked, err := f.storeBlock.IsBlocked(request.UserID, intPartnerID)
 	if err != nil {
 		return err
 	}
 
-	// user already like the partner
-	if count > 0 {
-		return ErrUserAlreadyLikePartner
+	if isBlocked {
+		return ErrPartnerIsBlocked
 	}
 
-	// check if the partner like user
-	count, err = f.storeHist.CountByUserIDAndPartnerID(intPartnerID, request.UserID)
+	count, err := f.storeHist.CountByUserIDAndPartnerID(request.UserID, intPartnerID)
 	if err != nil {
 		return err
 	}
 
-	status := models.MatchStatusPending
+	// user already like the partner
 	if count > 0 {
-		status = models.MatchStatusApproved
+		return ErrUserAlreadyLikePartner
 	}
 
 	partnerInfo, err := f.storeUser.GetUserInfoByID(intPartnerID)
@@ -259,37 +363,50 @@ func (f PartnerService) LikePartner(request PartnerServiceRequest) error {
 		return err
 	}
 
-	f.storeHist.CreateUserHistory(models.UserMatchHistory{
+	history := models.UserMatchHistory{
 		UserID:      uint(request.UserID),
 		PartnerID:   uint(partnerInfo.ID),
 		PartnerName: partnerInfo.Fullname,
-		Status:      status,
-	})
+		Status:      models.MatchStatusPending,
+		Decision:    decision,
+	}
 
-	// update partner status if the partner already like the user
-	if count > 0 {
-		f.storeHist.UpdatePartnerStatus(models.UserMatchHistory{
-			UserID:    uint(intPartnerID),
-			PartnerID: uint(request.UserID),
-			Status:    status,
-		})
+	// the like of the partner is checked in the same transaction, so the mutual like at the same time still complete the match
+	match, err := f.storeMatch.CreateLike(history)
+	if err != nil {
+		return err
+	}
+
+	if match.ID > 0 {
+		f.publishEvent(request.UserID, realtime.EventNewMatch, realtime.NewMatchEventData{PartnerID: intPartnerID})
+		f.publishEvent(intPartnerID, realtime.EventNewMatch, realtime.NewMatchEventData{PartnerID: request.UserID})
+	} else {
+		f.publishEvent(intPartnerID, realtime.EventLiked, realtime.LikedEventData{IsSuperLiked: decision == models.DecisionSuperLike})
 	}
 
+	f.cache.SetLastDecision(userID, decision, intPartnerID)
 	return nil
 }
 
-func (f PartnerService) GetListLikedPartner(request PartnerServiceRequest) ([]PartnerServiceInfo, error) {
-	hist, err := f.storeHist.GetUserHistoryListByUserID(models.UserMatchHistory{
-		UserID: uint(request.UserID),
-	})
+// publishEvent is func to push the realtime event to the user, the failure is only logged because the decision is already stored
+func (f PartnerService) publishEvent(userID int, eventType string, data interface{}) {
+	err := f.realtime.PublishEvent(userID, eventType, data)
+	if err != nil {
+		log.Println("[PartnerService]-Error Publish Event :", eventType, err)
+	}
+}
 
+func (f PartnerService) GetListLikedPartner(request HistoryServiceRequest) (HistoryServiceInfo, error) {
+	hist, nextCursor, err := f.getHistoryPage(request, models.LikeDecisions, false)
 	if err != nil {
-		return nil, err
+		return HistoryServiceInfo{}, err
 	}
 
-	var result []PartnerServiceInfo
+	result := HistoryServiceInfo{
+		NextCursor: nextCursor,
+	}
 	for _, data := range hist {
-		result = append(result, PartnerServiceInfo{
+		result.Histories = append(result.Histories, PartnerServiceInfo{
 			PartnerID:   int(data.PartnerID),
 			Fullname:    data.PartnerName,
 			Status:      data.Status.String(),
@@ -299,3 +416,31 @@ func (f PartnerService) GetListLikedPartner(request PartnerServiceRequest) ([]Pa
 
 	return result, nil
 }
+
+// GetListPassedPartner is func to get list partner passed by the user
+func (f PartnerService) GetListPassedPartner(request HistoryServiceRequest) (HistoryServiceInfo, error) {
+	// pass decision does not have match status
+	if len(request.Status) > 0 {
+		return HistoryServiceInfo{}, ErrInvalidHistoryStatus
+	}
+
+	// the pass history is updated when the partner is passed again, so the latest pass is sorted by the updated date
+	hist, nextCursor, err := f.getHistoryPage(request, []models.DecisionType{models.DecisionPass}, true)
+	if err != nil {
+		return HistoryServiceInfo{}, err
+	}
+
+	result := HistoryServiceInfo{
+		NextCursor: nextCursor,
+	}
+	for _, data := range hist {
+		result.Histories = append(result.Histories, PartnerServiceInfo{
+			PartnerID:   int(data.PartnerID),
+			Fullname:    data.PartnerName,
+			Status:      StatusPassed,
+			CreatedDate: data.UpdatedAt.String(),
+		})
+	}
+
+	return result, nil
+}
@@ -2,6 +2,12 @@ package partner
 
 import (
 	"fmt"
+	"gilsaputro/dating-apps/internal/service/realtime"
+	mock_realtime "gilsaputro/dating-apps/internal/service/realtime/mock"
+	"gilsaputro/dating-apps/internal/store/block"
+	mock_block "gilsaputro/dating-apps/internal/store/block/mock"
+	"gilsaputro/dating-apps/internal/store/match"
+	mock_match "gilsaputro/dating-apps/internal/store/match/mock"
 	"gilsaputro/dating-apps/internal/store/partnercache"
 	mock_partner "gilsaputro/dating-apps/internal/store/partnercache/mock"
 	"gilsaputro/dating-apps/internal/store/user"
@@ -11,6 +17,7 @@ import (
 	"gilsaputro/dating-apps/models"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/jinzhu/gorm"
@@ -18,10 +25,15 @@ import (
 
 func TestNewPartnerService(t *testing.T) {
 	type args struct {
-		storeUser  user.UserStoreMethod
-		storeHist  userhistory.UserHistoryStoreMethod
-		cache      partnercache.PartnerCacheStoreMethod
-		maxCounter int
+		storeUser          user.UserStoreMethod
+		storeHist          userhistory.UserHistoryStoreMethod
+		storeMatch         match.MatchStoreMethod
+		storeBlock         block.BlockStoreMethod
+		cache              partnercache.PartnerCacheStoreMethod
+		realtime           realtime.RealtimeServiceMethod
+		maxCounter         int
+		passCooldown       time.Duration
+		superLikeAllowance SuperLikeAllowance
 	}
 	tests := []struct {
 		name string
@@ -31,21 +43,32 @@ func TestNewPartnerService(t *testing.T) {
 		{
 			name: "success flow",
 			args: args{
-				storeUser: &user.UserStore{},
-				storeHist: &userhistory.UserHistoryStore{},
-				cache:     &partnercache.PartnerCacheStore{},
-			},
-			want: &PartnerService{
 				storeUser:  &user.UserStore{},
 				storeHist:  &userhistory.UserHistoryStore{},
+				storeMatch: &match.MatchStore{},
+				storeBlock: &block.BlockStore{},
 				cache:      &partnercache.PartnerCacheStore{},
-				maxCounter: 10,
+				realtime:   &realtime.RealtimeService{},
+			},
+			want: &PartnerService{
+				storeUser:    &user.UserStore{},
+				storeHist:    &userhistory.UserHistoryStore{},
+				storeMatch:   &match.MatchStore{},
+				storeBlock:   &block.BlockStore{},
+				cache:        &partnercache.PartnerCacheStore{},
+				realtime:     &realtime.RealtimeService{},
+				maxCounter:   10,
+				passCooldown: defaultPassCooldown,
+				superLikeAllowance: SuperLikeAllowance{
+					Default: defaultSuperLikeAllowance,
+					Premium: defaultPremiumSuperLikeAllowance,
+				},
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewPartnerService(tt.args.storeUser, tt.args.storeHist, tt.args.cache, tt.args.maxCounter); !reflect.DeepEqual(got, tt.want) {
+			if got := NewPartnerService(tt.args.storeUser, tt.args.storeHist, tt.args.storeMatch, tt.args.storeBlock, tt.args.cache, tt.args.realtime, tt.args.maxCounter, tt.args.passCooldown, tt.args.superLikeAllowance); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewPartnerService() = %v, want %v", got, tt.want)
 			}
 		})
@@ -56,7 +79,10 @@ func TestPartnerService_PassPartner(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
 	hStore := mock_userhist.NewMockUserHistoryStoreMethod(mockCtrl)
+	bStore := mock_block.NewMockBlockStoreMethod(mockCtrl)
+	mStore := mock_match.NewMockMatchStoreMethod(mockCtrl)
 	pStore := mock_partner.NewMockPartnerCacheStoreMethod(mockCtrl)
+	rService := mock_realtime.NewMockRealtimeServiceMethod(mockCtrl)
 	defer mockCtrl.Finish()
 	type args struct {
 		request PartnerServiceRequest
@@ -72,99 +98,319 @@ func TestPartnerService_PassPartner(t *testing.T) {
 			name: "success flow",
 			args: args{
 				request: PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: false,
+					UserID: 1,
+					Plan:   models.PlanFree,
 				},
 			},
 			mockFunc: func() {
 				pStore.EXPECT().GetViewedUserCounter("1").Return("1", nil)
-				uStore.EXPECT().Count().Return(4, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				pStore.EXPECT().GetCurentPartnerState("1").Return("2", nil)
+				hStore.EXPECT().CountByUserIDAndPartnerID(1, 2).Return(0, nil)
+				uStore.EXPECT().GetUserInfoByID(2).Return(models.User{Model: gorm.Model{ID: 2}, Fullname: "F2"}, nil)
+				hStore.EXPECT().SavePassHistory(models.UserMatchHistory{
+					UserID:      1,
+					PartnerID:   2,
+					PartnerName: "F2",
+					Decision:    models.DecisionPass,
+				}).Return(nil)
+				pStore.EXPECT().SetLastDecision("1", models.DecisionPass, 2).Return(nil)
 				pStore.EXPECT().GetViewedPartnerHistory("1").Return("2,3", nil)
-				pStore.EXPECT().SetViewedPartnerHistory("1", "2,3,4").Return(nil)
-				pStore.EXPECT().SetCurentPartnerState(1, 4).Return(nil)
-
-				uStore.EXPECT().GetUserInfoByID(4).Return(models.User{
-					Model: gorm.Model{
-						ID: 4,
+				hStore.EXPECT().GetPartnerIDsByUserID(1).Return([]int{5}, nil)
+				hStore.EXPECT().GetPassedPartnerIDsByUserID(1, gomock.Any()).Return([]int{7}, nil)
+				bStore.EXPECT().GetBlockedUserIDs(1).Return([]int{}, nil)
+				uStore.EXPECT().GetCandidateList(user.CandidateFilter{
+					ExcludeIDs:    []int{1, 5, 7, 2, 3},
+					Limit:         candidateFeedSize,
+					SuperLikerIDs: []int{},
+					LikerIDs:      []int{},
+				}).Return([]models.User{
+					{
+						Model: gorm.Model{
+							ID: 6,
+						},
+						Fullname: "F6",
+					},
+					{
+						Model: gorm.Model{
+							ID: 4,
+						},
+						Username: "U4",
+						Fullname: "F4",
+						Email:    "E4",
+						Plan:     models.PlanPremium,
 					},
-					Username:   "U4",
-					Fullname:   "F4",
-					Email:      "E4",
-					IsVerified: true,
 				}, nil)
+				hStore.EXPECT().GetUserIDsByPartnerID(1).Return([]int{}, nil)
+				hStore.EXPECT().GetSuperLikerIDsByPartnerID(1).Return([]int{}, nil)
+				pStore.EXPECT().SetViewedPartnerHistory("1", "2,3,4").Return(nil)
+				pStore.EXPECT().SetCurentPartnerState(1, 4).Return(nil)
 
 				hStore.EXPECT().CountByUserIDAndPartnerID(1, 4).Return(0, nil)
+				hStore.EXPECT().GetSuperLikerIDsByPartnerID(1).Return([]int{}, nil)
 
 				pStore.EXPECT().SetViewedUserCounter("1", "2").Return(nil)
 			},
 			want: PartnerServiceInfo{
 				PartnerID:   4,
 				Fullname:    "F4",
-				IsVerified:  true,
+				IsPremium:   true,
 				Status:      "PENDING",
 				CreatedDate: "0001-01-01 00:00:00 +0000 UTC",
 			},
 			wantErr: false,
 		},
 		{
-			name: "error on get partner info flow",
+			name: "success flow prioritize user who like the user",
 			args: args{
 				request: PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: false,
+					UserID: 1,
+					Plan:   models.PlanPremium,
 				},
 			},
 			mockFunc: func() {
-				pStore.EXPECT().GetViewedUserCounter("1").Return("1", nil)
-				uStore.EXPECT().Count().Return(4, nil)
-				pStore.EXPECT().GetViewedPartnerHistory("1").Return("2,3", nil)
-				pStore.EXPECT().SetViewedPartnerHistory("1", "2,3,4").Return(nil)
-				pStore.EXPECT().SetCurentPartnerState(1, 4).Return(nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				pStore.EXPECT().GetCurentPartnerState("1").Return("", nil)
+				pStore.EXPECT().GetViewedPartnerHistory("1").Return("", nil)
+				hStore.EXPECT().GetPartnerIDsByUserID(1).Return([]int{}, nil)
+				hStore.EXPECT().GetPassedPartnerIDsByUserID(1, gomock.Any()).Return([]int{}, nil)
+				bStore.EXPECT().GetBlockedUserIDs(1).Return([]int{}, nil)
+				uStore.EXPECT().GetCandidateList(user.CandidateFilter{
+					ExcludeIDs:    []int{1},
+					Limit:         candidateFeedSize,
+					SuperLikerIDs: []int{},
+					LikerIDs:      []int{6},
+				}).Return([]models.User{
+					{
+						Model: gorm.Model{
+							ID: 4,
+						},
+						Fullname: "F4",
+						Plan:     models.PlanPremium,
+					},
+					{
+						Model: gorm.Model{
+							ID: 6,
+						},
+						Fullname: "F6",
+					},
+				}, nil)
+				hStore.EXPECT().GetUserIDsByPartnerID(1).Return([]int{6}, nil)
+				hStore.EXPECT().GetSuperLikerIDsByPartnerID(1).Return([]int{}, nil)
+				pStore.EXPECT().SetViewedPartnerHistory("1", "6").Return(nil)
+				pStore.EXPECT().SetCurentPartnerState(1, 6).Return(nil)
 
-				uStore.EXPECT().GetUserInfoByID(4).Return(models.User{
-					Model: gorm.Model{
-						ID: 4,
+				hStore.EXPECT().CountByUserIDAndPartnerID(1, 6).Return(0, nil)
+				hStore.EXPECT().GetSuperLikerIDsByPartnerID(1).Return([]int{}, nil)
+			},
+			want: PartnerServiceInfo{
+				PartnerID:   6,
+				Fullname:    "F6",
+				Status:      "PENDING",
+				CreatedDate: "0001-01-01 00:00:00 +0000 UTC",
+			},
+			wantErr: false,
+		},
+		{
+			name: "success flow prioritize user who super like the user",
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				},
+			},
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				pStore.EXPECT().GetCurentPartnerState("1").Return("", nil)
+				pStore.EXPECT().GetViewedPartnerHistory("1").Return("", nil)
+				hStore.EXPECT().GetPartnerIDsByUserID(1).Return([]int{}, nil)
+				hStore.EXPECT().GetPassedPartnerIDsByUserID(1, gomock.Any()).Return([]int{}, nil)
+				bStore.EXPECT().GetBlockedUserIDs(1).Return([]int{}, nil)
+				uStore.EXPECT().GetCandidateList(user.CandidateFilter{
+					ExcludeIDs:    []int{1},
+					Limit:         candidateFeedSize,
+					SuperLikerIDs: []int{7},
+					LikerIDs:      []int{6, 7},
+				}).Return([]models.User{
+					{
+						Model: gorm.Model{
+							ID: 6,
+						},
+						Fullname: "F6",
+						Plan:     models.PlanPremium,
 					},
-					Username:   "U4",
-					Fullname:   "F4",
-					Email:      "E4",
-					IsVerified: true,
-				}, fmt.Errorf("some error"))
+					{
+						Model: gorm.Model{
+							ID: 7,
+						},
+						Fullname: "F7",
+					},
+				}, nil)
+				hStore.EXPECT().GetUserIDsByPartnerID(1).Return([]int{6, 7}, nil)
+				hStore.EXPECT().GetSuperLikerIDsByPartnerID(1).Return([]int{7}, nil)
+				pStore.EXPECT().SetViewedPartnerHistory("1", "7").Return(nil)
+				pStore.EXPECT().SetCurentPartnerState(1, 7).Return(nil)
+
+				hStore.EXPECT().CountByUserIDAndPartnerID(1, 7).Return(0, nil)
+				hStore.EXPECT().GetSuperLikerIDsByPartnerID(1).Return([]int{7}, nil)
+			},
+			want: PartnerServiceInfo{
+				PartnerID:    7,
+				Fullname:     "F7",
+				Status:       "PENDING",
+				CreatedDate:  "0001-01-01 00:00:00 +0000 UTC",
+				IsSuperLiked: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on GetSuperLikerIDsByPartnerID flow",
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
+				},
+			},
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				pStore.EXPECT().GetCurentPartnerState("1").Return("", nil)
+				pStore.EXPECT().GetViewedPartnerHistory("1").Return("", nil)
+				hStore.EXPECT().GetPartnerIDsByUserID(1).Return([]int{}, nil)
+				hStore.EXPECT().GetPassedPartnerIDsByUserID(1, gomock.Any()).Return([]int{}, nil)
+				bStore.EXPECT().GetBlockedUserIDs(1).Return([]int{}, nil)
+				hStore.EXPECT().GetUserIDsByPartnerID(1).Return([]int{}, nil)
+				hStore.EXPECT().GetSuperLikerIDsByPartnerID(1).Return(nil, fmt.Errorf("some error"))
 			},
 			want:    PartnerServiceInfo{},
 			wantErr: true,
 		},
 		{
-			name: "error on SetCurentPartnerState flow",
+			name: "error no partner available flow",
 			args: args{
 				request: PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: false,
+					UserID: 1,
+					Plan:   models.PlanFree,
 				},
 			},
 			mockFunc: func() {
 				pStore.EXPECT().GetViewedUserCounter("1").Return("1", nil)
-				uStore.EXPECT().Count().Return(4, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				pStore.EXPECT().GetCurentPartnerState("1").Return("", nil)
 				pStore.EXPECT().GetViewedPartnerHistory("1").Return("2,3", nil)
-				pStore.EXPECT().SetViewedPartnerHistory("1", "2,3,4").Return(nil)
-				pStore.EXPECT().SetCurentPartnerState(1, 4).Return(fmt.Errorf("some error"))
+				hStore.EXPECT().GetPartnerIDsByUserID(1).Return([]int{}, nil)
+				hStore.EXPECT().GetPassedPartnerIDsByUserID(1, gomock.Any()).Return([]int{}, nil)
+				bStore.EXPECT().GetBlockedUserIDs(1).Return([]int{}, nil)
+				hStore.EXPECT().GetUserIDsByPartnerID(1).Return([]int{}, nil)
+				hStore.EXPECT().GetSuperLikerIDsByPartnerID(1).Return([]int{}, nil)
+				uStore.EXPECT().GetCandidateList(user.CandidateFilter{
+					ExcludeIDs:    []int{1, 2, 3},
+					Limit:         candidateFeedSize,
+					SuperLikerIDs: []int{},
+					LikerIDs:      []int{},
+				}).Return([]models.User{}, nil)
 			},
 			want:    PartnerServiceInfo{},
 			wantErr: true,
 		},
 		{
-			name: "error on SetViewedPartnerHistory flow",
+			name: "error on GetUserIDsByPartnerID flow",
 			args: args{
 				request: PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: false,
+					UserID: 1,
+					Plan:   models.PlanFree,
 				},
 			},
 			mockFunc: func() {
 				pStore.EXPECT().GetViewedUserCounter("1").Return("1", nil)
-				uStore.EXPECT().Count().Return(4, nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				pStore.EXPECT().GetCurentPartnerState("1").Return("", nil)
 				pStore.EXPECT().GetViewedPartnerHistory("1").Return("2,3", nil)
-				pStore.EXPECT().SetViewedPartnerHistory("1", "2,3,4").Return(fmt.Errorf("some error"))
+				hStore.EXPECT().GetPartnerIDsByUserID(1).Return([]int{}, nil)
+				hStore.EXPECT().GetPassedPartnerIDsByUserID(1, gomock.Any()).Return([]int{}, nil)
+				bStore.EXPECT().GetBlockedUserIDs(1).Return([]int{}, nil)
+				hStore.EXPECT().GetUserIDsByPartnerID(1).Return(nil, fmt.Errorf("some error"))
+			},
+			want:    PartnerServiceInfo{},
+			wantErr: true,
+		},
+		{
+			name: "error on GetCandidateList flow",
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanFree,
+				},
+			},
+			mockFunc: func() {
+				pStore.EXPECT().GetViewedUserCounter("1").Return("1", nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				pStore.EXPECT().GetCurentPartnerState("1").Return("", nil)
+				pStore.EXPECT().GetViewedPartnerHistory("1").Return("2,3", nil)
+				hStore.EXPECT().GetPartnerIDsByUserID(1).Return([]int{}, nil)
+				hStore.EXPECT().GetPassedPartnerIDsByUserID(1, gomock.Any()).Return([]int{}, nil)
+				bStore.EXPECT().GetBlockedUserIDs(1).Return([]int{}, nil)
+				hStore.EXPECT().GetUserIDsByPartnerID(1).Return([]int{}, nil)
+				hStore.EXPECT().GetSuperLikerIDsByPartnerID(1).Return([]int{}, nil)
+				uStore.EXPECT().GetCandidateList(user.CandidateFilter{
+					ExcludeIDs:    []int{1, 2, 3},
+					Limit:         candidateFeedSize,
+					SuperLikerIDs: []int{},
+					LikerIDs:      []int{},
+				}).Return(nil, fmt.Errorf("some error"))
+			},
+			want:    PartnerServiceInfo{},
+			wantErr: true,
+		},
+		{
+			name: "error on GetPartnerIDsByUserID flow",
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanFree,
+				},
+			},
+			mockFunc: func() {
+				pStore.EXPECT().GetViewedUserCounter("1").Return("1", nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				pStore.EXPECT().GetCurentPartnerState("1").Return("", nil)
+				pStore.EXPECT().GetViewedPartnerHistory("1").Return("2,3", nil)
+				hStore.EXPECT().GetPartnerIDsByUserID(1).Return(nil, fmt.Errorf("some error"))
+			},
+			want:    PartnerServiceInfo{},
+			wantErr: true,
+		},
+		{
+			name: "error on SetCurentPartnerState flow",
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanFree,
+				},
+			},
+			mockFunc: func() {
+				pStore.EXPECT().GetViewedUserCounter("1").Return("1", nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				pStore.EXPECT().GetCurentPartnerState("1").Return("", nil)
+				pStore.EXPECT().GetViewedPartnerHistory("1").Return("2,3", nil)
+				hStore.EXPECT().GetPartnerIDsByUserID(1).Return([]int{}, nil)
+				hStore.EXPECT().GetPassedPartnerIDsByUserID(1, gomock.Any()).Return([]int{}, nil)
+				bStore.EXPECT().GetBlockedUserIDs(1).Return([]int{}, nil)
+				uStore.EXPECT().GetCandidateList(user.CandidateFilter{
+					ExcludeIDs:    []int{1, 2, 3},
+					Limit:         candidateFeedSize,
+					SuperLikerIDs: []int{},
+					LikerIDs:      []int{},
+				}).Return([]models.User{
+					{
+						Model: gorm.Model{
+							ID: 4,
+						},
+					},
+				}, nil)
+				hStore.EXPECT().GetUserIDsByPartnerID(1).Return([]int{}, nil)
+				hStore.EXPECT().GetSuperLikerIDsByPartnerID(1).Return([]int{}, nil)
+				pStore.EXPECT().SetViewedPartnerHistory("1", "2,3,4").Return(nil)
+				pStore.EXPECT().SetCurentPartnerState(1, 4).Return(fmt.Errorf("some error"))
 			},
 			want:    PartnerServiceInfo{},
 			wantErr: true,
@@ -173,39 +419,60 @@ func TestPartnerService_PassPartner(t *testing.T) {
 			name: "error on SetViewedPartnerHistory flow",
 			args: args{
 				request: PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: false,
+					UserID: 1,
+					Plan:   models.PlanFree,
 				},
 			},
 			mockFunc: func() {
 				pStore.EXPECT().GetViewedUserCounter("1").Return("1", nil)
-				uStore.EXPECT().Count().Return(4, nil)
-				pStore.EXPECT().GetViewedPartnerHistory("1").Return("2,3", fmt.Errorf("some error"))
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				pStore.EXPECT().GetCurentPartnerState("1").Return("", nil)
+				pStore.EXPECT().GetViewedPartnerHistory("1").Return("2,3", nil)
+				hStore.EXPECT().GetPartnerIDsByUserID(1).Return([]int{}, nil)
+				hStore.EXPECT().GetPassedPartnerIDsByUserID(1, gomock.Any()).Return([]int{}, nil)
+				bStore.EXPECT().GetBlockedUserIDs(1).Return([]int{}, nil)
+				uStore.EXPECT().GetCandidateList(user.CandidateFilter{
+					ExcludeIDs:    []int{1, 2, 3},
+					Limit:         candidateFeedSize,
+					SuperLikerIDs: []int{},
+					LikerIDs:      []int{},
+				}).Return([]models.User{
+					{
+						Model: gorm.Model{
+							ID: 4,
+						},
+					},
+				}, nil)
+				hStore.EXPECT().GetUserIDsByPartnerID(1).Return([]int{}, nil)
+				hStore.EXPECT().GetSuperLikerIDsByPartnerID(1).Return([]int{}, nil)
+				pStore.EXPECT().SetViewedPartnerHistory("1", "2,3,4").Return(fmt.Errorf("some error"))
 			},
 			want:    PartnerServiceInfo{},
 			wantErr: true,
 		},
 		{
-			name: "error on Count flow",
+			name: "error on GetViewedPartnerHistory flow",
 			args: args{
 				request: PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: false,
+					UserID: 1,
+					Plan:   models.PlanFree,
 				},
 			},
 			mockFunc: func() {
 				pStore.EXPECT().GetViewedUserCounter("1").Return("1", nil)
-				uStore.EXPECT().Count().Return(4, fmt.Errorf("some error"))
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				pStore.EXPECT().GetCurentPartnerState("1").Return("", nil)
+				pStore.EXPECT().GetViewedPartnerHistory("1").Return("2,3", fmt.Errorf("some error"))
 			},
 			want:    PartnerServiceInfo{},
 			wantErr: true,
 		},
 		{
-			name: "error on Count flow",
+			name: "error on GetViewedUserCounter flow",
 			args: args{
 				request: PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: false,
+					UserID: 1,
+					Plan:   models.PlanFree,
 				},
 			},
 			mockFunc: func() {
@@ -218,8 +485,8 @@ func TestPartnerService_PassPartner(t *testing.T) {
 			name: "error on max counter flow",
 			args: args{
 				request: PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: false,
+					UserID: 1,
+					Plan:   models.PlanFree,
 				},
 			},
 			mockFunc: func() {
@@ -231,7 +498,7 @@ func TestPartnerService_PassPartner(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := NewPartnerService(uStore, hStore, pStore, 10)
+			s := NewPartnerService(uStore, hStore, mStore, bStore, pStore, rService, 10, 0, SuperLikeAllowance{})
 			tt.mockFunc()
 			got, err := s.PassPartner(tt.args.request)
 			if (err != nil) != tt.wantErr {
@@ -249,8 +516,13 @@ func TestPartnerService_GetCurrentPartner(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
 	hStore := mock_userhist.NewMockUserHistoryStoreMethod(mockCtrl)
+	bStore := mock_block.NewMockBlockStoreMethod(mockCtrl)
+	mStore := mock_match.NewMockMatchStoreMethod(mockCtrl)
 	pStore := mock_partner.NewMockPartnerCacheStoreMethod(mockCtrl)
+	rService := mock_realtime.NewMockRealtimeServiceMethod(mockCtrl)
 	defer mockCtrl.Finish()
+	locationUpdatedAt := time.Now()
+	distance := 116
 	type args struct {
 		request PartnerServiceRequest
 	}
@@ -265,45 +537,215 @@ func TestPartnerService_GetCurrentPartner(t *testing.T) {
 			name: "success",
 			mockFunc: func() {
 				pStore.EXPECT().GetViewedUserCounter("1").Return("1", nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
 				pStore.EXPECT().GetCurentPartnerState("1").Return("4", nil)
 				uStore.EXPECT().GetUserInfoByID(4).Return(models.User{
 					Model: gorm.Model{
 						ID: 4,
 					},
-					Username:   "U4",
-					Fullname:   "F4",
-					Email:      "E4",
-					IsVerified: true,
+					Username:           "U4",
+					Fullname:           "F4",
+					Email:              "E4",
+					Plan:               models.PlanPremium,
+					IsIdentityVerified: true,
 				}, nil)
+				bStore.EXPECT().IsBlocked(1, 4).Return(false, nil)
 
 				hStore.EXPECT().CountByUserIDAndPartnerID(1, 4).Return(0, nil)
+				hStore.EXPECT().GetSuperLikerIDsByPartnerID(1).Return([]int{}, nil)
 			},
 			args: args{
 				request: PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: false,
+					UserID: 1,
+					Plan:   models.PlanFree,
 				},
 			},
 			want: PartnerServiceInfo{
 				PartnerID:   4,
 				Fullname:    "F4",
+				IsPremium:   true,
 				IsVerified:  true,
 				Status:      "PENDING",
 				CreatedDate: "0001-01-01 00:00:00 +0000 UTC",
 			},
 			wantErr: false,
 		},
+		{
+			name: "success regenerate when partner is no longer exists",
+			mockFunc: func() {
+				pStore.EXPECT().GetViewedUserCounter("1").Return("1", nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				pStore.EXPECT().GetCurentPartnerState("1").Return("4", nil)
+				uStore.EXPECT().GetUserInfoByID(4).Return(models.User{}, gorm.ErrRecordNotFound)
+				pStore.EXPECT().GetViewedPartnerHistory("1").Return("4", nil)
+				hStore.EXPECT().GetPartnerIDsByUserID(1).Return([]int{}, nil)
+				hStore.EXPECT().GetPassedPartnerIDsByUserID(1, gomock.Any()).Return([]int{}, nil)
+				bStore.EXPECT().GetBlockedUserIDs(1).Return([]int{}, nil)
+				uStore.EXPECT().GetCandidateList(user.CandidateFilter{
+					ExcludeIDs:    []int{1, 4},
+					Limit:         candidateFeedSize,
+					SuperLikerIDs: []int{},
+					LikerIDs:      []int{},
+				}).Return([]models.User{
+					{
+						Model: gorm.Model{
+							ID: 5,
+						},
+						Fullname: "F5",
+					},
+				}, nil)
+				hStore.EXPECT().GetUserIDsByPartnerID(1).Return([]int{}, nil)
+				hStore.EXPECT().GetSuperLikerIDsByPartnerID(1).Return([]int{}, nil)
+				pStore.EXPECT().SetViewedPartnerHistory("1", "4,5").Return(nil)
+				pStore.EXPECT().SetCurentPartnerState(1, 5).Return(nil)
+				hStore.EXPECT().CountByUserIDAndPartnerID(1, 5).Return(0, nil)
+				hStore.EXPECT().GetSuperLikerIDsByPartnerID(1).Return([]int{}, nil)
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanFree,
+				},
+			},
+			want: PartnerServiceInfo{
+				PartnerID:   5,
+				Fullname:    "F5",
+				Status:      "PENDING",
+				CreatedDate: "0001-01-01 00:00:00 +0000 UTC",
+			},
+			wantErr: false,
+		},
+		{
+			name: "success regenerate when partner is blocked",
+			mockFunc: func() {
+				pStore.EXPECT().GetViewedUserCounter("1").Return("1", nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				pStore.EXPECT().GetCurentPartnerState("1").Return("4", nil)
+				uStore.EXPECT().GetUserInfoByID(4).Return(models.User{Model: gorm.Model{ID: 4}, Fullname: "F4"}, nil)
+				bStore.EXPECT().IsBlocked(1, 4).Return(true, nil)
+				pStore.EXPECT().GetViewedPartnerHistory("1").Return("4", nil)
+				hStore.EXPECT().GetPartnerIDsByUserID(1).Return([]int{}, nil)
+				hStore.EXPECT().GetPassedPartnerIDsByUserID(1, gomock.Any()).Return([]int{}, nil)
+				bStore.EXPECT().GetBlockedUserIDs(1).Return([]int{4, 6}, nil)
+				uStore.EXPECT().GetCandidateList(user.CandidateFilter{
+					ExcludeIDs:    []int{1, 4, 6, 4},
+					Limit:         candidateFeedSize,
+					SuperLikerIDs: []int{},
+					LikerIDs:      []int{},
+				}).Return([]models.User{
+					{
+						Model: gorm.Model{
+							ID: 5,
+						},
+						Fullname: "F5",
+					},
+				}, nil)
+				hStore.EXPECT().GetUserIDsByPartnerID(1).Return([]int{}, nil)
+				hStore.EXPECT().GetSuperLikerIDsByPartnerID(1).Return([]int{}, nil)
+				pStore.EXPECT().SetViewedPartnerHistory("1", "4,5").Return(nil)
+				pStore.EXPECT().SetCurentPartnerState(1, 5).Return(nil)
+				hStore.EXPECT().CountByUserIDAndPartnerID(1, 5).Return(0, nil)
+				hStore.EXPECT().GetSuperLikerIDsByPartnerID(1).Return([]int{}, nil)
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanFree,
+				},
+			},
+			want: PartnerServiceInfo{
+				PartnerID:   5,
+				Fullname:    "F5",
+				Status:      "PENDING",
+				CreatedDate: "0001-01-01 00:00:00 +0000 UTC",
+			},
+			wantErr: false,
+		},
+		{
+			name: "error check blocked partner",
+			mockFunc: func() {
+				pStore.EXPECT().GetViewedUserCounter("1").Return("1", nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				pStore.EXPECT().GetCurentPartnerState("1").Return("4", nil)
+				uStore.EXPECT().GetUserInfoByID(4).Return(models.User{Model: gorm.Model{ID: 4}, Fullname: "F4"}, nil)
+				bStore.EXPECT().IsBlocked(1, 4).Return(false, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanFree,
+				},
+			},
+			want:    PartnerServiceInfo{},
+			wantErr: true,
+		},
+		{
+			name: "success with partner distance",
+			mockFunc: func() {
+				pStore.EXPECT().GetViewedUserCounter("1").Return("1", nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{
+					Model:             gorm.Model{ID: 1},
+					Latitude:          -6.2088,
+					Longitude:         106.8456,
+					LocationUpdatedAt: &locationUpdatedAt,
+				}, nil)
+				pStore.EXPECT().GetCurentPartnerState("1").Return("4", nil)
+				uStore.EXPECT().GetUserInfoByID(4).Return(models.User{
+					Model: gorm.Model{
+						ID: 4,
+					},
+					Fullname:          "F4",
+					Latitude:          -6.9175,
+					Longitude:         107.6191,
+					LocationUpdatedAt: &locationUpdatedAt,
+				}, nil)
+				bStore.EXPECT().IsBlocked(1, 4).Return(false, nil)
+
+				hStore.EXPECT().CountByUserIDAndPartnerID(1, 4).Return(0, nil)
+				hStore.EXPECT().GetSuperLikerIDsByPartnerID(1).Return([]int{}, nil)
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanFree,
+				},
+			},
+			want: PartnerServiceInfo{
+				PartnerID:   4,
+				Fullname:    "F4",
+				Status:      "PENDING",
+				CreatedDate: "0001-01-01 00:00:00 +0000 UTC",
+				Distance:    &distance,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error get profile user",
+			mockFunc: func() {
+				pStore.EXPECT().GetViewedUserCounter("1").Return("1", nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{}, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanFree,
+				},
+			},
+			want:    PartnerServiceInfo{},
+			wantErr: true,
+		},
 		{
 			name: "error get profile partner",
 			mockFunc: func() {
 				pStore.EXPECT().GetViewedUserCounter("1").Return("1", nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
 				pStore.EXPECT().GetCurentPartnerState("1").Return("4", nil)
 				uStore.EXPECT().GetUserInfoByID(4).Return(models.User{}, fmt.Errorf("some error"))
 			},
 			args: args{
 				request: PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: false,
+					UserID: 1,
+					Plan:   models.PlanFree,
 				},
 			},
 			want:    PartnerServiceInfo{},
@@ -313,12 +755,13 @@ func TestPartnerService_GetCurrentPartner(t *testing.T) {
 			name: "error get state partner",
 			mockFunc: func() {
 				pStore.EXPECT().GetViewedUserCounter("1").Return("1", nil)
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
 				pStore.EXPECT().GetCurentPartnerState("1").Return("", fmt.Errorf("some error"))
 			},
 			args: args{
 				request: PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: false,
+					UserID: 1,
+					Plan:   models.PlanFree,
 				},
 			},
 			want:    PartnerServiceInfo{},
@@ -331,8 +774,8 @@ func TestPartnerService_GetCurrentPartner(t *testing.T) {
 			},
 			args: args{
 				request: PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: false,
+					UserID: 1,
+					Plan:   models.PlanFree,
 				},
 			},
 			want:    PartnerServiceInfo{},
@@ -341,7 +784,7 @@ func TestPartnerService_GetCurrentPartner(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := NewPartnerService(uStore, hStore, pStore, 10)
+			s := NewPartnerService(uStore, hStore, mStore, bStore, pStore, rService, 10, 0, SuperLikeAllowance{})
 			tt.mockFunc()
 			got, err := s.GetCurrentPartner(tt.args.request)
 			if (err != nil) != tt.wantErr {
@@ -359,7 +802,10 @@ func TestPartnerService_LikePartner(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
 	hStore := mock_userhist.NewMockUserHistoryStoreMethod(mockCtrl)
+	bStore := mock_block.NewMockBlockStoreMethod(mockCtrl)
+	mStore := mock_match.NewMockMatchStoreMethod(mockCtrl)
 	pStore := mock_partner.NewMockPartnerCacheStoreMethod(mockCtrl)
+	rService := mock_realtime.NewMockRealtimeServiceMethod(mockCtrl)
 	defer mockCtrl.Finish()
 	type args struct {
 		request PartnerServiceRequest
@@ -370,12 +816,22 @@ func TestPartnerService_LikePartner(t *testing.T) {
 		args     args
 		wantErr  bool
 	}{
+		{
+			name:     "error email not verified",
+			mockFunc: func() {},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "success",
 			mockFunc: func() {
 				pStore.EXPECT().GetCurentPartnerState("1").Return("4", nil)
+				bStore.EXPECT().IsBlocked(1, 4).Return(false, nil)
 				hStore.EXPECT().CountByUserIDAndPartnerID(1, 4).Return(0, nil)
-				hStore.EXPECT().CountByUserIDAndPartnerID(4, 1).Return(1, nil)
 				uStore.EXPECT().GetUserInfoByID(4).Return(models.User{
 					Model: gorm.Model{
 						ID: 4,
@@ -383,33 +839,88 @@ func TestPartnerService_LikePartner(t *testing.T) {
 					Fullname: "P4",
 				}, nil)
 
-				hStore.EXPECT().CreateUserHistory(models.UserMatchHistory{
+				mStore.EXPECT().CreateLike(models.UserMatchHistory{
 					UserID:      1,
 					PartnerID:   4,
 					PartnerName: "P4",
-					Status:      models.MatchStatusApproved,
-				}).Return(nil)
+					Status:      models.MatchStatusPending,
+					Decision:    models.DecisionLike,
+				}).Return(models.Match{Model: gorm.Model{ID: 7}, UserID: 1, PartnerID: 4, Status: models.MatchStatusApproved}, nil)
+				rService.EXPECT().PublishEvent(1, realtime.EventNewMatch, realtime.NewMatchEventData{PartnerID: 4}).Return(nil)
+				rService.EXPECT().PublishEvent(4, realtime.EventNewMatch, realtime.NewMatchEventData{PartnerID: 1}).Return(nil)
+				pStore.EXPECT().SetLastDecision("1", models.DecisionLike, 4).Return(nil)
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID:          1,
+					Plan:            models.PlanFree,
+					IsEmailVerified: true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success pending like",
+			mockFunc: func() {
+				pStore.EXPECT().GetCurentPartnerState("1").Return("4", nil)
+				bStore.EXPECT().IsBlocked(1, 4).Return(false, nil)
+				hStore.EXPECT().CountByUserIDAndPartnerID(1, 4).Return(0, nil)
+				uStore.EXPECT().GetUserInfoByID(4).Return(models.User{
+					Model: gorm.Model{
+						ID: 4,
+					},
+					Fullname: "P4",
+				}, nil)
 
-				hStore.EXPECT().UpdatePartnerStatus(models.UserMatchHistory{
-					UserID:    uint(4),
-					PartnerID: uint(1),
-					Status:    models.MatchStatusApproved,
-				}).Return(nil)
+				mStore.EXPECT().CreateLike(models.UserMatchHistory{
+					UserID:      1,
+					PartnerID:   4,
+					PartnerName: "P4",
+					Status:      models.MatchStatusPending,
+					Decision:    models.DecisionLike,
+				}).Return(models.Match{}, nil)
+				rService.EXPECT().PublishEvent(4, realtime.EventLiked, realtime.LikedEventData{}).Return(fmt.Errorf("some error"))
+				pStore.EXPECT().SetLastDecision("1", models.DecisionLike, 4).Return(nil)
 			},
 			args: args{
 				request: PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: false,
+					UserID:          1,
+					Plan:            models.PlanFree,
+					IsEmailVerified: true,
 				},
 			},
 			wantErr: false,
 		},
+		{
+			name: "error on create like",
+			mockFunc: func() {
+				pStore.EXPECT().GetCurentPartnerState("1").Return("4", nil)
+				bStore.EXPECT().IsBlocked(1, 4).Return(false, nil)
+				hStore.EXPECT().CountByUserIDAndPartnerID(1, 4).Return(0, nil)
+				uStore.EXPECT().GetUserInfoByID(4).Return(models.User{
+					Model: gorm.Model{
+						ID: 4,
+					},
+					Fullname: "P4",
+				}, nil)
+
+				mStore.EXPECT().CreateLike(gomock.Any()).Return(models.Match{}, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID:          1,
+					Plan:            models.PlanFree,
+					IsEmailVerified: true,
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "error on get detail",
 			mockFunc: func() {
 				pStore.EXPECT().GetCurentPartnerState("1").Return("4", nil)
+				bStore.EXPECT().IsBlocked(1, 4).Return(false, nil)
 				hStore.EXPECT().CountByUserIDAndPartnerID(1, 4).Return(0, nil)
-				hStore.EXPECT().CountByUserIDAndPartnerID(4, 1).Return(1, nil)
 				uStore.EXPECT().GetUserInfoByID(4).Return(models.User{
 					Model: gorm.Model{
 						ID: 4,
@@ -419,37 +930,40 @@ func TestPartnerService_LikePartner(t *testing.T) {
 			},
 			args: args{
 				request: PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: false,
+					UserID:          1,
+					Plan:            models.PlanFree,
+					IsEmailVerified: true,
 				},
 			},
 			wantErr: true,
 		},
 		{
-			name: "error on user already like",
+			name: "error partner is blocked",
 			mockFunc: func() {
 				pStore.EXPECT().GetCurentPartnerState("1").Return("4", nil)
-				hStore.EXPECT().CountByUserIDAndPartnerID(1, 4).Return(1, nil)
+				bStore.EXPECT().IsBlocked(1, 4).Return(true, nil)
 			},
 			args: args{
 				request: PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: false,
+					UserID:          1,
+					Plan:            models.PlanFree,
+					IsEmailVerified: true,
 				},
 			},
 			wantErr: true,
 		},
 		{
-			name: "error on check partner detail",
+			name: "error on user already like",
 			mockFunc: func() {
 				pStore.EXPECT().GetCurentPartnerState("1").Return("4", nil)
-				hStore.EXPECT().CountByUserIDAndPartnerID(1, 4).Return(0, nil)
-				hStore.EXPECT().CountByUserIDAndPartnerID(4, 1).Return(1, fmt.Errorf("some error"))
+				bStore.EXPECT().IsBlocked(1, 4).Return(false, nil)
+				hStore.EXPECT().CountByUserIDAndPartnerID(1, 4).Return(1, nil)
 			},
 			args: args{
 				request: PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: false,
+					UserID:          1,
+					Plan:            models.PlanFree,
+					IsEmailVerified: true,
 				},
 			},
 			wantErr: true,
@@ -458,12 +972,14 @@ func TestPartnerService_LikePartner(t *testing.T) {
 			name: "error on check user detail",
 			mockFunc: func() {
 				pStore.EXPECT().GetCurentPartnerState("1").Return("4", nil)
+				bStore.EXPECT().IsBlocked(1, 4).Return(false, nil)
 				hStore.EXPECT().CountByUserIDAndPartnerID(1, 4).Return(0, fmt.Errorf("some error"))
 			},
 			args: args{
 				request: PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: false,
+					UserID:          1,
+					Plan:            models.PlanFree,
+					IsEmailVerified: true,
 				},
 			},
 			wantErr: true,
@@ -475,8 +991,9 @@ func TestPartnerService_LikePartner(t *testing.T) {
 			},
 			args: args{
 				request: PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: false,
+					UserID:          1,
+					Plan:            models.PlanFree,
+					IsEmailVerified: true,
 				},
 			},
 			wantErr: true,
@@ -484,7 +1001,7 @@ func TestPartnerService_LikePartner(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := NewPartnerService(uStore, hStore, pStore, 10)
+			s := NewPartnerService(uStore, hStore, mStore, bStore, pStore, rService, 10, 0, SuperLikeAllowance{})
 			tt.mockFunc()
 			if err := s.LikePartner(tt.args.request); (err != nil) != tt.wantErr {
 				t.Errorf("PartnerService.LikePartner() error = %v, wantErr %v", err, tt.wantErr)
@@ -497,22 +1014,29 @@ func TestPartnerService_GetListLikedPartner(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
 	hStore := mock_userhist.NewMockUserHistoryStoreMethod(mockCtrl)
+	bStore := mock_block.NewMockBlockStoreMethod(mockCtrl)
+	mStore := mock_match.NewMockMatchStoreMethod(mockCtrl)
 	pStore := mock_partner.NewMockPartnerCacheStoreMethod(mockCtrl)
+	rService := mock_realtime.NewMockRealtimeServiceMethod(mockCtrl)
+	likedAt := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
 	type args struct {
-		request PartnerServiceRequest
+		request HistoryServiceRequest
 	}
 	tests := []struct {
 		name     string
 		mockFunc func()
 		args     args
-		want     []PartnerServiceInfo
+		want     HistoryServiceInfo
 		wantErr  bool
 	}{
 		{
 			name: "success flow",
 			mockFunc: func() {
-				hStore.EXPECT().GetUserHistoryListByUserID(models.UserMatchHistory{
-					UserID: 1,
+				bStore.EXPECT().GetBlockedUserIDs(1).Return(nil, nil)
+				hStore.EXPECT().GetUserHistoryListByUserID(userhistory.HistoryFilter{
+					UserID:    1,
+					Decisions: models.LikeDecisions,
+					Limit:     DefaultHistoryPageLimit + 1,
 				}).Return([]models.UserMatchHistory{
 					{
 						Model: gorm.Model{
@@ -526,41 +1050,129 @@ func TestPartnerService_GetListLikedPartner(t *testing.T) {
 				}, nil)
 			},
 			args: args{
-				request: PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: true,
+				request: HistoryServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
 				},
 			},
-			want: []PartnerServiceInfo{
-				{
-					PartnerID:   4,
-					Fullname:    "P4",
-					Status:      models.MatchStatusApproved.String(),
-					CreatedDate: "0001-01-01 00:00:00 +0000 UTC",
+			want: HistoryServiceInfo{
+				Histories: []PartnerServiceInfo{
+					{
+						PartnerID:   4,
+						Fullname:    "P4",
+						Status:      models.MatchStatusApproved.String(),
+						CreatedDate: "0001-01-01 00:00:00 +0000 UTC",
+					},
 				},
 			},
 			wantErr: false,
 		},
 		{
-			name: "error flow",
+			name: "success flow with status filter and next page",
 			mockFunc: func() {
-				hStore.EXPECT().GetUserHistoryListByUserID(models.UserMatchHistory{
+				bStore.EXPECT().GetBlockedUserIDs(1).Return(nil, nil)
+				hStore.EXPECT().GetUserHistoryListByUserID(userhistory.HistoryFilter{
+					UserID:    1,
+					Decisions: models.LikeDecisions,
+					Status:    models.MatchStatusPending,
+					Cursor: &userhistory.HistoryCursor{
+						Time: likedAt,
+						ID:   9,
+					},
+					Limit:   2,
+					SortAsc: true,
+				}).Return([]models.UserMatchHistory{
+					{
+						Model: gorm.Model{
+							ID:        10,
+							CreatedAt: likedAt,
+						},
+						UserID:      1,
+						PartnerID:   4,
+						PartnerName: "P4",
+						Status:      models.MatchStatusPending,
+					},
+					{
+						Model: gorm.Model{
+							ID:        11,
+							CreatedAt: likedAt,
+						},
+						UserID:      1,
+						PartnerID:   5,
+						PartnerName: "P5",
+						Status:      models.MatchStatusPending,
+					},
+				}, nil)
+			},
+			args: args{
+				request: HistoryServiceRequest{
+					UserID:  1,
+					Status:  "pending",
+					Cursor:  encodeHistoryCursor(models.UserMatchHistory{Model: gorm.Model{ID: 9, CreatedAt: likedAt}}, false),
+					Limit:   1,
+					SortAsc: true,
+				},
+			},
+			want: HistoryServiceInfo{
+				Histories: []PartnerServiceInfo{
+					{
+						PartnerID:   4,
+						Fullname:    "P4",
+						Status:      models.MatchStatusPending.String(),
+						CreatedDate: likedAt.String(),
+					},
+				},
+				NextCursor: encodeHistoryCursor(models.UserMatchHistory{Model: gorm.Model{ID: 10, CreatedAt: likedAt}}, false),
+			},
+			wantErr: false,
+		},
+		{
+			name:     "error invalid status flow",
+			mockFunc: func() {},
+			args: args{
+				request: HistoryServiceRequest{
 					UserID: 1,
+					Status: "MATCHED",
+				},
+			},
+			want:    HistoryServiceInfo{},
+			wantErr: true,
+		},
+		{
+			name:     "error invalid cursor flow",
+			mockFunc: func() {},
+			args: args{
+				request: HistoryServiceRequest{
+					UserID: 1,
+					Cursor: "invalid",
+				},
+			},
+			want:    HistoryServiceInfo{},
+			wantErr: true,
+		},
+		{
+			name: "error flow",
+			mockFunc: func() {
+				bStore.EXPECT().GetBlockedUserIDs(1).Return(nil, nil)
+				hStore.EXPECT().GetUserHistoryListByUserID(userhistory.HistoryFilter{
+					UserID:    1,
+					Decisions: models.LikeDecisions,
+					Limit:     DefaultHistoryPageLimit + 1,
 				}).Return([]models.UserMatchHistory{}, fmt.Errorf("some error"))
 			},
 			args: args{
-				request: PartnerServiceRequest{
-					UserID:     1,
-					IsVerified: true,
+				request: HistoryServiceRequest{
+					UserID: 1,
+					Plan:   models.PlanPremium,
 				},
 			},
-			want:    nil,
+			want:    HistoryServiceInfo{},
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := NewPartnerService(uStore, hStore, pStore, 10)
+			s := NewPartnerService(uStore, hStore, mStore, bStore, pStore, rService, 10, 0, SuperLikeAllowance{})
 			tt.mockFunc()
 			got, err := s.GetListLikedPartner(tt.args.request)
 			if (err != nil) != tt.wantErr {
@@ -573,3 +1185,172 @@ func TestPartnerService_GetListLikedPartner(t *testing.T) {
 		})
 	}
 }
+
+func TestPartnerService_GetListPassedPartner(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	hStore := mock_userhist.NewMockUserHistoryStoreMethod(mockCtrl)
+	bStore := mock_block.NewMockBlockStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	passedAt := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+	type args struct {
+		request HistoryServiceRequest
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		want     HistoryServiceInfo
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				bStore.EXPECT().GetBlockedUserIDs(1).Return(nil, nil)
+				hStore.EXPECT().GetUserHistoryListByUserID(userhistory.HistoryFilter{
+					UserID:          1,
+					Decisions:       []models.DecisionType{models.DecisionPass},
+					Limit:           DefaultHistoryPageLimit + 1,
+					SortByUpdatedAt: true,
+				}).Return([]models.UserMatchHistory{
+					{
+						Model: gorm.Model{
+							ID:        1,
+							UpdatedAt: passedAt,
+						},
+						UserID:      1,
+						PartnerID:   4,
+						PartnerName: "P4",
+						Decision:    models.DecisionPass,
+					},
+				}, nil)
+			},
+			args: args{
+				request: HistoryServiceRequest{
+					UserID: 1,
+				},
+			},
+			want: HistoryServiceInfo{
+				Histories: []PartnerServiceInfo{
+					{
+						PartnerID:   4,
+						Fullname:    "P4",
+						Status:      StatusPassed,
+						CreatedDate: passedAt.String(),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "error filter passed history by status",
+			mockFunc: func() {},
+			args: args{
+				request: HistoryServiceRequest{
+					UserID: 1,
+					Status: "PENDING",
+				},
+			},
+			want:    HistoryServiceInfo{},
+			wantErr: true,
+		},
+		{
+			name: "error flow",
+			mockFunc: func() {
+				bStore.EXPECT().GetBlockedUserIDs(1).Return(nil, nil)
+				hStore.EXPECT().GetUserHistoryListByUserID(gomock.Any()).Return(nil, fmt.Errorf("some error"))
+			},
+			args: args{
+				request: HistoryServiceRequest{
+					UserID: 1,
+				},
+			},
+			want:    HistoryServiceInfo{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := PartnerService{
+				storeHist:  hStore,
+				storeBlock: bStore,
+			}
+			tt.mockFunc()
+			got, err := s.GetListPassedPartner(tt.args.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PartnerService.GetListPassedPartner() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PartnerService.GetListPassedPartner() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPartnerService_passCurrentPartner(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
+	hStore := mock_userhist.NewMockUserHistoryStoreMethod(mockCtrl)
+	pStore := mock_partner.NewMockPartnerCacheStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "success skip without current partner",
+			mockFunc: func() {
+				pStore.EXPECT().GetCurentPartnerState("1").Return("", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "success skip liked partner",
+			mockFunc: func() {
+				pStore.EXPECT().GetCurentPartnerState("1").Return("4", nil)
+				hStore.EXPECT().CountByUserIDAndPartnerID(1, 4).Return(1, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "success skip deleted partner",
+			mockFunc: func() {
+				pStore.EXPECT().GetCurentPartnerState("1").Return("4", nil)
+				hStore.EXPECT().CountByUserIDAndPartnerID(1, 4).Return(0, nil)
+				uStore.EXPECT().GetUserInfoByID(4).Return(models.User{}, gorm.ErrRecordNotFound)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on save pass history",
+			mockFunc: func() {
+				pStore.EXPECT().GetCurentPartnerState("1").Return("4", nil)
+				hStore.EXPECT().CountByUserIDAndPartnerID(1, 4).Return(0, nil)
+				uStore.EXPECT().GetUserInfoByID(4).Return(models.User{Model: gorm.Model{ID: 4}, Fullname: "P4"}, nil)
+				hStore.EXPECT().SavePassHistory(gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on get current partner",
+			mockFunc: func() {
+				pStore.EXPECT().GetCurentPartnerState("1").Return("", fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := PartnerService{
+				storeUser: uStore,
+				storeHist: hStore,
+				cache:     pStore,
+			}
+			tt.mockFunc()
+			if err := s.passCurrentPartner(PartnerServiceRequest{UserID: 1}); (err != nil) != tt.wantErr {
+				t.Errorf("PartnerService.passCurrentPartner() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
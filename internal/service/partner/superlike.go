@@ -0,0 +1,49 @@
+package partner
+
+import (
+	"fmt"
+	"gilsaputro/dating-apps/models"
+	"strconv"
+)
+
+// SuperLikePartner is func to super like the current partner, the super like is limited by the user daily allowance
+func (f PartnerService) SuperLikePartner(request PartnerServiceRequest) error {
+	userID := fmt.Sprintf("%v", request.UserID)
+
+	allowance := f.superLikeAllowance.Default
+	if models.GetEntitlements(request.Plan).ExtraSuperLike {
+		allowance = f.superLikeAllowance.Premium
+	}
+
+	counter, err := f.cache.GetSuperLikeCounter(userID)
+	if err != nil {
+		return err
+	}
+
+	numCounter, _ := strconv.Atoi(counter)
+	if numCounter >= allowance {
+		return ErrReachedMaxSuperLikeQuota
+	}
+
+	err = f.likeCurrentPartner(request, models.DecisionSuperLike)
+	if err != nil {
+		return err
+	}
+
+	numCounter++
+	f.cache.SetSuperLikeCounter(userID, fmt.Sprintf("%d", numCounter))
+	return nil
+}
+
+// refundSuperLike is func to give back one super like to today daily allowance of the user
+func (f PartnerService) refundSuperLike(userID string) {
+	counter, err := f.cache.GetSuperLikeCounter(userID)
+	if err != nil {
+		return
+	}
+
+	numCounter, _ := strconv.Atoi(counter)
+	if numCounter > 0 {
+		f.cache.SetSuperLikeCounter(userID, fmt.Sprintf("%d", numCounter-1))
+	}
+}
@@ -0,0 +1,168 @@
+package partner
+
+import (
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/realtime"
+	mock_realtime "gilsaputro/dating-apps/internal/service/realtime/mock"
+	mock_block "gilsaputro/dating-apps/internal/store/block/mock"
+	mock_match "gilsaputro/dating-apps/internal/store/match/mock"
+	mock_partner "gilsaputro/dating-apps/internal/store/partnercache/mock"
+	mock_user "gilsaputro/dating-apps/internal/store/user/mock"
+	mock_userhist "gilsaputro/dating-apps/internal/store/userhistory/mock"
+	"gilsaputro/dating-apps/models"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+)
+
+func TestPartnerService_SuperLikePartner(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
+	hStore := mock_userhist.NewMockUserHistoryStoreMethod(mockCtrl)
+	bStore := mock_block.NewMockBlockStoreMethod(mockCtrl)
+	mStore := mock_match.NewMockMatchStoreMethod(mockCtrl)
+	pStore := mock_partner.NewMockPartnerCacheStoreMethod(mockCtrl)
+	rService := mock_realtime.NewMockRealtimeServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		request PartnerServiceRequest
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		wantErr  error
+	}{
+		{
+			name: "error email not verified",
+			mockFunc: func() {
+				pStore.EXPECT().GetSuperLikeCounter("1").Return("0", nil)
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID: 1,
+				},
+			},
+			wantErr: ErrEmailNotVerified,
+		},
+		{
+			name: "success",
+			mockFunc: func() {
+				pStore.EXPECT().GetSuperLikeCounter("1").Return("0", nil)
+				pStore.EXPECT().GetCurentPartnerState("1").Return("4", nil)
+				bStore.EXPECT().IsBlocked(1, 4).Return(false, nil)
+				hStore.EXPECT().CountByUserIDAndPartnerID(1, 4).Return(0, nil)
+				uStore.EXPECT().GetUserInfoByID(4).Return(models.User{
+					Model: gorm.Model{
+						ID: 4,
+					},
+					Fullname: "P4",
+				}, nil)
+
+				mStore.EXPECT().CreateLike(models.UserMatchHistory{
+					UserID:      1,
+					PartnerID:   4,
+					PartnerName: "P4",
+					Status:      models.MatchStatusPending,
+					Decision:    models.DecisionSuperLike,
+				}).Return(models.Match{}, nil)
+				rService.EXPECT().PublishEvent(4, realtime.EventLiked, realtime.LikedEventData{IsSuperLiked: true}).Return(nil)
+				pStore.EXPECT().SetLastDecision("1", models.DecisionSuperLike, 4).Return(nil)
+				pStore.EXPECT().SetSuperLikeCounter("1", "1").Return(nil)
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID:          1,
+					Plan:            models.PlanFree,
+					IsEmailVerified: true,
+				},
+			},
+		},
+		{
+			name: "success premium user has bigger allowance",
+			mockFunc: func() {
+				pStore.EXPECT().GetSuperLikeCounter("1").Return("2", nil)
+				pStore.EXPECT().GetCurentPartnerState("1").Return("4", nil)
+				bStore.EXPECT().IsBlocked(1, 4).Return(false, nil)
+				hStore.EXPECT().CountByUserIDAndPartnerID(1, 4).Return(0, nil)
+				uStore.EXPECT().GetUserInfoByID(4).Return(models.User{
+					Model: gorm.Model{
+						ID: 4,
+					},
+					Fullname: "P4",
+				}, nil)
+
+				mStore.EXPECT().CreateLike(models.UserMatchHistory{
+					UserID:      1,
+					PartnerID:   4,
+					PartnerName: "P4",
+					Status:      models.MatchStatusPending,
+					Decision:    models.DecisionSuperLike,
+				}).Return(models.Match{Model: gorm.Model{ID: 7}, UserID: 1, PartnerID: 4, Status: models.MatchStatusApproved}, nil)
+				rService.EXPECT().PublishEvent(1, realtime.EventNewMatch, realtime.NewMatchEventData{PartnerID: 4}).Return(nil)
+				rService.EXPECT().PublishEvent(4, realtime.EventNewMatch, realtime.NewMatchEventData{PartnerID: 1}).Return(nil)
+				pStore.EXPECT().SetLastDecision("1", models.DecisionSuperLike, 4).Return(nil)
+				pStore.EXPECT().SetSuperLikeCounter("1", "3").Return(nil)
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID:          1,
+					Plan:            models.PlanPremium,
+					IsEmailVerified: true,
+				},
+			},
+		},
+		{
+			name: "error reach max quota",
+			mockFunc: func() {
+				pStore.EXPECT().GetSuperLikeCounter("1").Return("2", nil)
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID:          1,
+					Plan:            models.PlanFree,
+					IsEmailVerified: true,
+				},
+			},
+			wantErr: ErrReachedMaxSuperLikeQuota,
+		},
+		{
+			name: "error already like the partner",
+			mockFunc: func() {
+				pStore.EXPECT().GetSuperLikeCounter("1").Return("0", nil)
+				pStore.EXPECT().GetCurentPartnerState("1").Return("4", nil)
+				bStore.EXPECT().IsBlocked(1, 4).Return(false, nil)
+				hStore.EXPECT().CountByUserIDAndPartnerID(1, 4).Return(1, nil)
+			},
+			args: args{
+				request: PartnerServiceRequest{
+					UserID:          1,
+					Plan:            models.PlanFree,
+					IsEmailVerified: true,
+				},
+			},
+			wantErr: ErrUserAlreadyLikePartner,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewPartnerService(uStore, hStore, mStore, bStore, pStore, rService, 10, 0, SuperLikeAllowance{
+				Default: 2,
+				Premium: 5,
+			})
+			tt.mockFunc()
+			if err := s.SuperLikePartner(tt.args.request); err != tt.wantErr {
+				t.Errorf("PartnerService.SuperLikePartner() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("error on GetSuperLikeCounter", func(t *testing.T) {
+		s := NewPartnerService(uStore, hStore, mStore, bStore, pStore, rService, 10, 0, SuperLikeAllowance{})
+		pStore.EXPECT().GetSuperLikeCounter("1").Return("", fmt.Errorf("some error"))
+		if err := s.SuperLikePartner(PartnerServiceRequest{UserID: 1}); err == nil {
+			t.Errorf("PartnerService.SuperLikePartner() expect error")
+		}
+	})
+}
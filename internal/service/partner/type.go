@@ -1,24 +1,135 @@
 package partner
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 var (
-	ErrReachedMaxSwipeQuota    = errors.New("the user already reach max quota for swipe")
-	ErrCurrentPartnerIsMissing = errors.New("the user partner is missing, please find one partner first")
-	ErrUserAlreadyLikePartner  = errors.New("the user already like the partner")
+	ErrReachedMaxSwipeQuota     = errors.New("the user already reach max quota for swipe")
+	ErrCurrentPartnerIsMissing  = errors.New("the user partner is missing, please find one partner first")
+	ErrUserAlreadyLikePartner   = errors.New("the user already like the partner")
+	ErrNoPartnerAvailable       = errors.New("there is no partner available for the user right now")
+	ErrMatchNotFound            = errors.New("the user does not have match with the partner")
+	ErrRewindNotAllowed         = errors.New("rewind is not available in the user plan")
+	ErrNothingToRewind          = errors.New("there is no swipe to rewind")
+	ErrRewindMatchedPartner     = errors.New("the like already become a match and cannot be rewound")
+	ErrReachedMaxSuperLikeQuota = errors.New("the user already reach max quota for super like")
+	ErrInvalidHistoryCursor     = errors.New("the history cursor is invalid")
+	ErrInvalidHistoryStatus     = errors.New("the history status is invalid")
+	ErrPartnerIsBlocked         = errors.New("the partner is no longer available")
+	ErrEmailNotVerified         = errors.New("please verify your email before like a partner")
 )
 
+// StatusPassed is status of partner that passed by the user
+const StatusPassed = "PASSED"
+
+// defaultPassCooldown is default duration before a passed partner can be offered again
+const defaultPassCooldown = 7 * 24 * time.Hour
+
+const (
+	// defaultSuperLikeAllowance is default daily super like allowance for the plan without extra super like
+	defaultSuperLikeAllowance = 1
+	// defaultPremiumSuperLikeAllowance is default daily super like allowance for the plan with extra super like
+	defaultPremiumSuperLikeAllowance = 5
+)
+
+// SuperLikeAllowance is daily super like allowance for each type of user
+type SuperLikeAllowance struct {
+	Default int
+	Premium int
+}
+
 // PartnerServiceRequest is list parameter for Partner Partner
 type PartnerServiceRequest struct {
-	UserID     int
-	IsVerified bool
+	UserID int
+	// Plan is the subscription plan of the user to check the entitlements
+	Plan string
+	// IsEmailVerified is required to like a partner, the user with unverified email only can browse
+	IsEmailVerified bool
 }
 
 // PartnerServiceInfo struct is list parameter info for partner sevice
 type PartnerServiceInfo struct {
-	PartnerID   int
-	Fullname    string
+	PartnerID int
+	Fullname  string
+	// IsPremium is true when the partner is subscribed to one of the paid plan
+	IsPremium bool
+	// IsVerified is true when the identity of the partner is approved by admin, it is not related to the paid plan
 	IsVerified  bool
 	Status      string
 	CreatedDate string
+	// Distance is rounded partner distance in kilometer, nil when one of the user location is unknown
+	Distance *int
+	// IsSuperLiked is true when the partner super liked the user
+	IsSuperLiked bool
+}
+
+const (
+	// DefaultHistoryPageLimit is default number of history returned for each page
+	DefaultHistoryPageLimit = 20
+	// MaxHistoryPageLimit is max number of history returned for each page
+	MaxHistoryPageLimit = 100
+)
+
+// HistoryServiceRequest is list parameter for get partner history
+type HistoryServiceRequest struct {
+	UserID int
+	Plan   string
+	// Status is PENDING, APPROVED or REJECTED, empty means all status
+	Status string
+	// Cursor is the next cursor returned by the previous page, empty for the first page
+	Cursor  string
+	Limit   int
+	SortAsc bool
+}
+
+// HistoryServiceInfo struct is one page of partner history
+type HistoryServiceInfo struct {
+	Histories []PartnerServiceInfo
+	// NextCursor is empty when there is no next page
+	NextCursor string
+}
+
+// LikesReceivedServiceInfo struct is list of pending like received by the user
+type LikesReceivedServiceInfo struct {
+	Likes []PartnerServiceInfo
+	Total int
+	// IsRedacted is true when the liker profile is hidden because the user plan is not entitled
+	IsRedacted bool
+}
+
+const (
+	// DefaultMatchPageLimit is default number of match returned for each page
+	DefaultMatchPageLimit = 10
+	// MaxMatchPageLimit is max number of match returned for each page
+	MaxMatchPageLimit = 50
+)
+
+// MatchListServiceRequest is list parameter for get list match
+type MatchListServiceRequest struct {
+	UserID int
+	Page   int
+	Limit  int
+}
+
+// MatchServiceInfo struct is list parameter info for a match
+type MatchServiceInfo struct {
+	MatchID     int
+	Partner     PartnerServiceInfo
+	MatchedDate string
+}
+
+// MatchListServiceInfo struct is list match of the user with the pagination info
+type MatchListServiceInfo struct {
+	Matches []MatchServiceInfo
+	Page    int
+	Limit   int
+	Total   int
+}
+
+// UnmatchServiceRequest is list parameter for unmatch partner
+type UnmatchServiceRequest struct {
+	UserID    int
+	PartnerID int
 }
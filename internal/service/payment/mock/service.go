@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/payment/service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	payment "gilsaputro/dating-apps/internal/service/payment"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPaymentServiceMethod is a mock of PaymentServiceMethod interface.
+type MockPaymentServiceMethod struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentServiceMethodMockRecorder
+}
+
+// MockPaymentServiceMethodMockRecorder is the mock recorder for MockPaymentServiceMethod.
+type MockPaymentServiceMethodMockRecorder struct {
+	mock *MockPaymentServiceMethod
+}
+
+// NewMockPaymentServiceMethod creates a new mock instance.
+func NewMockPaymentServiceMethod(ctrl *gomock.Controller) *MockPaymentServiceMethod {
+	mock := &MockPaymentServiceMethod{ctrl: ctrl}
+	mock.recorder = &MockPaymentServiceMethodMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentServiceMethod) EXPECT() *MockPaymentServiceMethodMockRecorder {
+	return m.recorder
+}
+
+// CreateCheckout mocks base method.
+func (m *MockPaymentServiceMethod) CreateCheckout(arg0 payment.CheckoutServiceRequest) (payment.CheckoutServiceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCheckout", arg0)
+	ret0, _ := ret[0].(payment.CheckoutServiceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCheckout indicates an expected call of CreateCheckout.
+func (mr *MockPaymentServiceMethodMockRecorder) CreateCheckout(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCheckout", reflect.TypeOf((*MockPaymentServiceMethod)(nil).CreateCheckout), arg0)
+}
+
+// HandleWebhook mocks base method.
+func (m *MockPaymentServiceMethod) HandleWebhook(arg0 payment.WebhookServiceRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleWebhook", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleWebhook indicates an expected call of HandleWebhook.
+func (mr *MockPaymentServiceMethodMockRecorder) HandleWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleWebhook", reflect.TypeOf((*MockPaymentServiceMethod)(nil).HandleWebhook), arg0)
+}
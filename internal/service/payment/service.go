@@ -0,0 +1,270 @@
+package payment
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"gilsaputro/dating-apps/internal/service/subscription"
+	"gilsaputro/dating-apps/internal/store/payment"
+	"gilsaputro/dating-apps/internal/store/user"
+	"gilsaputro/dating-apps/models"
+	payment_provider "gilsaputro/dating-apps/pkg/payment"
+	"log"
+	"strings"
+	"time"
+)
+
+// PaymentServiceMethod is list method for Payment Service
+type PaymentServiceMethod interface {
+	CreateCheckout(CheckoutServiceRequest) (CheckoutServiceInfo, error)
+	HandleWebhook(WebhookServiceRequest) error
+}
+
+// PaymentService is list dependencies for payment service
+type PaymentService struct {
+	store        payment.PaymentStoreMethod
+	storeUser    user.UserStoreMethod
+	subscription subscription.SubscriptionServiceMethod
+	provider     payment_provider.PaymentProvider
+	config       CheckoutConfig
+}
+
+// NewPaymentService is func to generate PaymentServiceMethod interface
+func NewPaymentService(store payment.PaymentStoreMethod, storeUser user.UserStoreMethod, subscription subscription.SubscriptionServiceMethod, provider payment_provider.PaymentProvider, config CheckoutConfig) PaymentServiceMethod {
+	if len(config.Currency) == 0 {
+		config.Currency = defaultCurrency
+	}
+	return &PaymentService{
+		store:        store,
+		storeUser:    storeUser,
+		subscription: subscription,
+		provider:     provider,
+		config:       config,
+	}
+}
+
+// CreateCheckout is service level func to create hosted checkout page for the paid plan,
+// the plan is activated later by the webhook after the payment is received
+func (s *PaymentService) CreateCheckout(request CheckoutServiceRequest) (CheckoutServiceInfo, error) {
+	if request.UserId <= 0 {
+		return CheckoutServiceInfo{}, ErrDataNotFound
+	}
+
+	price := s.config.Prices[request.Plan]
+	if !models.IsValidPaidPlan(request.Plan) || price <= 0 {
+		return CheckoutServiceInfo{}, ErrInvalidPlan
+	}
+
+	userInfo, err := s.storeUser.GetUserInfoByID(request.UserId)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return CheckoutServiceInfo{}, ErrDataNotFound
+		}
+		return CheckoutServiceInfo{}, err
+	}
+
+	// the cancelled subscription can be renewed, the paid period is added after the current period
+	current, err := s.subscription.GetSubscription(subscription.GetSubscriptionServiceRequest{
+		UserId: request.UserId,
+	})
+	if err != nil {
+		return CheckoutServiceInfo{}, err
+	}
+
+	if current.Plan == request.Plan && current.Status == models.SubscriptionStatusActive {
+		return CheckoutServiceInfo{}, ErrAlreadySubscribed
+	}
+
+	reference, err := generateReference()
+	if err != nil {
+		return CheckoutServiceInfo{}, err
+	}
+
+	session, err := s.provider.CreateCheckoutSession(payment_provider.CheckoutRequest{
+		Reference:     reference,
+		Description:   request.Plan + " Plan",
+		Amount:        price,
+		Currency:      s.config.Currency,
+		CustomerEmail: userInfo.Email,
+		SuccessURL:    s.config.SuccessURL,
+		CancelURL:     s.config.CancelURL,
+	})
+	if err != nil {
+		return CheckoutServiceInfo{}, err
+	}
+
+	err = s.store.CreatePayment(models.Payment{
+		UserID:    userInfo.ID,
+		Plan:      request.Plan,
+		Provider:  s.provider.Name(),
+		Reference: reference,
+		SessionID: session.SessionID,
+		Amount:    price,
+		Currency:  s.config.Currency,
+		Status:    models.PaymentStatusPending,
+	})
+	if err != nil {
+		return CheckoutServiceInfo{}, err
+	}
+
+	return CheckoutServiceInfo{
+		SessionID: session.SessionID,
+		URL:       session.URL,
+	}, nil
+}
+
+// HandleWebhook is service level func to verify and process the webhook event of the payment provider,
+// the replayed event is ignored and the failed event is processed again when the provider retry it
+func (s *PaymentService) HandleWebhook(request WebhookServiceRequest) error {
+	event, err := s.provider.ParseWebhook(request.Payload, request.Signature)
+	if err != nil {
+		if err == payment_provider.ErrInvalidSignature || err == payment_provider.ErrInvalidPayload {
+			return ErrInvalidWebhook
+		}
+		return err
+	}
+
+	provider := s.provider.Name()
+	created, err := s.store.CreateEvent(models.PaymentEvent{
+		Provider:  provider,
+		EventID:   event.ID,
+		Type:      event.Type,
+		Reference: event.Reference,
+		Payload:   string(request.Payload),
+	})
+	if err != nil {
+		return err
+	}
+
+	if !created {
+		stored, err := s.store.GetEvent(provider, event.ID)
+		if err != nil {
+			return err
+		}
+		if stored.ProcessedAt != nil {
+			return nil
+		}
+	}
+
+	err = s.processEvent(event)
+	if err != nil {
+		return err
+	}
+
+	return s.store.MarkEventProcessed(provider, event.ID, time.Now())
+}
+
+// processEvent is func to apply the event into the payment, every status change is conditional
+// so the replayed and out of order event cannot grant or revoke the plan twice
+func (s *PaymentService) processEvent(event payment_provider.Event) error {
+	if len(event.Type) == 0 || (event.Type == payment_provider.EventCheckoutCompleted && !event.Paid) {
+		return nil
+	}
+
+	data, err := s.store.GetPaymentByReference(event.Reference)
+	if err != nil {
+		return err
+	}
+
+	if data.ID == 0 {
+		log.Println("[PaymentService]-Payment Not Found :", event.ID, event.Reference)
+		return nil
+	}
+
+	switch event.Type {
+	case payment_provider.EventCheckoutCompleted:
+		return s.activate(data)
+	case payment_provider.EventCheckoutExpired:
+		_, err = s.store.UpdatePaymentStatus(data.Reference, []string{models.PaymentStatusPending}, models.PaymentStatusExpired)
+		return err
+	case payment_provider.EventPaymentRefunded:
+		return s.refund(data)
+	}
+
+	return nil
+}
+
+// activate is func to mark the payment as paid and activate the plan, the paid payment without subscription
+// is activated again by the retry of the provider, so the failed activation is not lost
+func (s *PaymentService) activate(data models.Payment) error {
+	switch {
+	case data.Status == models.PaymentStatusPending:
+		ok, err := s.store.UpdatePaymentStatus(data.Reference, []string{models.PaymentStatusPending}, models.PaymentStatusPaid)
+		if err != nil || !ok {
+			return err
+		}
+	case data.Status != models.PaymentStatusPaid || data.SubscriptionID > 0:
+		return nil
+	}
+
+	_, err := s.link(data)
+	return err
+}
+
+// link is func to activate the plan of the paid payment and store the activated period into the payment,
+// the activation returns the same period for the same payment so it is safe to be retried
+func (s *PaymentService) link(data models.Payment) (int, error) {
+	info, err := s.subscription.ActivateSubscription(subscription.ActivateSubscriptionServiceRequest{
+		UserId:           int(data.UserID),
+		Plan:             data.Plan,
+		PaymentReference: data.Reference,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	err = s.store.SetPaymentSubscription(data.Reference, info.SubscriptionID)
+	if err != nil {
+		return 0, err
+	}
+
+	return info.SubscriptionID, nil
+}
+
+// refund is func to mark the payment as refunded and revoke the plan activated by the payment,
+// the pending payment is refunded too so the late completed event cannot activate the plan
+func (s *PaymentService) refund(data models.Payment) error {
+	switch data.Status {
+	case models.PaymentStatusPaid:
+		// the activation that is not finished is completed first, so the revoke cannot miss the period
+		subscriptionID := int(data.SubscriptionID)
+		if subscriptionID == 0 {
+			var err error
+			subscriptionID, err = s.link(data)
+			if err != nil {
+				return err
+			}
+		}
+
+		// the revoke is done before the status change, so it is retried when the status change is failed
+		err := s.subscription.RevokeSubscription(subscription.RevokeSubscriptionServiceRequest{
+			UserId:         int(data.UserID),
+			SubscriptionID: subscriptionID,
+		})
+		if err != nil {
+			return err
+		}
+	case models.PaymentStatusPending:
+	default:
+		return nil
+	}
+
+	ok, err := s.store.UpdatePaymentStatus(data.Reference, []string{data.Status}, models.PaymentStatusRefunded)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return ErrPaymentStatusChanged
+	}
+
+	return nil
+}
+
+// generateReference is func to generate random reference of the payment
+func generateReference() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
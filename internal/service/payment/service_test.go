@@ -0,0 +1,496 @@
+package payment
+
+import (
+	"fmt"
+	"gilsaputro/dating-apps/internal/service/subscription"
+	mock_subscription "gilsaputro/dating-apps/internal/service/subscription/mock"
+	mock_payment "gilsaputro/dating-apps/internal/store/payment/mock"
+	mock_user "gilsaputro/dating-apps/internal/store/user/mock"
+	"gilsaputro/dating-apps/models"
+	payment_provider "gilsaputro/dating-apps/pkg/payment"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+)
+
+func TestNewPaymentService(t *testing.T) {
+	provider := payment_provider.NewFakeProvider("whsec")
+	tests := []struct {
+		name   string
+		config CheckoutConfig
+		want   PaymentServiceMethod
+	}{
+		{
+			name: "success flow",
+			config: CheckoutConfig{
+				Currency: "idr",
+			},
+			want: &PaymentService{
+				provider: provider,
+				config: CheckoutConfig{
+					Currency: "idr",
+				},
+			},
+		},
+		{
+			name:   "success with default currency",
+			config: CheckoutConfig{},
+			want: &PaymentService{
+				provider: provider,
+				config: CheckoutConfig{
+					Currency: defaultCurrency,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewPaymentService(nil, nil, nil, provider, tt.config); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewPaymentService() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaymentService_CreateCheckout(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mStore := mock_payment.NewMockPaymentStoreMethod(mockCtrl)
+	mUser := mock_user.NewMockUserStoreMethod(mockCtrl)
+	mSubscription := mock_subscription.NewMockSubscriptionServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	provider := payment_provider.NewFakeProvider("whsec")
+	userInfo := models.User{
+		Model: gorm.Model{ID: 1},
+		Email: "user@mail.com",
+	}
+	request := CheckoutServiceRequest{
+		UserId: 1,
+		Plan:   models.PlanPremium,
+	}
+	tests := []struct {
+		name     string
+		request  CheckoutServiceRequest
+		mockFunc func()
+		want     CheckoutServiceInfo
+		wantErr  error
+	}{
+		{
+			name:    "success flow",
+			request: request,
+			mockFunc: func() {
+				mUser.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mSubscription.EXPECT().GetSubscription(subscription.GetSubscriptionServiceRequest{UserId: 1}).Return(subscription.SubscriptionServiceInfo{
+					Plan: models.PlanFree,
+				}, nil)
+				mStore.EXPECT().CreatePayment(gomock.Any()).DoAndReturn(func(data models.Payment) error {
+					session, ok := provider.GetSession(data.SessionID)
+					if !ok || session.Reference != data.Reference || session.CustomerEmail != "user@mail.com" {
+						t.Errorf("PaymentService.CreateCheckout() session = %+v", session)
+					}
+					if data.UserID != 1 || data.Plan != models.PlanPremium || data.Provider != payment_provider.ProviderFake ||
+						len(data.Reference) != 32 || data.Amount != 999 || data.Currency != "usd" || data.Status != models.PaymentStatusPending {
+						t.Errorf("PaymentService.CreateCheckout() payment = %+v", data)
+					}
+					return nil
+				})
+			},
+			want: CheckoutServiceInfo{
+				SessionID: "cs_fake_1",
+				URL:       "https://payment.fake.local/checkout/cs_fake_1",
+			},
+		},
+		{
+			name:    "success renew cancelled subscription",
+			request: request,
+			mockFunc: func() {
+				mUser.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mSubscription.EXPECT().GetSubscription(subscription.GetSubscriptionServiceRequest{UserId: 1}).Return(subscription.SubscriptionServiceInfo{
+					Plan:   models.PlanPremium,
+					Status: models.SubscriptionStatusCancelled,
+				}, nil)
+				mStore.EXPECT().CreatePayment(gomock.Any()).Return(nil)
+			},
+			want: CheckoutServiceInfo{
+				SessionID: "cs_fake_2",
+				URL:       "https://payment.fake.local/checkout/cs_fake_2",
+			},
+		},
+		{
+			name:     "error invalid user id",
+			request:  CheckoutServiceRequest{Plan: models.PlanPremium},
+			mockFunc: func() {},
+			wantErr:  ErrDataNotFound,
+		},
+		{
+			name:     "error invalid plan",
+			request:  CheckoutServiceRequest{UserId: 1, Plan: models.PlanFree},
+			mockFunc: func() {},
+			wantErr:  ErrInvalidPlan,
+		},
+		{
+			name:     "error plan without price",
+			request:  CheckoutServiceRequest{UserId: 1, Plan: models.PlanPlus},
+			mockFunc: func() {},
+			wantErr:  ErrInvalidPlan,
+		},
+		{
+			name:    "error user not found",
+			request: request,
+			mockFunc: func() {
+				mUser.EXPECT().GetUserInfoByID(1).Return(models.User{}, gorm.ErrRecordNotFound)
+			},
+			wantErr: ErrDataNotFound,
+		},
+		{
+			name:    "error already subscribed",
+			request: request,
+			mockFunc: func() {
+				mUser.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mSubscription.EXPECT().GetSubscription(subscription.GetSubscriptionServiceRequest{UserId: 1}).Return(subscription.SubscriptionServiceInfo{
+					Plan:   models.PlanPremium,
+					Status: models.SubscriptionStatusActive,
+				}, nil)
+			},
+			wantErr: ErrAlreadySubscribed,
+		},
+		{
+			name:    "error on get subscription",
+			request: request,
+			mockFunc: func() {
+				mUser.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mSubscription.EXPECT().GetSubscription(subscription.GetSubscriptionServiceRequest{UserId: 1}).Return(subscription.SubscriptionServiceInfo{}, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:    "error on create payment",
+			request: request,
+			mockFunc: func() {
+				mUser.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mSubscription.EXPECT().GetSubscription(subscription.GetSubscriptionServiceRequest{UserId: 1}).Return(subscription.SubscriptionServiceInfo{
+					Plan: models.PlanFree,
+				}, nil)
+				mStore.EXPECT().CreatePayment(gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := PaymentService{
+				store:        mStore,
+				storeUser:    mUser,
+				subscription: mSubscription,
+				provider:     provider,
+				config: CheckoutConfig{
+					Prices:   map[string]int64{models.PlanPremium: 999},
+					Currency: "usd",
+				},
+			}
+			tt.mockFunc()
+			got, err := service.CreateCheckout(tt.request)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("PaymentService.CreateCheckout() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PaymentService.CreateCheckout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaymentService_HandleWebhook(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mStore := mock_payment.NewMockPaymentStoreMethod(mockCtrl)
+	mSubscription := mock_subscription.NewMockSubscriptionServiceMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	provider := payment_provider.NewFakeProvider("whsec")
+	session, err := provider.CreateCheckoutSession(payment_provider.CheckoutRequest{
+		Reference: "ref-1",
+		Amount:    999,
+		Currency:  "usd",
+	})
+	if err != nil {
+		t.Fatalf("FakeProvider.CreateCheckoutSession() error = %v", err)
+	}
+	newPayment := func(status string, subscriptionID uint) models.Payment {
+		return models.Payment{
+			Model:          gorm.Model{ID: 5},
+			UserID:         1,
+			Plan:           models.PlanPremium,
+			Provider:       payment_provider.ProviderFake,
+			Reference:      "ref-1",
+			Status:         status,
+			SubscriptionID: subscriptionID,
+		}
+	}
+	processedAt := time.Now()
+	tests := []struct {
+		name      string
+		eventType string
+		signature string
+		mockFunc  func()
+		wantErr   error
+	}{
+		{
+			name:      "success checkout completed",
+			eventType: "checkout.session.completed",
+			mockFunc: func() {
+				mStore.EXPECT().CreateEvent(gomock.Any()).DoAndReturn(func(data models.PaymentEvent) (bool, error) {
+					if data.Provider != payment_provider.ProviderFake || data.EventID != "evt_1" || data.Type != payment_provider.EventCheckoutCompleted ||
+						data.Reference != "ref-1" || len(data.Payload) == 0 {
+						t.Errorf("PaymentService.HandleWebhook() event = %+v", data)
+					}
+					return true, nil
+				})
+				mStore.EXPECT().GetPaymentByReference("ref-1").Return(newPayment(models.PaymentStatusPending, 0), nil)
+				mStore.EXPECT().UpdatePaymentStatus("ref-1", []string{models.PaymentStatusPending}, models.PaymentStatusPaid).Return(true, nil)
+				mSubscription.EXPECT().ActivateSubscription(subscription.ActivateSubscriptionServiceRequest{
+					UserId:           1,
+					Plan:             models.PlanPremium,
+					PaymentReference: "ref-1",
+				}).Return(subscription.SubscriptionServiceInfo{SubscriptionID: 3}, nil)
+				mStore.EXPECT().SetPaymentSubscription("ref-1", 3).Return(nil)
+				mStore.EXPECT().MarkEventProcessed(payment_provider.ProviderFake, "evt_1", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "success replayed event is ignored",
+			eventType: "checkout.session.completed",
+			mockFunc: func() {
+				mStore.EXPECT().CreateEvent(gomock.Any()).Return(false, nil)
+				mStore.EXPECT().GetEvent(payment_provider.ProviderFake, "evt_1").Return(models.PaymentEvent{
+					Model:       gorm.Model{ID: 7},
+					ProcessedAt: &processedAt,
+				}, nil)
+			},
+		},
+		{
+			name:      "success failed event is processed again",
+			eventType: "checkout.session.completed",
+			mockFunc: func() {
+				mStore.EXPECT().CreateEvent(gomock.Any()).Return(false, nil)
+				mStore.EXPECT().GetEvent(payment_provider.ProviderFake, "evt_1").Return(models.PaymentEvent{
+					Model: gorm.Model{ID: 7},
+				}, nil)
+				mStore.EXPECT().GetPaymentByReference("ref-1").Return(newPayment(models.PaymentStatusPending, 0), nil)
+				mStore.EXPECT().UpdatePaymentStatus("ref-1", []string{models.PaymentStatusPending}, models.PaymentStatusPaid).Return(true, nil)
+				mSubscription.EXPECT().ActivateSubscription(gomock.Any()).Return(subscription.SubscriptionServiceInfo{SubscriptionID: 3}, nil)
+				mStore.EXPECT().SetPaymentSubscription("ref-1", 3).Return(nil)
+				mStore.EXPECT().MarkEventProcessed(payment_provider.ProviderFake, "evt_1", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "success completed after refunded does not activate the plan",
+			eventType: "checkout.session.completed",
+			mockFunc: func() {
+				mStore.EXPECT().CreateEvent(gomock.Any()).Return(true, nil)
+				mStore.EXPECT().GetPaymentByReference("ref-1").Return(newPayment(models.PaymentStatusRefunded, 0), nil)
+				mStore.EXPECT().MarkEventProcessed(payment_provider.ProviderFake, "evt_1", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "success completed is processed by other event",
+			eventType: "checkout.session.completed",
+			mockFunc: func() {
+				mStore.EXPECT().CreateEvent(gomock.Any()).Return(true, nil)
+				mStore.EXPECT().GetPaymentByReference("ref-1").Return(newPayment(models.PaymentStatusPending, 0), nil)
+				mStore.EXPECT().UpdatePaymentStatus("ref-1", []string{models.PaymentStatusPending}, models.PaymentStatusPaid).Return(false, nil)
+				mStore.EXPECT().MarkEventProcessed(payment_provider.ProviderFake, "evt_1", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "success replayed completed of linked payment",
+			eventType: "checkout.session.completed",
+			mockFunc: func() {
+				mStore.EXPECT().CreateEvent(gomock.Any()).Return(true, nil)
+				mStore.EXPECT().GetPaymentByReference("ref-1").Return(newPayment(models.PaymentStatusPaid, 3), nil)
+				mStore.EXPECT().MarkEventProcessed(payment_provider.ProviderFake, "evt_1", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "success retry finish the missing subscription link",
+			eventType: "checkout.session.completed",
+			mockFunc: func() {
+				mStore.EXPECT().CreateEvent(gomock.Any()).Return(false, nil)
+				mStore.EXPECT().GetEvent(payment_provider.ProviderFake, "evt_1").Return(models.PaymentEvent{
+					Model: gorm.Model{ID: 7},
+				}, nil)
+				mStore.EXPECT().GetPaymentByReference("ref-1").Return(newPayment(models.PaymentStatusPaid, 0), nil)
+				mSubscription.EXPECT().ActivateSubscription(subscription.ActivateSubscriptionServiceRequest{
+					UserId:           1,
+					Plan:             models.PlanPremium,
+					PaymentReference: "ref-1",
+				}).Return(subscription.SubscriptionServiceInfo{SubscriptionID: 3}, nil)
+				mStore.EXPECT().SetPaymentSubscription("ref-1", 3).Return(nil)
+				mStore.EXPECT().MarkEventProcessed(payment_provider.ProviderFake, "evt_1", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "success checkout expired",
+			eventType: "checkout.session.expired",
+			mockFunc: func() {
+				mStore.EXPECT().CreateEvent(gomock.Any()).Return(true, nil)
+				mStore.EXPECT().GetPaymentByReference("ref-1").Return(newPayment(models.PaymentStatusPending, 0), nil)
+				mStore.EXPECT().UpdatePaymentStatus("ref-1", []string{models.PaymentStatusPending}, models.PaymentStatusExpired).Return(true, nil)
+				mStore.EXPECT().MarkEventProcessed(payment_provider.ProviderFake, "evt_1", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "success refund paid payment",
+			eventType: "charge.refunded",
+			mockFunc: func() {
+				mStore.EXPECT().CreateEvent(gomock.Any()).Return(true, nil)
+				mStore.EXPECT().GetPaymentByReference("ref-1").Return(newPayment(models.PaymentStatusPaid, 3), nil)
+				mSubscription.EXPECT().RevokeSubscription(subscription.RevokeSubscriptionServiceRequest{
+					UserId:         1,
+					SubscriptionID: 3,
+				}).Return(nil)
+				mStore.EXPECT().UpdatePaymentStatus("ref-1", []string{models.PaymentStatusPaid}, models.PaymentStatusRefunded).Return(true, nil)
+				mStore.EXPECT().MarkEventProcessed(payment_provider.ProviderFake, "evt_1", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "success refund paid payment without subscription link",
+			eventType: "charge.refunded",
+			mockFunc: func() {
+				mStore.EXPECT().CreateEvent(gomock.Any()).Return(true, nil)
+				mStore.EXPECT().GetPaymentByReference("ref-1").Return(newPayment(models.PaymentStatusPaid, 0), nil)
+				mSubscription.EXPECT().ActivateSubscription(subscription.ActivateSubscriptionServiceRequest{
+					UserId:           1,
+					Plan:             models.PlanPremium,
+					PaymentReference: "ref-1",
+				}).Return(subscription.SubscriptionServiceInfo{SubscriptionID: 3}, nil)
+				mStore.EXPECT().SetPaymentSubscription("ref-1", 3).Return(nil)
+				mSubscription.EXPECT().RevokeSubscription(subscription.RevokeSubscriptionServiceRequest{
+					UserId:         1,
+					SubscriptionID: 3,
+				}).Return(nil)
+				mStore.EXPECT().UpdatePaymentStatus("ref-1", []string{models.PaymentStatusPaid}, models.PaymentStatusRefunded).Return(true, nil)
+				mStore.EXPECT().MarkEventProcessed(payment_provider.ProviderFake, "evt_1", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "success refund before completed",
+			eventType: "charge.refunded",
+			mockFunc: func() {
+				mStore.EXPECT().CreateEvent(gomock.Any()).Return(true, nil)
+				mStore.EXPECT().GetPaymentByReference("ref-1").Return(newPayment(models.PaymentStatusPending, 0), nil)
+				mStore.EXPECT().UpdatePaymentStatus("ref-1", []string{models.PaymentStatusPending}, models.PaymentStatusRefunded).Return(true, nil)
+				mStore.EXPECT().MarkEventProcessed(payment_provider.ProviderFake, "evt_1", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "success refund already refunded payment",
+			eventType: "charge.refunded",
+			mockFunc: func() {
+				mStore.EXPECT().CreateEvent(gomock.Any()).Return(true, nil)
+				mStore.EXPECT().GetPaymentByReference("ref-1").Return(newPayment(models.PaymentStatusRefunded, 3), nil)
+				mStore.EXPECT().MarkEventProcessed(payment_provider.ProviderFake, "evt_1", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "success payment not found",
+			eventType: "checkout.session.completed",
+			mockFunc: func() {
+				mStore.EXPECT().CreateEvent(gomock.Any()).Return(true, nil)
+				mStore.EXPECT().GetPaymentByReference("ref-1").Return(models.Payment{}, nil)
+				mStore.EXPECT().MarkEventProcessed(payment_provider.ProviderFake, "evt_1", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "success not handled event",
+			eventType: "customer.created",
+			mockFunc: func() {
+				mStore.EXPECT().CreateEvent(gomock.Any()).Return(true, nil)
+				mStore.EXPECT().MarkEventProcessed(payment_provider.ProviderFake, "evt_1", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "error invalid signature",
+			eventType: "checkout.session.completed",
+			signature: "t=1,v1=abc",
+			mockFunc:  func() {},
+			wantErr:   ErrInvalidWebhook,
+		},
+		{
+			name:      "error on create event",
+			eventType: "checkout.session.completed",
+			mockFunc: func() {
+				mStore.EXPECT().CreateEvent(gomock.Any()).Return(false, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:      "error on activate subscription",
+			eventType: "checkout.session.completed",
+			mockFunc: func() {
+				mStore.EXPECT().CreateEvent(gomock.Any()).Return(true, nil)
+				mStore.EXPECT().GetPaymentByReference("ref-1").Return(newPayment(models.PaymentStatusPending, 0), nil)
+				mStore.EXPECT().UpdatePaymentStatus("ref-1", []string{models.PaymentStatusPending}, models.PaymentStatusPaid).Return(true, nil)
+				mSubscription.EXPECT().ActivateSubscription(gomock.Any()).Return(subscription.SubscriptionServiceInfo{}, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:      "error on set payment subscription",
+			eventType: "checkout.session.completed",
+			mockFunc: func() {
+				mStore.EXPECT().CreateEvent(gomock.Any()).Return(true, nil)
+				mStore.EXPECT().GetPaymentByReference("ref-1").Return(newPayment(models.PaymentStatusPending, 0), nil)
+				mStore.EXPECT().UpdatePaymentStatus("ref-1", []string{models.PaymentStatusPending}, models.PaymentStatusPaid).Return(true, nil)
+				mSubscription.EXPECT().ActivateSubscription(gomock.Any()).Return(subscription.SubscriptionServiceInfo{SubscriptionID: 3}, nil)
+				mStore.EXPECT().SetPaymentSubscription("ref-1", 3).Return(fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:      "error refund when payment status is changed",
+			eventType: "charge.refunded",
+			mockFunc: func() {
+				mStore.EXPECT().CreateEvent(gomock.Any()).Return(true, nil)
+				mStore.EXPECT().GetPaymentByReference("ref-1").Return(newPayment(models.PaymentStatusPending, 0), nil)
+				mStore.EXPECT().UpdatePaymentStatus("ref-1", []string{models.PaymentStatusPending}, models.PaymentStatusRefunded).Return(false, nil)
+			},
+			wantErr: ErrPaymentStatusChanged,
+		},
+		{
+			name:      "error on revoke subscription",
+			eventType: "charge.refunded",
+			mockFunc: func() {
+				mStore.EXPECT().CreateEvent(gomock.Any()).Return(true, nil)
+				mStore.EXPECT().GetPaymentByReference("ref-1").Return(newPayment(models.PaymentStatusPaid, 3), nil)
+				mSubscription.EXPECT().RevokeSubscription(gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := PaymentService{
+				store:        mStore,
+				subscription: mSubscription,
+				provider:     provider,
+			}
+			payload, signature, err := provider.NewWebhook("evt_1", tt.eventType, session.SessionID)
+			if err != nil {
+				t.Fatalf("FakeProvider.NewWebhook() error = %v", err)
+			}
+			if len(tt.signature) > 0 {
+				signature = tt.signature
+			}
+			tt.mockFunc()
+			err = service.HandleWebhook(WebhookServiceRequest{
+				Payload:   payload,
+				Signature: signature,
+			})
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("PaymentService.HandleWebhook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package payment
+
+import (
+	"errors"
+)
+
+// list Service error
+var (
+	ErrDataNotFound         = errors.New("data not found")
+	ErrInvalidPlan          = errors.New("plan is invalid, the value should be PLUS or PREMIUM")
+	ErrAlreadySubscribed    = errors.New("user already subscribed to the plan")
+	ErrInvalidWebhook       = errors.New("invalid webhook request")
+	ErrPaymentStatusChanged = errors.New("payment status is changed by other event")
+)
+
+// defaultCurrency is currency of the checkout when the config is empty
+const defaultCurrency = "usd"
+
+// CheckoutConfig is list config for the checkout of the paid plan
+type CheckoutConfig struct {
+	// Prices is the price of each paid plan in the smallest unit of the currency, the plan without price cannot be bought
+	Prices     map[string]int64
+	Currency   string
+	SuccessURL string
+	CancelURL  string
+}
+
+// CheckoutServiceRequest is list parameter for create checkout of the plan
+type CheckoutServiceRequest struct {
+	UserId int
+	Plan   string
+}
+
+// CheckoutServiceInfo struct is list parameter info for hosted checkout page
+type CheckoutServiceInfo struct {
+	SessionID string
+	URL       string
+}
+
+// WebhookServiceRequest is list parameter for handle webhook event of the payment provider
+type WebhookServiceRequest struct {
+	Payload   []byte
+	Signature string
+}
@@ -0,0 +1,91 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/realtime/service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	realtime "gilsaputro/dating-apps/internal/service/realtime"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRealtimeServiceMethod is a mock of RealtimeServiceMethod interface.
+type MockRealtimeServiceMethod struct {
+	ctrl     *gomock.Controller
+	recorder *MockRealtimeServiceMethodMockRecorder
+}
+
+// MockRealtimeServiceMethodMockRecorder is the mock recorder for MockRealtimeServiceMethod.
+type MockRealtimeServiceMethodMockRecorder struct {
+	mock *MockRealtimeServiceMethod
+}
+
+// NewMockRealtimeServiceMethod creates a new mock instance.
+func NewMockRealtimeServiceMethod(ctrl *gomock.Controller) *MockRealtimeServiceMethod {
+	mock := &MockRealtimeServiceMethod{ctrl: ctrl}
+	mock.recorder = &MockRealtimeServiceMethodMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRealtimeServiceMethod) EXPECT() *MockRealtimeServiceMethodMockRecorder {
+	return m.recorder
+}
+
+// Listen mocks base method.
+func (m *MockRealtimeServiceMethod) Listen(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Listen", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Listen indicates an expected call of Listen.
+func (mr *MockRealtimeServiceMethodMockRecorder) Listen(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockRealtimeServiceMethod)(nil).Listen), ctx)
+}
+
+// PublishEvent mocks base method.
+func (m *MockRealtimeServiceMethod) PublishEvent(userID int, eventType string, data interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishEvent", userID, eventType, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishEvent indicates an expected call of PublishEvent.
+func (mr *MockRealtimeServiceMethodMockRecorder) PublishEvent(userID, eventType, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishEvent", reflect.TypeOf((*MockRealtimeServiceMethod)(nil).PublishEvent), userID, eventType, data)
+}
+
+// RunListener mocks base method.
+func (m *MockRealtimeServiceMethod) RunListener(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RunListener", ctx)
+}
+
+// RunListener indicates an expected call of RunListener.
+func (mr *MockRealtimeServiceMethodMockRecorder) RunListener(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunListener", reflect.TypeOf((*MockRealtimeServiceMethod)(nil).RunListener), ctx)
+}
+
+// Subscribe mocks base method.
+func (m *MockRealtimeServiceMethod) Subscribe(userID int) (<-chan realtime.Event, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", userID)
+	ret0, _ := ret[0].(<-chan realtime.Event)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockRealtimeServiceMethodMockRecorder) Subscribe(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockRealtimeServiceMethod)(nil).Subscribe), userID)
+}
@@ -0,0 +1,144 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"gilsaputro/dating-apps/pkg/redis"
+	"log"
+	"sync"
+	"time"
+)
+
+// RealtimeServiceMethod is list method for Realtime Service
+type RealtimeServiceMethod interface {
+	PublishEvent(userID int, eventType string, data interface{}) error
+	Subscribe(userID int) (<-chan Event, func())
+	Listen(ctx context.Context) error
+	RunListener(ctx context.Context)
+}
+
+// RealtimeService is list dependencies for realtime service
+type RealtimeService struct {
+	redis   redis.RedisMethod
+	channel string
+	// minBackoff and maxBackoff is the range of wait time before the listener is restarted after the error
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	mu         sync.RWMutex
+	// subscribers is list of connection channel of each user on this server instance
+	subscribers map[int]map[chan Event]struct{}
+}
+
+// NewRealtimeService is func to generate RealtimeServiceMethod interface
+func NewRealtimeService(redis redis.RedisMethod) RealtimeServiceMethod {
+	return &RealtimeService{
+		redis:       redis,
+		channel:     defaultChannel,
+		minBackoff:  defaultMinListenBackoff,
+		maxBackoff:  defaultMaxListenBackoff,
+		subscribers: make(map[int]map[chan Event]struct{}),
+	}
+}
+
+// PublishEvent is func to send the event to every connection of the user on all server instances
+func (r *RealtimeService) PublishEvent(userID int, eventType string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	message, err := json.Marshal(envelope{
+		UserID: userID,
+		Event: Event{
+			Type: eventType,
+			Data: payload,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.redis.Publish(r.channel, string(message))
+}
+
+// Subscribe is func to register a connection of the user, the returned func must be called when the connection is closed
+func (r *RealtimeService) Subscribe(userID int) (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBufferSize)
+
+	r.mu.Lock()
+	if _, ok := r.subscribers[userID]; !ok {
+		r.subscribers[userID] = make(map[chan Event]struct{})
+	}
+	r.subscribers[userID][ch] = struct{}{}
+	r.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			r.mu.Lock()
+			delete(r.subscribers[userID], ch)
+			if len(r.subscribers[userID]) == 0 {
+				delete(r.subscribers, userID)
+			}
+			r.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// Listen is func to receive the published event from redis and deliver it to the local connection, it blocks until the context is done
+func (r *RealtimeService) Listen(ctx context.Context) error {
+	return r.redis.Subscribe(ctx, r.channel, r.deliver)
+}
+
+// RunListener is func to keep the listener running until the context is done, the listener is restarted with
+// exponential backoff after the error so the event is delivered again once redis is back
+func (r *RealtimeService) RunListener(ctx context.Context) {
+	backoff := r.minBackoff
+	for {
+		startedAt := time.Now()
+		err := r.Listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		// the listener that already run long enough is healthy before, so the backoff start from the beginning
+		if time.Since(startedAt) > r.maxBackoff {
+			backoff = r.minBackoff
+		}
+
+		log.Println("[RealtimeService]-Error Listen, retry in", backoff, ":", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > r.maxBackoff {
+			backoff = r.maxBackoff
+		}
+	}
+}
+
+// deliver is func to push the published event to the connection of the user on this server instance
+func (r *RealtimeService) deliver(payload string) {
+	var message envelope
+	if err := json.Unmarshal([]byte(payload), &message); err != nil {
+		log.Println("[RealtimeService]-Invalid Event Payload :", err)
+		return
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for ch := range r.subscribers[message.UserID] {
+		select {
+		case ch <- message.Event:
+		default:
+			// the slow connection should not block other connection
+			log.Println("[RealtimeService]-Drop Event For User :", message.UserID)
+		}
+	}
+}
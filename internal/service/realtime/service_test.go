@@ -0,0 +1,143 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	mock_redis "gilsaputro/dating-apps/pkg/redis/mock"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestRealtimeService_PublishEvent(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	type args struct {
+		userID    int
+		eventType string
+		data      interface{}
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name: "success",
+			args: args{
+				userID:    1,
+				eventType: EventNewMatch,
+				data:      NewMatchEventData{PartnerID: 2},
+			},
+			mockFunc: func() {
+				rd.EXPECT().Publish(defaultChannel, `{"user_id":1,"event":{"type":"new_match","data":{"partner_id":2}}}`).Return(nil)
+			},
+		},
+		{
+			name: "error on publish",
+			args: args{
+				userID:    1,
+				eventType: EventLiked,
+				data:      LikedEventData{IsSuperLiked: true},
+			},
+			mockFunc: func() {
+				rd.EXPECT().Publish(defaultChannel, `{"user_id":1,"event":{"type":"liked","data":{"is_super_liked":true}}}`).Return(fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewRealtimeService(rd)
+			tt.mockFunc()
+			if err := s.PublishEvent(tt.args.userID, tt.args.eventType, tt.args.data); !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("RealtimeService.PublishEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRealtimeService_Listen(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	defer mockCtrl.Finish()
+
+	s := NewRealtimeService(rd)
+	events, unsubscribe := s.Subscribe(1)
+	otherEvents, unsubscribeOther := s.Subscribe(2)
+	defer unsubscribeOther()
+
+	rd.EXPECT().Subscribe(gomock.Any(), defaultChannel, gomock.Any()).DoAndReturn(func(ctx context.Context, channel string, handler func(payload string)) error {
+		handler(`invalid payload`)
+		handler(`{"user_id":1,"event":{"type":"new_message","data":{"id":3}}}`)
+		return nil
+	})
+
+	if err := s.Listen(context.Background()); err != nil {
+		t.Fatalf("RealtimeService.Listen() error = %v", err)
+	}
+
+	want := Event{Type: EventNewMessage, Data: json.RawMessage(`{"id":3}`)}
+	select {
+	case got := <-events:
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("RealtimeService.Listen() event = %v, want %v", got, want)
+		}
+	default:
+		t.Errorf("RealtimeService.Listen() expect event for the user")
+	}
+
+	select {
+	case got := <-otherEvents:
+		t.Errorf("RealtimeService.Listen() got unexpected event %v for other user", got)
+	default:
+	}
+
+	unsubscribe()
+	unsubscribe()
+	if _, ok := <-events; ok {
+		t.Errorf("RealtimeService.Subscribe() expect channel closed after unsubscribe")
+	}
+}
+
+func TestRealtimeService_RunListener(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	defer mockCtrl.Finish()
+
+	s := &RealtimeService{
+		redis:       rd,
+		channel:     defaultChannel,
+		minBackoff:  time.Millisecond,
+		maxBackoff:  time.Millisecond,
+		subscribers: make(map[int]map[chan Event]struct{}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gomock.InOrder(
+		rd.EXPECT().Subscribe(gomock.Any(), defaultChannel, gomock.Any()).Return(fmt.Errorf("some error")),
+		rd.EXPECT().Subscribe(gomock.Any(), defaultChannel, gomock.Any()).Return(nil),
+		rd.EXPECT().Subscribe(gomock.Any(), defaultChannel, gomock.Any()).DoAndReturn(func(ctx context.Context, channel string, handler func(payload string)) error {
+			cancel()
+			return ctx.Err()
+		}),
+	)
+
+	done := make(chan struct{})
+	go func() {
+		s.RunListener(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("RealtimeService.RunListener() expect stop after the context is done")
+	}
+}
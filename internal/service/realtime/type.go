@@ -0,0 +1,55 @@
+package realtime
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// list supported event type pushed to the user
+const (
+	EventNewMatch   = "new_match"
+	EventNewMessage = "new_message"
+	EventLiked      = "liked"
+)
+
+// defaultChannel is redis channel used to fan-out the event across server instances
+const defaultChannel = "dating-apps:events"
+
+// list wait time range before the listener is restarted after the error
+const (
+	defaultMinListenBackoff = time.Second
+	defaultMaxListenBackoff = 30 * time.Second
+)
+
+// subscriberBufferSize is number of pending event kept for each connection before the event is dropped
+const subscriberBufferSize = 16
+
+// Event is the payload pushed to the user connection
+type Event struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// envelope is the message published to redis channel
+type envelope struct {
+	UserID int   `json:"user_id"`
+	Event  Event `json:"event"`
+}
+
+// NewMatchEventData is data of new match event
+type NewMatchEventData struct {
+	PartnerID int `json:"partner_id"`
+}
+
+// LikedEventData is data of liked event, the liker is not shown so the event follow the likes received redaction
+type LikedEventData struct {
+	IsSuperLiked bool `json:"is_super_liked"`
+}
+
+// NewMessageEventData is data of new message event
+type NewMessageEventData struct {
+	MessageID   int    `json:"id"`
+	SenderID    int    `json:"sender_id"`
+	Content     string `json:"content"`
+	CreatedDate string `json:"created_date"`
+}
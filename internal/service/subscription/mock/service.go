@@ -0,0 +1,137 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/subscription/service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	subscription "gilsaputro/dating-apps/internal/service/subscription"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSubscriptionServiceMethod is a mock of SubscriptionServiceMethod interface.
+type MockSubscriptionServiceMethod struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionServiceMethodMockRecorder
+}
+
+// MockSubscriptionServiceMethodMockRecorder is the mock recorder for MockSubscriptionServiceMethod.
+type MockSubscriptionServiceMethodMockRecorder struct {
+	mock *MockSubscriptionServiceMethod
+}
+
+// NewMockSubscriptionServiceMethod creates a new mock instance.
+func NewMockSubscriptionServiceMethod(ctrl *gomock.Controller) *MockSubscriptionServiceMethod {
+	mock := &MockSubscriptionServiceMethod{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionServiceMethodMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptionServiceMethod) EXPECT() *MockSubscriptionServiceMethodMockRecorder {
+	return m.recorder
+}
+
+// ActivateSubscription mocks base method.
+func (m *MockSubscriptionServiceMethod) ActivateSubscription(arg0 subscription.ActivateSubscriptionServiceRequest) (subscription.SubscriptionServiceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateSubscription", arg0)
+	ret0, _ := ret[0].(subscription.SubscriptionServiceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivateSubscription indicates an expected call of ActivateSubscription.
+func (mr *MockSubscriptionServiceMethodMockRecorder) ActivateSubscription(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateSubscription", reflect.TypeOf((*MockSubscriptionServiceMethod)(nil).ActivateSubscription), arg0)
+}
+
+// CancelSubscription mocks base method.
+func (m *MockSubscriptionServiceMethod) CancelSubscription(arg0 subscription.CancelSubscriptionServiceRequest) (subscription.SubscriptionServiceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSubscription", arg0)
+	ret0, _ := ret[0].(subscription.SubscriptionServiceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelSubscription indicates an expected call of CancelSubscription.
+func (mr *MockSubscriptionServiceMethodMockRecorder) CancelSubscription(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSubscription", reflect.TypeOf((*MockSubscriptionServiceMethod)(nil).CancelSubscription), arg0)
+}
+
+// DowngradeExpired mocks base method.
+func (m *MockSubscriptionServiceMethod) DowngradeExpired(now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DowngradeExpired", now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DowngradeExpired indicates an expected call of DowngradeExpired.
+func (mr *MockSubscriptionServiceMethodMockRecorder) DowngradeExpired(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DowngradeExpired", reflect.TypeOf((*MockSubscriptionServiceMethod)(nil).DowngradeExpired), now)
+}
+
+// GetPlans mocks base method.
+func (m *MockSubscriptionServiceMethod) GetPlans() []subscription.PlanServiceInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlans")
+	ret0, _ := ret[0].([]subscription.PlanServiceInfo)
+	return ret0
+}
+
+// GetPlans indicates an expected call of GetPlans.
+func (mr *MockSubscriptionServiceMethodMockRecorder) GetPlans() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlans", reflect.TypeOf((*MockSubscriptionServiceMethod)(nil).GetPlans))
+}
+
+// GetSubscription mocks base method.
+func (m *MockSubscriptionServiceMethod) GetSubscription(arg0 subscription.GetSubscriptionServiceRequest) (subscription.SubscriptionServiceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscription", arg0)
+	ret0, _ := ret[0].(subscription.SubscriptionServiceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscription indicates an expected call of GetSubscription.
+func (mr *MockSubscriptionServiceMethodMockRecorder) GetSubscription(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscription", reflect.TypeOf((*MockSubscriptionServiceMethod)(nil).GetSubscription), arg0)
+}
+
+// RevokeSubscription mocks base method.
+func (m *MockSubscriptionServiceMethod) RevokeSubscription(arg0 subscription.RevokeSubscriptionServiceRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSubscription", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSubscription indicates an expected call of RevokeSubscription.
+func (mr *MockSubscriptionServiceMethodMockRecorder) RevokeSubscription(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSubscription", reflect.TypeOf((*MockSubscriptionServiceMethod)(nil).RevokeSubscription), arg0)
+}
+
+// RunDowngradeJob mocks base method.
+func (m *MockSubscriptionServiceMethod) RunDowngradeJob(ctx context.Context, interval time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RunDowngradeJob", ctx, interval)
+}
+
+// RunDowngradeJob indicates an expected call of RunDowngradeJob.
+func (mr *MockSubscriptionServiceMethodMockRecorder) RunDowngradeJob(ctx, interval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunDowngradeJob", reflect.TypeOf((*MockSubscriptionServiceMethod)(nil).RunDowngradeJob), ctx, interval)
+}
@@ -0,0 +1,367 @@
+package subscription
+
+import (
+	"context"
+	"gilsaputro/dating-apps/internal/store/subscription"
+	"gilsaputro/dating-apps/internal/store/tokencache"
+	"gilsaputro/dating-apps/internal/store/user"
+	"gilsaputro/dating-apps/models"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// SubscriptionServiceMethod is list method for Subscription Service
+type SubscriptionServiceMethod interface {
+	GetPlans() []PlanServiceInfo
+	GetSubscription(GetSubscriptionServiceRequest) (SubscriptionServiceInfo, error)
+	ActivateSubscription(ActivateSubscriptionServiceRequest) (SubscriptionServiceInfo, error)
+	RevokeSubscription(RevokeSubscriptionServiceRequest) error
+	CancelSubscription(CancelSubscriptionServiceRequest) (SubscriptionServiceInfo, error)
+	DowngradeExpired(now time.Time) (int, error)
+	RunDowngradeJob(ctx context.Context, interval time.Duration)
+}
+
+// SubscriptionService is list dependencies for subscription service
+type SubscriptionService struct {
+	store      subscription.SubscriptionStoreMethod
+	storeUser  user.UserStoreMethod
+	tokenCache tokencache.TokenCacheStoreMethod
+	period     time.Duration
+}
+
+// NewSubscriptionService is func to generate SubscriptionServiceMethod interface
+func NewSubscriptionService(store subscription.SubscriptionStoreMethod, storeUser user.UserStoreMethod, tokenCache tokencache.TokenCacheStoreMethod, period time.Duration) SubscriptionServiceMethod {
+	if period <= 0 {
+		period = defaultPeriod
+	}
+	return &SubscriptionService{
+		store:      store,
+		storeUser:  storeUser,
+		tokenCache: tokenCache,
+		period:     period,
+	}
+}
+
+// GetPlans is service level func to get list of plan tier and the entitlements
+func (s *SubscriptionService) GetPlans() []PlanServiceInfo {
+	result := make([]PlanServiceInfo, 0, len(plans))
+	for _, plan := range plans {
+		info := PlanServiceInfo{
+			Plan:         plan,
+			Entitlements: models.GetEntitlements(plan),
+		}
+		if models.IsValidPaidPlan(plan) {
+			info.PeriodInDay = int(s.period / (24 * time.Hour))
+		}
+		result = append(result, info)
+	}
+	return result
+}
+
+// GetSubscription is service level func to get the current plan and subscription of the user
+func (s *SubscriptionService) GetSubscription(request GetSubscriptionServiceRequest) (SubscriptionServiceInfo, error) {
+	if request.UserId <= 0 {
+		return SubscriptionServiceInfo{}, ErrDataNotFound
+	}
+
+	active, err := s.store.GetActiveSubscription(request.UserId)
+	if err != nil {
+		return SubscriptionServiceInfo{}, err
+	}
+
+	// the subscription that pass the expired time is not downgraded yet by the job, but it is not active anymore
+	if !active.IsActive(time.Now()) {
+		return SubscriptionServiceInfo{
+			Plan:         models.PlanFree,
+			Entitlements: models.GetEntitlements(models.PlanFree),
+		}, nil
+	}
+
+	return mapSubscriptionServiceInfo(active), nil
+}
+
+// ActivateSubscription is service level func to activate the paid plan of the user after the payment is done,
+// the period of the same plan is added after the current period and the subscription of the other plan is replaced by the new plan
+func (s *SubscriptionService) ActivateSubscription(request ActivateSubscriptionServiceRequest) (SubscriptionServiceInfo, error) {
+	if request.UserId <= 0 {
+		return SubscriptionServiceInfo{}, ErrDataNotFound
+	}
+
+	if !models.IsValidPaidPlan(request.Plan) {
+		return SubscriptionServiceInfo{}, ErrInvalidPlan
+	}
+
+	userInfo, err := s.storeUser.GetUserInfoByID(request.UserId)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return SubscriptionServiceInfo{}, ErrDataNotFound
+		}
+		return SubscriptionServiceInfo{}, err
+	}
+
+	now := time.Now()
+	if len(request.PaymentReference) > 0 {
+		stored, err := s.store.GetSubscriptionByPaymentReference(request.PaymentReference)
+		if err != nil {
+			return SubscriptionServiceInfo{}, err
+		}
+
+		// the period is already created by the previous attempt, only the plan of the user is completed
+		if stored.ID > 0 {
+			if stored.IsActive(now) && userInfo.GetPlan() != stored.Plan {
+				err = s.changePlan(userInfo, stored.Plan, now)
+				if err != nil {
+					return SubscriptionServiceInfo{}, err
+				}
+			}
+			return mapSubscriptionServiceInfo(stored), nil
+		}
+	}
+
+	active, err := s.store.GetActiveSubscription(request.UserId)
+	if err != nil {
+		return SubscriptionServiceInfo{}, err
+	}
+
+	startedAt := now
+	if active.IsActive(now) && active.Plan == request.Plan {
+		// the paid period is stored as the next period, so the refund of it does not revoke the current period
+		startedAt = active.ExpiredAt
+	} else if active.ID > 0 {
+		// the previous subscription is expired first, so the downgrade job does not downgrade the new plan
+		err = s.expireAll(request.UserId, now)
+		if err != nil {
+			return SubscriptionServiceInfo{}, err
+		}
+	}
+
+	result, err := s.store.CreateSubscription(models.Subscription{
+		UserID:           userInfo.ID,
+		Plan:             request.Plan,
+		Status:           models.SubscriptionStatusActive,
+		StartedAt:        startedAt,
+		ExpiredAt:        startedAt.Add(s.period),
+		PaymentReference: request.PaymentReference,
+	})
+	if err != nil {
+		return SubscriptionServiceInfo{}, err
+	}
+
+	if userInfo.GetPlan() != request.Plan {
+		err = s.changePlan(userInfo, request.Plan, now)
+		if err != nil {
+			return SubscriptionServiceInfo{}, err
+		}
+	}
+
+	return mapSubscriptionServiceInfo(result), nil
+}
+
+// RevokeSubscription is service level func to expire the period immediately and move the user back
+// to the plan of the remaining period, it is used when the payment of the period is refunded
+func (s *SubscriptionService) RevokeSubscription(request RevokeSubscriptionServiceRequest) error {
+	if request.UserId <= 0 || request.SubscriptionID <= 0 {
+		return ErrDataNotFound
+	}
+
+	_, err := s.downgrade(models.Subscription{
+		Model:  gorm.Model{ID: uint(request.SubscriptionID)},
+		UserID: uint(request.UserId),
+	}, time.Now())
+	return err
+}
+
+// CancelSubscription is service level func to cancel the active subscription of the user,
+// the plan is still active until the subscription is expired
+func (s *SubscriptionService) CancelSubscription(request CancelSubscriptionServiceRequest) (SubscriptionServiceInfo, error) {
+	if request.UserId <= 0 {
+		return SubscriptionServiceInfo{}, ErrDataNotFound
+	}
+
+	now := time.Now()
+	active, err := s.store.GetActiveSubscription(request.UserId)
+	if err != nil {
+		return SubscriptionServiceInfo{}, err
+	}
+
+	if !active.IsActive(now) || active.Status != models.SubscriptionStatusActive {
+		return SubscriptionServiceInfo{}, ErrSubscriptionNotActive
+	}
+
+	active.Status = models.SubscriptionStatusCancelled
+	active.CancelledAt = &now
+	err = s.store.UpdateSubscription(active)
+	if err != nil {
+		return SubscriptionServiceInfo{}, err
+	}
+
+	return mapSubscriptionServiceInfo(active), nil
+}
+
+// DowngradeExpired is service level func to expire the subscription that pass the expired time
+// and move the user back to the plan of the remaining subscription, it returns number of user downgraded
+func (s *SubscriptionService) DowngradeExpired(now time.Time) (int, error) {
+	var total int
+	for {
+		expired, err := s.store.GetExpiredSubscriptions(now, downgradeBatchSize)
+		if err != nil {
+			return total, err
+		}
+
+		for _, data := range expired {
+			downgraded, err := s.downgrade(data, now)
+			if err != nil {
+				return total, err
+			}
+			if downgraded {
+				total++
+			}
+		}
+
+		if len(expired) < downgradeBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// RunDowngradeJob is func to run the downgrade periodically until the context is done
+func (s *SubscriptionService) RunDowngradeJob(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		total, err := s.DowngradeExpired(time.Now())
+		if err != nil {
+			log.Println("[SubscriptionService]-Error Downgrade Expired Subscription :", err)
+		} else if total > 0 {
+			log.Println("[SubscriptionService]-Downgrade Expired Subscription :", total)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// downgrade is func to change the plan of the user and expire the subscription, it returns false when
+// the subscription is already expired by other process or the plan of the user is not changed.
+// The plan is changed before the subscription is expired, so the failed downgrade is retried by the next run
+func (s *SubscriptionService) downgrade(expired models.Subscription, now time.Time) (bool, error) {
+	list, err := s.store.GetActiveSubscriptions(int(expired.UserID))
+	if err != nil {
+		return false, err
+	}
+
+	plan, found := remainingPlan(list, expired.ID, now)
+	if !found {
+		return false, nil
+	}
+
+	var downgraded bool
+	userInfo, err := s.storeUser.GetUserInfoByID(int(expired.UserID))
+	if err != nil && !strings.Contains(err.Error(), "not found") {
+		return false, err
+	}
+
+	// the deleted user has nothing to downgrade
+	if err == nil && userInfo.GetPlan() != plan {
+		err = s.changePlan(userInfo, plan, now)
+		if err != nil {
+			return false, err
+		}
+		downgraded = true
+	}
+
+	ok, err := s.store.ExpireSubscription(int(expired.ID), now)
+	if err != nil {
+		return false, err
+	}
+
+	return downgraded && ok, nil
+}
+
+// remainingPlan is func to get the plan of the user after the subscription is expired, the later period is moved
+// back by the unused time of the expired subscription. It returns false when the subscription is already expired
+func remainingPlan(list []models.Subscription, expiredID uint, now time.Time) (string, bool) {
+	var expired models.Subscription
+	for _, data := range list {
+		if data.ID == expiredID {
+			expired = data
+		}
+	}
+
+	if expired.ID == 0 {
+		return "", false
+	}
+
+	unused := expired.ExpiredAt.Sub(now)
+	if expired.StartedAt.After(now) {
+		unused = expired.ExpiredAt.Sub(expired.StartedAt)
+	}
+
+	plan := models.PlanFree
+	var latest time.Time
+	for _, data := range list {
+		if data.ID == expired.ID {
+			continue
+		}
+
+		if unused > 0 && !data.StartedAt.Before(expired.ExpiredAt) {
+			data.ExpiredAt = data.ExpiredAt.Add(-unused)
+		}
+
+		if data.IsActive(now) && data.ExpiredAt.After(latest) {
+			plan = data.Plan
+			latest = data.ExpiredAt
+		}
+	}
+
+	return plan, true
+}
+
+// expireAll is func to expire every period of the user that is not expired yet
+func (s *SubscriptionService) expireAll(userID int, now time.Time) error {
+	list, err := s.store.GetActiveSubscriptions(userID)
+	if err != nil {
+		return err
+	}
+
+	for _, data := range list {
+		_, err = s.store.ExpireSubscription(int(data.ID), now)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// changePlan is func to update the plan of the user, the token issued before is refreshed to get the new plan claim
+func (s *SubscriptionService) changePlan(userInfo models.User, plan string, now time.Time) error {
+	err := s.storeUser.UpdatePlan(int(userInfo.ID), plan)
+	if err != nil {
+		return err
+	}
+
+	return s.tokenCache.SetClaimsChangedAt(int(userInfo.ID), now)
+}
+
+// mapSubscriptionServiceInfo is func to map the subscription into the service info
+func mapSubscriptionServiceInfo(data models.Subscription) SubscriptionServiceInfo {
+	info := SubscriptionServiceInfo{
+		SubscriptionID: int(data.ID),
+		Plan:           data.Plan,
+		Status:         data.Status,
+		StartedDate:    data.StartedAt.String(),
+		ExpiredDate:    data.ExpiredAt.String(),
+		Entitlements:   models.GetEntitlements(data.Plan),
+	}
+	if data.CancelledAt != nil {
+		info.CancelledDate = data.CancelledAt.String()
+	}
+	return info
+}
@@ -0,0 +1,782 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"gilsaputro/dating-apps/internal/store/subscription"
+	mock_subscription "gilsaputro/dating-apps/internal/store/subscription/mock"
+	"gilsaputro/dating-apps/internal/store/tokencache"
+	mock_tokencache "gilsaputro/dating-apps/internal/store/tokencache/mock"
+	"gilsaputro/dating-apps/internal/store/user"
+	mock_user "gilsaputro/dating-apps/internal/store/user/mock"
+	"gilsaputro/dating-apps/models"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+)
+
+func TestNewSubscriptionService(t *testing.T) {
+	type args struct {
+		store      subscription.SubscriptionStoreMethod
+		storeUser  user.UserStoreMethod
+		tokenCache tokencache.TokenCacheStoreMethod
+		period     time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+		want SubscriptionServiceMethod
+	}{
+		{
+			name: "success flow",
+			args: args{
+				period: 7 * 24 * time.Hour,
+			},
+			want: &SubscriptionService{
+				period: 7 * 24 * time.Hour,
+			},
+		},
+		{
+			name: "success with default period",
+			args: args{},
+			want: &SubscriptionService{
+				period: defaultPeriod,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewSubscriptionService(tt.args.store, tt.args.storeUser, tt.args.tokenCache, tt.args.period); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewSubscriptionService() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionService_GetPlans(t *testing.T) {
+	service := NewSubscriptionService(nil, nil, nil, 0)
+	want := []PlanServiceInfo{
+		{
+			Plan: models.PlanFree,
+		},
+		{
+			Plan:        models.PlanPlus,
+			PeriodInDay: 30,
+			Entitlements: models.Entitlements{
+				UnlimitedSwipe: true,
+				Rewind:         true,
+			},
+		},
+		{
+			Plan:        models.PlanPremium,
+			PeriodInDay: 30,
+			Entitlements: models.Entitlements{
+				UnlimitedSwipe:   true,
+				Rewind:           true,
+				SeeLikesReceived: true,
+				ExtraSuperLike:   true,
+			},
+		},
+	}
+	if got := service.GetPlans(); !reflect.DeepEqual(got, want) {
+		t.Errorf("SubscriptionService.GetPlans() = %v, want %v", got, want)
+	}
+}
+
+func TestSubscriptionService_GetSubscription(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mStore := mock_subscription.NewMockSubscriptionStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	startedAt := time.Now().Add(-time.Hour)
+	expiredAt := time.Now().Add(time.Hour)
+	tests := []struct {
+		name     string
+		request  GetSubscriptionServiceRequest
+		mockFunc func()
+		want     SubscriptionServiceInfo
+		wantErr  error
+	}{
+		{
+			name:    "success flow",
+			request: GetSubscriptionServiceRequest{UserId: 1},
+			mockFunc: func() {
+				mStore.EXPECT().GetActiveSubscription(1).Return(models.Subscription{
+					Model:     gorm.Model{ID: 2},
+					UserID:    1,
+					Plan:      models.PlanPlus,
+					Status:    models.SubscriptionStatusActive,
+					StartedAt: startedAt,
+					ExpiredAt: expiredAt,
+				}, nil)
+			},
+			want: SubscriptionServiceInfo{
+				SubscriptionID: 2,
+				Plan:           models.PlanPlus,
+				Status:         models.SubscriptionStatusActive,
+				StartedDate:    startedAt.String(),
+				ExpiredDate:    expiredAt.String(),
+				Entitlements:   models.GetEntitlements(models.PlanPlus),
+			},
+		},
+		{
+			name:    "success without subscription",
+			request: GetSubscriptionServiceRequest{UserId: 1},
+			mockFunc: func() {
+				mStore.EXPECT().GetActiveSubscription(1).Return(models.Subscription{}, nil)
+			},
+			want: SubscriptionServiceInfo{
+				Plan: models.PlanFree,
+			},
+		},
+		{
+			name:    "success with subscription pass the expired time",
+			request: GetSubscriptionServiceRequest{UserId: 1},
+			mockFunc: func() {
+				mStore.EXPECT().GetActiveSubscription(1).Return(models.Subscription{
+					Model:     gorm.Model{ID: 2},
+					UserID:    1,
+					Plan:      models.PlanPlus,
+					Status:    models.SubscriptionStatusActive,
+					StartedAt: startedAt,
+					ExpiredAt: startedAt,
+				}, nil)
+			},
+			want: SubscriptionServiceInfo{
+				Plan: models.PlanFree,
+			},
+		},
+		{
+			name:     "error invalid user id",
+			request:  GetSubscriptionServiceRequest{},
+			mockFunc: func() {},
+			wantErr:  ErrDataNotFound,
+		},
+		{
+			name:    "error on store",
+			request: GetSubscriptionServiceRequest{UserId: 1},
+			mockFunc: func() {
+				mStore.EXPECT().GetActiveSubscription(1).Return(models.Subscription{}, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := SubscriptionService{
+				store: mStore,
+			}
+			tt.mockFunc()
+			got, err := service.GetSubscription(tt.request)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("SubscriptionService.GetSubscription() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SubscriptionService.GetSubscription() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionService_ActivateSubscription(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mStore := mock_subscription.NewMockSubscriptionStoreMethod(mockCtrl)
+	mUser := mock_user.NewMockUserStoreMethod(mockCtrl)
+	mTokenCache := mock_tokencache.NewMockTokenCacheStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	userInfo := models.User{
+		Model:    gorm.Model{ID: 1},
+		Username: "username",
+	}
+	request := ActivateSubscriptionServiceRequest{
+		UserId: 1,
+		Plan:   models.PlanPremium,
+	}
+	expectCreate := func() {
+		mStore.EXPECT().CreateSubscription(gomock.Any()).DoAndReturn(func(data models.Subscription) (models.Subscription, error) {
+			if data.UserID != 1 || data.Plan != models.PlanPremium || data.Status != models.SubscriptionStatusActive ||
+				time.Since(data.StartedAt) > time.Minute || data.ExpiredAt.Sub(data.StartedAt) != 30*24*time.Hour {
+				t.Errorf("SubscriptionService.ActivateSubscription() subscription = %+v", data)
+			}
+			data.ID = 3
+			return data, nil
+		})
+	}
+	premiumUser := userInfo
+	premiumUser.Plan = models.PlanPremium
+	expiredAt := time.Now().Add(time.Hour)
+	cancelledAt := time.Now()
+	tests := []struct {
+		name     string
+		request  ActivateSubscriptionServiceRequest
+		mockFunc func()
+		wantID   int
+		wantPlan string
+		wantErr  error
+	}{
+		{
+			name:    "success flow",
+			request: request,
+			mockFunc: func() {
+				mUser.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mStore.EXPECT().GetActiveSubscription(1).Return(models.Subscription{}, nil)
+				expectCreate()
+				mUser.EXPECT().UpdatePlan(1, models.PlanPremium).Return(nil)
+				mTokenCache.EXPECT().SetClaimsChangedAt(1, gomock.Any()).Return(nil)
+			},
+			wantID:   3,
+			wantPlan: models.PlanPremium,
+		},
+		{
+			name:    "success change plan",
+			request: request,
+			mockFunc: func() {
+				mUser.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mStore.EXPECT().GetActiveSubscription(1).Return(models.Subscription{
+					Model:     gorm.Model{ID: 2},
+					Plan:      models.PlanPlus,
+					Status:    models.SubscriptionStatusActive,
+					ExpiredAt: expiredAt,
+				}, nil)
+				mStore.EXPECT().GetActiveSubscriptions(1).Return([]models.Subscription{{Model: gorm.Model{ID: 2}}, {Model: gorm.Model{ID: 4}}}, nil)
+				mStore.EXPECT().ExpireSubscription(2, gomock.Any()).Return(true, nil)
+				mStore.EXPECT().ExpireSubscription(4, gomock.Any()).Return(true, nil)
+				expectCreate()
+				mUser.EXPECT().UpdatePlan(1, models.PlanPremium).Return(nil)
+				mTokenCache.EXPECT().SetClaimsChangedAt(1, gomock.Any()).Return(nil)
+			},
+			wantID:   3,
+			wantPlan: models.PlanPremium,
+		},
+		{
+			name:    "success renew same plan as next period",
+			request: request,
+			mockFunc: func() {
+				mUser.EXPECT().GetUserInfoByID(1).Return(premiumUser, nil)
+				mStore.EXPECT().GetActiveSubscription(1).Return(models.Subscription{
+					Model:       gorm.Model{ID: 2},
+					UserID:      1,
+					Plan:        models.PlanPremium,
+					Status:      models.SubscriptionStatusCancelled,
+					ExpiredAt:   expiredAt,
+					CancelledAt: &cancelledAt,
+				}, nil)
+				mStore.EXPECT().CreateSubscription(models.Subscription{
+					UserID:    1,
+					Plan:      models.PlanPremium,
+					Status:    models.SubscriptionStatusActive,
+					StartedAt: expiredAt,
+					ExpiredAt: expiredAt.Add(defaultPeriod),
+				}).Return(models.Subscription{Model: gorm.Model{ID: 3}, Plan: models.PlanPremium}, nil)
+			},
+			wantID:   3,
+			wantPlan: models.PlanPremium,
+		},
+		{
+			name:    "success payment already activated",
+			request: ActivateSubscriptionServiceRequest{UserId: 1, Plan: models.PlanPremium, PaymentReference: "ref-1"},
+			mockFunc: func() {
+				mUser.EXPECT().GetUserInfoByID(1).Return(premiumUser, nil)
+				mStore.EXPECT().GetSubscriptionByPaymentReference("ref-1").Return(models.Subscription{
+					Model:            gorm.Model{ID: 2},
+					Plan:             models.PlanPremium,
+					Status:           models.SubscriptionStatusActive,
+					ExpiredAt:        expiredAt,
+					PaymentReference: "ref-1",
+				}, nil)
+			},
+			wantID:   2,
+			wantPlan: models.PlanPremium,
+		},
+		{
+			name:    "success payment already activated finish the plan change",
+			request: ActivateSubscriptionServiceRequest{UserId: 1, Plan: models.PlanPremium, PaymentReference: "ref-1"},
+			mockFunc: func() {
+				mUser.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mStore.EXPECT().GetSubscriptionByPaymentReference("ref-1").Return(models.Subscription{
+					Model:            gorm.Model{ID: 2},
+					Plan:             models.PlanPremium,
+					Status:           models.SubscriptionStatusActive,
+					ExpiredAt:        expiredAt,
+					PaymentReference: "ref-1",
+				}, nil)
+				mUser.EXPECT().UpdatePlan(1, models.PlanPremium).Return(nil)
+				mTokenCache.EXPECT().SetClaimsChangedAt(1, gomock.Any()).Return(nil)
+			},
+			wantID:   2,
+			wantPlan: models.PlanPremium,
+		},
+		{
+			name:    "success new payment store the reference",
+			request: ActivateSubscriptionServiceRequest{UserId: 1, Plan: models.PlanPremium, PaymentReference: "ref-1"},
+			mockFunc: func() {
+				mUser.EXPECT().GetUserInfoByID(1).Return(premiumUser, nil)
+				mStore.EXPECT().GetSubscriptionByPaymentReference("ref-1").Return(models.Subscription{}, nil)
+				mStore.EXPECT().GetActiveSubscription(1).Return(models.Subscription{}, nil)
+				mStore.EXPECT().CreateSubscription(gomock.Any()).DoAndReturn(func(data models.Subscription) (models.Subscription, error) {
+					if data.PaymentReference != "ref-1" {
+						t.Errorf("SubscriptionService.ActivateSubscription() subscription = %+v", data)
+					}
+					data.ID = 3
+					return data, nil
+				})
+			},
+			wantID:   3,
+			wantPlan: models.PlanPremium,
+		},
+		{
+			name:    "error on get subscription by payment reference",
+			request: ActivateSubscriptionServiceRequest{UserId: 1, Plan: models.PlanPremium, PaymentReference: "ref-1"},
+			mockFunc: func() {
+				mUser.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mStore.EXPECT().GetSubscriptionByPaymentReference("ref-1").Return(models.Subscription{}, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:     "error invalid user id",
+			request:  ActivateSubscriptionServiceRequest{Plan: models.PlanPremium},
+			mockFunc: func() {},
+			wantErr:  ErrDataNotFound,
+		},
+		{
+			name:     "error invalid plan",
+			request:  ActivateSubscriptionServiceRequest{UserId: 1, Plan: models.PlanFree},
+			mockFunc: func() {},
+			wantErr:  ErrInvalidPlan,
+		},
+		{
+			name:    "error user not found",
+			request: request,
+			mockFunc: func() {
+				mUser.EXPECT().GetUserInfoByID(1).Return(models.User{}, gorm.ErrRecordNotFound)
+			},
+			wantErr: ErrDataNotFound,
+		},
+		{
+			name:    "error on get active subscription",
+			request: request,
+			mockFunc: func() {
+				mUser.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mStore.EXPECT().GetActiveSubscription(1).Return(models.Subscription{}, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:    "error on expire previous plan",
+			request: request,
+			mockFunc: func() {
+				mUser.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mStore.EXPECT().GetActiveSubscription(1).Return(models.Subscription{
+					Model:     gorm.Model{ID: 2},
+					Plan:      models.PlanPlus,
+					Status:    models.SubscriptionStatusActive,
+					ExpiredAt: expiredAt,
+				}, nil)
+				mStore.EXPECT().GetActiveSubscriptions(1).Return(nil, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:    "error on create subscription",
+			request: request,
+			mockFunc: func() {
+				mUser.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mStore.EXPECT().GetActiveSubscription(1).Return(models.Subscription{}, nil)
+				mStore.EXPECT().CreateSubscription(gomock.Any()).Return(models.Subscription{}, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:    "error on update user",
+			request: request,
+			mockFunc: func() {
+				mUser.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mStore.EXPECT().GetActiveSubscription(1).Return(models.Subscription{}, nil)
+				expectCreate()
+				mUser.EXPECT().UpdatePlan(1, models.PlanPremium).Return(fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := SubscriptionService{
+				store:      mStore,
+				storeUser:  mUser,
+				tokenCache: mTokenCache,
+				period:     defaultPeriod,
+			}
+			tt.mockFunc()
+			got, err := service.ActivateSubscription(tt.request)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("SubscriptionService.ActivateSubscription() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.SubscriptionID != tt.wantID || got.Plan != tt.wantPlan {
+				t.Errorf("SubscriptionService.ActivateSubscription() = %+v, want id %v plan %v", got, tt.wantID, tt.wantPlan)
+			}
+		})
+	}
+}
+
+func TestSubscriptionService_RevokeSubscription(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mStore := mock_subscription.NewMockSubscriptionStoreMethod(mockCtrl)
+	mUser := mock_user.NewMockUserStoreMethod(mockCtrl)
+	mTokenCache := mock_tokencache.NewMockTokenCacheStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	premiumUser := models.User{
+		Model: gorm.Model{ID: 1},
+		Plan:  models.PlanPremium,
+	}
+	freeUser := premiumUser
+	freeUser.Plan = models.PlanFree
+	request := RevokeSubscriptionServiceRequest{
+		UserId:         1,
+		SubscriptionID: 2,
+	}
+	current := models.Subscription{
+		Model:     gorm.Model{ID: 2},
+		UserID:    1,
+		Plan:      models.PlanPremium,
+		Status:    models.SubscriptionStatusActive,
+		StartedAt: time.Now().Add(-time.Hour),
+		ExpiredAt: time.Now().Add(time.Hour),
+	}
+	tests := []struct {
+		name     string
+		request  RevokeSubscriptionServiceRequest
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name:    "success flow",
+			request: request,
+			mockFunc: func() {
+				mStore.EXPECT().GetActiveSubscriptions(1).Return([]models.Subscription{current}, nil)
+				mUser.EXPECT().GetUserInfoByID(1).Return(premiumUser, nil)
+				mUser.EXPECT().UpdatePlan(1, models.PlanFree).Return(nil)
+				mTokenCache.EXPECT().SetClaimsChangedAt(1, gomock.Any()).Return(nil)
+				mStore.EXPECT().ExpireSubscription(2, gomock.Any()).Return(true, nil)
+			},
+		},
+		{
+			name:    "success revoke renewal keep current period",
+			request: request,
+			mockFunc: func() {
+				previous := current
+				previous.ID = 1
+				renewal := current
+				renewal.StartedAt = previous.ExpiredAt
+				renewal.ExpiredAt = previous.ExpiredAt.Add(2 * time.Hour)
+				mStore.EXPECT().GetActiveSubscriptions(1).Return([]models.Subscription{previous, renewal}, nil)
+				mUser.EXPECT().GetUserInfoByID(1).Return(premiumUser, nil)
+				mStore.EXPECT().ExpireSubscription(2, gomock.Any()).Return(true, nil)
+			},
+		},
+		{
+			name:    "success revoke current period keep renewal moved back",
+			request: request,
+			mockFunc: func() {
+				renewal := current
+				renewal.ID = 3
+				renewal.StartedAt = current.ExpiredAt
+				renewal.ExpiredAt = current.ExpiredAt.Add(time.Hour)
+				mStore.EXPECT().GetActiveSubscriptions(1).Return([]models.Subscription{current, renewal}, nil)
+				mUser.EXPECT().GetUserInfoByID(1).Return(premiumUser, nil)
+				mStore.EXPECT().ExpireSubscription(2, gomock.Any()).Return(true, nil)
+			},
+		},
+		{
+			name:    "success already expired",
+			request: request,
+			mockFunc: func() {
+				mStore.EXPECT().GetActiveSubscriptions(1).Return([]models.Subscription{}, nil)
+			},
+		},
+		{
+			name:     "error invalid request",
+			request:  RevokeSubscriptionServiceRequest{UserId: 1},
+			mockFunc: func() {},
+			wantErr:  ErrDataNotFound,
+		},
+		{
+			name:    "error on get active subscriptions",
+			request: request,
+			mockFunc: func() {
+				mStore.EXPECT().GetActiveSubscriptions(1).Return(nil, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:    "error on update user keep the subscription to retry",
+			request: request,
+			mockFunc: func() {
+				mStore.EXPECT().GetActiveSubscriptions(1).Return([]models.Subscription{current}, nil)
+				mUser.EXPECT().GetUserInfoByID(1).Return(premiumUser, nil)
+				mUser.EXPECT().UpdatePlan(1, models.PlanFree).Return(fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:    "error on expire subscription",
+			request: request,
+			mockFunc: func() {
+				mStore.EXPECT().GetActiveSubscriptions(1).Return([]models.Subscription{current}, nil)
+				mUser.EXPECT().GetUserInfoByID(1).Return(freeUser, nil)
+				mStore.EXPECT().ExpireSubscription(2, gomock.Any()).Return(false, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := SubscriptionService{
+				store:      mStore,
+				storeUser:  mUser,
+				tokenCache: mTokenCache,
+				period:     defaultPeriod,
+			}
+			tt.mockFunc()
+			if err := service.RevokeSubscription(tt.request); !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("SubscriptionService.RevokeSubscription() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSubscriptionService_CancelSubscription(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mStore := mock_subscription.NewMockSubscriptionStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	active := models.Subscription{
+		Model:     gorm.Model{ID: 2},
+		UserID:    1,
+		Plan:      models.PlanPlus,
+		Status:    models.SubscriptionStatusActive,
+		ExpiredAt: time.Now().Add(time.Hour),
+	}
+	cancelled := active
+	cancelled.Status = models.SubscriptionStatusCancelled
+	tests := []struct {
+		name       string
+		request    CancelSubscriptionServiceRequest
+		mockFunc   func()
+		wantStatus string
+		wantErr    error
+	}{
+		{
+			name:    "success flow",
+			request: CancelSubscriptionServiceRequest{UserId: 1},
+			mockFunc: func() {
+				mStore.EXPECT().GetActiveSubscription(1).Return(active, nil)
+				mStore.EXPECT().UpdateSubscription(gomock.Any()).DoAndReturn(func(data models.Subscription) error {
+					if data.ID != 2 || data.Status != models.SubscriptionStatusCancelled || data.CancelledAt == nil {
+						t.Errorf("SubscriptionService.CancelSubscription() subscription = %+v", data)
+					}
+					return nil
+				})
+			},
+			wantStatus: models.SubscriptionStatusCancelled,
+		},
+		{
+			name:     "error invalid user id",
+			request:  CancelSubscriptionServiceRequest{},
+			mockFunc: func() {},
+			wantErr:  ErrDataNotFound,
+		},
+		{
+			name:    "error without subscription",
+			request: CancelSubscriptionServiceRequest{UserId: 1},
+			mockFunc: func() {
+				mStore.EXPECT().GetActiveSubscription(1).Return(models.Subscription{}, nil)
+			},
+			wantErr: ErrSubscriptionNotActive,
+		},
+		{
+			name:    "error already cancelled",
+			request: CancelSubscriptionServiceRequest{UserId: 1},
+			mockFunc: func() {
+				mStore.EXPECT().GetActiveSubscription(1).Return(cancelled, nil)
+			},
+			wantErr: ErrSubscriptionNotActive,
+		},
+		{
+			name:    "error on update",
+			request: CancelSubscriptionServiceRequest{UserId: 1},
+			mockFunc: func() {
+				mStore.EXPECT().GetActiveSubscription(1).Return(active, nil)
+				mStore.EXPECT().UpdateSubscription(gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := SubscriptionService{
+				store: mStore,
+			}
+			tt.mockFunc()
+			got, err := service.CancelSubscription(tt.request)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("SubscriptionService.CancelSubscription() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Status != tt.wantStatus {
+				t.Errorf("SubscriptionService.CancelSubscription() status = %v, want %v", got.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestSubscriptionService_DowngradeExpired(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mStore := mock_subscription.NewMockSubscriptionStoreMethod(mockCtrl)
+	mUser := mock_user.NewMockUserStoreMethod(mockCtrl)
+	mTokenCache := mock_tokencache.NewMockTokenCacheStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	now := time.Unix(1702592000, 0)
+	expired := []models.Subscription{
+		{Model: gorm.Model{ID: 2}, UserID: 1, Plan: models.PlanPremium, Status: models.SubscriptionStatusActive, StartedAt: now.Add(-time.Hour), ExpiredAt: now},
+		{Model: gorm.Model{ID: 3}, UserID: 4, Plan: models.PlanPlus, Status: models.SubscriptionStatusActive, StartedAt: now.Add(-time.Hour), ExpiredAt: now},
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     int
+		wantErr  error
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				mStore.EXPECT().GetExpiredSubscriptions(now, downgradeBatchSize).Return(expired, nil)
+				mStore.EXPECT().GetActiveSubscriptions(1).Return(expired[:1], nil)
+				mUser.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}, Plan: models.PlanPremium}, nil)
+				mUser.EXPECT().UpdatePlan(1, models.PlanFree).Return(nil)
+				mTokenCache.EXPECT().SetClaimsChangedAt(1, now).Return(nil)
+				mStore.EXPECT().ExpireSubscription(2, now).Return(true, nil)
+				// the other instance already expired the subscription
+				mStore.EXPECT().GetActiveSubscriptions(4).Return([]models.Subscription{}, nil)
+			},
+			want: 1,
+		},
+		{
+			name: "success move to the remaining plan",
+			mockFunc: func() {
+				mStore.EXPECT().GetExpiredSubscriptions(now, downgradeBatchSize).Return(expired[:1], nil)
+				mStore.EXPECT().GetActiveSubscriptions(1).Return([]models.Subscription{expired[0], {
+					Model:     gorm.Model{ID: 5},
+					UserID:    1,
+					Plan:      models.PlanPlus,
+					Status:    models.SubscriptionStatusActive,
+					StartedAt: now.Add(-time.Hour),
+					ExpiredAt: now.Add(time.Hour),
+				}}, nil)
+				mUser.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}, Plan: models.PlanPremium}, nil)
+				mUser.EXPECT().UpdatePlan(1, models.PlanPlus).Return(nil)
+				mTokenCache.EXPECT().SetClaimsChangedAt(1, now).Return(nil)
+				mStore.EXPECT().ExpireSubscription(2, now).Return(true, nil)
+			},
+			want: 1,
+		},
+		{
+			name: "success user is deleted",
+			mockFunc: func() {
+				mStore.EXPECT().GetExpiredSubscriptions(now, downgradeBatchSize).Return(expired[:1], nil)
+				mStore.EXPECT().GetActiveSubscriptions(1).Return(expired[:1], nil)
+				mUser.EXPECT().GetUserInfoByID(1).Return(models.User{}, gorm.ErrRecordNotFound)
+				mStore.EXPECT().ExpireSubscription(2, now).Return(true, nil)
+			},
+			want: 0,
+		},
+		{
+			name: "success nothing expired",
+			mockFunc: func() {
+				mStore.EXPECT().GetExpiredSubscriptions(now, downgradeBatchSize).Return([]models.Subscription{}, nil)
+			},
+			want: 0,
+		},
+		{
+			name: "error on get expired subscription",
+			mockFunc: func() {
+				mStore.EXPECT().GetExpiredSubscriptions(now, downgradeBatchSize).Return(nil, fmt.Errorf("some error"))
+			},
+			want:    0,
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error on update user",
+			mockFunc: func() {
+				mStore.EXPECT().GetExpiredSubscriptions(now, downgradeBatchSize).Return(expired[:1], nil)
+				mStore.EXPECT().GetActiveSubscriptions(1).Return(expired[:1], nil)
+				mUser.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}, Plan: models.PlanPremium}, nil)
+				mUser.EXPECT().UpdatePlan(1, models.PlanFree).Return(fmt.Errorf("some error"))
+			},
+			want:    0,
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error on expire subscription",
+			mockFunc: func() {
+				mStore.EXPECT().GetExpiredSubscriptions(now, downgradeBatchSize).Return(expired[:1], nil)
+				mStore.EXPECT().GetActiveSubscriptions(1).Return(expired[:1], nil)
+				mUser.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}, Plan: models.PlanFree}, nil)
+				mStore.EXPECT().ExpireSubscription(2, now).Return(false, fmt.Errorf("some error"))
+			},
+			want:    0,
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := SubscriptionService{
+				store:      mStore,
+				storeUser:  mUser,
+				tokenCache: mTokenCache,
+			}
+			tt.mockFunc()
+			got, err := service.DowngradeExpired(now)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("SubscriptionService.DowngradeExpired() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("SubscriptionService.DowngradeExpired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionService_RunDowngradeJob(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mStore := mock_subscription.NewMockSubscriptionStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	service := SubscriptionService{
+		store: mStore,
+	}
+	// the job stops after the second run because the context is cancelled
+	mStore.EXPECT().GetExpiredSubscriptions(gomock.Any(), downgradeBatchSize).Return(nil, fmt.Errorf("some error"))
+	mStore.EXPECT().GetExpiredSubscriptions(gomock.Any(), downgradeBatchSize).DoAndReturn(func(now time.Time, limit int) ([]models.Subscription, error) {
+		cancel()
+		return nil, nil
+	})
+
+	done := make(chan struct{})
+	go func() {
+		service.RunDowngradeJob(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Errorf("SubscriptionService.RunDowngradeJob() is not stopped after the context is done")
+	}
+}
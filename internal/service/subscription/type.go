@@ -0,0 +1,66 @@
+package subscription
+
+import (
+	"errors"
+	"gilsaputro/dating-apps/models"
+	"time"
+)
+
+// list Service error
+var (
+	ErrDataNotFound          = errors.New("data not found")
+	ErrInvalidPlan           = errors.New("plan is invalid, the value should be PLUS or PREMIUM")
+	ErrSubscriptionNotActive = errors.New("user does not have active subscription")
+)
+
+// defaultPeriod is default duration of one subscription period
+const defaultPeriod = 30 * 24 * time.Hour
+
+// downgradeBatchSize is number of expired subscription processed for each query of the downgrade job
+const downgradeBatchSize = 100
+
+// plans is list of plan tier shown to the user, the cheapest plan comes first
+var plans = []string{models.PlanFree, models.PlanPlus, models.PlanPremium}
+
+// PlanServiceInfo struct is list parameter info for a plan tier
+type PlanServiceInfo struct {
+	Plan         string
+	PeriodInDay  int
+	Entitlements models.Entitlements
+}
+
+// GetSubscriptionServiceRequest is list parameter for get subscription of the user
+type GetSubscriptionServiceRequest struct {
+	UserId int
+}
+
+// ActivateSubscriptionServiceRequest is list parameter for activate the paid plan of the user
+type ActivateSubscriptionServiceRequest struct {
+	UserId int
+	Plan   string
+	// PaymentReference is the payment that paid the plan, the activation of the same payment returns the stored period
+	PaymentReference string
+}
+
+// RevokeSubscriptionServiceRequest is list parameter for revoke the subscription of the user
+type RevokeSubscriptionServiceRequest struct {
+	UserId         int
+	SubscriptionID int
+}
+
+// CancelSubscriptionServiceRequest is list parameter for cancel the subscription
+type CancelSubscriptionServiceRequest struct {
+	UserId int
+}
+
+// SubscriptionServiceInfo struct is list parameter info for subscription of the user,
+// the user without active subscription has free plan and empty status
+type SubscriptionServiceInfo struct {
+	SubscriptionID int
+	Plan           string
+	Status         string
+	StartedDate    string
+	ExpiredDate    string
+	CancelledDate  string
+	Entitlements   models.Entitlements
+}
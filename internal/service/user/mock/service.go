@@ -34,6 +34,21 @@ func (m *MockUserServiceMethod) EXPECT() *MockUserServiceMethodMockRecorder {
 	return m.recorder
 }
 
+// ConfirmTwoFactor mocks base method.
+func (m *MockUserServiceMethod) ConfirmTwoFactor(arg0 user.ConfirmTwoFactorServiceRequest) (user.TwoFactorRecoveryInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTwoFactor", arg0)
+	ret0, _ := ret[0].(user.TwoFactorRecoveryInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTwoFactor indicates an expected call of ConfirmTwoFactor.
+func (mr *MockUserServiceMethodMockRecorder) ConfirmTwoFactor(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTwoFactor", reflect.TypeOf((*MockUserServiceMethod)(nil).ConfirmTwoFactor), arg0)
+}
+
 // DeleteUser mocks base method.
 func (m *MockUserServiceMethod) DeleteUser(arg0 user.DeleteUserServiceRequest) error {
 	m.ctrl.T.Helper()
@@ -48,6 +63,50 @@ func (mr *MockUserServiceMethodMockRecorder) DeleteUser(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserServiceMethod)(nil).DeleteUser), arg0)
 }
 
+// DisableTwoFactor mocks base method.
+func (m *MockUserServiceMethod) DisableTwoFactor(arg0 user.DisableTwoFactorServiceRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTwoFactor", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTwoFactor indicates an expected call of DisableTwoFactor.
+func (mr *MockUserServiceMethodMockRecorder) DisableTwoFactor(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTwoFactor", reflect.TypeOf((*MockUserServiceMethod)(nil).DisableTwoFactor), arg0)
+}
+
+// EnrollTwoFactor mocks base method.
+func (m *MockUserServiceMethod) EnrollTwoFactor(arg0 user.EnrollTwoFactorServiceRequest) (user.TwoFactorEnrollmentInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTwoFactor", arg0)
+	ret0, _ := ret[0].(user.TwoFactorEnrollmentInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollTwoFactor indicates an expected call of EnrollTwoFactor.
+func (mr *MockUserServiceMethodMockRecorder) EnrollTwoFactor(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTwoFactor", reflect.TypeOf((*MockUserServiceMethod)(nil).EnrollTwoFactor), arg0)
+}
+
+// GetSessions mocks base method.
+func (m *MockUserServiceMethod) GetSessions(arg0 user.GetSessionsServiceRequest) ([]user.SessionInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessions", arg0)
+	ret0, _ := ret[0].([]user.SessionInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessions indicates an expected call of GetSessions.
+func (mr *MockUserServiceMethodMockRecorder) GetSessions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessions", reflect.TypeOf((*MockUserServiceMethod)(nil).GetSessions), arg0)
+}
+
 // GetUserByID mocks base method.
 func (m *MockUserServiceMethod) GetUserByID(arg0 user.GetByIDServiceRequest) (user.UserServiceInfo, error) {
 	m.ctrl.T.Helper()
@@ -63,31 +122,46 @@ func (mr *MockUserServiceMethodMockRecorder) GetUserByID(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserServiceMethod)(nil).GetUserByID), arg0)
 }
 
-// UpdateUser mocks base method.
-func (m *MockUserServiceMethod) UpdateUser(arg0 user.UpdateUserServiceRequest) (user.UserServiceInfo, error) {
+// RevokeSession mocks base method.
+func (m *MockUserServiceMethod) RevokeSession(arg0 user.RevokeSessionServiceRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUser", arg0)
+	ret := m.ctrl.Call(m, "RevokeSession", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockUserServiceMethodMockRecorder) RevokeSession(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockUserServiceMethod)(nil).RevokeSession), arg0)
+}
+
+// UpdateLocation mocks base method.
+func (m *MockUserServiceMethod) UpdateLocation(arg0 user.UpdateLocationServiceRequest) (user.UserServiceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLocation", arg0)
 	ret0, _ := ret[0].(user.UserServiceInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateUser indicates an expected call of UpdateUser.
-func (mr *MockUserServiceMethodMockRecorder) UpdateUser(arg0 interface{}) *gomock.Call {
+// UpdateLocation indicates an expected call of UpdateLocation.
+func (mr *MockUserServiceMethodMockRecorder) UpdateLocation(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserServiceMethod)(nil).UpdateUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLocation", reflect.TypeOf((*MockUserServiceMethod)(nil).UpdateLocation), arg0)
 }
 
-// UpgradeUser mocks base method.
-func (m *MockUserServiceMethod) UpgradeUser(arg0 user.UpgradeServiceRequest) error {
+// UpdateUser mocks base method.
+func (m *MockUserServiceMethod) UpdateUser(arg0 user.UpdateUserServiceRequest) (user.UserServiceInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpgradeUser", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpdateUser", arg0)
+	ret0, _ := ret[0].(user.UserServiceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// UpgradeUser indicates an expected call of UpgradeUser.
-func (mr *MockUserServiceMethodMockRecorder) UpgradeUser(arg0 interface{}) *gomock.Call {
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockUserServiceMethodMockRecorder) UpdateUser(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeUser", reflect.TypeOf((*MockUserServiceMethod)(nil).UpgradeUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserServiceMethod)(nil).UpdateUser), arg0)
 }
@@ -1,8 +1,22 @@
 package user
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"gilsaputro/dating-apps/internal/service/authentication"
+	"gilsaputro/dating-apps/internal/store/session"
+	"gilsaputro/dating-apps/internal/store/tokencache"
+	"gilsaputro/dating-apps/internal/store/twofactor"
 	"gilsaputro/dating-apps/internal/store/user"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/geo"
 	"gilsaputro/dating-apps/pkg/hash"
+	"gilsaputro/dating-apps/pkg/totp"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
 )
 
 // UserServiceMethod is list method for User Service
@@ -10,20 +24,35 @@ type UserServiceMethod interface {
 	DeleteUser(DeleteUserServiceRequest) error
 	UpdateUser(UpdateUserServiceRequest) (UserServiceInfo, error)
 	GetUserByID(GetByIDServiceRequest) (UserServiceInfo, error)
-	UpgradeUser(UpgradeServiceRequest) error
+	UpdateLocation(UpdateLocationServiceRequest) (UserServiceInfo, error)
+	EnrollTwoFactor(EnrollTwoFactorServiceRequest) (TwoFactorEnrollmentInfo, error)
+	ConfirmTwoFactor(ConfirmTwoFactorServiceRequest) (TwoFactorRecoveryInfo, error)
+	DisableTwoFactor(DisableTwoFactorServiceRequest) error
+	GetSessions(GetSessionsServiceRequest) ([]SessionInfo, error)
+	RevokeSession(RevokeSessionServiceRequest) error
 }
 
 // UserService is list dependencies for user service
 type UserService struct {
-	store user.UserStoreMethod
-	hash  hash.HashMethod
+	store      user.UserStoreMethod
+	hash       hash.HashMethod
+	tokenCache tokencache.TokenCacheStoreMethod
+	twoFactor  twofactor.TwoFactorStoreMethod
+	totp       totp.TOTPMethod
+	session    session.SessionStoreMethod
+	auth       authentication.AuthenticationServiceMethod
 }
 
 // NewUserService is func to generate UserServiceMethod interface
-func NewUserService(store user.UserStoreMethod, hash hash.HashMethod) UserServiceMethod {
+func NewUserService(store user.UserStoreMethod, hash hash.HashMethod, tokenCache tokencache.TokenCacheStoreMethod, twoFactor twofactor.TwoFactorStoreMethod, totp totp.TOTPMethod, session session.SessionStoreMethod, auth authentication.AuthenticationServiceMethod) UserServiceMethod {
 	return &UserService{
-		hash:  hash,
-		store: store,
+		hash:       hash,
+		store:      store,
+		tokenCache: tokenCache,
+		twoFactor:  twoFactor,
+		totp:       totp,
+		session:    session,
+		auth:       auth,
 	}
 }
 
@@ -65,26 +94,147 @@ func (u *UserService) UpdateUser(request UpdateUserServiceRequest) (UserServiceI
 		userInfo.Password = string(hashPassword)
 	}
 
-	if len(request.Email) > 0 {
+	// the new email must be verified again
+	isEmailReset := false
+	isEmailChanged := false
+	if len(request.Email) > 0 && request.Email != userInfo.Email {
+		if !models.IsValidEmail(request.Email) {
+			return UserServiceInfo{}, ErrInvalidEmail
+		}
+		isEmailChanged = true
+		isEmailReset = userInfo.IsEmailVerified
 		userInfo.Email = request.Email
+		userInfo.IsEmailVerified = false
 	}
 
 	if len(request.Fullname) > 0 {
 		userInfo.Fullname = request.Fullname
 	}
 
+	err = applyDiscoveryProfile(&userInfo, request, time.Now())
+	if err != nil {
+		return UserServiceInfo{}, err
+	}
+
 	err = u.store.UpdateUser(userInfo)
 	if err != nil {
 		return UserServiceInfo{}, err
 	}
 
-	return UserServiceInfo{
-		UserId:      int(userInfo.ID),
-		Username:    userInfo.Username,
-		Fullname:    userInfo.Fullname,
-		Email:       userInfo.Email,
-		CreatedDate: userInfo.CreatedAt.String(),
-	}, nil
+	if isEmailReset {
+		err = u.tokenCache.SetClaimsChangedAt(request.UserId, time.Now())
+		if err != nil {
+			log.Println("[UserService]-Error Set Claims Changed :", err)
+		}
+	}
+
+	// the email is already changed, the failure is only logged because the user can resend the verification email
+	if isEmailChanged {
+		err = u.auth.ResendEmailVerification(authentication.ResendEmailVerificationServiceRequest{UserID: request.UserId})
+		if err != nil {
+			log.Println("[UserService]-Error Send Email Verification :", err)
+		}
+	}
+
+	return mapUserServiceInfo(userInfo), nil
+}
+
+// applyDiscoveryProfile is func to validate and set the discovery profile and preference into user info
+func applyDiscoveryProfile(userInfo *models.User, request UpdateUserServiceRequest, now time.Time) error {
+	if len(request.Birthdate) > 0 {
+		birthdate, err := time.Parse(birthdateFormat, request.Birthdate)
+		if err != nil {
+			return ErrInvalidBirthdate
+		}
+
+		if (models.User{Birthdate: &birthdate}).Age(now) < MinimumAge {
+			return ErrInvalidBirthdate
+		}
+		userInfo.Birthdate = &birthdate
+	}
+
+	if len(request.Gender) > 0 {
+		gender := strings.ToUpper(request.Gender)
+		if !models.IsValidGender(gender) {
+			return ErrInvalidGender
+		}
+		userInfo.Gender = gender
+	}
+
+	if request.InterestedIn != nil {
+		var genders []string
+		for _, gender := range request.InterestedIn {
+			gender = strings.ToUpper(gender)
+			if !models.IsValidGender(gender) {
+				return ErrInvalidGender
+			}
+			genders = append(genders, gender)
+		}
+		userInfo.InterestedIn = strings.Join(genders, ",")
+	}
+
+	if request.AgeMin != nil {
+		userInfo.PrefAgeMin = *request.AgeMin
+	}
+
+	if request.AgeMax != nil {
+		userInfo.PrefAgeMax = *request.AgeMax
+	}
+
+	if request.MaxDistance != nil {
+		if *request.MaxDistance < 0 || *request.MaxDistance > MaximumDistance {
+			return ErrInvalidMaxDistance
+		}
+		userInfo.PrefMaxDistance = *request.MaxDistance
+	}
+
+	if !isValidAgePreference(userInfo.PrefAgeMin) || !isValidAgePreference(userInfo.PrefAgeMax) ||
+		(userInfo.PrefAgeMin > 0 && userInfo.PrefAgeMax > 0 && userInfo.PrefAgeMin > userInfo.PrefAgeMax) {
+		return ErrInvalidAgeRange
+	}
+
+	return nil
+}
+
+// isValidAgePreference is func to check the age preference is unset or inside allowed age
+func isValidAgePreference(age int) bool {
+	return age == 0 || (age >= MinimumAge && age <= MaximumAge)
+}
+
+// mapUserServiceInfo is func to convert user model into user service info
+func mapUserServiceInfo(userInfo models.User) UserServiceInfo {
+	info := UserServiceInfo{
+		UserId:          int(userInfo.ID),
+		Username:        userInfo.Username,
+		Fullname:        userInfo.Fullname,
+		Email:           userInfo.Email,
+		Plan:            userInfo.GetPlan(),
+		IsEmailVerified: userInfo.IsEmailVerified,
+		Gender:          userInfo.Gender,
+		AgeMin:          userInfo.PrefAgeMin,
+		AgeMax:          userInfo.PrefAgeMax,
+		MaxDistance:     userInfo.PrefMaxDistance,
+		CreatedDate:     userInfo.CreatedAt.String(),
+	}
+
+	if userInfo.HasLocation() {
+		info.Location = &LocationInfo{
+			Latitude:    userInfo.Latitude,
+			Longitude:   userInfo.Longitude,
+			UpdatedDate: userInfo.LocationUpdatedAt.String(),
+		}
+	}
+
+	if userInfo.Birthdate != nil {
+		info.Birthdate = userInfo.Birthdate.Format(birthdateFormat)
+		info.Age = userInfo.Age(time.Now())
+	}
+
+	if len(userInfo.InterestedIn) > 0 {
+		info.InterestedIn = strings.Split(userInfo.InterestedIn, ",")
+	}
+
+	return info
 }
 
 // GetUserByID is service level func to validate and get all user based id
@@ -94,17 +244,132 @@ func (u *UserService) GetUserByID(request GetByIDServiceRequest) (UserServiceInf
 		return UserServiceInfo{}, err
 	}
 
-	return UserServiceInfo{
-		UserId:      int(userInfo.ID),
-		Username:    userInfo.Username,
-		Fullname:    userInfo.Fullname,
-		Email:       userInfo.Email,
-		IsVerified:  userInfo.IsVerified,
-		CreatedDate: userInfo.CreatedAt.String(),
+	return mapUserServiceInfo(userInfo), nil
+}
+
+// UpdateLocation is service level func to validate and update user location in database
+func (u *UserService) UpdateLocation(request UpdateLocationServiceRequest) (UserServiceInfo, error) {
+	if request.UserId <= 0 {
+		return UserServiceInfo{}, ErrDataNotFound
+	}
+
+	point := geo.Point{
+		Latitude:  request.Latitude,
+		Longitude: request.Longitude,
+	}
+	if !point.IsValid() {
+		return UserServiceInfo{}, ErrInvalidLocation
+	}
+
+	userInfo, err := u.store.GetUserInfoByID(request.UserId)
+	if err != nil || userInfo.ID <= 0 {
+		return UserServiceInfo{}, err
+	}
+
+	now := time.Now()
+	userInfo.Latitude = point.Latitude
+	userInfo.Longitude = point.Longitude
+	userInfo.LocationUpdatedAt = &now
+
+	err = u.store.UpdateUser(userInfo)
+	if err != nil {
+		return UserServiceInfo{}, err
+	}
+
+	return mapUserServiceInfo(userInfo), nil
+}
+
+// EnrollTwoFactor is service level func to generate new two factor secret, it is not used until the user confirm it
+func (u *UserService) EnrollTwoFactor(request EnrollTwoFactorServiceRequest) (TwoFactorEnrollmentInfo, error) {
+	if request.UserId <= 0 {
+		return TwoFactorEnrollmentInfo{}, ErrDataNotFound
+	}
+
+	userInfo, err := u.store.GetUserInfoByID(request.UserId)
+	if err != nil || userInfo.ID <= 0 {
+		return TwoFactorEnrollmentInfo{}, err
+	}
+
+	if !userInfo.IsEmailVerified {
+		return TwoFactorEnrollmentInfo{}, ErrTwoFactorNotAllowed
+	}
+
+	if !u.hash.CompareValue(userInfo.Password, request.Password) {
+		return TwoFactorEnrollmentInfo{}, ErrPasswordIsIncorrect
+	}
+
+	twoFactor, err := u.twoFactor.GetTwoFactor(request.UserId)
+	if err != nil {
+		return TwoFactorEnrollmentInfo{}, err
+	}
+
+	if twoFactor.IsEnabled() {
+		return TwoFactorEnrollmentInfo{}, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := u.totp.GenerateSecret()
+	if err != nil {
+		return TwoFactorEnrollmentInfo{}, err
+	}
+
+	// the pending enrollment is replaced, so only the last scanned secret can be confirmed
+	twoFactor.UserID = userInfo.ID
+	twoFactor.Secret = secret
+	twoFactor.RecoveryCodes = ""
+	err = u.twoFactor.SaveTwoFactor(twoFactor)
+	if err != nil {
+		return TwoFactorEnrollmentInfo{}, err
+	}
+
+	return TwoFactorEnrollmentInfo{
+		Secret: secret,
+		URI:    u.totp.GenerateURI(userInfo.Username, secret),
 	}, nil
 }
 
-func (u *UserService) UpgradeUser(request UpgradeServiceRequest) error {
+// ConfirmTwoFactor is service level func to enable the two factor authentication and generate the recovery code
+func (u *UserService) ConfirmTwoFactor(request ConfirmTwoFactorServiceRequest) (TwoFactorRecoveryInfo, error) {
+	if request.UserId <= 0 {
+		return TwoFactorRecoveryInfo{}, ErrDataNotFound
+	}
+
+	twoFactor, err := u.twoFactor.GetTwoFactor(request.UserId)
+	if err != nil {
+		return TwoFactorRecoveryInfo{}, err
+	}
+
+	if twoFactor.ID <= 0 {
+		return TwoFactorRecoveryInfo{}, ErrTwoFactorNotEnrolled
+	}
+
+	if twoFactor.IsEnabled() {
+		return TwoFactorRecoveryInfo{}, ErrTwoFactorAlreadyEnabled
+	}
+
+	if !u.totp.ValidateCode(twoFactor.Secret, request.Code) {
+		return TwoFactorRecoveryInfo{}, ErrInvalidTwoFactorCode
+	}
+
+	codes, hashedCodes, err := u.generateRecoveryCodes()
+	if err != nil {
+		return TwoFactorRecoveryInfo{}, err
+	}
+
+	now := time.Now()
+	twoFactor.EnabledAt = &now
+	twoFactor.SetRecoveryCodes(hashedCodes)
+	err = u.twoFactor.SaveTwoFactor(twoFactor)
+	if err != nil {
+		return TwoFactorRecoveryInfo{}, err
+	}
+
+	return TwoFactorRecoveryInfo{
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// DisableTwoFactor is service level func to remove the two factor authentication of the user
+func (u *UserService) DisableTwoFactor(request DisableTwoFactorServiceRequest) error {
 	if request.UserId <= 0 {
 		return ErrDataNotFound
 	}
@@ -114,15 +379,82 @@ func (u *UserService) UpgradeUser(request UpgradeServiceRequest) error {
 		return err
 	}
 
-	if userInfo.IsVerified {
-		return ErrUserIsVerified
-	}
-
 	if !u.hash.CompareValue(userInfo.Password, request.Password) {
 		return ErrPasswordIsIncorrect
 	}
 
-	userInfo.IsVerified = true
+	twoFactor, err := u.twoFactor.GetTwoFactor(request.UserId)
+	if err != nil {
+		return err
+	}
+
+	if twoFactor.ID <= 0 {
+		return ErrTwoFactorNotEnrolled
+	}
+
+	return u.twoFactor.DeleteTwoFactor(request.UserId)
+}
+
+// GetSessions is service level func to get list active device session of the user
+func (u *UserService) GetSessions(request GetSessionsServiceRequest) ([]SessionInfo, error) {
+	if request.UserId <= 0 {
+		return nil, ErrDataNotFound
+	}
+
+	sessions, err := u.session.GetActiveSessions(request.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]SessionInfo, 0, len(sessions))
+	for _, userSession := range sessions {
+		result = append(result, SessionInfo{
+			SessionID:   userSession.SessionID,
+			Device:      userSession.Device,
+			IPAddress:   userSession.IPAddress,
+			UserAgent:   userSession.UserAgent,
+			LastSeen:    userSession.LastSeenAt.String(),
+			CreatedDate: userSession.CreatedAt.String(),
+			IsCurrent:   userSession.SessionID == request.CurrentSessionID,
+		})
+	}
+	return result, nil
+}
+
+// RevokeSession is service level func to logout the device session of the user,
+// the token of the session is rejected even before it is expired
+func (u *UserService) RevokeSession(request RevokeSessionServiceRequest) error {
+	if request.UserId <= 0 || len(request.SessionID) == 0 {
+		return ErrSessionNotFound
+	}
+
+	err := u.session.RevokeSession(request.UserId, request.SessionID, time.Now())
+	if gorm.IsRecordNotFoundError(err) {
+		return ErrSessionNotFound
+	}
+
+	return err
+}
+
+// generateRecoveryCodes is func to generate random recovery code in xxxxx-xxxxx format and its hashed value
+func (u *UserService) generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashedCodes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, models.RecoveryCodeLength/2)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
 
-	return u.store.UpdateUser(userInfo)
+		code := hex.EncodeToString(b)
+		hashed, err := u.hash.HashValue(models.NormalizeRecoveryCode(code))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		half := models.RecoveryCodeLength / 2
+		codes = append(codes, code[:half]+"-"+code[half:])
+		hashedCodes = append(hashedCodes, string(hashed))
+	}
+	return codes, hashedCodes, nil
 }
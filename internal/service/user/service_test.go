@@ -2,13 +2,24 @@ package user
 
 import (
 	"fmt"
+	"gilsaputro/dating-apps/internal/service/authentication"
+	mock_authentication "gilsaputro/dating-apps/internal/service/authentication/mock"
+	"gilsaputro/dating-apps/internal/store/session"
+	mock_session "gilsaputro/dating-apps/internal/store/session/mock"
+	"gilsaputro/dating-apps/internal/store/tokencache"
+	mock_tokencache "gilsaputro/dating-apps/internal/store/tokencache/mock"
+	"gilsaputro/dating-apps/internal/store/twofactor"
+	mock_twofactor "gilsaputro/dating-apps/internal/store/twofactor/mock"
 	"gilsaputro/dating-apps/internal/store/user"
 	"gilsaputro/dating-apps/internal/store/user/mock"
 	"gilsaputro/dating-apps/models"
 	"gilsaputro/dating-apps/pkg/hash"
 	mock_hash "gilsaputro/dating-apps/pkg/hash/mock"
+	"gilsaputro/dating-apps/pkg/totp"
+	mock_totp "gilsaputro/dating-apps/pkg/totp/mock"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/jinzhu/gorm"
@@ -16,8 +27,13 @@ import (
 
 func TestNewUserService(t *testing.T) {
 	type args struct {
-		store user.UserStoreMethod
-		hash  hash.HashMethod
+		store      user.UserStoreMethod
+		hash       hash.HashMethod
+		tokenCache tokencache.TokenCacheStoreMethod
+		twoFactor  twofactor.TwoFactorStoreMethod
+		totp       totp.TOTPMethod
+		session    session.SessionStoreMethod
+		auth       authentication.AuthenticationServiceMethod
 	}
 	tests := []struct {
 		name string
@@ -27,18 +43,28 @@ func TestNewUserService(t *testing.T) {
 		{
 			name: "success",
 			args: args{
-				store: &user.UserStore{},
-				hash:  &hash.HashConfig{},
+				store:      &user.UserStore{},
+				hash:       &hash.HashConfig{},
+				tokenCache: &tokencache.TokenCacheStore{},
+				twoFactor:  &twofactor.TwoFactorStore{},
+				totp:       &totp.TOTPConfig{},
+				session:    &session.SessionStore{},
+				auth:       &authentication.AuthenticationService{},
 			},
 			want: &UserService{
-				store: &user.UserStore{},
-				hash:  &hash.HashConfig{},
+				store:      &user.UserStore{},
+				hash:       &hash.HashConfig{},
+				tokenCache: &tokencache.TokenCacheStore{},
+				twoFactor:  &twofactor.TwoFactorStore{},
+				totp:       &totp.TOTPConfig{},
+				session:    &session.SessionStore{},
+				auth:       &authentication.AuthenticationService{},
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewUserService(tt.args.store, tt.args.hash); !reflect.DeepEqual(got, tt.want) {
+			if got := NewUserService(tt.args.store, tt.args.hash, tt.args.tokenCache, tt.args.twoFactor, tt.args.totp, tt.args.session, tt.args.auth); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewUserService() = %v, want %v", got, tt.want)
 			}
 		})
@@ -176,6 +202,8 @@ func TestUserService_UpdateUser(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	mHash := mock_hash.NewMockHashMethod(mockCtrl)
 	mStore := mock.NewMockUserStoreMethod(mockCtrl)
+	mTokenCache := mock_tokencache.NewMockTokenCacheStoreMethod(mockCtrl)
+	mAuth := mock_authentication.NewMockAuthenticationServiceMethod(mockCtrl)
 	defer mockCtrl.Finish()
 	type args struct {
 		request UpdateUserServiceRequest
@@ -195,7 +223,7 @@ func TestUserService_UpdateUser(t *testing.T) {
 					Username: "username",
 					Password: "pass",
 					Fullname: "full",
-					Email:    "email",
+					Email:    "user@mail.com",
 				},
 			},
 			mockFunc: func() {
@@ -216,18 +244,121 @@ func TestUserService_UpdateUser(t *testing.T) {
 					Username: "username",
 					Password: "hash_password",
 					Fullname: "full",
-					Email:    "email",
+					Email:    "user@mail.com",
 				}).Return(nil)
+				mAuth.EXPECT().ResendEmailVerification(authentication.ResendEmailVerificationServiceRequest{UserID: 1}).Return(nil)
 			},
 			want: UserServiceInfo{
 				UserId:      1,
 				Username:    "username",
 				Fullname:    "full",
-				Email:       "email",
+				Email:       "user@mail.com",
+				Plan:        models.PlanFree,
+				CreatedDate: "0001-01-01 00:00:00 +0000 UTC",
+			},
+			wantErr: false,
+		},
+		{
+			name: "success change verified email flow",
+			args: args{
+				request: UpdateUserServiceRequest{
+					UserId:   1,
+					Username: "username",
+					Email:    "new@mail.com",
+				},
+			},
+			mockFunc: func() {
+				mStore.EXPECT().GetUserInfoByID(int(1)).Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Username:        "username",
+					Password:        "hash_password",
+					Email:           "old@mail.com",
+					IsEmailVerified: true,
+				}, nil)
+
+				mStore.EXPECT().UpdateUser(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Username: "username",
+					Password: "hash_password",
+					Email:    "new@mail.com",
+				}).Return(nil)
+				mTokenCache.EXPECT().SetClaimsChangedAt(1, gomock.Any()).Return(nil)
+				mAuth.EXPECT().ResendEmailVerification(authentication.ResendEmailVerificationServiceRequest{UserID: 1}).Return(fmt.Errorf("some error"))
+			},
+			want: UserServiceInfo{
+				UserId:      1,
+				Username:    "username",
+				Email:       "new@mail.com",
+				Plan:        models.PlanFree,
 				CreatedDate: "0001-01-01 00:00:00 +0000 UTC",
 			},
 			wantErr: false,
 		},
+		{
+			name: "success same email flow",
+			args: args{
+				request: UpdateUserServiceRequest{
+					UserId:   1,
+					Username: "username",
+					Email:    "user@mail.com",
+				},
+			},
+			mockFunc: func() {
+				mStore.EXPECT().GetUserInfoByID(int(1)).Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Username:        "username",
+					Password:        "hash_password",
+					Email:           "user@mail.com",
+					IsEmailVerified: true,
+				}, nil)
+
+				mStore.EXPECT().UpdateUser(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Username:        "username",
+					Password:        "hash_password",
+					Email:           "user@mail.com",
+					IsEmailVerified: true,
+				}).Return(nil)
+			},
+			want: UserServiceInfo{
+				UserId:          1,
+				Username:        "username",
+				Email:           "user@mail.com",
+				Plan:            models.PlanFree,
+				IsEmailVerified: true,
+				CreatedDate:     "0001-01-01 00:00:00 +0000 UTC",
+			},
+			wantErr: false,
+		},
+		{
+			name: "error invalid email flow",
+			args: args{
+				request: UpdateUserServiceRequest{
+					UserId:   1,
+					Username: "username",
+					Email:    "Name <user@mail.com>",
+				},
+			},
+			mockFunc: func() {
+				mStore.EXPECT().GetUserInfoByID(int(1)).Return(models.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Username: "username",
+					Email:    "old@mail.com",
+				}, nil)
+			},
+			want:    UserServiceInfo{},
+			wantErr: true,
+		},
 		{
 			name: "error update flow",
 			args: args{
@@ -236,7 +367,7 @@ func TestUserService_UpdateUser(t *testing.T) {
 					Username: "username",
 					Password: "pass",
 					Fullname: "full",
-					Email:    "email",
+					Email:    "user@mail.com",
 				},
 			},
 			mockFunc: func() {
@@ -257,7 +388,7 @@ func TestUserService_UpdateUser(t *testing.T) {
 					Username: "username",
 					Password: "hash_password",
 					Fullname: "full",
-					Email:    "email",
+					Email:    "user@mail.com",
 				}).Return(fmt.Errorf("some error"))
 			},
 			want:    UserServiceInfo{},
@@ -271,7 +402,7 @@ func TestUserService_UpdateUser(t *testing.T) {
 					Username: "username",
 					Password: "pass",
 					Fullname: "full",
-					Email:    "email",
+					Email:    "user@mail.com",
 				},
 			},
 			mockFunc: func() {
@@ -296,7 +427,7 @@ func TestUserService_UpdateUser(t *testing.T) {
 					Username: "username",
 					Password: "pass",
 					Fullname: "full",
-					Email:    "email",
+					Email:    "user@mail.com",
 				},
 			},
 			mockFunc: func() {
@@ -319,8 +450,10 @@ func TestUserService_UpdateUser(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			service := UserService{
-				store: mStore,
-				hash:  mHash,
+				store:      mStore,
+				hash:       mHash,
+				tokenCache: mTokenCache,
+				auth:       mAuth,
 			}
 			tt.mockFunc()
 			got, err := service.UpdateUser(tt.args.request)
@@ -372,6 +505,7 @@ func TestUserService_GetUserByID(t *testing.T) {
 				Username:    "username",
 				Fullname:    "full",
 				Email:       "email",
+				Plan:        models.PlanFree,
 				CreatedDate: "0001-01-01 00:00:00 +0000 UTC",
 			},
 			wantErr: false,
@@ -409,26 +543,27 @@ func TestUserService_GetUserByID(t *testing.T) {
 	}
 }
 
-func TestUserService_UpgradeUser(t *testing.T) {
+func TestUserService_UpdateLocation(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
-	mHash := mock_hash.NewMockHashMethod(mockCtrl)
 	mStore := mock.NewMockUserStoreMethod(mockCtrl)
 	defer mockCtrl.Finish()
 	type args struct {
-		request UpgradeServiceRequest
+		request UpdateLocationServiceRequest
 	}
 	tests := []struct {
 		name     string
 		mockFunc func()
 		args     args
+		want     *LocationInfo
 		wantErr  bool
 	}{
 		{
 			name: "success flow",
 			args: args{
-				request: UpgradeServiceRequest{
-					UserId:   1,
-					Password: "password",
+				request: UpdateLocationServiceRequest{
+					UserId:    1,
+					Latitude:  -6.2,
+					Longitude: 106.8,
 				},
 			},
 			mockFunc: func() {
@@ -437,21 +572,23 @@ func TestUserService_UpgradeUser(t *testing.T) {
 						ID: 1,
 					},
 					Username: "username",
-					Password: "hash_password",
 				}, nil)
 
-				mHash.EXPECT().CompareValue("hash_password", "password").Return(true)
-
 				mStore.EXPECT().UpdateUser(gomock.Any()).Return(nil)
 			},
+			want: &LocationInfo{
+				Latitude:  -6.2,
+				Longitude: 106.8,
+			},
 			wantErr: false,
 		},
 		{
-			name: "error flow",
+			name: "error on update flow",
 			args: args{
-				request: UpgradeServiceRequest{
-					UserId:   1,
-					Password: "password",
+				request: UpdateLocationServiceRequest{
+					UserId:    1,
+					Latitude:  -6.2,
+					Longitude: 106.8,
 				},
 			},
 			mockFunc: func() {
@@ -459,95 +596,731 @@ func TestUserService_UpgradeUser(t *testing.T) {
 					Model: gorm.Model{
 						ID: 1,
 					},
-					Username: "username",
-					Password: "hash_password",
 				}, nil)
 
-				mHash.EXPECT().CompareValue("hash_password", "password").Return(true)
-
 				mStore.EXPECT().UpdateUser(gomock.Any()).Return(fmt.Errorf("some error"))
 			},
 			wantErr: true,
 		},
 		{
-			name: "error invalid password flow",
+			name: "error on get data flow",
 			args: args{
-				request: UpgradeServiceRequest{
-					UserId:   1,
-					Password: "password",
+				request: UpdateLocationServiceRequest{
+					UserId:    1,
+					Latitude:  -6.2,
+					Longitude: 106.8,
 				},
 			},
 			mockFunc: func() {
-				mStore.EXPECT().GetUserInfoByID(int(1)).Return(models.User{
-					Model: gorm.Model{
-						ID: 1,
-					},
-					Username: "username",
-					Password: "hash_password",
-				}, nil)
-
-				mHash.EXPECT().CompareValue("hash_password", "password").Return(false)
+				mStore.EXPECT().GetUserInfoByID(int(1)).Return(models.User{}, fmt.Errorf("some error"))
 			},
 			wantErr: true,
 		},
 		{
-			name: "error user is verified flow",
+			name: "error invalid location flow",
 			args: args{
-				request: UpgradeServiceRequest{
-					UserId:   1,
-					Password: "password",
+				request: UpdateLocationServiceRequest{
+					UserId:    1,
+					Latitude:  91,
+					Longitude: 106.8,
 				},
 			},
 			mockFunc: func() {
-				mStore.EXPECT().GetUserInfoByID(int(1)).Return(models.User{
-					Model: gorm.Model{
-						ID: 1,
-					},
-					Username:   "username",
-					Password:   "hash_password",
-					IsVerified: true,
-				}, nil)
 			},
 			wantErr: true,
 		},
 		{
-			name: "error on get data flow",
+			name: "error invalid user flow",
 			args: args{
-				request: UpgradeServiceRequest{
-					UserId:   1,
-					Password: "password",
-				},
+				request: UpdateLocationServiceRequest{},
 			},
 			mockFunc: func() {
-				mStore.EXPECT().GetUserInfoByID(int(1)).Return(models.User{
-					Model: gorm.Model{
-						ID: 1,
-					},
-					Username: "username",
-					Password: "hash_password",
-				}, fmt.Errorf("some error"))
 			},
 			wantErr: true,
 		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := UserService{
+				store: mStore,
+			}
+			tt.mockFunc()
+			got, err := service.UpdateLocation(tt.args.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UserService.UpdateLocation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.want == nil {
+				return
+			}
+			if got.Location == nil || got.Location.Latitude != tt.want.Latitude || got.Location.Longitude != tt.want.Longitude {
+				t.Errorf("UserService.UpdateLocation() location = %v, want %v", got.Location, tt.want)
+			}
+		})
+	}
+}
+
+func Test_applyDiscoveryProfile(t *testing.T) {
+	now := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+	birthdate := time.Date(1998, 1, 2, 0, 0, 0, 0, time.UTC)
+	intPointer := func(v int) *int { return &v }
+	type args struct {
+		userInfo models.User
+		request  UpdateUserServiceRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    models.User
+		wantErr error
+	}{
+		{
+			name: "success flow",
+			args: args{
+				request: UpdateUserServiceRequest{
+					Birthdate:    "1998-01-02",
+					Gender:       "male",
+					InterestedIn: []string{"female", "OTHER"},
+					AgeMin:       intPointer(20),
+					AgeMax:       intPointer(30),
+					MaxDistance:  intPointer(25),
+				},
+			},
+			want: models.User{
+				Birthdate:       &birthdate,
+				Gender:          models.GenderMale,
+				InterestedIn:    "FEMALE,OTHER",
+				PrefAgeMin:      20,
+				PrefAgeMax:      30,
+				PrefMaxDistance: 25,
+			},
+		},
 		{
-			name: "error on get data flow",
+			name: "success reset interested in",
+			args: args{
+				userInfo: models.User{
+					InterestedIn: "FEMALE",
+				},
+				request: UpdateUserServiceRequest{
+					InterestedIn: []string{},
+				},
+			},
+			want: models.User{},
+		},
+		{
+			name: "success clear preference",
+			args: args{
+				userInfo: models.User{
+					PrefAgeMin:      20,
+					PrefAgeMax:      30,
+					PrefMaxDistance: 25,
+				},
+				request: UpdateUserServiceRequest{
+					AgeMin:      intPointer(0),
+					AgeMax:      intPointer(0),
+					MaxDistance: intPointer(0),
+				},
+			},
+			want: models.User{},
+		},
+		{
+			name: "success keep preference not sent",
+			args: args{
+				userInfo: models.User{
+					PrefAgeMin:      20,
+					PrefAgeMax:      30,
+					PrefMaxDistance: 25,
+				},
+				request: UpdateUserServiceRequest{
+					AgeMax: intPointer(0),
+				},
+			},
+			want: models.User{
+				PrefAgeMin:      20,
+				PrefMaxDistance: 25,
+			},
+		},
+		{
+			name: "error invalid birthdate format",
+			args: args{
+				request: UpdateUserServiceRequest{
+					Birthdate: "02-01-1998",
+				},
+			},
+			wantErr: ErrInvalidBirthdate,
+		},
+		{
+			name: "error underage user",
+			args: args{
+				request: UpdateUserServiceRequest{
+					Birthdate: "2010-01-01",
+				},
+			},
+			wantErr: ErrInvalidBirthdate,
+		},
+		{
+			name: "error invalid gender",
+			args: args{
+				request: UpdateUserServiceRequest{
+					Gender: "unknown",
+				},
+			},
+			wantErr: ErrInvalidGender,
+		},
+		{
+			name: "error invalid interested in",
+			args: args{
+				request: UpdateUserServiceRequest{
+					InterestedIn: []string{"unknown"},
+				},
+			},
+			wantErr: ErrInvalidGender,
+		},
+		{
+			name: "error age min greater than age max",
+			args: args{
+				userInfo: models.User{
+					PrefAgeMax: 25,
+				},
+				request: UpdateUserServiceRequest{
+					AgeMin: intPointer(30),
+				},
+			},
+			wantErr: ErrInvalidAgeRange,
+		},
+		{
+			name: "error age below minimum age",
+			args: args{
+				request: UpdateUserServiceRequest{
+					AgeMin: intPointer(10),
+				},
+			},
+			wantErr: ErrInvalidAgeRange,
+		},
+		{
+			name: "error max distance above maximum distance",
 			args: args{
-				request: UpgradeServiceRequest{},
+				request: UpdateUserServiceRequest{
+					MaxDistance: intPointer(501),
+				},
 			},
+			wantErr: ErrInvalidMaxDistance,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userInfo := tt.args.userInfo
+			err := applyDiscoveryProfile(&userInfo, tt.args.request, now)
+			if err != tt.wantErr {
+				t.Errorf("applyDiscoveryProfile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(userInfo, tt.want) {
+				t.Errorf("applyDiscoveryProfile() = %+v, want %+v", userInfo, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserService_EnrollTwoFactor(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mHash := mock_hash.NewMockHashMethod(mockCtrl)
+	mStore := mock.NewMockUserStoreMethod(mockCtrl)
+	mTwoFactor := mock_twofactor.NewMockTwoFactorStoreMethod(mockCtrl)
+	mTOTP := mock_totp.NewMockTOTPMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	enabledAt := time.Now()
+	verifiedUser := models.User{
+		Model: gorm.Model{
+			ID: 1,
+		},
+		Username:        "username",
+		Password:        "hash",
+		IsEmailVerified: true,
+	}
+	tests := []struct {
+		name     string
+		request  EnrollTwoFactorServiceRequest
+		mockFunc func()
+		want     TwoFactorEnrollmentInfo
+		wantErr  error
+	}{
+		{
+			name:    "success flow",
+			request: EnrollTwoFactorServiceRequest{UserId: 1, Password: "password"},
 			mockFunc: func() {
+				mStore.EXPECT().GetUserInfoByID(1).Return(verifiedUser, nil)
+				mHash.EXPECT().CompareValue("hash", "password").Return(true)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{}, nil)
+				mTOTP.EXPECT().GenerateSecret().Return("SECRET", nil)
+				mTwoFactor.EXPECT().SaveTwoFactor(models.UserTwoFactor{UserID: 1, Secret: "SECRET"}).Return(nil)
+				mTOTP.EXPECT().GenerateURI("username", "SECRET").Return("otpauth://totp/username?secret=SECRET")
 			},
-			wantErr: true,
+			want: TwoFactorEnrollmentInfo{
+				Secret: "SECRET",
+				URI:    "otpauth://totp/username?secret=SECRET",
+			},
+		},
+		{
+			name:    "success replace pending enrollment flow",
+			request: EnrollTwoFactorServiceRequest{UserId: 1, Password: "password"},
+			mockFunc: func() {
+				mStore.EXPECT().GetUserInfoByID(1).Return(verifiedUser, nil)
+				mHash.EXPECT().CompareValue("hash", "password").Return(true)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{Model: gorm.Model{ID: 2}, UserID: 1, Secret: "OLD"}, nil)
+				mTOTP.EXPECT().GenerateSecret().Return("SECRET", nil)
+				mTwoFactor.EXPECT().SaveTwoFactor(models.UserTwoFactor{Model: gorm.Model{ID: 2}, UserID: 1, Secret: "SECRET"}).Return(nil)
+				mTOTP.EXPECT().GenerateURI("username", "SECRET").Return("otpauth://totp/username?secret=SECRET")
+			},
+			want: TwoFactorEnrollmentInfo{
+				Secret: "SECRET",
+				URI:    "otpauth://totp/username?secret=SECRET",
+			},
+		},
+		{
+			name:    "error save flow",
+			request: EnrollTwoFactorServiceRequest{UserId: 1, Password: "password"},
+			mockFunc: func() {
+				mStore.EXPECT().GetUserInfoByID(1).Return(verifiedUser, nil)
+				mHash.EXPECT().CompareValue("hash", "password").Return(true)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{}, nil)
+				mTOTP.EXPECT().GenerateSecret().Return("SECRET", nil)
+				mTwoFactor.EXPECT().SaveTwoFactor(gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:    "error generate secret flow",
+			request: EnrollTwoFactorServiceRequest{UserId: 1, Password: "password"},
+			mockFunc: func() {
+				mStore.EXPECT().GetUserInfoByID(1).Return(verifiedUser, nil)
+				mHash.EXPECT().CompareValue("hash", "password").Return(true)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{}, nil)
+				mTOTP.EXPECT().GenerateSecret().Return("", fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:    "error already enabled flow",
+			request: EnrollTwoFactorServiceRequest{UserId: 1, Password: "password"},
+			mockFunc: func() {
+				mStore.EXPECT().GetUserInfoByID(1).Return(verifiedUser, nil)
+				mHash.EXPECT().CompareValue("hash", "password").Return(true)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{Model: gorm.Model{ID: 2}, EnabledAt: &enabledAt}, nil)
+			},
+			wantErr: ErrTwoFactorAlreadyEnabled,
+		},
+		{
+			name:    "error get two factor flow",
+			request: EnrollTwoFactorServiceRequest{UserId: 1, Password: "password"},
+			mockFunc: func() {
+				mStore.EXPECT().GetUserInfoByID(1).Return(verifiedUser, nil)
+				mHash.EXPECT().CompareValue("hash", "password").Return(true)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{}, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:    "error password incorrect flow",
+			request: EnrollTwoFactorServiceRequest{UserId: 1, Password: "password"},
+			mockFunc: func() {
+				mStore.EXPECT().GetUserInfoByID(1).Return(verifiedUser, nil)
+				mHash.EXPECT().CompareValue("hash", "password").Return(false)
+			},
+			wantErr: ErrPasswordIsIncorrect,
+		},
+		{
+			name:    "error user not verified flow",
+			request: EnrollTwoFactorServiceRequest{UserId: 1, Password: "password"},
+			mockFunc: func() {
+				mStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+			},
+			wantErr: ErrTwoFactorNotAllowed,
+		},
+		{
+			name:    "error get user flow",
+			request: EnrollTwoFactorServiceRequest{UserId: 1, Password: "password"},
+			mockFunc: func() {
+				mStore.EXPECT().GetUserInfoByID(1).Return(models.User{}, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:     "error invalid user id flow",
+			request:  EnrollTwoFactorServiceRequest{Password: "password"},
+			mockFunc: func() {},
+			wantErr:  ErrDataNotFound,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			service := UserService{
-				store: mStore,
-				hash:  mHash,
+				store:     mStore,
+				hash:      mHash,
+				twoFactor: mTwoFactor,
+				totp:      mTOTP,
+			}
+			tt.mockFunc()
+			got, err := service.EnrollTwoFactor(tt.request)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("UserService.EnrollTwoFactor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UserService.EnrollTwoFactor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserService_ConfirmTwoFactor(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mHash := mock_hash.NewMockHashMethod(mockCtrl)
+	mTwoFactor := mock_twofactor.NewMockTwoFactorStoreMethod(mockCtrl)
+	mTOTP := mock_totp.NewMockTOTPMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	enabledAt := time.Now()
+	pending := models.UserTwoFactor{
+		Model: gorm.Model{
+			ID: 2,
+		},
+		UserID: 1,
+		Secret: "SECRET",
+	}
+	tests := []struct {
+		name      string
+		request   ConfirmTwoFactorServiceRequest
+		mockFunc  func()
+		wantCodes int
+		wantErr   error
+	}{
+		{
+			name:    "success flow",
+			request: ConfirmTwoFactorServiceRequest{UserId: 1, Code: "123456"},
+			mockFunc: func() {
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(pending, nil)
+				mTOTP.EXPECT().ValidateCode("SECRET", "123456").Return(true)
+				mHash.EXPECT().HashValue(gomock.Any()).Return([]byte("hashed"), nil).Times(recoveryCodeCount)
+				mTwoFactor.EXPECT().SaveTwoFactor(gomock.Any()).DoAndReturn(func(twoFactor models.UserTwoFactor) error {
+					if !twoFactor.IsEnabled() || len(twoFactor.GetRecoveryCodes()) != recoveryCodeCount || twoFactor.Secret != "SECRET" {
+						t.Errorf("UserService.ConfirmTwoFactor() saved = %+v", twoFactor)
+					}
+					return nil
+				})
+			},
+			wantCodes: recoveryCodeCount,
+		},
+		{
+			name:    "error save flow",
+			request: ConfirmTwoFactorServiceRequest{UserId: 1, Code: "123456"},
+			mockFunc: func() {
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(pending, nil)
+				mTOTP.EXPECT().ValidateCode("SECRET", "123456").Return(true)
+				mHash.EXPECT().HashValue(gomock.Any()).Return([]byte("hashed"), nil).Times(recoveryCodeCount)
+				mTwoFactor.EXPECT().SaveTwoFactor(gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:    "error hash recovery code flow",
+			request: ConfirmTwoFactorServiceRequest{UserId: 1, Code: "123456"},
+			mockFunc: func() {
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(pending, nil)
+				mTOTP.EXPECT().ValidateCode("SECRET", "123456").Return(true)
+				mHash.EXPECT().HashValue(gomock.Any()).Return(nil, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:    "error invalid code flow",
+			request: ConfirmTwoFactorServiceRequest{UserId: 1, Code: "123456"},
+			mockFunc: func() {
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(pending, nil)
+				mTOTP.EXPECT().ValidateCode("SECRET", "123456").Return(false)
+			},
+			wantErr: ErrInvalidTwoFactorCode,
+		},
+		{
+			name:    "error already enabled flow",
+			request: ConfirmTwoFactorServiceRequest{UserId: 1, Code: "123456"},
+			mockFunc: func() {
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{Model: gorm.Model{ID: 2}, EnabledAt: &enabledAt}, nil)
+			},
+			wantErr: ErrTwoFactorAlreadyEnabled,
+		},
+		{
+			name:    "error not enrolled flow",
+			request: ConfirmTwoFactorServiceRequest{UserId: 1, Code: "123456"},
+			mockFunc: func() {
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{}, nil)
+			},
+			wantErr: ErrTwoFactorNotEnrolled,
+		},
+		{
+			name:    "error get two factor flow",
+			request: ConfirmTwoFactorServiceRequest{UserId: 1, Code: "123456"},
+			mockFunc: func() {
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{}, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:     "error invalid user id flow",
+			request:  ConfirmTwoFactorServiceRequest{Code: "123456"},
+			mockFunc: func() {},
+			wantErr:  ErrDataNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := UserService{
+				hash:      mHash,
+				twoFactor: mTwoFactor,
+				totp:      mTOTP,
+			}
+			tt.mockFunc()
+			got, err := service.ConfirmTwoFactor(tt.request)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("UserService.ConfirmTwoFactor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got.RecoveryCodes) != tt.wantCodes {
+				t.Errorf("UserService.ConfirmTwoFactor() = %v, want %d recovery code", got, tt.wantCodes)
+			}
+			for _, code := range got.RecoveryCodes {
+				if len(code) != 11 || code[5] != '-' {
+					t.Errorf("UserService.ConfirmTwoFactor() recovery code = %v, want xxxxx-xxxxx format", code)
+				}
+			}
+		})
+	}
+}
+
+func TestUserService_DisableTwoFactor(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mHash := mock_hash.NewMockHashMethod(mockCtrl)
+	mStore := mock.NewMockUserStoreMethod(mockCtrl)
+	mTwoFactor := mock_twofactor.NewMockTwoFactorStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	userInfo := models.User{
+		Model: gorm.Model{
+			ID: 1,
+		},
+		Password: "hash",
+	}
+	tests := []struct {
+		name     string
+		request  DisableTwoFactorServiceRequest
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name:    "success flow",
+			request: DisableTwoFactorServiceRequest{UserId: 1, Password: "password"},
+			mockFunc: func() {
+				mStore.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mHash.EXPECT().CompareValue("hash", "password").Return(true)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{Model: gorm.Model{ID: 2}}, nil)
+				mTwoFactor.EXPECT().DeleteTwoFactor(1).Return(nil)
+			},
+		},
+		{
+			name:    "error delete flow",
+			request: DisableTwoFactorServiceRequest{UserId: 1, Password: "password"},
+			mockFunc: func() {
+				mStore.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mHash.EXPECT().CompareValue("hash", "password").Return(true)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{Model: gorm.Model{ID: 2}}, nil)
+				mTwoFactor.EXPECT().DeleteTwoFactor(1).Return(fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:    "error not enrolled flow",
+			request: DisableTwoFactorServiceRequest{UserId: 1, Password: "password"},
+			mockFunc: func() {
+				mStore.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mHash.EXPECT().CompareValue("hash", "password").Return(true)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{}, nil)
+			},
+			wantErr: ErrTwoFactorNotEnrolled,
+		},
+		{
+			name:    "error get two factor flow",
+			request: DisableTwoFactorServiceRequest{UserId: 1, Password: "password"},
+			mockFunc: func() {
+				mStore.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mHash.EXPECT().CompareValue("hash", "password").Return(true)
+				mTwoFactor.EXPECT().GetTwoFactor(1).Return(models.UserTwoFactor{}, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:    "error password incorrect flow",
+			request: DisableTwoFactorServiceRequest{UserId: 1, Password: "password"},
+			mockFunc: func() {
+				mStore.EXPECT().GetUserInfoByID(1).Return(userInfo, nil)
+				mHash.EXPECT().CompareValue("hash", "password").Return(false)
+			},
+			wantErr: ErrPasswordIsIncorrect,
+		},
+		{
+			name:    "error get user flow",
+			request: DisableTwoFactorServiceRequest{UserId: 1, Password: "password"},
+			mockFunc: func() {
+				mStore.EXPECT().GetUserInfoByID(1).Return(models.User{}, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:     "error invalid user id flow",
+			request:  DisableTwoFactorServiceRequest{Password: "password"},
+			mockFunc: func() {},
+			wantErr:  ErrDataNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := UserService{
+				store:     mStore,
+				hash:      mHash,
+				twoFactor: mTwoFactor,
+			}
+			tt.mockFunc()
+			if err := service.DisableTwoFactor(tt.request); !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("UserService.DisableTwoFactor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserService_GetSessions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mSession := mock_session.NewMockSessionStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	lastSeenAt := time.Unix(1700000000, 0).UTC()
+	tests := []struct {
+		name     string
+		request  GetSessionsServiceRequest
+		mockFunc func()
+		want     []SessionInfo
+		wantErr  error
+	}{
+		{
+			name:    "success flow",
+			request: GetSessionsServiceRequest{UserId: 1, CurrentSessionID: "sid-2"},
+			mockFunc: func() {
+				mSession.EXPECT().GetActiveSessions(1).Return([]models.UserSession{
+					{SessionID: "sid-1", UserID: 1, Device: "iphone", IPAddress: "192.0.2.1", UserAgent: "agent", LastSeenAt: lastSeenAt},
+					{SessionID: "sid-2", UserID: 1, LastSeenAt: lastSeenAt},
+				}, nil)
+			},
+			want: []SessionInfo{
+				{
+					SessionID:   "sid-1",
+					Device:      "iphone",
+					IPAddress:   "192.0.2.1",
+					UserAgent:   "agent",
+					LastSeen:    "2023-11-14 22:13:20 +0000 UTC",
+					CreatedDate: "0001-01-01 00:00:00 +0000 UTC",
+				},
+				{
+					SessionID:   "sid-2",
+					LastSeen:    "2023-11-14 22:13:20 +0000 UTC",
+					CreatedDate: "0001-01-01 00:00:00 +0000 UTC",
+					IsCurrent:   true,
+				},
+			},
+		},
+		{
+			name:    "success no active session flow",
+			request: GetSessionsServiceRequest{UserId: 1},
+			mockFunc: func() {
+				mSession.EXPECT().GetActiveSessions(1).Return([]models.UserSession{}, nil)
+			},
+			want: []SessionInfo{},
+		},
+		{
+			name:    "error get sessions flow",
+			request: GetSessionsServiceRequest{UserId: 1},
+			mockFunc: func() {
+				mSession.EXPECT().GetActiveSessions(1).Return(nil, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:     "error invalid user id flow",
+			request:  GetSessionsServiceRequest{},
+			mockFunc: func() {},
+			wantErr:  ErrDataNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := UserService{
+				session: mSession,
+			}
+			tt.mockFunc()
+			got, err := service.GetSessions(tt.request)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("UserService.GetSessions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UserService.GetSessions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserService_RevokeSession(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mSession := mock_session.NewMockSessionStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name     string
+		request  RevokeSessionServiceRequest
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name:    "success flow",
+			request: RevokeSessionServiceRequest{UserId: 1, SessionID: "sid"},
+			mockFunc: func() {
+				mSession.EXPECT().RevokeSession(1, "sid", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "error session not found flow",
+			request: RevokeSessionServiceRequest{UserId: 1, SessionID: "sid"},
+			mockFunc: func() {
+				mSession.EXPECT().RevokeSession(1, "sid", gomock.Any()).Return(gorm.ErrRecordNotFound)
+			},
+			wantErr: ErrSessionNotFound,
+		},
+		{
+			name:    "error revoke session flow",
+			request: RevokeSessionServiceRequest{UserId: 1, SessionID: "sid"},
+			mockFunc: func() {
+				mSession.EXPECT().RevokeSession(1, "sid", gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name:     "error empty session id flow",
+			request:  RevokeSessionServiceRequest{UserId: 1},
+			mockFunc: func() {},
+			wantErr:  ErrSessionNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := UserService{
+				session: mSession,
 			}
 			tt.mockFunc()
-			if err := service.UpgradeUser(tt.args.request); (err != nil) != tt.wantErr {
-				t.Errorf("UserService.UpgradeUser() error = %v, wantErr %v", err, tt.wantErr)
+			if err := service.RevokeSession(tt.request); !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("UserService.RevokeSession() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
@@ -4,22 +4,64 @@ import "errors"
 
 // list Service error
 var (
-	ErrUserNameNotExists     = errors.New("username is not exists")
-	ErrUserNameAlreadyExists = errors.New("username already exists")
-	ErrPasswordIsIncorrect   = errors.New("password is incorrect")
-	ErrUserIsVerified        = errors.New("user already verified")
-	ErrUnauthorized          = errors.New("unauthorized")
-	ErrDataNotFound          = errors.New("data not found")
+	ErrUserNameNotExists       = errors.New("username is not exists")
+	ErrUserNameAlreadyExists   = errors.New("username already exists")
+	ErrPasswordIsIncorrect     = errors.New("password is incorrect")
+	ErrUnauthorized            = errors.New("unauthorized")
+	ErrDataNotFound            = errors.New("data not found")
+	ErrInvalidBirthdate        = errors.New("birthdate is invalid, the format should be YYYY-MM-DD and the user must be at least 18 years old")
+	ErrInvalidGender           = errors.New("gender is invalid, the value should be MALE, FEMALE or OTHER")
+	ErrInvalidAgeRange         = errors.New("age range is invalid, the age should be between 18 and 100 and age min cannot be greater than age max")
+	ErrInvalidMaxDistance      = errors.New("max distance is invalid, the distance should be between 1 and 500 km")
+	ErrInvalidLocation         = errors.New("location is invalid, latitude should be between -90 and 90 and longitude should be between -180 and 180")
+	ErrTwoFactorNotAllowed     = errors.New("two factor authentication only available for user with verified email")
+	ErrTwoFactorAlreadyEnabled = errors.New("two factor authentication is already enabled")
+	ErrTwoFactorNotEnrolled    = errors.New("two factor authentication is not enrolled")
+	ErrInvalidTwoFactorCode    = errors.New("two factor code is invalid")
+	ErrSessionNotFound         = errors.New("session is not found or already revoked")
+	ErrInvalidEmail            = errors.New("email is invalid")
 )
 
+// list of allowed age for user and discovery preference
+const (
+	MinimumAge = 18
+	MaximumAge = 100
+)
+
+// MaximumDistance is max allowed distance preference in kilometer
+const MaximumDistance = 500
+
+const birthdateFormat = "2006-01-02" // YYYY-MM-DD format
+
+// recoveryCodeCount is number of recovery code generated when the two factor authentication is enabled
+const recoveryCodeCount = 10
+
 // UserServiceInfo struct is list parameter info for user sevice
 type UserServiceInfo struct {
-	UserId      int
-	Username    string
-	Fullname    string
-	Email       string
-	IsVerified  bool
-	CreatedDate string
+	UserId   int
+	Username string
+	Fullname string
+	Email    string
+	// Plan is the subscription plan of the user
+	Plan string
+	// IsEmailVerified is true after the user verify the email
+	IsEmailVerified bool
+	Birthdate       string
+	Age             int
+	Gender          string
+	InterestedIn    []string
+	AgeMin          int
+	AgeMax          int
+	MaxDistance     int
+	Location        *LocationInfo
+	CreatedDate     string
+}
+
+// LocationInfo struct is last location shared by the user
+type LocationInfo struct {
+	Latitude    float64
+	Longitude   float64
+	UpdatedDate string
 }
 
 // DeleteUserServiceRequest is list parameter for add user by user
@@ -35,6 +77,22 @@ type UpdateUserServiceRequest struct {
 	Password string
 	Fullname string
 	Email    string
+	// Birthdate is in YYYY-MM-DD format
+	Birthdate string
+	Gender    string
+	// InterestedIn will be ignored if nil and reset to all gender if empty
+	InterestedIn []string
+	// AgeMin, AgeMax and MaxDistance will be ignored if nil and reset to no preference if 0
+	AgeMin      *int
+	AgeMax      *int
+	MaxDistance *int
+}
+
+// UpdateLocationServiceRequest is list parameter for update user location
+type UpdateLocationServiceRequest struct {
+	UserId    int
+	Latitude  float64
+	Longitude float64
 }
 
 // GetByIDServiceRequest is list parameter for get user by id
@@ -42,8 +100,55 @@ type GetByIDServiceRequest struct {
 	UserId int
 }
 
-// UpgradeServiceRequest is list parameter for upgrade user
-type UpgradeServiceRequest struct {
+// EnrollTwoFactorServiceRequest is list parameter for start the two factor authentication enrollment
+type EnrollTwoFactorServiceRequest struct {
+	UserId   int
+	Password string
+}
+
+// TwoFactorEnrollmentInfo is the secret that must be added to the authenticator app
+type TwoFactorEnrollmentInfo struct {
+	Secret string
+	URI    string
+}
+
+// ConfirmTwoFactorServiceRequest is list parameter for enable the two factor authentication with code from the authenticator app
+type ConfirmTwoFactorServiceRequest struct {
+	UserId int
+	Code   string
+}
+
+// TwoFactorRecoveryInfo is list recovery code that only shown once when the two factor authentication is enabled
+type TwoFactorRecoveryInfo struct {
+	RecoveryCodes []string
+}
+
+// DisableTwoFactorServiceRequest is list parameter for disable the two factor authentication
+type DisableTwoFactorServiceRequest struct {
 	UserId   int
 	Password string
 }
+
+// GetSessionsServiceRequest is list parameter for get active device session,
+// the current session id is used to mark the session of the request
+type GetSessionsServiceRequest struct {
+	UserId           int
+	CurrentSessionID string
+}
+
+// SessionInfo is device session of the user
+type SessionInfo struct {
+	SessionID   string
+	Device      string
+	IPAddress   string
+	UserAgent   string
+	LastSeen    string
+	CreatedDate string
+	IsCurrent   bool
+}
+
+// RevokeSessionServiceRequest is list parameter for revoke the device session
+type RevokeSessionServiceRequest struct {
+	UserId    int
+	SessionID string
+}
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/verification/service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	verification "gilsaputro/dating-apps/internal/service/verification"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockVerificationServiceMethod is a mock of VerificationServiceMethod interface.
+type MockVerificationServiceMethod struct {
+	ctrl     *gomock.Controller
+	recorder *MockVerificationServiceMethodMockRecorder
+}
+
+// MockVerificationServiceMethodMockRecorder is the mock recorder for MockVerificationServiceMethod.
+type MockVerificationServiceMethodMockRecorder struct {
+	mock *MockVerificationServiceMethod
+}
+
+// NewMockVerificationServiceMethod creates a new mock instance.
+func NewMockVerificationServiceMethod(ctrl *gomock.Controller) *MockVerificationServiceMethod {
+	mock := &MockVerificationServiceMethod{ctrl: ctrl}
+	mock.recorder = &MockVerificationServiceMethodMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVerificationServiceMethod) EXPECT() *MockVerificationServiceMethodMockRecorder {
+	return m.recorder
+}
+
+// GetListVerification mocks base method.
+func (m *MockVerificationServiceMethod) GetListVerification(request verification.VerificationListServiceRequest) (verification.VerificationListServiceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListVerification", request)
+	ret0, _ := ret[0].(verification.VerificationListServiceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListVerification indicates an expected call of GetListVerification.
+func (mr *MockVerificationServiceMethodMockRecorder) GetListVerification(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListVerification", reflect.TypeOf((*MockVerificationServiceMethod)(nil).GetListVerification), request)
+}
+
+// GetVerification mocks base method.
+func (m *MockVerificationServiceMethod) GetVerification(request verification.GetVerificationServiceRequest) (verification.VerificationStatusServiceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVerification", request)
+	ret0, _ := ret[0].(verification.VerificationStatusServiceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVerification indicates an expected call of GetVerification.
+func (mr *MockVerificationServiceMethodMockRecorder) GetVerification(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVerification", reflect.TypeOf((*MockVerificationServiceMethod)(nil).GetVerification), request)
+}
+
+// ReviewVerification mocks base method.
+func (m *MockVerificationServiceMethod) ReviewVerification(request verification.ReviewVerificationServiceRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewVerification", request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReviewVerification indicates an expected call of ReviewVerification.
+func (mr *MockVerificationServiceMethodMockRecorder) ReviewVerification(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewVerification", reflect.TypeOf((*MockVerificationServiceMethod)(nil).ReviewVerification), request)
+}
+
+// SubmitVerification mocks base method.
+func (m *MockVerificationServiceMethod) SubmitVerification(request verification.SubmitVerificationServiceRequest) (verification.VerificationServiceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitVerification", request)
+	ret0, _ := ret[0].(verification.VerificationServiceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitVerification indicates an expected call of SubmitVerification.
+func (mr *MockVerificationServiceMethodMockRecorder) SubmitVerification(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitVerification", reflect.TypeOf((*MockVerificationServiceMethod)(nil).SubmitVerification), request)
+}
@@ -0,0 +1,240 @@
+package verification
+
+import (
+	"gilsaputro/dating-apps/internal/store/user"
+	"gilsaputro/dating-apps/internal/store/verification"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/pagination"
+	"net/url"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
+
+// VerificationServiceMethod is list method for Verification Service
+type VerificationServiceMethod interface {
+	SubmitVerification(request SubmitVerificationServiceRequest) (VerificationServiceInfo, error)
+	GetVerification(request GetVerificationServiceRequest) (VerificationStatusServiceInfo, error)
+	GetListVerification(request VerificationListServiceRequest) (VerificationListServiceInfo, error)
+	ReviewVerification(request ReviewVerificationServiceRequest) error
+}
+
+// VerificationService is list dependencies for verification service
+type VerificationService struct {
+	store     verification.VerificationStoreMethod
+	storeUser user.UserStoreMethod
+}
+
+// NewVerificationService is func to generate VerificationServiceMethod interface
+func NewVerificationService(store verification.VerificationStoreMethod, storeUser user.UserStoreMethod) VerificationServiceMethod {
+	return &VerificationService{
+		store:     store,
+		storeUser: storeUser,
+	}
+}
+
+// SubmitVerification is func to put the selfie or id document of the user into review queue,
+// the user can submit again after the previous request is rejected
+func (v *VerificationService) SubmitVerification(request SubmitVerificationServiceRequest) (VerificationServiceInfo, error) {
+	verificationType := models.ParseVerificationType(strings.ToUpper(request.Type))
+	if verificationType == models.VerificationTypeUnknown {
+		return VerificationServiceInfo{}, ErrInvalidVerificationType
+	}
+
+	if !isValidMediaURL(request.MediaURL) {
+		return VerificationServiceInfo{}, ErrInvalidMediaURL
+	}
+
+	userInfo, err := v.getUser(request.UserId)
+	if err != nil {
+		return VerificationServiceInfo{}, err
+	}
+
+	if userInfo.IsIdentityVerified {
+		return VerificationServiceInfo{}, ErrAlreadyVerified
+	}
+
+	latest, err := v.store.GetLatestVerification(request.UserId)
+	if err != nil {
+		return VerificationServiceInfo{}, err
+	}
+
+	if latest.ID > 0 && latest.Status == models.VerificationStatusPending {
+		return VerificationServiceInfo{}, ErrVerificationPending
+	}
+
+	result, err := v.store.CreateVerification(models.VerificationRequest{
+		UserID:   userInfo.ID,
+		Type:     verificationType,
+		MediaURL: request.MediaURL,
+	})
+	if err != nil {
+		return VerificationServiceInfo{}, err
+	}
+
+	return mapVerificationServiceInfo(result), nil
+}
+
+// GetVerification is func to get the verification badge and the last verification request of the user
+func (v *VerificationService) GetVerification(request GetVerificationServiceRequest) (VerificationStatusServiceInfo, error) {
+	userInfo, err := v.getUser(request.UserId)
+	if err != nil {
+		return VerificationStatusServiceInfo{}, err
+	}
+
+	latest, err := v.store.GetLatestVerification(request.UserId)
+	if err != nil {
+		return VerificationStatusServiceInfo{}, err
+	}
+
+	result := VerificationStatusServiceInfo{
+		IsVerified: userInfo.IsIdentityVerified,
+	}
+	if latest.ID > 0 {
+		info := mapVerificationServiceInfo(latest)
+		result.Request = &info
+	}
+
+	return result, nil
+}
+
+// GetListVerification is func to get paginated verification request of review queue
+func (v *VerificationService) GetListVerification(request VerificationListServiceRequest) (VerificationListServiceInfo, error) {
+	status := models.VerificationStatusPending
+	if len(request.Status) > 0 {
+		status = models.ParseVerificationStatus(strings.ToUpper(request.Status))
+		if status == models.VerificationStatusUnknown {
+			return VerificationListServiceInfo{}, ErrInvalidVerificationStatus
+		}
+	}
+
+	page, limit := pagination.Normalize(request.Page, request.Limit, DefaultVerificationPageLimit, MaxVerificationPageLimit)
+	result := VerificationListServiceInfo{
+		Verifications: []VerificationServiceInfo{},
+		Page:          page,
+		Limit:         limit,
+	}
+
+	total, err := v.store.CountVerification(status)
+	if err != nil {
+		return VerificationListServiceInfo{}, err
+	}
+	result.Total = total
+
+	offset := (page - 1) * limit
+	if offset >= total {
+		return result, nil
+	}
+
+	list, err := v.store.GetVerificationList(verification.VerificationFilter{
+		Status: status,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return VerificationListServiceInfo{}, err
+	}
+
+	for _, data := range list {
+		result.Verifications = append(result.Verifications, mapVerificationServiceInfo(data))
+	}
+
+	return result, nil
+}
+
+// ReviewVerification is func to approve or reject the pending verification request by admin,
+// the approved request give the verification badge to the user
+func (v *VerificationService) ReviewVerification(request ReviewVerificationServiceRequest) error {
+	status := models.ParseVerificationStatus(strings.ToUpper(request.Status))
+	if status != models.VerificationStatusApproved && status != models.VerificationStatusRejected {
+		return ErrInvalidVerificationStatus
+	}
+
+	if status == models.VerificationStatusRejected && len(strings.TrimSpace(request.RejectReason)) == 0 {
+		return ErrRejectReasonRequired
+	}
+
+	if request.VerificationID <= 0 {
+		return ErrVerificationNotFound
+	}
+
+	data, err := v.store.GetVerificationByID(request.VerificationID)
+	if err != nil {
+		return err
+	}
+
+	if data.ID == 0 || data.Status != models.VerificationStatusPending {
+		return ErrVerificationNotFound
+	}
+
+	review := models.VerificationRequest{
+		Model:      gorm.Model{ID: data.ID},
+		Status:     status,
+		ReviewedBy: uint(request.AdminID),
+	}
+	if status == models.VerificationStatusRejected {
+		review.RejectReason = request.RejectReason
+	}
+
+	// the request is only closed once, so the concurrent review cannot approve the rejected request
+	err = v.store.ReviewVerification(review)
+	if gorm.IsRecordNotFoundError(err) {
+		return ErrVerificationNotFound
+	}
+
+	if err != nil || status != models.VerificationStatusApproved {
+		return err
+	}
+
+	// only the flag is updated, so the profile edit at the same time does not revert the approval
+	err = v.storeUser.UpdateIdentityVerified(int(data.UserID))
+	if gorm.IsRecordNotFoundError(err) {
+		// the user is deleted, nothing to verify
+		return nil
+	}
+	return err
+}
+
+// getUser is func to get the user info, it will return data not found if the user is not exists
+func (v *VerificationService) getUser(userID int) (models.User, error) {
+	if userID <= 0 {
+		return models.User{}, ErrDataNotFound
+	}
+
+	userInfo, err := v.storeUser.GetUserInfoByID(userID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return models.User{}, ErrDataNotFound
+		}
+		return models.User{}, err
+	}
+
+	return userInfo, nil
+}
+
+// isValidMediaURL is func to check the media url is absolute http or https url
+func isValidMediaURL(mediaURL string) bool {
+	parsed, err := url.Parse(mediaURL)
+	if err != nil {
+		return false
+	}
+
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && len(parsed.Host) > 0
+}
+
+// mapVerificationServiceInfo is func to convert verification request into verification service info
+func mapVerificationServiceInfo(data models.VerificationRequest) VerificationServiceInfo {
+	info := VerificationServiceInfo{
+		VerificationID: int(data.ID),
+		UserID:         int(data.UserID),
+		Type:           data.Type.String(),
+		MediaURL:       data.MediaURL,
+		Status:         data.Status.String(),
+		RejectReason:   data.RejectReason,
+		CreatedDate:    data.CreatedAt.String(),
+	}
+	if data.ReviewedAt != nil {
+		info.ReviewedDate = data.ReviewedAt.String()
+	}
+	return info
+}
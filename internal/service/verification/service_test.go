@@ -0,0 +1,510 @@
+package verification
+
+import (
+	"fmt"
+	"gilsaputro/dating-apps/internal/store/user"
+	mock_user "gilsaputro/dating-apps/internal/store/user/mock"
+	"gilsaputro/dating-apps/internal/store/verification"
+	mock_verification "gilsaputro/dating-apps/internal/store/verification/mock"
+	"gilsaputro/dating-apps/models"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+)
+
+func TestNewVerificationService(t *testing.T) {
+	type args struct {
+		store     verification.VerificationStoreMethod
+		storeUser user.UserStoreMethod
+	}
+	tests := []struct {
+		name string
+		args args
+		want VerificationServiceMethod
+	}{
+		{
+			name: "success",
+			args: args{
+				store:     &verification.VerificationStore{},
+				storeUser: &user.UserStore{},
+			},
+			want: &VerificationService{
+				store:     &verification.VerificationStore{},
+				storeUser: &user.UserStore{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewVerificationService(tt.args.store, tt.args.storeUser); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewVerificationService() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerificationService_SubmitVerification(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	vStore := mock_verification.NewMockVerificationStoreMethod(mockCtrl)
+	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	type args struct {
+		request SubmitVerificationServiceRequest
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     VerificationServiceInfo
+		wantErr  error
+	}{
+		{
+			name: "success",
+			args: args{
+				request: SubmitVerificationServiceRequest{UserId: 1, Type: "selfie", MediaURL: "https://cdn.local/selfie.jpg"},
+			},
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				vStore.EXPECT().GetLatestVerification(1).Return(models.VerificationRequest{}, nil)
+				vStore.EXPECT().CreateVerification(models.VerificationRequest{UserID: 1, Type: models.VerificationTypeSelfie, MediaURL: "https://cdn.local/selfie.jpg"}).
+					Return(models.VerificationRequest{Model: gorm.Model{ID: 3, CreatedAt: created}, UserID: 1, Type: models.VerificationTypeSelfie, MediaURL: "https://cdn.local/selfie.jpg", Status: models.VerificationStatusPending}, nil)
+			},
+			want: VerificationServiceInfo{
+				VerificationID: 3,
+				UserID:         1,
+				Type:           "SELFIE",
+				MediaURL:       "https://cdn.local/selfie.jpg",
+				Status:         "PENDING",
+				CreatedDate:    created.String(),
+			},
+		},
+		{
+			name: "success resubmit after rejected",
+			args: args{
+				request: SubmitVerificationServiceRequest{UserId: 1, Type: "ID_DOCUMENT", MediaURL: "http://cdn.local/id.jpg"},
+			},
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				vStore.EXPECT().GetLatestVerification(1).Return(models.VerificationRequest{Model: gorm.Model{ID: 2}, Status: models.VerificationStatusRejected}, nil)
+				vStore.EXPECT().CreateVerification(models.VerificationRequest{UserID: 1, Type: models.VerificationTypeIDDocument, MediaURL: "http://cdn.local/id.jpg"}).
+					Return(models.VerificationRequest{Model: gorm.Model{ID: 3, CreatedAt: created}, UserID: 1, Type: models.VerificationTypeIDDocument, MediaURL: "http://cdn.local/id.jpg", Status: models.VerificationStatusPending}, nil)
+			},
+			want: VerificationServiceInfo{
+				VerificationID: 3,
+				UserID:         1,
+				Type:           "ID_DOCUMENT",
+				MediaURL:       "http://cdn.local/id.jpg",
+				Status:         "PENDING",
+				CreatedDate:    created.String(),
+			},
+		},
+		{
+			name: "error invalid type",
+			args: args{
+				request: SubmitVerificationServiceRequest{UserId: 1, Type: "PASSPORT", MediaURL: "https://cdn.local/selfie.jpg"},
+			},
+			mockFunc: func() {},
+			wantErr:  ErrInvalidVerificationType,
+		},
+		{
+			name: "error invalid media url",
+			args: args{
+				request: SubmitVerificationServiceRequest{UserId: 1, Type: "SELFIE", MediaURL: "ftp://cdn.local/selfie.jpg"},
+			},
+			mockFunc: func() {},
+			wantErr:  ErrInvalidMediaURL,
+		},
+		{
+			name: "error user not found",
+			args: args{
+				request: SubmitVerificationServiceRequest{UserId: 1, Type: "SELFIE", MediaURL: "https://cdn.local/selfie.jpg"},
+			},
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{}, gorm.ErrRecordNotFound)
+			},
+			wantErr: ErrDataNotFound,
+		},
+		{
+			name: "error already verified",
+			args: args{
+				request: SubmitVerificationServiceRequest{UserId: 1, Type: "SELFIE", MediaURL: "https://cdn.local/selfie.jpg"},
+			},
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}, IsIdentityVerified: true}, nil)
+			},
+			wantErr: ErrAlreadyVerified,
+		},
+		{
+			name: "error pending request",
+			args: args{
+				request: SubmitVerificationServiceRequest{UserId: 1, Type: "SELFIE", MediaURL: "https://cdn.local/selfie.jpg"},
+			},
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				vStore.EXPECT().GetLatestVerification(1).Return(models.VerificationRequest{Model: gorm.Model{ID: 2}, Status: models.VerificationStatusPending}, nil)
+			},
+			wantErr: ErrVerificationPending,
+		},
+		{
+			name: "error on create",
+			args: args{
+				request: SubmitVerificationServiceRequest{UserId: 1, Type: "SELFIE", MediaURL: "https://cdn.local/selfie.jpg"},
+			},
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				vStore.EXPECT().GetLatestVerification(1).Return(models.VerificationRequest{}, nil)
+				vStore.EXPECT().CreateVerification(gomock.Any()).Return(models.VerificationRequest{}, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := VerificationService{
+				store:     vStore,
+				storeUser: uStore,
+			}
+			tt.mockFunc()
+			got, err := v.SubmitVerification(tt.args.request)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("VerificationService.SubmitVerification() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VerificationService.SubmitVerification() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerificationService_GetVerification(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	vStore := mock_verification.NewMockVerificationStoreMethod(mockCtrl)
+	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	reviewed := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	type args struct {
+		request GetVerificationServiceRequest
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     VerificationStatusServiceInfo
+		wantErr  error
+	}{
+		{
+			name: "success verified",
+			args: args{
+				request: GetVerificationServiceRequest{UserId: 1},
+			},
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}, IsIdentityVerified: true}, nil)
+				vStore.EXPECT().GetLatestVerification(1).Return(models.VerificationRequest{Model: gorm.Model{ID: 2, CreatedAt: created}, UserID: 1, Type: models.VerificationTypeSelfie, MediaURL: "https://cdn.local/selfie.jpg", Status: models.VerificationStatusApproved, ReviewedBy: 9, ReviewedAt: &reviewed}, nil)
+			},
+			want: VerificationStatusServiceInfo{
+				IsVerified: true,
+				Request: &VerificationServiceInfo{
+					VerificationID: 2,
+					UserID:         1,
+					Type:           "SELFIE",
+					MediaURL:       "https://cdn.local/selfie.jpg",
+					Status:         "APPROVED",
+					CreatedDate:    created.String(),
+					ReviewedDate:   reviewed.String(),
+				},
+			},
+		},
+		{
+			name: "success never submit",
+			args: args{
+				request: GetVerificationServiceRequest{UserId: 1},
+			},
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				vStore.EXPECT().GetLatestVerification(1).Return(models.VerificationRequest{}, nil)
+			},
+			want: VerificationStatusServiceInfo{},
+		},
+		{
+			name: "error user not found",
+			args: args{
+				request: GetVerificationServiceRequest{UserId: 1},
+			},
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{}, fmt.Errorf("record not found"))
+			},
+			wantErr: ErrDataNotFound,
+		},
+		{
+			name: "error on get latest",
+			args: args{
+				request: GetVerificationServiceRequest{UserId: 1},
+			},
+			mockFunc: func() {
+				uStore.EXPECT().GetUserInfoByID(1).Return(models.User{Model: gorm.Model{ID: 1}}, nil)
+				vStore.EXPECT().GetLatestVerification(1).Return(models.VerificationRequest{}, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := VerificationService{
+				store:     vStore,
+				storeUser: uStore,
+			}
+			tt.mockFunc()
+			got, err := v.GetVerification(tt.args.request)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("VerificationService.GetVerification() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VerificationService.GetVerification() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerificationService_GetListVerification(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	vStore := mock_verification.NewMockVerificationStoreMethod(mockCtrl)
+	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	type args struct {
+		request VerificationListServiceRequest
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		want     VerificationListServiceInfo
+		wantErr  error
+	}{
+		{
+			name: "success default pending",
+			args: args{
+				request: VerificationListServiceRequest{},
+			},
+			mockFunc: func() {
+				vStore.EXPECT().CountVerification(models.VerificationStatusPending).Return(1, nil)
+				vStore.EXPECT().GetVerificationList(verification.VerificationFilter{Status: models.VerificationStatusPending, Limit: DefaultVerificationPageLimit, Offset: 0}).
+					Return([]models.VerificationRequest{{Model: gorm.Model{ID: 2, CreatedAt: created}, UserID: 1, Type: models.VerificationTypeSelfie, MediaURL: "https://cdn.local/selfie.jpg", Status: models.VerificationStatusPending}}, nil)
+			},
+			want: VerificationListServiceInfo{
+				Verifications: []VerificationServiceInfo{
+					{
+						VerificationID: 2,
+						UserID:         1,
+						Type:           "SELFIE",
+						MediaURL:       "https://cdn.local/selfie.jpg",
+						Status:         "PENDING",
+						CreatedDate:    created.String(),
+					},
+				},
+				Page:  1,
+				Limit: DefaultVerificationPageLimit,
+				Total: 1,
+			},
+		},
+		{
+			name: "success page out of range",
+			args: args{
+				request: VerificationListServiceRequest{Status: "rejected", Page: 3, Limit: 100},
+			},
+			mockFunc: func() {
+				vStore.EXPECT().CountVerification(models.VerificationStatusRejected).Return(10, nil)
+			},
+			want: VerificationListServiceInfo{
+				Verifications: []VerificationServiceInfo{},
+				Page:          3,
+				Limit:         MaxVerificationPageLimit,
+				Total:         10,
+			},
+		},
+		{
+			name: "error invalid status",
+			args: args{
+				request: VerificationListServiceRequest{Status: "DONE"},
+			},
+			mockFunc: func() {},
+			wantErr:  ErrInvalidVerificationStatus,
+		},
+		{
+			name: "error on count",
+			args: args{
+				request: VerificationListServiceRequest{},
+			},
+			mockFunc: func() {
+				vStore.EXPECT().CountVerification(models.VerificationStatusPending).Return(0, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error on get list",
+			args: args{
+				request: VerificationListServiceRequest{},
+			},
+			mockFunc: func() {
+				vStore.EXPECT().CountVerification(models.VerificationStatusPending).Return(1, nil)
+				vStore.EXPECT().GetVerificationList(gomock.Any()).Return(nil, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := VerificationService{
+				store:     vStore,
+				storeUser: uStore,
+			}
+			tt.mockFunc()
+			got, err := v.GetListVerification(tt.args.request)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("VerificationService.GetListVerification() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VerificationService.GetListVerification() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerificationService_ReviewVerification(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	vStore := mock_verification.NewMockVerificationStoreMethod(mockCtrl)
+	uStore := mock_user.NewMockUserStoreMethod(mockCtrl)
+	defer mockCtrl.Finish()
+	pending := models.VerificationRequest{Model: gorm.Model{ID: 2}, UserID: 1, Status: models.VerificationStatusPending}
+	type args struct {
+		request ReviewVerificationServiceRequest
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name: "success approve",
+			args: args{
+				request: ReviewVerificationServiceRequest{VerificationID: 2, AdminID: 9, Status: "APPROVED"},
+			},
+			mockFunc: func() {
+				vStore.EXPECT().GetVerificationByID(2).Return(pending, nil)
+				vStore.EXPECT().ReviewVerification(models.VerificationRequest{Model: gorm.Model{ID: 2}, Status: models.VerificationStatusApproved, ReviewedBy: 9}).Return(nil)
+				uStore.EXPECT().UpdateIdentityVerified(1).Return(nil)
+			},
+		},
+		{
+			name: "success reject",
+			args: args{
+				request: ReviewVerificationServiceRequest{VerificationID: 2, AdminID: 9, Status: "rejected", RejectReason: "blurry photo"},
+			},
+			mockFunc: func() {
+				vStore.EXPECT().GetVerificationByID(2).Return(pending, nil)
+				vStore.EXPECT().ReviewVerification(models.VerificationRequest{Model: gorm.Model{ID: 2}, Status: models.VerificationStatusRejected, ReviewedBy: 9, RejectReason: "blurry photo"}).Return(nil)
+			},
+		},
+		{
+			name: "success approve deleted user",
+			args: args{
+				request: ReviewVerificationServiceRequest{VerificationID: 2, AdminID: 9, Status: "APPROVED"},
+			},
+			mockFunc: func() {
+				vStore.EXPECT().GetVerificationByID(2).Return(pending, nil)
+				vStore.EXPECT().ReviewVerification(gomock.Any()).Return(nil)
+				uStore.EXPECT().UpdateIdentityVerified(1).Return(gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			name: "error invalid status",
+			args: args{
+				request: ReviewVerificationServiceRequest{VerificationID: 2, AdminID: 9, Status: "PENDING"},
+			},
+			mockFunc: func() {},
+			wantErr:  ErrInvalidVerificationStatus,
+		},
+		{
+			name: "error reject without reason",
+			args: args{
+				request: ReviewVerificationServiceRequest{VerificationID: 2, AdminID: 9, Status: "REJECTED", RejectReason: " "},
+			},
+			mockFunc: func() {},
+			wantErr:  ErrRejectReasonRequired,
+		},
+		{
+			name: "error not found",
+			args: args{
+				request: ReviewVerificationServiceRequest{VerificationID: 2, AdminID: 9, Status: "APPROVED"},
+			},
+			mockFunc: func() {
+				vStore.EXPECT().GetVerificationByID(2).Return(models.VerificationRequest{}, nil)
+			},
+			wantErr: ErrVerificationNotFound,
+		},
+		{
+			name: "error already reviewed",
+			args: args{
+				request: ReviewVerificationServiceRequest{VerificationID: 2, AdminID: 9, Status: "APPROVED"},
+			},
+			mockFunc: func() {
+				vStore.EXPECT().GetVerificationByID(2).Return(models.VerificationRequest{Model: gorm.Model{ID: 2}, Status: models.VerificationStatusRejected}, nil)
+			},
+			wantErr: ErrVerificationNotFound,
+		},
+		{
+			name: "error reviewed concurrently",
+			args: args{
+				request: ReviewVerificationServiceRequest{VerificationID: 2, AdminID: 9, Status: "APPROVED"},
+			},
+			mockFunc: func() {
+				vStore.EXPECT().GetVerificationByID(2).Return(pending, nil)
+				vStore.EXPECT().ReviewVerification(gomock.Any()).Return(gorm.ErrRecordNotFound)
+			},
+			wantErr: ErrVerificationNotFound,
+		},
+		{
+			name: "error on get verification",
+			args: args{
+				request: ReviewVerificationServiceRequest{VerificationID: 2, AdminID: 9, Status: "APPROVED"},
+			},
+			mockFunc: func() {
+				vStore.EXPECT().GetVerificationByID(2).Return(models.VerificationRequest{}, fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+		{
+			name: "error on update user",
+			args: args{
+				request: ReviewVerificationServiceRequest{VerificationID: 2, AdminID: 9, Status: "APPROVED"},
+			},
+			mockFunc: func() {
+				vStore.EXPECT().GetVerificationByID(2).Return(pending, nil)
+				vStore.EXPECT().ReviewVerification(gomock.Any()).Return(nil)
+				uStore.EXPECT().UpdateIdentityVerified(1).Return(fmt.Errorf("some error"))
+			},
+			wantErr: fmt.Errorf("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := VerificationService{
+				store:     vStore,
+				storeUser: uStore,
+			}
+			tt.mockFunc()
+			if err := v.ReviewVerification(tt.args.request); !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("VerificationService.ReviewVerification() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package verification
+
+import "errors"
+
+// list Service error
+var (
+	ErrDataNotFound              = errors.New("data not found")
+	ErrInvalidVerificationType   = errors.New("verification type is invalid, the value should be SELFIE or ID_DOCUMENT")
+	ErrInvalidMediaURL           = errors.New("media url is invalid, the value should be http or https url")
+	ErrAlreadyVerified           = errors.New("user is already verified")
+	ErrVerificationPending       = errors.New("user already has pending verification request")
+	ErrInvalidVerificationStatus = errors.New("verification status is invalid")
+	ErrRejectReasonRequired      = errors.New("reject reason is required to reject the verification request")
+	ErrVerificationNotFound      = errors.New("the verification request is not found or already reviewed")
+)
+
+const (
+	// DefaultVerificationPageLimit is default number of verification request returned for each page
+	DefaultVerificationPageLimit = 10
+	// MaxVerificationPageLimit is max number of verification request returned for each page
+	MaxVerificationPageLimit = 50
+)
+
+// SubmitVerificationServiceRequest is list parameter for submit verification request
+type SubmitVerificationServiceRequest struct {
+	UserId int
+	// Type is SELFIE or ID_DOCUMENT
+	Type     string
+	MediaURL string
+}
+
+// GetVerificationServiceRequest is list parameter for get verification status of the user
+type GetVerificationServiceRequest struct {
+	UserId int
+}
+
+// VerificationServiceInfo struct is list parameter info for a verification request
+type VerificationServiceInfo struct {
+	VerificationID int
+	UserID         int
+	Type           string
+	MediaURL       string
+	Status         string
+	RejectReason   string
+	CreatedDate    string
+	ReviewedDate   string
+}
+
+// VerificationStatusServiceInfo struct is verification badge of the user with the last submitted request,
+// the request is nil when the user never submit the verification request
+type VerificationStatusServiceInfo struct {
+	IsVerified bool
+	Request    *VerificationServiceInfo
+}
+
+// VerificationListServiceRequest is list parameter for get verification review queue
+type VerificationListServiceRequest struct {
+	// Status is the verification status name, empty means PENDING
+	Status string
+	Page   int
+	Limit  int
+}
+
+// VerificationListServiceInfo struct is list verification request with the pagination info
+type VerificationListServiceInfo struct {
+	Verifications []VerificationServiceInfo
+	Page          int
+	Limit         int
+	Total         int
+}
+
+// ReviewVerificationServiceRequest is list parameter for review verification request
+type ReviewVerificationServiceRequest struct {
+	VerificationID int
+	AdminID        int
+	// Status is APPROVED or REJECTED
+	Status       string
+	RejectReason string
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/store/block/store.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	models "gilsaputro/dating-apps/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBlockStoreMethod is a mock of BlockStoreMethod interface.
+type MockBlockStoreMethod struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockStoreMethodMockRecorder
+}
+
+// MockBlockStoreMethodMockRecorder is the mock recorder for MockBlockStoreMethod.
+type MockBlockStoreMethodMockRecorder struct {
+	mock *MockBlockStoreMethod
+}
+
+// NewMockBlockStoreMethod creates a new mock instance.
+func NewMockBlockStoreMethod(ctrl *gomock.Controller) *MockBlockStoreMethod {
+	mock := &MockBlockStoreMethod{ctrl: ctrl}
+	mock.recorder = &MockBlockStoreMethodMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockStoreMethod) EXPECT() *MockBlockStoreMethodMockRecorder {
+	return m.recorder
+}
+
+// CreateBlock mocks base method.
+func (m *MockBlockStoreMethod) CreateBlock(block models.UserBlock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBlock", block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBlock indicates an expected call of CreateBlock.
+func (mr *MockBlockStoreMethodMockRecorder) CreateBlock(block interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBlock", reflect.TypeOf((*MockBlockStoreMethod)(nil).CreateBlock), block)
+}
+
+// GetBlockedUserIDs mocks base method.
+func (m *MockBlockStoreMethod) GetBlockedUserIDs(userID int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockedUserIDs", userID)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockedUserIDs indicates an expected call of GetBlockedUserIDs.
+func (mr *MockBlockStoreMethodMockRecorder) GetBlockedUserIDs(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockedUserIDs", reflect.TypeOf((*MockBlockStoreMethod)(nil).GetBlockedUserIDs), userID)
+}
+
+// IsBlocked mocks base method.
+func (m *MockBlockStoreMethod) IsBlocked(userID, otherUserID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBlocked", userID, otherUserID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBlocked indicates an expected call of IsBlocked.
+func (mr *MockBlockStoreMethodMockRecorder) IsBlocked(userID, otherUserID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBlocked", reflect.TypeOf((*MockBlockStoreMethod)(nil).IsBlocked), userID, otherUserID)
+}
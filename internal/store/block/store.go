@@ -0,0 +1,90 @@
+package block
+
+import (
+	"errors"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/postgres"
+
+	"github.com/jinzhu/gorm"
+)
+
+// BlockStoreMethod is set of methods for interacting with a user block storage system
+type BlockStoreMethod interface {
+	CreateBlock(block models.UserBlock) error
+	GetBlockedUserIDs(userID int) ([]int, error)
+	IsBlocked(userID, otherUserID int) (bool, error)
+}
+
+// BlockStore is list dependencies block store
+type BlockStore struct {
+	pg postgres.PostgresMethod
+}
+
+// NewBlockStore is func to generate BlockStoreMethod interface
+func NewBlockStore(pg postgres.PostgresMethod) BlockStoreMethod {
+	return &BlockStore{
+		pg: pg,
+	}
+}
+
+func (b *BlockStore) getDB() (*gorm.DB, error) {
+	db := b.pg.GetDB()
+	if db == nil {
+		return nil, errors.New("Database Client is not init")
+	}
+
+	return db, nil
+}
+
+// CreateBlock is func to store the block, blocking the same user again does nothing
+func (b *BlockStore) CreateBlock(block models.UserBlock) error {
+	db, err := b.getDB()
+	if err != nil {
+		return err
+	}
+
+	var existing models.UserBlock
+	err = db.Where("user_id = ? AND blocked_user_id = ?", block.UserID, block.BlockedUserID).First(&existing).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return db.Create(&block).Error
+	}
+
+	return err
+}
+
+// GetBlockedUserIDs is func to get user id blocked by the user or blocking the user
+func (b *BlockStore) GetBlockedUserIDs(userID int) ([]int, error) {
+	db, err := b.getDB()
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := []models.UserBlock{}
+	err = db.Where("user_id = ? OR blocked_user_id = ?", userID, userID).Find(&blocks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]int, 0, len(blocks))
+	for _, block := range blocks {
+		result = append(result, int(block.GetOtherUserID(uint(userID))))
+	}
+
+	return result, nil
+}
+
+// IsBlocked is func to check whether one of the user block the other user
+func (b *BlockStore) IsBlocked(userID, otherUserID int) (bool, error) {
+	db, err := b.getDB()
+	if err != nil {
+		return false, err
+	}
+
+	var count int
+	err = db.Model(models.UserBlock{}).Where("(user_id = ? AND blocked_user_id = ?) OR (user_id = ? AND blocked_user_id = ?)", userID, otherUserID, otherUserID, userID).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
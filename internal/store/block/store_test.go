@@ -0,0 +1,243 @@
+package block
+
+import (
+	"database/sql"
+	"fmt"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/postgres"
+	mock_postgres "gilsaputro/dating-apps/pkg/postgres/mock"
+	"log"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestNewBlockStore(t *testing.T) {
+	type args struct {
+		pg postgres.PostgresMethod
+	}
+	tests := []struct {
+		name string
+		args args
+		want BlockStoreMethod
+	}{
+		{
+			name: "success flow",
+			args: args{
+				pg: &postgres.Client{},
+			},
+			want: &BlockStore{
+				pg: &postgres.Client{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewBlockStore(tt.args.pg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewBlockStore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func InitDBsMockupStat() (*sql.DB, sqlmock.Sqlmock, *gorm.DB) {
+	db, mock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open("postgres", db)
+	gormDB.LogMode(true)
+	gormDB.SetLogger(log.New(os.Stdout, "\n", 0))
+	gormDB.Debug()
+	return db, mock, gormDB
+}
+
+func TestBlockStore_CreateBlock(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "success create new block",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_blocks"  WHERE "user_blocks"."deleted_at" IS NULL AND ((user_id = $1 AND blocked_user_id = $2)) ORDER BY "user_blocks"."id" ASC LIMIT 1`)).
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_blocks" ("created_at","updated_at","deleted_at","user_id","blocked_user_id") VALUES ($1,$2,$3,$4,$5) RETURNING "user_blocks"."id"`)).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "success already blocked",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_blocks"  WHERE "user_blocks"."deleted_at" IS NULL AND ((user_id = $1 AND blocked_user_id = $2)) ORDER BY "user_blocks"."id" ASC LIMIT 1`)).
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "blocked_user_id"}).AddRow(1, 1, 2))
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_blocks"  WHERE "user_blocks"."deleted_at" IS NULL AND ((user_id = $1 AND blocked_user_id = $2)) ORDER BY "user_blocks"."id" ASC LIMIT 1`)).
+					WillReturnError(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := BlockStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			if err := store.CreateBlock(models.UserBlock{UserID: 1, BlockedUserID: 2}); (err != nil) != tt.wantErr {
+				t.Errorf("BlockStore.CreateBlock() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBlockStore_GetBlockedUserIDs(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     []int
+		wantErr  bool
+	}{
+		{
+			name: "success both direction",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_blocks"  WHERE "user_blocks"."deleted_at" IS NULL AND ((user_id = $1 OR blocked_user_id = $2))`)).
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "blocked_user_id"}).AddRow(1, 1, 2).AddRow(2, 3, 1))
+			},
+			want:    []int{2, 3},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_blocks"  WHERE "user_blocks"."deleted_at" IS NULL AND ((user_id = $1 OR blocked_user_id = $2))`)).
+					WillReturnError(fmt.Errorf("some error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := BlockStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.GetBlockedUserIDs(1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BlockStore.GetBlockedUserIDs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BlockStore.GetBlockedUserIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlockStore_IsBlocked(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     bool
+		wantErr  bool
+	}{
+		{
+			name: "success blocked",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "user_blocks"  WHERE "user_blocks"."deleted_at" IS NULL AND (((user_id = $1 AND blocked_user_id = $2) OR (user_id = $3 AND blocked_user_id = $4)))`)).
+					WithArgs(1, 2, 2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "user_blocks"  WHERE "user_blocks"."deleted_at" IS NULL AND (((user_id = $1 AND blocked_user_id = $2) OR (user_id = $3 AND blocked_user_id = $4)))`)).
+					WillReturnError(fmt.Errorf("some error"))
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := BlockStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.IsBlocked(1, 2)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BlockStore.IsBlocked() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("BlockStore.IsBlocked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/store/identity/store.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	models "gilsaputro/dating-apps/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIdentityStoreMethod is a mock of IdentityStoreMethod interface.
+type MockIdentityStoreMethod struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityStoreMethodMockRecorder
+}
+
+// MockIdentityStoreMethodMockRecorder is the mock recorder for MockIdentityStoreMethod.
+type MockIdentityStoreMethodMockRecorder struct {
+	mock *MockIdentityStoreMethod
+}
+
+// NewMockIdentityStoreMethod creates a new mock instance.
+func NewMockIdentityStoreMethod(ctrl *gomock.Controller) *MockIdentityStoreMethod {
+	mock := &MockIdentityStoreMethod{ctrl: ctrl}
+	mock.recorder = &MockIdentityStoreMethodMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentityStoreMethod) EXPECT() *MockIdentityStoreMethodMockRecorder {
+	return m.recorder
+}
+
+// CreateIdentity mocks base method.
+func (m *MockIdentityStoreMethod) CreateIdentity(identity models.UserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIdentity", identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIdentity indicates an expected call of CreateIdentity.
+func (mr *MockIdentityStoreMethodMockRecorder) CreateIdentity(identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdentity", reflect.TypeOf((*MockIdentityStoreMethod)(nil).CreateIdentity), identity)
+}
+
+// GetIdentity mocks base method.
+func (m *MockIdentityStoreMethod) GetIdentity(provider, subject string) (models.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentity", provider, subject)
+	ret0, _ := ret[0].(models.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdentity indicates an expected call of GetIdentity.
+func (mr *MockIdentityStoreMethodMockRecorder) GetIdentity(provider, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentity", reflect.TypeOf((*MockIdentityStoreMethod)(nil).GetIdentity), provider, subject)
+}
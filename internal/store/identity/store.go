@@ -0,0 +1,62 @@
+package identity
+
+import (
+	"errors"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/postgres"
+
+	"github.com/jinzhu/gorm"
+)
+
+// IdentityStoreMethod is set of methods for interacting with a linked social login identity storage system
+type IdentityStoreMethod interface {
+	GetIdentity(provider, subject string) (models.UserIdentity, error)
+	CreateIdentity(identity models.UserIdentity) error
+}
+
+// IdentityStore is list dependencies identity store
+type IdentityStore struct {
+	pg postgres.PostgresMethod
+}
+
+// NewIdentityStore is func to generate IdentityStoreMethod interface
+func NewIdentityStore(pg postgres.PostgresMethod) IdentityStoreMethod {
+	return &IdentityStore{
+		pg: pg,
+	}
+}
+
+func (i *IdentityStore) getDB() (*gorm.DB, error) {
+	db := i.pg.GetDB()
+	if db == nil {
+		return nil, errors.New("Database Client is not init")
+	}
+
+	return db, nil
+}
+
+// GetIdentity is func to get the identity linked to the provider account, it will return empty data if the account is never linked
+func (i *IdentityStore) GetIdentity(provider, subject string) (models.UserIdentity, error) {
+	db, err := i.getDB()
+	if err != nil {
+		return models.UserIdentity{}, err
+	}
+
+	var identity models.UserIdentity
+	err = db.Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return models.UserIdentity{}, nil
+	}
+
+	return identity, err
+}
+
+// CreateIdentity is func to link the provider account to the user
+func (i *IdentityStore) CreateIdentity(identity models.UserIdentity) error {
+	db, err := i.getDB()
+	if err != nil {
+		return err
+	}
+
+	return db.Create(&identity).Error
+}
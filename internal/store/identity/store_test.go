@@ -0,0 +1,196 @@
+package identity
+
+import (
+	"database/sql"
+	"fmt"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/postgres"
+	mock_postgres "gilsaputro/dating-apps/pkg/postgres/mock"
+	"log"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestNewIdentityStore(t *testing.T) {
+	type args struct {
+		pg postgres.PostgresMethod
+	}
+	tests := []struct {
+		name string
+		args args
+		want IdentityStoreMethod
+	}{
+		{
+			name: "success flow",
+			args: args{
+				pg: &postgres.Client{},
+			},
+			want: &IdentityStore{
+				pg: &postgres.Client{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewIdentityStore(tt.args.pg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewIdentityStore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func InitDBsMockupStat() (*sql.DB, sqlmock.Sqlmock, *gorm.DB) {
+	db, mock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open("postgres", db)
+	gormDB.LogMode(true)
+	gormDB.SetLogger(log.New(os.Stdout, "\n", 0))
+	gormDB.Debug()
+	return db, mock, gormDB
+}
+
+func TestIdentityStore_GetIdentity(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     models.UserIdentity
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_identities"  WHERE "user_identities"."deleted_at" IS NULL AND ((provider = $1 AND subject = $2)) ORDER BY "user_identities"."id" ASC LIMIT 1`)).
+					WithArgs("google", "sub").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "provider", "subject", "email"}).AddRow(1, 2, "google", "sub", "a@mail.com"))
+			},
+			want: models.UserIdentity{
+				Model: gorm.Model{
+					ID: 1,
+				},
+				UserID:   2,
+				Provider: "google",
+				Subject:  "sub",
+				Email:    "a@mail.com",
+			},
+			wantErr: false,
+		},
+		{
+			name: "success never linked",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_identities"  WHERE "user_identities"."deleted_at" IS NULL AND ((provider = $1 AND subject = $2)) ORDER BY "user_identities"."id" ASC LIMIT 1`)).
+					WithArgs("google", "sub").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			want:    models.UserIdentity{},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_identities"  WHERE "user_identities"."deleted_at" IS NULL AND ((provider = $1 AND subject = $2)) ORDER BY "user_identities"."id" ASC LIMIT 1`)).
+					WillReturnError(fmt.Errorf("some error"))
+			},
+			want:    models.UserIdentity{},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    models.UserIdentity{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := IdentityStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.GetIdentity("google", "sub")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IdentityStore.GetIdentity() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IdentityStore.GetIdentity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdentityStore_CreateIdentity(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	identity := models.UserIdentity{
+		UserID:   2,
+		Provider: "google",
+		Subject:  "sub",
+		Email:    "a@mail.com",
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_identities" ("created_at","updated_at","deleted_at","user_id","provider","subject","email") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "user_identities"."id"`)).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_identities" ("created_at","updated_at","deleted_at","user_id","provider","subject","email") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "user_identities"."id"`)).
+					WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := IdentityStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			if err := store.CreateIdentity(identity); (err != nil) != tt.wantErr {
+				t.Errorf("IdentityStore.CreateIdentity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
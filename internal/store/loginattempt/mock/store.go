@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/store/loginattempt/store.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLoginAttemptStoreMethod is a mock of LoginAttemptStoreMethod interface.
+type MockLoginAttemptStoreMethod struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginAttemptStoreMethodMockRecorder
+}
+
+// MockLoginAttemptStoreMethodMockRecorder is the mock recorder for MockLoginAttemptStoreMethod.
+type MockLoginAttemptStoreMethodMockRecorder struct {
+	mock *MockLoginAttemptStoreMethod
+}
+
+// NewMockLoginAttemptStoreMethod creates a new mock instance.
+func NewMockLoginAttemptStoreMethod(ctrl *gomock.Controller) *MockLoginAttemptStoreMethod {
+	mock := &MockLoginAttemptStoreMethod{ctrl: ctrl}
+	mock.recorder = &MockLoginAttemptStoreMethodMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginAttemptStoreMethod) EXPECT() *MockLoginAttemptStoreMethodMockRecorder {
+	return m.recorder
+}
+
+// AddFailedAttempt mocks base method.
+func (m *MockLoginAttemptStoreMethod) AddFailedAttempt(subject string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFailedAttempt", subject)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFailedAttempt indicates an expected call of AddFailedAttempt.
+func (mr *MockLoginAttemptStoreMethodMockRecorder) AddFailedAttempt(subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFailedAttempt", reflect.TypeOf((*MockLoginAttemptStoreMethod)(nil).AddFailedAttempt), subject)
+}
+
+// GetLockout mocks base method.
+func (m *MockLoginAttemptStoreMethod) GetLockout(subject string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLockout", subject)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLockout indicates an expected call of GetLockout.
+func (mr *MockLoginAttemptStoreMethodMockRecorder) GetLockout(subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLockout", reflect.TypeOf((*MockLoginAttemptStoreMethod)(nil).GetLockout), subject)
+}
+
+// ResetFailedAttempt mocks base method.
+func (m *MockLoginAttemptStoreMethod) ResetFailedAttempt(subject string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetFailedAttempt", subject)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetFailedAttempt indicates an expected call of ResetFailedAttempt.
+func (mr *MockLoginAttemptStoreMethodMockRecorder) ResetFailedAttempt(subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetFailedAttempt", reflect.TypeOf((*MockLoginAttemptStoreMethod)(nil).ResetFailedAttempt), subject)
+}
+
+// SetLockout mocks base method.
+func (m *MockLoginAttemptStoreMethod) SetLockout(subject string, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLockout", subject, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLockout indicates an expected call of SetLockout.
+func (mr *MockLoginAttemptStoreMethodMockRecorder) SetLockout(subject, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLockout", reflect.TypeOf((*MockLoginAttemptStoreMethod)(nil).SetLockout), subject, until)
+}
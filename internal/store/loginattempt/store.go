@@ -0,0 +1,86 @@
+package loginattempt
+
+import (
+	"fmt"
+	"gilsaputro/dating-apps/pkg/redis"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LoginAttemptStoreMethod is set of methods for interacting with a failed login attempt storage system
+type LoginAttemptStoreMethod interface {
+	AddFailedAttempt(subject string) (int, error)
+	ResetFailedAttempt(subject string) error
+	SetLockout(subject string, until time.Time) error
+	GetLockout(subject string) (time.Time, error)
+}
+
+// LoginAttemptStore is list dependencies login attempt store
+type LoginAttemptStore struct {
+	rd redis.RedisMethod
+	// attemptWindow is how long the failed attempt is remembered since the last failure
+	attemptWindow time.Duration
+}
+
+// NewLoginAttemptStore is func to generate LoginAttemptStoreMethod interface
+func NewLoginAttemptStore(rd redis.RedisMethod, attemptWindow time.Duration) LoginAttemptStoreMethod {
+	return &LoginAttemptStore{
+		rd:            rd,
+		attemptWindow: attemptWindow,
+	}
+}
+
+const failedAttempt string = `LFA:%v` // format LFA:<subject>
+
+// AddFailedAttempt is func to increment the failed login counter of the subject, it returns the counter after increment
+func (f *LoginAttemptStore) AddFailedAttempt(subject string) (int, error) {
+	key := fmt.Sprintf(failedAttempt, subject)
+	counter, err := f.rd.Incr(key, f.attemptWindow)
+	if err != nil {
+		return 0, err
+	}
+	return int(counter), nil
+}
+
+// ResetFailedAttempt is func to clear the failed login counter and the lockout of the subject
+func (f *LoginAttemptStore) ResetFailedAttempt(subject string) error {
+	err := f.rd.Del(fmt.Sprintf(failedAttempt, subject))
+	if err != nil {
+		return err
+	}
+	return f.rd.Del(fmt.Sprintf(loginLockout, subject))
+}
+
+const loginLockout string = `LLO:%v` // format LLO:<subject>
+
+// SetLockout is func to reject the login of the subject until the given time
+func (f *LoginAttemptStore) SetLockout(subject string, until time.Time) error {
+	ttl := time.Until(until)
+	if ttl <= 0 {
+		return nil
+	}
+
+	key := fmt.Sprintf(loginLockout, subject)
+	return f.rd.Set(key, until.Unix(), ttl)
+}
+
+// GetLockout is func to get the time the lockout of the subject is ended, it is zero when the subject is not locked
+func (f *LoginAttemptStore) GetLockout(subject string) (time.Time, error) {
+	key := fmt.Sprintf(loginLockout, subject)
+	c, err := f.rd.Get(key)
+	if err != nil && strings.Contains(err.Error(), "redis: nil") {
+		return time.Time{}, nil
+	}
+
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	unix, err := strconv.ParseInt(c, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(unix, 0), nil
+}
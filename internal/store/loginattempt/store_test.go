@@ -0,0 +1,233 @@
+package loginattempt
+
+import (
+	"fmt"
+	"gilsaputro/dating-apps/pkg/redis"
+	mock_redis "gilsaputro/dating-apps/pkg/redis/mock"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewLoginAttemptStore(t *testing.T) {
+	type args struct {
+		rd            redis.RedisMethod
+		attemptWindow time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+		want LoginAttemptStoreMethod
+	}{
+		{
+			name: "success flow",
+			args: args{
+				rd:            &redis.RedisClient{},
+				attemptWindow: time.Hour,
+			},
+			want: &LoginAttemptStore{
+				rd:            &redis.RedisClient{},
+				attemptWindow: time.Hour,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewLoginAttemptStore(tt.args.rd, tt.args.attemptWindow); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewLoginAttemptStore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoginAttemptStore_AddFailedAttempt(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     int
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				rd.EXPECT().Incr("LFA:user:username", time.Hour).Return(int64(3), nil)
+			},
+			want: 3,
+		},
+		{
+			name: "error flow",
+			mockFunc: func() {
+				rd.EXPECT().Incr("LFA:user:username", time.Hour).Return(int64(0), fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := LoginAttemptStore{
+				rd:            rd,
+				attemptWindow: time.Hour,
+			}
+			tt.mockFunc()
+			got, err := s.AddFailedAttempt("user:username")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoginAttemptStore.AddFailedAttempt() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("LoginAttemptStore.AddFailedAttempt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoginAttemptStore_ResetFailedAttempt(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				rd.EXPECT().Del("LFA:user:username").Return(nil)
+				rd.EXPECT().Del("LLO:user:username").Return(nil)
+			},
+		},
+		{
+			name: "error delete lockout flow",
+			mockFunc: func() {
+				rd.EXPECT().Del("LFA:user:username").Return(nil)
+				rd.EXPECT().Del("LLO:user:username").Return(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error delete counter flow",
+			mockFunc: func() {
+				rd.EXPECT().Del("LFA:user:username").Return(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := LoginAttemptStore{
+				rd: rd,
+			}
+			tt.mockFunc()
+			if err := s.ResetFailedAttempt("user:username"); (err != nil) != tt.wantErr {
+				t.Errorf("LoginAttemptStore.ResetFailedAttempt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoginAttemptStore_SetLockout(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	until := time.Now().Add(time.Minute)
+	tests := []struct {
+		name     string
+		until    time.Time
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name:  "success flow",
+			until: until,
+			mockFunc: func() {
+				rd.EXPECT().Set("LLO:ip:127.0.0.1", until.Unix(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:     "success already ended flow",
+			until:    time.Now().Add(-time.Minute),
+			mockFunc: func() {},
+		},
+		{
+			name:  "error flow",
+			until: until,
+			mockFunc: func() {
+				rd.EXPECT().Set("LLO:ip:127.0.0.1", until.Unix(), gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := LoginAttemptStore{
+				rd: rd,
+			}
+			tt.mockFunc()
+			if err := s.SetLockout("ip:127.0.0.1", tt.until); (err != nil) != tt.wantErr {
+				t.Errorf("LoginAttemptStore.SetLockout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoginAttemptStore_GetLockout(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     time.Time
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				rd.EXPECT().Get("LLO:ip:127.0.0.1").Return("1700000000", nil)
+			},
+			want: time.Unix(1700000000, 0),
+		},
+		{
+			name: "success not locked flow",
+			mockFunc: func() {
+				rd.EXPECT().Get("LLO:ip:127.0.0.1").Return("", fmt.Errorf("redis: nil"))
+			},
+		},
+		{
+			name: "error invalid value flow",
+			mockFunc: func() {
+				rd.EXPECT().Get("LLO:ip:127.0.0.1").Return("abc", nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error flow",
+			mockFunc: func() {
+				rd.EXPECT().Get("LLO:ip:127.0.0.1").Return("", fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := LoginAttemptStore{
+				rd: rd,
+			}
+			tt.mockFunc()
+			got, err := s.GetLockout("ip:127.0.0.1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoginAttemptStore.GetLockout() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("LoginAttemptStore.GetLockout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/store/match/store.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	match "gilsaputro/dating-apps/internal/store/match"
+	models "gilsaputro/dating-apps/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMatchStoreMethod is a mock of MatchStoreMethod interface.
+type MockMatchStoreMethod struct {
+	ctrl     *gomock.Controller
+	recorder *MockMatchStoreMethodMockRecorder
+}
+
+// MockMatchStoreMethodMockRecorder is the mock recorder for MockMatchStoreMethod.
+type MockMatchStoreMethodMockRecorder struct {
+	mock *MockMatchStoreMethod
+}
+
+// NewMockMatchStoreMethod creates a new mock instance.
+func NewMockMatchStoreMethod(ctrl *gomock.Controller) *MockMatchStoreMethod {
+	mock := &MockMatchStoreMethod{ctrl: ctrl}
+	mock.recorder = &MockMatchStoreMethodMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMatchStoreMethod) EXPECT() *MockMatchStoreMethodMockRecorder {
+	return m.recorder
+}
+
+// CountApprovedByUserIDAndPartnerID mocks base method.
+func (m *MockMatchStoreMethod) CountApprovedByUserIDAndPartnerID(userID, partnerID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountApprovedByUserIDAndPartnerID", userID, partnerID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountApprovedByUserIDAndPartnerID indicates an expected call of CountApprovedByUserIDAndPartnerID.
+func (mr *MockMatchStoreMethodMockRecorder) CountApprovedByUserIDAndPartnerID(userID, partnerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountApprovedByUserIDAndPartnerID", reflect.TypeOf((*MockMatchStoreMethod)(nil).CountApprovedByUserIDAndPartnerID), userID, partnerID)
+}
+
+// CountByUserID mocks base method.
+func (m *MockMatchStoreMethod) CountByUserID(userID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByUserID", userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByUserID indicates an expected call of CountByUserID.
+func (mr *MockMatchStoreMethodMockRecorder) CountByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByUserID", reflect.TypeOf((*MockMatchStoreMethod)(nil).CountByUserID), userID)
+}
+
+// CreateLike mocks base method.
+func (m *MockMatchStoreMethod) CreateLike(history models.UserMatchHistory) (models.Match, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLike", history)
+	ret0, _ := ret[0].(models.Match)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLike indicates an expected call of CreateLike.
+func (mr *MockMatchStoreMethodMockRecorder) CreateLike(history interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLike", reflect.TypeOf((*MockMatchStoreMethod)(nil).CreateLike), history)
+}
+
+// GetMatchListByUserID mocks base method.
+func (m *MockMatchStoreMethod) GetMatchListByUserID(filter match.MatchFilter) ([]models.Match, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMatchListByUserID", filter)
+	ret0, _ := ret[0].([]models.Match)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMatchListByUserID indicates an expected call of GetMatchListByUserID.
+func (mr *MockMatchStoreMethodMockRecorder) GetMatchListByUserID(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMatchListByUserID", reflect.TypeOf((*MockMatchStoreMethod)(nil).GetMatchListByUserID), filter)
+}
+
+// Unmatch mocks base method.
+func (m *MockMatchStoreMethod) Unmatch(userID, partnerID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unmatch", userID, partnerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unmatch indicates an expected call of Unmatch.
+func (mr *MockMatchStoreMethodMockRecorder) Unmatch(userID, partnerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unmatch", reflect.TypeOf((*MockMatchStoreMethod)(nil).Unmatch), userID, partnerID)
+}
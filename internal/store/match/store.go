@@ -0,0 +1,223 @@
+package match
+
+import (
+	"errors"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/postgres"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// MatchStoreMethod is set of methods for interacting with a match storage system
+type MatchStoreMethod interface {
+	CreateLike(history models.UserMatchHistory) (models.Match, error)
+	GetMatchListByUserID(filter MatchFilter) ([]models.Match, error)
+	CountByUserID(userID int) (int, error)
+	CountApprovedByUserIDAndPartnerID(userID, partnerID int) (int, error)
+	Unmatch(userID, partnerID int) error
+}
+
+// MatchFilter is list parameter to query user matches
+type MatchFilter struct {
+	UserID int
+	Limit  int
+	Offset int
+}
+
+// MatchStore is list dependencies match store
+type MatchStore struct {
+	pg postgres.PostgresMethod
+}
+
+// NewMatchStore is func to generate MatchStoreMethod interface
+func NewMatchStore(pg postgres.PostgresMethod) MatchStoreMethod {
+	return &MatchStore{
+		pg: pg,
+	}
+}
+
+func (m *MatchStore) getDB() (*gorm.DB, error) {
+	db := m.pg.GetDB()
+	if db == nil {
+		return nil, errors.New("Database Client is not init")
+	}
+
+	return db, nil
+}
+
+// CreateLike is func to store the like of the user in one transaction, the like is approved and the match is created
+// when the partner already like the user, otherwise the like is pending and the returned match is empty.
+// Both user row is locked first, so the like of the partner at the same time wait and see this like
+func (m *MatchStore) CreateLike(history models.UserMatchHistory) (models.Match, error) {
+	db, err := m.getDB()
+	if err != nil {
+		return models.Match{}, err
+	}
+
+	tx := db.Begin()
+	if err := tx.Error; err != nil {
+		return models.Match{}, err
+	}
+
+	// the row is locked by the id order, so the two like of the same pair does not deadlock
+	var users []models.User
+	err = tx.Set("gorm:query_option", "FOR UPDATE").
+		Where("id IN (?)", []uint{history.UserID, history.PartnerID}).
+		Order("id").
+		Find(&users).Error
+	if err != nil {
+		tx.Rollback()
+		return models.Match{}, err
+	}
+
+	var count int
+	err = tx.Model(&models.UserMatchHistory{}).
+		Where("user_id = ? AND partner_id = ? AND decision IN (?)", history.PartnerID, history.UserID, models.LikeDecisions).
+		Count(&count).Error
+	if err != nil {
+		tx.Rollback()
+		return models.Match{}, err
+	}
+
+	if count == 0 {
+		history.Status = models.MatchStatusPending
+		if err := tx.Create(&history).Error; err != nil {
+			tx.Rollback()
+			return models.Match{}, err
+		}
+		return models.Match{}, tx.Commit().Error
+	}
+
+	history.Status = models.MatchStatusApproved
+	if err := tx.Create(&history).Error; err != nil {
+		tx.Rollback()
+		return models.Match{}, err
+	}
+
+	err = tx.Model(&models.UserMatchHistory{}).
+		Where("user_id = ? AND partner_id = ? AND decision IN (?)", history.PartnerID, history.UserID, models.LikeDecisions).
+		Update("status", models.MatchStatusApproved).Error
+	if err != nil {
+		tx.Rollback()
+		return models.Match{}, err
+	}
+
+	match := models.NewMatch(history.UserID, history.PartnerID, time.Now())
+	if err := tx.Create(&match).Error; err != nil {
+		tx.Rollback()
+		return models.Match{}, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return models.Match{}, err
+	}
+
+	return match, nil
+}
+
+// GetMatchListByUserID is func to get approved matches of the user ordered by the latest match
+func (m *MatchStore) GetMatchListByUserID(filter MatchFilter) ([]models.Match, error) {
+	db, err := m.getDB()
+	if err != nil {
+		return nil, err
+	}
+
+	query := db.Model(&models.Match{}).
+		Where("(user_id = ? OR partner_id = ?) AND status = ?", filter.UserID, filter.UserID, models.MatchStatusApproved)
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	result := []models.Match{}
+	if err := query.Order("matched_at DESC").Find(&result).Error; err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CountByUserID is func to get total approved matches of the user
+func (m *MatchStore) CountByUserID(userID int) (int, error) {
+	db, err := m.getDB()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = db.Model(&models.Match{}).
+		Where("(user_id = ? OR partner_id = ?) AND status = ?", userID, userID, models.MatchStatusApproved).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// CountApprovedByUserIDAndPartnerID is func to get total approved match between the user and the partner
+func (m *MatchStore) CountApprovedByUserIDAndPartnerID(userID, partnerID int) (int, error) {
+	db, err := m.getDB()
+	if err != nil {
+		return 0, err
+	}
+
+	pair := models.NewMatch(uint(userID), uint(partnerID), time.Time{})
+
+	var count int
+	err = db.Model(&models.Match{}).
+		Where("user_id = ? AND partner_id = ? AND status = ?", pair.UserID, pair.PartnerID, models.MatchStatusApproved).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Unmatch is func to reject the approved match and both user like history in one transaction, the rows are kept for auditing
+func (m *MatchStore) Unmatch(userID, partnerID int) error {
+	db, err := m.getDB()
+	if err != nil {
+		return err
+	}
+
+	pair := models.NewMatch(uint(userID), uint(partnerID), time.Time{})
+
+	tx := db.Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+
+	res := tx.Model(&models.Match{}).
+		Where("user_id = ? AND partner_id = ? AND status = ?", pair.UserID, pair.PartnerID, models.MatchStatusApproved).
+		Updates(map[string]interface{}{
+			"status":       models.MatchStatusRejected,
+			"unmatched_by": userID,
+			"unmatched_at": time.Now(),
+		})
+	if res.Error != nil {
+		tx.Rollback()
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		tx.Rollback()
+		return gorm.ErrRecordNotFound
+	}
+
+	err = tx.Model(&models.UserMatchHistory{}).
+		Where("((user_id = ? AND partner_id = ?) OR (user_id = ? AND partner_id = ?)) AND decision IN (?)", userID, partnerID, partnerID, userID, models.LikeDecisions).
+		Update("status", models.MatchStatusRejected).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
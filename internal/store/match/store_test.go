@@ -0,0 +1,541 @@
+package match
+
+import (
+	"database/sql"
+	"fmt"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/postgres"
+	mock_postgres "gilsaputro/dating-apps/pkg/postgres/mock"
+	"log"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestNewMatchStore(t *testing.T) {
+	type args struct {
+		pg postgres.PostgresMethod
+	}
+	tests := []struct {
+		name string
+		args args
+		want MatchStoreMethod
+	}{
+		{
+			name: "success flow",
+			args: args{
+				pg: &postgres.Client{},
+			},
+			want: &MatchStore{
+				pg: &postgres.Client{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewMatchStore(tt.args.pg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewMatchStore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+var errSome = fmt.Errorf("some error")
+
+func InitDBsMockupStat() (*sql.DB, sqlmock.Sqlmock, *gorm.DB) {
+	db, mock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open("postgres", db)
+	gormDB.LogMode(true)
+	gormDB.SetLogger(log.New(os.Stdout, "\n", 0))
+	gormDB.Debug()
+	return db, mock, gormDB
+}
+
+func TestMatchStore_CreateLike(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	type args struct {
+		history models.UserMatchHistory
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		want     models.Match
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."deleted_at" IS NULL AND ((id IN ($1,$2))) ORDER BY "id" FOR UPDATE`)).WithArgs(3, 2).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2).AddRow(3))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1 AND partner_id = $2 AND decision IN ($3,$4)))`)).WithArgs(2, 3, models.DecisionLike, models.DecisionSuperLike).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_match_histories" ("created_at","updated_at","deleted_at","user_id","partner_id","partner_name","status","decision") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "user_match_histories"."id"`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "user_match_histories" SET "status" = $1, "updated_at" = $2 WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $3 AND partner_id = $4 AND decision IN ($5,$6)))`)).WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "matches" ("created_at","updated_at","deleted_at","user_id","partner_id","status","matched_at","unmatched_by","unmatched_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING "matches"."id"`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				mockDB.ExpectCommit()
+			},
+			args: args{
+				history: models.UserMatchHistory{
+					UserID:      3,
+					PartnerID:   2,
+					PartnerName: "A",
+					Decision:    models.DecisionLike,
+				},
+			},
+			want: models.Match{
+				Model: gorm.Model{
+					ID: 5,
+				},
+				UserID:    2,
+				PartnerID: 3,
+				Status:    models.MatchStatusApproved,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success pending like",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."deleted_at" IS NULL AND ((id IN ($1,$2))) ORDER BY "id" FOR UPDATE`)).WithArgs(3, 2).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2).AddRow(3))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1 AND partner_id = $2 AND decision IN ($3,$4)))`)).WithArgs(2, 3, models.DecisionLike, models.DecisionSuperLike).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_match_histories" ("created_at","updated_at","deleted_at","user_id","partner_id","partner_name","status","decision") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "user_match_histories"."id"`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+			args: args{
+				history: models.UserMatchHistory{
+					UserID:    3,
+					PartnerID: 2,
+					Decision:  models.DecisionLike,
+				},
+			},
+			want:    models.Match{},
+			wantErr: false,
+		},
+		{
+			name: "error lock user rollback",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."deleted_at" IS NULL AND ((id IN ($1,$2))) ORDER BY "id" FOR UPDATE`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			args: args{
+				history: models.UserMatchHistory{
+					UserID:    3,
+					PartnerID: 2,
+					Decision:  models.DecisionLike,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error count partner like rollback",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."deleted_at" IS NULL AND ((id IN ($1,$2))) ORDER BY "id" FOR UPDATE`)).WithArgs(3, 2).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2).AddRow(3))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "user_match_histories"`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			args: args{
+				history: models.UserMatchHistory{
+					UserID:    3,
+					PartnerID: 2,
+					Decision:  models.DecisionLike,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error create match rollback",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."deleted_at" IS NULL AND ((id IN ($1,$2))) ORDER BY "id" FOR UPDATE`)).WithArgs(3, 2).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2).AddRow(3))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1 AND partner_id = $2 AND decision IN ($3,$4)))`)).WithArgs(2, 3, models.DecisionLike, models.DecisionSuperLike).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_match_histories" ("created_at","updated_at","deleted_at","user_id","partner_id","partner_name","status","decision") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "user_match_histories"."id"`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "user_match_histories" SET "status" = $1, "updated_at" = $2 WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $3 AND partner_id = $4 AND decision IN ($5,$6)))`)).WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "matches" ("created_at","updated_at","deleted_at","user_id","partner_id","status","matched_at","unmatched_by","unmatched_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING "matches"."id"`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			args: args{
+				history: models.UserMatchHistory{
+					UserID:    3,
+					PartnerID: 2,
+					Decision:  models.DecisionLike,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error update partner status rollback",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."deleted_at" IS NULL AND ((id IN ($1,$2))) ORDER BY "id" FOR UPDATE`)).WithArgs(3, 2).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2).AddRow(3))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1 AND partner_id = $2 AND decision IN ($3,$4)))`)).WithArgs(2, 3, models.DecisionLike, models.DecisionSuperLike).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_match_histories" ("created_at","updated_at","deleted_at","user_id","partner_id","partner_name","status","decision") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "user_match_histories"."id"`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "user_match_histories" SET "status" = $1, "updated_at" = $2 WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $3 AND partner_id = $4 AND decision IN ($5,$6)))`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			args: args{
+				history: models.UserMatchHistory{
+					UserID:    3,
+					PartnerID: 2,
+					Decision:  models.DecisionLike,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error create history rollback",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."deleted_at" IS NULL AND ((id IN ($1,$2))) ORDER BY "id" FOR UPDATE`)).WithArgs(3, 2).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2).AddRow(3))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1 AND partner_id = $2 AND decision IN ($3,$4)))`)).WithArgs(2, 3, models.DecisionLike, models.DecisionSuperLike).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_match_histories" ("created_at","updated_at","deleted_at","user_id","partner_id","partner_name","status","decision") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "user_match_histories"."id"`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			args: args{
+				history: models.UserMatchHistory{
+					UserID:    3,
+					PartnerID: 2,
+					Decision:  models.DecisionLike,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "nil database",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := MatchStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.CreateLike(tt.args.history)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MatchStore.CreateLike() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			got.MatchedAt = tt.want.MatchedAt
+			got.CreatedAt = tt.want.CreatedAt
+			got.UpdatedAt = tt.want.UpdatedAt
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MatchStore.CreateLike() = %v, want %v", got, tt.want)
+			}
+			if err := mockDB.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestMatchStore_GetMatchListByUserID(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		filter   MatchFilter
+		want     []models.Match
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches"  WHERE "matches"."deleted_at" IS NULL AND (((user_id = $1 OR partner_id = $2) AND status = $3)) ORDER BY matched_at DESC LIMIT 10 OFFSET 10`)).WithArgs(1, 1, models.MatchStatusApproved).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "partner_id", "status"}).AddRow(1, 1, 2, 2))
+			},
+			filter: MatchFilter{
+				UserID: 1,
+				Limit:  10,
+				Offset: 10,
+			},
+			want: []models.Match{
+				{Model: gorm.Model{ID: 1}, UserID: 1, PartnerID: 2, Status: models.MatchStatusApproved},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error get data",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "matches"  WHERE "matches"."deleted_at" IS NULL AND (((user_id = $1 OR partner_id = $2) AND status = $3)) ORDER BY matched_at DESC`)).WillReturnError(fmt.Errorf("some error"))
+			},
+			filter: MatchFilter{
+				UserID: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "nil database",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := MatchStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.GetMatchListByUserID(tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MatchStore.GetMatchListByUserID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MatchStore.GetMatchListByUserID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchStore_CountByUserID(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		userID   int
+		want     int
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "matches"  WHERE "matches"."deleted_at" IS NULL AND (((user_id = $1 OR partner_id = $2) AND status = $3))`)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+			},
+			userID:  1,
+			want:    3,
+			wantErr: false,
+		},
+		{
+			name: "error get data",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "matches"  WHERE "matches"."deleted_at" IS NULL AND (((user_id = $1 OR partner_id = $2) AND status = $3))`)).WillReturnError(fmt.Errorf("some error"))
+			},
+			userID:  1,
+			wantErr: true,
+		},
+		{
+			name: "nil database",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := MatchStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.CountByUserID(tt.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MatchStore.CountByUserID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MatchStore.CountByUserID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchStore_CountApprovedByUserIDAndPartnerID(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name      string
+		mockFunc  func()
+		userID    int
+		partnerID int
+		want      int
+		wantErr   bool
+	}{
+		{
+			name: "success with ordered pair",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "matches"  WHERE "matches"."deleted_at" IS NULL AND ((user_id = $1 AND partner_id = $2 AND status = $3))`)).
+					WithArgs(2, 3, models.MatchStatusApproved).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			},
+			userID:    3,
+			partnerID: 2,
+			want:      1,
+			wantErr:   false,
+		},
+		{
+			name: "error get data",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "matches"`)).WillReturnError(fmt.Errorf("some error"))
+			},
+			userID:    3,
+			partnerID: 2,
+			wantErr:   true,
+		},
+		{
+			name: "nil database",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := MatchStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.CountApprovedByUserIDAndPartnerID(tt.userID, tt.partnerID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MatchStore.CountApprovedByUserIDAndPartnerID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MatchStore.CountApprovedByUserIDAndPartnerID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchStore_Unmatch(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	type args struct {
+		userID    int
+		partnerID int
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "matches" SET "status" = $1, "unmatched_at" = $2, "unmatched_by" = $3, "updated_at" = $4 WHERE "matches"."deleted_at" IS NULL AND ((user_id = $5 AND partner_id = $6 AND status = $7))`)).
+					WithArgs(models.MatchStatusRejected, sqlmock.AnyArg(), 3, sqlmock.AnyArg(), 2, 3, models.MatchStatusApproved).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "user_match_histories" SET "status" = $1, "updated_at" = $2 WHERE "user_match_histories"."deleted_at" IS NULL AND ((((user_id = $3 AND partner_id = $4) OR (user_id = $5 AND partner_id = $6)) AND decision IN ($7,$8)))`)).
+					WithArgs(models.MatchStatusRejected, sqlmock.AnyArg(), 3, 2, 2, 3, models.DecisionLike, models.DecisionSuperLike).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mockDB.ExpectCommit()
+			},
+			args: args{
+				userID:    3,
+				partnerID: 2,
+			},
+		},
+		{
+			name: "error match not found",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "matches" SET "status" = $1, "unmatched_at" = $2, "unmatched_by" = $3, "updated_at" = $4 WHERE "matches"."deleted_at" IS NULL AND ((user_id = $5 AND partner_id = $6 AND status = $7))`)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mockDB.ExpectRollback()
+			},
+			args: args{
+				userID:    3,
+				partnerID: 2,
+			},
+			wantErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name: "error update history rollback",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "matches" SET "status" = $1, "unmatched_at" = $2, "unmatched_by" = $3, "updated_at" = $4 WHERE "matches"."deleted_at" IS NULL AND ((user_id = $5 AND partner_id = $6 AND status = $7))`)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "user_match_histories" SET "status" = $1, "updated_at" = $2 WHERE "user_match_histories"."deleted_at" IS NULL AND ((((user_id = $3 AND partner_id = $4) OR (user_id = $5 AND partner_id = $6)) AND decision IN ($7,$8)))`)).
+					WillReturnError(errSome)
+				mockDB.ExpectRollback()
+			},
+			args: args{
+				userID:    3,
+				partnerID: 2,
+			},
+			wantErr: errSome,
+		},
+		{
+			name: "error update match rollback",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "matches" SET "status" = $1, "unmatched_at" = $2, "unmatched_by" = $3, "updated_at" = $4 WHERE "matches"."deleted_at" IS NULL AND ((user_id = $5 AND partner_id = $6 AND status = $7))`)).
+					WillReturnError(errSome)
+				mockDB.ExpectRollback()
+			},
+			args: args{
+				userID:    3,
+				partnerID: 2,
+			},
+			wantErr: errSome,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := MatchStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			if err := store.Unmatch(tt.args.userID, tt.args.partnerID); err != tt.wantErr {
+				t.Errorf("MatchStore.Unmatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mockDB.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
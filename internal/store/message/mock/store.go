@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/store/message/store.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	message "gilsaputro/dating-apps/internal/store/message"
+	models "gilsaputro/dating-apps/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMessageStoreMethod is a mock of MessageStoreMethod interface.
+type MockMessageStoreMethod struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageStoreMethodMockRecorder
+}
+
+// MockMessageStoreMethodMockRecorder is the mock recorder for MockMessageStoreMethod.
+type MockMessageStoreMethodMockRecorder struct {
+	mock *MockMessageStoreMethod
+}
+
+// NewMockMessageStoreMethod creates a new mock instance.
+func NewMockMessageStoreMethod(ctrl *gomock.Controller) *MockMessageStoreMethod {
+	mock := &MockMessageStoreMethod{ctrl: ctrl}
+	mock.recorder = &MockMessageStoreMethodMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageStoreMethod) EXPECT() *MockMessageStoreMethodMockRecorder {
+	return m.recorder
+}
+
+// CreateMessage mocks base method.
+func (m *MockMessageStoreMethod) CreateMessage(message models.Message) (models.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMessage", message)
+	ret0, _ := ret[0].(models.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMessage indicates an expected call of CreateMessage.
+func (mr *MockMessageStoreMethodMockRecorder) CreateMessage(message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessage", reflect.TypeOf((*MockMessageStoreMethod)(nil).CreateMessage), message)
+}
+
+// GetMessageList mocks base method.
+func (m *MockMessageStoreMethod) GetMessageList(filter message.MessageFilter) ([]models.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageList", filter)
+	ret0, _ := ret[0].([]models.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageList indicates an expected call of GetMessageList.
+func (mr *MockMessageStoreMethodMockRecorder) GetMessageList(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageList", reflect.TypeOf((*MockMessageStoreMethod)(nil).GetMessageList), filter)
+}
+
+// MarkAsRead mocks base method.
+func (m *MockMessageStoreMethod) MarkAsRead(receiverID int, messageIDs []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAsRead", receiverID, messageIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAsRead indicates an expected call of MarkAsRead.
+func (mr *MockMessageStoreMethodMockRecorder) MarkAsRead(receiverID, messageIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsRead", reflect.TypeOf((*MockMessageStoreMethod)(nil).MarkAsRead), receiverID, messageIDs)
+}
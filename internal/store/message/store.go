@@ -0,0 +1,113 @@
+package message
+
+import (
+	"errors"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/postgres"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// MessageStoreMethod is set of methods for interacting with a message storage system
+type MessageStoreMethod interface {
+	CreateMessage(message models.Message) (models.Message, error)
+	GetMessageList(filter MessageFilter) ([]models.Message, error)
+	MarkAsRead(receiverID int, messageIDs []int) error
+}
+
+// MessageFilter is list parameter to query the conversation between two user
+type MessageFilter struct {
+	UserID    int
+	PartnerID int
+	// Cursor is position of the last message on the previous page, the next page start after it
+	Cursor *MessageCursor
+	// Limit is max number of message returned, zero means no limit
+	Limit int
+}
+
+// MessageCursor is position of a message on the created date ordering
+type MessageCursor struct {
+	CreatedAt time.Time
+	ID        uint
+}
+
+// MessageStore is list dependencies message store
+type MessageStore struct {
+	pg postgres.PostgresMethod
+}
+
+// NewMessageStore is func to generate MessageStoreMethod interface
+func NewMessageStore(pg postgres.PostgresMethod) MessageStoreMethod {
+	return &MessageStore{
+		pg: pg,
+	}
+}
+
+func (m *MessageStore) getDB() (*gorm.DB, error) {
+	db := m.pg.GetDB()
+	if db == nil {
+		return nil, errors.New("Database Client is not init")
+	}
+
+	return db, nil
+}
+
+// CreateMessage is func to store new message
+func (m *MessageStore) CreateMessage(message models.Message) (models.Message, error) {
+	db, err := m.getDB()
+	if err != nil {
+		return models.Message{}, err
+	}
+
+	err = db.Create(&message).Error
+	if err != nil {
+		return models.Message{}, err
+	}
+
+	return message, nil
+}
+
+// GetMessageList is func to get the conversation between two user ordered by the latest message
+func (m *MessageStore) GetMessageList(filter MessageFilter) ([]models.Message, error) {
+	db, err := m.getDB()
+	if err != nil {
+		return nil, err
+	}
+
+	query := db.Model(&models.Message{}).
+		Where("(sender_id = ? AND receiver_id = ?) OR (sender_id = ? AND receiver_id = ?)", filter.UserID, filter.PartnerID, filter.PartnerID, filter.UserID)
+
+	if filter.Cursor != nil {
+		query = query.Where("created_at < ? OR (created_at = ? AND id < ?)", filter.Cursor.CreatedAt, filter.Cursor.CreatedAt, filter.Cursor.ID)
+	}
+
+	query = query.Order("created_at DESC, id DESC")
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	result := []models.Message{}
+	err = query.Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// MarkAsRead is func to set the read time of the unread messages received by the user
+func (m *MessageStore) MarkAsRead(receiverID int, messageIDs []int) error {
+	if len(messageIDs) == 0 {
+		return nil
+	}
+
+	db, err := m.getDB()
+	if err != nil {
+		return err
+	}
+
+	return db.Model(&models.Message{}).
+		Where("receiver_id = ? AND id IN (?) AND read_at IS NULL", receiverID, messageIDs).
+		Update("read_at", time.Now()).Error
+}
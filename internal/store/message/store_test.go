@@ -0,0 +1,346 @@
+package message
+
+import (
+	"database/sql"
+	"fmt"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/postgres"
+	mock_postgres "gilsaputro/dating-apps/pkg/postgres/mock"
+	"log"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestNewMessageStore(t *testing.T) {
+	type args struct {
+		pg postgres.PostgresMethod
+	}
+	tests := []struct {
+		name string
+		args args
+		want MessageStoreMethod
+	}{
+		{
+			name: "success flow",
+			args: args{
+				pg: &postgres.Client{},
+			},
+			want: &MessageStore{
+				pg: &postgres.Client{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewMessageStore(tt.args.pg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewMessageStore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+var errSome = fmt.Errorf("some error")
+
+func InitDBsMockupStat() (*sql.DB, sqlmock.Sqlmock, *gorm.DB) {
+	db, mock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open("postgres", db)
+	gormDB.LogMode(true)
+	gormDB.SetLogger(log.New(os.Stdout, "\n", 0))
+	gormDB.Debug()
+	return db, mock, gormDB
+}
+
+func TestMessageStore_CreateMessage(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	type args struct {
+		message models.Message
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		want     models.Message
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "messages" ("created_at","updated_at","deleted_at","sender_id","receiver_id","content","read_at") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "messages"."id"`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+			args: args{
+				message: models.Message{
+					SenderID:   1,
+					ReceiverID: 2,
+					Content:    "hello",
+				},
+			},
+			want: models.Message{
+				Model: gorm.Model{
+					ID: 1,
+				},
+				SenderID:   1,
+				ReceiverID: 2,
+				Content:    "hello",
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "messages"`)).WillReturnError(errSome)
+				mockDB.ExpectRollback()
+			},
+			args: args{
+				message: models.Message{
+					SenderID:   1,
+					ReceiverID: 2,
+					Content:    "hello",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := MessageStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.CreateMessage(tt.args.message)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MessageStore.CreateMessage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.ID != tt.want.ID || got.SenderID != tt.want.SenderID || got.ReceiverID != tt.want.ReceiverID || got.Content != tt.want.Content {
+				t.Errorf("MessageStore.CreateMessage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageStore_GetMessageList(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	cursorTime := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+	type args struct {
+		filter MessageFilter
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		want     []models.Message
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "messages"  WHERE "messages"."deleted_at" IS NULL AND (((sender_id = $1 AND receiver_id = $2) OR (sender_id = $3 AND receiver_id = $4))) ORDER BY created_at DESC, id DESC`)).
+					WithArgs(1, 2, 2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "sender_id", "receiver_id", "content"}).AddRow(3, 2, 1, "hi").AddRow(2, 1, 2, "hello"))
+			},
+			args: args{
+				filter: MessageFilter{
+					UserID:    1,
+					PartnerID: 2,
+				},
+			},
+			want: []models.Message{
+				{
+					Model: gorm.Model{
+						ID: 3,
+					},
+					SenderID:   2,
+					ReceiverID: 1,
+					Content:    "hi",
+				},
+				{
+					Model: gorm.Model{
+						ID: 2,
+					},
+					SenderID:   1,
+					ReceiverID: 2,
+					Content:    "hello",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success with cursor and limit",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "messages"  WHERE "messages"."deleted_at" IS NULL AND (((sender_id = $1 AND receiver_id = $2) OR (sender_id = $3 AND receiver_id = $4)) AND (created_at < $5 OR (created_at = $6 AND id < $7))) ORDER BY created_at DESC, id DESC LIMIT 21`)).
+					WithArgs(1, 2, 2, 1, cursorTime, cursorTime, 5).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "sender_id", "receiver_id", "content"}).AddRow(4, 2, 1, "hi"))
+			},
+			args: args{
+				filter: MessageFilter{
+					UserID:    1,
+					PartnerID: 2,
+					Cursor: &MessageCursor{
+						CreatedAt: cursorTime,
+						ID:        5,
+					},
+					Limit: 21,
+				},
+			},
+			want: []models.Message{
+				{
+					Model: gorm.Model{
+						ID: 4,
+					},
+					SenderID:   2,
+					ReceiverID: 1,
+					Content:    "hi",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "messages"`)).WillReturnError(errSome)
+			},
+			args: args{
+				filter: MessageFilter{
+					UserID:    1,
+					PartnerID: 2,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := MessageStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.GetMessageList(tt.args.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MessageStore.GetMessageList() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MessageStore.GetMessageList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageStore_MarkAsRead(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	type args struct {
+		receiverID int
+		messageIDs []int
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "messages" SET "read_at" = $1, "updated_at" = $2 WHERE "messages"."deleted_at" IS NULL AND ((receiver_id = $3 AND id IN ($4,$5) AND read_at IS NULL))`)).WillReturnResult(sqlmock.NewResult(1, 2))
+				mockDB.ExpectCommit()
+			},
+			args: args{
+				receiverID: 1,
+				messageIDs: []int{3, 4},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "success without message",
+			mockFunc: func() {},
+			args: args{
+				receiverID: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "messages"`)).WillReturnError(errSome)
+				mockDB.ExpectRollback()
+			},
+			args: args{
+				receiverID: 1,
+				messageIDs: []int{3},
+			},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			args: args{
+				receiverID: 1,
+				messageIDs: []int{3},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := MessageStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			if err := store.MarkAsRead(tt.args.receiverID, tt.args.messageIDs); (err != nil) != tt.wantErr {
+				t.Errorf("MessageStore.MarkAsRead() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -5,6 +5,8 @@
 package mock
 
 import (
+	partnercache "gilsaputro/dating-apps/internal/store/partnercache"
+	models "gilsaputro/dating-apps/models"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -33,6 +35,20 @@ func (m *MockPartnerCacheStoreMethod) EXPECT() *MockPartnerCacheStoreMethodMockR
 	return m.recorder
 }
 
+// DeleteLastDecision mocks base method.
+func (m *MockPartnerCacheStoreMethod) DeleteLastDecision(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLastDecision", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLastDecision indicates an expected call of DeleteLastDecision.
+func (mr *MockPartnerCacheStoreMethodMockRecorder) DeleteLastDecision(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLastDecision", reflect.TypeOf((*MockPartnerCacheStoreMethod)(nil).DeleteLastDecision), userID)
+}
+
 // GetCurentPartnerState mocks base method.
 func (m *MockPartnerCacheStoreMethod) GetCurentPartnerState(userID string) (string, error) {
 	m.ctrl.T.Helper()
@@ -48,6 +64,36 @@ func (mr *MockPartnerCacheStoreMethodMockRecorder) GetCurentPartnerState(userID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurentPartnerState", reflect.TypeOf((*MockPartnerCacheStoreMethod)(nil).GetCurentPartnerState), userID)
 }
 
+// GetLastDecision mocks base method.
+func (m *MockPartnerCacheStoreMethod) GetLastDecision(userID string) (partnercache.LastDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastDecision", userID)
+	ret0, _ := ret[0].(partnercache.LastDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastDecision indicates an expected call of GetLastDecision.
+func (mr *MockPartnerCacheStoreMethodMockRecorder) GetLastDecision(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastDecision", reflect.TypeOf((*MockPartnerCacheStoreMethod)(nil).GetLastDecision), userID)
+}
+
+// GetSuperLikeCounter mocks base method.
+func (m *MockPartnerCacheStoreMethod) GetSuperLikeCounter(userID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuperLikeCounter", userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSuperLikeCounter indicates an expected call of GetSuperLikeCounter.
+func (mr *MockPartnerCacheStoreMethodMockRecorder) GetSuperLikeCounter(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuperLikeCounter", reflect.TypeOf((*MockPartnerCacheStoreMethod)(nil).GetSuperLikeCounter), userID)
+}
+
 // GetViewedPartnerHistory mocks base method.
 func (m *MockPartnerCacheStoreMethod) GetViewedPartnerHistory(userID string) (string, error) {
 	m.ctrl.T.Helper()
@@ -92,6 +138,34 @@ func (mr *MockPartnerCacheStoreMethodMockRecorder) SetCurentPartnerState(userID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCurentPartnerState", reflect.TypeOf((*MockPartnerCacheStoreMethod)(nil).SetCurentPartnerState), userID, partnerID)
 }
 
+// SetLastDecision mocks base method.
+func (m *MockPartnerCacheStoreMethod) SetLastDecision(userID string, decision models.DecisionType, partnerID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLastDecision", userID, decision, partnerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLastDecision indicates an expected call of SetLastDecision.
+func (mr *MockPartnerCacheStoreMethodMockRecorder) SetLastDecision(userID, decision, partnerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLastDecision", reflect.TypeOf((*MockPartnerCacheStoreMethod)(nil).SetLastDecision), userID, decision, partnerID)
+}
+
+// SetSuperLikeCounter mocks base method.
+func (m *MockPartnerCacheStoreMethod) SetSuperLikeCounter(userID, value string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSuperLikeCounter", userID, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSuperLikeCounter indicates an expected call of SetSuperLikeCounter.
+func (mr *MockPartnerCacheStoreMethodMockRecorder) SetSuperLikeCounter(userID, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSuperLikeCounter", reflect.TypeOf((*MockPartnerCacheStoreMethod)(nil).SetSuperLikeCounter), userID, value)
+}
+
 // SetViewedPartnerHistory mocks base method.
 func (m *MockPartnerCacheStoreMethod) SetViewedPartnerHistory(userID, value string) error {
 	m.ctrl.T.Helper()
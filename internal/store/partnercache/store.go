@@ -2,7 +2,9 @@ package partnercache
 
 import (
 	"fmt"
+	"gilsaputro/dating-apps/models"
 	"gilsaputro/dating-apps/pkg/redis"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -15,6 +17,11 @@ type PartnerCacheStoreMethod interface {
 	GetViewedPartnerHistory(userID string) (string, error)
 	SetViewedUserCounter(userID, value string) error
 	GetViewedUserCounter(userID string) (string, error)
+	SetSuperLikeCounter(userID, value string) error
+	GetSuperLikeCounter(userID string) (string, error)
+	SetLastDecision(userID string, decision models.DecisionType, partnerID int) error
+	GetLastDecision(userID string) (LastDecision, error)
+	DeleteLastDecision(userID string) error
 }
 
 // PartnerCacheStore is list dependencies partner cache store
@@ -87,3 +94,90 @@ func (f *PartnerCacheStore) GetViewedUserCounter(userID string) (string, error)
 
 	return c, err
 }
+
+const superLikeCounter string = `SLC:%v:%v` // format SLC:<datetime>:<userid>
+
+// SetSuperLikeCounter is func to store daily super like counter of user id
+func (f *PartnerCacheStore) SetSuperLikeCounter(userID, value string) error {
+	currentTime := time.Now().Format(dateFormat)
+	key := fmt.Sprintf(superLikeCounter, currentTime, userID)
+	return f.rd.Set(key, value, 24*time.Hour)
+}
+
+// GetSuperLikeCounter is func to get daily super like counter of user id
+func (f *PartnerCacheStore) GetSuperLikeCounter(userID string) (string, error) {
+	currentTime := time.Now().Format(dateFormat)
+	key := fmt.Sprintf(superLikeCounter, currentTime, userID)
+	c, err := f.rd.Get(key)
+	if err != nil && strings.Contains(err.Error(), "redis: nil") {
+		return "0", nil
+	}
+
+	return c, err
+}
+
+const lastDecision string = `LSD:%v` // format LSD:<userid> with value <decision>:<partnerid>:<date>
+
+// LastDecision is the last swipe decision of the user
+type LastDecision struct {
+	Decision  models.DecisionType
+	PartnerID int
+	// Date is the day of the decision in YYYYMMDD format, it is empty for the decision stored without the date
+	Date string
+}
+
+// IsToday is func to check the decision is made today, so it is counted in today daily counter
+func (l LastDecision) IsToday() bool {
+	return len(l.Date) > 0 && l.Date == time.Now().Format(dateFormat)
+}
+
+// SetLastDecision is func to store the last swipe decision of user id together with the date of the decision
+func (f *PartnerCacheStore) SetLastDecision(userID string, decision models.DecisionType, partnerID int) error {
+	key := fmt.Sprintf(lastDecision, userID)
+	return f.rd.Set(key, fmt.Sprintf("%d:%d:%s", decision, partnerID, time.Now().Format(dateFormat)), 24*time.Hour)
+}
+
+// GetLastDecision is func to get the last swipe decision of user id, it will return 0 partner id if there is no decision
+func (f *PartnerCacheStore) GetLastDecision(userID string) (LastDecision, error) {
+	key := fmt.Sprintf(lastDecision, userID)
+	c, err := f.rd.Get(key)
+	if err != nil && strings.Contains(err.Error(), "redis: nil") {
+		return LastDecision{}, nil
+	}
+
+	if err != nil {
+		return LastDecision{}, err
+	}
+
+	values := strings.Split(c, ":")
+	if len(values) != 2 && len(values) != 3 {
+		return LastDecision{}, nil
+	}
+
+	decision, err := strconv.Atoi(values[0])
+	if err != nil {
+		return LastDecision{}, nil
+	}
+
+	partnerID, err := strconv.Atoi(values[1])
+	if err != nil {
+		return LastDecision{}, nil
+	}
+
+	result := LastDecision{
+		Decision:  models.DecisionType(decision),
+		PartnerID: partnerID,
+	}
+
+	if len(values) == 3 {
+		result.Date = values[2]
+	}
+
+	return result, nil
+}
+
+// DeleteLastDecision is func to remove the last swipe decision of user id
+func (f *PartnerCacheStore) DeleteLastDecision(userID string) error {
+	key := fmt.Sprintf(lastDecision, userID)
+	return f.rd.Del(key)
+}
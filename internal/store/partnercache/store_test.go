@@ -2,6 +2,7 @@ package partnercache
 
 import (
 	"fmt"
+	"gilsaputro/dating-apps/models"
 	"gilsaputro/dating-apps/pkg/redis"
 	mock_redis "gilsaputro/dating-apps/pkg/redis/mock"
 	"reflect"
@@ -320,3 +321,245 @@ func TestPartnerCacheStore_GetViewedUserCounter(t *testing.T) {
 		})
 	}
 }
+
+func TestPartnerCacheStore_SetSuperLikeCounter(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	type args struct {
+		userID string
+		value  string
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				rd.EXPECT().Set("SLC:"+time.Now().Format(dateFormat)+":1", "10", 24*time.Hour).Return(nil)
+			},
+			args: args{
+				userID: "1",
+				value:  "10",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := PartnerCacheStore{
+				rd: rd,
+			}
+			tt.mockFunc()
+			if err := s.SetSuperLikeCounter(tt.args.userID, tt.args.value); (err != nil) != tt.wantErr {
+				t.Errorf("PartnerCacheStore.SetSuperLikeCounter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPartnerCacheStore_GetSuperLikeCounter(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	type args struct {
+		userID string
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				rd.EXPECT().Get(gomock.Any()).Return("10", nil)
+			},
+			args: args{
+				userID: "1",
+			},
+			want:    "10",
+			wantErr: false,
+		},
+		{
+			name: "nil data flow",
+			mockFunc: func() {
+				rd.EXPECT().Get(gomock.Any()).Return("", fmt.Errorf("redis: nil"))
+			},
+			args: args{
+				userID: "1",
+			},
+			want:    "0",
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := PartnerCacheStore{
+				rd: rd,
+			}
+			tt.mockFunc()
+			got, err := s.GetSuperLikeCounter(tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PartnerCacheStore.GetSuperLikeCounter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PartnerCacheStore.GetSuperLikeCounter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPartnerCacheStore_SetLastDecision(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	type args struct {
+		userID    string
+		decision  models.DecisionType
+		partnerID int
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				rd.EXPECT().Set("LSD:1", "2:4:"+time.Now().Format(dateFormat), 24*time.Hour).Return(nil)
+			},
+			args: args{
+				userID:    "1",
+				decision:  models.DecisionPass,
+				partnerID: 4,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error flow",
+			mockFunc: func() {
+				rd.EXPECT().Set("LSD:1", "1:4:"+time.Now().Format(dateFormat), 24*time.Hour).Return(fmt.Errorf("some error"))
+			},
+			args: args{
+				userID:    "1",
+				decision:  models.DecisionLike,
+				partnerID: 4,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := PartnerCacheStore{
+				rd: rd,
+			}
+			tt.mockFunc()
+			if err := s.SetLastDecision(tt.args.userID, tt.args.decision, tt.args.partnerID); (err != nil) != tt.wantErr {
+				t.Errorf("PartnerCacheStore.SetLastDecision() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPartnerCacheStore_GetLastDecision(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	today := time.Now().Format(dateFormat)
+	tests := []struct {
+		name        string
+		mockFunc    func()
+		want        LastDecision
+		wantIsToday bool
+		wantErr     bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				rd.EXPECT().Get("LSD:1").Return("3:4:"+today, nil)
+			},
+			want:        LastDecision{Decision: models.DecisionSuperLike, PartnerID: 4, Date: today},
+			wantIsToday: true,
+			wantErr:     false,
+		},
+		{
+			name: "success decision of yesterday flow",
+			mockFunc: func() {
+				rd.EXPECT().Get("LSD:1").Return("3:4:"+time.Now().AddDate(0, 0, -1).Format(dateFormat), nil)
+			},
+			want:        LastDecision{Decision: models.DecisionSuperLike, PartnerID: 4, Date: time.Now().AddDate(0, 0, -1).Format(dateFormat)},
+			wantIsToday: false,
+			wantErr:     false,
+		},
+		{
+			name: "success decision without date flow",
+			mockFunc: func() {
+				rd.EXPECT().Get("LSD:1").Return("2:4", nil)
+			},
+			want:        LastDecision{Decision: models.DecisionPass, PartnerID: 4},
+			wantIsToday: false,
+			wantErr:     false,
+		},
+		{
+			name: "nil data flow",
+			mockFunc: func() {
+				rd.EXPECT().Get("LSD:1").Return("", fmt.Errorf("redis: nil"))
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid data flow",
+			mockFunc: func() {
+				rd.EXPECT().Get("LSD:1").Return("abc", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error flow",
+			mockFunc: func() {
+				rd.EXPECT().Get("LSD:1").Return("", fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := PartnerCacheStore{
+				rd: rd,
+			}
+			tt.mockFunc()
+			got, err := s.GetLastDecision("1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PartnerCacheStore.GetLastDecision() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PartnerCacheStore.GetLastDecision() = %v, want %v", got, tt.want)
+			}
+			if got.IsToday() != tt.wantIsToday {
+				t.Errorf("LastDecision.IsToday() = %v, want %v", got.IsToday(), tt.wantIsToday)
+			}
+		})
+	}
+}
+
+func TestPartnerCacheStore_DeleteLastDecision(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	rd.EXPECT().Del("LSD:1").Return(nil)
+	s := PartnerCacheStore{
+		rd: rd,
+	}
+	if err := s.DeleteLastDecision("1"); err != nil {
+		t.Errorf("PartnerCacheStore.DeleteLastDecision() error = %v", err)
+	}
+}
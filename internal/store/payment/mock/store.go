@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/store/payment/store.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	models "gilsaputro/dating-apps/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPaymentStoreMethod is a mock of PaymentStoreMethod interface.
+type MockPaymentStoreMethod struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentStoreMethodMockRecorder
+}
+
+// MockPaymentStoreMethodMockRecorder is the mock recorder for MockPaymentStoreMethod.
+type MockPaymentStoreMethodMockRecorder struct {
+	mock *MockPaymentStoreMethod
+}
+
+// NewMockPaymentStoreMethod creates a new mock instance.
+func NewMockPaymentStoreMethod(ctrl *gomock.Controller) *MockPaymentStoreMethod {
+	mock := &MockPaymentStoreMethod{ctrl: ctrl}
+	mock.recorder = &MockPaymentStoreMethodMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentStoreMethod) EXPECT() *MockPaymentStoreMethodMockRecorder {
+	return m.recorder
+}
+
+// CreateEvent mocks base method.
+func (m *MockPaymentStoreMethod) CreateEvent(event models.PaymentEvent) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEvent", event)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEvent indicates an expected call of CreateEvent.
+func (mr *MockPaymentStoreMethodMockRecorder) CreateEvent(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockPaymentStoreMethod)(nil).CreateEvent), event)
+}
+
+// CreatePayment mocks base method.
+func (m *MockPaymentStoreMethod) CreatePayment(payment models.Payment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayment", payment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePayment indicates an expected call of CreatePayment.
+func (mr *MockPaymentStoreMethodMockRecorder) CreatePayment(payment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayment", reflect.TypeOf((*MockPaymentStoreMethod)(nil).CreatePayment), payment)
+}
+
+// GetEvent mocks base method.
+func (m *MockPaymentStoreMethod) GetEvent(provider, eventID string) (models.PaymentEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvent", provider, eventID)
+	ret0, _ := ret[0].(models.PaymentEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvent indicates an expected call of GetEvent.
+func (mr *MockPaymentStoreMethodMockRecorder) GetEvent(provider, eventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvent", reflect.TypeOf((*MockPaymentStoreMethod)(nil).GetEvent), provider, eventID)
+}
+
+// GetPaymentByReference mocks base method.
+func (m *MockPaymentStoreMethod) GetPaymentByReference(reference string) (models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentByReference", reference)
+	ret0, _ := ret[0].(models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentByReference indicates an expected call of GetPaymentByReference.
+func (mr *MockPaymentStoreMethodMockRecorder) GetPaymentByReference(reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentByReference", reflect.TypeOf((*MockPaymentStoreMethod)(nil).GetPaymentByReference), reference)
+}
+
+// MarkEventProcessed mocks base method.
+func (m *MockPaymentStoreMethod) MarkEventProcessed(provider, eventID string, processedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEventProcessed", provider, eventID, processedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEventProcessed indicates an expected call of MarkEventProcessed.
+func (mr *MockPaymentStoreMethodMockRecorder) MarkEventProcessed(provider, eventID, processedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventProcessed", reflect.TypeOf((*MockPaymentStoreMethod)(nil).MarkEventProcessed), provider, eventID, processedAt)
+}
+
+// SetPaymentSubscription mocks base method.
+func (m *MockPaymentStoreMethod) SetPaymentSubscription(reference string, subscriptionID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPaymentSubscription", reference, subscriptionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPaymentSubscription indicates an expected call of SetPaymentSubscription.
+func (mr *MockPaymentStoreMethodMockRecorder) SetPaymentSubscription(reference, subscriptionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPaymentSubscription", reflect.TypeOf((*MockPaymentStoreMethod)(nil).SetPaymentSubscription), reference, subscriptionID)
+}
+
+// UpdatePaymentStatus mocks base method.
+func (m *MockPaymentStoreMethod) UpdatePaymentStatus(reference string, fromStatus []string, toStatus string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentStatus", reference, fromStatus, toStatus)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePaymentStatus indicates an expected call of UpdatePaymentStatus.
+func (mr *MockPaymentStoreMethodMockRecorder) UpdatePaymentStatus(reference, fromStatus, toStatus interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentStatus", reflect.TypeOf((*MockPaymentStoreMethod)(nil).UpdatePaymentStatus), reference, fromStatus, toStatus)
+}
@@ -0,0 +1,141 @@
+package payment
+
+import (
+	"database/sql"
+	"errors"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/postgres"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// PaymentStoreMethod is set of methods for interacting with a payment and webhook event storage system
+type PaymentStoreMethod interface {
+	CreatePayment(payment models.Payment) error
+	GetPaymentByReference(reference string) (models.Payment, error)
+	UpdatePaymentStatus(reference string, fromStatus []string, toStatus string) (bool, error)
+	SetPaymentSubscription(reference string, subscriptionID int) error
+	CreateEvent(event models.PaymentEvent) (bool, error)
+	GetEvent(provider, eventID string) (models.PaymentEvent, error)
+	MarkEventProcessed(provider, eventID string, processedAt time.Time) error
+}
+
+// PaymentStore is list dependencies payment store
+type PaymentStore struct {
+	pg postgres.PostgresMethod
+}
+
+// NewPaymentStore is func to generate PaymentStoreMethod interface
+func NewPaymentStore(pg postgres.PostgresMethod) PaymentStoreMethod {
+	return &PaymentStore{
+		pg: pg,
+	}
+}
+
+func (p *PaymentStore) getDB() (*gorm.DB, error) {
+	db := p.pg.GetDB()
+	if db == nil {
+		return nil, errors.New("Database Client is not init")
+	}
+
+	return db, nil
+}
+
+// CreatePayment is func to store new payment of the checkout
+func (p *PaymentStore) CreatePayment(payment models.Payment) error {
+	db, err := p.getDB()
+	if err != nil {
+		return err
+	}
+
+	return db.Create(&payment).Error
+}
+
+// GetPaymentByReference is func to get the payment by the reference, it will return empty data if the payment is not exists
+func (p *PaymentStore) GetPaymentByReference(reference string) (models.Payment, error) {
+	db, err := p.getDB()
+	if err != nil {
+		return models.Payment{}, err
+	}
+
+	var payment models.Payment
+	err = db.Where("reference = ?", reference).First(&payment).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return models.Payment{}, nil
+	}
+
+	return payment, err
+}
+
+// UpdatePaymentStatus is func to change the payment status only when the current status is one of the from status,
+// it will return false if the status is already changed by other event
+func (p *PaymentStore) UpdatePaymentStatus(reference string, fromStatus []string, toStatus string) (bool, error) {
+	db, err := p.getDB()
+	if err != nil {
+		return false, err
+	}
+
+	query := db.Model(models.Payment{}).Where("reference = ? AND status IN (?)", reference, fromStatus).Update("status", toStatus)
+	if query.Error != nil {
+		return false, query.Error
+	}
+
+	return query.RowsAffected > 0, nil
+}
+
+// SetPaymentSubscription is func to store the subscription activated by the payment
+func (p *PaymentStore) SetPaymentSubscription(reference string, subscriptionID int) error {
+	db, err := p.getDB()
+	if err != nil {
+		return err
+	}
+
+	return db.Model(models.Payment{}).Where("reference = ?", reference).Update("subscription_id", subscriptionID).Error
+}
+
+// CreateEvent is func to store the webhook event, it will return false if the event is already stored
+func (p *PaymentStore) CreateEvent(event models.PaymentEvent) (bool, error) {
+	db, err := p.getDB()
+	if err != nil {
+		return false, err
+	}
+
+	// the conflict is not returned as error, so the concurrent delivery of the same event does not fail the request
+	err = db.Set("gorm:insert_option", "ON CONFLICT DO NOTHING").Create(&event).Error
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetEvent is func to get the stored webhook event, it will return empty data if the event is not exists
+func (p *PaymentStore) GetEvent(provider, eventID string) (models.PaymentEvent, error) {
+	db, err := p.getDB()
+	if err != nil {
+		return models.PaymentEvent{}, err
+	}
+
+	var event models.PaymentEvent
+	err = db.Where("provider = ? AND event_id = ?", provider, eventID).First(&event).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return models.PaymentEvent{}, nil
+	}
+
+	return event, err
+}
+
+// MarkEventProcessed is func to set the processed time of the webhook event
+func (p *PaymentStore) MarkEventProcessed(provider, eventID string, processedAt time.Time) error {
+	db, err := p.getDB()
+	if err != nil {
+		return err
+	}
+
+	return db.Model(models.PaymentEvent{}).Where("provider = ? AND event_id = ?", provider, eventID).Update("processed_at", &processedAt).Error
+}
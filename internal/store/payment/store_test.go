@@ -0,0 +1,561 @@
+package payment
+
+import (
+	"database/sql"
+	"fmt"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/postgres"
+	mock_postgres "gilsaputro/dating-apps/pkg/postgres/mock"
+	"log"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestNewPaymentStore(t *testing.T) {
+	type args struct {
+		pg postgres.PostgresMethod
+	}
+	tests := []struct {
+		name string
+		args args
+		want PaymentStoreMethod
+	}{
+		{
+			name: "success flow",
+			args: args{
+				pg: &postgres.Client{},
+			},
+			want: &PaymentStore{
+				pg: &postgres.Client{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewPaymentStore(tt.args.pg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewPaymentStore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func InitDBsMockupStat() (*sql.DB, sqlmock.Sqlmock, *gorm.DB) {
+	db, mock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open("postgres", db)
+	gormDB.LogMode(true)
+	gormDB.SetLogger(log.New(os.Stdout, "\n", 0))
+	gormDB.Debug()
+	return db, mock, gormDB
+}
+
+func TestPaymentStore_CreatePayment(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	payment := models.Payment{
+		UserID:    1,
+		Plan:      models.PlanPremium,
+		Provider:  "stripe",
+		Reference: "ref-1",
+		SessionID: "cs_1",
+		Amount:    999,
+		Currency:  "usd",
+		Status:    models.PaymentStatusPending,
+	}
+	query := `INSERT INTO "payments" ("created_at","updated_at","deleted_at","user_id","plan","provider","reference","session_id","amount","currency","status","subscription_id") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12) RETURNING "payments"."id"`
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := PaymentStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			if err := store.CreatePayment(payment); (err != nil) != tt.wantErr {
+				t.Errorf("PaymentStore.CreatePayment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPaymentStore_GetPaymentByReference(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	query := `SELECT * FROM "payments"  WHERE "payments"."deleted_at" IS NULL AND ((reference = $1)) ORDER BY "payments"."id" ASC LIMIT 1`
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     models.Payment
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("ref-1").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "plan", "reference", "status"}).AddRow(3, 1, models.PlanPremium, "ref-1", models.PaymentStatusPending))
+			},
+			want: models.Payment{
+				Model: gorm.Model{
+					ID: 3,
+				},
+				UserID:    1,
+				Plan:      models.PlanPremium,
+				Reference: "ref-1",
+				Status:    models.PaymentStatusPending,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success not found",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("ref-1").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			want:    models.Payment{},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(fmt.Errorf("some error"))
+			},
+			want:    models.Payment{},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    models.Payment{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := PaymentStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.GetPaymentByReference("ref-1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PaymentStore.GetPaymentByReference() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PaymentStore.GetPaymentByReference() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaymentStore_UpdatePaymentStatus(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	query := `UPDATE "payments" SET "status" = $1, "updated_at" = $2 WHERE "payments"."deleted_at" IS NULL AND ((reference = $3 AND status IN ($4)))`
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     bool
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(models.PaymentStatusPaid, sqlmock.AnyArg(), "ref-1", models.PaymentStatusPending).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectCommit()
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "success status already changed",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(query)).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				mockDB.ExpectCommit()
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(query)).
+					WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := PaymentStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.UpdatePaymentStatus("ref-1", []string{models.PaymentStatusPending}, models.PaymentStatusPaid)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PaymentStore.UpdatePaymentStatus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PaymentStore.UpdatePaymentStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaymentStore_SetPaymentSubscription(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	query := `UPDATE "payments" SET "subscription_id" = $1, "updated_at" = $2 WHERE "payments"."deleted_at" IS NULL AND ((reference = $3))`
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(2, sqlmock.AnyArg(), "ref-1").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(query)).
+					WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := PaymentStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			if err := store.SetPaymentSubscription("ref-1", 2); (err != nil) != tt.wantErr {
+				t.Errorf("PaymentStore.SetPaymentSubscription() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPaymentStore_CreateEvent(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	event := models.PaymentEvent{
+		Provider:  "stripe",
+		EventID:   "evt_1",
+		Type:      "checkout.completed",
+		Reference: "ref-1",
+		Payload:   `{"id":"evt_1"}`,
+	}
+	query := `INSERT INTO "payment_events" ("created_at","updated_at","deleted_at","provider","event_id","type","reference","payload","processed_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) ON CONFLICT DO NOTHING RETURNING "payment_events"."id"`
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     bool
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "success event already stored",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mockDB.ExpectRollback()
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := PaymentStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.CreateEvent(event)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PaymentStore.CreateEvent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PaymentStore.CreateEvent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaymentStore_GetEvent(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	processedAt := time.Unix(1700000000, 0)
+	query := `SELECT * FROM "payment_events"  WHERE "payment_events"."deleted_at" IS NULL AND ((provider = $1 AND event_id = $2)) ORDER BY "payment_events"."id" ASC LIMIT 1`
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     models.PaymentEvent
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("stripe", "evt_1").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "provider", "event_id", "processed_at"}).AddRow(4, "stripe", "evt_1", processedAt))
+			},
+			want: models.PaymentEvent{
+				Model: gorm.Model{
+					ID: 4,
+				},
+				Provider:    "stripe",
+				EventID:     "evt_1",
+				ProcessedAt: &processedAt,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success not found",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("stripe", "evt_1").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			want:    models.PaymentEvent{},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(fmt.Errorf("some error"))
+			},
+			want:    models.PaymentEvent{},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    models.PaymentEvent{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := PaymentStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.GetEvent("stripe", "evt_1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PaymentStore.GetEvent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PaymentStore.GetEvent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaymentStore_MarkEventProcessed(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	processedAt := time.Unix(1700000000, 0)
+	query := `UPDATE "payment_events" SET "processed_at" = $1, "updated_at" = $2 WHERE "payment_events"."deleted_at" IS NULL AND ((provider = $3 AND event_id = $4))`
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(processedAt, sqlmock.AnyArg(), "stripe", "evt_1").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(query)).
+					WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := PaymentStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			if err := store.MarkEventProcessed("stripe", "evt_1", processedAt); (err != nil) != tt.wantErr {
+				t.Errorf("PaymentStore.MarkEventProcessed() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
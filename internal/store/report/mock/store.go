@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/store/report/store.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	report "gilsaputro/dating-apps/internal/store/report"
+	models "gilsaputro/dating-apps/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReportStoreMethod is a mock of ReportStoreMethod interface.
+type MockReportStoreMethod struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportStoreMethodMockRecorder
+}
+
+// MockReportStoreMethodMockRecorder is the mock recorder for MockReportStoreMethod.
+type MockReportStoreMethodMockRecorder struct {
+	mock *MockReportStoreMethod
+}
+
+// NewMockReportStoreMethod creates a new mock instance.
+func NewMockReportStoreMethod(ctrl *gomock.Controller) *MockReportStoreMethod {
+	mock := &MockReportStoreMethod{ctrl: ctrl}
+	mock.recorder = &MockReportStoreMethodMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportStoreMethod) EXPECT() *MockReportStoreMethodMockRecorder {
+	return m.recorder
+}
+
+// CountReport mocks base method.
+func (m *MockReportStoreMethod) CountReport(status models.ReportStatus) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountReport", status)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountReport indicates an expected call of CountReport.
+func (mr *MockReportStoreMethodMockRecorder) CountReport(status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountReport", reflect.TypeOf((*MockReportStoreMethod)(nil).CountReport), status)
+}
+
+// CreateReport mocks base method.
+func (m *MockReportStoreMethod) CreateReport(report models.UserReport) (models.UserReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReport", report)
+	ret0, _ := ret[0].(models.UserReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReport indicates an expected call of CreateReport.
+func (mr *MockReportStoreMethodMockRecorder) CreateReport(report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReport", reflect.TypeOf((*MockReportStoreMethod)(nil).CreateReport), report)
+}
+
+// GetReportList mocks base method.
+func (m *MockReportStoreMethod) GetReportList(filter report.ReportFilter) ([]models.UserReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReportList", filter)
+	ret0, _ := ret[0].([]models.UserReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReportList indicates an expected call of GetReportList.
+func (mr *MockReportStoreMethodMockRecorder) GetReportList(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportList", reflect.TypeOf((*MockReportStoreMethod)(nil).GetReportList), filter)
+}
+
+// ResolveReport mocks base method.
+func (m *MockReportStoreMethod) ResolveReport(report models.UserReport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveReport", report)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveReport indicates an expected call of ResolveReport.
+func (mr *MockReportStoreMethodMockRecorder) ResolveReport(report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveReport", reflect.TypeOf((*MockReportStoreMethod)(nil).ResolveReport), report)
+}
@@ -0,0 +1,119 @@
+package report
+
+import (
+	"errors"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/postgres"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// ReportStoreMethod is set of methods for interacting with a user report storage system
+type ReportStoreMethod interface {
+	CreateReport(report models.UserReport) (models.UserReport, error)
+	GetReportList(filter ReportFilter) ([]models.UserReport, error)
+	CountReport(status models.ReportStatus) (int, error)
+	ResolveReport(report models.UserReport) error
+}
+
+// ReportFilter is list parameter to query user report
+type ReportFilter struct {
+	Status models.ReportStatus
+	Limit  int
+	Offset int
+}
+
+// ReportStore is list dependencies report store
+type ReportStore struct {
+	pg postgres.PostgresMethod
+}
+
+// NewReportStore is func to generate ReportStoreMethod interface
+func NewReportStore(pg postgres.PostgresMethod) ReportStoreMethod {
+	return &ReportStore{
+		pg: pg,
+	}
+}
+
+func (r *ReportStore) getDB() (*gorm.DB, error) {
+	db := r.pg.GetDB()
+	if db == nil {
+		return nil, errors.New("Database Client is not init")
+	}
+
+	return db, nil
+}
+
+// CreateReport is func to store new report into moderation queue
+func (r *ReportStore) CreateReport(report models.UserReport) (models.UserReport, error) {
+	db, err := r.getDB()
+	if err != nil {
+		return models.UserReport{}, err
+	}
+
+	report.Status = models.ReportStatusOpen
+	err = db.Create(&report).Error
+	if err != nil {
+		return models.UserReport{}, err
+	}
+
+	return report, nil
+}
+
+// GetReportList is func to get report with the given status, the oldest report comes first
+func (r *ReportStore) GetReportList(filter ReportFilter) ([]models.UserReport, error) {
+	db, err := r.getDB()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []models.UserReport{}
+	err = db.Where("status = ?", filter.Status).Order("created_at ASC").Limit(filter.Limit).Offset(filter.Offset).Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CountReport is func to count report with the given status
+func (r *ReportStore) CountReport(status models.ReportStatus) (int, error) {
+	db, err := r.getDB()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = db.Model(models.UserReport{}).Where("status = ?", status).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// ResolveReport is func to close the open report with the resolution, it will return record not found if the report is not open
+func (r *ReportStore) ResolveReport(report models.UserReport) error {
+	db, err := r.getDB()
+	if err != nil {
+		return err
+	}
+
+	resolvedAt := time.Now()
+	query := db.Model(models.UserReport{}).Where("id = ? AND status = ?", report.ID, models.ReportStatusOpen).Updates(map[string]interface{}{
+		"status":      report.Status,
+		"resolved_by": report.ResolvedBy,
+		"resolved_at": &resolvedAt,
+		"resolution":  report.Resolution,
+	})
+	if query.Error != nil {
+		return query.Error
+	}
+
+	if query.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,321 @@
+package report
+
+import (
+	"database/sql"
+	"fmt"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/postgres"
+	mock_postgres "gilsaputro/dating-apps/pkg/postgres/mock"
+	"log"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestNewReportStore(t *testing.T) {
+	type args struct {
+		pg postgres.PostgresMethod
+	}
+	tests := []struct {
+		name string
+		args args
+		want ReportStoreMethod
+	}{
+		{
+			name: "success flow",
+			args: args{
+				pg: &postgres.Client{},
+			},
+			want: &ReportStore{
+				pg: &postgres.Client{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewReportStore(tt.args.pg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewReportStore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+var errSome = fmt.Errorf("some error")
+
+func InitDBsMockupStat() (*sql.DB, sqlmock.Sqlmock, *gorm.DB) {
+	db, mock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open("postgres", db)
+	gormDB.LogMode(true)
+	gormDB.SetLogger(log.New(os.Stdout, "\n", 0))
+	gormDB.Debug()
+	return db, mock, gormDB
+}
+
+func TestReportStore_CreateReport(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     uint
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_reports" ("created_at","updated_at","deleted_at","reporter_id","reported_user_id","reason","note","status","resolved_by","resolved_at","resolution") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11) RETURNING "user_reports"."id"`)).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 1, 2, models.ReportReasonSpam, "spam", models.ReportStatusOpen, 0, nil, "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				mockDB.ExpectCommit()
+			},
+			want:    5,
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_reports"`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := ReportStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.CreateReport(models.UserReport{
+				ReporterID:     1,
+				ReportedUserID: 2,
+				Reason:         models.ReportReasonSpam,
+				Note:           "spam",
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReportStore.CreateReport() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.ID != tt.want {
+				t.Errorf("ReportStore.CreateReport() = %v, want %v", got.ID, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportStore_GetReportList(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     []models.UserReport
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_reports"  WHERE "user_reports"."deleted_at" IS NULL AND ((status = $1)) ORDER BY created_at ASC LIMIT 10 OFFSET 10`)).
+					WithArgs(models.ReportStatusOpen).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "reporter_id", "reported_user_id", "reason", "status"}).AddRow(1, 1, 2, models.ReportReasonSpam, models.ReportStatusOpen))
+			},
+			want: []models.UserReport{
+				{
+					Model:          gorm.Model{ID: 1},
+					ReporterID:     1,
+					ReportedUserID: 2,
+					Reason:         models.ReportReasonSpam,
+					Status:         models.ReportStatusOpen,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_reports"`)).WillReturnError(fmt.Errorf("some error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := ReportStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.GetReportList(ReportFilter{
+				Status: models.ReportStatusOpen,
+				Limit:  10,
+				Offset: 10,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReportStore.GetReportList() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReportStore.GetReportList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportStore_CountReport(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     int
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "user_reports"  WHERE "user_reports"."deleted_at" IS NULL AND ((status = $1))`)).
+					WithArgs(models.ReportStatusOpen).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+			},
+			want:    3,
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "user_reports"`)).WillReturnError(fmt.Errorf("some error"))
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := ReportStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.CountReport(models.ReportStatusOpen)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReportStore.CountReport() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ReportStore.CountReport() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportStore_ResolveReport(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "user_reports" SET "resolution" = $1, "resolved_at" = $2, "resolved_by" = $3, "status" = $4, "updated_at" = $5 WHERE "user_reports"."deleted_at" IS NULL AND ((id = $6 AND status = $7))`)).
+					WithArgs("fake account", sqlmock.AnyArg(), 9, models.ReportStatusResolved, sqlmock.AnyArg(), 1, models.ReportStatusOpen).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name: "error report is not open",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "user_reports"`)).WillReturnResult(sqlmock.NewResult(1, 0))
+				mockDB.ExpectCommit()
+			},
+			wantErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "user_reports"`)).WillReturnError(errSome)
+				mockDB.ExpectRollback()
+			},
+			wantErr: errSome,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := ReportStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			err := store.ResolveReport(models.UserReport{
+				Model:      gorm.Model{ID: 1},
+				Status:     models.ReportStatusResolved,
+				ResolvedBy: 9,
+				Resolution: "fake account",
+			})
+			if err != tt.wantErr {
+				t.Errorf("ReportStore.ResolveReport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
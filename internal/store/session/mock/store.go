@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/store/session/store.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	models "gilsaputro/dating-apps/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSessionStoreMethod is a mock of SessionStoreMethod interface.
+type MockSessionStoreMethod struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionStoreMethodMockRecorder
+}
+
+// MockSessionStoreMethodMockRecorder is the mock recorder for MockSessionStoreMethod.
+type MockSessionStoreMethodMockRecorder struct {
+	mock *MockSessionStoreMethod
+}
+
+// NewMockSessionStoreMethod creates a new mock instance.
+func NewMockSessionStoreMethod(ctrl *gomock.Controller) *MockSessionStoreMethod {
+	mock := &MockSessionStoreMethod{ctrl: ctrl}
+	mock.recorder = &MockSessionStoreMethodMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionStoreMethod) EXPECT() *MockSessionStoreMethodMockRecorder {
+	return m.recorder
+}
+
+// CreateSession mocks base method.
+func (m *MockSessionStoreMethod) CreateSession(session models.UserSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockSessionStoreMethodMockRecorder) CreateSession(session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockSessionStoreMethod)(nil).CreateSession), session)
+}
+
+// GetActiveSessions mocks base method.
+func (m *MockSessionStoreMethod) GetActiveSessions(userID int) ([]models.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveSessions", userID)
+	ret0, _ := ret[0].([]models.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveSessions indicates an expected call of GetActiveSessions.
+func (mr *MockSessionStoreMethodMockRecorder) GetActiveSessions(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveSessions", reflect.TypeOf((*MockSessionStoreMethod)(nil).GetActiveSessions), userID)
+}
+
+// GetSession mocks base method.
+func (m *MockSessionStoreMethod) GetSession(sessionID string) (models.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", sessionID)
+	ret0, _ := ret[0].(models.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockSessionStoreMethodMockRecorder) GetSession(sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockSessionStoreMethod)(nil).GetSession), sessionID)
+}
+
+// RevokeAllSessions mocks base method.
+func (m *MockSessionStoreMethod) RevokeAllSessions(userID int, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllSessions", userID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAllSessions indicates an expected call of RevokeAllSessions.
+func (mr *MockSessionStoreMethodMockRecorder) RevokeAllSessions(userID, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllSessions", reflect.TypeOf((*MockSessionStoreMethod)(nil).RevokeAllSessions), userID, revokedAt)
+}
+
+// RevokeSession mocks base method.
+func (m *MockSessionStoreMethod) RevokeSession(userID int, sessionID string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", userID, sessionID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockSessionStoreMethodMockRecorder) RevokeSession(userID, sessionID, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockSessionStoreMethod)(nil).RevokeSession), userID, sessionID, revokedAt)
+}
+
+// UpdateLastSeen mocks base method.
+func (m *MockSessionStoreMethod) UpdateLastSeen(sessionID string, lastSeenAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastSeen", sessionID, lastSeenAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastSeen indicates an expected call of UpdateLastSeen.
+func (mr *MockSessionStoreMethodMockRecorder) UpdateLastSeen(sessionID, lastSeenAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastSeen", reflect.TypeOf((*MockSessionStoreMethod)(nil).UpdateLastSeen), sessionID, lastSeenAt)
+}
@@ -0,0 +1,181 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/postgres"
+	"gilsaputro/dating-apps/pkg/redis"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// SessionStoreMethod is set of methods for interacting with a device session storage system
+type SessionStoreMethod interface {
+	CreateSession(session models.UserSession) error
+	GetSession(sessionID string) (models.UserSession, error)
+	GetActiveSessions(userID int) ([]models.UserSession, error)
+	UpdateLastSeen(sessionID string, lastSeenAt time.Time) error
+	RevokeSession(userID int, sessionID string, revokedAt time.Time) error
+	RevokeAllSessions(userID int, revokedAt time.Time) error
+}
+
+// SessionStore is list dependencies session store, the session is stored in database and cached in redis
+// because it is checked on every authenticated request
+type SessionStore struct {
+	pg       postgres.PostgresMethod
+	rd       redis.RedisMethod
+	cacheTTL time.Duration
+}
+
+// NewSessionStore is func to generate SessionStoreMethod interface
+func NewSessionStore(pg postgres.PostgresMethod, rd redis.RedisMethod, cacheTTL time.Duration) SessionStoreMethod {
+	return &SessionStore{
+		pg:       pg,
+		rd:       rd,
+		cacheTTL: cacheTTL,
+	}
+}
+
+func (s *SessionStore) getDB() (*gorm.DB, error) {
+	db := s.pg.GetDB()
+	if db == nil {
+		return nil, errors.New("Database Client is not init")
+	}
+
+	return db, nil
+}
+
+const userSession string = `USS:%v` // format USS:<sessionid>
+
+// CreateSession is func to store new session of the login
+func (s *SessionStore) CreateSession(session models.UserSession) error {
+	db, err := s.getDB()
+	if err != nil {
+		return err
+	}
+
+	return db.Create(&session).Error
+}
+
+// GetSession is func to get the session from cache or database, it will return empty data if the session is not exists
+func (s *SessionStore) GetSession(sessionID string) (models.UserSession, error) {
+	key := fmt.Sprintf(userSession, sessionID)
+	value, err := s.rd.Get(key)
+	if err != nil && !strings.Contains(err.Error(), "redis: nil") {
+		return models.UserSession{}, err
+	}
+
+	var session models.UserSession
+	if err == nil {
+		err = json.Unmarshal([]byte(value), &session)
+		return session, err
+	}
+
+	db, err := s.getDB()
+	if err != nil {
+		return models.UserSession{}, err
+	}
+
+	err = db.Where("session_id = ?", sessionID).First(&session).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return models.UserSession{}, nil
+	}
+
+	if err != nil {
+		return models.UserSession{}, err
+	}
+
+	// the session is already loaded from database, failed to cache it only make the next request slower
+	data, err := json.Marshal(session)
+	if err == nil {
+		_ = s.rd.Set(key, string(data), s.cacheTTL)
+	}
+
+	return session, nil
+}
+
+// GetActiveSessions is func to get the session of the user that is not revoked, the last used session comes first
+func (s *SessionStore) GetActiveSessions(userID int) ([]models.UserSession, error) {
+	db, err := s.getDB()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []models.UserSession{}
+	err = db.Where("user_id = ? AND revoked_at IS NULL", userID).Order("last_seen_at DESC").Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateLastSeen is func to update the last time the session is used
+func (s *SessionStore) UpdateLastSeen(sessionID string, lastSeenAt time.Time) error {
+	db, err := s.getDB()
+	if err != nil {
+		return err
+	}
+
+	err = db.Model(models.UserSession{}).Where("session_id = ?", sessionID).Update("last_seen_at", lastSeenAt).Error
+	if err != nil {
+		return err
+	}
+
+	return s.rd.Del(fmt.Sprintf(userSession, sessionID))
+}
+
+// RevokeSession is func to revoke the active session of the user, it will return record not found if the session is not active
+func (s *SessionStore) RevokeSession(userID int, sessionID string, revokedAt time.Time) error {
+	db, err := s.getDB()
+	if err != nil {
+		return err
+	}
+
+	query := db.Model(models.UserSession{}).Where("user_id = ? AND session_id = ? AND revoked_at IS NULL", userID, sessionID).Update("revoked_at", &revokedAt)
+	if query.Error != nil {
+		return query.Error
+	}
+
+	if query.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	// the cached session must be removed, otherwise the revoked session still can be used until the cache is expired
+	return s.rd.Del(fmt.Sprintf(userSession, sessionID))
+}
+
+// RevokeAllSessions is func to revoke all active session of the user
+func (s *SessionStore) RevokeAllSessions(userID int, revokedAt time.Time) error {
+	sessions, err := s.GetActiveSessions(userID)
+	if err != nil {
+		return err
+	}
+
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	db, err := s.getDB()
+	if err != nil {
+		return err
+	}
+
+	err = db.Model(models.UserSession{}).Where("user_id = ? AND revoked_at IS NULL", userID).Update("revoked_at", &revokedAt).Error
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		err = s.rd.Del(fmt.Sprintf(userSession, session.SessionID))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
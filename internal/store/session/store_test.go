@@ -0,0 +1,547 @@
+package session
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/postgres"
+	mock_postgres "gilsaputro/dating-apps/pkg/postgres/mock"
+	"gilsaputro/dating-apps/pkg/redis"
+	mock_redis "gilsaputro/dating-apps/pkg/redis/mock"
+	"log"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestNewSessionStore(t *testing.T) {
+	type args struct {
+		pg       postgres.PostgresMethod
+		rd       redis.RedisMethod
+		cacheTTL time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+		want SessionStoreMethod
+	}{
+		{
+			name: "success flow",
+			args: args{
+				pg:       &postgres.Client{},
+				rd:       &redis.RedisClient{},
+				cacheTTL: time.Hour,
+			},
+			want: &SessionStore{
+				pg:       &postgres.Client{},
+				rd:       &redis.RedisClient{},
+				cacheTTL: time.Hour,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewSessionStore(tt.args.pg, tt.args.rd, tt.args.cacheTTL); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewSessionStore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+var errSome = fmt.Errorf("some error")
+
+func InitDBsMockupStat() (*sql.DB, sqlmock.Sqlmock, *gorm.DB) {
+	db, mock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open("postgres", db)
+	gormDB.LogMode(true)
+	gormDB.SetLogger(log.New(os.Stdout, "\n", 0))
+	gormDB.Debug()
+	return db, mock, gormDB
+}
+
+func TestSessionStore_CreateSession(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	lastSeenAt := time.Unix(1700000000, 0)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_sessions" ("created_at","updated_at","deleted_at","session_id","user_id","device","ip_address","user_agent","last_seen_at","revoked_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING "user_sessions"."id"`)).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "sid", 1, "iphone", "192.0.2.1", "agent", lastSeenAt, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_sessions"`)).WillReturnError(errSome)
+				mockDB.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := SessionStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			err := store.CreateSession(models.UserSession{
+				SessionID:  "sid",
+				UserID:     1,
+				Device:     "iphone",
+				IPAddress:  "192.0.2.1",
+				UserAgent:  "agent",
+				LastSeenAt: lastSeenAt,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SessionStore.CreateSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSessionStore_GetSession(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+
+	session := models.UserSession{
+		Model: gorm.Model{
+			ID: 1,
+		},
+		SessionID: "sid",
+		UserID:    1,
+		Device:    "iphone",
+	}
+	cached, _ := json.Marshal(session)
+	query := `SELECT * FROM "user_sessions"  WHERE "user_sessions"."deleted_at" IS NULL AND ((session_id = $1)) ORDER BY "user_sessions"."id" ASC LIMIT 1`
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     models.UserSession
+		wantErr  bool
+	}{
+		{
+			name: "success from cache",
+			mockFunc: func() {
+				rd.EXPECT().Get("USS:sid").Return(string(cached), nil)
+			},
+			want:    session,
+			wantErr: false,
+		},
+		{
+			name: "success from database",
+			mockFunc: func() {
+				rd.EXPECT().Get("USS:sid").Return("", fmt.Errorf("redis: nil"))
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("sid").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "session_id", "user_id", "device"}).AddRow(1, "sid", 1, "iphone"))
+				rd.EXPECT().Set("USS:sid", string(cached), time.Hour).Return(errSome)
+			},
+			want:    session,
+			wantErr: false,
+		},
+		{
+			name: "success session not exists",
+			mockFunc: func() {
+				rd.EXPECT().Get("USS:sid").Return("", fmt.Errorf("redis: nil"))
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("sid").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			want:    models.UserSession{},
+			wantErr: false,
+		},
+		{
+			name: "error on redis",
+			mockFunc: func() {
+				rd.EXPECT().Get("USS:sid").Return("", errSome)
+			},
+			want:    models.UserSession{},
+			wantErr: true,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				rd.EXPECT().Get("USS:sid").Return("", fmt.Errorf("redis: nil"))
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errSome)
+			},
+			want:    models.UserSession{},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				rd.EXPECT().Get("USS:sid").Return("", fmt.Errorf("redis: nil"))
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    models.UserSession{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := SessionStore{
+				pg:       pg,
+				rd:       rd,
+				cacheTTL: time.Hour,
+			}
+			tt.mockFunc()
+			got, err := store.GetSession("sid")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SessionStore.GetSession() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SessionStore.GetSession() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionStore_GetActiveSessions(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	query := `SELECT * FROM "user_sessions"  WHERE "user_sessions"."deleted_at" IS NULL AND ((user_id = $1 AND revoked_at IS NULL)) ORDER BY last_seen_at DESC`
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     []models.UserSession
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "session_id", "user_id"}).AddRow(1, "sid-1", 1).AddRow(2, "sid-2", 1))
+			},
+			want: []models.UserSession{
+				{Model: gorm.Model{ID: 1}, SessionID: "sid-1", UserID: 1},
+				{Model: gorm.Model{ID: 2}, SessionID: "sid-2", UserID: 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errSome)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := SessionStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.GetActiveSessions(1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SessionStore.GetActiveSessions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SessionStore.GetActiveSessions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionStore_UpdateLastSeen(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	lastSeenAt := time.Unix(1700000000, 0)
+	query := `UPDATE "user_sessions" SET "last_seen_at" = $1, "updated_at" = $2 WHERE "user_sessions"."deleted_at" IS NULL AND ((session_id = $3))`
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(lastSeenAt, sqlmock.AnyArg(), "sid").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectCommit()
+				rd.EXPECT().Del("USS:sid").Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on redis",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectCommit()
+				rd.EXPECT().Del("USS:sid").Return(errSome)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(query)).WillReturnError(errSome)
+				mockDB.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := SessionStore{
+				pg: pg,
+				rd: rd,
+			}
+			tt.mockFunc()
+			err := store.UpdateLastSeen("sid", lastSeenAt)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SessionStore.UpdateLastSeen() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSessionStore_RevokeSession(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	revokedAt := time.Unix(1700000000, 0)
+	query := `UPDATE "user_sessions" SET "revoked_at" = $1, "updated_at" = $2 WHERE "user_sessions"."deleted_at" IS NULL AND ((user_id = $3 AND session_id = $4 AND revoked_at IS NULL))`
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(revokedAt, sqlmock.AnyArg(), 1, "sid").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectCommit()
+				rd.EXPECT().Del("USS:sid").Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "session is not active",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(1, 0))
+				mockDB.ExpectCommit()
+			},
+			wantErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name: "error on redis",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectCommit()
+				rd.EXPECT().Del("USS:sid").Return(errSome)
+			},
+			wantErr: errSome,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(query)).WillReturnError(errSome)
+				mockDB.ExpectRollback()
+			},
+			wantErr: errSome,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			wantErr: fmt.Errorf("Database Client is not init"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := SessionStore{
+				pg: pg,
+				rd: rd,
+			}
+			tt.mockFunc()
+			err := store.RevokeSession(1, "sid", revokedAt)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("SessionStore.RevokeSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSessionStore_RevokeAllSessions(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	revokedAt := time.Unix(1700000000, 0)
+	selectQuery := `SELECT * FROM "user_sessions"  WHERE "user_sessions"."deleted_at" IS NULL AND ((user_id = $1 AND revoked_at IS NULL)) ORDER BY last_seen_at DESC`
+	updateQuery := `UPDATE "user_sessions" SET "revoked_at" = $1, "updated_at" = $2 WHERE "user_sessions"."deleted_at" IS NULL AND ((user_id = $3 AND revoked_at IS NULL))`
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB).Times(2)
+				mockDB.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "session_id", "user_id"}).AddRow(1, "sid-1", 1).AddRow(2, "sid-2", 1))
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(updateQuery)).
+					WithArgs(revokedAt, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				mockDB.ExpectCommit()
+				rd.EXPECT().Del("USS:sid-1").Return(nil)
+				rd.EXPECT().Del("USS:sid-2").Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "success no active session",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on redis",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB).Times(2)
+				mockDB.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "session_id", "user_id"}).AddRow(1, "sid-1", 1))
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(updateQuery)).WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectCommit()
+				rd.EXPECT().Del("USS:sid-1").Return(errSome)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on update",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB).Times(2)
+				mockDB.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "session_id", "user_id"}).AddRow(1, "sid-1", 1))
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(updateQuery)).WillReturnError(errSome)
+				mockDB.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on select",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(selectQuery)).WillReturnError(errSome)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := SessionStore{
+				pg: pg,
+				rd: rd,
+			}
+			tt.mockFunc()
+			err := store.RevokeAllSessions(1, revokedAt)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SessionStore.RevokeAllSessions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/store/subscription/store.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	models "gilsaputro/dating-apps/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSubscriptionStoreMethod is a mock of SubscriptionStoreMethod interface.
+type MockSubscriptionStoreMethod struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionStoreMethodMockRecorder
+}
+
+// MockSubscriptionStoreMethodMockRecorder is the mock recorder for MockSubscriptionStoreMethod.
+type MockSubscriptionStoreMethodMockRecorder struct {
+	mock *MockSubscriptionStoreMethod
+}
+
+// NewMockSubscriptionStoreMethod creates a new mock instance.
+func NewMockSubscriptionStoreMethod(ctrl *gomock.Controller) *MockSubscriptionStoreMethod {
+	mock := &MockSubscriptionStoreMethod{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionStoreMethodMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptionStoreMethod) EXPECT() *MockSubscriptionStoreMethodMockRecorder {
+	return m.recorder
+}
+
+// CreateSubscription mocks base method.
+func (m *MockSubscriptionStoreMethod) CreateSubscription(subscription models.Subscription) (models.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscription", subscription)
+	ret0, _ := ret[0].(models.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubscription indicates an expected call of CreateSubscription.
+func (mr *MockSubscriptionStoreMethodMockRecorder) CreateSubscription(subscription interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockSubscriptionStoreMethod)(nil).CreateSubscription), subscription)
+}
+
+// ExpireSubscription mocks base method.
+func (m *MockSubscriptionStoreMethod) ExpireSubscription(subscriptionID int, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireSubscription", subscriptionID, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireSubscription indicates an expected call of ExpireSubscription.
+func (mr *MockSubscriptionStoreMethodMockRecorder) ExpireSubscription(subscriptionID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireSubscription", reflect.TypeOf((*MockSubscriptionStoreMethod)(nil).ExpireSubscription), subscriptionID, now)
+}
+
+// GetActiveSubscription mocks base method.
+func (m *MockSubscriptionStoreMethod) GetActiveSubscription(userID int) (models.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveSubscription", userID)
+	ret0, _ := ret[0].(models.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveSubscription indicates an expected call of GetActiveSubscription.
+func (mr *MockSubscriptionStoreMethodMockRecorder) GetActiveSubscription(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveSubscription", reflect.TypeOf((*MockSubscriptionStoreMethod)(nil).GetActiveSubscription), userID)
+}
+
+// GetActiveSubscriptions mocks base method.
+func (m *MockSubscriptionStoreMethod) GetActiveSubscriptions(userID int) ([]models.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveSubscriptions", userID)
+	ret0, _ := ret[0].([]models.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveSubscriptions indicates an expected call of GetActiveSubscriptions.
+func (mr *MockSubscriptionStoreMethodMockRecorder) GetActiveSubscriptions(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveSubscriptions", reflect.TypeOf((*MockSubscriptionStoreMethod)(nil).GetActiveSubscriptions), userID)
+}
+
+// GetExpiredSubscriptions mocks base method.
+func (m *MockSubscriptionStoreMethod) GetExpiredSubscriptions(now time.Time, limit int) ([]models.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredSubscriptions", now, limit)
+	ret0, _ := ret[0].([]models.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredSubscriptions indicates an expected call of GetExpiredSubscriptions.
+func (mr *MockSubscriptionStoreMethodMockRecorder) GetExpiredSubscriptions(now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredSubscriptions", reflect.TypeOf((*MockSubscriptionStoreMethod)(nil).GetExpiredSubscriptions), now, limit)
+}
+
+// GetSubscriptionByPaymentReference mocks base method.
+func (m *MockSubscriptionStoreMethod) GetSubscriptionByPaymentReference(reference string) (models.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionByPaymentReference", reference)
+	ret0, _ := ret[0].(models.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptionByPaymentReference indicates an expected call of GetSubscriptionByPaymentReference.
+func (mr *MockSubscriptionStoreMethodMockRecorder) GetSubscriptionByPaymentReference(reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionByPaymentReference", reflect.TypeOf((*MockSubscriptionStoreMethod)(nil).GetSubscriptionByPaymentReference), reference)
+}
+
+// UpdateSubscription mocks base method.
+func (m *MockSubscriptionStoreMethod) UpdateSubscription(subscription models.Subscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscription", subscription)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSubscription indicates an expected call of UpdateSubscription.
+func (mr *MockSubscriptionStoreMethodMockRecorder) UpdateSubscription(subscription interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscription", reflect.TypeOf((*MockSubscriptionStoreMethod)(nil).UpdateSubscription), subscription)
+}
@@ -0,0 +1,191 @@
+package subscription
+
+import (
+	"errors"
+	"fmt"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/postgres"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// SubscriptionStoreMethod is set of methods for interacting with a subscription storage system
+type SubscriptionStoreMethod interface {
+	GetActiveSubscription(userID int) (models.Subscription, error)
+	GetActiveSubscriptions(userID int) ([]models.Subscription, error)
+	GetSubscriptionByPaymentReference(reference string) (models.Subscription, error)
+	CreateSubscription(subscription models.Subscription) (models.Subscription, error)
+	UpdateSubscription(subscription models.Subscription) error
+	GetExpiredSubscriptions(now time.Time, limit int) ([]models.Subscription, error)
+	ExpireSubscription(subscriptionID int, now time.Time) (bool, error)
+}
+
+// SubscriptionStore is list dependencies subscription store
+type SubscriptionStore struct {
+	pg postgres.PostgresMethod
+}
+
+// NewSubscriptionStore is func to generate SubscriptionStoreMethod interface
+func NewSubscriptionStore(pg postgres.PostgresMethod) SubscriptionStoreMethod {
+	return &SubscriptionStore{
+		pg: pg,
+	}
+}
+
+func (s *SubscriptionStore) getDB() (*gorm.DB, error) {
+	db := s.pg.GetDB()
+	if db == nil {
+		return nil, errors.New("Database Client is not init")
+	}
+
+	return db, nil
+}
+
+// GetActiveSubscription is func to get the latest subscription of the user that is not expired yet,
+// it will return empty data if the user never subscribe
+func (s *SubscriptionStore) GetActiveSubscription(userID int) (models.Subscription, error) {
+	db, err := s.getDB()
+	if err != nil {
+		return models.Subscription{}, err
+	}
+
+	var subscription models.Subscription
+	err = db.Where("user_id = ? AND status <> ?", userID, models.SubscriptionStatusExpired).Order("expired_at DESC").First(&subscription).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return models.Subscription{}, nil
+	}
+
+	return subscription, err
+}
+
+// GetActiveSubscriptions is func to get all period of the user that is not expired yet, the earliest period comes first
+func (s *SubscriptionStore) GetActiveSubscriptions(userID int) ([]models.Subscription, error) {
+	db, err := s.getDB()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []models.Subscription{}
+	err = db.Where("user_id = ? AND status <> ?", userID, models.SubscriptionStatusExpired).Order("started_at ASC").Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetSubscriptionByPaymentReference is func to get the period paid by the payment, it will return empty data if the period is not exists
+func (s *SubscriptionStore) GetSubscriptionByPaymentReference(reference string) (models.Subscription, error) {
+	db, err := s.getDB()
+	if err != nil {
+		return models.Subscription{}, err
+	}
+
+	var subscription models.Subscription
+	err = db.Where("payment_reference = ?", reference).First(&subscription).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return models.Subscription{}, nil
+	}
+
+	return subscription, err
+}
+
+// CreateSubscription is func to store new subscription of the user and return the stored subscription
+func (s *SubscriptionStore) CreateSubscription(subscription models.Subscription) (models.Subscription, error) {
+	db, err := s.getDB()
+	if err != nil {
+		return models.Subscription{}, err
+	}
+
+	err = db.Create(&subscription).Error
+	if err != nil {
+		return models.Subscription{}, err
+	}
+
+	return subscription, nil
+}
+
+// UpdateSubscription is func to update the subscription
+func (s *SubscriptionStore) UpdateSubscription(subscription models.Subscription) error {
+	db, err := s.getDB()
+	if err != nil {
+		return err
+	}
+
+	return db.Save(&subscription).Error
+}
+
+// GetExpiredSubscriptions is func to get the subscription that pass the expired time but the status is not expired yet,
+// the oldest subscription comes first
+func (s *SubscriptionStore) GetExpiredSubscriptions(now time.Time, limit int) ([]models.Subscription, error) {
+	db, err := s.getDB()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []models.Subscription{}
+	err = db.Where("status <> ? AND expired_at <= ?", models.SubscriptionStatusExpired, now).Order("expired_at ASC").Limit(limit).Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ExpireSubscription is func to set the subscription status to expired, it will return false if the subscription is already expired
+// so the caller on other server instance does not downgrade the same user twice. The unused time of the expired period is removed
+// from the next periods of the user in the same transaction, so the revoked period is not given back by the renewal after it
+func (s *SubscriptionStore) ExpireSubscription(subscriptionID int, now time.Time) (bool, error) {
+	db, err := s.getDB()
+	if err != nil {
+		return false, err
+	}
+
+	tx := db.Begin()
+	if err := tx.Error; err != nil {
+		return false, err
+	}
+
+	var subscription models.Subscription
+	err = tx.Set("gorm:query_option", "FOR UPDATE").Where("id = ? AND status <> ?", subscriptionID, models.SubscriptionStatusExpired).First(&subscription).Error
+	if err != nil {
+		tx.Rollback()
+		if gorm.IsRecordNotFoundError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	err = tx.Model(&subscription).Update("status", models.SubscriptionStatusExpired).Error
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	start := subscription.StartedAt
+	if now.After(start) {
+		start = now
+	}
+
+	unused := subscription.ExpiredAt.Sub(start)
+	if unused > 0 {
+		interval := fmt.Sprintf("%d microseconds", unused.Microseconds())
+		err = tx.Model(models.Subscription{}).
+			Where("user_id = ? AND status <> ? AND started_at >= ?", subscription.UserID, models.SubscriptionStatusExpired, subscription.ExpiredAt).
+			Updates(map[string]interface{}{
+				"started_at": gorm.Expr("started_at - CAST(? AS INTERVAL)", interval),
+				"expired_at": gorm.Expr("expired_at - CAST(? AS INTERVAL)", interval),
+			}).Error
+		if err != nil {
+			tx.Rollback()
+			return false, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,624 @@
+package subscription
+
+import (
+	"database/sql"
+	"fmt"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/postgres"
+	mock_postgres "gilsaputro/dating-apps/pkg/postgres/mock"
+	"log"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestNewSubscriptionStore(t *testing.T) {
+	type args struct {
+		pg postgres.PostgresMethod
+	}
+	tests := []struct {
+		name string
+		args args
+		want SubscriptionStoreMethod
+	}{
+		{
+			name: "success flow",
+			args: args{
+				pg: &postgres.Client{},
+			},
+			want: &SubscriptionStore{
+				pg: &postgres.Client{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewSubscriptionStore(tt.args.pg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewSubscriptionStore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func InitDBsMockupStat() (*sql.DB, sqlmock.Sqlmock, *gorm.DB) {
+	db, mock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open("postgres", db)
+	gormDB.LogMode(true)
+	gormDB.SetLogger(log.New(os.Stdout, "\n", 0))
+	gormDB.Debug()
+	return db, mock, gormDB
+}
+
+func TestSubscriptionStore_GetActiveSubscription(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	expiredAt := time.Unix(1700000000, 0)
+	query := `SELECT * FROM "subscriptions"  WHERE "subscriptions"."deleted_at" IS NULL AND ((user_id = $1 AND status <> $2)) ORDER BY expired_at DESC,"subscriptions"."id" ASC LIMIT 1`
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     models.Subscription
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1, models.SubscriptionStatusExpired).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "plan", "status", "expired_at"}).AddRow(2, 1, models.PlanPremium, models.SubscriptionStatusActive, expiredAt))
+			},
+			want: models.Subscription{
+				Model: gorm.Model{
+					ID: 2,
+				},
+				UserID:    1,
+				Plan:      models.PlanPremium,
+				Status:    models.SubscriptionStatusActive,
+				ExpiredAt: expiredAt,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success never subscribe",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1, models.SubscriptionStatusExpired).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			want:    models.Subscription{},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(fmt.Errorf("some error"))
+			},
+			want:    models.Subscription{},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    models.Subscription{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := SubscriptionStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.GetActiveSubscription(1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SubscriptionStore.GetActiveSubscription() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SubscriptionStore.GetActiveSubscription() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionStore_GetActiveSubscriptions(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	startedAt := time.Unix(1700000000, 0)
+	query := `SELECT * FROM "subscriptions"  WHERE "subscriptions"."deleted_at" IS NULL AND ((user_id = $1 AND status <> $2)) ORDER BY started_at ASC`
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     []models.Subscription
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1, models.SubscriptionStatusExpired).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "plan", "started_at"}).AddRow(2, 1, models.PlanPremium, startedAt))
+			},
+			want: []models.Subscription{
+				{
+					Model: gorm.Model{
+						ID: 2,
+					},
+					UserID:    1,
+					Plan:      models.PlanPremium,
+					StartedAt: startedAt,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(fmt.Errorf("some error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := SubscriptionStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.GetActiveSubscriptions(1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SubscriptionStore.GetActiveSubscriptions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SubscriptionStore.GetActiveSubscriptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionStore_GetSubscriptionByPaymentReference(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	query := `SELECT * FROM "subscriptions"  WHERE "subscriptions"."deleted_at" IS NULL AND ((payment_reference = $1)) ORDER BY "subscriptions"."id" ASC LIMIT 1`
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     models.Subscription
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("ref-1").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "plan", "payment_reference"}).AddRow(2, 1, models.PlanPremium, "ref-1"))
+			},
+			want: models.Subscription{
+				Model: gorm.Model{
+					ID: 2,
+				},
+				UserID:           1,
+				Plan:             models.PlanPremium,
+				PaymentReference: "ref-1",
+			},
+			wantErr: false,
+		},
+		{
+			name: "success not found",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("ref-1").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			want:    models.Subscription{},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(fmt.Errorf("some error"))
+			},
+			want:    models.Subscription{},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    models.Subscription{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := SubscriptionStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.GetSubscriptionByPaymentReference("ref-1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SubscriptionStore.GetSubscriptionByPaymentReference() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SubscriptionStore.GetSubscriptionByPaymentReference() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionStore_CreateSubscription(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	subscription := models.Subscription{
+		UserID:    1,
+		Plan:      models.PlanPremium,
+		Status:    models.SubscriptionStatusActive,
+		StartedAt: time.Unix(1700000000, 0),
+		ExpiredAt: time.Unix(1702592000, 0),
+	}
+	query := `INSERT INTO "subscriptions" ("created_at","updated_at","deleted_at","user_id","plan","status","started_at","expired_at","cancelled_at","payment_reference") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING "subscriptions"."id"`
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantID   uint
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+			wantID:  1,
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := SubscriptionStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.CreateSubscription(subscription)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SubscriptionStore.CreateSubscription() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.ID != tt.wantID {
+				t.Errorf("SubscriptionStore.CreateSubscription() id = %v, want %v", got.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestSubscriptionStore_UpdateSubscription(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	cancelledAt := time.Unix(1701000000, 0)
+	subscription := models.Subscription{
+		Model: gorm.Model{
+			ID: 2,
+		},
+		UserID:      1,
+		Plan:        models.PlanPremium,
+		Status:      models.SubscriptionStatusCancelled,
+		StartedAt:   time.Unix(1700000000, 0),
+		ExpiredAt:   time.Unix(1702592000, 0),
+		CancelledAt: &cancelledAt,
+	}
+	query := `UPDATE "subscriptions" SET "updated_at" = $1, "deleted_at" = $2, "user_id" = $3, "plan" = $4, "status" = $5, "started_at" = $6, "expired_at" = $7, "cancelled_at" = $8, "payment_reference" = $9 WHERE "subscriptions"."deleted_at" IS NULL AND "subscriptions"."id" = $10`
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(query)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(query)).
+					WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := SubscriptionStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			if err := store.UpdateSubscription(subscription); (err != nil) != tt.wantErr {
+				t.Errorf("SubscriptionStore.UpdateSubscription() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSubscriptionStore_GetExpiredSubscriptions(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	now := time.Unix(1702592000, 0)
+	query := `SELECT * FROM "subscriptions"  WHERE "subscriptions"."deleted_at" IS NULL AND ((status <> $1 AND expired_at <= $2)) ORDER BY expired_at ASC LIMIT 100`
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     []models.Subscription
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(models.SubscriptionStatusExpired, now).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "plan", "status"}).AddRow(2, 1, models.PlanPlus, models.SubscriptionStatusCancelled))
+			},
+			want: []models.Subscription{
+				{
+					Model: gorm.Model{
+						ID: 2,
+					},
+					UserID: 1,
+					Plan:   models.PlanPlus,
+					Status: models.SubscriptionStatusCancelled,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(fmt.Errorf("some error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := SubscriptionStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.GetExpiredSubscriptions(now, 100)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SubscriptionStore.GetExpiredSubscriptions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SubscriptionStore.GetExpiredSubscriptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionStore_ExpireSubscription(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	now := time.Unix(1700000000, 0)
+	selectQuery := `SELECT * FROM "subscriptions"  WHERE "subscriptions"."deleted_at" IS NULL AND ((id = $1 AND status <> $2)) ORDER BY "subscriptions"."id" ASC LIMIT 1 FOR UPDATE`
+	expireQuery := `UPDATE "subscriptions" SET "status" = $1, "updated_at" = $2 WHERE "subscriptions"."deleted_at" IS NULL AND "subscriptions"."id" = $3`
+	shiftQuery := `UPDATE "subscriptions" SET "expired_at" = expired_at - CAST($1 AS INTERVAL), "started_at" = started_at - CAST($2 AS INTERVAL), "updated_at" = $3 WHERE "subscriptions"."deleted_at" IS NULL AND ((user_id = $4 AND status <> $5 AND started_at >= $6))`
+	rows := func(startedAt, expiredAt time.Time) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "user_id", "status", "started_at", "expired_at"}).
+			AddRow(2, 1, models.SubscriptionStatusActive, startedAt, expiredAt)
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     bool
+		wantErr  bool
+	}{
+		{
+			name: "success flow shift the next period",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WithArgs(2, models.SubscriptionStatusExpired).
+					WillReturnRows(rows(now.Add(-time.Hour), now.Add(2*time.Hour)))
+				mockDB.ExpectExec(regexp.QuoteMeta(expireQuery)).
+					WithArgs(models.SubscriptionStatusExpired, sqlmock.AnyArg(), 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectExec(regexp.QuoteMeta(shiftQuery)).
+					WithArgs("7200000000 microseconds", "7200000000 microseconds", sqlmock.AnyArg(), 1, models.SubscriptionStatusExpired, now.Add(2*time.Hour)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectCommit()
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "success period already passed",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WillReturnRows(rows(now.Add(-2*time.Hour), now.Add(-time.Hour)))
+				mockDB.ExpectExec(regexp.QuoteMeta(expireQuery)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectCommit()
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "success already expired",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mockDB.ExpectRollback()
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "error on select",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "error on shift",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WillReturnRows(rows(now.Add(-time.Hour), now.Add(2*time.Hour)))
+				mockDB.ExpectExec(regexp.QuoteMeta(expireQuery)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectExec(regexp.QuoteMeta(shiftQuery)).
+					WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := SubscriptionStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.ExpireSubscription(2, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SubscriptionStore.ExpireSubscription() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("SubscriptionStore.ExpireSubscription() = %v, want %v", got, tt.want)
+			}
+			if err := mockDB.ExpectationsWereMet(); err != nil {
+				t.Errorf("SubscriptionStore.ExpireSubscription() expectation = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/store/tokencache/store.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTokenCacheStoreMethod is a mock of TokenCacheStoreMethod interface.
+type MockTokenCacheStoreMethod struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenCacheStoreMethodMockRecorder
+}
+
+// MockTokenCacheStoreMethodMockRecorder is the mock recorder for MockTokenCacheStoreMethod.
+type MockTokenCacheStoreMethodMockRecorder struct {
+	mock *MockTokenCacheStoreMethod
+}
+
+// NewMockTokenCacheStoreMethod creates a new mock instance.
+func NewMockTokenCacheStoreMethod(ctrl *gomock.Controller) *MockTokenCacheStoreMethod {
+	mock := &MockTokenCacheStoreMethod{ctrl: ctrl}
+	mock.recorder = &MockTokenCacheStoreMethodMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenCacheStoreMethod) EXPECT() *MockTokenCacheStoreMethodMockRecorder {
+	return m.recorder
+}
+
+// GetClaimsChangedAt mocks base method.
+func (m *MockTokenCacheStoreMethod) GetClaimsChangedAt(userID int) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClaimsChangedAt", userID)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClaimsChangedAt indicates an expected call of GetClaimsChangedAt.
+func (mr *MockTokenCacheStoreMethodMockRecorder) GetClaimsChangedAt(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClaimsChangedAt", reflect.TypeOf((*MockTokenCacheStoreMethod)(nil).GetClaimsChangedAt), userID)
+}
+
+// GetSessionsRevokedAt mocks base method.
+func (m *MockTokenCacheStoreMethod) GetSessionsRevokedAt(userID int) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionsRevokedAt", userID)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionsRevokedAt indicates an expected call of GetSessionsRevokedAt.
+func (mr *MockTokenCacheStoreMethodMockRecorder) GetSessionsRevokedAt(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionsRevokedAt", reflect.TypeOf((*MockTokenCacheStoreMethod)(nil).GetSessionsRevokedAt), userID)
+}
+
+// IsTokenRevoked mocks base method.
+func (m *MockTokenCacheStoreMethod) IsTokenRevoked(tokenID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTokenRevoked", tokenID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTokenRevoked indicates an expected call of IsTokenRevoked.
+func (mr *MockTokenCacheStoreMethodMockRecorder) IsTokenRevoked(tokenID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenRevoked", reflect.TypeOf((*MockTokenCacheStoreMethod)(nil).IsTokenRevoked), tokenID)
+}
+
+// RevokeToken mocks base method.
+func (m *MockTokenCacheStoreMethod) RevokeToken(tokenID string, expiredAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", tokenID, expiredAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockTokenCacheStoreMethodMockRecorder) RevokeToken(tokenID, expiredAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockTokenCacheStoreMethod)(nil).RevokeToken), tokenID, expiredAt)
+}
+
+// SetClaimsChangedAt mocks base method.
+func (m *MockTokenCacheStoreMethod) SetClaimsChangedAt(userID int, changedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetClaimsChangedAt", userID, changedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetClaimsChangedAt indicates an expected call of SetClaimsChangedAt.
+func (mr *MockTokenCacheStoreMethodMockRecorder) SetClaimsChangedAt(userID, changedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetClaimsChangedAt", reflect.TypeOf((*MockTokenCacheStoreMethod)(nil).SetClaimsChangedAt), userID, changedAt)
+}
+
+// SetSessionsRevokedAt mocks base method.
+func (m *MockTokenCacheStoreMethod) SetSessionsRevokedAt(userID int, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSessionsRevokedAt", userID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSessionsRevokedAt indicates an expected call of SetSessionsRevokedAt.
+func (mr *MockTokenCacheStoreMethodMockRecorder) SetSessionsRevokedAt(userID, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSessionsRevokedAt", reflect.TypeOf((*MockTokenCacheStoreMethod)(nil).SetSessionsRevokedAt), userID, revokedAt)
+}
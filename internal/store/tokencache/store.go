@@ -0,0 +1,111 @@
+package tokencache
+
+import (
+	"fmt"
+	"gilsaputro/dating-apps/pkg/redis"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TokenCacheStoreMethod is set of methods for interacting with a token denylist storage system
+type TokenCacheStoreMethod interface {
+	RevokeToken(tokenID string, expiredAt time.Time) (bool, error)
+	IsTokenRevoked(tokenID string) (bool, error)
+	SetClaimsChangedAt(userID int, changedAt time.Time) error
+	GetClaimsChangedAt(userID int) (time.Time, error)
+	SetSessionsRevokedAt(userID int, revokedAt time.Time) error
+	GetSessionsRevokedAt(userID int) (time.Time, error)
+}
+
+// TokenCacheStore is list dependencies token cache store
+type TokenCacheStore struct {
+	rd redis.RedisMethod
+	// accessTokenTTL is lifetime of the access token, the claims change marker is useless after all old access token is expired
+	accessTokenTTL time.Duration
+	// refreshTokenTTL is lifetime of the refresh token, the sessions revoked marker is kept until all old refresh token is expired
+	refreshTokenTTL time.Duration
+}
+
+// NewTokenCacheStore is func to generate TokenCacheStoreMethod interface
+func NewTokenCacheStore(rd redis.RedisMethod, accessTokenTTL time.Duration, refreshTokenTTL time.Duration) TokenCacheStoreMethod {
+	return &TokenCacheStore{
+		rd:              rd,
+		accessTokenTTL:  accessTokenTTL,
+		refreshTokenTTL: refreshTokenTTL,
+	}
+}
+
+const revokedToken string = `RVT:%v` // format RVT:<jti>
+
+// RevokeToken is func to put the token id into denylist until the token is expired, it returns false if the token is already revoked
+func (f *TokenCacheStore) RevokeToken(tokenID string, expiredAt time.Time) (bool, error) {
+	ttl := time.Until(expiredAt)
+	// the expired token is already rejected by the token validation
+	if ttl <= 0 {
+		return true, nil
+	}
+
+	key := fmt.Sprintf(revokedToken, tokenID)
+	return f.rd.SetNX(key, 1, ttl)
+}
+
+// IsTokenRevoked is func to check whether the token id is in denylist
+func (f *TokenCacheStore) IsTokenRevoked(tokenID string) (bool, error) {
+	key := fmt.Sprintf(revokedToken, tokenID)
+	_, err := f.rd.Get(key)
+	if err != nil && strings.Contains(err.Error(), "redis: nil") {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+const claimsChangedAt string = `CCA:%v` // format CCA:<userid>
+
+// SetClaimsChangedAt is func to mark the claims of the user is changed, the access token issued before it must be refreshed
+func (f *TokenCacheStore) SetClaimsChangedAt(userID int, changedAt time.Time) error {
+	key := fmt.Sprintf(claimsChangedAt, userID)
+	return f.rd.Set(key, changedAt.Unix(), f.accessTokenTTL)
+}
+
+// GetClaimsChangedAt is func to get the last time the claims of the user is changed, it is zero when there is no change
+func (f *TokenCacheStore) GetClaimsChangedAt(userID int) (time.Time, error) {
+	return f.getTime(fmt.Sprintf(claimsChangedAt, userID))
+}
+
+const sessionsRevokedAt string = `SRA:%v` // format SRA:<userid>
+
+// SetSessionsRevokedAt is func to revoke all session of the user, the refresh token issued before it can not be used anymore
+func (f *TokenCacheStore) SetSessionsRevokedAt(userID int, revokedAt time.Time) error {
+	key := fmt.Sprintf(sessionsRevokedAt, userID)
+	return f.rd.Set(key, revokedAt.Unix(), f.refreshTokenTTL)
+}
+
+// GetSessionsRevokedAt is func to get the last time all session of the user is revoked, it is zero when there is no revocation
+func (f *TokenCacheStore) GetSessionsRevokedAt(userID int) (time.Time, error) {
+	return f.getTime(fmt.Sprintf(sessionsRevokedAt, userID))
+}
+
+// getTime is func to get unix time stored in the key, it is zero when the key is not exists
+func (f *TokenCacheStore) getTime(key string) (time.Time, error) {
+	c, err := f.rd.Get(key)
+	if err != nil && strings.Contains(err.Error(), "redis: nil") {
+		return time.Time{}, nil
+	}
+
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	unix, err := strconv.ParseInt(c, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(unix, 0), nil
+}
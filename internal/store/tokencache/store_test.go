@@ -0,0 +1,311 @@
+package tokencache
+
+import (
+	"fmt"
+	"gilsaputro/dating-apps/pkg/redis"
+	mock_redis "gilsaputro/dating-apps/pkg/redis/mock"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewTokenCacheStore(t *testing.T) {
+	type args struct {
+		rd              redis.RedisMethod
+		accessTokenTTL  time.Duration
+		refreshTokenTTL time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+		want TokenCacheStoreMethod
+	}{
+		{
+			name: "success flow",
+			args: args{
+				rd:              &redis.RedisClient{},
+				accessTokenTTL:  time.Hour,
+				refreshTokenTTL: 24 * time.Hour,
+			},
+			want: &TokenCacheStore{
+				rd:              &redis.RedisClient{},
+				accessTokenTTL:  time.Hour,
+				refreshTokenTTL: 24 * time.Hour,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewTokenCacheStore(tt.args.rd, tt.args.accessTokenTTL, tt.args.refreshTokenTTL); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewTokenCacheStore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenCacheStore_RevokeToken(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	type args struct {
+		tokenID   string
+		expiredAt time.Time
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		want     bool
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				rd.EXPECT().SetNX("RVT:abc", 1, gomock.Any()).Return(true, nil)
+			},
+			args: args{
+				tokenID:   "abc",
+				expiredAt: time.Now().Add(time.Hour),
+			},
+			want: true,
+		},
+		{
+			name: "already revoked flow",
+			mockFunc: func() {
+				rd.EXPECT().SetNX("RVT:abc", 1, gomock.Any()).Return(false, nil)
+			},
+			args: args{
+				tokenID:   "abc",
+				expiredAt: time.Now().Add(time.Hour),
+			},
+			want: false,
+		},
+		{
+			name:     "expired token flow",
+			mockFunc: func() {},
+			args: args{
+				tokenID:   "abc",
+				expiredAt: time.Now().Add(-time.Hour),
+			},
+			want: true,
+		},
+		{
+			name: "error flow",
+			mockFunc: func() {
+				rd.EXPECT().SetNX("RVT:abc", 1, gomock.Any()).Return(false, fmt.Errorf("some error"))
+			},
+			args: args{
+				tokenID:   "abc",
+				expiredAt: time.Now().Add(time.Hour),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := TokenCacheStore{
+				rd: rd,
+			}
+			tt.mockFunc()
+			got, err := s.RevokeToken(tt.args.tokenID, tt.args.expiredAt)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TokenCacheStore.RevokeToken() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("TokenCacheStore.RevokeToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenCacheStore_IsTokenRevoked(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     bool
+		wantErr  bool
+	}{
+		{
+			name: "revoked flow",
+			mockFunc: func() {
+				rd.EXPECT().Get("RVT:abc").Return("1", nil)
+			},
+			want: true,
+		},
+		{
+			name: "not revoked flow",
+			mockFunc: func() {
+				rd.EXPECT().Get("RVT:abc").Return("", fmt.Errorf("redis: nil"))
+			},
+			want: false,
+		},
+		{
+			name: "error flow",
+			mockFunc: func() {
+				rd.EXPECT().Get("RVT:abc").Return("", fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := TokenCacheStore{
+				rd: rd,
+			}
+			tt.mockFunc()
+			got, err := s.IsTokenRevoked("abc")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TokenCacheStore.IsTokenRevoked() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("TokenCacheStore.IsTokenRevoked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenCacheStore_SetClaimsChangedAt(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	changedAt := time.Unix(1700000000, 0)
+
+	rd.EXPECT().Set("CCA:1", int64(1700000000), 3*time.Hour).Return(nil)
+	s := TokenCacheStore{
+		rd:             rd,
+		accessTokenTTL: 3 * time.Hour,
+	}
+	if err := s.SetClaimsChangedAt(1, changedAt); err != nil {
+		t.Errorf("TokenCacheStore.SetClaimsChangedAt() error = %v", err)
+	}
+}
+
+func TestTokenCacheStore_GetClaimsChangedAt(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     time.Time
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				rd.EXPECT().Get("CCA:1").Return("1700000000", nil)
+			},
+			want: time.Unix(1700000000, 0),
+		},
+		{
+			name: "no change flow",
+			mockFunc: func() {
+				rd.EXPECT().Get("CCA:1").Return("", fmt.Errorf("redis: nil"))
+			},
+			want: time.Time{},
+		},
+		{
+			name: "invalid value flow",
+			mockFunc: func() {
+				rd.EXPECT().Get("CCA:1").Return("abc", nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error flow",
+			mockFunc: func() {
+				rd.EXPECT().Get("CCA:1").Return("", fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := TokenCacheStore{
+				rd: rd,
+			}
+			tt.mockFunc()
+			got, err := s.GetClaimsChangedAt(1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TokenCacheStore.GetClaimsChangedAt() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("TokenCacheStore.GetClaimsChangedAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenCacheStore_SetSessionsRevokedAt(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	revokedAt := time.Unix(1700000000, 0)
+
+	rd.EXPECT().Set("SRA:1", int64(1700000000), 720*time.Hour).Return(nil)
+	s := TokenCacheStore{
+		rd:              rd,
+		refreshTokenTTL: 720 * time.Hour,
+	}
+	if err := s.SetSessionsRevokedAt(1, revokedAt); err != nil {
+		t.Errorf("TokenCacheStore.SetSessionsRevokedAt() error = %v", err)
+	}
+}
+
+func TestTokenCacheStore_GetSessionsRevokedAt(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     time.Time
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				rd.EXPECT().Get("SRA:1").Return("1700000000", nil)
+			},
+			want: time.Unix(1700000000, 0),
+		},
+		{
+			name: "no revocation flow",
+			mockFunc: func() {
+				rd.EXPECT().Get("SRA:1").Return("", fmt.Errorf("redis: nil"))
+			},
+			want: time.Time{},
+		},
+		{
+			name: "error flow",
+			mockFunc: func() {
+				rd.EXPECT().Get("SRA:1").Return("", fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := TokenCacheStore{
+				rd: rd,
+			}
+			tt.mockFunc()
+			got, err := s.GetSessionsRevokedAt(1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TokenCacheStore.GetSessionsRevokedAt() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("TokenCacheStore.GetSessionsRevokedAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/store/twofactor/store.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	models "gilsaputro/dating-apps/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTwoFactorStoreMethod is a mock of TwoFactorStoreMethod interface.
+type MockTwoFactorStoreMethod struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorStoreMethodMockRecorder
+}
+
+// MockTwoFactorStoreMethodMockRecorder is the mock recorder for MockTwoFactorStoreMethod.
+type MockTwoFactorStoreMethodMockRecorder struct {
+	mock *MockTwoFactorStoreMethod
+}
+
+// NewMockTwoFactorStoreMethod creates a new mock instance.
+func NewMockTwoFactorStoreMethod(ctrl *gomock.Controller) *MockTwoFactorStoreMethod {
+	mock := &MockTwoFactorStoreMethod{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorStoreMethodMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactorStoreMethod) EXPECT() *MockTwoFactorStoreMethodMockRecorder {
+	return m.recorder
+}
+
+// DeleteTwoFactor mocks base method.
+func (m *MockTwoFactorStoreMethod) DeleteTwoFactor(userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTwoFactor", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTwoFactor indicates an expected call of DeleteTwoFactor.
+func (mr *MockTwoFactorStoreMethodMockRecorder) DeleteTwoFactor(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTwoFactor", reflect.TypeOf((*MockTwoFactorStoreMethod)(nil).DeleteTwoFactor), userID)
+}
+
+// GetTwoFactor mocks base method.
+func (m *MockTwoFactorStoreMethod) GetTwoFactor(userID int) (models.UserTwoFactor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTwoFactor", userID)
+	ret0, _ := ret[0].(models.UserTwoFactor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTwoFactor indicates an expected call of GetTwoFactor.
+func (mr *MockTwoFactorStoreMethodMockRecorder) GetTwoFactor(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTwoFactor", reflect.TypeOf((*MockTwoFactorStoreMethod)(nil).GetTwoFactor), userID)
+}
+
+// SaveTwoFactor mocks base method.
+func (m *MockTwoFactorStoreMethod) SaveTwoFactor(twoFactor models.UserTwoFactor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTwoFactor", twoFactor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTwoFactor indicates an expected call of SaveTwoFactor.
+func (mr *MockTwoFactorStoreMethodMockRecorder) SaveTwoFactor(twoFactor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTwoFactor", reflect.TypeOf((*MockTwoFactorStoreMethod)(nil).SaveTwoFactor), twoFactor)
+}
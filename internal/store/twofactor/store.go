@@ -0,0 +1,77 @@
+package twofactor
+
+import (
+	"errors"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/postgres"
+
+	"github.com/jinzhu/gorm"
+)
+
+// TwoFactorStoreMethod is set of methods for interacting with a two factor authentication storage system
+type TwoFactorStoreMethod interface {
+	GetTwoFactor(userID int) (models.UserTwoFactor, error)
+	SaveTwoFactor(twoFactor models.UserTwoFactor) error
+	DeleteTwoFactor(userID int) error
+}
+
+// TwoFactorStore is list dependencies two factor store
+type TwoFactorStore struct {
+	pg postgres.PostgresMethod
+}
+
+// NewTwoFactorStore is func to generate TwoFactorStoreMethod interface
+func NewTwoFactorStore(pg postgres.PostgresMethod) TwoFactorStoreMethod {
+	return &TwoFactorStore{
+		pg: pg,
+	}
+}
+
+func (t *TwoFactorStore) getDB() (*gorm.DB, error) {
+	db := t.pg.GetDB()
+	if db == nil {
+		return nil, errors.New("Database Client is not init")
+	}
+
+	return db, nil
+}
+
+// GetTwoFactor is func to get two factor setting of the user, it will return empty data if the user never enroll
+func (t *TwoFactorStore) GetTwoFactor(userID int) (models.UserTwoFactor, error) {
+	db, err := t.getDB()
+	if err != nil {
+		return models.UserTwoFactor{}, err
+	}
+
+	var twoFactor models.UserTwoFactor
+	err = db.Where("user_id = ?", userID).First(&twoFactor).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return models.UserTwoFactor{}, nil
+	}
+
+	return twoFactor, err
+}
+
+// SaveTwoFactor is func to create the two factor setting or update it if the id is set
+func (t *TwoFactorStore) SaveTwoFactor(twoFactor models.UserTwoFactor) error {
+	db, err := t.getDB()
+	if err != nil {
+		return err
+	}
+
+	if twoFactor.ID == 0 {
+		return db.Create(&twoFactor).Error
+	}
+
+	return db.Save(&twoFactor).Error
+}
+
+// DeleteTwoFactor is func to remove the two factor setting of the user permanently, so the user can enroll again
+func (t *TwoFactorStore) DeleteTwoFactor(userID int) error {
+	db, err := t.getDB()
+	if err != nil {
+		return err
+	}
+
+	return db.Unscoped().Where("user_id = ?", userID).Delete(models.UserTwoFactor{}).Error
+}
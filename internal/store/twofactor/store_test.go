@@ -0,0 +1,272 @@
+package twofactor
+
+import (
+	"database/sql"
+	"fmt"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/postgres"
+	mock_postgres "gilsaputro/dating-apps/pkg/postgres/mock"
+	"log"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestNewTwoFactorStore(t *testing.T) {
+	type args struct {
+		pg postgres.PostgresMethod
+	}
+	tests := []struct {
+		name string
+		args args
+		want TwoFactorStoreMethod
+	}{
+		{
+			name: "success flow",
+			args: args{
+				pg: &postgres.Client{},
+			},
+			want: &TwoFactorStore{
+				pg: &postgres.Client{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewTwoFactorStore(tt.args.pg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewTwoFactorStore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func InitDBsMockupStat() (*sql.DB, sqlmock.Sqlmock, *gorm.DB) {
+	db, mock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open("postgres", db)
+	gormDB.LogMode(true)
+	gormDB.SetLogger(log.New(os.Stdout, "\n", 0))
+	gormDB.Debug()
+	return db, mock, gormDB
+}
+
+func TestTwoFactorStore_GetTwoFactor(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     models.UserTwoFactor
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_two_factors"  WHERE "user_two_factors"."deleted_at" IS NULL AND ((user_id = $1)) ORDER BY "user_two_factors"."id" ASC LIMIT 1`)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "secret", "recovery_codes"}).AddRow(1, 1, "secret", "a,b"))
+			},
+			want: models.UserTwoFactor{
+				Model: gorm.Model{
+					ID: 1,
+				},
+				UserID:        1,
+				Secret:        "secret",
+				RecoveryCodes: "a,b",
+			},
+			wantErr: false,
+		},
+		{
+			name: "success never enroll",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_two_factors"  WHERE "user_two_factors"."deleted_at" IS NULL AND ((user_id = $1)) ORDER BY "user_two_factors"."id" ASC LIMIT 1`)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			want:    models.UserTwoFactor{},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_two_factors"  WHERE "user_two_factors"."deleted_at" IS NULL AND ((user_id = $1)) ORDER BY "user_two_factors"."id" ASC LIMIT 1`)).
+					WillReturnError(fmt.Errorf("some error"))
+			},
+			want:    models.UserTwoFactor{},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    models.UserTwoFactor{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := TwoFactorStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.GetTwoFactor(1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TwoFactorStore.GetTwoFactor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TwoFactorStore.GetTwoFactor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTwoFactorStore_SaveTwoFactor(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name      string
+		twoFactor models.UserTwoFactor
+		mockFunc  func()
+		wantErr   bool
+	}{
+		{
+			name: "success create flow",
+			twoFactor: models.UserTwoFactor{
+				UserID: 1,
+				Secret: "secret",
+			},
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_two_factors" ("created_at","updated_at","deleted_at","user_id","secret","enabled_at","recovery_codes") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "user_two_factors"."id"`)).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "success update flow",
+			twoFactor: models.UserTwoFactor{
+				Model: gorm.Model{
+					ID: 1,
+				},
+				UserID: 1,
+				Secret: "secret",
+			},
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "user_two_factors" SET "updated_at" = $1, "deleted_at" = $2, "user_id" = $3, "secret" = $4, "enabled_at" = $5, "recovery_codes" = $6 WHERE "user_two_factors"."deleted_at" IS NULL AND "user_two_factors"."id" = $7`)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			twoFactor: models.UserTwoFactor{
+				UserID: 1,
+				Secret: "secret",
+			},
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_two_factors" ("created_at","updated_at","deleted_at","user_id","secret","enabled_at","recovery_codes") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "user_two_factors"."id"`)).
+					WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := TwoFactorStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			if err := store.SaveTwoFactor(tt.twoFactor); (err != nil) != tt.wantErr {
+				t.Errorf("TwoFactorStore.SaveTwoFactor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTwoFactorStore_DeleteTwoFactor(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`DELETE FROM "user_two_factors"  WHERE (user_id = $1)`)).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mockDB.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`DELETE FROM "user_two_factors"  WHERE (user_id = $1)`)).
+					WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := TwoFactorStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			if err := store.DeleteTwoFactor(1); (err != nil) != tt.wantErr {
+				t.Errorf("TwoFactorStore.DeleteTwoFactor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@
 package mock
 
 import (
+	user "gilsaputro/dating-apps/internal/store/user"
 	models "gilsaputro/dating-apps/models"
 	reflect "reflect"
 
@@ -77,6 +78,36 @@ func (mr *MockUserStoreMethodMockRecorder) DeleteUser(userid interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserStoreMethod)(nil).DeleteUser), userid)
 }
 
+// GetCandidateList mocks base method.
+func (m *MockUserStoreMethod) GetCandidateList(filter user.CandidateFilter) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCandidateList", filter)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCandidateList indicates an expected call of GetCandidateList.
+func (mr *MockUserStoreMethodMockRecorder) GetCandidateList(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandidateList", reflect.TypeOf((*MockUserStoreMethod)(nil).GetCandidateList), filter)
+}
+
+// GetUserInfoByEmail mocks base method.
+func (m *MockUserStoreMethod) GetUserInfoByEmail(email string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserInfoByEmail", email)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserInfoByEmail indicates an expected call of GetUserInfoByEmail.
+func (mr *MockUserStoreMethodMockRecorder) GetUserInfoByEmail(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserInfoByEmail", reflect.TypeOf((*MockUserStoreMethod)(nil).GetUserInfoByEmail), email)
+}
+
 // GetUserInfoByID mocks base method.
 func (m *MockUserStoreMethod) GetUserInfoByID(userid int) (models.User, error) {
 	m.ctrl.T.Helper()
@@ -107,6 +138,63 @@ func (mr *MockUserStoreMethodMockRecorder) GetUserInfoByUsername(username interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserInfoByUsername", reflect.TypeOf((*MockUserStoreMethod)(nil).GetUserInfoByUsername), username)
 }
 
+// GetUserListByIDs mocks base method.
+func (m *MockUserStoreMethod) GetUserListByIDs(userids []int) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserListByIDs", userids)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserListByIDs indicates an expected call of GetUserListByIDs.
+func (mr *MockUserStoreMethodMockRecorder) GetUserListByIDs(userids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserListByIDs", reflect.TypeOf((*MockUserStoreMethod)(nil).GetUserListByIDs), userids)
+}
+
+// UpdateEmailVerified mocks base method.
+func (m *MockUserStoreMethod) UpdateEmailVerified(userid int, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEmailVerified", userid, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEmailVerified indicates an expected call of UpdateEmailVerified.
+func (mr *MockUserStoreMethodMockRecorder) UpdateEmailVerified(userid, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmailVerified", reflect.TypeOf((*MockUserStoreMethod)(nil).UpdateEmailVerified), userid, email)
+}
+
+// UpdateIdentityVerified mocks base method.
+func (m *MockUserStoreMethod) UpdateIdentityVerified(userid int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIdentityVerified", userid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateIdentityVerified indicates an expected call of UpdateIdentityVerified.
+func (mr *MockUserStoreMethodMockRecorder) UpdateIdentityVerified(userid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIdentityVerified", reflect.TypeOf((*MockUserStoreMethod)(nil).UpdateIdentityVerified), userid)
+}
+
+// UpdatePlan mocks base method.
+func (m *MockUserStoreMethod) UpdatePlan(userid int, plan string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePlan", userid, plan)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePlan indicates an expected call of UpdatePlan.
+func (mr *MockUserStoreMethodMockRecorder) UpdatePlan(userid, plan interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlan", reflect.TypeOf((*MockUserStoreMethod)(nil).UpdatePlan), userid, plan)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUserStoreMethod) UpdateUser(userinfo models.User) error {
 	m.ctrl.T.Helper()
@@ -2,10 +2,13 @@ package user
 
 import (
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/jinzhu/gorm"
 
 	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/geo"
 	"gilsaputro/dating-apps/pkg/postgres"
 )
 
@@ -13,12 +16,42 @@ import (
 type UserStoreMethod interface {
 	CreateUser(userinfo models.User) error
 	UpdateUser(userinfo models.User) error
+	UpdatePlan(userid int, plan string) error
+	UpdateEmailVerified(userid int, email string) error
+	UpdateIdentityVerified(userid int) error
 	DeleteUser(userid int) error
 	GetUserInfoByUsername(username string) (models.User, error)
+	GetUserInfoByEmail(email string) (models.User, error)
 	GetUserInfoByID(userid int) (models.User, error)
 	Count() (int, error)
+	GetCandidateList(filter CandidateFilter) ([]models.User, error)
+	GetUserListByIDs(userids []int) ([]models.User, error)
 }
 
+// CandidateFilter is list parameter to query partner candidates
+type CandidateFilter struct {
+	ExcludeIDs []int
+	Limit      int
+	// Genders, MinBirthdate and MaxBirthdate is the user preference that must be satisfied by the candidate
+	Genders      []string
+	MinBirthdate *time.Time
+	MaxBirthdate *time.Time
+	// Gender and Age is the user profile that must satisfy the candidate preference
+	Gender string
+	Age    int
+	// Area is the bounding box of user max distance preference, nil means no distance limit
+	Area *geo.Box
+	// Location is the user location to check the exact distance, nil means the user location is unknown
+	Location    *geo.Point
+	MaxDistance int
+	// SuperLikerIDs and LikerIDs is the user who already like the user, they are ranked first before the limit
+	SuperLikerIDs []int
+	LikerIDs      []int
+}
+
+// distanceQuery is the haversine distance in kilometer between the candidate and the point (latitude, latitude, longitude), same as geo.Distance
+const distanceQuery = "(12742 * ASIN(LEAST(1, SQRT(POWER(SIN(RADIANS(latitude - ?) / 2), 2) + COS(RADIANS(?)) * COS(RADIANS(latitude)) * POWER(SIN(RADIANS(longitude - ?) / 2), 2)))))"
+
 // UserStore is list dependencies user store
 type UserStore struct {
 	pg postgres.PostgresMethod
@@ -50,7 +83,8 @@ func (u *UserStore) CreateUser(userinfo models.User) error {
 	return db.Create(&userinfo).Error
 }
 
-// UpdateUser is func to edit / update user info into database
+// UpdateUser is func to edit / update user info into database, the plan and the verified flags is not updated
+// because they are changed by other process at the same time, use the single column update func instead
 func (u *UserStore) UpdateUser(userinfo models.User) error {
 	db, err := u.getDB()
 	if err != nil {
@@ -63,12 +97,85 @@ func (u *UserStore) UpdateUser(userinfo models.User) error {
 		return err
 	}
 
+	omits := []string{"plan", "is_identity_verified", "is_email_verified"}
+	// the new email must be verified again, so the flag is reset together with the email
+	if user.Email != userinfo.Email {
+		user.IsEmailVerified = false
+		omits = omits[:2]
+	}
+
 	user.Password = userinfo.Password
 	user.Fullname = userinfo.Fullname
 	user.Email = userinfo.Email
-	user.IsVerified = userinfo.IsVerified
+	user.Birthdate = userinfo.Birthdate
+	user.Gender = userinfo.Gender
+	user.InterestedIn = userinfo.InterestedIn
+	user.PrefAgeMin = userinfo.PrefAgeMin
+	user.PrefAgeMax = userinfo.PrefAgeMax
+	user.Latitude = userinfo.Latitude
+	user.Longitude = userinfo.Longitude
+	user.LocationUpdatedAt = userinfo.LocationUpdatedAt
+	user.PrefMaxDistance = userinfo.PrefMaxDistance
+
+	return db.Omit(omits...).Save(&user).Error
+}
+
+// UpdatePlan is func to update only the plan of the user into database
+func (u *UserStore) UpdatePlan(userid int, plan string) error {
+	db, err := u.getDB()
+	if err != nil {
+		return err
+	}
 
-	return db.Save(&user).Error
+	res := db.Model(&models.User{}).Where("id = ?", userid).Update("plan", plan)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// UpdateEmailVerified is func to set the email of the user as verified, it is not found
+// when the email is already changed after the verification is sent to the old email
+func (u *UserStore) UpdateEmailVerified(userid int, email string) error {
+	db, err := u.getDB()
+	if err != nil {
+		return err
+	}
+
+	res := db.Model(&models.User{}).Where("id = ? AND email = ?", userid, email).Update("is_email_verified", true)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// UpdateIdentityVerified is func to set the identity of the user as verified
+func (u *UserStore) UpdateIdentityVerified(userid int) error {
+	db, err := u.getDB()
+	if err != nil {
+		return err
+	}
+
+	res := db.Model(&models.User{}).Where("id = ?", userid).Update("is_identity_verified", true)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
 }
 
 // GetUserID is func to get user id by username and password
@@ -86,6 +193,21 @@ func (u *UserStore) GetUserInfoByUsername(username string) (models.User, error)
 	return user, nil
 }
 
+// GetUserInfoByEmail is func to get the oldest user info by email on database, the user with verified email is returned first
+func (u *UserStore) GetUserInfoByEmail(email string) (models.User, error) {
+	var user models.User
+	db, err := u.getDB()
+	if err != nil {
+		return models.User{}, err
+	}
+
+	if err := db.Where("LOWER(email) = LOWER(?)", email).Order("is_email_verified DESC").First(&user).Error; err != nil {
+		return models.User{}, err
+	}
+
+	return user, nil
+}
+
 // DeleteUser is func to delete user info on database
 func (u *UserStore) DeleteUser(userid int) error {
 	db, err := u.getDB()
@@ -132,3 +254,110 @@ func (u *UserStore) Count() (int, error) {
 
 	return count, nil
 }
+
+// GetCandidateList is func to get active users that can be offered as partner candidates
+func (u *UserStore) GetCandidateList(filter CandidateFilter) ([]models.User, error) {
+	db, err := u.getDB()
+	if err != nil {
+		return nil, err
+	}
+
+	query := db.Model(&models.User{})
+	if len(filter.ExcludeIDs) > 0 {
+		query = query.Where("id NOT IN (?)", filter.ExcludeIDs)
+	}
+
+	if len(filter.Genders) > 0 {
+		query = query.Where("gender IN (?)", filter.Genders)
+	}
+
+	if filter.MinBirthdate != nil {
+		query = query.Where("birthdate > ?", *filter.MinBirthdate)
+	}
+
+	if filter.MaxBirthdate != nil {
+		query = query.Where("birthdate <= ?", *filter.MaxBirthdate)
+	}
+
+	if filter.Area != nil {
+		query = query.Where("location_updated_at IS NOT NULL AND latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ?",
+			filter.Area.MinLatitude, filter.Area.MaxLatitude, filter.Area.MinLongitude, filter.Area.MaxLongitude)
+	}
+
+	// the exact distance is checked before the limit so the out of range candidate does not take the feed slot
+	if filter.Location != nil {
+		lat, lng := filter.Location.Latitude, filter.Location.Longitude
+		if filter.MaxDistance > 0 {
+			query = query.Where("location_updated_at IS NOT NULL AND "+distanceQuery+" <= ?", lat, lat, lng, filter.MaxDistance)
+		}
+		query = query.Where("(COALESCE(pref_max_distance, 0) = 0 OR (location_updated_at IS NOT NULL AND "+distanceQuery+" <= pref_max_distance))", lat, lat, lng)
+	} else {
+		// the distance is unknown so the candidate max distance preference cannot be satisfied
+		query = query.Where("COALESCE(pref_max_distance, 0) = 0")
+	}
+
+	// candidate without gender preference accept all gender
+	if len(filter.Gender) > 0 {
+		query = query.Where("(COALESCE(interested_in, '') = '' OR (',' || interested_in || ',') LIKE ?)", "%,"+filter.Gender+",%")
+	} else {
+		query = query.Where("COALESCE(interested_in, '') = ''")
+	}
+
+	// candidate without age preference accept all age
+	if filter.Age > 0 {
+		query = query.Where("COALESCE(pref_age_min, 0) <= ? AND (COALESCE(pref_age_max, 0) = 0 OR pref_age_max >= ?)", filter.Age, filter.Age)
+	} else {
+		query = query.Where("COALESCE(pref_age_min, 0) = 0 AND COALESCE(pref_age_max, 0) = 0")
+	}
+
+	// the user who already like the user is ranked before the limit so they are not dropped from the feed
+	if len(filter.SuperLikerIDs) > 0 {
+		query = query.Order(rankIDsFirst(filter.SuperLikerIDs))
+	}
+
+	if len(filter.LikerIDs) > 0 {
+		query = query.Order(rankIDsFirst(filter.LikerIDs))
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	result := []models.User{}
+	if err := query.Order("updated_at DESC").Find(&result).Error; err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetUserListByIDs is func to get list user info by list of id on database
+func (u *UserStore) GetUserListByIDs(userids []int) ([]models.User, error) {
+	db, err := u.getDB()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []models.User{}
+	if len(userids) == 0 {
+		return result, nil
+	}
+
+	if err := db.Where("id IN (?)", userids).Find(&result).Error; err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// rankIDsFirst is func to generate the order that put the user in the given ids first
+func rankIDsFirst(ids []int) *gorm.SqlExpr {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	return gorm.Expr("CASE WHEN id IN ("+strings.Join(placeholders, ",")+") THEN 0 ELSE 1 END", args...)
+}
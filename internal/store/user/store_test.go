@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/geo"
 	"gilsaputro/dating-apps/pkg/postgres"
 	mock_postgres "gilsaputro/dating-apps/pkg/postgres/mock"
 	"log"
@@ -11,6 +12,7 @@ import (
 	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/jinzhu/gorm"
@@ -72,15 +74,15 @@ func TestUserStore_CreateUser(t *testing.T) {
 			mockFunc: func() {
 				pg.EXPECT().GetDB().Return(gormDB)
 				mockDB.ExpectBegin()
-				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "users" ("created_at","updated_at","deleted_at","username","password","fullname","email","is_verified") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "users"."id"`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "users" ("created_at","updated_at","deleted_at","username","password","fullname","email","plan","birthdate","gender","interested_in","pref_age_min","pref_age_max","latitude","longitude","location_updated_at","pref_max_distance","is_admin","is_email_verified","is_identity_verified") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20) RETURNING "users"."id"`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 				mockDB.ExpectCommit()
 			},
 			args: models.User{
-				Username:   "abc",
-				Password:   "password_hashed",
-				Fullname:   "abc_a",
-				Email:      "abc@dev.com",
-				IsVerified: false,
+				Username: "abc",
+				Password: "password_hashed",
+				Fullname: "abc_a",
+				Email:    "abc@dev.com",
+				Plan:     models.PlanFree,
 			},
 			wantErr: false,
 		},
@@ -89,15 +91,15 @@ func TestUserStore_CreateUser(t *testing.T) {
 			mockFunc: func() {
 				pg.EXPECT().GetDB().Return(gormDB)
 				mockDB.ExpectBegin()
-				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "users" ("created_at","updated_at","deleted_at","username","password","fullname","email","is_verified") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "users"."id"`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "users" ("created_at","updated_at","deleted_at","username","password","fullname","email","plan","birthdate","gender","interested_in","pref_age_min","pref_age_max","latitude","longitude","location_updated_at","pref_max_distance","is_admin","is_email_verified","is_identity_verified") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20) RETURNING "users"."id"`)).WillReturnError(fmt.Errorf("some error"))
 				mockDB.ExpectCommit()
 			},
 			args: models.User{
-				Username:   "abc",
-				Password:   "password_hashed",
-				Fullname:   "abc_a",
-				Email:      "abc@dev.com",
-				IsVerified: false,
+				Username: "abc",
+				Password: "password_hashed",
+				Fullname: "abc_a",
+				Email:    "abc@dev.com",
+				Plan:     models.PlanFree,
 			},
 			wantErr: true,
 		},
@@ -107,11 +109,11 @@ func TestUserStore_CreateUser(t *testing.T) {
 				pg.EXPECT().GetDB().Return(nil)
 			},
 			args: models.User{
-				Username:   "abc",
-				Password:   "password_hashed",
-				Fullname:   "abc_a",
-				Email:      "abc@dev.com",
-				IsVerified: false,
+				Username: "abc",
+				Password: "password_hashed",
+				Fullname: "abc_a",
+				Email:    "abc@dev.com",
+				Plan:     models.PlanFree,
 			},
 			wantErr: true,
 		},
@@ -155,23 +157,48 @@ func TestUserStore_UpdateUser(t *testing.T) {
 		wantErr  bool
 	}{
 		{
-			name: "success",
+			name: "success changed email reset the verified flag",
 			mockFunc: func() {
 				pg.EXPECT().GetDB().Return(gormDB)
 				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."deleted_at" IS NULL AND ((username = $1 AND id = $2)) ORDER BY "users"."id" ASC LIMIT 1`)).WillReturnRows(expectedRows)
 				mockDB.ExpectBegin()
-				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "updated_at" = $1, "deleted_at" = $2, "username" = $3, "password" = $4, "fullname" = $5, "email" = $6, "is_verified" = $7 WHERE "users"."deleted_at" IS NULL AND "users"."id" = $8`)).WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "updated_at" = $1, "deleted_at" = $2, "username" = $3, "password" = $4, "fullname" = $5, "email" = $6, "birthdate" = $7, "gender" = $8, "interested_in" = $9, "pref_age_min" = $10, "pref_age_max" = $11, "latitude" = $12, "longitude" = $13, "location_updated_at" = $14, "pref_max_distance" = $15, "is_admin" = $16, "is_email_verified" = $17 WHERE "users"."deleted_at" IS NULL AND "users"."id" = $18`)).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "abc", "password_hashed", "abc_a", "abc@dev.com", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), false, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
 				mockDB.ExpectCommit()
 			},
 			args: models.User{
 				Model: gorm.Model{
 					ID: 1,
 				},
-				Username:   "abc",
-				Password:   "password_hashed",
-				Fullname:   "abc_a",
-				Email:      "abc@dev.com",
-				IsVerified: true,
+				Username: "abc",
+				Password: "password_hashed",
+				Fullname: "abc_a",
+				Email:    "abc@dev.com",
+				Plan:     models.PlanPremium,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success same email keep the verified flag",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."deleted_at" IS NULL AND ((username = $1 AND id = $2)) ORDER BY "users"."id" ASC LIMIT 1`)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "email", "is_email_verified"}).AddRow(1, "abc", "abc@dev.com", true))
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "updated_at" = $1, "deleted_at" = $2, "username" = $3, "password" = $4, "fullname" = $5, "email" = $6, "birthdate" = $7, "gender" = $8, "interested_in" = $9, "pref_age_min" = $10, "pref_age_max" = $11, "latitude" = $12, "longitude" = $13, "location_updated_at" = $14, "pref_max_distance" = $15, "is_admin" = $16 WHERE "users"."deleted_at" IS NULL AND "users"."id" = $17`)).WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectCommit()
+			},
+			args: models.User{
+				Model: gorm.Model{
+					ID: 1,
+				},
+				Username:           "abc",
+				Fullname:           "abc_a",
+				Email:              "abc@dev.com",
+				Plan:               models.PlanFree,
+				IsEmailVerified:    false,
+				IsIdentityVerified: true,
 			},
 			wantErr: false,
 		},
@@ -181,7 +208,7 @@ func TestUserStore_UpdateUser(t *testing.T) {
 				pg.EXPECT().GetDB().Return(gormDB)
 				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."deleted_at" IS NULL AND ((username = $1 AND id = $2)) ORDER BY "users"."id" ASC LIMIT 1`)).WillReturnRows(expectedRows)
 				mockDB.ExpectBegin()
-				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "updated_at" = $1, "deleted_at" = $2, "username" = $3, "password" = $4, "fullname" = $5, "email" = $6, "is_verified" = $7 WHERE "users"."deleted_at" IS NULL AND "users"."id" = $8`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "updated_at" = $1, "deleted_at" = $2, "username" = $3, "password" = $4, "fullname" = $5, "email" = $6, "birthdate" = $7, "gender" = $8, "interested_in" = $9, "pref_age_min" = $10, "pref_age_max" = $11, "latitude" = $12, "longitude" = $13, "location_updated_at" = $14, "pref_max_distance" = $15, "is_admin" = $16, "is_email_verified" = $17 WHERE "users"."deleted_at" IS NULL AND "users"."id" = $18`)).WillReturnError(fmt.Errorf("some error"))
 			},
 			args: models.User{
 				Model: gorm.Model{
@@ -241,6 +268,251 @@ func TestUserStore_UpdateUser(t *testing.T) {
 	}
 }
 
+func TestUserStore_UpdatePlan(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	type args struct {
+		userid int
+		plan   string
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "plan" = $1, "updated_at" = $2 WHERE "users"."deleted_at" IS NULL AND ((id = $3))`)).WithArgs(models.PlanPremium, sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mockDB.ExpectCommit()
+			},
+			args: args{
+				userid: 1,
+				plan:   models.PlanPremium,
+			},
+			wantErr: false,
+		},
+		{
+			name: "user not found",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "plan" = $1, "updated_at" = $2 WHERE "users"."deleted_at" IS NULL AND ((id = $3))`)).WillReturnResult(sqlmock.NewResult(0, 0))
+				mockDB.ExpectCommit()
+			},
+			args: args{
+				userid: 1,
+				plan:   models.PlanPremium,
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed update",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "plan" = $1, "updated_at" = $2 WHERE "users"."deleted_at" IS NULL AND ((id = $3))`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			args: args{
+				userid: 1,
+				plan:   models.PlanPremium,
+			},
+			wantErr: true,
+		},
+		{
+			name: "nil database",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := UserStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			if err := service.UpdatePlan(tt.args.userid, tt.args.plan); (err != nil) != tt.wantErr {
+				t.Errorf("UserStore.UpdatePlan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mockDB.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestUserStore_UpdateEmailVerified(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	type args struct {
+		userid int
+		email  string
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "is_email_verified" = $1, "updated_at" = $2 WHERE "users"."deleted_at" IS NULL AND ((id = $3 AND email = $4))`)).WithArgs(true, sqlmock.AnyArg(), 1, "abc@dev.com").WillReturnResult(sqlmock.NewResult(0, 1))
+				mockDB.ExpectCommit()
+			},
+			args: args{
+				userid: 1,
+				email:  "abc@dev.com",
+			},
+			wantErr: false,
+		},
+		{
+			name: "user not found",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "is_email_verified" = $1, "updated_at" = $2 WHERE "users"."deleted_at" IS NULL AND ((id = $3 AND email = $4))`)).WillReturnResult(sqlmock.NewResult(0, 0))
+				mockDB.ExpectCommit()
+			},
+			args: args{
+				userid: 1,
+				email:  "abc@dev.com",
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed update",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "is_email_verified" = $1, "updated_at" = $2 WHERE "users"."deleted_at" IS NULL AND ((id = $3 AND email = $4))`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			args: args{
+				userid: 1,
+				email:  "abc@dev.com",
+			},
+			wantErr: true,
+		},
+		{
+			name: "nil database",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := UserStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			if err := service.UpdateEmailVerified(tt.args.userid, tt.args.email); (err != nil) != tt.wantErr {
+				t.Errorf("UserStore.UpdateEmailVerified() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mockDB.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestUserStore_UpdateIdentityVerified(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	type args struct {
+		userid int
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "is_identity_verified" = $1, "updated_at" = $2 WHERE "users"."deleted_at" IS NULL AND ((id = $3))`)).WithArgs(true, sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mockDB.ExpectCommit()
+			},
+			args: args{
+				userid: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "user not found",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "is_identity_verified" = $1, "updated_at" = $2 WHERE "users"."deleted_at" IS NULL AND ((id = $3))`)).WillReturnResult(sqlmock.NewResult(0, 0))
+				mockDB.ExpectCommit()
+			},
+			args: args{
+				userid: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed update",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "is_identity_verified" = $1, "updated_at" = $2 WHERE "users"."deleted_at" IS NULL AND ((id = $3))`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			args: args{
+				userid: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "nil database",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := UserStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			if err := service.UpdateIdentityVerified(tt.args.userid); (err != nil) != tt.wantErr {
+				t.Errorf("UserStore.UpdateIdentityVerified() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mockDB.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
 func TestUserStore_GetUserInfoByUsername(t *testing.T) {
 	db, mockDB, gormDB := InitDBsMockupStat()
 	defer db.Close()
@@ -323,6 +595,88 @@ func TestUserStore_GetUserInfoByUsername(t *testing.T) {
 	}
 }
 
+func TestUserStore_GetUserInfoByEmail(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	var userDataMock = &models.User{
+		Model: gorm.Model{
+			ID: 1,
+		},
+		Username: "abc",
+		Password: "pas1",
+		Fullname: "full",
+		Email:    "email",
+	}
+	var expectedRows = sqlmock.NewRows([]string{"id", "username", "password", "fullname", "email"}).
+		AddRow(userDataMock.ID, userDataMock.Username, userDataMock.Password, userDataMock.Fullname, userDataMock.Email)
+
+	tests := []struct {
+		name     string
+		email    string
+		mockFunc func()
+		want     models.User
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."deleted_at" IS NULL AND ((LOWER(email) = LOWER($1))) ORDER BY is_email_verified DESC,"users"."id" ASC LIMIT 1`)).WillReturnRows(expectedRows)
+			},
+			email: "email",
+			want: models.User{
+				Model: gorm.Model{
+					ID: 1,
+				},
+				Username: "abc",
+				Password: "pas1",
+				Fullname: "full",
+				Email:    "email",
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed get data",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."deleted_at" IS NULL AND ((LOWER(email) = LOWER($1))) ORDER BY is_email_verified DESC,"users"."id" ASC LIMIT 1`)).WillReturnError(fmt.Errorf("some error"))
+			},
+			email:   "email",
+			want:    models.User{},
+			wantErr: true,
+		},
+		{
+			name: "nil database",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			email:   "email",
+			want:    models.User{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := UserStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := service.GetUserInfoByEmail(tt.email)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UserStore.GetUserInfoByEmail() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UserStore.GetUserInfoByEmail() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestUserStore_DeleteUser(t *testing.T) {
 	db, mockDB, gormDB := InitDBsMockupStat()
 	defer db.Close()
@@ -518,3 +872,178 @@ func TestUserStore_Count(t *testing.T) {
 		})
 	}
 }
+
+func TestUserStore_GetCandidateList(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	var expectedRows = sqlmock.NewRows([]string{"id", "username", "fullname"}).
+		AddRow(2, "abc", "full")
+	minBirthdate := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	maxBirthdate := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		filter   CandidateFilter
+		mockFunc func()
+		want     []models.User
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			filter: CandidateFilter{
+				ExcludeIDs:   []int{1, 3},
+				Limit:        10,
+				Genders:      []string{models.GenderFemale},
+				MinBirthdate: &minBirthdate,
+				MaxBirthdate: &maxBirthdate,
+				Gender:       models.GenderMale,
+				Age:          25,
+				Area: &geo.Box{
+					MinLatitude:  -7,
+					MaxLatitude:  -6,
+					MinLongitude: 106,
+					MaxLongitude: 107,
+				},
+				Location:      &geo.Point{Latitude: -6.5, Longitude: 106.5},
+				MaxDistance:   50,
+				SuperLikerIDs: []int{4},
+				LikerIDs:      []int{5, 6},
+			},
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				distance := "(12742 * ASIN(LEAST(1, SQRT(POWER(SIN(RADIANS(latitude - $%d) / 2), 2) + COS(RADIANS($%d)) * COS(RADIANS(latitude)) * POWER(SIN(RADIANS(longitude - $%d) / 2), 2)))))"
+				query := `SELECT * FROM "users" WHERE "users"."deleted_at" IS NULL AND ((id NOT IN ($1,$2)) AND (gender IN ($3)) AND (birthdate > $4) AND (birthdate <= $5) AND (location_updated_at IS NOT NULL AND latitude BETWEEN $6 AND $7 AND longitude BETWEEN $8 AND $9)` +
+					` AND (location_updated_at IS NOT NULL AND ` + fmt.Sprintf(distance, 10, 11, 12) + ` <= $13)` +
+					` AND ((COALESCE(pref_max_distance, 0) = 0 OR (location_updated_at IS NOT NULL AND ` + fmt.Sprintf(distance, 14, 15, 16) + ` <= pref_max_distance)))` +
+					` AND ((COALESCE(interested_in, '') = '' OR (',' || interested_in || ',') LIKE $17)) AND (COALESCE(pref_age_min, 0) <= $18 AND (COALESCE(pref_age_max, 0) = 0 OR pref_age_max >= $19)))` +
+					` ORDER BY CASE WHEN id IN ($20) THEN 0 ELSE 1 END,CASE WHEN id IN ($21,$22) THEN 0 ELSE 1 END,updated_at DESC LIMIT 10`
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1, 3, models.GenderFemale, minBirthdate, maxBirthdate, -7.0, -6.0, 106.0, 107.0, -6.5, -6.5, 106.5, 50, -6.5, -6.5, 106.5, "%,"+models.GenderMale+",%", 25, 25, 4, 5, 6).
+					WillReturnRows(expectedRows)
+			},
+			want: []models.User{
+				{
+					Model: gorm.Model{
+						ID: 2,
+					},
+					Username: "abc",
+					Fullname: "full",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error get data",
+			filter: CandidateFilter{
+				Limit: 10,
+			},
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."deleted_at" IS NULL AND ((COALESCE(pref_max_distance, 0) = 0) AND (COALESCE(interested_in, '') = '') AND (COALESCE(pref_age_min, 0) = 0 AND COALESCE(pref_age_max, 0) = 0)) ORDER BY updated_at DESC LIMIT 10`)).WillReturnError(fmt.Errorf("some error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "nil database",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := UserStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := service.GetCandidateList(tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UserStore.GetCandidateList() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UserStore.GetCandidateList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserStore_GetUserListByIDs(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		userids  []int
+		want     []models.User
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users"  WHERE "users"."deleted_at" IS NULL AND ((id IN ($1,$2)))`)).WithArgs(2, 3).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "fullname"}).AddRow(2, "abc", "full").AddRow(3, "def", "name"))
+			},
+			userids: []int{2, 3},
+			want: []models.User{
+				{Model: gorm.Model{ID: 2}, Username: "abc", Fullname: "full"},
+				{Model: gorm.Model{ID: 3}, Username: "def", Fullname: "name"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success empty id",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+			},
+			userids: []int{},
+			want:    []models.User{},
+			wantErr: false,
+		},
+		{
+			name: "error get data",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users"  WHERE "users"."deleted_at" IS NULL AND ((id IN ($1)))`)).WillReturnError(fmt.Errorf("some error"))
+			},
+			userids: []int{2},
+			wantErr: true,
+		},
+		{
+			name: "nil database",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			userids: []int{2},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := UserStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := service.GetUserListByIDs(tt.userids)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UserStore.GetUserListByIDs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UserStore.GetUserListByIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,8 +5,10 @@
 package mock
 
 import (
+	userhistory "gilsaputro/dating-apps/internal/store/userhistory"
 	models "gilsaputro/dating-apps/models"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -63,19 +65,122 @@ func (mr *MockUserHistoryStoreMethodMockRecorder) CreateUserHistory(hist interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserHistory", reflect.TypeOf((*MockUserHistoryStoreMethod)(nil).CreateUserHistory), hist)
 }
 
+// DeleteUserHistory mocks base method.
+func (m *MockUserHistoryStoreMethod) DeleteUserHistory(userID, partnerID int, decision models.DecisionType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserHistory", userID, partnerID, decision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserHistory indicates an expected call of DeleteUserHistory.
+func (mr *MockUserHistoryStoreMethodMockRecorder) DeleteUserHistory(userID, partnerID, decision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserHistory", reflect.TypeOf((*MockUserHistoryStoreMethod)(nil).DeleteUserHistory), userID, partnerID, decision)
+}
+
+// GetPartnerIDsByUserID mocks base method.
+func (m *MockUserHistoryStoreMethod) GetPartnerIDsByUserID(userID int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPartnerIDsByUserID", userID)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPartnerIDsByUserID indicates an expected call of GetPartnerIDsByUserID.
+func (mr *MockUserHistoryStoreMethodMockRecorder) GetPartnerIDsByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPartnerIDsByUserID", reflect.TypeOf((*MockUserHistoryStoreMethod)(nil).GetPartnerIDsByUserID), userID)
+}
+
+// GetPassedPartnerIDsByUserID mocks base method.
+func (m *MockUserHistoryStoreMethod) GetPassedPartnerIDsByUserID(userID int, since time.Time) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPassedPartnerIDsByUserID", userID, since)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPassedPartnerIDsByUserID indicates an expected call of GetPassedPartnerIDsByUserID.
+func (mr *MockUserHistoryStoreMethodMockRecorder) GetPassedPartnerIDsByUserID(userID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPassedPartnerIDsByUserID", reflect.TypeOf((*MockUserHistoryStoreMethod)(nil).GetPassedPartnerIDsByUserID), userID, since)
+}
+
+// GetPendingLikeListByPartnerID mocks base method.
+func (m *MockUserHistoryStoreMethod) GetPendingLikeListByPartnerID(partnerID int) ([]models.UserMatchHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingLikeListByPartnerID", partnerID)
+	ret0, _ := ret[0].([]models.UserMatchHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingLikeListByPartnerID indicates an expected call of GetPendingLikeListByPartnerID.
+func (mr *MockUserHistoryStoreMethodMockRecorder) GetPendingLikeListByPartnerID(partnerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingLikeListByPartnerID", reflect.TypeOf((*MockUserHistoryStoreMethod)(nil).GetPendingLikeListByPartnerID), partnerID)
+}
+
+// GetSuperLikerIDsByPartnerID mocks base method.
+func (m *MockUserHistoryStoreMethod) GetSuperLikerIDsByPartnerID(partnerID int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuperLikerIDsByPartnerID", partnerID)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSuperLikerIDsByPartnerID indicates an expected call of GetSuperLikerIDsByPartnerID.
+func (mr *MockUserHistoryStoreMethodMockRecorder) GetSuperLikerIDsByPartnerID(partnerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuperLikerIDsByPartnerID", reflect.TypeOf((*MockUserHistoryStoreMethod)(nil).GetSuperLikerIDsByPartnerID), partnerID)
+}
+
 // GetUserHistoryListByUserID mocks base method.
-func (m *MockUserHistoryStoreMethod) GetUserHistoryListByUserID(hist models.UserMatchHistory) ([]models.UserMatchHistory, error) {
+func (m *MockUserHistoryStoreMethod) GetUserHistoryListByUserID(filter userhistory.HistoryFilter) ([]models.UserMatchHistory, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserHistoryListByUserID", hist)
+	ret := m.ctrl.Call(m, "GetUserHistoryListByUserID", filter)
 	ret0, _ := ret[0].([]models.UserMatchHistory)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserHistoryListByUserID indicates an expected call of GetUserHistoryListByUserID.
-func (mr *MockUserHistoryStoreMethodMockRecorder) GetUserHistoryListByUserID(hist interface{}) *gomock.Call {
+func (mr *MockUserHistoryStoreMethodMockRecorder) GetUserHistoryListByUserID(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserHistoryListByUserID", reflect.TypeOf((*MockUserHistoryStoreMethod)(nil).GetUserHistoryListByUserID), filter)
+}
+
+// GetUserIDsByPartnerID mocks base method.
+func (m *MockUserHistoryStoreMethod) GetUserIDsByPartnerID(partnerID int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDsByPartnerID", partnerID)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDsByPartnerID indicates an expected call of GetUserIDsByPartnerID.
+func (mr *MockUserHistoryStoreMethodMockRecorder) GetUserIDsByPartnerID(partnerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDsByPartnerID", reflect.TypeOf((*MockUserHistoryStoreMethod)(nil).GetUserIDsByPartnerID), partnerID)
+}
+
+// SavePassHistory mocks base method.
+func (m *MockUserHistoryStoreMethod) SavePassHistory(history models.UserMatchHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePassHistory", history)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePassHistory indicates an expected call of SavePassHistory.
+func (mr *MockUserHistoryStoreMethodMockRecorder) SavePassHistory(history interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserHistoryListByUserID", reflect.TypeOf((*MockUserHistoryStoreMethod)(nil).GetUserHistoryListByUserID), hist)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePassHistory", reflect.TypeOf((*MockUserHistoryStoreMethod)(nil).SavePassHistory), history)
 }
 
 // UpdatePartnerStatus mocks base method.
@@ -2,8 +2,10 @@ package userhistory
 
 import (
 	"errors"
+	"fmt"
 	"gilsaputro/dating-apps/models"
 	"gilsaputro/dating-apps/pkg/postgres"
+	"time"
 
 	"github.com/jinzhu/gorm"
 )
@@ -11,9 +13,40 @@ import (
 // UserHistoryStoreMethod is set of methods for interacting with a user storage system
 type UserHistoryStoreMethod interface {
 	CreateUserHistory(hist models.UserMatchHistory) error
-	GetUserHistoryListByUserID(hist models.UserMatchHistory) ([]models.UserMatchHistory, error)
+	GetUserHistoryListByUserID(filter HistoryFilter) ([]models.UserMatchHistory, error)
 	CountByUserIDAndPartnerID(userID, partnerID int) (int, error)
 	UpdatePartnerStatus(history models.UserMatchHistory) error
+	GetPartnerIDsByUserID(userID int) ([]int, error)
+	GetUserIDsByPartnerID(partnerID int) ([]int, error)
+	GetSuperLikerIDsByPartnerID(partnerID int) ([]int, error)
+	GetPendingLikeListByPartnerID(partnerID int) ([]models.UserMatchHistory, error)
+	SavePassHistory(history models.UserMatchHistory) error
+	GetPassedPartnerIDsByUserID(userID int, since time.Time) ([]int, error)
+	DeleteUserHistory(userID, partnerID int, decision models.DecisionType) error
+}
+
+// HistoryFilter is list parameter to query user history
+type HistoryFilter struct {
+	UserID    int
+	Decisions []models.DecisionType
+	// ExcludePartnerIDs is list partner id that is not returned
+	ExcludePartnerIDs []int
+	// Status is only applied when it is set
+	Status models.MatchStatus
+	// Cursor is position of the last history on the previous page, the next page start after it
+	Cursor *HistoryCursor
+	// Limit is max number of history returned, zero means no limit
+	Limit int
+	// SortAsc is true to sort the history by the oldest created date first
+	SortAsc bool
+	// SortByUpdatedAt is true to sort the history by the updated date, the pass history is updated when the partner is passed again
+	SortByUpdatedAt bool
+}
+
+// HistoryCursor is position of a history on the created date ordering, or the updated date ordering when the filter sort by it
+type HistoryCursor struct {
+	Time time.Time
+	ID   uint
 }
 
 // UserHistoryStore is list dependencies user store
@@ -46,14 +79,47 @@ func (u UserHistoryStore) CreateUserHistory(history models.UserMatchHistory) err
 	return db.Create(&history).Error
 }
 
-func (u UserHistoryStore) GetUserHistoryListByUserID(history models.UserMatchHistory) ([]models.UserMatchHistory, error) {
+func (u UserHistoryStore) GetUserHistoryListByUserID(filter HistoryFilter) ([]models.UserMatchHistory, error) {
 	db, err := u.getDB()
 	if err != nil {
 		return nil, err
 	}
 
+	query := db.Model(models.UserMatchHistory{}).Where("user_id = ?", filter.UserID)
+	if len(filter.Decisions) > 0 {
+		query = query.Where("decision IN (?)", filter.Decisions)
+	}
+
+	if len(filter.ExcludePartnerIDs) > 0 {
+		query = query.Where("partner_id NOT IN (?)", filter.ExcludePartnerIDs)
+	}
+
+	if filter.Status > 0 {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	column := "created_at"
+	if filter.SortByUpdatedAt {
+		column = "updated_at"
+	}
+
+	// id is used as tie breaker for the history with the same date
+	order, operator := "DESC", "<"
+	if filter.SortAsc {
+		order, operator = "ASC", ">"
+	}
+
+	if filter.Cursor != nil {
+		query = query.Where(fmt.Sprintf("%s %s ? OR (%s = ? AND id %s ?)", column, operator, column, operator), filter.Cursor.Time, filter.Cursor.Time, filter.Cursor.ID)
+	}
+
+	query = query.Order(fmt.Sprintf("%s %s, id %s", column, order, order))
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
 	result := []models.UserMatchHistory{}
-	err = db.Model(models.UserMatchHistory{}).Find(&result, history).Error
+	err = query.Find(&result).Error
 	if err != nil {
 		return nil, err
 	}
@@ -68,7 +134,7 @@ func (u UserHistoryStore) CountByUserIDAndPartnerID(userID, partnerID int) (int,
 	}
 
 	var count int
-	err = db.Model(models.UserMatchHistory{}).Where("user_id = ? AND partner_id = ?", userID, partnerID).Count(&count).Error
+	err = db.Model(models.UserMatchHistory{}).Where("user_id = ? AND partner_id = ? AND decision IN (?)", userID, partnerID, models.LikeDecisions).Count(&count).Error
 	if err != nil {
 		return 0, err
 	}
@@ -83,7 +149,7 @@ func (u UserHistoryStore) UpdatePartnerStatus(history models.UserMatchHistory) e
 	}
 
 	var user models.UserMatchHistory
-	err = db.Where("user_id = ? AND partner_id = ?", history.UserID, history.PartnerID).First(&user).Error
+	err = db.Where("user_id = ? AND partner_id = ? AND decision IN (?)", history.UserID, history.PartnerID, models.LikeDecisions).First(&user).Error
 	if err != nil {
 		return err
 	}
@@ -91,3 +157,113 @@ func (u UserHistoryStore) UpdatePartnerStatus(history models.UserMatchHistory) e
 	user.Status = history.Status
 	return db.Save(&user).Error
 }
+
+func (u UserHistoryStore) GetPartnerIDsByUserID(userID int) ([]int, error) {
+	db, err := u.getDB()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []int{}
+	err = db.Model(models.UserMatchHistory{}).Where("user_id = ? AND decision IN (?)", userID, models.LikeDecisions).Pluck("partner_id", &result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+func (u UserHistoryStore) GetUserIDsByPartnerID(partnerID int) ([]int, error) {
+	db, err := u.getDB()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []int{}
+	err = db.Model(models.UserMatchHistory{}).Where("partner_id = ? AND decision IN (?)", partnerID, models.LikeDecisions).Pluck("user_id", &result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetSuperLikerIDsByPartnerID is func to get user id who super liked the partner
+func (u UserHistoryStore) GetSuperLikerIDsByPartnerID(partnerID int) ([]int, error) {
+	db, err := u.getDB()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []int{}
+	err = db.Model(models.UserMatchHistory{}).Where("partner_id = ? AND decision = ?", partnerID, models.DecisionSuperLike).Pluck("user_id", &result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// GetPendingLikeListByPartnerID is func to get the pending like received by the partner, the latest like comes first
+func (u UserHistoryStore) GetPendingLikeListByPartnerID(partnerID int) ([]models.UserMatchHistory, error) {
+	db, err := u.getDB()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []models.UserMatchHistory{}
+	err = db.Where("partner_id = ? AND status = ? AND decision IN (?)", partnerID, models.MatchStatusPending, models.LikeDecisions).Order("created_at DESC").Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// SavePassHistory is func to store the user pass decision, passing the same partner again only refresh the pass time
+func (u UserHistoryStore) SavePassHistory(history models.UserMatchHistory) error {
+	db, err := u.getDB()
+	if err != nil {
+		return err
+	}
+
+	var pass models.UserMatchHistory
+	err = db.Where("user_id = ? AND partner_id = ? AND decision = ?", history.UserID, history.PartnerID, models.DecisionPass).First(&pass).Error
+	if gorm.IsRecordNotFoundError(err) {
+		history.Decision = models.DecisionPass
+		return db.Create(&history).Error
+	}
+
+	if err != nil {
+		return err
+	}
+
+	pass.PartnerName = history.PartnerName
+	return db.Save(&pass).Error
+}
+
+// GetPassedPartnerIDsByUserID is func to get partner id passed by the user since the given time
+func (u UserHistoryStore) GetPassedPartnerIDsByUserID(userID int, since time.Time) ([]int, error) {
+	db, err := u.getDB()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []int{}
+	err = db.Model(models.UserMatchHistory{}).Where("user_id = ? AND decision = ? AND updated_at > ?", userID, models.DecisionPass, since).Pluck("partner_id", &result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, err
+}
+
+// DeleteUserHistory is func to soft delete the user decision to the partner
+func (u UserHistoryStore) DeleteUserHistory(userID, partnerID int, decision models.DecisionType) error {
+	db, err := u.getDB()
+	if err != nil {
+		return err
+	}
+
+	return db.Where("user_id = ? AND partner_id = ? AND decision = ?", userID, partnerID, decision).Delete(&models.UserMatchHistory{}).Error
+}
@@ -11,6 +11,7 @@ import (
 	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/jinzhu/gorm"
@@ -75,7 +76,7 @@ func TestUserHistoryStore_CreateUserHistory(t *testing.T) {
 			mockFunc: func() {
 				pg.EXPECT().GetDB().Return(gormDB)
 				mockDB.ExpectBegin()
-				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_match_histories" ("created_at","updated_at","deleted_at","user_id","partner_id","partner_name","status") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "user_match_histories"."id"`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_match_histories" ("created_at","updated_at","deleted_at","user_id","partner_id","partner_name","status","decision") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "user_match_histories"."id"`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 				mockDB.ExpectCommit()
 			},
 			args: args{
@@ -84,6 +85,7 @@ func TestUserHistoryStore_CreateUserHistory(t *testing.T) {
 					PartnerID:   1,
 					PartnerName: "A",
 					Status:      1,
+					Decision:    models.DecisionLike,
 				},
 			},
 			wantErr: false,
@@ -93,7 +95,7 @@ func TestUserHistoryStore_CreateUserHistory(t *testing.T) {
 			mockFunc: func() {
 				pg.EXPECT().GetDB().Return(gormDB)
 				mockDB.ExpectBegin()
-				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_match_histories" ("created_at","updated_at","deleted_at","user_id","partner_id","partner_name","status") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "user_match_histories"."id"`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_match_histories" ("created_at","updated_at","deleted_at","user_id","partner_id","partner_name","status","decision") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "user_match_histories"."id"`)).WillReturnError(fmt.Errorf("some error"))
 			},
 			args: args{
 				history: models.UserMatchHistory{
@@ -101,6 +103,7 @@ func TestUserHistoryStore_CreateUserHistory(t *testing.T) {
 					PartnerID:   1,
 					PartnerName: "A",
 					Status:      1,
+					Decision:    models.DecisionLike,
 				},
 			},
 			wantErr: true,
@@ -116,6 +119,7 @@ func TestUserHistoryStore_CreateUserHistory(t *testing.T) {
 					PartnerID:   1,
 					PartnerName: "A",
 					Status:      1,
+					Decision:    models.DecisionLike,
 				},
 			},
 			wantErr: true,
@@ -149,12 +153,14 @@ func TestUserHistoryStore_GetUserHistoryListByUserID(t *testing.T) {
 		PartnerID:   1,
 		PartnerName: "A",
 		Status:      1,
+		Decision:    models.DecisionLike,
 	}
-	var expectedRows = sqlmock.NewRows([]string{"id", "user_id", "partner_id", "partner_name", "status"}).
-		AddRow(userDataMock.ID, userDataMock.UserID, userDataMock.PartnerID, userDataMock.PartnerName, userDataMock.Status)
+	var expectedRows = sqlmock.NewRows([]string{"id", "user_id", "partner_id", "partner_name", "status", "decision"}).
+		AddRow(userDataMock.ID, userDataMock.UserID, userDataMock.PartnerID, userDataMock.PartnerName, userDataMock.Status, userDataMock.Decision)
 
+	cursorTime := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
 	type args struct {
-		history models.UserMatchHistory
+		filter HistoryFilter
 	}
 	tests := []struct {
 		name     string
@@ -167,11 +173,12 @@ func TestUserHistoryStore_GetUserHistoryListByUserID(t *testing.T) {
 			name: "success",
 			mockFunc: func() {
 				pg.EXPECT().GetDB().Return(gormDB)
-				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND (("user_match_histories"."user_id" = $1))`)).WillReturnRows(expectedRows)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1) AND (decision IN ($2,$3))) ORDER BY created_at DESC, id DESC`)).WillReturnRows(expectedRows)
 			},
 			args: args{
-				history: models.UserMatchHistory{
-					UserID: 1,
+				filter: HistoryFilter{
+					UserID:    1,
+					Decisions: models.LikeDecisions,
 				},
 			},
 			want: []models.UserMatchHistory{
@@ -183,6 +190,76 @@ func TestUserHistoryStore_GetUserHistoryListByUserID(t *testing.T) {
 					PartnerID:   1,
 					PartnerName: "A",
 					Status:      1,
+					Decision:    models.DecisionLike,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success with status, cursor and limit",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1) AND (decision IN ($2,$3)) AND (partner_id NOT IN ($4)) AND (status = $5) AND (created_at > $6 OR (created_at = $7 AND id > $8))) ORDER BY created_at ASC, id ASC LIMIT 11`)).
+					WithArgs(1, models.DecisionLike, models.DecisionSuperLike, 3, models.MatchStatusPending, cursorTime, cursorTime, 5).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "partner_id", "partner_name", "status", "decision"}).AddRow(6, 1, 2, "B", 1, 1))
+			},
+			args: args{
+				filter: HistoryFilter{
+					UserID:            1,
+					Decisions:         models.LikeDecisions,
+					ExcludePartnerIDs: []int{3},
+					Status:            models.MatchStatusPending,
+					Cursor: &HistoryCursor{
+						Time: cursorTime,
+						ID:   5,
+					},
+					Limit:   11,
+					SortAsc: true,
+				},
+			},
+			want: []models.UserMatchHistory{
+				{
+					Model: gorm.Model{
+						ID: 6,
+					},
+					UserID:      1,
+					PartnerID:   2,
+					PartnerName: "B",
+					Status:      models.MatchStatusPending,
+					Decision:    models.DecisionLike,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success pass history sort by updated date",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1) AND (decision IN ($2)) AND (updated_at < $3 OR (updated_at = $4 AND id < $5))) ORDER BY updated_at DESC, id DESC LIMIT 11`)).
+					WithArgs(1, models.DecisionPass, cursorTime, cursorTime, 5).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "partner_id", "partner_name", "status", "decision"}).AddRow(6, 1, 2, "B", 0, 2))
+			},
+			args: args{
+				filter: HistoryFilter{
+					UserID:    1,
+					Decisions: []models.DecisionType{models.DecisionPass},
+					Cursor: &HistoryCursor{
+						Time: cursorTime,
+						ID:   5,
+					},
+					Limit:           11,
+					SortByUpdatedAt: true,
+				},
+			},
+			want: []models.UserMatchHistory{
+				{
+					Model: gorm.Model{
+						ID: 6,
+					},
+					UserID:      1,
+					PartnerID:   2,
+					PartnerName: "B",
+					Decision:    models.DecisionPass,
 				},
 			},
 			wantErr: false,
@@ -191,11 +268,12 @@ func TestUserHistoryStore_GetUserHistoryListByUserID(t *testing.T) {
 			name: "error on db",
 			mockFunc: func() {
 				pg.EXPECT().GetDB().Return(gormDB)
-				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND (("user_match_histories"."user_id" = $1))`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1) AND (decision IN ($2,$3)))`)).WillReturnError(fmt.Errorf("some error"))
 			},
 			args: args{
-				history: models.UserMatchHistory{
-					UserID: 1,
+				filter: HistoryFilter{
+					UserID:    1,
+					Decisions: models.LikeDecisions,
 				},
 			},
 			want:    nil,
@@ -207,8 +285,9 @@ func TestUserHistoryStore_GetUserHistoryListByUserID(t *testing.T) {
 				pg.EXPECT().GetDB().Return(nil)
 			},
 			args: args{
-				history: models.UserMatchHistory{
-					UserID: 1,
+				filter: HistoryFilter{
+					UserID:    1,
+					Decisions: models.LikeDecisions,
 				},
 			},
 			want:    nil,
@@ -221,7 +300,7 @@ func TestUserHistoryStore_GetUserHistoryListByUserID(t *testing.T) {
 				pg: pg,
 			}
 			tt.mockFunc()
-			got, err := service.GetUserHistoryListByUserID(tt.args.history)
+			got, err := service.GetUserHistoryListByUserID(tt.args.filter)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UserHistoryStore.GetUserHistoryListByUserID() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -233,6 +312,101 @@ func TestUserHistoryStore_GetUserHistoryListByUserID(t *testing.T) {
 	}
 }
 
+func TestUserHistoryStore_GetPendingLikeListByPartnerID(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	var userDataMock = &models.UserMatchHistory{
+		Model: gorm.Model{
+			ID: 1,
+		},
+		UserID:      1,
+		PartnerID:   1,
+		PartnerName: "A",
+		Status:      1,
+		Decision:    models.DecisionLike,
+	}
+	var expectedRows = sqlmock.NewRows([]string{"id", "user_id", "partner_id", "partner_name", "status", "decision"}).
+		AddRow(userDataMock.ID, userDataMock.UserID, userDataMock.PartnerID, userDataMock.PartnerName, userDataMock.Status, userDataMock.Decision)
+
+	type args struct {
+		partnerID int
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		want     []models.UserMatchHistory
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((partner_id = $1 AND status = $2 AND decision IN ($3,$4))) ORDER BY created_at DESC`)).WithArgs(1, models.MatchStatusPending, models.DecisionLike, models.DecisionSuperLike).WillReturnRows(expectedRows)
+			},
+			args: args{
+				partnerID: 1,
+			},
+			want: []models.UserMatchHistory{
+				{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					UserID:      1,
+					PartnerID:   1,
+					PartnerName: "A",
+					Status:      1,
+					Decision:    models.DecisionLike,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((partner_id = $1 AND status = $2 AND decision IN ($3,$4))) ORDER BY created_at DESC`)).WillReturnError(fmt.Errorf("some error"))
+			},
+			args: args{
+				partnerID: 1,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			args: args{
+				partnerID: 1,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := UserHistoryStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := service.GetPendingLikeListByPartnerID(tt.args.partnerID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UserHistoryStore.GetPendingLikeListByPartnerID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UserHistoryStore.GetPendingLikeListByPartnerID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestUserHistoryStore_CountByUserIDAndPartnerID(t *testing.T) {
 	db, mockDB, gormDB := InitDBsMockupStat()
 	defer db.Close()
@@ -255,7 +429,7 @@ func TestUserHistoryStore_CountByUserIDAndPartnerID(t *testing.T) {
 			name: "success",
 			mockFunc: func() {
 				pg.EXPECT().GetDB().Return(gormDB)
-				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "user_match_histories"  WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1 AND partner_id = $2))`)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "user_match_histories"  WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1 AND partner_id = $2 AND decision IN ($3,$4)))`)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 			},
 			args: args{
 				userID:    1,
@@ -268,7 +442,7 @@ func TestUserHistoryStore_CountByUserIDAndPartnerID(t *testing.T) {
 			name: "error on db",
 			mockFunc: func() {
 				pg.EXPECT().GetDB().Return(gormDB)
-				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "user_match_histories"  WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1 AND partner_id = $2))`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "user_match_histories"  WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1 AND partner_id = $2 AND decision IN ($3,$4)))`)).WillReturnError(fmt.Errorf("some error"))
 			},
 			args: args{
 				userID:    1,
@@ -340,9 +514,9 @@ func TestUserHistoryStore_UpdatePartnerStatus(t *testing.T) {
 			name: "success",
 			mockFunc: func() {
 				pg.EXPECT().GetDB().Return(gormDB)
-				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1 AND partner_id = $2)) ORDER BY "user_match_histories"."id" ASC LIMIT 1`)).WillReturnRows(expectedRows)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1 AND partner_id = $2 AND decision IN ($3,$4))) ORDER BY "user_match_histories"."id" ASC LIMIT 1`)).WillReturnRows(expectedRows)
 				mockDB.ExpectBegin()
-				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "user_match_histories" SET "updated_at" = $1, "deleted_at" = $2, "user_id" = $3, "partner_id" = $4, "partner_name" = $5, "status" = $6 WHERE "user_match_histories"."deleted_at" IS NULL AND "user_match_histories"."id" = $7`)).WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "user_match_histories" SET "updated_at" = $1, "deleted_at" = $2, "user_id" = $3, "partner_id" = $4, "partner_name" = $5, "status" = $6, "decision" = $7 WHERE "user_match_histories"."deleted_at" IS NULL AND "user_match_histories"."id" = $8`)).WillReturnResult(sqlmock.NewResult(1, 1))
 				mockDB.ExpectCommit()
 			},
 			args: args{
@@ -354,6 +528,7 @@ func TestUserHistoryStore_UpdatePartnerStatus(t *testing.T) {
 					PartnerID:   1,
 					PartnerName: "A",
 					Status:      1,
+					Decision:    models.DecisionLike,
 				},
 			},
 			wantErr: false,
@@ -362,9 +537,9 @@ func TestUserHistoryStore_UpdatePartnerStatus(t *testing.T) {
 			name: "error on db",
 			mockFunc: func() {
 				pg.EXPECT().GetDB().Return(gormDB)
-				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1 AND partner_id = $2)) ORDER BY "user_match_histories"."id" ASC LIMIT 1`)).WillReturnRows(expectedRows)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1 AND partner_id = $2 AND decision IN ($3,$4))) ORDER BY "user_match_histories"."id" ASC LIMIT 1`)).WillReturnRows(expectedRows)
 				mockDB.ExpectBegin()
-				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "user_match_histories" SET "updated_at" = $1, "deleted_at" = $2, "user_id" = $3, "partner_id" = $4, "partner_name" = $5, "status" = $6 WHERE "user_match_histories"."deleted_at" IS NULL AND "user_match_histories"."id" = $7`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "user_match_histories" SET "updated_at" = $1, "deleted_at" = $2, "user_id" = $3, "partner_id" = $4, "partner_name" = $5, "status" = $6, "decision" = $7 WHERE "user_match_histories"."deleted_at" IS NULL AND "user_match_histories"."id" = $8`)).WillReturnError(fmt.Errorf("some error"))
 			},
 			args: args{
 				history: models.UserMatchHistory{
@@ -375,6 +550,7 @@ func TestUserHistoryStore_UpdatePartnerStatus(t *testing.T) {
 					PartnerID:   1,
 					PartnerName: "A",
 					Status:      1,
+					Decision:    models.DecisionLike,
 				},
 			},
 			wantErr: true,
@@ -383,7 +559,7 @@ func TestUserHistoryStore_UpdatePartnerStatus(t *testing.T) {
 			name: "error on db select",
 			mockFunc: func() {
 				pg.EXPECT().GetDB().Return(gormDB)
-				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1 AND partner_id = $2)) ORDER BY "user_match_histories"."id" ASC LIMIT 1`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1 AND partner_id = $2 AND decision IN ($3,$4))) ORDER BY "user_match_histories"."id" ASC LIMIT 1`)).WillReturnError(fmt.Errorf("some error"))
 			},
 			args: args{
 				history: models.UserMatchHistory{
@@ -394,6 +570,7 @@ func TestUserHistoryStore_UpdatePartnerStatus(t *testing.T) {
 					PartnerID:   1,
 					PartnerName: "A",
 					Status:      1,
+					Decision:    models.DecisionLike,
 				},
 			},
 			wantErr: true,
@@ -412,6 +589,7 @@ func TestUserHistoryStore_UpdatePartnerStatus(t *testing.T) {
 					PartnerID:   1,
 					PartnerName: "A",
 					Status:      1,
+					Decision:    models.DecisionLike,
 				},
 			},
 			wantErr: true,
@@ -429,3 +607,382 @@ func TestUserHistoryStore_UpdatePartnerStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestUserHistoryStore_GetPartnerIDsByUserID(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		userID   int
+		want     []int
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT partner_id FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1 AND decision IN ($2,$3)))`)).WillReturnRows(sqlmock.NewRows([]string{"partner_id"}).AddRow(2).AddRow(3))
+			},
+			userID:  1,
+			want:    []int{2, 3},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT partner_id FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1 AND decision IN ($2,$3)))`)).WillReturnError(fmt.Errorf("some error"))
+			},
+			userID:  1,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			userID:  1,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := UserHistoryStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := service.GetPartnerIDsByUserID(tt.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UserHistoryStore.GetPartnerIDsByUserID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UserHistoryStore.GetPartnerIDsByUserID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserHistoryStore_GetUserIDsByPartnerID(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name      string
+		mockFunc  func()
+		partnerID int
+		want      []int
+		wantErr   bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT user_id FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((partner_id = $1 AND decision IN ($2,$3)))`)).WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(2))
+			},
+			partnerID: 1,
+			want:      []int{2},
+			wantErr:   false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT user_id FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((partner_id = $1 AND decision IN ($2,$3)))`)).WillReturnError(fmt.Errorf("some error"))
+			},
+			partnerID: 1,
+			want:      nil,
+			wantErr:   true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			partnerID: 1,
+			want:      nil,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := UserHistoryStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := service.GetUserIDsByPartnerID(tt.partnerID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UserHistoryStore.GetUserIDsByPartnerID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UserHistoryStore.GetUserIDsByPartnerID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserHistoryStore_GetSuperLikerIDsByPartnerID(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name      string
+		mockFunc  func()
+		partnerID int
+		want      []int
+		wantErr   bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT user_id FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((partner_id = $1 AND decision = $2))`)).WithArgs(1, models.DecisionSuperLike).WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(2))
+			},
+			partnerID: 1,
+			want:      []int{2},
+			wantErr:   false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT user_id FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((partner_id = $1 AND decision = $2))`)).WillReturnError(fmt.Errorf("some error"))
+			},
+			partnerID: 1,
+			want:      nil,
+			wantErr:   true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			partnerID: 1,
+			want:      nil,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := UserHistoryStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := service.GetSuperLikerIDsByPartnerID(tt.partnerID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UserHistoryStore.GetSuperLikerIDsByPartnerID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UserHistoryStore.GetSuperLikerIDsByPartnerID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserHistoryStore_SavePassHistory(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	history := models.UserMatchHistory{
+		UserID:      1,
+		PartnerID:   2,
+		PartnerName: "A",
+		Decision:    models.DecisionPass,
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "success create new pass",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1 AND partner_id = $2 AND decision = $3)) ORDER BY "user_match_histories"."id" ASC LIMIT 1`)).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_match_histories" ("created_at","updated_at","deleted_at","user_id","partner_id","partner_name","status","decision") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "user_match_histories"."id"`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "success refresh existing pass",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1 AND partner_id = $2 AND decision = $3)) ORDER BY "user_match_histories"."id" ASC LIMIT 1`)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "partner_id", "partner_name", "decision"}).AddRow(1, 1, 2, "A", models.DecisionPass))
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "user_match_histories" SET "updated_at" = $1, "deleted_at" = $2, "user_id" = $3, "partner_id" = $4, "partner_name" = $5, "status" = $6, "decision" = $7 WHERE "user_match_histories"."deleted_at" IS NULL AND "user_match_histories"."id" = $8`)).WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on get pass",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1 AND partner_id = $2 AND decision = $3)) ORDER BY "user_match_histories"."id" ASC LIMIT 1`)).WillReturnError(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := UserHistoryStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			if err := service.SavePassHistory(history); (err != nil) != tt.wantErr {
+				t.Errorf("UserHistoryStore.SavePassHistory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mockDB.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestUserHistoryStore_GetPassedPartnerIDsByUserID(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	since := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     []int
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT partner_id FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1 AND decision = $2 AND updated_at > $3))`)).
+					WithArgs(1, models.DecisionPass, since).
+					WillReturnRows(sqlmock.NewRows([]string{"partner_id"}).AddRow(2).AddRow(3))
+			},
+			want:    []int{2, 3},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT partner_id FROM "user_match_histories" WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $1 AND decision = $2 AND updated_at > $3))`)).WillReturnError(fmt.Errorf("some error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := UserHistoryStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := service.GetPassedPartnerIDsByUserID(1, since)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UserHistoryStore.GetPassedPartnerIDsByUserID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UserHistoryStore.GetPassedPartnerIDsByUserID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserHistoryStore_DeleteUserHistory(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "user_match_histories" SET "deleted_at"=$1  WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $2 AND partner_id = $3 AND decision = $4))`)).
+					WithArgs(sqlmock.AnyArg(), 1, 2, models.DecisionPass).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mockDB.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "user_match_histories" SET "deleted_at"=$1  WHERE "user_match_histories"."deleted_at" IS NULL AND ((user_id = $2 AND partner_id = $3 AND decision = $4))`)).
+					WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := UserHistoryStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			if err := service.DeleteUserHistory(1, 2, models.DecisionPass); (err != nil) != tt.wantErr {
+				t.Errorf("UserHistoryStore.DeleteUserHistory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mockDB.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/store/verification/store.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	verification "gilsaputro/dating-apps/internal/store/verification"
+	models "gilsaputro/dating-apps/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockVerificationStoreMethod is a mock of VerificationStoreMethod interface.
+type MockVerificationStoreMethod struct {
+	ctrl     *gomock.Controller
+	recorder *MockVerificationStoreMethodMockRecorder
+}
+
+// MockVerificationStoreMethodMockRecorder is the mock recorder for MockVerificationStoreMethod.
+type MockVerificationStoreMethodMockRecorder struct {
+	mock *MockVerificationStoreMethod
+}
+
+// NewMockVerificationStoreMethod creates a new mock instance.
+func NewMockVerificationStoreMethod(ctrl *gomock.Controller) *MockVerificationStoreMethod {
+	mock := &MockVerificationStoreMethod{ctrl: ctrl}
+	mock.recorder = &MockVerificationStoreMethodMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVerificationStoreMethod) EXPECT() *MockVerificationStoreMethodMockRecorder {
+	return m.recorder
+}
+
+// CountVerification mocks base method.
+func (m *MockVerificationStoreMethod) CountVerification(status models.VerificationStatus) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountVerification", status)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountVerification indicates an expected call of CountVerification.
+func (mr *MockVerificationStoreMethodMockRecorder) CountVerification(status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountVerification", reflect.TypeOf((*MockVerificationStoreMethod)(nil).CountVerification), status)
+}
+
+// CreateVerification mocks base method.
+func (m *MockVerificationStoreMethod) CreateVerification(request models.VerificationRequest) (models.VerificationRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVerification", request)
+	ret0, _ := ret[0].(models.VerificationRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVerification indicates an expected call of CreateVerification.
+func (mr *MockVerificationStoreMethodMockRecorder) CreateVerification(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVerification", reflect.TypeOf((*MockVerificationStoreMethod)(nil).CreateVerification), request)
+}
+
+// GetLatestVerification mocks base method.
+func (m *MockVerificationStoreMethod) GetLatestVerification(userID int) (models.VerificationRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestVerification", userID)
+	ret0, _ := ret[0].(models.VerificationRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestVerification indicates an expected call of GetLatestVerification.
+func (mr *MockVerificationStoreMethodMockRecorder) GetLatestVerification(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestVerification", reflect.TypeOf((*MockVerificationStoreMethod)(nil).GetLatestVerification), userID)
+}
+
+// GetVerificationByID mocks base method.
+func (m *MockVerificationStoreMethod) GetVerificationByID(id int) (models.VerificationRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVerificationByID", id)
+	ret0, _ := ret[0].(models.VerificationRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVerificationByID indicates an expected call of GetVerificationByID.
+func (mr *MockVerificationStoreMethodMockRecorder) GetVerificationByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVerificationByID", reflect.TypeOf((*MockVerificationStoreMethod)(nil).GetVerificationByID), id)
+}
+
+// GetVerificationList mocks base method.
+func (m *MockVerificationStoreMethod) GetVerificationList(filter verification.VerificationFilter) ([]models.VerificationRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVerificationList", filter)
+	ret0, _ := ret[0].([]models.VerificationRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVerificationList indicates an expected call of GetVerificationList.
+func (mr *MockVerificationStoreMethodMockRecorder) GetVerificationList(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVerificationList", reflect.TypeOf((*MockVerificationStoreMethod)(nil).GetVerificationList), filter)
+}
+
+// ReviewVerification mocks base method.
+func (m *MockVerificationStoreMethod) ReviewVerification(request models.VerificationRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewVerification", request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReviewVerification indicates an expected call of ReviewVerification.
+func (mr *MockVerificationStoreMethodMockRecorder) ReviewVerification(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewVerification", reflect.TypeOf((*MockVerificationStoreMethod)(nil).ReviewVerification), request)
+}
@@ -0,0 +1,154 @@
+package verification
+
+import (
+	"errors"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/postgres"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// VerificationStoreMethod is set of methods for interacting with a verification request storage system
+type VerificationStoreMethod interface {
+	CreateVerification(request models.VerificationRequest) (models.VerificationRequest, error)
+	GetVerificationByID(id int) (models.VerificationRequest, error)
+	GetLatestVerification(userID int) (models.VerificationRequest, error)
+	GetVerificationList(filter VerificationFilter) ([]models.VerificationRequest, error)
+	CountVerification(status models.VerificationStatus) (int, error)
+	ReviewVerification(request models.VerificationRequest) error
+}
+
+// VerificationFilter is list parameter to query verification request
+type VerificationFilter struct {
+	Status models.VerificationStatus
+	Limit  int
+	Offset int
+}
+
+// VerificationStore is list dependencies verification store
+type VerificationStore struct {
+	pg postgres.PostgresMethod
+}
+
+// NewVerificationStore is func to generate VerificationStoreMethod interface
+func NewVerificationStore(pg postgres.PostgresMethod) VerificationStoreMethod {
+	return &VerificationStore{
+		pg: pg,
+	}
+}
+
+func (v *VerificationStore) getDB() (*gorm.DB, error) {
+	db := v.pg.GetDB()
+	if db == nil {
+		return nil, errors.New("Database Client is not init")
+	}
+
+	return db, nil
+}
+
+// CreateVerification is func to store new verification request into review queue
+func (v *VerificationStore) CreateVerification(request models.VerificationRequest) (models.VerificationRequest, error) {
+	db, err := v.getDB()
+	if err != nil {
+		return models.VerificationRequest{}, err
+	}
+
+	request.Status = models.VerificationStatusPending
+	err = db.Create(&request).Error
+	if err != nil {
+		return models.VerificationRequest{}, err
+	}
+
+	return request, nil
+}
+
+// GetVerificationByID is func to get the verification request by id, it will return empty data if the request is not exists
+func (v *VerificationStore) GetVerificationByID(id int) (models.VerificationRequest, error) {
+	db, err := v.getDB()
+	if err != nil {
+		return models.VerificationRequest{}, err
+	}
+
+	var request models.VerificationRequest
+	err = db.Where("id = ?", id).First(&request).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return models.VerificationRequest{}, nil
+	}
+
+	return request, err
+}
+
+// GetLatestVerification is func to get the last verification request of the user, it will return empty data if the user never submit the request
+func (v *VerificationStore) GetLatestVerification(userID int) (models.VerificationRequest, error) {
+	db, err := v.getDB()
+	if err != nil {
+		return models.VerificationRequest{}, err
+	}
+
+	var request models.VerificationRequest
+	err = db.Where("user_id = ?", userID).Order("created_at DESC").First(&request).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return models.VerificationRequest{}, nil
+	}
+
+	return request, err
+}
+
+// GetVerificationList is func to get verification request with the given status, the oldest request comes first
+func (v *VerificationStore) GetVerificationList(filter VerificationFilter) ([]models.VerificationRequest, error) {
+	db, err := v.getDB()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []models.VerificationRequest{}
+	err = db.Where("status = ?", filter.Status).Order("created_at ASC").Limit(filter.Limit).Offset(filter.Offset).Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CountVerification is func to count verification request with the given status
+func (v *VerificationStore) CountVerification(status models.VerificationStatus) (int, error) {
+	db, err := v.getDB()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = db.Model(models.VerificationRequest{}).Where("status = ?", status).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// ReviewVerification is func to close the pending verification request with the review result,
+// it will return record not found if the request is not pending
+func (v *VerificationStore) ReviewVerification(request models.VerificationRequest) error {
+	db, err := v.getDB()
+	if err != nil {
+		return err
+	}
+
+	reviewedAt := time.Now()
+	query := db.Model(models.VerificationRequest{}).Where("id = ? AND status = ?", request.ID, models.VerificationStatusPending).Updates(map[string]interface{}{
+		"status":        request.Status,
+		"reviewed_by":   request.ReviewedBy,
+		"reviewed_at":   &reviewedAt,
+		"reject_reason": request.RejectReason,
+	})
+	if query.Error != nil {
+		return query.Error
+	}
+
+	if query.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
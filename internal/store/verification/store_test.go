@@ -0,0 +1,475 @@
+package verification
+
+import (
+	"database/sql"
+	"fmt"
+	"gilsaputro/dating-apps/models"
+	"gilsaputro/dating-apps/pkg/postgres"
+	mock_postgres "gilsaputro/dating-apps/pkg/postgres/mock"
+	"log"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestNewVerificationStore(t *testing.T) {
+	type args struct {
+		pg postgres.PostgresMethod
+	}
+	tests := []struct {
+		name string
+		args args
+		want VerificationStoreMethod
+	}{
+		{
+			name: "success flow",
+			args: args{
+				pg: &postgres.Client{},
+			},
+			want: &VerificationStore{
+				pg: &postgres.Client{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewVerificationStore(tt.args.pg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewVerificationStore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+var errSome = fmt.Errorf("some error")
+
+func InitDBsMockupStat() (*sql.DB, sqlmock.Sqlmock, *gorm.DB) {
+	db, mock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open("postgres", db)
+	gormDB.LogMode(true)
+	gormDB.SetLogger(log.New(os.Stdout, "\n", 0))
+	gormDB.Debug()
+	return db, mock, gormDB
+}
+
+func TestVerificationStore_CreateVerification(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     uint
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "verification_requests" ("created_at","updated_at","deleted_at","user_id","type","media_url","status","reviewed_by","reviewed_at","reject_reason") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING "verification_requests"."id"`)).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 1, models.VerificationTypeSelfie, "https://cdn.local/selfie.jpg", models.VerificationStatusPending, 0, nil, "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				mockDB.ExpectCommit()
+			},
+			want:    5,
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "verification_requests"`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := VerificationStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.CreateVerification(models.VerificationRequest{
+				UserID:   1,
+				Type:     models.VerificationTypeSelfie,
+				MediaURL: "https://cdn.local/selfie.jpg",
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerificationStore.CreateVerification() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.ID != tt.want {
+				t.Errorf("VerificationStore.CreateVerification() = %v, want %v", got.ID, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerificationStore_GetVerificationByID(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	query := `SELECT * FROM "verification_requests"  WHERE "verification_requests"."deleted_at" IS NULL AND ((id = $1)) ORDER BY "verification_requests"."id" ASC LIMIT 1`
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     models.VerificationRequest
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(5).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type", "status"}).AddRow(5, 1, models.VerificationTypeSelfie, models.VerificationStatusPending))
+			},
+			want: models.VerificationRequest{
+				Model:  gorm.Model{ID: 5},
+				UserID: 1,
+				Type:   models.VerificationTypeSelfie,
+				Status: models.VerificationStatusPending,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success not found",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(5).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			want:    models.VerificationRequest{},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(fmt.Errorf("some error"))
+			},
+			want:    models.VerificationRequest{},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    models.VerificationRequest{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := VerificationStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.GetVerificationByID(5)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerificationStore.GetVerificationByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VerificationStore.GetVerificationByID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerificationStore_GetLatestVerification(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	query := `SELECT * FROM "verification_requests"  WHERE "verification_requests"."deleted_at" IS NULL AND ((user_id = $1)) ORDER BY created_at DESC,"verification_requests"."id" ASC LIMIT 1`
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     models.VerificationRequest
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type", "status", "reject_reason"}).AddRow(5, 1, models.VerificationTypeIDDocument, models.VerificationStatusRejected, "blurry photo"))
+			},
+			want: models.VerificationRequest{
+				Model:        gorm.Model{ID: 5},
+				UserID:       1,
+				Type:         models.VerificationTypeIDDocument,
+				Status:       models.VerificationStatusRejected,
+				RejectReason: "blurry photo",
+			},
+			wantErr: false,
+		},
+		{
+			name: "success never submit",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			want:    models.VerificationRequest{},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(fmt.Errorf("some error"))
+			},
+			want:    models.VerificationRequest{},
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    models.VerificationRequest{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := VerificationStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.GetLatestVerification(1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerificationStore.GetLatestVerification() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VerificationStore.GetLatestVerification() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerificationStore_GetVerificationList(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     []models.VerificationRequest
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "verification_requests"  WHERE "verification_requests"."deleted_at" IS NULL AND ((status = $1)) ORDER BY created_at ASC LIMIT 10 OFFSET 10`)).
+					WithArgs(models.VerificationStatusPending).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type", "media_url", "status"}).AddRow(5, 1, models.VerificationTypeSelfie, "https://cdn.local/selfie.jpg", models.VerificationStatusPending))
+			},
+			want: []models.VerificationRequest{
+				{
+					Model:    gorm.Model{ID: 5},
+					UserID:   1,
+					Type:     models.VerificationTypeSelfie,
+					MediaURL: "https://cdn.local/selfie.jpg",
+					Status:   models.VerificationStatusPending,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "verification_requests"`)).WillReturnError(fmt.Errorf("some error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := VerificationStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.GetVerificationList(VerificationFilter{
+				Status: models.VerificationStatusPending,
+				Limit:  10,
+				Offset: 10,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerificationStore.GetVerificationList() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VerificationStore.GetVerificationList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerificationStore_CountVerification(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     int
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "verification_requests"  WHERE "verification_requests"."deleted_at" IS NULL AND ((status = $1))`)).
+					WithArgs(models.VerificationStatusPending).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+			},
+			want:    3,
+			wantErr: false,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "verification_requests"`)).WillReturnError(fmt.Errorf("some error"))
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "db is nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(nil)
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := VerificationStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			got, err := store.CountVerification(models.VerificationStatusPending)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerificationStore.CountVerification() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("VerificationStore.CountVerification() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerificationStore_ReviewVerification(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "verification_requests" SET "reject_reason" = $1, "reviewed_at" = $2, "reviewed_by" = $3, "status" = $4, "updated_at" = $5 WHERE "verification_requests"."deleted_at" IS NULL AND ((id = $6 AND status = $7))`)).
+					WithArgs("blurry photo", sqlmock.AnyArg(), 9, models.VerificationStatusRejected, sqlmock.AnyArg(), 5, models.VerificationStatusPending).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name: "error request is not pending",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "verification_requests"`)).WillReturnResult(sqlmock.NewResult(1, 0))
+				mockDB.ExpectCommit()
+			},
+			wantErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name: "error on db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB().Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "verification_requests"`)).WillReturnError(errSome)
+				mockDB.ExpectRollback()
+			},
+			wantErr: errSome,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := VerificationStore{
+				pg: pg,
+			}
+			tt.mockFunc()
+			err := store.ReviewVerification(models.VerificationRequest{
+				Model:        gorm.Model{ID: 5},
+				Status:       models.VerificationStatusRejected,
+				ReviewedBy:   9,
+				RejectReason: "blurry photo",
+			})
+			if err != tt.wantErr {
+				t.Errorf("VerificationStore.ReviewVerification() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
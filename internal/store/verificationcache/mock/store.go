@@ -0,0 +1,151 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/store/verificationcache/store.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	verificationcache "gilsaputro/dating-apps/internal/store/verificationcache"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockVerificationCacheStoreMethod is a mock of VerificationCacheStoreMethod interface.
+type MockVerificationCacheStoreMethod struct {
+	ctrl     *gomock.Controller
+	recorder *MockVerificationCacheStoreMethodMockRecorder
+}
+
+// MockVerificationCacheStoreMethodMockRecorder is the mock recorder for MockVerificationCacheStoreMethod.
+type MockVerificationCacheStoreMethodMockRecorder struct {
+	mock *MockVerificationCacheStoreMethod
+}
+
+// NewMockVerificationCacheStoreMethod creates a new mock instance.
+func NewMockVerificationCacheStoreMethod(ctrl *gomock.Controller) *MockVerificationCacheStoreMethod {
+	mock := &MockVerificationCacheStoreMethod{ctrl: ctrl}
+	mock.recorder = &MockVerificationCacheStoreMethodMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVerificationCacheStoreMethod) EXPECT() *MockVerificationCacheStoreMethodMockRecorder {
+	return m.recorder
+}
+
+// ConsumeEmailVerification mocks base method.
+func (m *MockVerificationCacheStoreMethod) ConsumeEmailVerification(token string) (verificationcache.EmailVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeEmailVerification", token)
+	ret0, _ := ret[0].(verificationcache.EmailVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeEmailVerification indicates an expected call of ConsumeEmailVerification.
+func (mr *MockVerificationCacheStoreMethodMockRecorder) ConsumeEmailVerification(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeEmailVerification", reflect.TypeOf((*MockVerificationCacheStoreMethod)(nil).ConsumeEmailVerification), token)
+}
+
+// ConsumeOAuthState mocks base method.
+func (m *MockVerificationCacheStoreMethod) ConsumeOAuthState(state string) (verificationcache.OAuthState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeOAuthState", state)
+	ret0, _ := ret[0].(verificationcache.OAuthState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeOAuthState indicates an expected call of ConsumeOAuthState.
+func (mr *MockVerificationCacheStoreMethodMockRecorder) ConsumeOAuthState(state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeOAuthState", reflect.TypeOf((*MockVerificationCacheStoreMethod)(nil).ConsumeOAuthState), state)
+}
+
+// ConsumePasswordReset mocks base method.
+func (m *MockVerificationCacheStoreMethod) ConsumePasswordReset(userID int) (verificationcache.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumePasswordReset", userID)
+	ret0, _ := ret[0].(verificationcache.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumePasswordReset indicates an expected call of ConsumePasswordReset.
+func (mr *MockVerificationCacheStoreMethodMockRecorder) ConsumePasswordReset(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumePasswordReset", reflect.TypeOf((*MockVerificationCacheStoreMethod)(nil).ConsumePasswordReset), userID)
+}
+
+// ConsumeTwoFactorChallenge mocks base method.
+func (m *MockVerificationCacheStoreMethod) ConsumeTwoFactorChallenge(token string) (verificationcache.TwoFactorChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeTwoFactorChallenge", token)
+	ret0, _ := ret[0].(verificationcache.TwoFactorChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeTwoFactorChallenge indicates an expected call of ConsumeTwoFactorChallenge.
+func (mr *MockVerificationCacheStoreMethodMockRecorder) ConsumeTwoFactorChallenge(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeTwoFactorChallenge", reflect.TypeOf((*MockVerificationCacheStoreMethod)(nil).ConsumeTwoFactorChallenge), token)
+}
+
+// SetEmailVerification mocks base method.
+func (m *MockVerificationCacheStoreMethod) SetEmailVerification(token string, info verificationcache.EmailVerification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEmailVerification", token, info)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEmailVerification indicates an expected call of SetEmailVerification.
+func (mr *MockVerificationCacheStoreMethodMockRecorder) SetEmailVerification(token, info interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEmailVerification", reflect.TypeOf((*MockVerificationCacheStoreMethod)(nil).SetEmailVerification), token, info)
+}
+
+// SetOAuthState mocks base method.
+func (m *MockVerificationCacheStoreMethod) SetOAuthState(state string, info verificationcache.OAuthState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOAuthState", state, info)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOAuthState indicates an expected call of SetOAuthState.
+func (mr *MockVerificationCacheStoreMethodMockRecorder) SetOAuthState(state, info interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOAuthState", reflect.TypeOf((*MockVerificationCacheStoreMethod)(nil).SetOAuthState), state, info)
+}
+
+// SetPasswordReset mocks base method.
+func (m *MockVerificationCacheStoreMethod) SetPasswordReset(userID int, info verificationcache.PasswordReset) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPasswordReset", userID, info)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPasswordReset indicates an expected call of SetPasswordReset.
+func (mr *MockVerificationCacheStoreMethodMockRecorder) SetPasswordReset(userID, info interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPasswordReset", reflect.TypeOf((*MockVerificationCacheStoreMethod)(nil).SetPasswordReset), userID, info)
+}
+
+// SetTwoFactorChallenge mocks base method.
+func (m *MockVerificationCacheStoreMethod) SetTwoFactorChallenge(token string, info verificationcache.TwoFactorChallenge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTwoFactorChallenge", token, info)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTwoFactorChallenge indicates an expected call of SetTwoFactorChallenge.
+func (mr *MockVerificationCacheStoreMethodMockRecorder) SetTwoFactorChallenge(token, info interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTwoFactorChallenge", reflect.TypeOf((*MockVerificationCacheStoreMethod)(nil).SetTwoFactorChallenge), token, info)
+}
@@ -0,0 +1,219 @@
+package verificationcache
+
+import (
+	"encoding/json"
+	"fmt"
+	"gilsaputro/dating-apps/pkg/redis"
+	"strings"
+	"time"
+)
+
+// VerificationCacheStoreMethod is set of methods for interacting with one time verification token storage system
+type VerificationCacheStoreMethod interface {
+	SetEmailVerification(token string, info EmailVerification) error
+	ConsumeEmailVerification(token string) (EmailVerification, error)
+	SetPasswordReset(userID int, info PasswordReset) error
+	ConsumePasswordReset(userID int) (PasswordReset, error)
+	SetTwoFactorChallenge(token string, info TwoFactorChallenge) error
+	ConsumeTwoFactorChallenge(token string) (TwoFactorChallenge, error)
+	SetOAuthState(state string, info OAuthState) error
+	ConsumeOAuthState(state string) (OAuthState, error)
+}
+
+// EmailVerification is the email waiting to be verified by the user
+type EmailVerification struct {
+	UserID int    `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+// PasswordReset is the reset password code waiting to be used by the user
+type PasswordReset struct {
+	Code string `json:"code"`
+	// Attempts is number of wrong code submitted for this code
+	Attempts  int       `json:"attempts"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+// TwoFactorChallenge is the login waiting for the two factor code after the password is valid
+type TwoFactorChallenge struct {
+	UserID int `json:"user_id"`
+	// Attempts is number of wrong code submitted for this challenge
+	Attempts  int       `json:"attempts"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+// OAuthState is the social login waiting for the callback from the provider
+type OAuthState struct {
+	Provider  string    `json:"provider"`
+	Nonce     string    `json:"nonce"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+// VerificationCacheStore is list dependencies verification cache store
+type VerificationCacheStore struct {
+	rd       redis.RedisMethod
+	emailTTL time.Duration
+}
+
+// NewVerificationCacheStore is func to generate VerificationCacheStoreMethod interface
+func NewVerificationCacheStore(rd redis.RedisMethod, emailTTL time.Duration) VerificationCacheStoreMethod {
+	return &VerificationCacheStore{
+		rd:       rd,
+		emailTTL: emailTTL,
+	}
+}
+
+const emailVerificationToken string = `EVT:%v` // format EVT:<token>
+
+// SetEmailVerification is func to store the email verification token until it is expired
+func (f *VerificationCacheStore) SetEmailVerification(token string, info EmailVerification) error {
+	value, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf(emailVerificationToken, token)
+	return f.rd.Set(key, string(value), f.emailTTL)
+}
+
+// ConsumeEmailVerification is func to get and delete the email verification token, so the token only can be used once
+// it returns empty info when the token is not exists or already expired
+func (f *VerificationCacheStore) ConsumeEmailVerification(token string) (EmailVerification, error) {
+	key := fmt.Sprintf(emailVerificationToken, token)
+	value, err := f.rd.GetDel(key)
+	if err != nil && strings.Contains(err.Error(), "redis: nil") {
+		return EmailVerification{}, nil
+	}
+
+	if err != nil {
+		return EmailVerification{}, err
+	}
+
+	var info EmailVerification
+	err = json.Unmarshal([]byte(value), &info)
+	if err != nil {
+		return EmailVerification{}, err
+	}
+
+	return info, nil
+}
+
+const passwordResetCode string = `PRC:%v` // format PRC:<userid>
+
+// SetPasswordReset is func to store the reset password code of the user until it is expired, the old code is replaced
+func (f *VerificationCacheStore) SetPasswordReset(userID int, info PasswordReset) error {
+	ttl := time.Until(info.ExpiredAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	value, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf(passwordResetCode, userID)
+	return f.rd.Set(key, string(value), ttl)
+}
+
+// ConsumePasswordReset is func to get and delete the reset password code of the user, so the code only can be used once
+// it returns empty info when the code is not exists or already expired
+func (f *VerificationCacheStore) ConsumePasswordReset(userID int) (PasswordReset, error) {
+	key := fmt.Sprintf(passwordResetCode, userID)
+	value, err := f.rd.GetDel(key)
+	if err != nil && strings.Contains(err.Error(), "redis: nil") {
+		return PasswordReset{}, nil
+	}
+
+	if err != nil {
+		return PasswordReset{}, err
+	}
+
+	var info PasswordReset
+	err = json.Unmarshal([]byte(value), &info)
+	if err != nil {
+		return PasswordReset{}, err
+	}
+
+	return info, nil
+}
+
+const twoFactorChallenge string = `TFC:%v` // format TFC:<token>
+
+// SetTwoFactorChallenge is func to store the two factor challenge token until it is expired
+func (f *VerificationCacheStore) SetTwoFactorChallenge(token string, info TwoFactorChallenge) error {
+	ttl := time.Until(info.ExpiredAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	value, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf(twoFactorChallenge, token)
+	return f.rd.Set(key, string(value), ttl)
+}
+
+// ConsumeTwoFactorChallenge is func to get and delete the two factor challenge token, so the token only can be used once
+// it returns empty info when the token is not exists or already expired
+func (f *VerificationCacheStore) ConsumeTwoFactorChallenge(token string) (TwoFactorChallenge, error) {
+	key := fmt.Sprintf(twoFactorChallenge, token)
+	value, err := f.rd.GetDel(key)
+	if err != nil && strings.Contains(err.Error(), "redis: nil") {
+		return TwoFactorChallenge{}, nil
+	}
+
+	if err != nil {
+		return TwoFactorChallenge{}, err
+	}
+
+	var info TwoFactorChallenge
+	err = json.Unmarshal([]byte(value), &info)
+	if err != nil {
+		return TwoFactorChallenge{}, err
+	}
+
+	return info, nil
+}
+
+const oauthState string = `OAS:%v` // format OAS:<state>
+
+// SetOAuthState is func to store the social login state until it is expired
+func (f *VerificationCacheStore) SetOAuthState(state string, info OAuthState) error {
+	ttl := time.Until(info.ExpiredAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	value, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf(oauthState, state)
+	return f.rd.Set(key, string(value), ttl)
+}
+
+// ConsumeOAuthState is func to get and delete the social login state, so the state only can be used once
+// it returns empty info when the state is not exists or already expired
+func (f *VerificationCacheStore) ConsumeOAuthState(state string) (OAuthState, error) {
+	key := fmt.Sprintf(oauthState, state)
+	value, err := f.rd.GetDel(key)
+	if err != nil && strings.Contains(err.Error(), "redis: nil") {
+		return OAuthState{}, nil
+	}
+
+	if err != nil {
+		return OAuthState{}, err
+	}
+
+	var info OAuthState
+	err = json.Unmarshal([]byte(value), &info)
+	if err != nil {
+		return OAuthState{}, err
+	}
+
+	return info, nil
+}
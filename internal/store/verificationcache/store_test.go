@@ -0,0 +1,450 @@
+package verificationcache
+
+import (
+	"fmt"
+	"gilsaputro/dating-apps/pkg/redis"
+	mock_redis "gilsaputro/dating-apps/pkg/redis/mock"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewVerificationCacheStore(t *testing.T) {
+	type args struct {
+		rd       redis.RedisMethod
+		emailTTL time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+		want VerificationCacheStoreMethod
+	}{
+		{
+			name: "success flow",
+			args: args{
+				rd:       &redis.RedisClient{},
+				emailTTL: time.Hour,
+			},
+			want: &VerificationCacheStore{
+				rd:       &redis.RedisClient{},
+				emailTTL: time.Hour,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewVerificationCacheStore(tt.args.rd, tt.args.emailTTL); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewVerificationCacheStore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerificationCacheStore_SetEmailVerification(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				rd.EXPECT().Set("EVT:token", `{"user_id":1,"email":"user@mail.com"}`, 24*time.Hour).Return(nil)
+			},
+		},
+		{
+			name: "error flow",
+			mockFunc: func() {
+				rd.EXPECT().Set("EVT:token", `{"user_id":1,"email":"user@mail.com"}`, 24*time.Hour).Return(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := VerificationCacheStore{
+				rd:       rd,
+				emailTTL: 24 * time.Hour,
+			}
+			tt.mockFunc()
+			err := s.SetEmailVerification("token", EmailVerification{UserID: 1, Email: "user@mail.com"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerificationCacheStore.SetEmailVerification() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerificationCacheStore_ConsumeEmailVerification(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     EmailVerification
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				rd.EXPECT().GetDel("EVT:token").Return(`{"user_id":1,"email":"user@mail.com"}`, nil)
+			},
+			want: EmailVerification{UserID: 1, Email: "user@mail.com"},
+		},
+		{
+			name: "token not exists flow",
+			mockFunc: func() {
+				rd.EXPECT().GetDel("EVT:token").Return("", fmt.Errorf("redis: nil"))
+			},
+			want: EmailVerification{},
+		},
+		{
+			name: "invalid value flow",
+			mockFunc: func() {
+				rd.EXPECT().GetDel("EVT:token").Return("abc", nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error flow",
+			mockFunc: func() {
+				rd.EXPECT().GetDel("EVT:token").Return("", fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := VerificationCacheStore{
+				rd: rd,
+			}
+			tt.mockFunc()
+			got, err := s.ConsumeEmailVerification("token")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerificationCacheStore.ConsumeEmailVerification() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VerificationCacheStore.ConsumeEmailVerification() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerificationCacheStore_SetPasswordReset(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	expiredAt := time.Now().Add(15 * time.Minute).Truncate(time.Second)
+	value := fmt.Sprintf(`{"code":"123456","attempts":1,"expired_at":"%s"}`, expiredAt.Format(time.RFC3339Nano))
+	tests := []struct {
+		name     string
+		info     PasswordReset
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			info: PasswordReset{Code: "123456", Attempts: 1, ExpiredAt: expiredAt},
+			mockFunc: func() {
+				rd.EXPECT().Set("PRC:1", value, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:     "expired code flow",
+			info:     PasswordReset{Code: "123456", ExpiredAt: time.Now().Add(-time.Minute)},
+			mockFunc: func() {},
+		},
+		{
+			name: "error flow",
+			info: PasswordReset{Code: "123456", Attempts: 1, ExpiredAt: expiredAt},
+			mockFunc: func() {
+				rd.EXPECT().Set("PRC:1", value, gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := VerificationCacheStore{
+				rd: rd,
+			}
+			tt.mockFunc()
+			if err := s.SetPasswordReset(1, tt.info); (err != nil) != tt.wantErr {
+				t.Errorf("VerificationCacheStore.SetPasswordReset() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerificationCacheStore_ConsumePasswordReset(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	expiredAt := time.Unix(1700000000, 0).UTC()
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     PasswordReset
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				rd.EXPECT().GetDel("PRC:1").Return(`{"code":"123456","attempts":2,"expired_at":"2023-11-14T22:13:20Z"}`, nil)
+			},
+			want: PasswordReset{Code: "123456", Attempts: 2, ExpiredAt: expiredAt},
+		},
+		{
+			name: "code not exists flow",
+			mockFunc: func() {
+				rd.EXPECT().GetDel("PRC:1").Return("", fmt.Errorf("redis: nil"))
+			},
+			want: PasswordReset{},
+		},
+		{
+			name: "invalid value flow",
+			mockFunc: func() {
+				rd.EXPECT().GetDel("PRC:1").Return("abc", nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error flow",
+			mockFunc: func() {
+				rd.EXPECT().GetDel("PRC:1").Return("", fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := VerificationCacheStore{
+				rd: rd,
+			}
+			tt.mockFunc()
+			got, err := s.ConsumePasswordReset(1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerificationCacheStore.ConsumePasswordReset() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VerificationCacheStore.ConsumePasswordReset() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerificationCacheStore_SetTwoFactorChallenge(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	expiredAt := time.Now().Add(15 * time.Minute).Truncate(time.Second)
+	value := fmt.Sprintf(`{"user_id":1,"attempts":1,"expired_at":"%s"}`, expiredAt.Format(time.RFC3339Nano))
+	tests := []struct {
+		name     string
+		info     TwoFactorChallenge
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			info: TwoFactorChallenge{UserID: 1, Attempts: 1, ExpiredAt: expiredAt},
+			mockFunc: func() {
+				rd.EXPECT().Set("TFC:token", value, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:     "expired challenge flow",
+			info:     TwoFactorChallenge{UserID: 1, ExpiredAt: time.Now().Add(-time.Minute)},
+			mockFunc: func() {},
+		},
+		{
+			name: "error flow",
+			info: TwoFactorChallenge{UserID: 1, Attempts: 1, ExpiredAt: expiredAt},
+			mockFunc: func() {
+				rd.EXPECT().Set("TFC:token", value, gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := VerificationCacheStore{
+				rd: rd,
+			}
+			tt.mockFunc()
+			if err := s.SetTwoFactorChallenge("token", tt.info); (err != nil) != tt.wantErr {
+				t.Errorf("VerificationCacheStore.SetTwoFactorChallenge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerificationCacheStore_ConsumeTwoFactorChallenge(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	expiredAt := time.Unix(1700000000, 0).UTC()
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     TwoFactorChallenge
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				rd.EXPECT().GetDel("TFC:token").Return(`{"user_id":1,"attempts":2,"expired_at":"2023-11-14T22:13:20Z"}`, nil)
+			},
+			want: TwoFactorChallenge{UserID: 1, Attempts: 2, ExpiredAt: expiredAt},
+		},
+		{
+			name: "challenge not exists flow",
+			mockFunc: func() {
+				rd.EXPECT().GetDel("TFC:token").Return("", fmt.Errorf("redis: nil"))
+			},
+			want: TwoFactorChallenge{},
+		},
+		{
+			name: "invalid value flow",
+			mockFunc: func() {
+				rd.EXPECT().GetDel("TFC:token").Return("abc", nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error flow",
+			mockFunc: func() {
+				rd.EXPECT().GetDel("TFC:token").Return("", fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := VerificationCacheStore{
+				rd: rd,
+			}
+			tt.mockFunc()
+			got, err := s.ConsumeTwoFactorChallenge("token")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerificationCacheStore.ConsumeTwoFactorChallenge() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VerificationCacheStore.ConsumeTwoFactorChallenge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerificationCacheStore_SetOAuthState(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	expiredAt := time.Now().Add(15 * time.Minute).Truncate(time.Second)
+	value := fmt.Sprintf(`{"provider":"google","nonce":"nonce","expired_at":"%s"}`, expiredAt.Format(time.RFC3339Nano))
+	tests := []struct {
+		name     string
+		info     OAuthState
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			info: OAuthState{Provider: "google", Nonce: "nonce", ExpiredAt: expiredAt},
+			mockFunc: func() {
+				rd.EXPECT().Set("OAS:state", value, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:     "expired state flow",
+			info:     OAuthState{Provider: "google", ExpiredAt: time.Now().Add(-time.Minute)},
+			mockFunc: func() {},
+		},
+		{
+			name: "error flow",
+			info: OAuthState{Provider: "google", Nonce: "nonce", ExpiredAt: expiredAt},
+			mockFunc: func() {
+				rd.EXPECT().Set("OAS:state", value, gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := VerificationCacheStore{
+				rd: rd,
+			}
+			tt.mockFunc()
+			if err := s.SetOAuthState("state", tt.info); (err != nil) != tt.wantErr {
+				t.Errorf("VerificationCacheStore.SetOAuthState() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerificationCacheStore_ConsumeOAuthState(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	rd := mock_redis.NewMockRedisMethod(mockCtrl)
+	expiredAt := time.Unix(1700000000, 0).UTC()
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     OAuthState
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				rd.EXPECT().GetDel("OAS:state").Return(`{"provider":"google","nonce":"nonce","expired_at":"2023-11-14T22:13:20Z"}`, nil)
+			},
+			want: OAuthState{Provider: "google", Nonce: "nonce", ExpiredAt: expiredAt},
+		},
+		{
+			name: "state not exists flow",
+			mockFunc: func() {
+				rd.EXPECT().GetDel("OAS:state").Return("", fmt.Errorf("redis: nil"))
+			},
+			want: OAuthState{},
+		},
+		{
+			name: "invalid value flow",
+			mockFunc: func() {
+				rd.EXPECT().GetDel("OAS:state").Return("abc", nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error flow",
+			mockFunc: func() {
+				rd.EXPECT().GetDel("OAS:state").Return("", fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := VerificationCacheStore{
+				rd: rd,
+			}
+			tt.mockFunc()
+			got, err := s.ConsumeOAuthState("state")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerificationCacheStore.ConsumeOAuthState() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VerificationCacheStore.ConsumeOAuthState() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
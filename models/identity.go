@@ -0,0 +1,14 @@
+package models
+
+import "github.com/jinzhu/gorm"
+
+// UserIdentity struct to the social login account linked to the user
+type UserIdentity struct {
+	gorm.Model
+	UserID uint `gorm:"not null;index"`
+	// Provider and Subject is unique id of the account on the social login provider
+	Provider string `gorm:"size:20;not null;unique_index:idx_identity_provider_subject"`
+	Subject  string `gorm:"not null;unique_index:idx_identity_provider_subject"`
+	// Email is the email of the account when the identity is linked
+	Email string
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Match struct to mutual match information between two user
+type Match struct {
+	gorm.Model
+	// UserID is always the smaller user id of the pair, so one pair of user only has one match
+	UserID    uint `gorm:"not null;unique_index:idx_match_user_partner"`
+	PartnerID uint `gorm:"not null;unique_index:idx_match_user_partner"`
+	Status    MatchStatus
+	MatchedAt time.Time
+	// UnmatchedBy and UnmatchedAt is set when one of the user unmatch the partner
+	UnmatchedBy uint
+	UnmatchedAt *time.Time
+}
+
+// NewMatch is func to create approved match between two user with ordered user id
+func NewMatch(userID, partnerID uint, matchedAt time.Time) Match {
+	if userID > partnerID {
+		userID, partnerID = partnerID, userID
+	}
+
+	return Match{
+		UserID:    userID,
+		PartnerID: partnerID,
+		Status:    MatchStatusApproved,
+		MatchedAt: matchedAt,
+	}
+}
+
+// GetPartnerID is func to get the other user id of the match
+func (m Match) GetPartnerID(userID uint) uint {
+	if m.UserID == userID {
+		return m.PartnerID
+	}
+	return m.UserID
+}
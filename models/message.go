@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Message struct to hold chat message between two matched user
+type Message struct {
+	gorm.Model
+	SenderID   uint   `gorm:"not null;index:idx_message_sender_receiver"`
+	ReceiverID uint   `gorm:"not null;index:idx_message_sender_receiver"`
+	Content    string `gorm:"not null"`
+	// ReadAt is set when the receiver read the message
+	ReadAt *time.Time
+}
@@ -0,0 +1,9 @@
+package models
+
+import "time"
+
+// SchemaMigration struct to the one time data migration that is already applied into the database
+type SchemaMigration struct {
+	Name      string `gorm:"primary_key;size:100"`
+	AppliedAt time.Time
+}
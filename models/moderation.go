@@ -0,0 +1,110 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// UserBlock struct to block information, the blocked pair is hidden from each other in both direction
+type UserBlock struct {
+	gorm.Model
+	UserID        uint `gorm:"not null;unique_index:idx_block_user_blocked"`
+	BlockedUserID uint `gorm:"not null;unique_index:idx_block_user_blocked"`
+}
+
+// GetOtherUserID is func to get the other user of the block pair
+func (b UserBlock) GetOtherUserID(userID uint) uint {
+	if b.UserID == userID {
+		return b.BlockedUserID
+	}
+	return b.UserID
+}
+
+// UserReport struct to user report information that need to be reviewed by admin
+type UserReport struct {
+	gorm.Model
+	ReporterID     uint `gorm:"not null;index"`
+	ReportedUserID uint `gorm:"not null;index"`
+	Reason         ReportReason
+	Note           string
+	Status         ReportStatus `gorm:"index"`
+	// ResolvedBy, ResolvedAt and Resolution is set when the admin resolve the report
+	ResolvedBy uint
+	ResolvedAt *time.Time
+	Resolution string
+}
+
+// ReportReason is the reason code of a user report
+type ReportReason int
+
+const (
+	ReportReasonUnknown       ReportReason = -1
+	ReportReasonSpam          ReportReason = 1
+	ReportReasonHarassment    ReportReason = 2
+	ReportReasonInappropriate ReportReason = 3
+	ReportReasonFakeProfile   ReportReason = 4
+	ReportReasonUnderage      ReportReason = 5
+	ReportReasonOther         ReportReason = 6
+)
+
+var ReportReasonToString = map[ReportReason]string{
+	ReportReasonUnknown:       "UNKNOWN",
+	ReportReasonSpam:          "SPAM",
+	ReportReasonHarassment:    "HARASSMENT",
+	ReportReasonInappropriate: "INAPPROPRIATE_CONTENT",
+	ReportReasonFakeProfile:   "FAKE_PROFILE",
+	ReportReasonUnderage:      "UNDERAGE",
+	ReportReasonOther:         "OTHER",
+}
+
+func (r ReportReason) String() string {
+	if val, ok := ReportReasonToString[r]; ok {
+		return val
+	}
+	return ReportReasonToString[-1]
+}
+
+// ParseReportReason is func to convert reason code into report reason, it will return unknown reason if the code is not supported
+func ParseReportReason(code string) ReportReason {
+	for reason, val := range ReportReasonToString {
+		if reason != ReportReasonUnknown && val == code {
+			return reason
+		}
+	}
+	return ReportReasonUnknown
+}
+
+// ReportStatus is the moderation status of a user report
+type ReportStatus int
+
+const (
+	ReportStatusUnknown   ReportStatus = -1
+	ReportStatusOpen      ReportStatus = 1
+	ReportStatusResolved  ReportStatus = 2
+	ReportStatusDismissed ReportStatus = 3
+)
+
+var ReportStatusToString = map[ReportStatus]string{
+	ReportStatusUnknown:   "UNKNOWN",
+	ReportStatusOpen:      "OPEN",
+	ReportStatusResolved:  "RESOLVED",
+	ReportStatusDismissed: "DISMISSED",
+}
+
+func (r ReportStatus) String() string {
+	if val, ok := ReportStatusToString[r]; ok {
+		return val
+	}
+	return ReportStatusToString[-1]
+}
+
+// ParseReportStatus is func to convert status name into report status, it will return unknown status if the name is not supported
+func ParseReportStatus(name string) ReportStatus {
+	for status, val := range ReportStatusToString {
+		if status != ReportStatusUnknown && val == name {
+			return status
+		}
+	}
+	return ReportStatusUnknown
+}
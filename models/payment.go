@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Payment struct to the checkout of the plan paid through the payment provider
+type Payment struct {
+	gorm.Model
+	UserID   uint   `gorm:"not null;index"`
+	Plan     string `gorm:"size:20;not null"`
+	Provider string `gorm:"size:20;not null"`
+	// Reference is generated when the checkout is created, every webhook event of the payment contains it
+	Reference string `gorm:"size:64;not null;unique_index"`
+	SessionID string
+	// Amount is in the smallest unit of the currency
+	Amount   int64
+	Currency string `gorm:"size:3"`
+	Status   string `gorm:"size:20;not null;index"`
+	// SubscriptionID is the subscription activated by the payment, it is revoked when the payment is refunded
+	SubscriptionID uint
+}
+
+// list of payment status, the status only move forward so the late webhook event cannot change the final status
+const (
+	PaymentStatusPending  = "PENDING"
+	PaymentStatusPaid     = "PAID"
+	PaymentStatusExpired  = "EXPIRED"
+	PaymentStatusRefunded = "REFUNDED"
+)
+
+// PaymentEvent struct to the webhook event received from the payment provider
+type PaymentEvent struct {
+	gorm.Model
+	// Provider and EventID is unique id of the event, the replayed event has the same id
+	Provider  string `gorm:"size:20;not null;unique_index:idx_payment_event_provider_event"`
+	EventID   string `gorm:"not null;unique_index:idx_payment_event_provider_event"`
+	Type      string `gorm:"size:50"`
+	Reference string `gorm:"size:64;index"`
+	Payload   string `gorm:"type:text"`
+	// ProcessedAt is nil when the event is failed to process, so the retry of the provider is processed again
+	ProcessedAt *time.Time
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// UserSession struct to device session created by every login, the session id is stored in the sid claim of the token
+type UserSession struct {
+	gorm.Model
+	SessionID  string `gorm:"not null;unique_index"`
+	UserID     uint   `gorm:"not null;index"`
+	Device     string
+	IPAddress  string
+	UserAgent  string
+	LastSeenAt time.Time
+	// RevokedAt is set when the user logout or revoke the session from other device
+	RevokedAt *time.Time
+}
+
+// IsRevoked is func to check the session can not be used anymore
+func (s UserSession) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
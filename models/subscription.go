@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Subscription struct to the premium plan subscribed by the user for a period, every renewal is stored as the next period
+// so the refund of one payment only revoke the period paid by it
+type Subscription struct {
+	gorm.Model
+	UserID    uint   `gorm:"not null;index"`
+	Plan      string `gorm:"size:20;not null"`
+	Status    string `gorm:"size:20;not null;index"`
+	StartedAt time.Time
+	ExpiredAt time.Time `gorm:"index"`
+	// CancelledAt is set when the user cancel the subscription, the plan is still active until it is expired
+	CancelledAt *time.Time
+	// PaymentReference is the payment that paid the period, the retry of the same payment does not create the period twice
+	PaymentReference string `gorm:"size:64;index"`
+}
+
+// list of plan tier, the user without active subscription is in the free plan
+const (
+	PlanFree    = "FREE"
+	PlanPlus    = "PLUS"
+	PlanPremium = "PREMIUM"
+)
+
+// list of subscription status
+const (
+	SubscriptionStatusActive    = "ACTIVE"
+	SubscriptionStatusCancelled = "CANCELLED"
+	SubscriptionStatusExpired   = "EXPIRED"
+)
+
+// IsActive is func to check the subscription still give the plan entitlements at the given time
+func (s Subscription) IsActive(now time.Time) bool {
+	return s.ID > 0 && s.Status != SubscriptionStatusExpired && now.Before(s.ExpiredAt)
+}
+
+// Entitlements is list of feature unlocked by the plan
+type Entitlements struct {
+	// UnlimitedSwipe skip the daily swipe quota
+	UnlimitedSwipe bool
+	Rewind         bool
+	// SeeLikesReceived show the liker profile instead of the redacted like
+	SeeLikesReceived bool
+	// ExtraSuperLike use the premium daily super like allowance
+	ExtraSuperLike bool
+}
+
+// planEntitlements is entitlements of each plan tier
+var planEntitlements = map[string]Entitlements{
+	PlanFree: {},
+	PlanPlus: {
+		UnlimitedSwipe: true,
+		Rewind:         true,
+	},
+	PlanPremium: {
+		UnlimitedSwipe:   true,
+		Rewind:           true,
+		SeeLikesReceived: true,
+		ExtraSuperLike:   true,
+	},
+}
+
+// GetEntitlements is func to get entitlements of the plan, unknown plan is treated as free plan
+func GetEntitlements(plan string) Entitlements {
+	return planEntitlements[plan]
+}
+
+// IsValidPaidPlan is func to check the plan can be subscribed
+func IsValidPaidPlan(plan string) bool {
+	switch plan {
+	case PlanPlus, PlanPremium:
+		return true
+	}
+	return false
+}
+
+// GetPlan is func to get the current plan of the user, the empty plan is the free plan
+func (u User) GetPlan() string {
+	if len(u.Plan) == 0 {
+		return PlanFree
+	}
+	return u.Plan
+}
+
+// IsPremium is func to check the user is subscribed to one of the paid plan
+func (u User) IsPremium() bool {
+	return u.GetPlan() != PlanFree
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// UserTwoFactor struct to two factor authentication setting of the user
+type UserTwoFactor struct {
+	gorm.Model
+	UserID uint   `gorm:"not null;unique_index"`
+	Secret string `gorm:"not null"`
+	// EnabledAt is nil until the user confirm the enrollment with a valid code
+	EnabledAt *time.Time
+	// RecoveryCodes is comma separated hashed recovery code, the used code is removed
+	RecoveryCodes string
+}
+
+// RecoveryCodeLength is length of the recovery code in hex without the separator
+const RecoveryCodeLength = 10
+
+// NormalizeRecoveryCode is func to remove the separator and case of the recovery code before it is hashed or compared
+func NormalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
+
+// IsEnabled is func to check the two factor authentication is already confirmed
+func (t UserTwoFactor) IsEnabled() bool {
+	return t.EnabledAt != nil
+}
+
+// GetRecoveryCodes is func to get list hashed recovery code
+func (t UserTwoFactor) GetRecoveryCodes() []string {
+	if len(t.RecoveryCodes) == 0 {
+		return []string{}
+	}
+	return strings.Split(t.RecoveryCodes, ",")
+}
+
+// SetRecoveryCodes is func to store list hashed recovery code
+func (t *UserTwoFactor) SetRecoveryCodes(codes []string) {
+	t.RecoveryCodes = strings.Join(codes, ",")
+}
@@ -1,13 +1,96 @@
 package models
 
-import "github.com/jinzhu/gorm"
+import (
+	"net/mail"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
 
 // User struct to user information
 type User struct {
 	gorm.Model
-	Username   string `gorm:"size:50;not null"`
-	Password   string `gorm:"not null"`
-	Fullname   string
-	Email      string
-	IsVerified bool
+	Username string `gorm:"size:50;not null"`
+	Password string `gorm:"not null"`
+	Fullname string
+	Email    string
+	// Plan is the plan of the active subscription, it is changed by the subscription and the downgrade job
+	Plan      string `gorm:"size:20"`
+	Birthdate *time.Time
+	Gender    string `gorm:"size:10"`
+	// InterestedIn is comma separated list of gender the user want to discover
+	InterestedIn string
+	PrefAgeMin   int
+	PrefAgeMax   int
+	Latitude     float64
+	Longitude    float64
+	// LocationUpdatedAt is nil when the user never share the location
+	LocationUpdatedAt *time.Time
+	// PrefMaxDistance is max partner distance in kilometer, 0 means no limit
+	PrefMaxDistance int
+	// IsAdmin is set manually for the user who can access the admin api
+	IsAdmin bool
+	// IsEmailVerified is true after the user open the link sent to the email, it is not related to the subscription plan
+	IsEmailVerified bool
+	// IsIdentityVerified is true after the admin approve the verification request of the user, it is shown as badge to other user
+	IsIdentityVerified bool
+}
+
+// list of supported gender
+const (
+	GenderMale   = "MALE"
+	GenderFemale = "FEMALE"
+	GenderOther  = "OTHER"
+)
+
+// list of user role stored in the token
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// Roles is func to get list role of the user
+func (u User) Roles() []string {
+	roles := []string{RoleUser}
+	if u.IsAdmin {
+		roles = append(roles, RoleAdmin)
+	}
+	return roles
+}
+
+// IsValidGender is func to check the gender is supported
+func IsValidGender(gender string) bool {
+	switch gender {
+	case GenderMale, GenderFemale, GenderOther:
+		return true
+	}
+	return false
+}
+
+// IsValidEmail is func to check the email is a single plain address
+func IsValidEmail(email string) bool {
+	address, err := mail.ParseAddress(email)
+	return err == nil && address.Address == email
+}
+
+// HasLocation is func to check the user already share the location
+func (u User) HasLocation() bool {
+	return u.LocationUpdatedAt != nil
+}
+
+// Age is func to get user age at the given time, it will return 0 if the birthdate is not set
+func (u User) Age(now time.Time) int {
+	if u.Birthdate == nil || u.Birthdate.IsZero() {
+		return 0
+	}
+
+	age := now.Year() - u.Birthdate.Year()
+	if now.Month() < u.Birthdate.Month() || (now.Month() == u.Birthdate.Month() && now.Day() < u.Birthdate.Day()) {
+		age--
+	}
+
+	if age < 0 {
+		return 0
+	}
+	return age
 }
@@ -9,6 +9,35 @@ type UserMatchHistory struct {
 	PartnerID   uint
 	PartnerName string
 	Status      MatchStatus
+	// Decision is the user decision to the partner, existing history before pass is stored are like
+	Decision DecisionType `gorm:"not null;default:1"`
+}
+
+// DecisionType is the user decision when swiping a partner
+type DecisionType int
+
+const (
+	DecisionUnknown   DecisionType = -1
+	DecisionLike      DecisionType = 1
+	DecisionPass      DecisionType = 2
+	DecisionSuperLike DecisionType = 3
+)
+
+// LikeDecisions is list decision that count as liking the partner
+var LikeDecisions = []DecisionType{DecisionLike, DecisionSuperLike}
+
+var DecisionTypeToString = map[DecisionType]string{
+	DecisionUnknown:   "UNKNOWN",
+	DecisionLike:      "LIKE",
+	DecisionPass:      "PASS",
+	DecisionSuperLike: "SUPER_LIKE",
+}
+
+func (d DecisionType) String() string {
+	if val, ok := DecisionTypeToString[d]; ok {
+		return val
+	}
+	return DecisionTypeToString[DecisionUnknown]
 }
 
 type MatchStatus int
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// VerificationRequest struct to the selfie or id document submitted by the user to prove the person is real,
+// the request is reviewed by admin and it is not related to the subscription plan
+type VerificationRequest struct {
+	gorm.Model
+	UserID uint `gorm:"not null;index"`
+	Type   VerificationType
+	// MediaURL is the uploaded selfie or id document, it is only shown to the admin
+	MediaURL string             `gorm:"not null"`
+	Status   VerificationStatus `gorm:"index"`
+	// ReviewedBy, ReviewedAt and RejectReason is set when the admin review the request
+	ReviewedBy   uint
+	ReviewedAt   *time.Time
+	RejectReason string
+}
+
+// VerificationType is the kind of media submitted for verification
+type VerificationType int
+
+const (
+	VerificationTypeUnknown    VerificationType = -1
+	VerificationTypeSelfie     VerificationType = 1
+	VerificationTypeIDDocument VerificationType = 2
+)
+
+var VerificationTypeToString = map[VerificationType]string{
+	VerificationTypeUnknown:    "UNKNOWN",
+	VerificationTypeSelfie:     "SELFIE",
+	VerificationTypeIDDocument: "ID_DOCUMENT",
+}
+
+func (v VerificationType) String() string {
+	if val, ok := VerificationTypeToString[v]; ok {
+		return val
+	}
+	return VerificationTypeToString[-1]
+}
+
+// ParseVerificationType is func to convert type name into verification type, it will return unknown type if the name is not supported
+func ParseVerificationType(name string) VerificationType {
+	for verificationType, val := range VerificationTypeToString {
+		if verificationType != VerificationTypeUnknown && val == name {
+			return verificationType
+		}
+	}
+	return VerificationTypeUnknown
+}
+
+// VerificationStatus is the review status of a verification request
+type VerificationStatus int
+
+const (
+	VerificationStatusUnknown  VerificationStatus = -1
+	VerificationStatusPending  VerificationStatus = 1
+	VerificationStatusApproved VerificationStatus = 2
+	VerificationStatusRejected VerificationStatus = 3
+)
+
+var VerificationStatusToString = map[VerificationStatus]string{
+	VerificationStatusUnknown:  "UNKNOWN",
+	VerificationStatusPending:  "PENDING",
+	VerificationStatusApproved: "APPROVED",
+	VerificationStatusRejected: "REJECTED",
+}
+
+func (v VerificationStatus) String() string {
+	if val, ok := VerificationStatusToString[v]; ok {
+		return val
+	}
+	return VerificationStatusToString[-1]
+}
+
+// ParseVerificationStatus is func to convert status name into verification status, it will return unknown status if the name is not supported
+func ParseVerificationStatus(name string) VerificationStatus {
+	for status, val := range VerificationStatusToString {
+		if status != VerificationStatusUnknown && val == name {
+			return status
+		}
+	}
+	return VerificationStatusUnknown
+}
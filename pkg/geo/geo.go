@@ -0,0 +1,78 @@
+package geo
+
+import "math"
+
+// earthRadiusKm is mean radius of the earth in kilometer
+const earthRadiusKm = 6371.0
+
+// Point is a coordinate on the earth in degree
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Box is a coordinate range that cover an area on the earth
+type Box struct {
+	MinLatitude  float64
+	MaxLatitude  float64
+	MinLongitude float64
+	MaxLongitude float64
+}
+
+// IsValid is func to check the point is inside valid latitude and longitude range
+func (p Point) IsValid() bool {
+	return p.Latitude >= -90 && p.Latitude <= 90 && p.Longitude >= -180 && p.Longitude <= 180
+}
+
+// Distance is func to calculate great circle distance between two point in kilometer using haversine formula
+func Distance(from, to Point) float64 {
+	lat1 := toRadian(from.Latitude)
+	lat2 := toRadian(to.Latitude)
+	deltaLat := toRadian(to.Latitude - from.Latitude)
+	deltaLng := toRadian(to.Longitude - from.Longitude)
+
+	a := math.Sin(deltaLat/2)*math.Sin(deltaLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(deltaLng/2)*math.Sin(deltaLng/2)
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+
+	return earthRadiusKm * c
+}
+
+// BoundingBox is func to get the box that cover all point within radius kilometer from center,
+// the box is used to pre-filter the point before calculate the exact distance
+func BoundingBox(center Point, radiusKm float64) Box {
+	deltaLat := toDegree(radiusKm / earthRadiusKm)
+	box := Box{
+		MinLatitude:  center.Latitude - deltaLat,
+		MaxLatitude:  center.Latitude + deltaLat,
+		MinLongitude: -180,
+		MaxLongitude: 180,
+	}
+
+	// the box cover one of the pole, so all longitude is inside the radius
+	if box.MinLatitude <= -90 || box.MaxLatitude >= 90 {
+		box.MinLatitude = math.Max(box.MinLatitude, -90)
+		box.MaxLatitude = math.Min(box.MaxLatitude, 90)
+		return box
+	}
+
+	deltaLng := toDegree(math.Asin(math.Sin(radiusKm/earthRadiusKm) / math.Cos(toRadian(center.Latitude))))
+	minLng := center.Longitude - deltaLng
+	maxLng := center.Longitude + deltaLng
+
+	// keep the full longitude range if the box cross the antimeridian
+	if minLng >= -180 && maxLng <= 180 {
+		box.MinLongitude = minLng
+		box.MaxLongitude = maxLng
+	}
+
+	return box
+}
+
+func toRadian(degree float64) float64 {
+	return degree * math.Pi / 180
+}
+
+func toDegree(radian float64) float64 {
+	return radian * 180 / math.Pi
+}
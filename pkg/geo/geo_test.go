@@ -0,0 +1,141 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	type args struct {
+		from Point
+		to   Point
+	}
+	tests := []struct {
+		name string
+		args args
+		want float64
+	}{
+		{
+			name: "same point",
+			args: args{
+				from: Point{Latitude: -6.2, Longitude: 106.8},
+				to:   Point{Latitude: -6.2, Longitude: 106.8},
+			},
+			want: 0,
+		},
+		{
+			name: "jakarta to bandung",
+			args: args{
+				from: Point{Latitude: -6.2088, Longitude: 106.8456},
+				to:   Point{Latitude: -6.9175, Longitude: 107.6191},
+			},
+			want: 116,
+		},
+		{
+			name: "london to paris",
+			args: args{
+				from: Point{Latitude: 51.5074, Longitude: -0.1278},
+				to:   Point{Latitude: 48.8566, Longitude: 2.3522},
+			},
+			want: 344,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Distance(tt.args.from, tt.args.to); math.Round(got) != tt.want {
+				t.Errorf("Distance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoundingBox(t *testing.T) {
+	type args struct {
+		center   Point
+		radiusKm float64
+	}
+	tests := []struct {
+		name string
+		args args
+		want Box
+	}{
+		{
+			name: "box near equator",
+			args: args{
+				center:   Point{Latitude: 0, Longitude: 0},
+				radiusKm: 111.19492664455873,
+			},
+			want: Box{MinLatitude: -1, MaxLatitude: 1, MinLongitude: -1, MaxLongitude: 1},
+		},
+		{
+			name: "box cover the pole",
+			args: args{
+				center:   Point{Latitude: 89.5, Longitude: 10},
+				radiusKm: 100,
+			},
+			want: Box{MinLatitude: 88.60067, MaxLatitude: 90, MinLongitude: -180, MaxLongitude: 180},
+		},
+		{
+			name: "box cross the antimeridian",
+			args: args{
+				center:   Point{Latitude: 0, Longitude: 179.9},
+				radiusKm: 111.19492664455873,
+			},
+			want: Box{MinLatitude: -1, MaxLatitude: 1, MinLongitude: -180, MaxLongitude: 180},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BoundingBox(tt.args.center, tt.args.radiusKm)
+			if !almostEqual(got.MinLatitude, tt.want.MinLatitude) || !almostEqual(got.MaxLatitude, tt.want.MaxLatitude) ||
+				!almostEqual(got.MinLongitude, tt.want.MinLongitude) || !almostEqual(got.MaxLongitude, tt.want.MaxLongitude) {
+				t.Errorf("BoundingBox() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoundingBox_containPointInsideRadius(t *testing.T) {
+	center := Point{Latitude: -6.2088, Longitude: 106.8456}
+	box := BoundingBox(center, 120)
+	bandung := Point{Latitude: -6.9175, Longitude: 107.6191}
+	if bandung.Latitude < box.MinLatitude || bandung.Latitude > box.MaxLatitude ||
+		bandung.Longitude < box.MinLongitude || bandung.Longitude > box.MaxLongitude {
+		t.Errorf("BoundingBox() = %+v, should contain %+v", box, bandung)
+	}
+}
+
+func TestPoint_IsValid(t *testing.T) {
+	tests := []struct {
+		name  string
+		point Point
+		want  bool
+	}{
+		{
+			name:  "valid point",
+			point: Point{Latitude: -6.2, Longitude: 106.8},
+			want:  true,
+		},
+		{
+			name:  "invalid latitude",
+			point: Point{Latitude: 91, Longitude: 106.8},
+			want:  false,
+		},
+		{
+			name:  "invalid longitude",
+			point: Point{Latitude: -6.2, Longitude: -181},
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.point.IsValid(); got != tt.want {
+				t.Errorf("Point.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 0.0001
+}
@@ -0,0 +1,47 @@
+package mailer
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileMailer is mailer for local development, the email is written to the file instead of sent
+type FileMailer struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileMailer is func to create File Mailer, the email is written to the standard log when the path is empty
+func NewFileMailer(path string) Mailer {
+	return &FileMailer{
+		path: path,
+	}
+}
+
+// SendMail is func to append the email into the file
+func (m *FileMailer) SendMail(to string, subject string, body string) error {
+	if err := validateHeader(to, subject); err != nil {
+		return err
+	}
+
+	content := fmt.Sprintf("Date: %s\nTo: %s\nSubject: %s\n\n%s\n\n", time.Now().Format(time.RFC1123Z), to, subject, body)
+	if len(m.path) == 0 {
+		log.Print("[FileMailer]-Send Mail :\n", content)
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, err := os.OpenFile(m.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(content)
+	return err
+}
@@ -0,0 +1,44 @@
+package mailer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileMailer_SendMail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.log")
+	m := NewFileMailer(path)
+
+	if err := m.SendMail("user@mail.com", "Verify your email", "first"); err != nil {
+		t.Fatalf("FileMailer.SendMail() error = %v", err)
+	}
+	if err := m.SendMail("user@mail.com", "Verify your email", "second"); err != nil {
+		t.Fatalf("FileMailer.SendMail() error = %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read mail file error = %v", err)
+	}
+	for _, want := range []string{"To: user@mail.com", "Subject: Verify your email", "first", "second"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("FileMailer.SendMail() content = %s, want contains %v", content, want)
+		}
+	}
+}
+
+func TestFileMailer_SendMailWithoutPath(t *testing.T) {
+	m := NewFileMailer("")
+	if err := m.SendMail("user@mail.com", "Verify your email", "hello"); err != nil {
+		t.Errorf("FileMailer.SendMail() error = %v", err)
+	}
+}
+
+func TestFileMailer_SendMailInvalidHeader(t *testing.T) {
+	m := NewFileMailer("")
+	if err := m.SendMail("user@mail.com", "subject\nBcc: other@mail.com", "hello"); err != ErrInvalidHeader {
+		t.Errorf("FileMailer.SendMail() error = %v, want %v", err, ErrInvalidHeader)
+	}
+}
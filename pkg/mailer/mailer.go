@@ -0,0 +1,30 @@
+package mailer
+
+import (
+	"errors"
+	"strings"
+)
+
+// list mailer type in the config
+const (
+	TypeSMTP = "smtp"
+	TypeFile = "file"
+)
+
+// ErrInvalidHeader is returned when the recipient or subject contains line break
+var ErrInvalidHeader = errors.New("mail header must not contain line break")
+
+// Mailer is list method to send email to the user
+type Mailer interface {
+	SendMail(to string, subject string, body string) error
+}
+
+// validateHeader is func to prevent header injection from the user input
+func validateHeader(values ...string) error {
+	for _, v := range values {
+		if strings.ContainsAny(v, "\r\n") {
+			return ErrInvalidHeader
+		}
+	}
+	return nil
+}
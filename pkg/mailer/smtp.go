@@ -0,0 +1,61 @@
+package mailer
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// sendMail is wrapper of smtp.SendMail, it is replaced on the unit test
+var sendMail = smtp.SendMail
+
+// SMTPConfig is list config to send email through SMTP server
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPMailer is mailer that send the email through SMTP server
+type SMTPMailer struct {
+	config SMTPConfig
+}
+
+// NewSMTPMailer is func to create SMTP Mailer
+func NewSMTPMailer(config SMTPConfig) Mailer {
+	return &SMTPMailer{
+		config: config,
+	}
+}
+
+// SendMail is func to send plain text email through SMTP server
+func (m *SMTPMailer) SendMail(to string, subject string, body string) error {
+	if err := validateHeader(to, subject); err != nil {
+		return err
+	}
+
+	// the auth is skipped for local SMTP server without credential
+	var auth smtp.Auth
+	if len(m.config.Username) > 0 {
+		auth = smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+	}
+
+	addr := net.JoinHostPort(m.config.Host, m.config.Port)
+	return sendMail(addr, auth, m.config.From, []string{to}, buildMessage(m.config.From, to, subject, body))
+}
+
+// buildMessage is func to build RFC 822 plain text message
+func buildMessage(from string, to string, subject string, body string) []byte {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("From: %s\r\n", from))
+	sb.WriteString(fmt.Sprintf("To: %s\r\n", to))
+	sb.WriteString(fmt.Sprintf("Subject: %s\r\n", subject))
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n")
+	sb.WriteString("\r\n")
+	sb.WriteString(body)
+	return []byte(sb.String())
+}
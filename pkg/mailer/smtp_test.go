@@ -0,0 +1,138 @@
+package mailer
+
+import (
+	"fmt"
+	"net/smtp"
+	"reflect"
+	"testing"
+)
+
+func TestNewSMTPMailer(t *testing.T) {
+	config := SMTPConfig{
+		Host: "localhost",
+		Port: "1025",
+		From: "no-reply@dating-apps.com",
+	}
+	want := &SMTPMailer{
+		config: config,
+	}
+	if got := NewSMTPMailer(config); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewSMTPMailer() = %v, want %v", got, want)
+	}
+}
+
+func TestSMTPMailer_SendMail(t *testing.T) {
+	type args struct {
+		to      string
+		subject string
+		body    string
+	}
+	tests := []struct {
+		name     string
+		config   SMTPConfig
+		args     args
+		sendErr  error
+		wantAuth bool
+		wantSent bool
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			config: SMTPConfig{
+				Host: "localhost",
+				Port: "1025",
+				From: "no-reply@dating-apps.com",
+			},
+			args: args{
+				to:      "user@mail.com",
+				subject: "Verify your email",
+				body:    "hello",
+			},
+			wantSent: true,
+		},
+		{
+			name: "success with auth flow",
+			config: SMTPConfig{
+				Host:     "localhost",
+				Port:     "587",
+				Username: "username",
+				Password: "password",
+				From:     "no-reply@dating-apps.com",
+			},
+			args: args{
+				to:      "user@mail.com",
+				subject: "Verify your email",
+				body:    "hello",
+			},
+			wantAuth: true,
+			wantSent: true,
+		},
+		{
+			name: "error send flow",
+			config: SMTPConfig{
+				Host: "localhost",
+				Port: "1025",
+				From: "no-reply@dating-apps.com",
+			},
+			args: args{
+				to:      "user@mail.com",
+				subject: "Verify your email",
+				body:    "hello",
+			},
+			sendErr:  fmt.Errorf("some error"),
+			wantSent: true,
+			wantErr:  true,
+		},
+		{
+			name: "error header injection flow",
+			config: SMTPConfig{
+				Host: "localhost",
+				Port: "1025",
+			},
+			args: args{
+				to:      "user@mail.com\r\nBcc: other@mail.com",
+				subject: "Verify your email",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var isSent bool
+			origin := sendMail
+			defer func() { sendMail = origin }()
+			sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+				isSent = true
+				if addr != tt.config.Host+":"+tt.config.Port {
+					t.Errorf("SMTPMailer.SendMail() addr = %v", addr)
+				}
+				if (a != nil) != tt.wantAuth {
+					t.Errorf("SMTPMailer.SendMail() auth = %v, wantAuth %v", a, tt.wantAuth)
+				}
+				if !reflect.DeepEqual(to, []string{tt.args.to}) {
+					t.Errorf("SMTPMailer.SendMail() to = %v", to)
+				}
+				wantMsg := buildMessage(tt.config.From, tt.args.to, tt.args.subject, tt.args.body)
+				if !reflect.DeepEqual(msg, wantMsg) {
+					t.Errorf("SMTPMailer.SendMail() msg = %s, want %s", msg, wantMsg)
+				}
+				return tt.sendErr
+			}
+
+			m := NewSMTPMailer(tt.config)
+			if err := m.SendMail(tt.args.to, tt.args.subject, tt.args.body); (err != nil) != tt.wantErr {
+				t.Errorf("SMTPMailer.SendMail() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if isSent != tt.wantSent {
+				t.Errorf("SMTPMailer.SendMail() isSent = %v, wantSent %v", isSent, tt.wantSent)
+			}
+		})
+	}
+}
+
+func Test_buildMessage(t *testing.T) {
+	want := "From: no-reply@dating-apps.com\r\nTo: user@mail.com\r\nSubject: Verify your email\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\nhello"
+	if got := string(buildMessage("no-reply@dating-apps.com", "user@mail.com", "Verify your email", "hello")); got != want {
+		t.Errorf("buildMessage() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,308 @@
+package oidc
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"gilsaputro/dating-apps/pkg/token"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// list supported social login provider
+const (
+	ProviderGoogle = "google"
+	ProviderApple  = "apple"
+)
+
+// ErrInvalidIDToken is returned when the id token from the provider is not valid
+var ErrInvalidIDToken = errors.New("invalid id token")
+
+// Identity is the account of the user on the provider taken from the verified id token
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// ProviderConfig is list config of OpenID Connect provider that use authorization code flow
+type ProviderConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	AuthURL      string
+	TokenURL     string
+	JWKSURL      string
+	Scopes       []string
+	// ResponseMode is set to form_post for the provider that post the callback instead of redirect (apple)
+	ResponseMode string
+}
+
+// Provider is list method of OpenID Connect provider
+type Provider interface {
+	AuthCodeURL(state, nonce string) string
+	Exchange(code, nonce string) (Identity, error)
+}
+
+// OIDCProvider is list dependencies of OIDC Package
+type OIDCProvider struct {
+	config     ProviderConfig
+	httpClient *http.Client
+
+	mu   sync.Mutex
+	keys map[string]*rsa.PublicKey
+}
+
+// Option set options for OIDC provider
+type Option func(*OIDCProvider)
+
+const defaultTimeout = 10 * time.Second
+
+var defaultScopes = []string{"openid", "email", "profile"}
+
+// NewProvider func to create Provider interface
+func NewProvider(config ProviderConfig, options ...Option) Provider {
+	if len(config.Scopes) == 0 {
+		config.Scopes = defaultScopes
+	}
+
+	p := &OIDCProvider{
+		config:     config,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		keys:       map[string]*rsa.PublicKey{},
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(p)
+	}
+
+	return p
+}
+
+// WithHTTPClientOptions is func to set the http client used to call the provider
+func WithHTTPClientOptions(client *http.Client) Option {
+	return Option(
+		func(p *OIDCProvider) {
+			if client != nil {
+				p.httpClient = client
+			}
+		})
+}
+
+// AuthCodeURL func to generate the provider login url, the state and nonce must be checked again on the callback
+func (p *OIDCProvider) AuthCodeURL(state, nonce string) string {
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	if len(p.config.ResponseMode) > 0 {
+		query.Set("response_mode", p.config.ResponseMode)
+	}
+
+	separator := "?"
+	if strings.Contains(p.config.AuthURL, "?") {
+		separator = "&"
+	}
+	return p.config.AuthURL + separator + query.Encode()
+}
+
+// tokenResponse is response of the provider token endpoint
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Exchange func to exchange the authorization code with the id token and return the verified identity
+func (p *OIDCProvider) Exchange(code, nonce string) (Identity, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("client_id", p.config.ClientID)
+	form.Set("client_secret", p.config.ClientSecret)
+
+	resp, err := p.httpClient.PostForm(p.config.TokenURL, form)
+	if err != nil {
+		return Identity{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return Identity{}, err
+	}
+
+	var tokenResp tokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return Identity{}, fmt.Errorf("invalid token response: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return Identity{}, fmt.Errorf("token request failed with status %v: %v %v", resp.StatusCode, tokenResp.Error, tokenResp.ErrorDescription)
+	}
+
+	if len(tokenResp.IDToken) == 0 {
+		return Identity{}, ErrInvalidIDToken
+	}
+
+	return p.verifyIDToken(tokenResp.IDToken, nonce)
+}
+
+// verifyIDToken func to validate the signature and claims of the id token
+func (p *OIDCProvider) verifyIDToken(idToken, nonce string) (Identity, error) {
+	claims := jwt.MapClaims{}
+	parsed, err := jwt.ParseWithClaims(idToken, claims, func(t *jwt.Token) (interface{}, error) {
+		if t.Method != jwt.SigningMethodRS256 {
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+
+		kid, _ := t.Header["kid"].(string)
+		return p.getKey(kid)
+	})
+	if err != nil || !parsed.Valid {
+		return Identity{}, ErrInvalidIDToken
+	}
+
+	// exp is required by the spec, the parser only validate it when it is exists
+	if _, ok := claims["exp"]; !ok {
+		return Identity{}, ErrInvalidIDToken
+	}
+
+	if !claims.VerifyIssuer(p.config.Issuer, true) || !hasAudience(claims["aud"], p.config.ClientID) {
+		return Identity{}, ErrInvalidIDToken
+	}
+
+	if value, _ := claims["nonce"].(string); value != nonce {
+		return Identity{}, ErrInvalidIDToken
+	}
+
+	subject, _ := claims["sub"].(string)
+	if len(subject) == 0 {
+		return Identity{}, ErrInvalidIDToken
+	}
+
+	email, _ := claims["email"].(string)
+	name, _ := claims["name"].(string)
+	return Identity{
+		Subject:       subject,
+		Email:         email,
+		EmailVerified: isTrue(claims["email_verified"]),
+		Name:          name,
+	}, nil
+}
+
+// hasAudience func to check the aud claim that can be a string or list of string
+func hasAudience(aud interface{}, clientID string) bool {
+	switch value := aud.(type) {
+	case string:
+		return value == clientID
+	case []interface{}:
+		for _, item := range value {
+			if s, ok := item.(string); ok && s == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isTrue func to read boolean claim, some provider (apple) send it as a string
+func isTrue(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+	return false
+}
+
+// getKey func to get the public key of the kid, the key set is fetched again when the kid is unknown because the provider rotate the key
+func (p *OIDCProvider) getKey(kid string) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+
+	keys, err := p.fetchKeys()
+	if err != nil {
+		return nil, err
+	}
+	p.keys = keys
+
+	key, ok := p.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("signing key %v is not found", kid)
+	}
+	return key, nil
+}
+
+// fetchKeys func to get the RSA public keys from the provider JWKS endpoint
+func (p *OIDCProvider) fetchKeys() (map[string]*rsa.PublicKey, error) {
+	resp, err := p.httpClient.Get(p.config.JWKSURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jwks request failed with status %v", resp.StatusCode)
+	}
+
+	var jwks token.JWKS
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&jwks); err != nil {
+		return nil, err
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, jwk := range jwks.Keys {
+		if jwk.KeyType != "RSA" {
+			continue
+		}
+
+		key, err := parseRSAKey(jwk)
+		if err != nil {
+			return nil, err
+		}
+		keys[jwk.KeyID] = key
+	}
+
+	return keys, nil
+}
+
+// parseRSAKey func to build RSA public key from the base64url modulus and exponent
+func parseRSAKey(jwk token.JWK) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus of key %v", jwk.KeyID)
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent of key %v", jwk.KeyID)
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
@@ -0,0 +1,309 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"gilsaputro/dating-apps/pkg/token"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// fakeProvider is local OpenID Connect provider that return the configured id token for every code
+type fakeProvider struct {
+	server  *httptest.Server
+	key     *rsa.PrivateKey
+	keyID   string
+	idToken string
+	status  int
+	// form is the last form received by the token endpoint
+	form url.Values
+}
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+
+	f := &fakeProvider{key: key, keyID: "key-1", status: http.StatusOK}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		f.form = r.PostForm
+		w.WriteHeader(f.status)
+		if f.status != http.StatusOK {
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "access", "token_type": "Bearer", "id_token": f.idToken})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(token.JWKS{Keys: []token.JWK{{
+			KeyType:   "RSA",
+			KeyID:     f.keyID,
+			Use:       "sig",
+			Algorithm: "RS256",
+			N:         base64.RawURLEncoding.EncodeToString(f.key.PublicKey.N.Bytes()),
+			E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(f.key.PublicKey.E)).Bytes()),
+		}}})
+	})
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeProvider) config() ProviderConfig {
+	return ProviderConfig{
+		Issuer:       f.server.URL,
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		RedirectURL:  "http://localhost/v1/oauth/google/callback",
+		AuthURL:      f.server.URL + "/auth",
+		TokenURL:     f.server.URL + "/token",
+		JWKSURL:      f.server.URL + "/jwks",
+	}
+}
+
+func (f *fakeProvider) sign(t *testing.T, method jwt.SigningMethod, kid string, claims jwt.MapClaims) string {
+	tok := jwt.NewWithClaims(method, claims)
+	tok.Header["kid"] = kid
+	var key interface{} = f.key
+	if method == jwt.SigningMethodHS256 {
+		key = []byte("secret")
+	}
+	signed, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	return signed
+}
+
+func (f *fakeProvider) claims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            f.server.URL,
+		"aud":            "client-id",
+		"sub":            "sub-1",
+		"email":          "user@mail.com",
+		"email_verified": true,
+		"name":           "User",
+		"nonce":          "nonce",
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	client := &http.Client{}
+	got, ok := NewProvider(ProviderConfig{ClientID: "id"}, WithHTTPClientOptions(client)).(*OIDCProvider)
+	if !ok {
+		t.Fatalf("NewProvider() type = %T, want *OIDCProvider", got)
+	}
+	if got.httpClient != client || len(got.config.Scopes) != len(defaultScopes) || got.keys == nil {
+		t.Errorf("NewProvider() = %+v", got)
+	}
+
+	got = NewProvider(ProviderConfig{Scopes: []string{"openid"}}, WithHTTPClientOptions(nil)).(*OIDCProvider)
+	if got.httpClient == nil || len(got.config.Scopes) != 1 {
+		t.Errorf("NewProvider() default option = %+v", got)
+	}
+}
+
+func TestOIDCProvider_AuthCodeURL(t *testing.T) {
+	p := NewProvider(ProviderConfig{
+		ClientID:     "client-id",
+		RedirectURL:  "http://localhost/callback",
+		AuthURL:      "https://appleid.apple.com/auth/authorize",
+		Scopes:       []string{"name", "email"},
+		ResponseMode: "form_post",
+	})
+
+	got, err := url.Parse(p.AuthCodeURL("state", "nonce"))
+	if err != nil {
+		t.Fatalf("url.Parse() error = %v", err)
+	}
+
+	want := map[string]string{
+		"response_type": "code",
+		"client_id":     "client-id",
+		"redirect_uri":  "http://localhost/callback",
+		"scope":         "name email",
+		"state":         "state",
+		"nonce":         "nonce",
+		"response_mode": "form_post",
+	}
+	query := got.Query()
+	for key, value := range want {
+		if query.Get(key) != value {
+			t.Errorf("OIDCProvider.AuthCodeURL() %v = %v, want %v", key, query.Get(key), value)
+		}
+	}
+	if got.Host != "appleid.apple.com" || got.Path != "/auth/authorize" {
+		t.Errorf("OIDCProvider.AuthCodeURL() = %v", got)
+	}
+}
+
+func TestOIDCProvider_Exchange(t *testing.T) {
+	f := newFakeProvider(t)
+	tests := []struct {
+		name    string
+		token   func() string
+		status  int
+		want    Identity
+		wantErr bool
+	}{
+		{
+			name: "success flow",
+			token: func() string {
+				return f.sign(t, jwt.SigningMethodRS256, f.keyID, f.claims())
+			},
+			want: Identity{Subject: "sub-1", Email: "user@mail.com", EmailVerified: true, Name: "User"},
+		},
+		{
+			name: "success string email verified and audience list",
+			token: func() string {
+				claims := f.claims()
+				claims["email_verified"] = "true"
+				claims["aud"] = []string{"other", "client-id"}
+				delete(claims, "name")
+				return f.sign(t, jwt.SigningMethodRS256, f.keyID, claims)
+			},
+			want: Identity{Subject: "sub-1", Email: "user@mail.com", EmailVerified: true},
+		},
+		{
+			name: "token endpoint error",
+			token: func() string {
+				return ""
+			},
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name: "missing id token",
+			token: func() string {
+				return ""
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid nonce",
+			token: func() string {
+				claims := f.claims()
+				claims["nonce"] = "other"
+				return f.sign(t, jwt.SigningMethodRS256, f.keyID, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid audience",
+			token: func() string {
+				claims := f.claims()
+				claims["aud"] = "other"
+				return f.sign(t, jwt.SigningMethodRS256, f.keyID, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid issuer",
+			token: func() string {
+				claims := f.claims()
+				claims["iss"] = "https://evil.com"
+				return f.sign(t, jwt.SigningMethodRS256, f.keyID, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "expired token",
+			token: func() string {
+				claims := f.claims()
+				claims["exp"] = time.Now().Add(-time.Minute).Unix()
+				return f.sign(t, jwt.SigningMethodRS256, f.keyID, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing expiry",
+			token: func() string {
+				claims := f.claims()
+				delete(claims, "exp")
+				return f.sign(t, jwt.SigningMethodRS256, f.keyID, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing subject",
+			token: func() string {
+				claims := f.claims()
+				delete(claims, "sub")
+				return f.sign(t, jwt.SigningMethodRS256, f.keyID, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown key id",
+			token: func() string {
+				return f.sign(t, jwt.SigningMethodRS256, "key-2", f.claims())
+			},
+			wantErr: true,
+		},
+		{
+			name: "unexpected signing method",
+			token: func() string {
+				return f.sign(t, jwt.SigningMethodHS256, f.keyID, f.claims())
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f.idToken = tt.token()
+			f.status = http.StatusOK
+			if tt.status != 0 {
+				f.status = tt.status
+			}
+
+			p := NewProvider(f.config())
+			got, err := p.Exchange("code", "nonce")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("OIDCProvider.Exchange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("OIDCProvider.Exchange() = %+v, want %+v", got, tt.want)
+			}
+			if f.form.Get("code") != "code" || f.form.Get("client_secret") != "client-secret" || f.form.Get("grant_type") != "authorization_code" {
+				t.Errorf("OIDCProvider.Exchange() form = %v", f.form)
+			}
+		})
+	}
+}
+
+func TestOIDCProvider_getKey(t *testing.T) {
+	f := newFakeProvider(t)
+	p := NewProvider(f.config()).(*OIDCProvider)
+
+	key, err := p.getKey(f.keyID)
+	if err != nil || key.N.Cmp(f.key.PublicKey.N) != 0 {
+		t.Fatalf("OIDCProvider.getKey() = %v, %v", key, err)
+	}
+
+	// the rotated key is fetched again because the kid is unknown
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+	f.key = newKey
+	f.keyID = "key-2"
+	key, err = p.getKey("key-2")
+	if err != nil || key.N.Cmp(newKey.PublicKey.N) != 0 {
+		t.Errorf("OIDCProvider.getKey() rotated = %v, %v", key, err)
+	}
+}
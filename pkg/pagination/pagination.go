@@ -0,0 +1,23 @@
+package pagination
+
+// Normalize is func to set default page and limit when the request is out of range
+func Normalize(page, limit, defaultLimit, maxLimit int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+
+	return page, NormalizeLimit(limit, defaultLimit, maxLimit)
+}
+
+// NormalizeLimit is func to set default limit when the limit is not set and cap the limit to the max limit
+func NormalizeLimit(limit, defaultLimit, maxLimit int) int {
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	return limit
+}
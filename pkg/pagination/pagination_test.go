@@ -0,0 +1,43 @@
+package pagination
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	type args struct {
+		page  int
+		limit int
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantPage  int
+		wantLimit int
+	}{
+		{
+			name:      "default value",
+			args:      args{},
+			wantPage:  1,
+			wantLimit: 10,
+		},
+		{
+			name:      "request value",
+			args:      args{page: 3, limit: 20},
+			wantPage:  3,
+			wantLimit: 20,
+		},
+		{
+			name:      "limit over max",
+			args:      args{page: 2, limit: 100},
+			wantPage:  2,
+			wantLimit: 50,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, limit := Normalize(tt.args.page, tt.args.limit, 10, 50)
+			if page != tt.wantPage || limit != tt.wantLimit {
+				t.Errorf("Normalize() = %v, %v, want %v, %v", page, limit, tt.wantPage, tt.wantLimit)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package payment
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// fakeCheckoutURL is the checkout page of the fake provider, the page is not exists
+const fakeCheckoutURL = "https://payment.fake.local/checkout/"
+
+// FakeProvider is in memory payment provider for the unit test and local development,
+// the webhook use the stripe format so it is handled by the same code as the real provider
+type FakeProvider struct {
+	webhookSecret string
+
+	mu       sync.Mutex
+	counter  int
+	sessions map[string]CheckoutRequest
+}
+
+// NewFakeProvider func to create fake payment provider
+func NewFakeProvider(webhookSecret string) *FakeProvider {
+	return &FakeProvider{
+		webhookSecret: webhookSecret,
+		sessions:      map[string]CheckoutRequest{},
+	}
+}
+
+// Name func to get the provider name
+func (f *FakeProvider) Name() string {
+	return ProviderFake
+}
+
+// CreateCheckoutSession func to store the checkout request in memory
+func (f *FakeProvider) CreateCheckoutSession(request CheckoutRequest) (CheckoutSession, error) {
+	if err := validateCheckout(request); err != nil {
+		return CheckoutSession{}, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.counter++
+	sessionID := fmt.Sprintf("cs_fake_%d", f.counter)
+	f.sessions[sessionID] = request
+	return CheckoutSession{
+		SessionID: sessionID,
+		URL:       fakeCheckoutURL + sessionID,
+	}, nil
+}
+
+// GetSession func to get the checkout request of the session
+func (f *FakeProvider) GetSession(sessionID string) (CheckoutRequest, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	request, ok := f.sessions[sessionID]
+	return request, ok
+}
+
+// ParseWebhook func to verify the signature and parse the webhook event
+func (f *FakeProvider) ParseWebhook(payload []byte, signature string) (Event, error) {
+	if err := verifySignature(f.webhookSecret, payload, signature, defaultTolerance, time.Now()); err != nil {
+		return Event{}, err
+	}
+
+	return parseStripeEvent(payload)
+}
+
+// NewWebhook func to build the signed stripe event of the session, the event type is the stripe event type
+// e.g. checkout.session.completed or charge.refunded
+func (f *FakeProvider) NewWebhook(eventID, eventType, sessionID string) (payload []byte, signature string, err error) {
+	request, ok := f.GetSession(sessionID)
+	if !ok {
+		return nil, "", fmt.Errorf("session %v is not found", sessionID)
+	}
+
+	object := map[string]interface{}{
+		"id":       sessionID,
+		"object":   "checkout.session",
+		"metadata": map[string]string{"reference": request.Reference},
+	}
+	switch eventType {
+	case "charge.refunded":
+		object["id"] = "ch_" + sessionID
+		object["object"] = "charge"
+	default:
+		object["client_reference_id"] = request.Reference
+		object["payment_status"] = "paid"
+	}
+
+	now := time.Now()
+	payload, err = json.Marshal(map[string]interface{}{
+		"id":      eventID,
+		"type":    eventType,
+		"created": now.Unix(),
+		"data":    map[string]interface{}{"object": object},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return payload, SignPayload(f.webhookSecret, payload, now), nil
+}
@@ -0,0 +1,54 @@
+package payment
+
+import (
+	"testing"
+)
+
+func TestFakeProvider(t *testing.T) {
+	provider := NewFakeProvider("whsec")
+	var _ PaymentProvider = provider
+
+	if _, err := provider.CreateCheckoutSession(CheckoutRequest{Reference: "ref-1"}); err != ErrInvalidCheckout {
+		t.Fatalf("FakeProvider.CreateCheckoutSession() error = %v, want %v", err, ErrInvalidCheckout)
+	}
+
+	session, err := provider.CreateCheckoutSession(CheckoutRequest{Reference: "ref-1", Amount: 999, Currency: "usd"})
+	if err != nil {
+		t.Fatalf("FakeProvider.CreateCheckoutSession() error = %v", err)
+	}
+	if session.SessionID != "cs_fake_1" || session.URL != fakeCheckoutURL+"cs_fake_1" {
+		t.Fatalf("FakeProvider.CreateCheckoutSession() = %v", session)
+	}
+
+	payload, signature, err := provider.NewWebhook("evt_1", "checkout.session.completed", session.SessionID)
+	if err != nil {
+		t.Fatalf("FakeProvider.NewWebhook() error = %v", err)
+	}
+	event, err := provider.ParseWebhook(payload, signature)
+	if err != nil {
+		t.Fatalf("FakeProvider.ParseWebhook() error = %v", err)
+	}
+	if event.ID != "evt_1" || event.Type != EventCheckoutCompleted || event.Reference != "ref-1" || event.SessionID != session.SessionID || !event.Paid {
+		t.Errorf("FakeProvider.ParseWebhook() = %v", event)
+	}
+
+	payload, signature, err = provider.NewWebhook("evt_2", "charge.refunded", session.SessionID)
+	if err != nil {
+		t.Fatalf("FakeProvider.NewWebhook() error = %v", err)
+	}
+	event, err = provider.ParseWebhook(payload, signature)
+	if err != nil {
+		t.Fatalf("FakeProvider.ParseWebhook() error = %v", err)
+	}
+	if event.Type != EventPaymentRefunded || event.Reference != "ref-1" {
+		t.Errorf("FakeProvider.ParseWebhook() = %v", event)
+	}
+
+	if _, err := provider.ParseWebhook(payload, "t=1,v1=abc"); err != ErrInvalidSignature {
+		t.Errorf("FakeProvider.ParseWebhook() error = %v, want %v", err, ErrInvalidSignature)
+	}
+
+	if _, _, err := provider.NewWebhook("evt_3", "checkout.session.completed", "cs_unknown"); err == nil {
+		t.Errorf("FakeProvider.NewWebhook() expect error for unknown session")
+	}
+}
@@ -0,0 +1,144 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// list payment provider type in the config
+const (
+	ProviderStripe = "stripe"
+	ProviderFake   = "fake"
+)
+
+// SignatureHeader is the header of the webhook request that contains the signature,
+// the fake provider use the same header because it follow the stripe format
+const SignatureHeader = "Stripe-Signature"
+
+// list webhook event type, the event of the provider is normalized into one of this type
+const (
+	EventCheckoutCompleted = "checkout.completed"
+	EventCheckoutExpired   = "checkout.expired"
+	EventPaymentRefunded   = "payment.refunded"
+)
+
+// list payment package error
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrInvalidPayload   = errors.New("invalid webhook payload")
+	ErrInvalidCheckout  = errors.New("invalid checkout request")
+	ErrUnknownProvider  = errors.New("unknown payment provider")
+)
+
+// defaultTolerance is max age of the webhook signature to prevent the old request from being replayed
+const defaultTolerance = 5 * time.Minute
+
+// CheckoutRequest is list parameter to create hosted checkout page
+type CheckoutRequest struct {
+	// Reference is generated by the caller, every webhook event of the payment contains the reference
+	Reference   string
+	Description string
+	// Amount is in the smallest unit of the currency
+	Amount        int64
+	Currency      string
+	CustomerEmail string
+	SuccessURL    string
+	CancelURL     string
+}
+
+// CheckoutSession is hosted checkout page created by the provider
+type CheckoutSession struct {
+	SessionID string
+	URL       string
+}
+
+// Event is the verified webhook event of the provider
+type Event struct {
+	ID string
+	// Type is empty when the event of the provider is not handled
+	Type      string
+	Reference string
+	SessionID string
+	// Paid is true when the money is already received, the checkout can be completed with the pending async payment
+	Paid      bool
+	CreatedAt time.Time
+}
+
+// PaymentProvider is list method of the payment provider
+type PaymentProvider interface {
+	Name() string
+	CreateCheckoutSession(request CheckoutRequest) (CheckoutSession, error)
+	ParseWebhook(payload []byte, signature string) (Event, error)
+}
+
+// validateCheckout is func to validate the checkout request before it is sent to the provider
+func validateCheckout(request CheckoutRequest) error {
+	if len(request.Reference) == 0 || request.Amount <= 0 || len(request.Currency) == 0 {
+		return ErrInvalidCheckout
+	}
+	return nil
+}
+
+// computeSignature is func to sign the payload with the stripe scheme, the signed value is "<timestamp>.<payload>"
+func computeSignature(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%d.", timestamp)))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignPayload is func to generate the signature header of the payload, it is used to send the webhook to the local server
+func SignPayload(secret string, payload []byte, signedAt time.Time) string {
+	timestamp := signedAt.Unix()
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, computeSignature(secret, timestamp, payload))
+}
+
+// verifySignature is func to verify the signature header with format "t=<timestamp>,v1=<signature>",
+// the header can contain more than one v1 signature when the secret is rotated
+func verifySignature(secret string, payload []byte, header string, tolerance time.Duration, now time.Time) error {
+	if len(secret) == 0 {
+		return ErrInvalidSignature
+	}
+
+	var timestamp int64 = -1
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "t":
+			value, err := strconv.ParseInt(kv[1], 10, 64)
+			if err != nil {
+				return ErrInvalidSignature
+			}
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, kv[1])
+		}
+	}
+
+	if timestamp < 0 || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+
+	signedAt := time.Unix(timestamp, 0)
+	if now.Sub(signedAt) > tolerance || signedAt.Sub(now) > tolerance {
+		return ErrInvalidSignature
+	}
+
+	expected := computeSignature(secret, timestamp, payload)
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
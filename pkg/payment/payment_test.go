@@ -0,0 +1,89 @@
+package payment
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func Test_verifySignature(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	payload := []byte(`{"id":"evt_1"}`)
+	valid := computeSignature("secret", now.Unix(), payload)
+	tests := []struct {
+		name    string
+		secret  string
+		payload []byte
+		header  string
+		wantErr error
+	}{
+		{
+			name:    "success flow",
+			secret:  "secret",
+			payload: payload,
+			header:  SignPayload("secret", payload, now),
+		},
+		{
+			name:    "success with rotated secret",
+			secret:  "secret",
+			payload: payload,
+			header:  fmt.Sprintf("t=%d,v1=%s,v1=%s", now.Unix(), computeSignature("old", now.Unix(), payload), valid),
+		},
+		{
+			name:    "error wrong secret",
+			secret:  "other",
+			payload: payload,
+			header:  SignPayload("secret", payload, now),
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "error payload is changed",
+			secret:  "secret",
+			payload: []byte(`{"id":"evt_2"}`),
+			header:  SignPayload("secret", payload, now),
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "error signature is too old",
+			secret:  "secret",
+			payload: payload,
+			header:  SignPayload("secret", payload, now.Add(-10*time.Minute)),
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "error missing timestamp",
+			secret:  "secret",
+			payload: payload,
+			header:  "v1=" + valid,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "error invalid timestamp",
+			secret:  "secret",
+			payload: payload,
+			header:  "t=abc,v1=" + valid,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "error missing signature",
+			secret:  "secret",
+			payload: payload,
+			header:  fmt.Sprintf("t=%d", now.Unix()),
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "error empty secret",
+			secret:  "",
+			payload: payload,
+			header:  SignPayload("", payload, now),
+			wantErr: ErrInvalidSignature,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifySignature(tt.secret, tt.payload, tt.header, defaultTolerance, now); err != tt.wantErr {
+				t.Errorf("verifySignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package payment
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultStripeBaseURL = "https://api.stripe.com"
+
+const defaultTimeout = 10 * time.Second
+
+// StripeConfig is list config of the stripe account
+type StripeConfig struct {
+	SecretKey     string
+	WebhookSecret string
+	// BaseURL is the stripe api url, it is changed to the stripe compatible server on the local development
+	BaseURL string
+}
+
+// StripeProvider is list dependencies of stripe payment provider
+type StripeProvider struct {
+	config     StripeConfig
+	httpClient *http.Client
+	tolerance  time.Duration
+	now        func() time.Time
+}
+
+// Option set options for stripe payment provider
+type Option func(*StripeProvider)
+
+// NewStripeProvider func to create PaymentProvider interface that use stripe checkout
+func NewStripeProvider(config StripeConfig, options ...Option) PaymentProvider {
+	if len(config.BaseURL) == 0 {
+		config.BaseURL = defaultStripeBaseURL
+	}
+
+	p := &StripeProvider{
+		config:     config,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		tolerance:  defaultTolerance,
+		now:        time.Now,
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(p)
+	}
+
+	return p
+}
+
+// WithHTTPClientOptions is func to set the http client used to call the stripe api
+func WithHTTPClientOptions(client *http.Client) Option {
+	return Option(
+		func(p *StripeProvider) {
+			if client != nil {
+				p.httpClient = client
+			}
+		})
+}
+
+// Name func to get the provider name
+func (p *StripeProvider) Name() string {
+	return ProviderStripe
+}
+
+// stripeCheckoutResponse is response of the create checkout session api
+type stripeCheckoutResponse struct {
+	ID    string `json:"id"`
+	URL   string `json:"url"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// CreateCheckoutSession func to create one time payment checkout page, the reference is stored in the session
+// and in the payment intent so the refund event of the charge can be matched to the payment
+func (p *StripeProvider) CreateCheckoutSession(request CheckoutRequest) (CheckoutSession, error) {
+	if err := validateCheckout(request); err != nil {
+		return CheckoutSession{}, err
+	}
+
+	form := url.Values{}
+	form.Set("mode", "payment")
+	form.Set("success_url", request.SuccessURL)
+	form.Set("cancel_url", request.CancelURL)
+	form.Set("client_reference_id", request.Reference)
+	form.Set("line_items[0][quantity]", "1")
+	form.Set("line_items[0][price_data][currency]", strings.ToLower(request.Currency))
+	form.Set("line_items[0][price_data][unit_amount]", strconv.FormatInt(request.Amount, 10))
+	form.Set("line_items[0][price_data][product_data][name]", request.Description)
+	form.Set("metadata[reference]", request.Reference)
+	form.Set("payment_intent_data[metadata][reference]", request.Reference)
+	if len(request.CustomerEmail) > 0 {
+		form.Set("customer_email", request.CustomerEmail)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, p.config.BaseURL+"/v1/checkout/sessions", strings.NewReader(form.Encode()))
+	if err != nil {
+		return CheckoutSession{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer "+p.config.SecretKey)
+	// the retry of the same reference return the same session instead of creating new one
+	req.Header.Set("Idempotency-Key", request.Reference)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return CheckoutSession{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return CheckoutSession{}, err
+	}
+
+	var checkoutResp stripeCheckoutResponse
+	if err := json.Unmarshal(body, &checkoutResp); err != nil {
+		return CheckoutSession{}, fmt.Errorf("invalid checkout response: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var message string
+		if checkoutResp.Error != nil {
+			message = checkoutResp.Error.Message
+		}
+		return CheckoutSession{}, fmt.Errorf("checkout request failed with status %v: %v", resp.StatusCode, message)
+	}
+
+	if len(checkoutResp.ID) == 0 || len(checkoutResp.URL) == 0 {
+		return CheckoutSession{}, fmt.Errorf("invalid checkout response: empty session")
+	}
+
+	return CheckoutSession{
+		SessionID: checkoutResp.ID,
+		URL:       checkoutResp.URL,
+	}, nil
+}
+
+// ParseWebhook func to verify the signature and parse the webhook event
+func (p *StripeProvider) ParseWebhook(payload []byte, signature string) (Event, error) {
+	if err := verifySignature(p.config.WebhookSecret, payload, signature, p.tolerance, p.now()); err != nil {
+		return Event{}, err
+	}
+
+	return parseStripeEvent(payload)
+}
+
+// stripeEvent is the webhook event of stripe, only the field of the checkout session and charge is parsed
+type stripeEvent struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Created int64  `json:"created"`
+	Data    struct {
+		Object struct {
+			ID                string            `json:"id"`
+			Object            string            `json:"object"`
+			ClientReferenceID string            `json:"client_reference_id"`
+			PaymentStatus     string            `json:"payment_status"`
+			Metadata          map[string]string `json:"metadata"`
+		} `json:"object"`
+	} `json:"data"`
+}
+
+// parseStripeEvent is func to normalize the stripe event
+func parseStripeEvent(payload []byte) (Event, error) {
+	var data stripeEvent
+	if err := json.Unmarshal(payload, &data); err != nil || len(data.ID) == 0 {
+		return Event{}, ErrInvalidPayload
+	}
+
+	object := data.Data.Object
+	event := Event{
+		ID:        data.ID,
+		Reference: object.ClientReferenceID,
+		CreatedAt: time.Unix(data.Created, 0),
+	}
+	if len(event.Reference) == 0 {
+		event.Reference = object.Metadata["reference"]
+	}
+
+	switch data.Type {
+	case "checkout.session.completed":
+		event.Type = EventCheckoutCompleted
+		event.SessionID = object.ID
+		event.Paid = object.PaymentStatus == "paid"
+	case "checkout.session.async_payment_succeeded":
+		event.Type = EventCheckoutCompleted
+		event.SessionID = object.ID
+		event.Paid = true
+	case "checkout.session.expired", "checkout.session.async_payment_failed":
+		event.Type = EventCheckoutExpired
+		event.SessionID = object.ID
+	case "charge.refunded":
+		event.Type = EventPaymentRefunded
+	}
+
+	return event, nil
+}
@@ -0,0 +1,219 @@
+package payment
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStripeProvider_CreateCheckoutSession(t *testing.T) {
+	var form url.Values
+	var header http.Header
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/checkout/sessions" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		r.ParseForm()
+		form = r.PostForm
+		header = r.Header
+		w.WriteHeader(status)
+		if status != http.StatusOK {
+			json.NewEncoder(w).Encode(map[string]interface{}{"error": map[string]string{"message": "invalid currency"}})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id": "cs_test_1", "url": "https://checkout.stripe.com/c/pay/cs_test_1"})
+	}))
+	defer server.Close()
+
+	provider := NewStripeProvider(StripeConfig{
+		SecretKey: "sk_test",
+		BaseURL:   server.URL,
+	}, WithHTTPClientOptions(server.Client()))
+	request := CheckoutRequest{
+		Reference:     "ref-1",
+		Description:   "PREMIUM Plan",
+		Amount:        999,
+		Currency:      "USD",
+		CustomerEmail: "a@mail.com",
+		SuccessURL:    "http://localhost/success",
+		CancelURL:     "http://localhost/cancel",
+	}
+
+	tests := []struct {
+		name    string
+		request CheckoutRequest
+		status  int
+		want    CheckoutSession
+		wantErr bool
+	}{
+		{
+			name:    "success flow",
+			request: request,
+			status:  http.StatusOK,
+			want: CheckoutSession{
+				SessionID: "cs_test_1",
+				URL:       "https://checkout.stripe.com/c/pay/cs_test_1",
+			},
+		},
+		{
+			name:    "error from stripe",
+			request: request,
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+		{
+			name:    "error invalid request",
+			request: CheckoutRequest{Reference: "ref-1", Currency: "USD"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status = tt.status
+			got, err := provider.CreateCheckoutSession(tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StripeProvider.CreateCheckoutSession() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StripeProvider.CreateCheckoutSession() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// the form of the success flow is overwritten by the error flow, so the request is checked again
+	status = http.StatusOK
+	provider.CreateCheckoutSession(request)
+	wantForm := map[string]string{
+		"mode":                                          "payment",
+		"client_reference_id":                           "ref-1",
+		"customer_email":                                "a@mail.com",
+		"line_items[0][price_data][currency]":           "usd",
+		"line_items[0][price_data][unit_amount]":        "999",
+		"line_items[0][price_data][product_data][name]": "PREMIUM Plan",
+		"metadata[reference]":                           "ref-1",
+		"payment_intent_data[metadata][reference]":      "ref-1",
+	}
+	for key, value := range wantForm {
+		if form.Get(key) != value {
+			t.Errorf("StripeProvider.CreateCheckoutSession() form %v = %v, want %v", key, form.Get(key), value)
+		}
+	}
+	if header.Get("Authorization") != "Bearer sk_test" || header.Get("Idempotency-Key") != "ref-1" {
+		t.Errorf("StripeProvider.CreateCheckoutSession() header = %v", header)
+	}
+}
+
+func TestStripeProvider_ParseWebhook(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	provider := &StripeProvider{
+		config:    StripeConfig{WebhookSecret: "whsec"},
+		tolerance: defaultTolerance,
+		now:       func() time.Time { return now },
+	}
+	tests := []struct {
+		name      string
+		payload   string
+		signature string
+		want      Event
+		wantErr   error
+	}{
+		{
+			name:    "success checkout completed",
+			payload: `{"id":"evt_1","type":"checkout.session.completed","created":1700000000,"data":{"object":{"id":"cs_1","client_reference_id":"ref-1","payment_status":"paid"}}}`,
+			want: Event{
+				ID:        "evt_1",
+				Type:      EventCheckoutCompleted,
+				Reference: "ref-1",
+				SessionID: "cs_1",
+				Paid:      true,
+				CreatedAt: now,
+			},
+		},
+		{
+			name:    "success checkout completed with pending async payment",
+			payload: `{"id":"evt_1","type":"checkout.session.completed","created":1700000000,"data":{"object":{"id":"cs_1","client_reference_id":"ref-1","payment_status":"unpaid"}}}`,
+			want: Event{
+				ID:        "evt_1",
+				Type:      EventCheckoutCompleted,
+				Reference: "ref-1",
+				SessionID: "cs_1",
+				CreatedAt: now,
+			},
+		},
+		{
+			name:    "success async payment succeeded",
+			payload: `{"id":"evt_2","type":"checkout.session.async_payment_succeeded","created":1700000000,"data":{"object":{"id":"cs_1","client_reference_id":"ref-1","payment_status":"paid"}}}`,
+			want: Event{
+				ID:        "evt_2",
+				Type:      EventCheckoutCompleted,
+				Reference: "ref-1",
+				SessionID: "cs_1",
+				Paid:      true,
+				CreatedAt: now,
+			},
+		},
+		{
+			name:    "success checkout expired",
+			payload: `{"id":"evt_3","type":"checkout.session.expired","created":1700000000,"data":{"object":{"id":"cs_1","client_reference_id":"ref-1"}}}`,
+			want: Event{
+				ID:        "evt_3",
+				Type:      EventCheckoutExpired,
+				Reference: "ref-1",
+				SessionID: "cs_1",
+				CreatedAt: now,
+			},
+		},
+		{
+			name:    "success charge refunded",
+			payload: `{"id":"evt_4","type":"charge.refunded","created":1700000000,"data":{"object":{"id":"ch_1","object":"charge","metadata":{"reference":"ref-1"}}}}`,
+			want: Event{
+				ID:        "evt_4",
+				Type:      EventPaymentRefunded,
+				Reference: "ref-1",
+				CreatedAt: now,
+			},
+		},
+		{
+			name:    "success not handled event",
+			payload: `{"id":"evt_5","type":"customer.created","created":1700000000,"data":{"object":{"id":"cus_1"}}}`,
+			want: Event{
+				ID:        "evt_5",
+				CreatedAt: now,
+			},
+		},
+		{
+			name:      "error invalid signature",
+			payload:   `{"id":"evt_1","type":"checkout.session.completed"}`,
+			signature: SignPayload("other", []byte(`{"id":"evt_1","type":"checkout.session.completed"}`), now),
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:    "error invalid payload",
+			payload: `{"type":"checkout.session.completed"}`,
+			wantErr: ErrInvalidPayload,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signature := tt.signature
+			if len(signature) == 0 {
+				signature = SignPayload("whsec", []byte(tt.payload), now)
+			}
+			got, err := provider.ParseWebhook([]byte(tt.payload), signature)
+			if err != tt.wantErr {
+				t.Errorf("StripeProvider.ParseWebhook() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StripeProvider.ParseWebhook() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
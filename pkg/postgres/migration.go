@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"fmt"
+	"gilsaputro/dating-apps/models"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Migration is one time data change applied after the auto migrate, it is recorded by the name
+// so it is applied once even when the server is restarted
+type Migration struct {
+	Name string
+	Up   func(tx *gorm.DB) error
+}
+
+// RunMigrations is func to apply the migration that is not applied yet in the given order, every migration
+// is applied in one transaction with the record, so the failed migration is applied again on the next start
+func RunMigrations(db *gorm.DB, migrations []Migration) error {
+	for _, migration := range migrations {
+		err := runMigration(db, migration)
+		if err != nil {
+			return fmt.Errorf("migration %v: %w", migration.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func runMigration(db *gorm.DB, migration Migration) error {
+	tx := db.Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+
+	// the lock make the other server instance wait until the migration is recorded
+	err := tx.Exec("LOCK TABLE schema_migrations IN EXCLUSIVE MODE").Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var count int
+	err = tx.Model(&models.SchemaMigration{}).Where("name = ?", migration.Name).Count(&count).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if count > 0 {
+		tx.Rollback()
+		return nil
+	}
+
+	err = migration.Up(tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Create(&models.SchemaMigration{
+		Name:      migration.Name,
+		AppliedAt: time.Now(),
+	}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
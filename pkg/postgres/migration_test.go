@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestRunMigrations(t *testing.T) {
+	type args struct {
+		migrations []Migration
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func(mock sqlmock.Sqlmock)
+		wantUp   int
+		wantErr  bool
+	}{
+		{
+			name: "success apply new migration",
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(`LOCK TABLE schema_migrations IN EXCLUSIVE MODE`)).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "schema_migrations"  WHERE (name = $1)`)).
+					WithArgs("0001_test").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE users SET plan = 'FREE'`)).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "schema_migrations" ("name","applied_at") VALUES ($1,$2) RETURNING "schema_migrations"."name"`)).
+					WithArgs("0001_test", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("0001_test"))
+				mock.ExpectCommit()
+			},
+			wantUp: 1,
+		},
+		{
+			name: "success skip applied migration",
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(`LOCK TABLE schema_migrations IN EXCLUSIVE MODE`)).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "schema_migrations"  WHERE (name = $1)`)).
+					WithArgs("0001_test").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectRollback()
+			},
+			wantUp: 0,
+		},
+		{
+			name: "failed migration is not recorded",
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(`LOCK TABLE schema_migrations IN EXCLUSIVE MODE`)).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "schema_migrations"  WHERE (name = $1)`)).
+					WithArgs("0001_test").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE users SET plan = 'FREE'`)).WillReturnError(errors.New("some error"))
+				mock.ExpectRollback()
+			},
+			wantUp:  1,
+			wantErr: true,
+		},
+		{
+			name: "failed lock",
+			mockFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(`LOCK TABLE schema_migrations IN EXCLUSIVE MODE`)).WillReturnError(errors.New("some error"))
+				mock.ExpectRollback()
+			},
+			wantUp:  0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			defer db.Close()
+			gormDB, _ := gorm.Open("postgres", db)
+			tt.mockFunc(mock)
+
+			var up int
+			migrations := []Migration{
+				{
+					Name: "0001_test",
+					Up: func(tx *gorm.DB) error {
+						up++
+						return tx.Exec("UPDATE users SET plan = 'FREE'").Error
+					},
+				},
+			}
+
+			err := RunMigrations(gormDB, migrations)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RunMigrations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if up != tt.wantUp {
+				t.Errorf("RunMigrations() up = %v, want %v", up, tt.wantUp)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
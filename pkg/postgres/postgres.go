@@ -33,7 +33,7 @@ func NewPostgresClient(config interface{}) (PostgresMethod, error) {
 		return nil, err
 	}
 	// Automatically create the table for the struct
-	db.AutoMigrate(&models.User{}, &models.UserMatchHistory{})
+	db.AutoMigrate(&models.User{}, &models.UserMatchHistory{}, &models.Match{}, &models.UserBlock{}, &models.UserReport{}, &models.Message{}, &models.UserTwoFactor{}, &models.UserSession{}, &models.UserIdentity{}, &models.Subscription{}, &models.Payment{}, &models.PaymentEvent{}, &models.VerificationRequest{}, &models.SchemaMigration{})
 	return &Client{db: db}, nil
 }
 
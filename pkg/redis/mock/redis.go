@@ -5,6 +5,7 @@
 package mock
 
 import (
+	context "context"
 	reflect "reflect"
 	time "time"
 
@@ -34,6 +35,20 @@ func (m *MockRedisMethod) EXPECT() *MockRedisMethodMockRecorder {
 	return m.recorder
 }
 
+// Del mocks base method.
+func (m *MockRedisMethod) Del(key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Del", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Del indicates an expected call of Del.
+func (mr *MockRedisMethodMockRecorder) Del(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockRedisMethod)(nil).Del), key)
+}
+
 // Get mocks base method.
 func (m *MockRedisMethod) Get(key string) (string, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +64,50 @@ func (mr *MockRedisMethodMockRecorder) Get(key interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRedisMethod)(nil).Get), key)
 }
 
+// GetDel mocks base method.
+func (m *MockRedisMethod) GetDel(key string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDel", key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDel indicates an expected call of GetDel.
+func (mr *MockRedisMethodMockRecorder) GetDel(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDel", reflect.TypeOf((*MockRedisMethod)(nil).GetDel), key)
+}
+
+// Incr mocks base method.
+func (m *MockRedisMethod) Incr(key string, expiration time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Incr", key, expiration)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Incr indicates an expected call of Incr.
+func (mr *MockRedisMethodMockRecorder) Incr(key, expiration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Incr", reflect.TypeOf((*MockRedisMethod)(nil).Incr), key, expiration)
+}
+
+// Publish mocks base method.
+func (m *MockRedisMethod) Publish(channel string, message interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", channel, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockRedisMethodMockRecorder) Publish(channel, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockRedisMethod)(nil).Publish), channel, message)
+}
+
 // Set mocks base method.
 func (m *MockRedisMethod) Set(key string, value interface{}, expiration time.Duration) error {
 	m.ctrl.T.Helper()
@@ -62,3 +121,32 @@ func (mr *MockRedisMethodMockRecorder) Set(key, value, expiration interface{}) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockRedisMethod)(nil).Set), key, value, expiration)
 }
+
+// SetNX mocks base method.
+func (m *MockRedisMethod) SetNX(key string, value interface{}, expiration time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNX", key, value, expiration)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetNX indicates an expected call of SetNX.
+func (mr *MockRedisMethodMockRecorder) SetNX(key, value, expiration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNX", reflect.TypeOf((*MockRedisMethod)(nil).SetNX), key, value, expiration)
+}
+
+// Subscribe mocks base method.
+func (m *MockRedisMethod) Subscribe(ctx context.Context, channel string, handler func(string)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, channel, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockRedisMethodMockRecorder) Subscribe(ctx, channel, handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockRedisMethod)(nil).Subscribe), ctx, channel, handler)
+}
@@ -18,7 +18,13 @@ type RedisConfig struct {
 // RedisMethod is list all available method for redis
 type RedisMethod interface {
 	Set(key string, value interface{}, expiration time.Duration) error
+	SetNX(key string, value interface{}, expiration time.Duration) (bool, error)
 	Get(key string) (string, error)
+	GetDel(key string) (string, error)
+	Del(key string) error
+	Incr(key string, expiration time.Duration) (int64, error)
+	Publish(channel string, message interface{}) error
+	Subscribe(ctx context.Context, channel string, handler func(payload string)) error
 }
 
 // RedisClient is a wrapper around the Redis client.
@@ -52,3 +58,59 @@ func (rc *RedisClient) Set(key string, value interface{}, expiration time.Durati
 func (rc *RedisClient) Get(key string) (string, error) {
 	return rc.client.Get(context.Background(), key).Result()
 }
+
+// GetDel gets the value for the given key and deletes the key atomically from Redis.
+func (rc *RedisClient) GetDel(key string) (string, error) {
+	return rc.client.GetDel(context.Background(), key).Result()
+}
+
+// Del deletes the given key from Redis.
+func (rc *RedisClient) Del(key string) error {
+	return rc.client.Del(context.Background(), key).Err()
+}
+
+// Incr increments the counter of the given key and resets its expiration, it returns the counter after increment.
+func (rc *RedisClient) Incr(key string, expiration time.Duration) (int64, error) {
+	ctx := context.Background()
+	pipe := rc.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, expiration)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+// SetNX sets the value for the given key only if the key does not exist, it returns false if the key already exists.
+func (rc *RedisClient) SetNX(key string, value interface{}, expiration time.Duration) (bool, error) {
+	return rc.client.SetNX(context.Background(), key, value, expiration).Result()
+}
+
+// Publish posts the message to the given channel in Redis.
+func (rc *RedisClient) Publish(channel string, message interface{}) error {
+	return rc.client.Publish(context.Background(), channel, message).Err()
+}
+
+// Subscribe listens to the given channel and calls the handler for each message, it blocks until the context is done.
+func (rc *RedisClient) Subscribe(ctx context.Context, channel string, handler func(payload string)) error {
+	pubsub := rc.client.Subscribe(ctx, channel)
+	defer pubsub.Close()
+
+	// wait for the subscription to be confirmed before receiving the message
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return err
+	}
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			handler(msg.Payload)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+func TestSigningMethodEd25519(t *testing.T) {
+	publicKey, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+	otherPublicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+
+	signature, err := SigningMethodEdDSA.Sign("header.payload", privateKey)
+	if err != nil {
+		t.Fatalf("SigningMethodEd25519.Sign() error = %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		signingString string
+		key           interface{}
+		wantErr       error
+	}{
+		{
+			name:          "valid signature",
+			signingString: "header.payload",
+			key:           publicKey,
+		},
+		{
+			name:          "tampered payload",
+			signingString: "header.other",
+			key:           publicKey,
+			wantErr:       jwt.ErrSignatureInvalid,
+		},
+		{
+			name:          "other key",
+			signingString: "header.payload",
+			key:           otherPublicKey,
+			wantErr:       jwt.ErrSignatureInvalid,
+		},
+		{
+			name:          "invalid key type",
+			signingString: "header.payload",
+			key:           []byte("secret"),
+			wantErr:       jwt.ErrInvalidKeyType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SigningMethodEdDSA.Verify(tt.signingString, signature, tt.key); err != tt.wantErr {
+				t.Errorf("SigningMethodEd25519.Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := SigningMethodEdDSA.Sign("header.payload", []byte("secret")); err != jwt.ErrInvalidKeyType {
+		t.Errorf("SigningMethodEd25519.Sign() error = %v, wantErr %v", err, jwt.ErrInvalidKeyType)
+	}
+}
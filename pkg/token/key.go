@@ -0,0 +1,135 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// list supported asymmetric algorithm of the signing key
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// SigningKey is private key to sign the token, the key id is stored in the kid header
+type SigningKey struct {
+	KeyID      string
+	Algorithm  string
+	PrivateKey crypto.Signer
+}
+
+// JWKS is list public key of the signing key in JSON Web Key Set format
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK is public key of a signing key in JSON Web Key format
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+// ParseSigningKeys is func to parse PEM private keys mapped by key id, the active key id must be one of the keys
+func ParseSigningKeys(activeKeyID string, pemKeys map[string]string) ([]SigningKey, error) {
+	keys := make([]SigningKey, 0, len(pemKeys))
+	for keyID, value := range pemKeys {
+		key, err := ParseSigningKey(keyID, []byte(value))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	// keep the order stable so the published key set does not change between instances
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].KeyID < keys[j].KeyID
+	})
+
+	if _, ok := findSigningKey(keys, activeKeyID); !ok {
+		return nil, fmt.Errorf("active signing key %v is not found", activeKeyID)
+	}
+
+	return keys, nil
+}
+
+// ParseSigningKey is func to parse PEM encoded RSA or Ed25519 private key
+func ParseSigningKey(keyID string, pemData []byte) (SigningKey, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return SigningKey{}, fmt.Errorf("invalid PEM of signing key %v", keyID)
+	}
+
+	var parsed interface{}
+	var err error
+	if block.Type == "RSA PRIVATE KEY" {
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	} else {
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("invalid signing key %v: %v", keyID, err)
+	}
+
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		return SigningKey{KeyID: keyID, Algorithm: AlgorithmRS256, PrivateKey: key}, nil
+	case ed25519.PrivateKey:
+		return SigningKey{KeyID: keyID, Algorithm: AlgorithmEdDSA, PrivateKey: key}, nil
+	}
+
+	return SigningKey{}, fmt.Errorf("unsupported type of signing key %v", keyID)
+}
+
+// signingMethod is func to get jwt signing method of the key
+func (k SigningKey) signingMethod() jwt.SigningMethod {
+	if k.Algorithm == AlgorithmEdDSA {
+		return SigningMethodEdDSA
+	}
+	return jwt.SigningMethodRS256
+}
+
+// publicJWK is func to generate public JWK of the key
+func (k SigningKey) publicJWK() JWK {
+	jwk := JWK{
+		KeyID:     k.KeyID,
+		Use:       "sig",
+		Algorithm: k.Algorithm,
+	}
+
+	switch publicKey := k.PrivateKey.Public().(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+	}
+
+	return jwk
+}
+
+func findSigningKey(keys []SigningKey, keyID string) (SigningKey, bool) {
+	for _, key := range keys {
+		if key.KeyID == keyID {
+			return key, true
+		}
+	}
+	return SigningKey{}, false
+}
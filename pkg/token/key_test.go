@@ -0,0 +1,131 @@
+package token
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+)
+
+func generateRSAPEM(t *testing.T) (*rsa.PrivateKey, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Error generate rsa key err = %v\n", err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return key, string(data)
+}
+
+func generateEd25519PEM(t *testing.T) (ed25519.PrivateKey, string) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Error generate ed25519 key err = %v\n", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("Error marshal ed25519 key err = %v\n", err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	return key, string(data)
+}
+
+func TestParseSigningKey(t *testing.T) {
+	_, rsaPEM := generateRSAPEM(t)
+	_, edPEM := generateEd25519PEM(t)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ecDER, _ := x509.MarshalPKCS8PrivateKey(ecKey)
+	ecPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecDER}))
+	tests := []struct {
+		name          string
+		pem           string
+		wantAlgorithm string
+		wantErr       bool
+	}{
+		{
+			name:          "rsa key",
+			pem:           rsaPEM,
+			wantAlgorithm: AlgorithmRS256,
+		},
+		{
+			name:          "ed25519 key",
+			pem:           edPEM,
+			wantAlgorithm: AlgorithmEdDSA,
+		},
+		{
+			name:    "unsupported ecdsa key",
+			pem:     ecPEM,
+			wantErr: true,
+		},
+		{
+			name:    "invalid pem",
+			pem:     "not a pem",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSigningKey("kid", []byte(tt.pem))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseSigningKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Algorithm != tt.wantAlgorithm {
+				t.Errorf("ParseSigningKey() algorithm = %v, want %v", got.Algorithm, tt.wantAlgorithm)
+			}
+		})
+	}
+}
+
+func TestParseSigningKeys(t *testing.T) {
+	_, rsaPEM := generateRSAPEM(t)
+	_, edPEM := generateEd25519PEM(t)
+
+	keys, err := ParseSigningKeys("key-2", map[string]string{
+		"key-2": edPEM,
+		"key-1": rsaPEM,
+	})
+	if err != nil {
+		t.Fatalf("ParseSigningKeys() error = %v", err)
+	}
+
+	if len(keys) != 2 || keys[0].KeyID != "key-1" || keys[1].KeyID != "key-2" {
+		t.Errorf("ParseSigningKeys() expect keys sorted by key id, got %v", keys)
+	}
+
+	if _, err := ParseSigningKeys("key-3", map[string]string{"key-1": rsaPEM}); err == nil {
+		t.Errorf("ParseSigningKeys() expect error when active key is missing")
+	}
+
+	if _, err := ParseSigningKeys("key-1", map[string]string{"key-1": "invalid"}); err == nil {
+		t.Errorf("ParseSigningKeys() expect error when key is invalid")
+	}
+}
+
+func TestSigningKey_publicJWK(t *testing.T) {
+	rsaKey, _ := generateRSAPEM(t)
+	edKey, _ := generateEd25519PEM(t)
+
+	got := SigningKey{KeyID: "rsa", Algorithm: AlgorithmRS256, PrivateKey: rsaKey}.publicJWK()
+	if got.KeyType != "RSA" || got.KeyID != "rsa" || got.Use != "sig" || got.Algorithm != AlgorithmRS256 || got.E != "AQAB" ||
+		got.N != base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()) {
+		t.Errorf("SigningKey.publicJWK() rsa = %v", got)
+	}
+
+	got = SigningKey{KeyID: "ed", Algorithm: AlgorithmEdDSA, PrivateKey: edKey}.publicJWK()
+	want := JWK{
+		KeyType:   "OKP",
+		KeyID:     "ed",
+		Use:       "sig",
+		Algorithm: AlgorithmEdDSA,
+		Curve:     "Ed25519",
+		X:         base64.RawURLEncoding.EncodeToString(edKey.Public().(ed25519.PublicKey)),
+	}
+	if got != want {
+		t.Errorf("SigningKey.publicJWK() ed25519 = %v, want %v", got, want)
+	}
+}
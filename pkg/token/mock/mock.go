@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: C:\Users\gilsp\go\src\dating-apps\pkg\token\token.go
+// Source: pkg/token/token.go
 
 // Package mock_token is a generated GoMock package.
 package mock_token
@@ -34,6 +34,21 @@ func (m *MockTokenMethod) EXPECT() *MockTokenMethodMockRecorder {
 	return m.recorder
 }
 
+// GenerateRefreshToken mocks base method.
+func (m *MockTokenMethod) GenerateRefreshToken(arg0 token.TokenBody) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateRefreshToken", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateRefreshToken indicates an expected call of GenerateRefreshToken.
+func (mr *MockTokenMethodMockRecorder) GenerateRefreshToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateRefreshToken", reflect.TypeOf((*MockTokenMethod)(nil).GenerateRefreshToken), arg0)
+}
+
 // GenerateToken mocks base method.
 func (m *MockTokenMethod) GenerateToken(arg0 token.TokenBody) (string, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +64,35 @@ func (mr *MockTokenMethodMockRecorder) GenerateToken(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockTokenMethod)(nil).GenerateToken), arg0)
 }
 
+// GetJWKS mocks base method.
+func (m *MockTokenMethod) GetJWKS() token.JWKS {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJWKS")
+	ret0, _ := ret[0].(token.JWKS)
+	return ret0
+}
+
+// GetJWKS indicates an expected call of GetJWKS.
+func (mr *MockTokenMethodMockRecorder) GetJWKS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWKS", reflect.TypeOf((*MockTokenMethod)(nil).GetJWKS))
+}
+
+// ValidateRefreshToken mocks base method.
+func (m *MockTokenMethod) ValidateRefreshToken(arg0 string) (token.TokenBody, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateRefreshToken", arg0)
+	ret0, _ := ret[0].(token.TokenBody)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateRefreshToken indicates an expected call of ValidateRefreshToken.
+func (mr *MockTokenMethodMockRecorder) ValidateRefreshToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateRefreshToken", reflect.TypeOf((*MockTokenMethod)(nil).ValidateRefreshToken), arg0)
+}
+
 // ValidateToken mocks base method.
 func (m *MockTokenMethod) ValidateToken(arg0 string) (token.TokenBody, error) {
 	m.ctrl.T.Helper()
@@ -1,54 +1,175 @@
 package token
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
 )
 
+// list token type stored in the typ claim
+const (
+	TypeAccess  = "access"
+	TypeRefresh = "refresh"
+)
+
 // TokenConfig is list dependencies of token package
 type TokenConfig struct {
-	Secret        string
-	ExpTimeInHour int64
+	Secret               string
+	ExpTimeInHour        int64
+	RefreshExpTimeInHour int64
+	// ActiveKeyID is kid of the signing key for new token, the shared secret is used when it is empty
+	ActiveKeyID string
+	// SigningKeys is the active and the previous signing key, the previous key is kept to validate the token issued before the rotation
+	SigningKeys []SigningKey
+	// Issuer and Audience are stored in the token and must match on validation when they are set
+	Issuer   string
+	Audience string
 }
 
+// Option set options for token config
+type Option func(*TokenConfig)
+
 // TokenMethod is method for Token Package
 type TokenMethod interface {
 	GenerateToken(TokenBody) (string, error)
+	GenerateRefreshToken(TokenBody) (string, error)
 	ValidateToken(string) (TokenBody, error)
+	ValidateRefreshToken(string) (TokenBody, error)
+	GetJWKS() JWKS
 }
 
 // TokenBody is list parameter that will be stored as token
 type TokenBody struct {
 	UserID int
+	Roles  []string
+	// Plan is the subscription plan of the user when the token is issued
+	Plan string
+	// IsEmailVerified is true when the user already verify the registered email
+	IsEmailVerified bool
+	// SessionID is shared by the access token and the refresh token of the same login
+	SessionID string
+	// TokenID, IssuedAt and ExpiredAt are generated when the token is created
+	TokenID   string
+	IssuedAt  time.Time
+	ExpiredAt time.Time
 }
 
 // NewTokenMethod is func to generate TokenMethod interface
-func NewTokenMethod(secret string, expinHour int64) TokenMethod {
-	return TokenConfig{
-		Secret:        secret,
-		ExpTimeInHour: expinHour,
+func NewTokenMethod(secret string, expinHour int64, refreshExpInHour int64, options ...Option) TokenMethod {
+	config := TokenConfig{
+		Secret:               secret,
+		ExpTimeInHour:        expinHour,
+		RefreshExpTimeInHour: refreshExpInHour,
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(&config)
 	}
+
+	return config
+}
+
+// WithIssuerOptions is func to set issuer and audience of the token
+func WithIssuerOptions(issuer, audience string) Option {
+	return Option(
+		func(t *TokenConfig) {
+			t.Issuer = issuer
+			t.Audience = audience
+		})
+}
+
+// GenerateSessionID is func to generate random session id for a new login
+func GenerateSessionID() (string, error) {
+	return generateTokenID()
+}
+
+// WithSigningKeys is func to sign the token with asymmetric key instead of the shared secret
+func WithSigningKeys(activeKeyID string, keys []SigningKey) Option {
+	return Option(
+		func(t *TokenConfig) {
+			t.ActiveKeyID = activeKeyID
+			t.SigningKeys = keys
+		})
 }
 
-// GenerateToken is func to generate token from body
+// GenerateToken is func to generate access token from body
 func (t TokenConfig) GenerateToken(body TokenBody) (string, error) {
+	return t.generate(body, TypeAccess, time.Hour*time.Duration(t.ExpTimeInHour))
+}
+
+// GenerateRefreshToken is func to generate refresh token from body
+func (t TokenConfig) GenerateRefreshToken(body TokenBody) (string, error) {
+	return t.generate(body, TypeRefresh, time.Hour*time.Duration(t.RefreshExpTimeInHour))
+}
+
+// ValidateToken is func to validate and generate body from access token
+func (t TokenConfig) ValidateToken(tokenString string) (TokenBody, error) {
+	return t.validate(tokenString, TypeAccess)
+}
+
+// ValidateRefreshToken is func to validate and generate body from refresh token
+func (t TokenConfig) ValidateRefreshToken(tokenString string) (TokenBody, error) {
+	return t.validate(tokenString, TypeRefresh)
+}
+
+// GetJWKS is func to get public key of all signing key, so other service can validate the token without the private key
+func (t TokenConfig) GetJWKS() JWKS {
+	jwks := JWKS{
+		Keys: []JWK{},
+	}
+	for _, key := range t.SigningKeys {
+		jwks.Keys = append(jwks.Keys, key.publicJWK())
+	}
+	return jwks
+}
+
+func (t TokenConfig) generate(body TokenBody, tokenType string, expiration time.Duration) (string, error) {
+	tokenID, err := generateTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
 	claims := jwt.MapClaims{
-		"userid": body.UserID,
-		"exp":    time.Now().Add(time.Hour * time.Duration(t.ExpTimeInHour)).Unix(),
+		"userid":         body.UserID,
+		"roles":          body.Roles,
+		"plan":           body.Plan,
+		"email_verified": body.IsEmailVerified,
+		"sid":            body.SessionID,
+		"jti":            tokenID,
+		"typ":            tokenType,
+		"iat":            now.Unix(),
+		"exp":            now.Add(expiration).Unix(),
+	}
+	if len(t.Issuer) > 0 {
+		claims["iss"] = t.Issuer
+	}
+	if len(t.Audience) > 0 {
+		claims["aud"] = t.Audience
+	}
+
+	if len(t.ActiveKeyID) == 0 {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString([]byte(t.Secret))
+	}
+
+	key, ok := findSigningKey(t.SigningKeys, t.ActiveKeyID)
+	if !ok {
+		return "", fmt.Errorf("signing key %v is not found", t.ActiveKeyID)
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token := jwt.NewWithClaims(key.signingMethod(), claims)
+	token.Header["kid"] = key.KeyID
 
-	return token.SignedString([]byte(t.Secret))
+	return token.SignedString(key.PrivateKey)
 }
 
-// ValidateToken is func to validate and generate body from token
-func (t TokenConfig) ValidateToken(tokenString string) (TokenBody, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return []byte(t.Secret), nil
-	})
+func (t TokenConfig) validate(tokenString string, tokenType string) (TokenBody, error) {
+	token, err := jwt.Parse(tokenString, t.verificationKey)
 
 	if err != nil {
 		return TokenBody{}, err
@@ -60,9 +181,89 @@ func (t TokenConfig) ValidateToken(tokenString string) (TokenBody, error) {
 			return TokenBody{}, fmt.Errorf("Invalid Token")
 		}
 
+		// the refresh token must not be accepted as access token and vice versa
+		typ, _ := claims["typ"].(string)
+		if typ != tokenType {
+			return TokenBody{}, fmt.Errorf("Invalid Token")
+		}
+
+		// the token without jti can not be revoked
+		tokenID, _ := claims["jti"].(string)
+		if len(tokenID) == 0 {
+			return TokenBody{}, fmt.Errorf("Invalid Token")
+		}
+
+		exp, ok := claims["exp"].(float64)
+		if !ok {
+			return TokenBody{}, fmt.Errorf("Invalid Token")
+		}
+
+		iat, ok := claims["iat"].(float64)
+		if !ok {
+			return TokenBody{}, fmt.Errorf("Invalid Token")
+		}
+
+		if len(t.Issuer) > 0 && !claims.VerifyIssuer(t.Issuer, true) {
+			return TokenBody{}, fmt.Errorf("Invalid Token")
+		}
+
+		if len(t.Audience) > 0 && !claims.VerifyAudience(t.Audience, true) {
+			return TokenBody{}, fmt.Errorf("Invalid Token")
+		}
+
+		var roles []string
+		if values, ok := claims["roles"].([]interface{}); ok {
+			for _, value := range values {
+				if role, ok := value.(string); ok {
+					roles = append(roles, role)
+				}
+			}
+		}
+		plan, _ := claims["plan"].(string)
+		isEmailVerified, _ := claims["email_verified"].(bool)
+		sessionID, _ := claims["sid"].(string)
+
 		if userIDFloat64 > 0 {
-			return TokenBody{UserID: int(userIDFloat64)}, nil
+			return TokenBody{
+				UserID:          int(userIDFloat64),
+				Roles:           roles,
+				Plan:            plan,
+				IsEmailVerified: isEmailVerified,
+				SessionID:       sessionID,
+				TokenID:         tokenID,
+				IssuedAt:        time.Unix(int64(iat), 0),
+				ExpiredAt:       time.Unix(int64(exp), 0),
+			}, nil
 		}
 	}
 	return TokenBody{}, fmt.Errorf("Invalid Token")
 }
+
+// verificationKey is func to get the key to verify the token signature based on the kid header
+func (t TokenConfig) verificationKey(token *jwt.Token) (interface{}, error) {
+	keyID, _ := token.Header["kid"].(string)
+	if len(keyID) == 0 {
+		// the shared secret is only accepted when the asymmetric key is not configured
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok || len(t.SigningKeys) > 0 {
+			return nil, fmt.Errorf("Invalid Token")
+		}
+		return []byte(t.Secret), nil
+	}
+
+	// the algorithm must follow the key, so the public key can not be used as hmac secret
+	key, ok := findSigningKey(t.SigningKeys, keyID)
+	if !ok || token.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("Invalid Token")
+	}
+
+	return key.PrivateKey.Public(), nil
+}
+
+// generateTokenID is func to generate random jti
+func generateTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -3,6 +3,7 @@ package token
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/dgrijalva/jwt-go"
 )
@@ -65,6 +66,52 @@ func TestTokenConfig_GenerateToken(t *testing.T) {
 			wantErr:         false,
 			want:            TokenBody{},
 		},
+		{
+			name: "error validate refresh token as access token flow",
+			tr: TokenConfig{
+				Secret:               "my_secret_key",
+				ExpTimeInHour:        1,
+				RefreshExpTimeInHour: 1,
+			},
+			args: args{
+				bodyGenerate: TokenBody{
+					UserID: 1,
+				},
+			},
+			mockFunc: func(s string) string {
+				tkn, _ := TokenConfig{Secret: "my_secret_key", RefreshExpTimeInHour: 1}.GenerateRefreshToken(TokenBody{UserID: 1})
+				return tkn
+			},
+			wantErrValidate: true,
+			wantErr:         false,
+			want:            TokenBody{},
+		},
+		{
+			name: "error validate token without jti flow",
+			tr: TokenConfig{
+				Secret:        "my_secret_key",
+				ExpTimeInHour: 1,
+			},
+			args: args{
+				bodyGenerate: TokenBody{
+					UserID: 1,
+				},
+			},
+			mockFunc: func(s string) string {
+				claims := jwt.MapClaims{
+					"userid": 1,
+					"typ":    TypeAccess,
+					"exp":    time.Now().Add(time.Hour).Unix(),
+				}
+
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+				tkn, _ := token.SignedString([]byte("my_secret_key"))
+				return tkn
+			},
+			wantErrValidate: true,
+			wantErr:         false,
+			want:            TokenBody{},
+		},
 		{
 			name: "error validate invalid value flow",
 			tr: TokenConfig{
@@ -108,7 +155,7 @@ func TestTokenConfig_GenerateToken(t *testing.T) {
 				}
 
 				if !tt.wantErrValidate {
-					if (tt.want) != gotData {
+					if tt.want.UserID != gotData.UserID || len(gotData.TokenID) == 0 || gotData.ExpiredAt.Before(time.Now()) {
 						t.Errorf("TokenConfig.ValidateToken() got = %v, want %v", gotData, tt.want)
 						return
 					}
@@ -118,10 +165,47 @@ func TestTokenConfig_GenerateToken(t *testing.T) {
 	}
 }
 
+func TestTokenConfig_GenerateRefreshToken(t *testing.T) {
+	tr := TokenConfig{
+		Secret:               "my_secret_key",
+		ExpTimeInHour:        1,
+		RefreshExpTimeInHour: 24,
+	}
+
+	refreshToken, err := tr.GenerateRefreshToken(TokenBody{UserID: 1})
+	if err != nil {
+		t.Fatalf("TokenConfig.GenerateRefreshToken() error = %v", err)
+	}
+
+	got, err := tr.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		t.Fatalf("TokenConfig.ValidateRefreshToken() error = %v", err)
+	}
+
+	if got.UserID != 1 || len(got.TokenID) == 0 || got.ExpiredAt.Before(time.Now().Add(23*time.Hour)) {
+		t.Errorf("TokenConfig.ValidateRefreshToken() got = %v", got)
+	}
+
+	accessToken, err := tr.GenerateToken(TokenBody{UserID: 1})
+	if err != nil {
+		t.Fatalf("TokenConfig.GenerateToken() error = %v", err)
+	}
+
+	if _, err := tr.ValidateRefreshToken(accessToken); err == nil {
+		t.Errorf("TokenConfig.ValidateRefreshToken() expect error for access token")
+	}
+
+	other, _ := tr.GenerateRefreshToken(TokenBody{UserID: 1})
+	if otherBody, _ := tr.ValidateRefreshToken(other); otherBody.TokenID == got.TokenID {
+		t.Errorf("TokenConfig.GenerateRefreshToken() expect unique token id")
+	}
+}
+
 func TestNewTokenMethod(t *testing.T) {
 	type args struct {
-		secret    string
-		expinHour int64
+		secret           string
+		expinHour        int64
+		refreshExpInHour int64
 	}
 	tests := []struct {
 		name string
@@ -131,20 +215,185 @@ func TestNewTokenMethod(t *testing.T) {
 		{
 			name: "success flow",
 			args: args{
-				secret:    "some_secret",
-				expinHour: 1,
+				secret:           "some_secret",
+				expinHour:        1,
+				refreshExpInHour: 24,
 			},
 			want: TokenConfig{
-				Secret:        "some_secret",
-				ExpTimeInHour: 1,
+				Secret:               "some_secret",
+				ExpTimeInHour:        1,
+				RefreshExpTimeInHour: 24,
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewTokenMethod(tt.args.secret, tt.args.expinHour); !reflect.DeepEqual(got, tt.want) {
+			if got := NewTokenMethod(tt.args.secret, tt.args.expinHour, tt.args.refreshExpInHour); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewTokenMethod() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestTokenConfig_SigningKeys(t *testing.T) {
+	rsaKey, _ := generateRSAPEM(t)
+	edKey, _ := generateEd25519PEM(t)
+	keys := []SigningKey{
+		{KeyID: "key-1", Algorithm: AlgorithmRS256, PrivateKey: rsaKey},
+		{KeyID: "key-2", Algorithm: AlgorithmEdDSA, PrivateKey: edKey},
+	}
+
+	for _, key := range keys {
+		t.Run("sign and validate with "+key.Algorithm, func(t *testing.T) {
+			tr := NewTokenMethod("my_secret_key", 1, 1, WithSigningKeys(key.KeyID, keys))
+			tkn, err := tr.GenerateToken(TokenBody{UserID: 1})
+			if err != nil {
+				t.Fatalf("TokenConfig.GenerateToken() error = %v", err)
+			}
+
+			parsed, _, err := new(jwt.Parser).ParseUnverified(tkn, jwt.MapClaims{})
+			if err != nil {
+				t.Fatalf("Error parse token err = %v", err)
+			}
+			if parsed.Header["kid"] != key.KeyID || parsed.Header["alg"] != key.Algorithm {
+				t.Errorf("TokenConfig.GenerateToken() header = %v", parsed.Header)
+			}
+
+			got, err := tr.ValidateToken(tkn)
+			if err != nil || got.UserID != 1 {
+				t.Errorf("TokenConfig.ValidateToken() got = %v, error = %v", got, err)
+			}
+		})
+	}
+
+	t.Run("token signed by previous key is valid after rotation", func(t *testing.T) {
+		old := NewTokenMethod("", 1, 1, WithSigningKeys("key-1", keys[:1]))
+		tkn, _ := old.GenerateToken(TokenBody{UserID: 1})
+
+		rotated := NewTokenMethod("", 1, 1, WithSigningKeys("key-2", keys))
+		if _, err := rotated.ValidateToken(tkn); err != nil {
+			t.Errorf("TokenConfig.ValidateToken() error = %v", err)
+		}
+
+		removed := NewTokenMethod("", 1, 1, WithSigningKeys("key-2", keys[1:]))
+		if _, err := removed.ValidateToken(tkn); err == nil {
+			t.Errorf("TokenConfig.ValidateToken() expect error after the key is removed")
+		}
+	})
+
+	t.Run("reject shared secret token when signing key is configured", func(t *testing.T) {
+		tkn, _ := NewTokenMethod("my_secret_key", 1, 1).GenerateToken(TokenBody{UserID: 1})
+		tr := NewTokenMethod("my_secret_key", 1, 1, WithSigningKeys("key-1", keys))
+		if _, err := tr.ValidateToken(tkn); err == nil {
+			t.Errorf("TokenConfig.ValidateToken() expect error for shared secret token")
+		}
+	})
+
+	t.Run("reject algorithm that does not follow the key", func(t *testing.T) {
+		claims := jwt.MapClaims{
+			"userid": 1,
+			"jti":    "jti",
+			"typ":    TypeAccess,
+			"exp":    time.Now().Add(time.Hour).Unix(),
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		token.Header["kid"] = "key-1"
+		tkn, _ := token.SignedString([]byte("my_secret_key"))
+
+		tr := NewTokenMethod("my_secret_key", 1, 1, WithSigningKeys("key-1", keys))
+		if _, err := tr.ValidateToken(tkn); err == nil {
+			t.Errorf("TokenConfig.ValidateToken() expect error for mismatch algorithm")
+		}
+	})
+
+	t.Run("error on missing active key", func(t *testing.T) {
+		tr := NewTokenMethod("", 1, 1, WithSigningKeys("key-3", keys))
+		if _, err := tr.GenerateToken(TokenBody{UserID: 1}); err == nil {
+			t.Errorf("TokenConfig.GenerateToken() expect error for missing active key")
+		}
+	})
+}
+
+func TestTokenConfig_GetJWKS(t *testing.T) {
+	edKey, _ := generateEd25519PEM(t)
+	tests := []struct {
+		name     string
+		tr       TokenConfig
+		wantKeys int
+	}{
+		{
+			name:     "without signing key",
+			tr:       TokenConfig{Secret: "my_secret_key"},
+			wantKeys: 0,
+		},
+		{
+			name: "with signing key",
+			tr: TokenConfig{
+				ActiveKeyID: "key-1",
+				SigningKeys: []SigningKey{{KeyID: "key-1", Algorithm: AlgorithmEdDSA, PrivateKey: edKey}},
+			},
+			wantKeys: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.tr.GetJWKS()
+			if got.Keys == nil || len(got.Keys) != tt.wantKeys {
+				t.Errorf("TokenConfig.GetJWKS() = %v, want %d keys", got, tt.wantKeys)
+			}
+		})
+	}
+}
+
+func TestTokenConfig_Claims(t *testing.T) {
+	tr := NewTokenMethod("my_secret_key", 1, 1, WithIssuerOptions("dating-apps", "dating-apps-api"))
+	before := time.Now().Add(-time.Second)
+
+	tkn, err := tr.GenerateToken(TokenBody{
+		UserID:          1,
+		Roles:           []string{"user", "admin"},
+		Plan:            "PREMIUM",
+		IsEmailVerified: true,
+		SessionID:       "sid",
+	})
+	if err != nil {
+		t.Fatalf("TokenConfig.GenerateToken() error = %v", err)
+	}
+
+	got, err := tr.ValidateToken(tkn)
+	if err != nil {
+		t.Fatalf("TokenConfig.ValidateToken() error = %v", err)
+	}
+
+	if got.UserID != 1 || !reflect.DeepEqual(got.Roles, []string{"user", "admin"}) || got.Plan != "PREMIUM" || !got.IsEmailVerified || got.SessionID != "sid" || got.IssuedAt.Before(before) {
+		t.Errorf("TokenConfig.ValidateToken() got = %+v", got)
+	}
+
+	tests := []struct {
+		name string
+		tr   TokenMethod
+	}{
+		{
+			name: "error other issuer",
+			tr:   NewTokenMethod("my_secret_key", 1, 1, WithIssuerOptions("other", "dating-apps-api")),
+		},
+		{
+			name: "error other audience",
+			tr:   NewTokenMethod("my_secret_key", 1, 1, WithIssuerOptions("dating-apps", "other")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.tr.ValidateToken(tkn); err == nil {
+				t.Errorf("TokenConfig.ValidateToken() expect error")
+			}
+		})
+	}
+
+	t.Run("error missing issuer", func(t *testing.T) {
+		other, _ := NewTokenMethod("my_secret_key", 1, 1).GenerateToken(TokenBody{UserID: 1})
+		if _, err := tr.ValidateToken(other); err == nil {
+			t.Errorf("TokenConfig.ValidateToken() expect error")
+		}
+	})
+}
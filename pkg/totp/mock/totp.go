@@ -0,0 +1,77 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/totp/totp.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTOTPMethod is a mock of TOTPMethod interface.
+type MockTOTPMethod struct {
+	ctrl     *gomock.Controller
+	recorder *MockTOTPMethodMockRecorder
+}
+
+// MockTOTPMethodMockRecorder is the mock recorder for MockTOTPMethod.
+type MockTOTPMethodMockRecorder struct {
+	mock *MockTOTPMethod
+}
+
+// NewMockTOTPMethod creates a new mock instance.
+func NewMockTOTPMethod(ctrl *gomock.Controller) *MockTOTPMethod {
+	mock := &MockTOTPMethod{ctrl: ctrl}
+	mock.recorder = &MockTOTPMethodMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTOTPMethod) EXPECT() *MockTOTPMethodMockRecorder {
+	return m.recorder
+}
+
+// GenerateSecret mocks base method.
+func (m *MockTOTPMethod) GenerateSecret() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateSecret")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateSecret indicates an expected call of GenerateSecret.
+func (mr *MockTOTPMethodMockRecorder) GenerateSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSecret", reflect.TypeOf((*MockTOTPMethod)(nil).GenerateSecret))
+}
+
+// GenerateURI mocks base method.
+func (m *MockTOTPMethod) GenerateURI(account, secret string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateURI", account, secret)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GenerateURI indicates an expected call of GenerateURI.
+func (mr *MockTOTPMethodMockRecorder) GenerateURI(account, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateURI", reflect.TypeOf((*MockTOTPMethod)(nil).GenerateURI), account, secret)
+}
+
+// ValidateCode mocks base method.
+func (m *MockTOTPMethod) ValidateCode(secret, code string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateCode", secret, code)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ValidateCode indicates an expected call of ValidateCode.
+func (mr *MockTOTPMethodMockRecorder) ValidateCode(secret, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateCode", reflect.TypeOf((*MockTOTPMethod)(nil).ValidateCode), secret, code)
+}
@@ -0,0 +1,163 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTPConfig is list dependencies of TOTP Package, the code is generated based on RFC 6238 with HMAC-SHA1
+type TOTPConfig struct {
+	issuer string
+	period time.Duration
+	digits int
+	// skew is number of period before and after the current time that is still accepted
+	skew int
+	now  func() time.Time
+}
+
+// TOTPMethod is list method for TOTP Package
+type TOTPMethod interface {
+	GenerateSecret() (string, error)
+	GenerateURI(account, secret string) string
+	ValidateCode(secret, code string) bool
+}
+
+// Option set options for TOTP config
+type Option func(*TOTPConfig)
+
+const (
+	defaultPeriod = 30 * time.Second
+	defaultDigits = 6
+	defaultSkew   = 1
+	// secretSize is length of the generated secret in byte, 160 bit is recommended for HMAC-SHA1
+	secretSize = 20
+)
+
+// encoding is base32 without padding that is expected by the authenticator app
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewTOTPMethod func to create TOTPMethod interface
+func NewTOTPMethod(issuer string, options ...Option) TOTPMethod {
+	t := &TOTPConfig{
+		issuer: issuer,
+		period: defaultPeriod,
+		digits: defaultDigits,
+		skew:   defaultSkew,
+		now:    time.Now,
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(t)
+	}
+
+	return t
+}
+
+// WithSkewOptions is func to set number of accepted period before and after the current time
+func WithSkewOptions(skew int) Option {
+	return Option(
+		func(t *TOTPConfig) {
+			if skew < 0 {
+				skew = defaultSkew
+			}
+			t.skew = skew
+		})
+}
+
+// WithClockOptions is func to set the clock used to validate the code
+func WithClockOptions(now func() time.Time) Option {
+	return Option(
+		func(t *TOTPConfig) {
+			if now != nil {
+				t.now = now
+			}
+		})
+}
+
+// GenerateSecret func to generate new random secret in base32 format
+func (t *TOTPConfig) GenerateSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+// GenerateURI func to generate otpauth uri of the secret that can be scanned by the authenticator app
+func (t *TOTPConfig) GenerateURI(account, secret string) string {
+	label := url.PathEscape(account)
+	if len(t.issuer) > 0 {
+		label = url.PathEscape(t.issuer) + ":" + label
+	}
+
+	query := url.Values{}
+	query.Set("secret", secret)
+	if len(t.issuer) > 0 {
+		query.Set("issuer", t.issuer)
+	}
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(t.digits))
+	query.Set("period", fmt.Sprint(int(t.period.Seconds())))
+
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// ValidateCode func to check the code is valid for the secret at the current time
+func (t *TOTPConfig) ValidateCode(secret, code string) bool {
+	if len(code) != t.digits {
+		return false
+	}
+
+	counter := t.now().Unix() / int64(t.period.Seconds())
+	for i := -t.skew; i <= t.skew; i++ {
+		expected, err := t.generateCode(secret, counter+int64(i))
+		if err != nil {
+			return false
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateCode func to generate the code of the secret at the given time
+func (t *TOTPConfig) GenerateCode(secret string, at time.Time) (string, error) {
+	return t.generateCode(secret, at.Unix()/int64(t.period.Seconds()))
+}
+
+// generateCode func to generate HOTP code of the counter based on RFC 4226
+func (t *TOTPConfig) generateCode(secret string, counter int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", err
+	}
+
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < t.digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", t.digits, value%mod), nil
+}
@@ -0,0 +1,120 @@
+package totp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+// rfcSecret is base32 of the "12345678901234567890" secret used by RFC 6238 test vector
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestNewTOTPMethod(t *testing.T) {
+	got, ok := NewTOTPMethod("dating-apps", WithSkewOptions(2)).(*TOTPConfig)
+	if !ok {
+		t.Fatalf("NewTOTPMethod() type = %T, want *TOTPConfig", got)
+	}
+	if got.issuer != "dating-apps" || got.period != defaultPeriod || got.digits != defaultDigits || got.skew != 2 || got.now == nil {
+		t.Errorf("NewTOTPMethod() = %+v", got)
+	}
+
+	got = NewTOTPMethod("", WithSkewOptions(-1), WithClockOptions(nil)).(*TOTPConfig)
+	if got.skew != defaultSkew || got.now == nil {
+		t.Errorf("NewTOTPMethod() default option = %+v", got)
+	}
+}
+
+func TestTOTPConfig_GenerateCode(t *testing.T) {
+	tests := []struct {
+		name string
+		at   int64
+		want string
+	}{
+		{name: "rfc vector 59", at: 59, want: "94287082"},
+		{name: "rfc vector 1111111109", at: 1111111109, want: "07081804"},
+		{name: "rfc vector 1111111111", at: 1111111111, want: "14050471"},
+		{name: "rfc vector 1234567890", at: 1234567890, want: "89005924"},
+		{name: "rfc vector 2000000000", at: 2000000000, want: "69279037"},
+		{name: "rfc vector 20000000000", at: 20000000000, want: "65353130"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &TOTPConfig{period: defaultPeriod, digits: 8}
+			got, err := config.GenerateCode(rfcSecret, time.Unix(tt.at, 0))
+			if err != nil {
+				t.Fatalf("TOTPConfig.GenerateCode() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("TOTPConfig.GenerateCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	config := &TOTPConfig{period: defaultPeriod, digits: defaultDigits}
+	if _, err := config.GenerateCode("not base32!", time.Unix(59, 0)); err == nil {
+		t.Errorf("TOTPConfig.GenerateCode() invalid secret error = nil, want error")
+	}
+}
+
+func TestTOTPConfig_ValidateCode(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	config := NewTOTPMethod("dating-apps", WithClockOptions(func() time.Time { return now })).(*TOTPConfig)
+	current, _ := config.GenerateCode(rfcSecret, now)
+	previous, _ := config.GenerateCode(rfcSecret, now.Add(-30*time.Second))
+	tooOld, _ := config.GenerateCode(rfcSecret, now.Add(-90*time.Second))
+	tests := []struct {
+		name   string
+		secret string
+		code   string
+		want   bool
+	}{
+		{name: "current code", secret: rfcSecret, code: current, want: true},
+		{name: "previous code inside skew", secret: rfcSecret, code: previous, want: true},
+		{name: "code outside skew", secret: rfcSecret, code: tooOld, want: false},
+		{name: "invalid length", secret: rfcSecret, code: "123", want: false},
+		{name: "invalid secret", secret: "not base32!", code: current, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := config.ValidateCode(tt.secret, tt.code); got != tt.want {
+				t.Errorf("TOTPConfig.ValidateCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTOTPConfig_GenerateSecret(t *testing.T) {
+	config := NewTOTPMethod("dating-apps")
+	secret, err := config.GenerateSecret()
+	if err != nil {
+		t.Fatalf("TOTPConfig.GenerateSecret() error = %v", err)
+	}
+
+	key, err := encoding.DecodeString(secret)
+	if err != nil || len(key) != secretSize {
+		t.Errorf("TOTPConfig.GenerateSecret() = %v, decoded size %d error %v", secret, len(key), err)
+	}
+
+	other, _ := config.GenerateSecret()
+	if other == secret {
+		t.Errorf("TOTPConfig.GenerateSecret() generate the same secret twice")
+	}
+}
+
+func TestTOTPConfig_GenerateURI(t *testing.T) {
+	config := NewTOTPMethod("Dating Apps")
+	got := config.GenerateURI("user@mail.com", rfcSecret)
+
+	u, err := url.Parse(got)
+	if err != nil {
+		t.Fatalf("TOTPConfig.GenerateURI() = %v, error = %v", got, err)
+	}
+	if u.Scheme != "otpauth" || u.Host != "totp" || u.Path != "/Dating Apps:user@mail.com" {
+		t.Errorf("TOTPConfig.GenerateURI() = %v", got)
+	}
+
+	query := u.Query()
+	if query.Get("secret") != rfcSecret || query.Get("issuer") != "Dating Apps" || query.Get("digits") != "6" || query.Get("period") != "30" {
+		t.Errorf("TOTPConfig.GenerateURI() query = %v", query)
+	}
+}
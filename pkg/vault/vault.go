@@ -7,6 +7,7 @@ import (
 )
 
 const secret_path = "secret/data/config"
+const token_keys_path = "secret/data/token_keys"
 
 // Client is a list dependencies for vault package
 type Client struct {
@@ -16,6 +17,7 @@ type Client struct {
 // VaultMethod is list method for vault package
 type VaultMethod interface {
 	GetConfig() (map[string]string, error)
+	GetTokenKeys() (map[string]string, error)
 }
 
 // NewVaultClient func to init vault and return the VaultMethod interface
@@ -41,7 +43,7 @@ func NewVaultClient(token, address string) (VaultMethod, error) {
 // GetConfig is func to get config on secret
 func (c *Client) GetConfig() (map[string]string, error) {
 	// Get Secret in Vault
-	res, err := readSecretFromPath(c.vault)
+	res, err := readSecretFromPath(c.vault, secret_path)
 	if err != nil {
 		fmt.Println("Error reading secret: ", err)
 		return nil, err
@@ -55,6 +57,34 @@ func (c *Client) GetConfig() (map[string]string, error) {
 	return secretMap, nil
 }
 
-func readSecretFromPath(vault *api.Client) (*api.Secret, error) {
-	return vault.Logical().Read(secret_path)
+// GetTokenKeys is func to get PEM private key of token signing key mapped by key id, it is empty when the keys are not stored
+func (c *Client) GetTokenKeys() (map[string]string, error) {
+	res, err := readSecretFromPath(c.vault, token_keys_path)
+	if err != nil {
+		fmt.Println("Error reading token keys: ", err)
+		return nil, err
+	}
+
+	keyMap := make(map[string]string)
+	if res == nil {
+		return keyMap, nil
+	}
+
+	data, ok := res.Data["data"].(map[string]interface{})
+	if !ok {
+		return keyMap, nil
+	}
+
+	for key, value := range data {
+		pem, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("Error: token key %v is invalid", key)
+		}
+		keyMap[key] = pem
+	}
+	return keyMap, nil
+}
+
+func readSecretFromPath(vault *api.Client, path string) (*api.Secret, error) {
+	return vault.Logical().Read(path)
 }
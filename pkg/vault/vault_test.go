@@ -65,7 +65,7 @@ func TestClient_GetConfig(t *testing.T) {
 			},
 			wantErr: false,
 			patch: func() {
-				monkey.Patch(readSecretFromPath, func(*api.Client) (*api.Secret, error) {
+				monkey.Patch(readSecretFromPath, func(*api.Client, string) (*api.Secret, error) {
 					// Mocked response data
 					data := map[string]interface{}{
 						"key1": "value1",
@@ -84,7 +84,7 @@ func TestClient_GetConfig(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 			patch: func() {
-				monkey.Patch(readSecretFromPath, func(*api.Client) (*api.Secret, error) {
+				monkey.Patch(readSecretFromPath, func(*api.Client, string) (*api.Secret, error) {
 					return nil, fmt.Errorf("some error")
 				})
 			},
@@ -109,3 +109,81 @@ func TestClient_GetConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_GetTokenKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       *Client
+		want    map[string]string
+		patch   func()
+		wantErr bool
+	}{
+		{
+			name: "Success flow",
+			c:    &Client{},
+			want: map[string]string{
+				"key-1": "pem1",
+				"key-2": "pem2",
+			},
+			patch: func() {
+				monkey.Patch(readSecretFromPath, func(_ *api.Client, path string) (*api.Secret, error) {
+					if path != token_keys_path {
+						return nil, fmt.Errorf("invalid path")
+					}
+					data := map[string]interface{}{
+						"key-1": "pem1",
+						"key-2": "pem2",
+					}
+					return &api.Secret{Data: map[string]interface{}{"data": data}}, nil
+				})
+			},
+		},
+		{
+			name: "Keys not stored flow",
+			c:    &Client{},
+			want: map[string]string{},
+			patch: func() {
+				monkey.Patch(readSecretFromPath, func(*api.Client, string) (*api.Secret, error) {
+					return nil, nil
+				})
+			},
+		},
+		{
+			name:    "Invalid key value flow",
+			c:       &Client{},
+			wantErr: true,
+			patch: func() {
+				monkey.Patch(readSecretFromPath, func(*api.Client, string) (*api.Secret, error) {
+					data := map[string]interface{}{
+						"key-1": 1,
+					}
+					return &api.Secret{Data: map[string]interface{}{"data": data}}, nil
+				})
+			},
+		},
+		{
+			name:    "Got error while read flow",
+			c:       &Client{},
+			wantErr: true,
+			patch: func() {
+				monkey.Patch(readSecretFromPath, func(*api.Client, string) (*api.Secret, error) {
+					return nil, fmt.Errorf("some error")
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.patch()
+			defer monkey.UnpatchAll()
+			got, err := tt.c.GetTokenKeys()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.GetTokenKeys() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Client.GetTokenKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}